@@ -0,0 +1,384 @@
+package goshopify
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Statuses of an app subscription. A subscription is PENDING until the
+// merchant approved it at its confirmation url.
+const (
+	AppSubscriptionStatusPending   = "PENDING"
+	AppSubscriptionStatusActive    = "ACTIVE"
+	AppSubscriptionStatusDeclined  = "DECLINED"
+	AppSubscriptionStatusExpired   = "EXPIRED"
+	AppSubscriptionStatusFrozen    = "FROZEN"
+	AppSubscriptionStatusCancelled = "CANCELLED"
+)
+
+// Intervals of recurring app pricing.
+const (
+	AppPricingIntervalEvery30Days = "EVERY_30_DAYS"
+	AppPricingIntervalAnnual      = "ANNUAL"
+)
+
+// Replacement behaviors deciding when a new subscription replaces the active
+// one. STANDARD applies upgrades right away and downgrades on the next
+// billing cycle.
+const (
+	AppSubscriptionReplacementApplyImmediately        = "APPLY_IMMEDIATELY"
+	AppSubscriptionReplacementApplyOnNextBillingCycle = "APPLY_ON_NEXT_BILLING_CYCLE"
+	AppSubscriptionReplacementStandard                = "STANDARD"
+)
+
+// AppSubscriptionService is an interface for billing merchants for the app
+// with subscriptions through the GraphQL Admin API, which unlike the REST
+// recurring application charges can combine recurring and usage pricing in
+// a single plan.
+// See: https://shopify.dev/docs/apps/launch/billing/subscription-billing
+type AppSubscriptionService interface {
+	Create(AppSubscriptionInput) (*AppSubscription, error)
+	Cancel(string, bool) (*AppSubscription, error)
+	ListActive() ([]AppSubscription, error)
+	CreateUsageRecord(AppUsageRecordInput) (*AppUsageRecord, error)
+}
+
+// AppSubscriptionServiceOp handles communication with the app subscription
+// related GraphQL queries and mutations.
+type AppSubscriptionServiceOp struct {
+	client *Client
+}
+
+// AppSubscription represents the subscription of a shop to a plan of the
+// app. ConfirmationURL is only set by Create, the merchant has to be
+// redirected there to approve the subscription.
+type AppSubscription struct {
+	ID               string
+	Name             string
+	Status           string
+	Test             bool
+	TrialDays        int
+	CurrentPeriodEnd *time.Time
+	CreatedAt        *time.Time
+	LineItems        []AppSubscriptionLineItem
+	ConfirmationURL  string
+}
+
+// AppSubscriptionLineItem is a part of the plan of a subscription, priced
+// either on a recurring basis or by usage. The ID of a usage line item is
+// needed to create usage records.
+type AppSubscriptionLineItem struct {
+	ID        string
+	Recurring *AppRecurringPricing
+	Usage     *AppUsagePricing
+}
+
+// AppRecurringPricing charges Price every Interval, e.g.
+// AppPricingIntervalEvery30Days.
+type AppRecurringPricing struct {
+	Price        decimal.Decimal
+	CurrencyCode string
+	Interval     string
+}
+
+// AppUsagePricing charges the usage records created during a billing cycle
+// up to CappedAmount. Terms are shown to the merchant, e.g. "$1 per 100
+// emails". BalanceUsed is only set on subscriptions read from Shopify.
+type AppUsagePricing struct {
+	CappedAmount decimal.Decimal
+	CurrencyCode string
+	Terms        string
+	BalanceUsed  *decimal.Decimal
+}
+
+// AppSubscriptionInput describes a subscription to create. ReturnURL is where
+// the merchant is sent after approving it. Test subscriptions aren't charged,
+// use them on development stores.
+type AppSubscriptionInput struct {
+	Name                string
+	ReturnURL           string
+	LineItems           []AppSubscriptionLineItem
+	TrialDays           int
+	Test                bool
+	ReplacementBehavior string
+}
+
+// AppUsageRecordInput charges Price for usage to the usage line item of a
+// subscription. The IdempotencyKey makes retrying the charge safe.
+type AppUsageRecordInput struct {
+	LineItemID     string
+	Price          decimal.Decimal
+	CurrencyCode   string
+	Description    string
+	IdempotencyKey string
+}
+
+// AppUsageRecord represents a usage charge of a subscription.
+type AppUsageRecord struct {
+	ID             string
+	Description    string
+	IdempotencyKey string
+	Price          *decimal.Decimal
+	CurrencyCode   string
+	CreatedAt      *time.Time
+}
+
+func (l AppSubscriptionLineItem) input() map[string]interface{} {
+	plan := map[string]interface{}{}
+	if l.Recurring != nil {
+		details := map[string]interface{}{
+			"price": map[string]interface{}{"amount": l.Recurring.Price.String(), "currencyCode": l.Recurring.CurrencyCode},
+		}
+		if l.Recurring.Interval != "" {
+			details["interval"] = l.Recurring.Interval
+		}
+		plan["appRecurringPricingDetails"] = details
+	}
+	if l.Usage != nil {
+		plan["appUsagePricingDetails"] = map[string]interface{}{
+			"cappedAmount": map[string]interface{}{"amount": l.Usage.CappedAmount.String(), "currencyCode": l.Usage.CurrencyCode},
+			"terms":        l.Usage.Terms,
+		}
+	}
+	return map[string]interface{}{"plan": plan}
+}
+
+// appSubscriptionNode is the GraphQL representation of a subscription, the
+// pricing of its line items is a union.
+type appSubscriptionNode struct {
+	ID               string     `json:"id"`
+	Name             string     `json:"name"`
+	Status           string     `json:"status"`
+	Test             bool       `json:"test"`
+	TrialDays        int        `json:"trialDays"`
+	CurrentPeriodEnd *time.Time `json:"currentPeriodEnd"`
+	CreatedAt        *time.Time `json:"createdAt"`
+	LineItems        []struct {
+		ID   string `json:"id"`
+		Plan struct {
+			PricingDetails struct {
+				Typename     string   `json:"__typename"`
+				Interval     string   `json:"interval"`
+				Price        *moneyV2 `json:"price"`
+				Terms        string   `json:"terms"`
+				CappedAmount *moneyV2 `json:"cappedAmount"`
+				BalanceUsed  *moneyV2 `json:"balanceUsed"`
+			} `json:"pricingDetails"`
+		} `json:"plan"`
+	} `json:"lineItems"`
+}
+
+func (n *appSubscriptionNode) subscription() *AppSubscription {
+	if n == nil {
+		return nil
+	}
+	subscription := &AppSubscription{
+		ID:               n.ID,
+		Name:             n.Name,
+		Status:           n.Status,
+		Test:             n.Test,
+		TrialDays:        n.TrialDays,
+		CurrentPeriodEnd: n.CurrentPeriodEnd,
+		CreatedAt:        n.CreatedAt,
+	}
+	for _, item := range n.LineItems {
+		lineItem := AppSubscriptionLineItem{ID: item.ID}
+		details := item.Plan.PricingDetails
+		switch details.Typename {
+		case "AppRecurringPricing":
+			lineItem.Recurring = &AppRecurringPricing{Interval: details.Interval}
+			if details.Price != nil && details.Price.Amount != nil {
+				lineItem.Recurring.Price = *details.Price.Amount
+				lineItem.Recurring.CurrencyCode = details.Price.CurrencyCode
+			}
+		case "AppUsagePricing":
+			lineItem.Usage = &AppUsagePricing{Terms: details.Terms}
+			if details.CappedAmount != nil && details.CappedAmount.Amount != nil {
+				lineItem.Usage.CappedAmount = *details.CappedAmount.Amount
+				lineItem.Usage.CurrencyCode = details.CappedAmount.CurrencyCode
+			}
+			if details.BalanceUsed != nil {
+				lineItem.Usage.BalanceUsed = details.BalanceUsed.Amount
+			}
+		}
+		subscription.LineItems = append(subscription.LineItems, lineItem)
+	}
+	return subscription
+}
+
+const appSubscriptionFields = `id name status test trialDays currentPeriodEnd createdAt
+lineItems {
+  id
+  plan {
+    pricingDetails {
+      __typename
+      ... on AppRecurringPricing { interval price { amount currencyCode } }
+      ... on AppUsagePricing { terms cappedAmount { amount currencyCode } balanceUsed { amount currencyCode } }
+    }
+  }
+}`
+
+const appSubscriptionCreateMutation = `mutation($name: String!, $returnUrl: URL!, $lineItems: [AppSubscriptionLineItemInput!]!, $trialDays: Int, $test: Boolean, $replacementBehavior: AppSubscriptionReplacementBehavior) {
+  appSubscriptionCreate(name: $name, returnUrl: $returnUrl, lineItems: $lineItems, trialDays: $trialDays, test: $test, replacementBehavior: $replacementBehavior) {
+    appSubscription { ` + appSubscriptionFields + ` }
+    confirmationUrl
+    userErrors { field message }
+  }
+}`
+
+const appSubscriptionCancelMutation = `mutation($id: ID!, $prorate: Boolean) {
+  appSubscriptionCancel(id: $id, prorate: $prorate) {
+    appSubscription { ` + appSubscriptionFields + ` }
+    userErrors { field message }
+  }
+}`
+
+const appActiveSubscriptionsQuery = `query {
+  currentAppInstallation {
+    activeSubscriptions { ` + appSubscriptionFields + ` }
+  }
+}`
+
+const appUsageRecordCreateMutation = `mutation($subscriptionLineItemId: ID!, $price: MoneyInput!, $description: String!, $idempotencyKey: String) {
+  appUsageRecordCreate(subscriptionLineItemId: $subscriptionLineItemId, price: $price, description: $description, idempotencyKey: $idempotencyKey) {
+    appUsageRecord { id description idempotencyKey createdAt price { amount currencyCode } }
+    userErrors { field message }
+  }
+}`
+
+// Create a subscription, which is PENDING until the merchant approved it at
+// the ConfirmationURL of the returned subscription.
+func (s *AppSubscriptionServiceOp) Create(input AppSubscriptionInput) (*AppSubscription, error) {
+	lineItems := make([]map[string]interface{}, 0, len(input.LineItems))
+	for _, lineItem := range input.LineItems {
+		lineItems = append(lineItems, lineItem.input())
+	}
+	vars := map[string]interface{}{
+		"name":      input.Name,
+		"returnUrl": input.ReturnURL,
+		"lineItems": lineItems,
+		"test":      input.Test,
+	}
+	if input.TrialDays > 0 {
+		vars["trialDays"] = input.TrialDays
+	}
+	if input.ReplacementBehavior != "" {
+		vars["replacementBehavior"] = input.ReplacementBehavior
+	}
+	resp := struct {
+		AppSubscriptionCreate struct {
+			AppSubscription *appSubscriptionNode `json:"appSubscription"`
+			ConfirmationURL string               `json:"confirmationUrl"`
+			UserErrors      []UserError          `json:"userErrors"`
+		} `json:"appSubscriptionCreate"`
+	}{}
+
+	err := s.client.GraphQL.Query(appSubscriptionCreateMutation, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if err := userErrorsToError(resp.AppSubscriptionCreate.UserErrors); err != nil {
+		return nil, err
+	}
+
+	subscription := resp.AppSubscriptionCreate.AppSubscription.subscription()
+	if subscription != nil {
+		subscription.ConfirmationURL = resp.AppSubscriptionCreate.ConfirmationURL
+	}
+	return subscription, nil
+}
+
+// Cancel a subscription, refunding the unused part of the billing cycle when
+// prorate is set.
+func (s *AppSubscriptionServiceOp) Cancel(id string, prorate bool) (*AppSubscription, error) {
+	vars := map[string]interface{}{
+		"id":      id,
+		"prorate": prorate,
+	}
+	resp := struct {
+		AppSubscriptionCancel struct {
+			AppSubscription *appSubscriptionNode `json:"appSubscription"`
+			UserErrors      []UserError          `json:"userErrors"`
+		} `json:"appSubscriptionCancel"`
+	}{}
+
+	err := s.client.GraphQL.Query(appSubscriptionCancelMutation, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if err := userErrorsToError(resp.AppSubscriptionCancel.UserErrors); err != nil {
+		return nil, err
+	}
+	return resp.AppSubscriptionCancel.AppSubscription.subscription(), nil
+}
+
+// ListActive lists the active subscriptions of the shop to the app, at most
+// one outside of test subscriptions.
+func (s *AppSubscriptionServiceOp) ListActive() ([]AppSubscription, error) {
+	resp := struct {
+		CurrentAppInstallation struct {
+			ActiveSubscriptions []appSubscriptionNode `json:"activeSubscriptions"`
+		} `json:"currentAppInstallation"`
+	}{}
+
+	err := s.client.GraphQL.Query(appActiveSubscriptionsQuery, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	var subscriptions []AppSubscription
+	for i := range resp.CurrentAppInstallation.ActiveSubscriptions {
+		subscriptions = append(subscriptions, *resp.CurrentAppInstallation.ActiveSubscriptions[i].subscription())
+	}
+	return subscriptions, nil
+}
+
+// CreateUsageRecord charges usage to the usage line item of a subscription.
+// Charges beyond the capped amount of the line item fail.
+func (s *AppSubscriptionServiceOp) CreateUsageRecord(input AppUsageRecordInput) (*AppUsageRecord, error) {
+	vars := map[string]interface{}{
+		"subscriptionLineItemId": input.LineItemID,
+		"price":                  map[string]interface{}{"amount": input.Price.String(), "currencyCode": input.CurrencyCode},
+		"description":            input.Description,
+	}
+	if input.IdempotencyKey != "" {
+		vars["idempotencyKey"] = input.IdempotencyKey
+	}
+	resp := struct {
+		AppUsageRecordCreate struct {
+			AppUsageRecord *struct {
+				ID             string     `json:"id"`
+				Description    string     `json:"description"`
+				IdempotencyKey string     `json:"idempotencyKey"`
+				CreatedAt      *time.Time `json:"createdAt"`
+				Price          *moneyV2   `json:"price"`
+			} `json:"appUsageRecord"`
+			UserErrors []UserError `json:"userErrors"`
+		} `json:"appUsageRecordCreate"`
+	}{}
+
+	err := s.client.GraphQL.Query(appUsageRecordCreateMutation, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if err := userErrorsToError(resp.AppUsageRecordCreate.UserErrors); err != nil {
+		return nil, err
+	}
+
+	node := resp.AppUsageRecordCreate.AppUsageRecord
+	if node == nil {
+		return nil, nil
+	}
+	record := &AppUsageRecord{
+		ID:             node.ID,
+		Description:    node.Description,
+		IdempotencyKey: node.IdempotencyKey,
+		CreatedAt:      node.CreatedAt,
+	}
+	if node.Price != nil {
+		record.Price = node.Price.Amount
+		record.CurrencyCode = node.Price.CurrencyCode
+	}
+	return record, nil
+}
@@ -0,0 +1,164 @@
+package goshopify
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/shopspring/decimal"
+)
+
+func TestAppSubscriptionCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests, string(loadFixture("app_subscription_create.json"))))
+
+	subscription, err := client.AppSubscription.Create(AppSubscriptionInput{
+		Name:      "Pro",
+		ReturnURL: "https://example.com/billing",
+		LineItems: []AppSubscriptionLineItem{
+			{Recurring: &AppRecurringPricing{Price: decimal.RequireFromString("29"), CurrencyCode: "USD", Interval: AppPricingIntervalEvery30Days}},
+			{Usage: &AppUsagePricing{CappedAmount: decimal.RequireFromString("100"), CurrencyCode: "USD", Terms: "$1 per 100 emails"}},
+		},
+		TrialDays:           7,
+		Test:                true,
+		ReplacementBehavior: AppSubscriptionReplacementApplyImmediately,
+	})
+	if err != nil {
+		t.Fatalf("AppSubscription.Create returned error: %v", err)
+	}
+
+	if subscription.Status != AppSubscriptionStatusPending || subscription.TrialDays != 7 ||
+		subscription.ConfirmationURL != "https://fooshop.myshopify.com/admin/charges/1/confirm_recurring_application_charge" {
+		t.Errorf("AppSubscription.Create returned %+v", subscription)
+	}
+	if len(subscription.LineItems) != 2 {
+		t.Fatalf("AppSubscription.Create returned line items %+v", subscription.LineItems)
+	}
+	recurring := subscription.LineItems[0].Recurring
+	if recurring == nil || !recurring.Price.Equal(decimal.RequireFromString("29")) || recurring.Interval != AppPricingIntervalEvery30Days {
+		t.Errorf("AppSubscription.Create returned recurring pricing %+v", recurring)
+	}
+	usage := subscription.LineItems[1].Usage
+	if usage == nil || !usage.CappedAmount.Equal(decimal.RequireFromString("100")) || usage.BalanceUsed == nil ||
+		!usage.BalanceUsed.Equal(decimal.RequireFromString("12.5")) || subscription.LineItems[1].Recurring != nil {
+		t.Errorf("AppSubscription.Create returned usage pricing %+v", usage)
+	}
+
+	expectedVars := map[string]interface{}{
+		"name":      "Pro",
+		"returnUrl": "https://example.com/billing",
+		"lineItems": []interface{}{
+			map[string]interface{}{"plan": map[string]interface{}{"appRecurringPricingDetails": map[string]interface{}{
+				"price":    map[string]interface{}{"amount": "29", "currencyCode": "USD"},
+				"interval": "EVERY_30_DAYS",
+			}}},
+			map[string]interface{}{"plan": map[string]interface{}{"appUsagePricingDetails": map[string]interface{}{
+				"cappedAmount": map[string]interface{}{"amount": "100", "currencyCode": "USD"},
+				"terms":        "$1 per 100 emails",
+			}}},
+		},
+		"trialDays":           float64(7),
+		"test":                true,
+		"replacementBehavior": "APPLY_IMMEDIATELY",
+	}
+	if !reflect.DeepEqual(requests[0].Variables, expectedVars) {
+		t.Errorf("AppSubscription.Create sent %+v, expected %+v", requests[0].Variables, expectedVars)
+	}
+}
+
+func TestAppSubscriptionCreateUserErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"data":{"appSubscriptionCreate":{"appSubscription":null,"confirmationUrl":null,"userErrors":[{"field":["returnUrl"],"message":"Return url is invalid"}]}}}`))
+
+	_, err := client.AppSubscription.Create(AppSubscriptionInput{Name: "Pro", ReturnURL: "nope"})
+	if err == nil {
+		t.Error("AppSubscription.Create expected the user error")
+	}
+}
+
+func TestAppSubscriptionCancel(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests, string(loadFixture("app_subscription_cancel.json"))))
+
+	subscription, err := client.AppSubscription.Cancel("gid://shopify/AppSubscription/1", true)
+	if err != nil {
+		t.Fatalf("AppSubscription.Cancel returned error: %v", err)
+	}
+	if subscription.Status != AppSubscriptionStatusCancelled {
+		t.Errorf("AppSubscription.Cancel returned %+v", subscription)
+	}
+
+	expectedVars := map[string]interface{}{"id": "gid://shopify/AppSubscription/1", "prorate": true}
+	if !reflect.DeepEqual(requests[0].Variables, expectedVars) {
+		t.Errorf("AppSubscription.Cancel sent %+v, expected %+v", requests[0].Variables, expectedVars)
+	}
+}
+
+func TestAppSubscriptionListActive(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("app_subscriptions_active.json")))
+
+	subscriptions, err := client.AppSubscription.ListActive()
+	if err != nil {
+		t.Fatalf("AppSubscription.ListActive returned error: %v", err)
+	}
+	if len(subscriptions) != 1 || subscriptions[0].Status != AppSubscriptionStatusActive || len(subscriptions[0].LineItems) != 2 {
+		t.Errorf("AppSubscription.ListActive returned %+v", subscriptions)
+	}
+}
+
+func TestAppSubscriptionCreateUsageRecord(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests,
+			`{"data":{"appUsageRecordCreate":{"appUsageRecord":{"id":"gid://shopify/AppUsageRecord/1","description":"100 emails","idempotencyKey":"shop-1-2023-06-01","createdAt":"2023-06-01T12:00:00Z","price":{"amount":"1.0","currencyCode":"USD"}},"userErrors":[]}}}`,
+			`{"data":{"appUsageRecordCreate":{"appUsageRecord":null,"userErrors":[{"field":["price"],"message":"Total price exceeds balance remaining"}]}}}`,
+		))
+
+	input := AppUsageRecordInput{
+		LineItemID:     "gid://shopify/AppSubscriptionLineItem/1?v=1&index=1",
+		Price:          decimal.RequireFromString("1"),
+		CurrencyCode:   "USD",
+		Description:    "100 emails",
+		IdempotencyKey: "shop-1-2023-06-01",
+	}
+	record, err := client.AppSubscription.CreateUsageRecord(input)
+	if err != nil {
+		t.Fatalf("AppSubscription.CreateUsageRecord returned error: %v", err)
+	}
+	if record.ID != "gid://shopify/AppUsageRecord/1" || record.Price == nil || !record.Price.Equal(decimal.RequireFromString("1")) || record.CurrencyCode != "USD" {
+		t.Errorf("AppSubscription.CreateUsageRecord returned %+v", record)
+	}
+
+	expectedVars := map[string]interface{}{
+		"subscriptionLineItemId": "gid://shopify/AppSubscriptionLineItem/1?v=1&index=1",
+		"price":                  map[string]interface{}{"amount": "1", "currencyCode": "USD"},
+		"description":            "100 emails",
+		"idempotencyKey":         "shop-1-2023-06-01",
+	}
+	if !reflect.DeepEqual(requests[0].Variables, expectedVars) {
+		t.Errorf("AppSubscription.CreateUsageRecord sent %+v, expected %+v", requests[0].Variables, expectedVars)
+	}
+
+	if _, err := client.AppSubscription.CreateUsageRecord(input); err == nil {
+		t.Error("AppSubscription.CreateUsageRecord expected the user error")
+	}
+}
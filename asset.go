@@ -56,9 +56,7 @@ type assetGetOptions struct {
 // List the metadata for all assets in the given theme
 func (s *AssetServiceOp) List(themeID int64, options interface{}) ([]Asset, error) {
 	path := fmt.Sprintf("%s/%d/assets.json", assetsBasePath, themeID)
-	resource := new(AssetsResource)
-	err := s.client.Get(path, resource, options)
-	return resource.Assets, err
+	return listResource[Asset](s.client, path, "assets", options)
 }
 
 // Get an asset by key from the given theme
@@ -68,18 +66,13 @@ func (s *AssetServiceOp) Get(themeID int64, key string) (*Asset, error) {
 		Key:     key,
 		ThemeID: themeID,
 	}
-	resource := new(AssetResource)
-	err := s.client.Get(path, resource, options)
-	return resource.Asset, err
+	return getResource[Asset](s.client, path, "asset", options)
 }
 
 // Update an asset
 func (s *AssetServiceOp) Update(themeID int64, asset Asset) (*Asset, error) {
 	path := fmt.Sprintf("%s/%d/assets.json", assetsBasePath, themeID)
-	wrappedData := AssetResource{Asset: &asset}
-	resource := new(AssetResource)
-	err := s.client.Put(path, wrappedData, resource)
-	return resource.Asset, err
+	return updateResource(s.client, path, "asset", asset)
 }
 
 // Delete an asset
@@ -0,0 +1,49 @@
+package goshopify
+
+import "net/http"
+
+// authMode is how a client authenticates its requests.
+type authMode int
+
+const (
+	// authModeAuto sends the access token when there is one, otherwise the
+	// private app credentials of the App
+	authModeAuto authMode = iota
+
+	// authModeAccessToken only sends the access token, see WithAccessToken
+	authModeAccessToken
+
+	// authModePrivateApp only sends the private app credentials, see
+	// WithPrivateAppAuth
+	authModePrivateApp
+)
+
+// authenticate adds the credentials of the client to a request.
+func (c *Client) authenticate(req *http.Request) {
+	if c.storefrontToken != "" {
+		req.Header.Add("X-Shopify-Storefront-Access-Token", c.storefrontToken)
+		return
+	}
+
+	switch c.authMode {
+	case authModeAccessToken:
+		req.Header.Add("X-Shopify-Access-Token", c.token)
+	case authModePrivateApp:
+		req.SetBasicAuth(c.app.ApiKey, c.app.Password)
+	default:
+		if c.token != "" {
+			req.Header.Add("X-Shopify-Access-Token", c.token)
+		} else if c.app.Password != "" {
+			req.SetBasicAuth(c.app.ApiKey, c.app.Password)
+		}
+	}
+}
+
+// warnAmbiguousAuth logs when a client has both an access token and private
+// app credentials without an option saying which one to use.
+func (c *Client) warnAmbiguousAuth() {
+	if c.authMode == authModeAuto && c.storefrontToken == "" && c.token != "" && c.app.Password != "" {
+		c.log.Warnf("both an access token and private app credentials are configured, using the access token; " +
+			"pick one with WithAccessToken or WithPrivateAppAuth")
+	}
+}
@@ -0,0 +1,69 @@
+package goshopify
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestClientAuthentication(t *testing.T) {
+	privateApp := App{ApiKey: "apikey", Password: "apipassword"}
+
+	cases := []struct {
+		description string
+		client      *Client
+		token       string
+		basic       bool
+	}{
+		{"token", NewClient(App{}, "fooshop", "abcd"), "abcd", false},
+		{"private app", NewClient(privateApp, "fooshop", ""), "", true},
+		{"both, token wins", NewClient(privateApp, "fooshop", "abcd"), "abcd", false},
+		{"both, private app selected", NewClient(privateApp, "fooshop", "abcd", WithPrivateAppAuth("apikey", "apipassword")), "", true},
+		{"custom app token selected", NewClient(privateApp, "fooshop", "", WithAccessToken("custom")), "custom", false},
+		{"private app option", NewClient(App{}, "fooshop", "", WithPrivateAppAuth("apikey", "apipassword")), "", true},
+	}
+
+	for _, c := range cases {
+		req, err := c.client.NewRequest("GET", "foo", nil, nil)
+		if err != nil {
+			t.Fatalf("%s: NewRequest returned error: %v", c.description, err)
+		}
+
+		if token := req.Header.Get("X-Shopify-Access-Token"); token != c.token {
+			t.Errorf("%s: X-Shopify-Access-Token = %q, expected %q", c.description, token, c.token)
+		}
+
+		username, password, ok := req.BasicAuth()
+		if ok != c.basic {
+			t.Errorf("%s: basic auth set = %v, expected %v", c.description, ok, c.basic)
+		}
+		if c.basic && (username != "apikey" || password != "apipassword") {
+			t.Errorf("%s: basic auth = %s:%s, expected apikey:apipassword", c.description, username, password)
+		}
+	}
+}
+
+func TestClientAmbiguousAuthWarning(t *testing.T) {
+	privateApp := App{ApiKey: "apikey", Password: "apipassword"}
+
+	cases := []struct {
+		description string
+		opts        []Option
+		warns       bool
+	}{
+		{"ambiguous", nil, true},
+		{"token selected", []Option{WithAccessToken("abcd")}, false},
+		{"private app selected", []Option{WithPrivateAppAuth("apikey", "apipassword")}, false},
+	}
+
+	for _, c := range cases {
+		out := &bytes.Buffer{}
+		logger := &LeveledLogger{Level: LevelWarn, stderrOverride: out}
+		NewClient(privateApp, "fooshop", "abcd", append([]Option{WithLogger(logger)}, c.opts...)...)
+
+		warned := strings.Contains(out.String(), "both an access token and private app credentials")
+		if warned != c.warns {
+			t.Errorf("%s: warned = %v, expected %v (%q)", c.description, warned, c.warns, out.String())
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package goshopify
+
+import (
+	"context"
+	"sync"
+)
+
+// defaultBatchWorkers is the number of items a batch processes concurrently
+// when WithBatchWorkers isn't used.
+const defaultBatchWorkers = 4
+
+// BatchFunc is the call, or sequence of calls, a batch makes for one item.
+type BatchFunc[T, R any] func(ctx context.Context, c *Client, item T) (R, error)
+
+// BatchResult is the outcome of one item of a batch.
+type BatchResult[T, R any] struct {
+	Item  T
+	Value R
+	Err   error
+}
+
+// BatchOption is used to configure a batch.
+type BatchOption func(b *batchConfig)
+
+type batchConfig struct {
+	workers int
+	limits  BucketLimits
+}
+
+// WithBatchWorkers sets the number of items processed concurrently, defaults
+// to 4.
+func WithBatchWorkers(workers int) BatchOption {
+	return func(b *batchConfig) {
+		if workers > 0 {
+			b.workers = workers
+		}
+	}
+}
+
+// WithBatchLimits sets the bucket the workers share to pace the items when
+// the client was created without WithRateLimiter, every item counts as one
+// request. Defaults to StandardRESTLimits, use PlusRESTLimits for Shopify
+// Plus stores.
+func WithBatchLimits(limits BucketLimits) BatchOption {
+	return func(b *batchConfig) {
+		b.limits = limits
+	}
+}
+
+// Batch calls fn for every item with a bounded number of workers and returns
+// the results in the order of the items. Failing items don't stop the batch,
+// their errors are in the results. Once the context is done the remaining
+// items fail with its error.
+//
+// The workers share the client's rate limiter, see WithRateLimiter, so bulk
+// backfills run as fast as the shop's call limit allows without running into
+// it. Clients without one pace the items with a bucket shared by the workers
+// instead, see WithBatchLimits.
+func Batch[T, R any](ctx context.Context, c *Client, items []T, fn BatchFunc[T, R], opts ...BatchOption) []BatchResult[T, R] {
+	config := batchConfig{workers: defaultBatchWorkers, limits: StandardRESTLimits}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	var bucket *leakyBucket
+	if c.restBucket == nil && c.graphQLBucket == nil {
+		bucket = newLeakyBucket(config.limits, 1)
+	}
+
+	results := make([]BatchResult[T, R], len(items))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < config.workers && w < len(items); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = runBatchItem(ctx, c, bucket, items[i], fn)
+			}
+		}()
+	}
+
+	for i := range items {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
+
+func runBatchItem[T, R any](ctx context.Context, c *Client, bucket *leakyBucket, item T, fn BatchFunc[T, R]) BatchResult[T, R] {
+	result := BatchResult[T, R]{Item: item}
+
+	if bucket != nil {
+		result.Err = bucket.take(ctx, c.rateLimited)
+	}
+	if result.Err == nil {
+		result.Err = waitForRateLimit(ctx, c)
+	}
+	if result.Err == nil {
+		result.Value, result.Err = fn(ctx, c, item)
+	}
+	return result
+}
@@ -0,0 +1,96 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestBatch(t *testing.T) {
+	setup()
+	defer teardown()
+
+	for _, id := range []int64{1, 2, 3, 4, 5} {
+		httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/%d.json", client.pathPrefix, id),
+			httpmock.NewStringResponder(200, fmt.Sprintf(`{"product":{"id":%d}}`, id)))
+	}
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/6.json", client.pathPrefix),
+		httpmock.NewStringResponder(404, `{"errors":"Not Found"}`))
+
+	var mu sync.Mutex
+	running, maxRunning := 0, 0
+	ids := []int64{1, 2, 3, 4, 5, 6}
+
+	results := Batch(context.Background(), client, ids, func(ctx context.Context, c *Client, id int64) (*Product, error) {
+		mu.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			running--
+			mu.Unlock()
+		}()
+		return c.Product.Get(id, nil)
+	}, WithBatchWorkers(2))
+
+	if len(results) != len(ids) {
+		t.Fatalf("Batch returned %d results, expected %d", len(results), len(ids))
+	}
+	for i, result := range results[:5] {
+		if result.Err != nil || result.Item != ids[i] || result.Value.ID != ids[i] {
+			t.Errorf("Batch result %d = %+v, expected product %d", i, result, ids[i])
+		}
+	}
+	if results[5].Err == nil {
+		t.Errorf("Batch result 5 = %+v, expected an error", results[5])
+	}
+	if maxRunning > 2 {
+		t.Errorf("Batch ran %d items at once, expected at most 2", maxRunning)
+	}
+}
+
+func TestBatchLimits(t *testing.T) {
+	setup()
+	defer teardown()
+
+	c := NewClient(app, "fooshop", "abcd")
+	results := Batch(context.Background(), c, []int{1, 2, 3}, func(ctx context.Context, c *Client, i int) (int, error) {
+		return i * 2, nil
+	}, WithBatchLimits(BucketLimits{Size: 1, LeakRate: 1000}))
+
+	for i, result := range results {
+		if result.Err != nil || result.Value != (i+1)*2 {
+			t.Errorf("Batch result %d = %+v, expected %d", i, result, (i+1)*2)
+		}
+	}
+}
+
+func TestBatchCancelled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	called := false
+	results := Batch(ctx, client, []int{1, 2}, func(ctx context.Context, c *Client, i int) (int, error) {
+		called = true
+		return i, nil
+	})
+
+	if called {
+		t.Errorf("Batch called the function with a cancelled context")
+	}
+	for i, result := range results {
+		if !errors.Is(result.Err, context.Canceled) {
+			t.Errorf("Batch result %d = %+v, expected %v", i, result, context.Canceled)
+		}
+	}
+}
@@ -5,7 +5,10 @@ import (
 	"time"
 )
 
-const blogsBasePath = "blogs"
+const (
+	blogsBasePath     = "blogs"
+	blogsResourceName = "blogs"
+)
 
 // BlogService is an interface for interfacing with the blogs endpoints
 // of the Shopify API.
@@ -17,6 +20,9 @@ type BlogService interface {
 	Create(Blog) (*Blog, error)
 	Update(Blog) (*Blog, error)
 	Delete(int64) error
+
+	// MetafieldsService used for Blog resource to communicate with Metafields resource
+	MetafieldsService
 }
 
 // BlogServiceOp handles communication with the blog related methods of
@@ -53,9 +59,7 @@ type BlogResource struct {
 // List all blogs
 func (s *BlogServiceOp) List(options interface{}) ([]Blog, error) {
 	path := fmt.Sprintf("%s.json", blogsBasePath)
-	resource := new(BlogsResource)
-	err := s.client.Get(path, resource, options)
-	return resource.Blogs, err
+	return listResource[Blog](s.client, path, "blogs", options)
 }
 
 // Count blogs
@@ -67,30 +71,58 @@ func (s *BlogServiceOp) Count(options interface{}) (int, error) {
 // Get single blog
 func (s *BlogServiceOp) Get(blogId int64, options interface{}) (*Blog, error) {
 	path := fmt.Sprintf("%s/%d.json", blogsBasePath, blogId)
-	resource := new(BlogResource)
-	err := s.client.Get(path, resource, options)
-	return resource.Blog, err
+	return getResource[Blog](s.client, path, "blog", options)
 }
 
 // Create a new blog
 func (s *BlogServiceOp) Create(blog Blog) (*Blog, error) {
 	path := fmt.Sprintf("%s.json", blogsBasePath)
-	wrappedData := BlogResource{Blog: &blog}
-	resource := new(BlogResource)
-	err := s.client.Post(path, wrappedData, resource)
-	return resource.Blog, err
+	return createResource(s.client, path, "blog", blog)
 }
 
 // Update an existing blog
 func (s *BlogServiceOp) Update(blog Blog) (*Blog, error) {
 	path := fmt.Sprintf("%s/%d.json", blogsBasePath, blog.ID)
-	wrappedData := BlogResource{Blog: &blog}
-	resource := new(BlogResource)
-	err := s.client.Put(path, wrappedData, resource)
-	return resource.Blog, err
+	return updateResource(s.client, path, "blog", blog)
 }
 
 // Delete an blog
 func (s *BlogServiceOp) Delete(blogId int64) error {
 	return s.client.Delete(fmt.Sprintf("%s/%d.json", blogsBasePath, blogId))
 }
+
+// List metafields for a blog
+func (s *BlogServiceOp) ListMetafields(blogID int64, options interface{}) ([]Metafield, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: blogsResourceName, resourceID: blogID}
+	return metafieldService.List(options)
+}
+
+// Count metafields for a blog
+func (s *BlogServiceOp) CountMetafields(blogID int64, options interface{}) (int, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: blogsResourceName, resourceID: blogID}
+	return metafieldService.Count(options)
+}
+
+// Get individual metafield for a blog
+func (s *BlogServiceOp) GetMetafield(blogID int64, metafieldID int64, options interface{}) (*Metafield, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: blogsResourceName, resourceID: blogID}
+	return metafieldService.Get(metafieldID, options)
+}
+
+// Create a new metafield for a blog
+func (s *BlogServiceOp) CreateMetafield(blogID int64, metafield Metafield) (*Metafield, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: blogsResourceName, resourceID: blogID}
+	return metafieldService.Create(metafield)
+}
+
+// Update an existing metafield for a blog
+func (s *BlogServiceOp) UpdateMetafield(blogID int64, metafield Metafield) (*Metafield, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: blogsResourceName, resourceID: blogID}
+	return metafieldService.Update(metafield)
+}
+
+// Delete an existing metafield for a blog
+func (s *BlogServiceOp) DeleteMetafield(blogID int64, metafieldID int64) error {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: blogsResourceName, resourceID: blogID}
+	return metafieldService.Delete(metafieldID)
+}
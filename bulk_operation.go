@@ -0,0 +1,322 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// Statuses of a bulk operation.
+const (
+	BulkOperationStatusCreated   = "CREATED"
+	BulkOperationStatusRunning   = "RUNNING"
+	BulkOperationStatusCompleted = "COMPLETED"
+	BulkOperationStatusCanceling = "CANCELING"
+	BulkOperationStatusCanceled  = "CANCELED"
+	BulkOperationStatusFailed    = "FAILED"
+	BulkOperationStatusExpired   = "EXPIRED"
+)
+
+// defaultBulkOperationPollInterval is how often Wait checks on an operation
+// when no interval is given.
+const defaultBulkOperationPollInterval = 5 * time.Second
+
+// ErrBulkOperationNotFinished is returned by Results for operations that
+// are still running.
+var ErrBulkOperationNotFinished = errors.New("bulk operation has not finished")
+
+// BulkOperationService is an interface for running bulk operations, which
+// export the results of a GraphQL query of any size as a JSONL file, or run a
+// mutation once for every line of a JSONL file, without being limited by the
+// rate limits or pagination.
+// See: https://shopify.dev/api/usage/bulk-operations/queries
+// See: https://shopify.dev/api/usage/bulk-operations/imports
+type BulkOperationService interface {
+	RunQuery(string) (*BulkOperation, error)
+	RunMutation(context.Context, string, io.Reader) (*BulkOperation, error)
+	Get(string) (*BulkOperation, error)
+	Current() (*BulkOperation, error)
+	Cancel(string) (*BulkOperation, error)
+	Wait(context.Context, string, time.Duration) (*BulkOperation, error)
+	Results(context.Context, *BulkOperation) (*JSONLReader, error)
+}
+
+// BulkOperationServiceOp handles communication with the bulk operation
+// related GraphQL queries and mutations.
+type BulkOperationServiceOp struct {
+	client *Client
+}
+
+// BulkOperation represents a bulk operation. URL is where the results can be
+// downloaded from once it completed, PartialDataURL holds the results up to
+// the point it failed.
+type BulkOperation struct {
+	ID              string     `json:"id"`
+	Type            string     `json:"type"`
+	Status          string     `json:"status"`
+	ErrorCode       string     `json:"errorCode"`
+	Query           string     `json:"query"`
+	ObjectCount     int64      `json:"objectCount,string"`
+	RootObjectCount int64      `json:"rootObjectCount,string"`
+	FileSize        int64      `json:"fileSize,string"`
+	URL             string     `json:"url"`
+	PartialDataURL  string     `json:"partialDataUrl"`
+	CreatedAt       *time.Time `json:"createdAt"`
+	CompletedAt     *time.Time `json:"completedAt"`
+}
+
+// Finished reports whether the operation reached a final status.
+func (op *BulkOperation) Finished() bool {
+	switch op.Status {
+	case BulkOperationStatusCompleted, BulkOperationStatusCanceled,
+		BulkOperationStatusFailed, BulkOperationStatusExpired:
+		return true
+	}
+	return false
+}
+
+// bulkOperationPayload is the result of every bulk operation mutation.
+type bulkOperationPayload struct {
+	BulkOperation *BulkOperation `json:"bulkOperation"`
+	UserErrors    []UserError    `json:"userErrors"`
+}
+
+const bulkOperationFields = `id type status errorCode query objectCount rootObjectCount fileSize url partialDataUrl createdAt completedAt`
+
+const bulkOperationRunQueryMutation = `mutation($query: String!) {
+  bulkOperationRunQuery(query: $query) {
+    bulkOperation { ` + bulkOperationFields + ` }
+    userErrors { field message }
+  }
+}`
+
+const bulkOperationRunMutationMutation = `mutation($mutation: String!, $stagedUploadPath: String!) {
+  bulkOperationRunMutation(mutation: $mutation, stagedUploadPath: $stagedUploadPath) {
+    bulkOperation { ` + bulkOperationFields + ` }
+    userErrors { field message }
+  }
+}`
+
+// bulkMutationVariablesFilename is the name the variables are uploaded as.
+const bulkMutationVariablesFilename = "bulk_mutation_variables.jsonl"
+
+const bulkOperationQuery = `query($id: ID!) {
+  node(id: $id) { ... on BulkOperation { ` + bulkOperationFields + ` } }
+}`
+
+const currentBulkOperationQuery = `{
+  currentBulkOperation { ` + bulkOperationFields + ` }
+}`
+
+const bulkOperationCancelMutation = `mutation($id: ID!) {
+  bulkOperationCancel(id: $id) {
+    bulkOperation { ` + bulkOperationFields + ` }
+    userErrors { field message }
+  }
+}`
+
+// RunQuery starts a bulk operation exporting the results of the query. Only
+// one bulk query can run at a time per shop and app.
+func (s *BulkOperationServiceOp) RunQuery(query string) (*BulkOperation, error) {
+	resp := struct {
+		Payload bulkOperationPayload `json:"bulkOperationRunQuery"`
+	}{}
+	return s.mutate(bulkOperationRunQueryMutation, map[string]interface{}{"query": query}, &resp, &resp.Payload)
+}
+
+// RunMutation uploads the variables, a JSONL file with the variables of one
+// call per line, and starts a bulk operation running the mutation for each of
+// them. Once it finished, the results hold one BulkMutationResult per line,
+// see JSONLReader.NextMutationResult. Only one bulk mutation can run at a
+// time per shop and app.
+func (s *BulkOperationServiceOp) RunMutation(ctx context.Context, mutation string, variables io.Reader) (*BulkOperation, error) {
+	targets, err := s.client.StagedUpload.Create(StagedUploadInput{
+		Resource:   StagedUploadResourceBulkMutationVariables,
+		Filename:   bulkMutationVariablesFilename,
+		MimeType:   "text/jsonl",
+		HTTPMethod: "POST",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	target := targets[0]
+	if err := s.client.StagedUpload.Upload(ctx, target, variables); err != nil {
+		return nil, err
+	}
+
+	resp := struct {
+		Payload bulkOperationPayload `json:"bulkOperationRunMutation"`
+	}{}
+	vars := map[string]interface{}{"mutation": mutation, "stagedUploadPath": target.Parameter("key")}
+	return s.mutate(bulkOperationRunMutationMutation, vars, &resp, &resp.Payload)
+}
+
+// Get returns the bulk operation with the given ID, nil if there is none.
+func (s *BulkOperationServiceOp) Get(id string) (*BulkOperation, error) {
+	resp := struct {
+		Node *BulkOperation `json:"node"`
+	}{}
+	err := s.client.GraphQL.Query(bulkOperationQuery, map[string]interface{}{"id": id}, &resp)
+	return resp.Node, err
+}
+
+// Current returns the most recent bulk query of the app, nil if there is
+// none.
+func (s *BulkOperationServiceOp) Current() (*BulkOperation, error) {
+	resp := struct {
+		CurrentBulkOperation *BulkOperation `json:"currentBulkOperation"`
+	}{}
+	err := s.client.GraphQL.Query(currentBulkOperationQuery, nil, &resp)
+	return resp.CurrentBulkOperation, err
+}
+
+// Cancel asks Shopify to cancel a running bulk operation, it is canceled
+// once its status is BulkOperationStatusCanceled.
+func (s *BulkOperationServiceOp) Cancel(id string) (*BulkOperation, error) {
+	resp := struct {
+		Payload bulkOperationPayload `json:"bulkOperationCancel"`
+	}{}
+	return s.mutate(bulkOperationCancelMutation, map[string]interface{}{"id": id}, &resp, &resp.Payload)
+}
+
+// Wait polls the bulk operation every interval, 5 seconds when zero, until
+// it finished or the context is done. Apps subscribed to the
+// bulk_operations/finish webhook can call Get instead once it is delivered,
+// see BulkOperationFinished.
+func (s *BulkOperationServiceOp) Wait(ctx context.Context, id string, interval time.Duration) (*BulkOperation, error) {
+	if interval <= 0 {
+		interval = defaultBulkOperationPollInterval
+	}
+
+	for {
+		op, err := s.Get(id)
+		if err != nil {
+			return nil, err
+		}
+		if op == nil {
+			return nil, fmt.Errorf("bulk operation %s not found", id)
+		}
+		if op.Finished() {
+			return op, nil
+		}
+		if err := sleepContext(ctx, interval); err != nil {
+			return op, err
+		}
+	}
+}
+
+// Results downloads the results of a finished bulk operation, or the partial
+// results of a failed one. The reader is empty when the query matched
+// nothing, it must be closed.
+func (s *BulkOperationServiceOp) Results(ctx context.Context, op *BulkOperation) (*JSONLReader, error) {
+	if !op.Finished() {
+		return nil, ErrBulkOperationNotFinished
+	}
+
+	url := op.URL
+	if url == "" {
+		url = op.PartialDataURL
+	}
+	if url == "" {
+		return NewJSONLReader(http.NoBody), nil
+	}
+
+	// the url is signed, sending the access token along is not needed
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.transferClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, ResponseError{Status: resp.StatusCode, Message: "downloading bulk operation results: " + resp.Status}
+	}
+	return NewJSONLReader(resp.Body), nil
+}
+
+func (s *BulkOperationServiceOp) mutate(mutation string, vars, resp interface{}, payload *bulkOperationPayload) (*BulkOperation, error) {
+	err := s.client.GraphQL.Query(mutation, vars, resp)
+	if err == nil {
+		err = userErrorsToError(payload.UserErrors)
+	}
+	return payload.BulkOperation, err
+}
+
+// JSONLReader streams the records of a JSONL file, one JSON object per line,
+// like the results of a bulk operation. Nested objects of a bulk query, e.g.
+// the variants of a product, are separate records pointing at their parent
+// through __parentId.
+type JSONLReader struct {
+	r   io.ReadCloser
+	dec *json.Decoder
+}
+
+// NewJSONLReader returns a reader for the JSONL records read from r. Closing
+// the reader closes r when it is an io.Closer.
+func NewJSONLReader(r io.Reader) *JSONLReader {
+	rc, ok := r.(io.ReadCloser)
+	if !ok {
+		rc = ioutil.NopCloser(r)
+	}
+	return &JSONLReader{r: rc, dec: json.NewDecoder(rc)}
+}
+
+// Next decodes the next record into v, it returns io.EOF after the last one.
+func (r *JSONLReader) Next(v interface{}) error {
+	return r.dec.Decode(v)
+}
+
+// BulkMutationResult is the result of one line of a bulk mutation. Data holds
+// the payload of the mutation, keyed by the mutation's name.
+type BulkMutationResult struct {
+	Line   int                        `json:"__lineNumber"`
+	Data   map[string]json.RawMessage `json:"data"`
+	Errors []GraphQLError             `json:"errors"`
+}
+
+// Err returns the errors of the line, the top level errors or the user errors
+// of the mutation payload, or nil when it succeeded.
+func (r *BulkMutationResult) Err() error {
+	if len(r.Errors) > 0 {
+		responseError := ResponseError{Status: 200}
+		for _, e := range r.Errors {
+			responseError.Errors = append(responseError.Errors, e.Message)
+		}
+		return responseError
+	}
+
+	var userErrors []UserError
+	for _, data := range r.Data {
+		payload := struct {
+			UserErrors []UserError `json:"userErrors"`
+		}{}
+		if json.Unmarshal(data, &payload) == nil {
+			userErrors = append(userErrors, payload.UserErrors...)
+		}
+	}
+	return userErrorsToError(userErrors)
+}
+
+// NextMutationResult reads the result of the next line of a bulk mutation,
+// it returns io.EOF after the last one.
+func (r *JSONLReader) NextMutationResult() (*BulkMutationResult, error) {
+	result := &BulkMutationResult{}
+	if err := r.Next(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Close closes the underlying reader.
+func (r *JSONLReader) Close() error {
+	return r.r.Close()
+}
@@ -0,0 +1,326 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+const bulkOperationJSON = `{"id":"gid://shopify/BulkOperation/1","type":"QUERY","status":"%s","errorCode":null,"query":"{ products { edges { node { id } } } }","objectCount":"%d","rootObjectCount":"%d","fileSize":%s,"url":%s,"partialDataUrl":null,"createdAt":"2022-01-01T10:00:00Z","completedAt":null}`
+
+func bulkOperationResponder(t *testing.T, responses map[string][]string) httpmock.Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		body, _ := ioutil.ReadAll(req.Body)
+		payload := graphQLRequest{}
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Fatalf("decoding GraphQL request: %v", err)
+		}
+		for operation, bodies := range responses {
+			if strings.Contains(payload.Query, operation) && len(bodies) > 0 {
+				responses[operation] = bodies[1:]
+				return httpmock.NewStringResponse(200, bodies[0]), nil
+			}
+		}
+		t.Fatalf("unexpected GraphQL query: %s", payload.Query)
+		return nil, nil
+	}
+}
+
+func TestBulkOperationRunQuery(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		bulkOperationResponder(t, map[string][]string{
+			"bulkOperationRunQuery": {`{"data":{"bulkOperationRunQuery":{"bulkOperation":` + fmt.Sprintf(bulkOperationJSON, "CREATED", 0, 0, "null", "null") + `,"userErrors":[]}}}`},
+		}))
+
+	op, err := client.BulkOperation.RunQuery("{ products { edges { node { id } } } }")
+	if err != nil {
+		t.Fatalf("BulkOperation.RunQuery returned error: %v", err)
+	}
+	if op.ID != "gid://shopify/BulkOperation/1" || op.Status != BulkOperationStatusCreated {
+		t.Errorf("BulkOperation.RunQuery returned %+v, expected a created operation", op)
+	}
+	if op.Finished() {
+		t.Error("BulkOperation.Finished returned true for a created operation")
+	}
+}
+
+func TestBulkOperationRunQueryUserErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"data":{"bulkOperationRunQuery":{"bulkOperation":null,"userErrors":[{"field":["query"],"message":"A bulk query operation for this app and shop is already in progress"}]}}}`))
+
+	_, err := client.BulkOperation.RunQuery("{ products { edges { node { id } } } }")
+	expected := "query: A bulk query operation for this app and shop is already in progress"
+	if err == nil || err.Error() != expected {
+		t.Errorf("BulkOperation.RunQuery returned %v, expected %s", err, expected)
+	}
+}
+
+func TestBulkOperationCurrent(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"data":{"currentBulkOperation":`+fmt.Sprintf(bulkOperationJSON, "RUNNING", 10, 2, "null", "null")+`}}`))
+
+	op, err := client.BulkOperation.Current()
+	if err != nil {
+		t.Fatalf("BulkOperation.Current returned error: %v", err)
+	}
+	if op.Status != BulkOperationStatusRunning || op.ObjectCount != 10 || op.RootObjectCount != 2 {
+		t.Errorf("BulkOperation.Current returned %+v, expected a running operation with 10 objects", op)
+	}
+}
+
+func TestBulkOperationCancel(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"data":{"bulkOperationCancel":{"bulkOperation":`+fmt.Sprintf(bulkOperationJSON, "CANCELING", 0, 0, "null", "null")+`,"userErrors":[]}}}`))
+
+	op, err := client.BulkOperation.Cancel("gid://shopify/BulkOperation/1")
+	if err != nil {
+		t.Fatalf("BulkOperation.Cancel returned error: %v", err)
+	}
+	if op.Status != BulkOperationStatusCanceling {
+		t.Errorf("BulkOperation.Cancel returned status %s, expected %s", op.Status, BulkOperationStatusCanceling)
+	}
+}
+
+func TestBulkOperationWaitAndResults(t *testing.T) {
+	setup()
+	defer teardown()
+
+	resultsURL := "https://storage.googleapis.com/shopify/bulk.jsonl?signature=abc"
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		bulkOperationResponder(t, map[string][]string{
+			"node(id: $id)": {
+				`{"data":{"node":` + fmt.Sprintf(bulkOperationJSON, "RUNNING", 1, 1, "null", "null") + `}}`,
+				`{"data":{"node":` + fmt.Sprintf(bulkOperationJSON, "COMPLETED", 3, 1, `"180"`, `"`+resultsURL+`"`) + `}}`,
+			},
+		}))
+	httpmock.RegisterResponder("GET", resultsURL, func(req *http.Request) (*http.Response, error) {
+		if req.Header.Get("X-Shopify-Access-Token") != "" {
+			t.Error("the access token was sent to the results url")
+		}
+		return httpmock.NewStringResponse(200, `{"id":"gid://shopify/Product/1","title":"Shirt"}
+{"id":"gid://shopify/ProductVariant/1","__parentId":"gid://shopify/Product/1"}
+{"id":"gid://shopify/ProductVariant/2","__parentId":"gid://shopify/Product/1"}
+`), nil
+	})
+
+	op, err := client.BulkOperation.Wait(context.Background(), "gid://shopify/BulkOperation/1", time.Millisecond)
+	if err != nil {
+		t.Fatalf("BulkOperation.Wait returned error: %v", err)
+	}
+	if op.Status != BulkOperationStatusCompleted || op.FileSize != 180 || op.URL != resultsURL {
+		t.Fatalf("BulkOperation.Wait returned %+v, expected the completed operation", op)
+	}
+
+	results, err := client.BulkOperation.Results(context.Background(), op)
+	if err != nil {
+		t.Fatalf("BulkOperation.Results returned error: %v", err)
+	}
+	defer results.Close()
+
+	type record struct {
+		ID       string `json:"id"`
+		Title    string `json:"title"`
+		ParentID string `json:"__parentId"`
+	}
+	var records []record
+	for {
+		var r record
+		err := results.Next(&r)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("JSONLReader.Next returned error: %v", err)
+		}
+		records = append(records, r)
+	}
+
+	if len(records) != 3 {
+		t.Fatalf("JSONLReader returned %d records, expected 3", len(records))
+	}
+	if records[0].Title != "Shirt" || records[2].ParentID != "gid://shopify/Product/1" {
+		t.Errorf("JSONLReader returned %+v", records)
+	}
+}
+
+func TestBulkOperationWaitContextDone(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"data":{"node":`+fmt.Sprintf(bulkOperationJSON, "RUNNING", 1, 1, "null", "null")+`}}`))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	op, err := client.BulkOperation.Wait(ctx, "gid://shopify/BulkOperation/1", time.Millisecond)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("BulkOperation.Wait returned %v, expected %v", err, context.DeadlineExceeded)
+	}
+	if op == nil || op.Status != BulkOperationStatusRunning {
+		t.Errorf("BulkOperation.Wait returned %+v, expected the running operation", op)
+	}
+}
+
+func TestBulkOperationResults(t *testing.T) {
+	setup()
+	defer teardown()
+
+	_, err := client.BulkOperation.Results(context.Background(), &BulkOperation{Status: BulkOperationStatusRunning})
+	if err != ErrBulkOperationNotFinished {
+		t.Errorf("BulkOperation.Results returned %v, expected %v", err, ErrBulkOperationNotFinished)
+	}
+
+	// a query matching nothing has no results file
+	results, err := client.BulkOperation.Results(context.Background(), &BulkOperation{Status: BulkOperationStatusCompleted})
+	if err != nil {
+		t.Fatalf("BulkOperation.Results returned error: %v", err)
+	}
+	if err := results.Next(&struct{}{}); err != io.EOF {
+		t.Errorf("JSONLReader.Next returned %v, expected io.EOF", err)
+	}
+
+	partialURL := "https://storage.googleapis.com/shopify/partial.jsonl"
+	httpmock.RegisterResponder("GET", partialURL, httpmock.NewStringResponder(403, "expired"))
+	_, err = client.BulkOperation.Results(context.Background(), &BulkOperation{Status: BulkOperationStatusFailed, PartialDataURL: partialURL})
+	if responseError, ok := err.(ResponseError); !ok || responseError.Status != 403 {
+		t.Errorf("BulkOperation.Results returned %v, expected a 403 ResponseError", err)
+	}
+}
+
+func TestBulkOperationResultsOutlastClientTimeout(t *testing.T) {
+	// results stream longer than the client's timeout
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"id":"gid://shopify/Product/1"}`)
+		w.(http.Flusher).Flush()
+		time.Sleep(100 * time.Millisecond)
+		fmt.Fprintln(w, `{"id":"gid://shopify/Product/2"}`)
+	}))
+	defer server.Close()
+
+	testClient := NewClient(app, "fooshop", "abcd", WithHTTPClient(&http.Client{Timeout: 20 * time.Millisecond}))
+	results, err := testClient.BulkOperation.Results(context.Background(), &BulkOperation{Status: BulkOperationStatusCompleted, URL: server.URL})
+	if err != nil {
+		t.Fatalf("BulkOperation.Results returned error: %v", err)
+	}
+	defer results.Close()
+
+	count := 0
+	record := struct{ ID string }{}
+	for {
+		err := results.Next(&record)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("JSONLReader.Next returned error: %v", err)
+		}
+		count++
+	}
+	if count != 2 {
+		t.Errorf("JSONLReader read %d records, expected 2", count)
+	}
+	if testClient.Client.Timeout != 20*time.Millisecond {
+		t.Errorf("BulkOperation.Results changed the client timeout to %s", testClient.Client.Timeout)
+	}
+}
+
+func TestBulkOperationRunMutation(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var runVariables map[string]interface{}
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := ioutil.ReadAll(req.Body)
+			payload := struct {
+				Query     string                 `json:"query"`
+				Variables map[string]interface{} `json:"variables"`
+			}{}
+			if err := json.Unmarshal(body, &payload); err != nil {
+				t.Fatalf("decoding GraphQL request: %v", err)
+			}
+			if strings.Contains(payload.Query, "stagedUploadsCreate") {
+				return httpmock.NewStringResponse(200, stagedUploadResponse), nil
+			}
+			runVariables = payload.Variables
+			return httpmock.NewStringResponse(200, `{"data":{"bulkOperationRunMutation":{"bulkOperation":`+
+				fmt.Sprintf(bulkOperationJSON, "CREATED", 0, 0, "null", "null")+`,"userErrors":[]}}}`), nil
+		})
+
+	uploaded := false
+	httpmock.RegisterResponder("POST", "https://shopify.s3.amazonaws.com", func(req *http.Request) (*http.Response, error) {
+		uploaded = true
+		return httpmock.NewStringResponse(201, ""), nil
+	})
+
+	mutation := `mutation($input: ProductInput!) { productUpdate(input: $input) { product { id } userErrors { field message } } }`
+	variables := strings.NewReader(`{"input":{"id":"gid://shopify/Product/1","title":"Shirt"}}` + "\n")
+	op, err := client.BulkOperation.RunMutation(context.Background(), mutation, variables)
+	if err != nil {
+		t.Fatalf("BulkOperation.RunMutation returned error: %v", err)
+	}
+	if op.Status != BulkOperationStatusCreated {
+		t.Errorf("BulkOperation.RunMutation returned status %s, expected %s", op.Status, BulkOperationStatusCreated)
+	}
+	if !uploaded {
+		t.Error("BulkOperation.RunMutation did not upload the variables")
+	}
+	if runVariables["mutation"] != mutation {
+		t.Errorf("bulkOperationRunMutation was called with mutation %v", runVariables["mutation"])
+	}
+	if path := runVariables["stagedUploadPath"]; path != "tmp/1/bulk/abc/bulk_mutation_variables.jsonl" {
+		t.Errorf("bulkOperationRunMutation was called with staged upload path %v", path)
+	}
+}
+
+func TestJSONLReaderNextMutationResult(t *testing.T) {
+	results := NewJSONLReader(strings.NewReader(`{"data":{"productUpdate":{"product":{"id":"gid://shopify/Product/1"},"userErrors":[]}},"__lineNumber":0}
+{"data":{"productUpdate":{"product":null,"userErrors":[{"field":["title"],"message":"can't be blank"}]}},"__lineNumber":1}
+{"errors":[{"message":"Internal error"}],"__lineNumber":2}
+`))
+	defer results.Close()
+
+	expected := []string{"", "title: can't be blank", "Internal error"}
+	for i, want := range expected {
+		result, err := results.NextMutationResult()
+		if err != nil {
+			t.Fatalf("JSONLReader.NextMutationResult returned error: %v", err)
+		}
+		if result.Line != i {
+			t.Errorf("BulkMutationResult.Line returned %d, expected %d", result.Line, i)
+		}
+		got := ""
+		if err := result.Err(); err != nil {
+			got = err.Error()
+		}
+		if got != want {
+			t.Errorf("BulkMutationResult.Err for line %d returned %q, expected %q", i, got, want)
+		}
+	}
+
+	if _, err := results.NextMutationResult(); err != io.EOF {
+		t.Errorf("JSONLReader.NextMutationResult returned %v after the last line, expected io.EOF", err)
+	}
+}
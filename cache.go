@@ -0,0 +1,142 @@
+package goshopify
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache stores responses to GET requests for a while, see WithCache. Keys
+// are request URLs, which include the shop, the path and the query, values
+// are opaque bytes so a cache can be backed by e.g. Redis or memcached.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte, ttl time.Duration)
+}
+
+// MemoryCache is a Cache keeping the values in memory until they expire.
+type MemoryCache struct {
+	mu        sync.Mutex
+	entries   map[string]memoryCacheEntry
+	nextSweep int
+}
+
+type memoryCacheEntry struct {
+	value   []byte
+	expires time.Time
+}
+
+// minMemoryCacheSweep is the number of entries a MemoryCache holds before it
+// first removes expired ones.
+const minMemoryCacheSweep = 64
+
+// NewMemoryCache returns an empty in-memory cache.
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{entries: map[string]memoryCacheEntry{}, nextSweep: minMemoryCacheSweep}
+}
+
+// Get returns the value cached for the key unless it expired.
+func (m *MemoryCache) Get(key string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expires) {
+		delete(m.entries, key)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// Set caches the value for the key for the ttl.
+func (m *MemoryCache) Set(key string, value []byte, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entries[key] = memoryCacheEntry{value: value, expires: time.Now().Add(ttl)}
+	if len(m.entries) < m.nextSweep {
+		return
+	}
+
+	// remove the entries that expired without being read again
+	now := time.Now()
+	for k, entry := range m.entries {
+		if now.After(entry.expires) {
+			delete(m.entries, k)
+		}
+	}
+	m.nextSweep = 2 * len(m.entries)
+	if m.nextSweep < minMemoryCacheSweep {
+		m.nextSweep = minMemoryCacheSweep
+	}
+}
+
+// cachedResponse is what the client stores in a Cache, the headers are kept
+// for the pagination links.
+type cachedResponse struct {
+	Header http.Header     `json:"header"`
+	Body   json.RawMessage `json:"body"`
+}
+
+// cacheKey returns the key a request is cached by, and whether it may be
+// cached at all.
+func (c *Client) cacheKey(req *http.Request) (string, bool) {
+	if c.cache == nil || req.Method != http.MethodGet {
+		return "", false
+	}
+
+	if len(c.cacheResources) > 0 {
+		resource := strings.Split(c.metricsResource(req), "/")[0]
+		cached := false
+		for _, r := range c.cacheResources {
+			if r == resource {
+				cached = true
+				break
+			}
+		}
+		if !cached {
+			return "", false
+		}
+	}
+
+	return req.URL.String(), true
+}
+
+// fromCache returns the cached response to a request, nil when there is none.
+func (c *Client) fromCache(req *http.Request) *cachedResponse {
+	key, ok := c.cacheKey(req)
+	if !ok {
+		return nil
+	}
+
+	value, ok := c.cache.Get(key)
+	if !ok {
+		return nil
+	}
+	cached := &cachedResponse{}
+	if err := json.Unmarshal(value, cached); err != nil {
+		c.log.Debugf("ignoring invalid cached response for %s: %s", key, err)
+		return nil
+	}
+	return cached
+}
+
+// toCache stores the response to a request when it may be cached.
+func (c *Client) toCache(req *http.Request, resp *http.Response, body []byte) {
+	key, ok := c.cacheKey(req)
+	if !ok || resp.StatusCode != http.StatusOK {
+		return
+	}
+
+	value, err := json.Marshal(cachedResponse{Header: resp.Header, Body: body})
+	if err != nil {
+		return
+	}
+	c.cache.Set(key, value, c.cacheTTL)
+}
@@ -0,0 +1,88 @@
+package goshopify
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestCache(t *testing.T) {
+	setup()
+	defer teardown()
+
+	WithCache(NewMemoryCache(), time.Minute, "shop", "locations")(client)
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shop.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"shop":{"id":1,"name":"Foo"}}`))
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"product":{"id":1}}`))
+
+	for i := 0; i < 3; i++ {
+		shop, err := client.Shop.Get(nil)
+		if err != nil {
+			t.Fatalf("Shop.Get returned error: %v", err)
+		}
+		if shop.ID != 1 || shop.Name != "Foo" {
+			t.Errorf("Shop.Get returned %+v, expected shop 1", shop)
+		}
+		if _, err := client.Product.Get(1, nil); err != nil {
+			t.Fatalf("Product.Get returned error: %v", err)
+		}
+	}
+
+	info := httpmock.GetCallCountInfo()
+	if calls := info[fmt.Sprintf("GET https://fooshop.myshopify.com/%s/shop.json", client.pathPrefix)]; calls != 1 {
+		t.Errorf("Shop.Get called the API %d times, expected once", calls)
+	}
+	if calls := info[fmt.Sprintf("GET https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix)]; calls != 3 {
+		t.Errorf("Product.Get called the API %d times, expected 3 times", calls)
+	}
+}
+
+func TestCachePagination(t *testing.T) {
+	setup()
+	defer teardown()
+
+	WithCache(NewMemoryCache(), time.Minute)(client)
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/locations.json", client.pathPrefix),
+		createResponderWithHeaders(200, `{"locations":[{"id":1}]}`, map[string]string{
+			"Link": `<https://fooshop.myshopify.com/admin/api/2020-01/locations.json?page_info=abc&limit=1>; rel="next"`,
+		}))
+
+	for i := 0; i < 2; i++ {
+		_, pagination, err := listResourceWithPagination[Location](client, fmt.Sprintf("%s.json", locationsBasePath), "locations", nil)
+		if err != nil {
+			t.Fatalf("listResourceWithPagination returned error: %v", err)
+		}
+		if pagination == nil || pagination.NextPageOptions == nil || pagination.NextPageOptions.PageInfo != "abc" {
+			t.Errorf("listResourceWithPagination returned pagination %+v, expected the next page", pagination)
+		}
+	}
+
+	if calls := httpmock.GetTotalCallCount(); calls != 1 {
+		t.Errorf("listResourceWithPagination called the API %d times, expected once", calls)
+	}
+}
+
+func TestMemoryCacheExpiry(t *testing.T) {
+	cache := NewMemoryCache()
+	cache.Set("a", []byte("a"), time.Minute)
+	cache.Set("b", []byte("b"), -time.Second)
+
+	if value, ok := cache.Get("a"); !ok || string(value) != "a" {
+		t.Errorf("MemoryCache.Get(\"a\") = %s, %v, expected a", value, ok)
+	}
+	if _, ok := cache.Get("b"); ok {
+		t.Errorf("MemoryCache.Get(\"b\") returned an expired value")
+	}
+
+	for i := 0; i < minMemoryCacheSweep; i++ {
+		cache.Set(fmt.Sprint(i), nil, -time.Second)
+	}
+	if n := len(cache.entries); n >= minMemoryCacheSweep {
+		t.Errorf("MemoryCache holds %d entries, expected the expired ones to be removed", n)
+	}
+}
@@ -0,0 +1,79 @@
+package goshopify
+
+import (
+	"fmt"
+	"time"
+)
+
+const carrierServicesBasePath = "carrier_services"
+
+// CarrierServiceService is an interface for interfacing with the carrier
+// service endpoints of the Shopify API.
+// See: https://shopify.dev/docs/admin-api/rest/reference/shipping-and-fulfillment/carrierservice
+type CarrierServiceService interface {
+	List() ([]CarrierService, error)
+	Get(int64) (*CarrierService, error)
+	Create(CarrierService) (*CarrierService, error)
+	Update(CarrierService) (*CarrierService, error)
+	Delete(int64) error
+}
+
+// CarrierServiceServiceOp handles communication with the carrier service
+// related methods of the Shopify API.
+type CarrierServiceServiceOp struct {
+	client *Client
+}
+
+// CarrierService represents a Shopify carrier service, an app provided
+// shipping rate provider.
+type CarrierService struct {
+	ID                 int64      `json:"id,omitempty"`
+	Name               string     `json:"name,omitempty"`
+	Active             *bool      `json:"active,omitempty"`
+	ServiceDiscovery   *bool      `json:"service_discovery,omitempty"`
+	CarrierServiceType string     `json:"carrier_service_type,omitempty"`
+	CallbackURL        string     `json:"callback_url,omitempty"`
+	Format             string     `json:"format,omitempty"`
+	AdminGraphqlAPIID  string     `json:"admin_graphql_api_id,omitempty"`
+	CreatedAt          *time.Time `json:"created_at,omitempty"`
+	UpdatedAt          *time.Time `json:"updated_at,omitempty"`
+}
+
+// CarrierServiceResource represents the result from the carrier_services/X.json endpoint
+type CarrierServiceResource struct {
+	CarrierService *CarrierService `json:"carrier_service"`
+}
+
+// CarrierServicesResource represents the result from the carrier_services.json endpoint
+type CarrierServicesResource struct {
+	CarrierServices []CarrierService `json:"carrier_services"`
+}
+
+// List carrier services
+func (s *CarrierServiceServiceOp) List() ([]CarrierService, error) {
+	path := fmt.Sprintf("%s.json", carrierServicesBasePath)
+	return listResource[CarrierService](s.client, path, "carrier_services", nil)
+}
+
+// Get individual carrier service
+func (s *CarrierServiceServiceOp) Get(carrierServiceID int64) (*CarrierService, error) {
+	path := fmt.Sprintf("%s/%d.json", carrierServicesBasePath, carrierServiceID)
+	return getResource[CarrierService](s.client, path, "carrier_service", nil)
+}
+
+// Create a new carrier service
+func (s *CarrierServiceServiceOp) Create(carrierService CarrierService) (*CarrierService, error) {
+	path := fmt.Sprintf("%s.json", carrierServicesBasePath)
+	return createResource(s.client, path, "carrier_service", carrierService)
+}
+
+// Update an existing carrier service
+func (s *CarrierServiceServiceOp) Update(carrierService CarrierService) (*CarrierService, error) {
+	path := fmt.Sprintf("%s/%d.json", carrierServicesBasePath, carrierService.ID)
+	return updateResource(s.client, path, "carrier_service", carrierService)
+}
+
+// Delete an existing carrier service
+func (s *CarrierServiceServiceOp) Delete(carrierServiceID int64) error {
+	return s.client.Delete(fmt.Sprintf("%s/%d.json", carrierServicesBasePath, carrierServiceID))
+}
@@ -0,0 +1,112 @@
+package goshopify
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func carrierServiceTests(t *testing.T, carrierService CarrierService) {
+	expectedID := int64(1036894960)
+	if carrierService.ID != expectedID {
+		t.Errorf("CarrierService.ID returned %+v, expected %+v", carrierService.ID, expectedID)
+	}
+
+	expectedName := "Shipping Rate Provider"
+	if carrierService.Name != expectedName {
+		t.Errorf("CarrierService.Name returned %+v, expected %+v", carrierService.Name, expectedName)
+	}
+
+	if carrierService.Active == nil || !*carrierService.Active {
+		t.Errorf("CarrierService.Active returned %+v, expected true", carrierService.Active)
+	}
+
+	expectedURL := "http://shippingrateprovider.com/"
+	if carrierService.CallbackURL != expectedURL {
+		t.Errorf("CarrierService.CallbackURL returned %+v, expected %+v", carrierService.CallbackURL, expectedURL)
+	}
+}
+
+func TestCarrierServiceList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/carrier_services.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("carrier_services.json")))
+
+	carrierServices, err := client.CarrierService.List()
+	if err != nil {
+		t.Errorf("CarrierService.List returned error: %v", err)
+	}
+
+	if len(carrierServices) != 1 {
+		t.Fatalf("CarrierService.List got %v carrier services, expected: 1", len(carrierServices))
+	}
+
+	carrierServiceTests(t, carrierServices[0])
+}
+
+func TestCarrierServiceGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/carrier_services/1036894960.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("carrier_service.json")))
+
+	carrierService, err := client.CarrierService.Get(1036894960)
+	if err != nil {
+		t.Errorf("CarrierService.Get returned error: %v", err)
+	}
+
+	carrierServiceTests(t, *carrierService)
+}
+
+func TestCarrierServiceCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/carrier_services.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("carrier_service.json")))
+
+	carrierService, err := client.CarrierService.Create(CarrierService{
+		Name:        "Shipping Rate Provider",
+		CallbackURL: "http://shippingrateprovider.com/",
+	})
+	if err != nil {
+		t.Errorf("CarrierService.Create returned error: %v", err)
+	}
+
+	carrierServiceTests(t, *carrierService)
+}
+
+func TestCarrierServiceUpdate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/carrier_services/1036894960.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("carrier_service.json")))
+
+	carrierService, err := client.CarrierService.Update(CarrierService{
+		ID:   1036894960,
+		Name: "Shipping Rate Provider",
+	})
+	if err != nil {
+		t.Errorf("CarrierService.Update returned error: %v", err)
+	}
+
+	carrierServiceTests(t, *carrierService)
+}
+
+func TestCarrierServiceDelete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("https://fooshop.myshopify.com/%s/carrier_services/1036894960.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, "{}"))
+
+	err := client.CarrierService.Delete(1036894960)
+	if err != nil {
+		t.Errorf("CarrierService.Delete returned error: %v", err)
+	}
+}
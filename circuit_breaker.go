@@ -0,0 +1,308 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without sending the request while the circuit
+// breaker of the client is open, see WithCircuitBreaker.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitState is the state of a circuit breaker.
+type CircuitState int
+
+const (
+	// CircuitClosed lets requests through, the normal state.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen fails requests with ErrCircuitOpen until the cooldown
+	// passed.
+	CircuitOpen
+	// CircuitHalfOpen lets a single probe request through after the
+	// cooldown, its outcome closes or opens the circuit again.
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// CircuitBreaker configures the circuit breaker of WithCircuitBreaker.
+// Network errors and 5xx responses count as failures, 429 responses are
+// ignored as the rate limit is handled by retrying, other responses count as
+// successes. The circuit opens when either threshold is reached.
+type CircuitBreaker struct {
+	// ConsecutiveFailures opens the circuit after that many failed requests
+	// in a row, 0 disables the threshold.
+	ConsecutiveFailures int
+
+	// FailureRate opens the circuit once the fraction, between 0 and 1, of
+	// failed requests within Window reaches it and at least MinRequests were
+	// made. 0 disables the threshold, which needs a Window.
+	FailureRate float64
+	MinRequests int
+	Window      time.Duration
+
+	// Cooldown is how long the circuit stays open before a probe request is
+	// let through.
+	Cooldown time.Duration
+
+	// RetryBudget limits the retries within Window to that fraction of the
+	// requests, e.g. 0.2 for one retry per five requests, so retries can't
+	// multiply the load on a failing shop. A single retry per window is
+	// always allowed and retries after a 429 response don't count. 0
+	// disables the budget, which needs a Window.
+	RetryBudget float64
+
+	// OnStateChange is called whenever the circuit changes state.
+	OnStateChange func(from, to CircuitState)
+}
+
+// DefaultCircuitBreaker returns a breaker opening after 5 consecutive
+// failures or half of the requests of a minute failing, staying open for 30
+// seconds and allowing a retry per five requests.
+func DefaultCircuitBreaker() CircuitBreaker {
+	return CircuitBreaker{
+		ConsecutiveFailures: 5,
+		FailureRate:         0.5,
+		MinRequests:         20,
+		Window:              time.Minute,
+		Cooldown:            30 * time.Second,
+		RetryBudget:         0.2,
+	}
+}
+
+// attemptOutcome is what an attempt tells the breaker about the shop.
+type attemptOutcome int
+
+const (
+	attemptSucceeded attemptOutcome = iota
+	attemptFailed
+	// the attempt says nothing about the shop, e.g. it was canceled
+	attemptIgnored
+)
+
+// breakerEvent is an attempt, flagged when it was a retry, or an outcome,
+// flagged when it failed.
+type breakerEvent struct {
+	at   time.Time
+	flag bool
+}
+
+// circuitBreaker is the state of a CircuitBreaker shared by the goroutines
+// using the client.
+type circuitBreaker struct {
+	config CircuitBreaker
+	now    func() time.Time
+
+	mu          sync.Mutex
+	state       CircuitState
+	openedAt    time.Time
+	probing     bool
+	consecutive int
+	// attempts and outcomes within the window, oldest first
+	attempts []breakerEvent
+	outcomes []breakerEvent
+}
+
+func newCircuitBreaker(config CircuitBreaker) *circuitBreaker {
+	return &circuitBreaker{config: config, now: time.Now}
+}
+
+// State returns the current state, an open circuit whose cooldown passed is
+// reported as half-open.
+func (b *circuitBreaker) State() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == CircuitOpen && b.now().Sub(b.openedAt) >= b.config.Cooldown {
+		return CircuitHalfOpen
+	}
+	return b.state
+}
+
+// allow reports whether an attempt may be sent, returning ErrCircuitOpen
+// when it may not. Every allowed attempt must be followed by record.
+func (b *circuitBreaker) allow(retry bool) error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	now := b.now()
+	from := b.state
+	switch b.state {
+	case CircuitOpen:
+		if now.Sub(b.openedAt) < b.config.Cooldown {
+			b.mu.Unlock()
+			return ErrCircuitOpen
+		}
+		b.state = CircuitHalfOpen
+		b.probing = true
+	case CircuitHalfOpen:
+		if b.probing {
+			b.mu.Unlock()
+			return ErrCircuitOpen
+		}
+		b.probing = true
+	}
+	b.attempts = append(pruneEvents(b.attempts, now, b.config.Window), breakerEvent{at: now, flag: retry})
+	to := b.state
+	b.mu.Unlock()
+
+	b.notify(from, to)
+	return nil
+}
+
+// allowRetry reports whether a failed attempt may be retried: the circuit is
+// closed and the retry budget isn't spent.
+func (b *circuitBreaker) allowRetry() bool {
+	if b == nil {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state != CircuitClosed {
+		return false
+	}
+	if b.config.RetryBudget <= 0 {
+		return true
+	}
+
+	b.attempts = pruneEvents(b.attempts, b.now(), b.config.Window)
+	requests, retries := 0, 0
+	for _, e := range b.attempts {
+		if e.flag {
+			retries++
+		} else {
+			requests++
+		}
+	}
+	return retries < 1 || float64(retries+1) <= b.config.RetryBudget*float64(requests)
+}
+
+// record updates the breaker with the outcome of the latest allowed attempt.
+func (b *circuitBreaker) record(outcome attemptOutcome) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	now := b.now()
+	from := b.state
+	b.probing = false
+
+	if outcome == attemptIgnored {
+		b.mu.Unlock()
+		return
+	}
+
+	failed := outcome == attemptFailed
+	b.outcomes = append(pruneEvents(b.outcomes, now, b.config.Window), breakerEvent{at: now, flag: failed})
+
+	switch {
+	case b.state == CircuitHalfOpen && failed:
+		b.open(now)
+	case b.state == CircuitHalfOpen:
+		b.state = CircuitClosed
+		b.consecutive = 0
+		b.outcomes = nil
+	case failed:
+		b.consecutive++
+		if b.tripped(now) {
+			b.open(now)
+		}
+	default:
+		b.consecutive = 0
+	}
+	to := b.state
+	b.mu.Unlock()
+
+	b.notify(from, to)
+}
+
+// tripped reports whether a threshold is reached, it must be called with the
+// lock held.
+func (b *circuitBreaker) tripped(now time.Time) bool {
+	if b.config.ConsecutiveFailures > 0 && b.consecutive >= b.config.ConsecutiveFailures {
+		return true
+	}
+	if b.config.FailureRate <= 0 {
+		return false
+	}
+
+	if len(b.outcomes) == 0 || len(b.outcomes) < b.config.MinRequests {
+		return false
+	}
+	failures := 0
+	for _, e := range b.outcomes {
+		if e.flag {
+			failures++
+		}
+	}
+	return float64(failures)/float64(len(b.outcomes)) >= b.config.FailureRate
+}
+
+// open opens the circuit, it must be called with the lock held.
+func (b *circuitBreaker) open(now time.Time) {
+	b.state = CircuitOpen
+	b.openedAt = now
+	b.consecutive = 0
+	b.outcomes = nil
+}
+
+// pruneEvents drops the events older than the window, all of them when there is
+// no window.
+func pruneEvents(events []breakerEvent, now time.Time, window time.Duration) []breakerEvent {
+	if window <= 0 {
+		return events[:0]
+	}
+	i := 0
+	for i < len(events) && now.Sub(events[i].at) > window {
+		i++
+	}
+	return events[i:]
+}
+
+func (b *circuitBreaker) notify(from, to CircuitState) {
+	if from != to && b.config.OnStateChange != nil {
+		b.config.OnStateChange(from, to)
+	}
+}
+
+// attemptOutcomeOf classifies the result of sending req.
+func attemptOutcomeOf(req *http.Request, resp *http.Response, err error) attemptOutcome {
+	if err != nil {
+		if req.Context().Err() != nil || errors.Is(err, context.Canceled) {
+			return attemptIgnored
+		}
+		return attemptFailed
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return attemptIgnored
+	}
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return attemptFailed
+	}
+	return attemptSucceeded
+}
+
+// CircuitState returns the state of the circuit breaker, CircuitClosed when
+// the client has none.
+func (c *Client) CircuitState() CircuitState {
+	if c.breaker == nil {
+		return CircuitClosed
+	}
+	return c.breaker.State()
+}
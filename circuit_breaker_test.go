@@ -0,0 +1,245 @@
+package goshopify
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+// fakeClock is a time source for circuit breakers that only moves when told.
+type fakeClock struct{ now time.Time }
+
+func (c *fakeClock) Now() time.Time          { return c.now }
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func TestCircuitBreakerConsecutiveFailures(t *testing.T) {
+	setup()
+	defer teardown()
+
+	clock := &fakeClock{now: time.Now()}
+	var changes []string
+	breaker := DefaultCircuitBreaker()
+	breaker.ConsecutiveFailures = 2
+	breaker.OnStateChange = func(from, to CircuitState) {
+		changes = append(changes, fmt.Sprintf("%s->%s", from, to))
+	}
+	WithCircuitBreaker(breaker)(client)
+	client.breaker.now = clock.Now
+
+	status := 500
+	calls := 0
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shop.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			calls++
+			return httpmock.NewStringResponse(status, `{"shop": {}}`), nil
+		})
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.Shop.Get(nil); err == nil {
+			t.Fatalf("Shop.Get returned no error for a 500")
+		}
+	}
+	if client.CircuitState() != CircuitOpen {
+		t.Errorf("CircuitState is %s after 2 failures, expected open", client.CircuitState())
+	}
+
+	_, err := client.Shop.Get(nil)
+	if !errors.Is(err, ErrCircuitOpen) || calls != 2 {
+		t.Errorf("Shop.Get returned %v after %d calls, expected ErrCircuitOpen without a call", err, calls)
+	}
+
+	clock.Advance(breaker.Cooldown)
+	status = 200
+	if _, err := client.Shop.Get(nil); err != nil {
+		t.Errorf("Shop.Get probe returned error: %v", err)
+	}
+
+	expected := []string{"closed->open", "open->half-open", "half-open->closed"}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("OnStateChange got %v, expected %v", changes, expected)
+	}
+}
+
+func TestCircuitBreakerIgnoresRateLimit(t *testing.T) {
+	setup()
+	defer teardown()
+
+	breaker := DefaultCircuitBreaker()
+	breaker.ConsecutiveFailures = 2
+	WithCircuitBreaker(breaker)(client)
+	WithRetry(0)(client)
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shop.json", client.pathPrefix),
+		createResponderWithHeaders(429, `{"errors": "Exceeded 2 calls per second for api client."}`, map[string]string{"Retry-After": "0"}))
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.Shop.Get(nil); errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("Shop.Get returned %v after %d rate limited requests", err, i)
+		}
+	}
+	if client.CircuitState() != CircuitClosed {
+		t.Errorf("CircuitState is %s after 429 responses, expected closed", client.CircuitState())
+	}
+}
+
+func TestCircuitBreakerRateLimitRetriesOutsideBudget(t *testing.T) {
+	setup()
+	defer teardown()
+
+	WithCircuitBreaker(DefaultCircuitBreaker())(client)
+	WithRetry(5)(client)
+
+	// the first attempt of every call is rate limited
+	calls := 0
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shop.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls%2 == 1 {
+				resp := httpmock.NewStringResponse(429, `{"errors": "Exceeded 2 calls per second for api client."}`)
+				resp.Header.Set("Retry-After", "0.001")
+				return resp, nil
+			}
+			return httpmock.NewStringResponse(200, `{"shop": {}}`), nil
+		})
+
+	for i := 0; i < 10; i++ {
+		if _, err := client.Shop.Get(nil); err != nil {
+			t.Fatalf("Shop.Get call %d returned error: %v", i+1, err)
+		}
+	}
+	if calls != 20 {
+		t.Errorf("Shop.Get made %d calls, expected 20", calls)
+	}
+}
+
+func TestCircuitBreakerFailedProbe(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	b := newCircuitBreaker(CircuitBreaker{ConsecutiveFailures: 1, Cooldown: time.Second})
+	b.now = clock.Now
+
+	b.allow(false)
+	b.record(attemptFailed)
+	clock.Advance(time.Second)
+
+	if err := b.allow(false); err != nil {
+		t.Fatalf("allow after the cooldown returned %v", err)
+	}
+	if err := b.allow(false); err != ErrCircuitOpen {
+		t.Errorf("allow during the probe returned %v, expected ErrCircuitOpen", err)
+	}
+	b.record(attemptFailed)
+	if b.State() != CircuitOpen {
+		t.Errorf("State after a failed probe is %s, expected open", b.State())
+	}
+}
+
+func TestCircuitBreakerFailureRate(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	b := newCircuitBreaker(CircuitBreaker{FailureRate: 0.5, MinRequests: 4, Window: time.Minute, Cooldown: time.Second})
+	b.now = clock.Now
+
+	outcomes := []attemptOutcome{attemptFailed, attemptSucceeded, attemptIgnored, attemptFailed}
+	for _, outcome := range outcomes {
+		b.allow(false)
+		b.record(outcome)
+	}
+	if b.State() != CircuitClosed {
+		t.Fatalf("State is %s below MinRequests, expected closed", b.State())
+	}
+
+	b.allow(false)
+	b.record(attemptSucceeded)
+	b.allow(false)
+	b.record(attemptFailed)
+	if b.State() != CircuitOpen {
+		t.Errorf("State is %s with 3 of 5 requests failed, expected open", b.State())
+	}
+}
+
+func TestCircuitBreakerFailureRateWindow(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	b := newCircuitBreaker(CircuitBreaker{FailureRate: 0.5, MinRequests: 2, Window: time.Minute, Cooldown: time.Second})
+	b.now = clock.Now
+
+	b.allow(false)
+	b.record(attemptFailed)
+	clock.Advance(2 * time.Minute)
+	b.allow(false)
+	b.record(attemptSucceeded)
+	b.allow(false)
+	b.record(attemptSucceeded)
+	b.allow(false)
+	b.record(attemptFailed)
+
+	// 2 of 4 requests failed, but the first one is outside the window
+	if b.State() != CircuitClosed {
+		t.Errorf("State is %s with 1 of 3 requests of the window failed, expected closed", b.State())
+	}
+}
+
+func TestCircuitBreakerRetryBudget(t *testing.T) {
+	setup()
+	defer teardown()
+
+	breaker := CircuitBreaker{Window: time.Minute, RetryBudget: 0.5}
+	WithCircuitBreaker(breaker)(client)
+	WithRetryPolicy(testRetryPolicy(5))(client)
+
+	calls := 0
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shop.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			calls++
+			return httpmock.NewStringResponse(502, `{"errors": "Bad Gateway"}`), nil
+		})
+
+	// a single retry is always allowed
+	if _, err := client.Shop.Get(nil); err == nil {
+		t.Fatalf("Shop.Get returned no error for a 502")
+	}
+	if calls != 2 {
+		t.Errorf("Shop.Get made %d calls, expected a single retry", calls)
+	}
+
+	// one retry for two requests spends the budget of 0.5
+	calls = 0
+	client.Shop.Get(nil)
+	if calls != 1 {
+		t.Errorf("Shop.Get made %d calls, expected 1 with a spent retry budget", calls)
+	}
+}
+
+func TestAttemptOutcomeOf(t *testing.T) {
+	req, _ := http.NewRequest("GET", "https://fooshop.myshopify.com/admin/shop.json", nil)
+
+	cases := []struct {
+		resp     *http.Response
+		err      error
+		expected attemptOutcome
+	}{
+		{&http.Response{StatusCode: 200}, nil, attemptSucceeded},
+		{&http.Response{StatusCode: 404}, nil, attemptSucceeded},
+		{&http.Response{StatusCode: 429}, nil, attemptIgnored},
+		{&http.Response{StatusCode: 503}, nil, attemptFailed},
+		{nil, timeoutError{}, attemptFailed},
+	}
+
+	for _, c := range cases {
+		if actual := attemptOutcomeOf(req, c.resp, c.err); actual != c.expected {
+			t.Errorf("attemptOutcomeOf(%v, %v) = %d, expected %d", c.resp, c.err, actual, c.expected)
+		}
+	}
+}
+
+func TestCircuitStateWithoutBreaker(t *testing.T) {
+	setup()
+	defer teardown()
+
+	if client.CircuitState() != CircuitClosed {
+		t.Errorf("CircuitState is %s without a breaker, expected closed", client.CircuitState())
+	}
+}
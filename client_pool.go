@@ -0,0 +1,98 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrNoToken is returned by a TokenSource for shops it has no access token
+// for, e.g. because the app was uninstalled.
+var ErrNoToken = errors.New("no access token for shop")
+
+// TokenSource looks up the access token of a shop, e.g. from the database the
+// tokens are stored in after the oauth flow.
+type TokenSource interface {
+	Token(ctx context.Context, shop string) (string, error)
+}
+
+// TokenSourceFunc adapts a function to a TokenSource.
+type TokenSourceFunc func(ctx context.Context, shop string) (string, error)
+
+// Token calls f(ctx, shop).
+func (f TokenSourceFunc) Token(ctx context.Context, shop string) (string, error) {
+	return f(ctx, shop)
+}
+
+// ClientPool hands out a client per shop for apps installed on many shops.
+// Clients are created on first use with the token from the TokenSource and
+// kept, so the rate limit state of a shop lives as long as the pool. All
+// clients share one http.Client and thereby its connection pool.
+type ClientPool struct {
+	app    App
+	tokens TokenSource
+	opts   []Option
+
+	mu      sync.Mutex
+	clients map[string]*Client
+}
+
+// NewClientPool returns a pool creating clients for the app with the tokens
+// from tokens and the given options, which apply to every client. Pass
+// WithHTTPClient or WithTransport to share a custom http client.
+func NewClientPool(app App, tokens TokenSource, opts ...Option) *ClientPool {
+	shared := WithHTTPClient(&http.Client{Timeout: time.Second * defaultHttpTimeout})
+	return &ClientPool{
+		app:     app,
+		tokens:  tokens,
+		opts:    append([]Option{shared}, opts...),
+		clients: map[string]*Client{},
+	}
+}
+
+// Client returns the client of a shop, given as its myshopify domain or
+// short name, creating it when the pool has none yet.
+func (p *ClientPool) Client(ctx context.Context, shop string) (*Client, error) {
+	shop = ShopFullName(shop)
+	if !ValidShopDomain(shop) {
+		return nil, ErrInvalidShopDomain
+	}
+
+	p.mu.Lock()
+	client, ok := p.clients[shop]
+	p.mu.Unlock()
+	if ok {
+		return client, nil
+	}
+
+	// look the token up without holding the lock, other shops shouldn't
+	// wait for a slow token source
+	token, err := p.tokens.Token(ctx, shop)
+	if err != nil {
+		return nil, err
+	}
+	if token == "" {
+		return nil, ErrNoToken
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if client, ok := p.clients[shop]; ok {
+		// created by another goroutine in the meantime
+		return client, nil
+	}
+	client = NewClient(p.app, shop, token, p.opts...)
+	p.clients[shop] = client
+	return client, nil
+}
+
+// Invalidate drops the client of a shop, the next call to Client looks up
+// the token again. Call it when the token of a shop changed or the app was
+// uninstalled.
+func (p *ClientPool) Invalidate(shop string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.clients, ShopFullName(shop))
+}
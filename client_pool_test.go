@@ -0,0 +1,95 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestClientPool(t *testing.T) {
+	lookups := map[string]int{}
+	tokens := TokenSourceFunc(func(ctx context.Context, shop string) (string, error) {
+		lookups[shop]++
+		switch shop {
+		case "fooshop.myshopify.com":
+			return "footoken", nil
+		case "barshop.myshopify.com":
+			return "bartoken", nil
+		case "broken.myshopify.com":
+			return "", errors.New("database down")
+		}
+		return "", ErrNoToken
+	})
+
+	pool := NewClientPool(App{ApiKey: "apikey"}, tokens, WithVersion(testApiVersion), WithRetry(2))
+	ctx := context.Background()
+
+	foo, err := pool.Client(ctx, "fooshop")
+	if err != nil {
+		t.Fatalf("ClientPool.Client returned error: %v", err)
+	}
+	if foo.token != "footoken" || foo.baseURL.Host != "fooshop.myshopify.com" {
+		t.Errorf("ClientPool.Client returned a client for %s with token %s", foo.baseURL.Host, foo.token)
+	}
+	if foo.apiVersion != testApiVersion || foo.retries != 2 {
+		t.Errorf("ClientPool.Client did not apply the options")
+	}
+
+	again, _ := pool.Client(ctx, "fooshop.myshopify.com")
+	if again != foo {
+		t.Error("ClientPool.Client returned a new client for a shop it has a client for")
+	}
+	if lookups["fooshop.myshopify.com"] != 1 {
+		t.Errorf("the token of fooshop was looked up %d times, expected once", lookups["fooshop.myshopify.com"])
+	}
+
+	bar, err := pool.Client(ctx, "barshop")
+	if err != nil {
+		t.Fatalf("ClientPool.Client returned error: %v", err)
+	}
+	if bar.token != "bartoken" {
+		t.Errorf("ClientPool.Client returned a client with token %s, expected bartoken", bar.token)
+	}
+	if bar.Client != foo.Client {
+		t.Error("the clients of the pool don't share their http client")
+	}
+
+	pool.Invalidate("fooshop")
+	renewed, _ := pool.Client(ctx, "fooshop")
+	if renewed == foo || lookups["fooshop.myshopify.com"] != 2 {
+		t.Error("ClientPool.Client did not create a new client after Invalidate")
+	}
+}
+
+func TestClientPoolErrors(t *testing.T) {
+	tokens := TokenSourceFunc(func(ctx context.Context, shop string) (string, error) {
+		switch shop {
+		case "broken.myshopify.com":
+			return "", errors.New("database down")
+		case "empty.myshopify.com":
+			return "", nil
+		}
+		return "", ErrNoToken
+	})
+	pool := NewClientPool(App{}, tokens)
+
+	cases := []struct {
+		shop     string
+		expected string
+	}{
+		{"evil.com/x", ErrInvalidShopDomain.Error()},
+		{"unknown", ErrNoToken.Error()},
+		{"empty", ErrNoToken.Error()},
+		{"broken", "database down"},
+	}
+
+	for _, c := range cases {
+		client, err := pool.Client(context.Background(), c.shop)
+		if err == nil || err.Error() != c.expected {
+			t.Errorf("ClientPool.Client(%q) returned %v, expected %s", c.shop, err, c.expected)
+		}
+		if client != nil {
+			t.Errorf("ClientPool.Client(%q) returned a client", c.shop)
+		}
+	}
+}
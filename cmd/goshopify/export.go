@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	goshopify "github.com/myhelix/go-shopify"
+	"github.com/myhelix/go-shopify/export"
+)
+
+// runExport streams every page of a resource to stdout, see the export
+// package.
+func runExport(client *goshopify.Client, format string, args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("export requires a resource, one of: %s", strings.Join(resourceNames(), ", "))
+	}
+
+	r, err := lookupResource(args[0])
+	if err != nil {
+		return err
+	}
+
+	query, err := parseQuery(args[1:])
+	if err != nil {
+		return err
+	}
+
+	resource := export.NewResource(r.path, reflect.Zero(r.model).Interface())
+	_, err = export.Export(context.Background(), client, resource, stdout, export.Options{
+		Format: export.Format(format),
+		Query:  query,
+	})
+	return err
+}
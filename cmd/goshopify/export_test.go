@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func registerProductPages() {
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/products.json", baseURL),
+		func(req *http.Request) (*http.Response, error) {
+			if req.URL.Query().Get("page_info") == "abc" {
+				return httpmock.NewStringResponse(200, `{"products": [{"id": 2, "title": "bar, baz"}]}`), nil
+			}
+			resp := httpmock.NewStringResponse(200, `{"products": [{"id": 1, "title": "foo", "tags": "a"}]}`)
+			resp.Header.Set("Link", fmt.Sprintf(`<%s/products.json?limit=1&page_info=abc>; rel="next"`, baseURL))
+			return resp, nil
+		})
+}
+
+func TestRunExportJSONL(t *testing.T) {
+	setup()
+	defer teardown()
+	registerProductPages()
+
+	out := runCommand(t, "", "export", "products", "limit=1")
+	expected := `{"id":1,"title":"foo","tags":"a","image":{}}` + "\n" + `{"id":2,"title":"bar, baz","image":{}}` + "\n"
+	if out != expected {
+		t.Errorf("export products returned %q, expected %q", out, expected)
+	}
+
+	if calls := httpmock.GetTotalCallCount(); calls != 2 {
+		t.Errorf("export products sent %d requests, expected 2", calls)
+	}
+}
+
+func TestRunExportCSV(t *testing.T) {
+	setup()
+	defer teardown()
+	registerProductPages()
+
+	out := runCommand(t, "", "-format", "csv", "export", "products", "limit=1", "fields=id,title,tags")
+	expected := "id,title,tags\n1,foo,a\n2,\"bar, baz\",\n"
+	if out != expected {
+		t.Errorf("export products returned %q, expected %q", out, expected)
+	}
+}
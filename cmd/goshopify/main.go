@@ -0,0 +1,311 @@
+// Command goshopify is a small command line client for ad-hoc Admin API
+// operations. It is built on the goshopify package and goes through the same
+// client code paths, so it doubles as living documentation and a debugging
+// aid.
+//
+// Usage:
+//
+//	goshopify [flags] list <resource> [key=value ...]
+//	goshopify [flags] count <resource> [key=value ...]
+//	goshopify [flags] get <resource> <id>
+//	goshopify [flags] create <resource> < resource.json
+//	goshopify [flags] update <resource> <id> < resource.json
+//	goshopify [flags] delete <resource> <id>
+//	goshopify [flags] export <resource> [key=value ...]
+//	goshopify [flags] graphql [name=value ...] < query.graphql
+//	goshopify [flags] webhook <topic> <address>
+//	goshopify [flags] raw <method> <path> [< body.json]
+//	goshopify resources
+//
+// The shop and access token are read from the -shop and -token flags or the
+// SHOPIFY_SHOP and SHOPIFY_TOKEN environment variables. Results are written to
+// stdout as JSON, export streams every page of a resource as JSONL or, with
+// -format csv, as CSV.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	goshopify "github.com/myhelix/go-shopify"
+)
+
+var errUsage = errors.New("usage: goshopify [flags] <list|count|get|create|update|delete|export|graphql|webhook|raw|resources> [args]")
+
+// newClient is overridden in tests to point the client at a mock transport.
+var newClient = func(shop, token string, opts ...goshopify.Option) *goshopify.Client {
+	return goshopify.NewClient(goshopify.App{}, shop, token, opts...)
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdin io.Reader, stdout io.Writer) error {
+	flags := flag.NewFlagSet("goshopify", flag.ContinueOnError)
+	shop := flags.String("shop", os.Getenv("SHOPIFY_SHOP"), "shop name, e.g. theshop or theshop.myshopify.com")
+	token := flags.String("token", os.Getenv("SHOPIFY_TOKEN"), "Admin API access token")
+	version := flags.String("version", os.Getenv("SHOPIFY_API_VERSION"), "Admin API version, e.g. 2021-01")
+	retries := flags.Int("retries", 3, "number of attempts for rate limited requests")
+	format := flags.String("format", "jsonl", "export format, jsonl or csv")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	args = flags.Args()
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	command, args := args[0], args[1:]
+	if command == "resources" {
+		return writeJSON(stdout, resourceNames())
+	}
+
+	if *shop == "" || *token == "" {
+		return errors.New("a shop and token are required, see -shop and -token")
+	}
+
+	opts := []goshopify.Option{goshopify.WithRetry(*retries)}
+	if *version != "" {
+		opts = append(opts, goshopify.WithVersion(*version))
+	}
+	client := newClient(*shop, *token, opts...)
+
+	switch command {
+	case "raw":
+		return runRaw(client, args, stdin, stdout)
+	case "graphql":
+		return runGraphQL(client, args, stdin, stdout)
+	case "webhook":
+		return runWebhook(client, args, stdout)
+	case "export":
+		return runExport(client, *format, args, stdout)
+	case "list", "count", "get", "create", "update", "delete":
+		return runResource(client, command, args, stdin, stdout)
+	}
+
+	return errUsage
+}
+
+func runResource(client *goshopify.Client, command string, args []string, stdin io.Reader, stdout io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%s requires a resource, one of: %s", command, strings.Join(resourceNames(), ", "))
+	}
+
+	r, err := lookupResource(args[0])
+	if err != nil {
+		return err
+	}
+	args = args[1:]
+
+	switch command {
+	case "list":
+		path, err := withQuery(fmt.Sprintf("%s.json", r.path), args)
+		if err != nil {
+			return err
+		}
+		list := r.newList()
+		if err := client.Get(path, list.Interface(), nil); err != nil {
+			return err
+		}
+		return writeJSON(stdout, list.Elem().Field(0).Interface())
+
+	case "count":
+		path, err := withQuery(fmt.Sprintf("%s/count.json", r.path), args)
+		if err != nil {
+			return err
+		}
+		count, err := client.Count(path, nil)
+		if err != nil {
+			return err
+		}
+		return writeJSON(stdout, map[string]int{"count": count})
+
+	case "get":
+		id, err := parseID(args)
+		if err != nil {
+			return err
+		}
+		single := r.newSingle()
+		if err := client.Get(fmt.Sprintf("%s/%d.json", r.path, id), single.Interface(), nil); err != nil {
+			return err
+		}
+		return writeJSON(stdout, single.Elem().Field(0).Interface())
+
+	case "create", "update":
+		path := fmt.Sprintf("%s.json", r.path)
+		if command == "update" {
+			id, err := parseID(args)
+			if err != nil {
+				return err
+			}
+			path = fmt.Sprintf("%s/%d.json", r.path, id)
+		}
+
+		data := r.newSingle()
+		item := reflect.New(r.model)
+		if err := json.NewDecoder(stdin).Decode(item.Interface()); err != nil {
+			return fmt.Errorf("could not decode %s from stdin: %v", r.singular, err)
+		}
+		data.Elem().Field(0).Set(item)
+
+		single := r.newSingle()
+		if command == "create" {
+			err = client.Post(path, data.Interface(), single.Interface())
+		} else {
+			err = client.Put(path, data.Interface(), single.Interface())
+		}
+		if err != nil {
+			return err
+		}
+		return writeJSON(stdout, single.Elem().Field(0).Interface())
+
+	case "delete":
+		id, err := parseID(args)
+		if err != nil {
+			return err
+		}
+		return client.Delete(fmt.Sprintf("%s/%d.json", r.path, id))
+	}
+
+	return errUsage
+}
+
+func runRaw(client *goshopify.Client, args []string, stdin io.Reader, stdout io.Writer) error {
+	if len(args) != 2 {
+		return errors.New("raw requires a method and a path, e.g. raw GET shop.json")
+	}
+
+	method, path := strings.ToUpper(args[0]), args[1]
+
+	var data interface{}
+	if method == "POST" || method == "PUT" {
+		body, err := ioutil.ReadAll(stdin)
+		if err != nil {
+			return err
+		}
+		if len(body) > 0 {
+			data = json.RawMessage(body)
+		}
+	}
+
+	// Shopify responds to deletes with an empty body
+	var out interface{}
+	var resource interface{} = &out
+	if method == "DELETE" {
+		resource = nil
+	}
+
+	if err := client.CreateAndDo(method, path, data, nil, resource); err != nil {
+		return err
+	}
+	if out == nil {
+		return nil
+	}
+	return writeJSON(stdout, out)
+}
+
+// runGraphQL runs the query read from stdin. The name=value arguments are its
+// variables, values that are valid JSON are passed as such, e.g. first=10,
+// anything else as a string.
+func runGraphQL(client *goshopify.Client, args []string, stdin io.Reader, stdout io.Writer) error {
+	query, err := ioutil.ReadAll(stdin)
+	if err != nil {
+		return err
+	}
+	if len(strings.TrimSpace(string(query))) == 0 {
+		return errors.New("graphql requires a query on stdin")
+	}
+
+	var vars map[string]interface{}
+	for _, arg := range args {
+		kv := strings.SplitN(arg, "=", 2)
+		if len(kv) != 2 {
+			return fmt.Errorf("invalid variable %q, expected name=value", arg)
+		}
+		if vars == nil {
+			vars = map[string]interface{}{}
+		}
+		var value interface{}
+		if json.Unmarshal([]byte(kv[1]), &value) != nil {
+			value = kv[1]
+		}
+		vars[kv[0]] = value
+	}
+
+	var out interface{}
+	if err := client.GraphQL.Query(string(query), vars, &out); err != nil {
+		return err
+	}
+	return writeJSON(stdout, out)
+}
+
+// runWebhook makes sure a JSON webhook for the topic is delivered to the
+// address, creating or updating the topic's webhook as needed.
+func runWebhook(client *goshopify.Client, args []string, stdout io.Writer) error {
+	if len(args) != 2 {
+		return errors.New("webhook requires a topic and an address, e.g. webhook orders/create https://example.com/hook")
+	}
+
+	results, err := client.EnsureWebhooks([]goshopify.Webhook{{Topic: args[0], Address: args[1], Format: "json"}})
+	if err != nil {
+		return err
+	}
+	return writeJSON(stdout, results)
+}
+
+// withQuery appends key=value arguments to the path as query parameters.
+func withQuery(path string, args []string) (string, error) {
+	if len(args) == 0 {
+		return path, nil
+	}
+
+	values, err := parseQuery(args)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s?%s", path, values.Encode()), nil
+}
+
+// parseQuery parses key=value arguments.
+func parseQuery(args []string) (url.Values, error) {
+	values := url.Values{}
+	for _, arg := range args {
+		kv := strings.SplitN(arg, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid query parameter %q, expected key=value", arg)
+		}
+		values.Add(kv[0], kv[1])
+	}
+	return values, nil
+}
+
+func parseID(args []string) (int64, error) {
+	if len(args) == 0 {
+		return 0, errors.New("an id is required")
+	}
+	id, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid id %q", args[0])
+	}
+	return id, nil
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
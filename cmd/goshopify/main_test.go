@@ -0,0 +1,167 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	goshopify "github.com/myhelix/go-shopify"
+)
+
+const baseURL = "https://fooshop.myshopify.com/admin/api/2021-01"
+
+func setup() {
+	newClient = func(shop, token string, opts ...goshopify.Option) *goshopify.Client {
+		c := goshopify.NewClient(goshopify.App{}, shop, token, opts...)
+		httpmock.ActivateNonDefault(c.Client)
+		return c
+	}
+}
+
+func teardown() {
+	httpmock.DeactivateAndReset()
+}
+
+func runCommand(t *testing.T, stdin string, args ...string) string {
+	t.Helper()
+	out := new(bytes.Buffer)
+	args = append([]string{"-shop", "fooshop", "-token", "abcd", "-version", "2021-01"}, args...)
+	if err := run(args, strings.NewReader(stdin), out); err != nil {
+		t.Fatalf("run(%v) returned error: %v", args, err)
+	}
+	return out.String()
+}
+
+func TestRunList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponderWithQuery("GET", fmt.Sprintf("%s/products.json", baseURL), "limit=1",
+		httpmock.NewStringResponder(200, `{"products": [{"id": 1, "title": "foo", "unknown": true}]}`))
+
+	out := runCommand(t, "", "list", "products", "limit=1")
+	expected := "[\n  {\n    \"id\": 1,\n    \"title\": \"foo\",\n    \"image\": {}\n  }\n]\n"
+	if out != expected {
+		t.Errorf("list products returned %q, expected %q", out, expected)
+	}
+}
+
+func TestRunGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/webhooks/1.json", baseURL),
+		httpmock.NewStringResponder(200, `{"webhook": {"id": 1, "topic": "orders/create"}}`))
+
+	out := runCommand(t, "", "get", "webhooks", "1")
+	if !strings.Contains(out, `"topic": "orders/create"`) {
+		t.Errorf("get webhooks returned %s, expected the webhook topic", out)
+	}
+}
+
+func TestRunCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var body string
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/redirects.json", baseURL),
+		func(req *http.Request) (*http.Response, error) {
+			b, _ := ioutil.ReadAll(req.Body)
+			body = string(b)
+			return httpmock.NewStringResponse(201, `{"redirect": {"id": 1, "path": "/foo", "target": "/bar"}}`), nil
+		})
+
+	out := runCommand(t, `{"path": "/foo", "target": "/bar"}`, "create", "redirects")
+
+	expectedBody := `{"redirect":{"path":"/foo","target":"/bar"}}`
+	if body != expectedBody {
+		t.Errorf("create redirects sent %s, expected %s", body, expectedBody)
+	}
+	if !strings.Contains(out, `"id": 1`) {
+		t.Errorf("create redirects returned %s, expected the created redirect", out)
+	}
+}
+
+func TestRunRaw(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/shop.json", baseURL),
+		httpmock.NewStringResponder(200, `{"shop": {"id": 1}}`))
+
+	out := runCommand(t, "", "raw", "get", "shop.json")
+	expected := "{\n  \"shop\": {\n    \"id\": 1\n  }\n}\n"
+	if out != expected {
+		t.Errorf("raw GET shop.json returned %q, expected %q", out, expected)
+	}
+}
+
+func TestRunGraphQL(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var body string
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/graphql.json", baseURL),
+		func(req *http.Request) (*http.Response, error) {
+			b, _ := ioutil.ReadAll(req.Body)
+			body = string(b)
+			return httpmock.NewStringResponse(200, `{"data": {"products": {"edges": []}}}`), nil
+		})
+
+	out := runCommand(t, "query($first: Int!, $q: String) { products(first: $first, query: $q) { edges { node { id } } } }",
+		"graphql", "first=10", "q=title:foo")
+
+	expectedBody := `{"query":"query($first: Int!, $q: String) { products(first: $first, query: $q) { edges { node { id } } } }","variables":{"first":10,"q":"title:foo"}}`
+	if body != expectedBody {
+		t.Errorf("graphql sent %s, expected %s", body, expectedBody)
+	}
+	expected := "{\n  \"products\": {\n    \"edges\": []\n  }\n}\n"
+	if out != expected {
+		t.Errorf("graphql returned %q, expected %q", out, expected)
+	}
+}
+
+func TestRunWebhook(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/webhooks.json", baseURL),
+		httpmock.NewStringResponder(200, `{"webhooks": []}`))
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/webhooks.json", baseURL),
+		httpmock.NewStringResponder(201, `{"webhook": {"id": 1, "topic": "orders/create", "address": "https://example.com/hook", "format": "json"}}`))
+
+	out := runCommand(t, "", "webhook", "orders/create", "https://example.com/hook")
+	if !strings.Contains(out, `"Status": "created"`) {
+		t.Errorf("webhook returned %s, expected a created webhook", out)
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	cases := [][]string{
+		{},
+		{"frobnicate"},
+		{"list"},
+		{"list", "unicorns"},
+		{"get", "products"},
+		{"get", "products", "abc"},
+		{"raw", "GET"},
+		{"graphql"},
+		{"webhook", "orders/create"},
+		{"export"},
+		{"-format", "xml", "export", "products"},
+	}
+
+	for _, args := range cases {
+		args = append([]string{"-shop", "fooshop", "-token", "abcd"}, args...)
+		if err := run(args, strings.NewReader(""), ioutil.Discard); err == nil {
+			t.Errorf("run(%v) expected an error", args)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	goshopify "github.com/myhelix/go-shopify"
+)
+
+// resource describes a top level REST resource modelled by the library.
+type resource struct {
+	// path is the plural path segment, also used as the key of list responses
+	path string
+	// singular is the key single resource requests and responses are wrapped in
+	singular string
+	// model is the struct the resource is decoded into
+	model reflect.Type
+}
+
+var resources = map[string]resource{}
+
+func register(path, singular string, model interface{}) {
+	resources[path] = resource{path: path, singular: singular, model: reflect.TypeOf(model)}
+}
+
+func init() {
+	register("application_charges", "application_charge", goshopify.ApplicationCharge{})
+	register("blogs", "blog", goshopify.Blog{})
+	register("carrier_services", "carrier_service", goshopify.CarrierService{})
+	register("collects", "collect", goshopify.Collect{})
+	register("custom_collections", "custom_collection", goshopify.CustomCollection{})
+	register("customers", "customer", goshopify.Customer{})
+	register("draft_orders", "draft_order", goshopify.DraftOrder{})
+	register("gift_cards", "gift_card", goshopify.GiftCard{})
+	register("inventory_items", "inventory_item", goshopify.InventoryItem{})
+	register("inventory_levels", "inventory_level", goshopify.InventoryLevel{})
+	register("locations", "location", goshopify.Location{})
+	register("metafields", "metafield", goshopify.Metafield{})
+	register("orders", "order", goshopify.Order{})
+	register("pages", "page", goshopify.Page{})
+	register("price_rules", "price_rule", goshopify.PriceRule{})
+	register("products", "product", goshopify.Product{})
+	register("recurring_application_charges", "recurring_application_charge", goshopify.RecurringApplicationCharge{})
+	register("redirects", "redirect", goshopify.Redirect{})
+	register("script_tags", "script_tag", goshopify.ScriptTag{})
+	register("smart_collections", "smart_collection", goshopify.SmartCollection{})
+	register("themes", "theme", goshopify.Theme{})
+	register("variants", "variant", goshopify.Variant{})
+	register("webhooks", "webhook", goshopify.Webhook{})
+}
+
+func lookupResource(name string) (resource, error) {
+	r, ok := resources[name]
+	if !ok {
+		return r, fmt.Errorf("unknown resource %q, expected one of: %s", name, strings.Join(resourceNames(), ", "))
+	}
+	return r, nil
+}
+
+func resourceNames() []string {
+	names := make([]string, 0, len(resources))
+	for name := range resources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// newList returns a pointer to a wrapper struct for list responses, e.g.
+// struct{ Products []Product `json:"products"` }.
+func (r resource) newList() reflect.Value {
+	return reflect.New(reflect.StructOf([]reflect.StructField{{
+		Name: "Items",
+		Type: reflect.SliceOf(r.model),
+		Tag:  reflect.StructTag(fmt.Sprintf(`json:"%s"`, r.path)),
+	}}))
+}
+
+// newSingle returns a pointer to a wrapper struct for single resource
+// requests and responses, e.g. struct{ Product *Product `json:"product"` }.
+func (r resource) newSingle() reflect.Value {
+	return reflect.New(reflect.StructOf([]reflect.StructField{{
+		Name: "Item",
+		Type: reflect.PtrTo(r.model),
+		Tag:  reflect.StructTag(fmt.Sprintf(`json:"%s"`, r.singular)),
+	}}))
+}
@@ -46,9 +46,7 @@ type CollectsResource struct {
 // List collects
 func (s *CollectServiceOp) List(options interface{}) ([]Collect, error) {
 	path := fmt.Sprintf("%s.json", collectsBasePath)
-	resource := new(CollectsResource)
-	err := s.client.Get(path, resource, options)
-	return resource.Collects, err
+	return listResource[Collect](s.client, path, "collects", options)
 }
 
 // Count collects
@@ -2,7 +2,6 @@ package goshopify
 
 import (
 	"fmt"
-	"net/http"
 	"time"
 )
 
@@ -45,9 +44,7 @@ type CollectionResource struct {
 // Get individual collection
 func (s *CollectionServiceOp) Get(collectionID int64, options interface{}) (*Collection, error) {
 	path := fmt.Sprintf("%s/%d.json", collectionsBasePath, collectionID)
-	resource := new(CollectionResource)
-	err := s.client.Get(path, resource, options)
-	return resource.Collection, err
+	return getResource[Collection](s.client, path, "collection", options)
 }
 
 // List products for a collection
@@ -62,21 +59,5 @@ func (s *CollectionServiceOp) ListProducts(collectionID int64, options interface
 // List products for a collection and return pagination to retrieve next/previous results.
 func (s *CollectionServiceOp) ListProductsWithPagination(collectionID int64, options interface{}) ([]Product, *Pagination, error) {
 	path := fmt.Sprintf("%s/%d/products.json", collectionsBasePath, collectionID)
-	resource := new(ProductsResource)
-	headers := http.Header{}
-
-	headers, err := s.client.createAndDoGetHeaders("GET", path, nil, options, resource)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	// Extract pagination info from header
-	linkHeader := headers.Get("Link")
-
-	pagination, err := extractPagination(linkHeader)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	return resource.Products, pagination, nil
+	return listResourceWithPagination[Product](s.client, path, "products", options)
 }
@@ -144,7 +144,7 @@ func TestCollectionListProducts(t *testing.T) {
 			UpdatedAt:      &updatedAt,
 			PublishedAt:    &publishedAt,
 			PublishedScope: "web",
-			Tags:           "Best",
+			Tags:           Tags{"Best"},
 			Options: []ProductOption{
 				{
 					ID:        6519940513924,
@@ -194,7 +194,7 @@ func TestCollectionListProductsError(t *testing.T) {
 		t.Errorf("Collection.ListProducts returned products %v, expected no products to be returned", products)
 	}
 
-	expectedError := fmt.Errorf("invalid character 's' looking for beginning of object key string")
+	expectedError := fmt.Errorf("GET /%s/collections/1/products.json: decoding response: invalid character 's' looking for beginning of object key string", client.pathPrefix)
 	if err == nil || err.Error() != expectedError.Error() {
 		t.Errorf("Collection.ListProducts err returned %v, expected %v", err, expectedError)
 	}
@@ -279,7 +279,7 @@ func TestListProductsWithPagination(t *testing.T) {
 			UpdatedAt:      &updatedAt,
 			PublishedAt:    &publishedAt,
 			PublishedScope: "web",
-			Tags:           "Best",
+			Tags:           Tags{"Best"},
 			Options: []ProductOption{
 				{
 					ID:        6519940513924,
@@ -327,6 +327,7 @@ func TestListProductsWithPagination(t *testing.T) {
 			IDs:          nil,
 		},
 		PreviousPageOptions: nil,
+		NextPageURL:         "http://valid.url?limit=1&page_info=pageInfoCode",
 	}
 	if !reflect.DeepEqual(page, expectedPage) {
 		t.Errorf("Collection.ListProductsWithPagination returned %+v, expected %+v", page, expectedPage)
@@ -355,7 +356,7 @@ func TestCollectionListProductsWithPaginationRequestError(t *testing.T) {
 		t.Errorf("Collection.ListProductsWithPagination returned pagination %v, expected nil", products)
 	}
 
-	expectedError := fmt.Errorf("invalid character 's' looking for beginning of object key string")
+	expectedError := fmt.Errorf("GET /%s/collections/1/products.json: decoding response: invalid character 's' looking for beginning of object key string", client.pathPrefix)
 	if err == nil || err.Error() != expectedError.Error() {
 		t.Errorf("Collection.ListProductsWithPagination err returned %v, expected %v", err, expectedError)
 	}
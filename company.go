@@ -0,0 +1,529 @@
+package goshopify
+
+import "time"
+
+// CompanyService is an interface for interfacing with the company endpoints
+// of the Shopify API.
+// See: https://shopify.dev/docs/apps/b2b
+type CompanyService interface {
+	List(string) ([]Company, error)
+	Get(string) (*Company, error)
+	Create(CompanyCreateInput) (*Company, error)
+	Update(string, CompanyInput) (*Company, error)
+	Delete(string) error
+	CreateLocation(string, CompanyLocationInput) (*CompanyLocation, error)
+	DeleteLocation(string) error
+	CreateContact(string, CompanyContactInput) (*CompanyContact, error)
+	AssignCustomer(string, int64) (*CompanyContact, error)
+	DeleteContact(string) error
+	AssignRole(contactID, roleID, locationID string) (*CompanyContactRoleAssignment, error)
+	RevokeRole(contactID, assignmentID string) error
+}
+
+// CompanyServiceOp handles communication with the company related GraphQL
+// queries and mutations.
+type CompanyServiceOp struct {
+	client *Client
+}
+
+// Company represents a business that buys from the shop. ContactRoles are the
+// roles its contacts can be assigned at its locations, e.g. "Location admin"
+// and "Ordering only".
+type Company struct {
+	ID           string               `json:"id"`
+	Name         string               `json:"name"`
+	ExternalID   string               `json:"externalId,omitempty"`
+	Note         string               `json:"note,omitempty"`
+	Locations    []CompanyLocation    `json:"locations,omitempty"`
+	Contacts     []CompanyContact     `json:"contacts,omitempty"`
+	ContactRoles []CompanyContactRole `json:"contactRoles,omitempty"`
+	CreatedAt    *time.Time           `json:"createdAt,omitempty"`
+	UpdatedAt    *time.Time           `json:"updatedAt,omitempty"`
+}
+
+// CompanyLocation represents a branch of a company that orders are placed
+// for and shipped to.
+type CompanyLocation struct {
+	ID              string          `json:"id"`
+	Name            string          `json:"name"`
+	ExternalID      string          `json:"externalId,omitempty"`
+	Phone           string          `json:"phone,omitempty"`
+	Locale          string          `json:"locale,omitempty"`
+	ShippingAddress *CompanyAddress `json:"shippingAddress,omitempty"`
+	BillingAddress  *CompanyAddress `json:"billingAddress,omitempty"`
+}
+
+// CompanyAddress is the shipping or billing address of a company location.
+type CompanyAddress struct {
+	Recipient   string `json:"recipient,omitempty"`
+	Address1    string `json:"address1,omitempty"`
+	Address2    string `json:"address2,omitempty"`
+	City        string `json:"city,omitempty"`
+	Zip         string `json:"zip,omitempty"`
+	CountryCode string `json:"countryCode,omitempty"`
+	ZoneCode    string `json:"zoneCode,omitempty"`
+	Phone       string `json:"phone,omitempty"`
+}
+
+// CompanyContact represents a customer buying on behalf of a company.
+type CompanyContact struct {
+	ID              string                         `json:"id"`
+	CustomerID      string                         `json:"customerId,omitempty"`
+	Title           string                         `json:"title,omitempty"`
+	Locale          string                         `json:"locale,omitempty"`
+	IsMainContact   bool                           `json:"isMainContact"`
+	RoleAssignments []CompanyContactRoleAssignment `json:"roleAssignments,omitempty"`
+}
+
+// CompanyContactRole is a role contacts can have at a company location.
+type CompanyContactRole struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// CompanyContactRoleAssignment grants a contact a role at a location.
+type CompanyContactRoleAssignment struct {
+	ID         string `json:"id"`
+	RoleID     string `json:"roleId"`
+	RoleName   string `json:"roleName,omitempty"`
+	LocationID string `json:"locationId"`
+}
+
+// CompanyInput describes the details of a company.
+type CompanyInput struct {
+	Name          string     `json:"name,omitempty"`
+	ExternalID    string     `json:"externalId,omitempty"`
+	Note          string     `json:"note,omitempty"`
+	CustomerSince *time.Time `json:"customerSince,omitempty"`
+}
+
+// CompanyLocationInput describes a company location. BillingSameAsShipping
+// uses the shipping address for billing too.
+type CompanyLocationInput struct {
+	Name                  string          `json:"name,omitempty"`
+	ExternalID            string          `json:"externalId,omitempty"`
+	Phone                 string          `json:"phone,omitempty"`
+	Locale                string          `json:"locale,omitempty"`
+	Note                  string          `json:"note,omitempty"`
+	ShippingAddress       *CompanyAddress `json:"shippingAddress,omitempty"`
+	BillingAddress        *CompanyAddress `json:"billingAddress,omitempty"`
+	BillingSameAsShipping bool            `json:"billingSameAsShipping,omitempty"`
+}
+
+// CompanyContactInput describes a company contact, a customer with the email
+// is created unless there is one already.
+type CompanyContactInput struct {
+	Email     string `json:"email,omitempty"`
+	FirstName string `json:"firstName,omitempty"`
+	LastName  string `json:"lastName,omitempty"`
+	Phone     string `json:"phone,omitempty"`
+	Title     string `json:"title,omitempty"`
+	Locale    string `json:"locale,omitempty"`
+}
+
+// CompanyCreateInput describes a company to create along with its first
+// location and main contact, which are optional.
+type CompanyCreateInput struct {
+	Company         CompanyInput          `json:"company"`
+	CompanyLocation *CompanyLocationInput `json:"companyLocation,omitempty"`
+	CompanyContact  *CompanyContactInput  `json:"companyContact,omitempty"`
+}
+
+// companyContactNode is the GraphQL representation of a contact, its
+// customer and role assignments are nested.
+type companyContactNode struct {
+	ID            string `json:"id"`
+	Title         string `json:"title"`
+	Locale        string `json:"locale"`
+	IsMainContact bool   `json:"isMainContact"`
+	Customer      *struct {
+		ID string `json:"id"`
+	} `json:"customer"`
+	RoleAssignments struct {
+		Edges []struct {
+			Node companyContactRoleAssignmentNode `json:"node"`
+		} `json:"edges"`
+	} `json:"roleAssignments"`
+}
+
+func (n *companyContactNode) contact() *CompanyContact {
+	if n == nil {
+		return nil
+	}
+	contact := &CompanyContact{
+		ID:            n.ID,
+		Title:         n.Title,
+		Locale:        n.Locale,
+		IsMainContact: n.IsMainContact,
+	}
+	if n.Customer != nil {
+		contact.CustomerID = n.Customer.ID
+	}
+	for i := range n.RoleAssignments.Edges {
+		contact.RoleAssignments = append(contact.RoleAssignments, *n.RoleAssignments.Edges[i].Node.assignment())
+	}
+	return contact
+}
+
+// companyContactRoleAssignmentNode is the GraphQL representation of a role
+// assignment.
+type companyContactRoleAssignmentNode struct {
+	ID   string `json:"id"`
+	Role *struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"role"`
+	CompanyLocation *struct {
+		ID string `json:"id"`
+	} `json:"companyLocation"`
+}
+
+func (n *companyContactRoleAssignmentNode) assignment() *CompanyContactRoleAssignment {
+	if n == nil {
+		return nil
+	}
+	assignment := &CompanyContactRoleAssignment{ID: n.ID}
+	if n.Role != nil {
+		assignment.RoleID = n.Role.ID
+		assignment.RoleName = n.Role.Name
+	}
+	if n.CompanyLocation != nil {
+		assignment.LocationID = n.CompanyLocation.ID
+	}
+	return assignment
+}
+
+// companyNode is the GraphQL representation of a company, whose locations,
+// contacts and roles are connections.
+type companyNode struct {
+	ID           string     `json:"id"`
+	Name         string     `json:"name"`
+	ExternalID   string     `json:"externalId"`
+	Note         string     `json:"note"`
+	CreatedAt    *time.Time `json:"createdAt"`
+	UpdatedAt    *time.Time `json:"updatedAt"`
+	ContactRoles struct {
+		Edges []struct {
+			Node CompanyContactRole `json:"node"`
+		} `json:"edges"`
+	} `json:"contactRoles"`
+	Locations struct {
+		Edges []struct {
+			Node CompanyLocation `json:"node"`
+		} `json:"edges"`
+	} `json:"locations"`
+	Contacts struct {
+		Edges []struct {
+			Node companyContactNode `json:"node"`
+		} `json:"edges"`
+	} `json:"contacts"`
+}
+
+func (n *companyNode) company() *Company {
+	if n == nil {
+		return nil
+	}
+	company := &Company{
+		ID:         n.ID,
+		Name:       n.Name,
+		ExternalID: n.ExternalID,
+		Note:       n.Note,
+		CreatedAt:  n.CreatedAt,
+		UpdatedAt:  n.UpdatedAt,
+	}
+	for _, edge := range n.ContactRoles.Edges {
+		company.ContactRoles = append(company.ContactRoles, edge.Node)
+	}
+	for _, edge := range n.Locations.Edges {
+		company.Locations = append(company.Locations, edge.Node)
+	}
+	for i := range n.Contacts.Edges {
+		company.Contacts = append(company.Contacts, *n.Contacts.Edges[i].Node.contact())
+	}
+	return company
+}
+
+const companyAddressFields = `recipient address1 address2 city zip countryCode zoneCode phone`
+
+const companyLocationFields = `id name externalId phone locale
+  shippingAddress { ` + companyAddressFields + ` }
+  billingAddress { ` + companyAddressFields + ` }`
+
+const companyContactRoleAssignmentFields = `id role { id name } companyLocation { id }`
+
+const companyContactFields = `id title locale isMainContact customer { id }
+  roleAssignments(first: 50) { edges { node { ` + companyContactRoleAssignmentFields + ` } } }`
+
+const companyFields = `id name externalId note createdAt updatedAt
+  contactRoles(first: 10) { edges { node { id name } } }
+  locations(first: 50) { edges { node { ` + companyLocationFields + ` } } }
+  contacts(first: 50) { edges { node { ` + companyContactFields + ` } } }`
+
+const companiesQuery = `query($query: String, $after: String) {
+  companies(first: 50, query: $query, after: $after) {
+    edges { node { ` + companyFields + ` } }
+    pageInfo { hasNextPage endCursor }
+  }
+}`
+
+const companyQuery = `query($id: ID!) {
+  company(id: $id) { ` + companyFields + ` }
+}`
+
+const companyCreateMutation = `mutation($input: CompanyCreateInput!) {
+  companyCreate(input: $input) {
+    company { ` + companyFields + ` }
+    userErrors { field message }
+  }
+}`
+
+const companyUpdateMutation = `mutation($companyId: ID!, $input: CompanyInput!) {
+  companyUpdate(companyId: $companyId, input: $input) {
+    company { ` + companyFields + ` }
+    userErrors { field message }
+  }
+}`
+
+const companyDeleteMutation = `mutation($id: ID!) {
+  companyDelete(id: $id) {
+    deletedCompanyId
+    userErrors { field message }
+  }
+}`
+
+const companyLocationCreateMutation = `mutation($companyId: ID!, $input: CompanyLocationInput!) {
+  companyLocationCreate(companyId: $companyId, input: $input) {
+    companyLocation { ` + companyLocationFields + ` }
+    userErrors { field message }
+  }
+}`
+
+const companyLocationDeleteMutation = `mutation($companyLocationId: ID!) {
+  companyLocationDelete(companyLocationId: $companyLocationId) {
+    deletedCompanyLocationId
+    userErrors { field message }
+  }
+}`
+
+const companyContactCreateMutation = `mutation($companyId: ID!, $input: CompanyContactInput!) {
+  companyContactCreate(companyId: $companyId, input: $input) {
+    companyContact { ` + companyContactFields + ` }
+    userErrors { field message }
+  }
+}`
+
+const companyAssignCustomerAsContactMutation = `mutation($companyId: ID!, $customerId: ID!) {
+  companyAssignCustomerAsContact(companyId: $companyId, customerId: $customerId) {
+    companyContact { ` + companyContactFields + ` }
+    userErrors { field message }
+  }
+}`
+
+const companyContactDeleteMutation = `mutation($companyContactId: ID!) {
+  companyContactDelete(companyContactId: $companyContactId) {
+    deletedCompanyContactId
+    userErrors { field message }
+  }
+}`
+
+const companyContactAssignRoleMutation = `mutation($companyContactId: ID!, $companyContactRoleId: ID!, $companyLocationId: ID!) {
+  companyContactAssignRole(companyContactId: $companyContactId, companyContactRoleId: $companyContactRoleId, companyLocationId: $companyLocationId) {
+    companyContactRoleAssignment { ` + companyContactRoleAssignmentFields + ` }
+    userErrors { field message }
+  }
+}`
+
+const companyContactRevokeRoleMutation = `mutation($companyContactId: ID!, $companyContactRoleAssignmentId: ID!) {
+  companyContactRevokeRole(companyContactId: $companyContactId, companyContactRoleAssignmentId: $companyContactRoleAssignmentId) {
+    revokedCompanyContactRoleAssignmentId
+    userErrors { field message }
+  }
+}`
+
+// List the companies matching the search query, e.g. "name:Acme", or all of
+// them if it is empty.
+func (s *CompanyServiceOp) List(query string) ([]Company, error) {
+	var companies []Company
+	var after *string
+	for {
+		vars := map[string]interface{}{"after": after}
+		if query != "" {
+			vars["query"] = query
+		}
+		resp := struct {
+			Companies struct {
+				Edges []struct {
+					Node companyNode `json:"node"`
+				} `json:"edges"`
+				PageInfo graphQLPageInfo `json:"pageInfo"`
+			} `json:"companies"`
+		}{}
+
+		err := s.client.GraphQL.Query(companiesQuery, vars, &resp)
+		if err != nil {
+			return companies, err
+		}
+
+		for i := range resp.Companies.Edges {
+			companies = append(companies, *resp.Companies.Edges[i].Node.company())
+		}
+
+		pageInfo := resp.Companies.PageInfo
+		if !pageInfo.HasNextPage {
+			return companies, nil
+		}
+		after = &pageInfo.EndCursor
+	}
+}
+
+// Get a company by its ID, nil if there is none.
+func (s *CompanyServiceOp) Get(id string) (*Company, error) {
+	resp := struct {
+		Company *companyNode `json:"company"`
+	}{}
+	err := s.client.GraphQL.Query(companyQuery, map[string]interface{}{"id": id}, &resp)
+	return resp.Company.company(), err
+}
+
+// Create a new company, optionally along with its first location and main
+// contact.
+func (s *CompanyServiceOp) Create(input CompanyCreateInput) (*Company, error) {
+	resp := struct {
+		CompanyCreate struct {
+			Company    *companyNode `json:"company"`
+			UserErrors []UserError  `json:"userErrors"`
+		} `json:"companyCreate"`
+	}{}
+
+	err := s.client.GraphQL.Query(companyCreateMutation, map[string]interface{}{"input": input}, &resp)
+	if err == nil {
+		err = userErrorsToError(resp.CompanyCreate.UserErrors)
+	}
+	return resp.CompanyCreate.Company.company(), err
+}
+
+// Update the details of an existing company
+func (s *CompanyServiceOp) Update(id string, input CompanyInput) (*Company, error) {
+	vars := map[string]interface{}{
+		"companyId": id,
+		"input":     input,
+	}
+	resp := struct {
+		CompanyUpdate struct {
+			Company    *companyNode `json:"company"`
+			UserErrors []UserError  `json:"userErrors"`
+		} `json:"companyUpdate"`
+	}{}
+
+	err := s.client.GraphQL.Query(companyUpdateMutation, vars, &resp)
+	if err == nil {
+		err = userErrorsToError(resp.CompanyUpdate.UserErrors)
+	}
+	return resp.CompanyUpdate.Company.company(), err
+}
+
+// Delete an existing company along with its locations and contacts
+func (s *CompanyServiceOp) Delete(id string) error {
+	return graphQLMutate(s.client, companyDeleteMutation, "companyDelete", map[string]interface{}{"id": id})
+}
+
+// CreateLocation adds a location to a company.
+func (s *CompanyServiceOp) CreateLocation(companyID string, input CompanyLocationInput) (*CompanyLocation, error) {
+	vars := map[string]interface{}{
+		"companyId": companyID,
+		"input":     input,
+	}
+	resp := struct {
+		CompanyLocationCreate struct {
+			CompanyLocation *CompanyLocation `json:"companyLocation"`
+			UserErrors      []UserError      `json:"userErrors"`
+		} `json:"companyLocationCreate"`
+	}{}
+
+	err := s.client.GraphQL.Query(companyLocationCreateMutation, vars, &resp)
+	if err == nil {
+		err = userErrorsToError(resp.CompanyLocationCreate.UserErrors)
+	}
+	return resp.CompanyLocationCreate.CompanyLocation, err
+}
+
+// DeleteLocation deletes a location of a company.
+func (s *CompanyServiceOp) DeleteLocation(id string) error {
+	return graphQLMutate(s.client, companyLocationDeleteMutation, "companyLocationDelete", map[string]interface{}{"companyLocationId": id})
+}
+
+// CreateContact adds a contact to a company.
+func (s *CompanyServiceOp) CreateContact(companyID string, input CompanyContactInput) (*CompanyContact, error) {
+	vars := map[string]interface{}{
+		"companyId": companyID,
+		"input":     input,
+	}
+	resp := struct {
+		CompanyContactCreate struct {
+			CompanyContact *companyContactNode `json:"companyContact"`
+			UserErrors     []UserError         `json:"userErrors"`
+		} `json:"companyContactCreate"`
+	}{}
+
+	err := s.client.GraphQL.Query(companyContactCreateMutation, vars, &resp)
+	if err == nil {
+		err = userErrorsToError(resp.CompanyContactCreate.UserErrors)
+	}
+	return resp.CompanyContactCreate.CompanyContact.contact(), err
+}
+
+// AssignCustomer makes an existing customer a contact of a company.
+func (s *CompanyServiceOp) AssignCustomer(companyID string, customerID int64) (*CompanyContact, error) {
+	vars := map[string]interface{}{
+		"companyId":  companyID,
+		"customerId": customerGID(customerID),
+	}
+	resp := struct {
+		CompanyAssignCustomerAsContact struct {
+			CompanyContact *companyContactNode `json:"companyContact"`
+			UserErrors     []UserError         `json:"userErrors"`
+		} `json:"companyAssignCustomerAsContact"`
+	}{}
+
+	err := s.client.GraphQL.Query(companyAssignCustomerAsContactMutation, vars, &resp)
+	if err == nil {
+		err = userErrorsToError(resp.CompanyAssignCustomerAsContact.UserErrors)
+	}
+	return resp.CompanyAssignCustomerAsContact.CompanyContact.contact(), err
+}
+
+// DeleteContact removes a contact from its company, the customer is kept.
+func (s *CompanyServiceOp) DeleteContact(id string) error {
+	return graphQLMutate(s.client, companyContactDeleteMutation, "companyContactDelete", map[string]interface{}{"companyContactId": id})
+}
+
+// AssignRole grants a contact one of the contact roles of its company at a
+// location of the company.
+func (s *CompanyServiceOp) AssignRole(contactID, roleID, locationID string) (*CompanyContactRoleAssignment, error) {
+	vars := map[string]interface{}{
+		"companyContactId":     contactID,
+		"companyContactRoleId": roleID,
+		"companyLocationId":    locationID,
+	}
+	resp := struct {
+		CompanyContactAssignRole struct {
+			CompanyContactRoleAssignment *companyContactRoleAssignmentNode `json:"companyContactRoleAssignment"`
+			UserErrors                   []UserError                       `json:"userErrors"`
+		} `json:"companyContactAssignRole"`
+	}{}
+
+	err := s.client.GraphQL.Query(companyContactAssignRoleMutation, vars, &resp)
+	if err == nil {
+		err = userErrorsToError(resp.CompanyContactAssignRole.UserErrors)
+	}
+	return resp.CompanyContactAssignRole.CompanyContactRoleAssignment.assignment(), err
+}
+
+// RevokeRole revokes a role assignment of a contact.
+func (s *CompanyServiceOp) RevokeRole(contactID, assignmentID string) error {
+	vars := map[string]interface{}{
+		"companyContactId":               contactID,
+		"companyContactRoleAssignmentId": assignmentID,
+	}
+	return graphQLMutate(s.client, companyContactRevokeRoleMutation, "companyContactRevokeRole", vars)
+}
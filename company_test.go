@@ -0,0 +1,236 @@
+package goshopify
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func companyTests(t *testing.T, company Company) {
+	expected := Company{
+		ID:         "gid://shopify/Company/1",
+		Name:       "Acme",
+		ExternalID: "acme-1",
+		ContactRoles: []CompanyContactRole{
+			{ID: "gid://shopify/CompanyContactRole/2", Name: "Location admin"},
+			{ID: "gid://shopify/CompanyContactRole/3", Name: "Ordering only"},
+		},
+		Locations: []CompanyLocation{{
+			ID:              "gid://shopify/CompanyLocation/4",
+			Name:            "Head office",
+			Locale:          "en",
+			ShippingAddress: &CompanyAddress{Address1: "1 Main St", City: "Ottawa", Zip: "K1A 0A1", CountryCode: "CA", ZoneCode: "ON"},
+		}},
+		Contacts: []CompanyContact{{
+			ID:            "gid://shopify/CompanyContact/5",
+			CustomerID:    "gid://shopify/Customer/6",
+			Title:         "Buyer",
+			Locale:        "en",
+			IsMainContact: true,
+			RoleAssignments: []CompanyContactRoleAssignment{{
+				ID:         "gid://shopify/CompanyContactRoleAssignment/7",
+				RoleID:     "gid://shopify/CompanyContactRole/2",
+				RoleName:   "Location admin",
+				LocationID: "gid://shopify/CompanyLocation/4",
+			}},
+		}},
+	}
+	if !reflect.DeepEqual(company, expected) {
+		t.Errorf("Company returned %+v, expected %+v", company, expected)
+	}
+}
+
+func TestCompanyList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests,
+			string(loadFixture("companies.json")),
+			`{"data":{"companies":{"edges":[{"node":{"id":"gid://shopify/Company/8","name":"Globex"}}],"pageInfo":{"hasNextPage":false}}}}`,
+		))
+
+	companies, err := client.Company.List("name:A*")
+	if err != nil {
+		t.Fatalf("Company.List returned error: %v", err)
+	}
+
+	if len(companies) != 2 {
+		t.Fatalf("Company.List returned %d companies, expected 2", len(companies))
+	}
+	companyTests(t, companies[0])
+	expected := Company{ID: "gid://shopify/Company/8", Name: "Globex"}
+	if !reflect.DeepEqual(companies[1], expected) {
+		t.Errorf("Company.List returned %+v, expected %+v", companies[1], expected)
+	}
+	if query := requests[0].Variables.(map[string]interface{})["query"]; query != "name:A*" {
+		t.Errorf("Company.List sent query %v", query)
+	}
+	if len(requests) != 2 || requests[1].Variables.(map[string]interface{})["after"] != "abc" {
+		t.Errorf("Company.List sent %+v, expected the second page after abc", requests)
+	}
+}
+
+func TestCompanyGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests,
+			string(loadFixture("company.json")),
+			`{"data":{"company":null}}`,
+		))
+
+	company, err := client.Company.Get("gid://shopify/Company/1")
+	if err != nil {
+		t.Fatalf("Company.Get returned error: %v", err)
+	}
+	companyTests(t, *company)
+
+	company, err = client.Company.Get("gid://shopify/Company/9")
+	if err != nil || company != nil {
+		t.Errorf("Company.Get of a missing company returned %+v, %v", company, err)
+	}
+}
+
+func TestCompanyCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests, string(loadFixture("company_create.json"))))
+
+	company, err := client.Company.Create(CompanyCreateInput{
+		Company: CompanyInput{Name: "Acme", ExternalID: "acme-1"},
+		CompanyLocation: &CompanyLocationInput{
+			Name:                  "Head office",
+			ShippingAddress:       &CompanyAddress{Address1: "1 Main St", City: "Ottawa", CountryCode: "CA"},
+			BillingSameAsShipping: true,
+		},
+		CompanyContact: &CompanyContactInput{Email: "buyer@acme.example", FirstName: "Wile"},
+	})
+	if err != nil {
+		t.Fatalf("Company.Create returned error: %v", err)
+	}
+	companyTests(t, *company)
+
+	expected := map[string]interface{}{
+		"company": map[string]interface{}{"name": "Acme", "externalId": "acme-1"},
+		"companyLocation": map[string]interface{}{
+			"name":                  "Head office",
+			"shippingAddress":       map[string]interface{}{"address1": "1 Main St", "city": "Ottawa", "countryCode": "CA"},
+			"billingSameAsShipping": true,
+		},
+		"companyContact": map[string]interface{}{"email": "buyer@acme.example", "firstName": "Wile"},
+	}
+	if input := requests[0].Variables.(map[string]interface{})["input"]; !reflect.DeepEqual(input, expected) {
+		t.Errorf("Company.Create sent %+v, expected %+v", input, expected)
+	}
+}
+
+func TestCompanyUpdate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests, `{"data":{"companyUpdate":{"company":null,"userErrors":[{"field":["input","name"],"message":"Name can't be blank"}]}}}`))
+
+	_, err := client.Company.Update("gid://shopify/Company/1", CompanyInput{Note: "VIP"})
+	if err == nil || err.Error() != "input.name: Name can't be blank" {
+		t.Errorf("Company.Update returned %v, expected the user error", err)
+	}
+
+	expected := map[string]interface{}{
+		"companyId": "gid://shopify/Company/1",
+		"input":     map[string]interface{}{"note": "VIP"},
+	}
+	if !reflect.DeepEqual(requests[0].Variables, expected) {
+		t.Errorf("Company.Update sent %+v, expected %+v", requests[0].Variables, expected)
+	}
+}
+
+func TestCompanyLocationsAndContacts(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests,
+			`{"data":{"companyLocationCreate":{"companyLocation":{"id":"gid://shopify/CompanyLocation/10","name":"Warehouse"},"userErrors":[]}}}`,
+			`{"data":{"companyContactCreate":{"companyContact":{"id":"gid://shopify/CompanyContact/11","isMainContact":false,"customer":{"id":"gid://shopify/Customer/12"},"roleAssignments":{"edges":[]}},"userErrors":[]}}}`,
+			`{"data":{"companyAssignCustomerAsContact":{"companyContact":{"id":"gid://shopify/CompanyContact/13","isMainContact":false,"customer":{"id":"gid://shopify/Customer/14"},"roleAssignments":{"edges":[]}},"userErrors":[]}}}`,
+			`{"data":{"companyContactAssignRole":{"companyContactRoleAssignment":{"id":"gid://shopify/CompanyContactRoleAssignment/15","role":{"id":"gid://shopify/CompanyContactRole/3","name":"Ordering only"},"companyLocation":{"id":"gid://shopify/CompanyLocation/10"}},"userErrors":[]}}}`,
+			`{"data":{"companyContactRevokeRole":{"revokedCompanyContactRoleAssignmentId":"gid://shopify/CompanyContactRoleAssignment/15","userErrors":[]}}}`,
+			`{"data":{"companyContactDelete":{"deletedCompanyContactId":"gid://shopify/CompanyContact/13","userErrors":[]}}}`,
+			`{"data":{"companyLocationDelete":{"deletedCompanyLocationId":"gid://shopify/CompanyLocation/10","userErrors":[]}}}`,
+			`{"data":{"companyDelete":{"deletedCompanyId":"gid://shopify/Company/1","userErrors":[]}}}`,
+		))
+
+	companyID := "gid://shopify/Company/1"
+	location, err := client.Company.CreateLocation(companyID, CompanyLocationInput{Name: "Warehouse"})
+	if err != nil || location == nil || location.ID != "gid://shopify/CompanyLocation/10" {
+		t.Errorf("Company.CreateLocation returned %+v, %v", location, err)
+	}
+	contact, err := client.Company.CreateContact(companyID, CompanyContactInput{Email: "clerk@acme.example"})
+	if err != nil || contact == nil || contact.CustomerID != "gid://shopify/Customer/12" {
+		t.Errorf("Company.CreateContact returned %+v, %v", contact, err)
+	}
+	contact, err = client.Company.AssignCustomer(companyID, 14)
+	if err != nil || contact == nil || contact.ID != "gid://shopify/CompanyContact/13" {
+		t.Errorf("Company.AssignCustomer returned %+v, %v", contact, err)
+	}
+	assignment, err := client.Company.AssignRole(contact.ID, "gid://shopify/CompanyContactRole/3", location.ID)
+	expectedAssignment := &CompanyContactRoleAssignment{
+		ID:         "gid://shopify/CompanyContactRoleAssignment/15",
+		RoleID:     "gid://shopify/CompanyContactRole/3",
+		RoleName:   "Ordering only",
+		LocationID: "gid://shopify/CompanyLocation/10",
+	}
+	if err != nil || !reflect.DeepEqual(assignment, expectedAssignment) {
+		t.Errorf("Company.AssignRole returned %+v, %v, expected %+v", assignment, err, expectedAssignment)
+	}
+	if err := client.Company.RevokeRole(contact.ID, assignment.ID); err != nil {
+		t.Errorf("Company.RevokeRole returned error: %v", err)
+	}
+	if err := client.Company.DeleteContact(contact.ID); err != nil {
+		t.Errorf("Company.DeleteContact returned error: %v", err)
+	}
+	if err := client.Company.DeleteLocation(location.ID); err != nil {
+		t.Errorf("Company.DeleteLocation returned error: %v", err)
+	}
+	if err := client.Company.Delete(companyID); err != nil {
+		t.Errorf("Company.Delete returned error: %v", err)
+	}
+
+	expected := []map[string]interface{}{
+		{"companyId": companyID, "input": map[string]interface{}{"name": "Warehouse"}},
+		{"companyId": companyID, "input": map[string]interface{}{"email": "clerk@acme.example"}},
+		{"companyId": companyID, "customerId": "gid://shopify/Customer/14"},
+		{
+			"companyContactId":     "gid://shopify/CompanyContact/13",
+			"companyContactRoleId": "gid://shopify/CompanyContactRole/3",
+			"companyLocationId":    "gid://shopify/CompanyLocation/10",
+		},
+		{
+			"companyContactId":               "gid://shopify/CompanyContact/13",
+			"companyContactRoleAssignmentId": "gid://shopify/CompanyContactRoleAssignment/15",
+		},
+		{"companyContactId": "gid://shopify/CompanyContact/13"},
+		{"companyLocationId": "gid://shopify/CompanyLocation/10"},
+		{"id": companyID},
+	}
+	if len(requests) != len(expected) {
+		t.Fatalf("Company sent %d requests, expected %d", len(requests), len(expected))
+	}
+	for i := range expected {
+		if !reflect.DeepEqual(requests[i].Variables, expected[i]) {
+			t.Errorf("request %d sent %+v, expected %+v", i, requests[i].Variables, expected[i])
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package goshopify
+
+import (
+	"fmt"
+)
+
+const countriesBasePath = "countries"
+
+// CountryService is an interface for interacting with the countries
+// endpoints of the Shopify API.
+// See https://shopify.dev/docs/api/admin-rest/2023-04/resources/country
+type CountryService interface {
+	List(interface{}) ([]Country, error)
+	ListWithPagination(interface{}) ([]Country, *Pagination, error)
+	Count(interface{}) (int, error)
+	Get(int64, interface{}) (*Country, error)
+	Create(Country) (*Country, error)
+	Update(Country) (*Country, error)
+	Delete(int64) error
+}
+
+// CountryServiceOp handles communication with the country related methods of
+// the Shopify API.
+type CountryServiceOp struct {
+	client *Client
+}
+
+// Country represents a Shopify country.
+type Country struct {
+	ID      int64   `json:"id,omitempty"`
+	Name    string  `json:"name,omitempty"`
+	Code    string  `json:"code,omitempty"`
+	TaxName string  `json:"tax_name,omitempty"`
+	Tax     float64 `json:"tax,omitempty"`
+}
+
+// CountryResource represents the result from the countries/X.json endpoint
+type CountryResource struct {
+	Country *Country `json:"country"`
+}
+
+// CountriesResource represents the result from the countries.json endpoint
+type CountriesResource struct {
+	Countries []Country `json:"countries"`
+}
+
+// List countries
+func (s *CountryServiceOp) List(options interface{}) ([]Country, error) {
+	countries, _, err := s.ListWithPagination(options)
+	if err != nil {
+		return nil, err
+	}
+	return countries, nil
+}
+
+// List countries with pagination
+func (s *CountryServiceOp) ListWithPagination(options interface{}) ([]Country, *Pagination, error) {
+	path := fmt.Sprintf("%s.json", countriesBasePath)
+	return listResourceWithPagination[Country](s.client, path, "countries", options)
+}
+
+// Count countries
+func (s *CountryServiceOp) Count(options interface{}) (int, error) {
+	path := fmt.Sprintf("%s/count.json", countriesBasePath)
+	return s.client.Count(path, options)
+}
+
+// Get individual country
+func (s *CountryServiceOp) Get(countryID int64, options interface{}) (*Country, error) {
+	path := fmt.Sprintf("%s/%d.json", countriesBasePath, countryID)
+	return getResource[Country](s.client, path, "country", options)
+}
+
+// Create a new country
+func (s *CountryServiceOp) Create(country Country) (*Country, error) {
+	path := fmt.Sprintf("%s.json", countriesBasePath)
+	return createResource(s.client, path, "country", country)
+}
+
+// Update an existing country
+func (s *CountryServiceOp) Update(country Country) (*Country, error) {
+	path := fmt.Sprintf("%s/%d.json", countriesBasePath, country.ID)
+	return updateResource(s.client, path, "country", country)
+}
+
+// Delete an existing country.
+func (s *CountryServiceOp) Delete(countryID int64) error {
+	return s.client.Delete(fmt.Sprintf("%s/%d.json", countriesBasePath, countryID))
+}
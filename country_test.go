@@ -0,0 +1,134 @@
+package goshopify
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestCountryList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/countries.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"countries": [{"id":1},{"id":2}]}`))
+
+	countries, err := client.Country.List(nil)
+	if err != nil {
+		t.Errorf("Country.List returned error: %v", err)
+	}
+
+	expected := []Country{{ID: 1}, {ID: 2}}
+	if !reflect.DeepEqual(countries, expected) {
+		t.Errorf("Country.List returned %+v, expected %+v", countries, expected)
+	}
+}
+
+func TestCountryListWithPagination(t *testing.T) {
+	setup()
+	defer teardown()
+
+	listURL := fmt.Sprintf("https://fooshop.myshopify.com/%s/countries.json", client.pathPrefix)
+	httpmock.RegisterResponder("GET", listURL,
+		func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(200, `{"countries": [{"id":1}]}`)
+			resp.Header.Set("Link", fmt.Sprintf(`<%s?page_info=abc&limit=1>; rel="next"`, listURL))
+			return resp, nil
+		})
+
+	countries, pagination, err := client.Country.ListWithPagination(nil)
+	if err != nil {
+		t.Fatalf("Country.ListWithPagination returned error: %v", err)
+	}
+	if len(countries) != 1 || countries[0].ID != 1 {
+		t.Errorf("Country.ListWithPagination returned %+v", countries)
+	}
+	if pagination == nil || pagination.NextPageOptions == nil || pagination.NextPageOptions.PageInfo != "abc" {
+		t.Errorf("Country.ListWithPagination returned pagination %+v", pagination)
+	}
+}
+
+func TestCountryCount(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/countries/count.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"count": 3}`))
+
+	cnt, err := client.Country.Count(nil)
+	if err != nil {
+		t.Errorf("Country.Count returned error: %v", err)
+	}
+
+	expected := 3
+	if cnt != expected {
+		t.Errorf("Country.Count returned %d, expected %d", cnt, expected)
+	}
+}
+
+func TestCountryGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/countries/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"country": {"id":1}}`))
+
+	country, err := client.Country.Get(1, nil)
+	if err != nil {
+		t.Errorf("Country.Get returned error: %v", err)
+	}
+
+	if country.ID != 1 {
+		t.Errorf("Country.Get returned %+v, expected ID 1", country)
+	}
+}
+
+func TestCountryCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/countries.json", client.pathPrefix),
+		httpmock.NewStringResponder(201, `{"country": {"id":1}}`))
+
+	country, err := client.Country.Create(Country{})
+	if err != nil {
+		t.Errorf("Country.Create returned error: %v", err)
+	}
+
+	if country.ID != 1 {
+		t.Errorf("Country.Create returned %+v, expected ID 1", country)
+	}
+}
+
+func TestCountryUpdate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/countries/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"country": {"id":1}}`))
+
+	country, err := client.Country.Update(Country{ID: 1})
+	if err != nil {
+		t.Errorf("Country.Update returned error: %v", err)
+	}
+
+	if country.ID != 1 {
+		t.Errorf("Country.Update returned %+v, expected ID 1", country)
+	}
+}
+
+func TestCountryDelete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("https://fooshop.myshopify.com/%s/countries/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, "{}"))
+
+	err := client.Country.Delete(1)
+	if err != nil {
+		t.Errorf("Country.Delete returned error: %v", err)
+	}
+}
@@ -2,7 +2,6 @@ package goshopify
 
 import (
 	"fmt"
-	"net/http"
 	"time"
 )
 
@@ -17,8 +16,10 @@ type CustomCollectionService interface {
 	ListWithPagination(interface{}) ([]CustomCollection, *Pagination, error)
 	Count(interface{}) (int, error)
 	Get(int64, interface{}) (*CustomCollection, error)
+	GetByHandle(string) (*CustomCollection, error)
 	Create(CustomCollection) (*CustomCollection, error)
 	Update(CustomCollection) (*CustomCollection, error)
+	UpdateFields(int64, *Update) (*CustomCollection, error)
 	Delete(int64) error
 
 	// MetafieldsService used for CustomCollection resource to communicate with Metafields resource
@@ -41,10 +42,21 @@ type CustomCollection struct {
 	SortOrder      string      `json:"sort_order,omitempty"`
 	TemplateSuffix string      `json:"template_suffix,omitempty"`
 	Image          Image       `json:"image,omitempty"`
-	Published      bool        `json:"published,omitempty"`
+	Published      *bool       `json:"published,omitempty"`
 	PublishedAt    *time.Time  `json:"published_at,omitempty"`
 	PublishedScope string      `json:"published_scope,omitempty"`
 	Metafields     []Metafield `json:"metafields,omitempty"`
+	NullFields     NullFields  `json:"-"`
+}
+
+// MarshalJSON encodes the custom collection, sending the NullFields as null. The
+// image is left out unless set, add "image" to the NullFields to remove it.
+func (c CustomCollection) MarshalJSON() ([]byte, error) {
+	type customCollection CustomCollection
+	return marshalWithNullFields(struct {
+		customCollection
+		Image *Image `json:"image,omitempty"`
+	}{customCollection(c), c.Image.orNil()}, c.NullFields)
 }
 
 // CustomCollectionResource represents the result form the custom_collections/X.json endpoint
@@ -69,23 +81,7 @@ func (s *CustomCollectionServiceOp) List(options interface{}) ([]CustomCollectio
 // List custom collections with pagination
 func (s *CustomCollectionServiceOp) ListWithPagination(options interface{}) ([]CustomCollection, *Pagination, error) {
 	path := fmt.Sprintf("%s.json", customCollectionsBasePath)
-	resource := new(CustomCollectionsResource)
-	headers := http.Header{}
-
-	headers, err := s.client.createAndDoGetHeaders("GET", path, nil, options, resource)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	// Extract pagination info from header
-	linkHeader := headers.Get("Link")
-
-	pagination, err := extractPagination(linkHeader)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	return resource.Collections, pagination, nil
+	return listResourceWithPagination[CustomCollection](s.client, path, "custom_collections", options)
 }
 
 // Count custom collections
@@ -97,28 +93,32 @@ func (s *CustomCollectionServiceOp) Count(options interface{}) (int, error) {
 // Get individual custom collection
 func (s *CustomCollectionServiceOp) Get(collectionID int64, options interface{}) (*CustomCollection, error) {
 	path := fmt.Sprintf("%s/%d.json", customCollectionsBasePath, collectionID)
-	resource := new(CustomCollectionResource)
-	err := s.client.Get(path, resource, options)
-	return resource.Collection, err
+	return getResource[CustomCollection](s.client, path, "custom_collection", options)
+}
+
+// GetByHandle gets the custom collection with the handle, or returns a NotFoundError
+func (s *CustomCollectionServiceOp) GetByHandle(handle string) (*CustomCollection, error) {
+	path := fmt.Sprintf("%s.json", customCollectionsBasePath)
+	return getResourceByHandle(s.client, path, "custom_collections", handle, func(collection CustomCollection) string { return collection.Handle })
 }
 
 // Create a new custom collection
 // See Image for the details of the Image creation for a collection.
 func (s *CustomCollectionServiceOp) Create(collection CustomCollection) (*CustomCollection, error) {
 	path := fmt.Sprintf("%s.json", customCollectionsBasePath)
-	wrappedData := CustomCollectionResource{Collection: &collection}
-	resource := new(CustomCollectionResource)
-	err := s.client.Post(path, wrappedData, resource)
-	return resource.Collection, err
+	return createResource(s.client, path, "custom_collection", collection)
 }
 
 // Update an existing custom collection
 func (s *CustomCollectionServiceOp) Update(collection CustomCollection) (*CustomCollection, error) {
 	path := fmt.Sprintf("%s/%d.json", customCollectionsBasePath, collection.ID)
-	wrappedData := CustomCollectionResource{Collection: &collection}
-	resource := new(CustomCollectionResource)
-	err := s.client.Put(path, wrappedData, resource)
-	return resource.Collection, err
+	return updateResource(s.client, path, "custom_collection", collection)
+}
+
+// UpdateFields updates only the fields set in update on the custom collection, see Update.
+func (s *CustomCollectionServiceOp) UpdateFields(collectionID int64, update *Update) (*CustomCollection, error) {
+	path := fmt.Sprintf("%s/%d.json", customCollectionsBasePath, collectionID)
+	return updateFieldsResource[CustomCollection](s.client, path, "custom_collection", collectionID, update)
 }
 
 // Delete an existing custom collection.
@@ -1,11 +1,13 @@
 package goshopify
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
 	"runtime"
+	"strings"
 	"testing"
 	"time"
 
@@ -141,6 +143,7 @@ func TestCustomCollectionWithPagination(t *testing.T) {
 			[]CustomCollection{{ID: 1}},
 			&Pagination{
 				NextPageOptions: &ListOptions{PageInfo: "foo", Limit: 2},
+				NextPageURL:     "http://valid.url?page_info=foo&limit=2",
 			},
 			nil,
 		},
@@ -151,6 +154,8 @@ func TestCustomCollectionWithPagination(t *testing.T) {
 			&Pagination{
 				NextPageOptions:     &ListOptions{PageInfo: "foo"},
 				PreviousPageOptions: &ListOptions{PageInfo: "bar"},
+				NextPageURL:         "http://valid.url?page_info=foo",
+				PreviousPageURL:     "http://valid.url?page_info=bar",
 			},
 			nil,
 		},
@@ -426,3 +431,60 @@ func TestCustomCollectionDeleteMetafield(t *testing.T) {
 		t.Errorf("CustomCollection.DeleteMetafield() returned error: %v", err)
 	}
 }
+
+func TestCustomCollectionGetByHandle(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponderWithQuery(
+		"GET",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/custom_collections.json", client.pathPrefix),
+		map[string]string{"handle": "summer"},
+		httpmock.NewStringResponder(200, `{"custom_collections": [{"id": 1, "handle": "summer-sale"}, {"id": 2, "handle": "summer"}]}`))
+	httpmock.RegisterResponderWithQuery(
+		"GET",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/custom_collections.json", client.pathPrefix),
+		map[string]string{"handle": "winter"},
+		httpmock.NewStringResponder(200, `{"custom_collections": []}`))
+
+	found, err := client.CustomCollection.GetByHandle("summer")
+	if err != nil {
+		t.Fatalf("CustomCollection.GetByHandle returned error: %v", err)
+	}
+	if found.ID != 2 {
+		t.Errorf("CustomCollection.GetByHandle returned %+v, expected ID 2", found)
+	}
+
+	_, err = client.CustomCollection.GetByHandle("winter")
+	expected := NotFoundError{Resource: "custom_collections", Handle: "winter"}
+	if err != expected {
+		t.Errorf("CustomCollection.GetByHandle returned error %v, expected %v", err, expected)
+	}
+}
+
+func TestCustomCollectionMarshalImage(t *testing.T) {
+	image := Image{Alt: "Macbooks on a desk"}
+	if err := image.SetAttachment(strings.NewReader("png")); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name       string
+		collection CustomCollection
+		expected   string
+	}{
+		{"unset", CustomCollection{ID: 1, Title: "Macbooks"}, `{"id":1,"title":"Macbooks"}`},
+		{"attachment", CustomCollection{ID: 1, Image: image}, `{"id":1,"image":{"alt":"Macbooks on a desk","attachment":"cG5n"}}`},
+		{"alt", CustomCollection{ID: 1, Image: Image{Alt: "Macbooks"}}, `{"id":1,"image":{"alt":"Macbooks"}}`},
+		{"removed", CustomCollection{ID: 1, NullFields: NullFields{"image"}}, `{"id":1,"image":null}`},
+	}
+
+	for _, c := range cases {
+		data, err := json.Marshal(c.collection)
+		if err != nil {
+			t.Errorf("%s: json.Marshal returned error: %v", c.name, err)
+		} else if string(data) != c.expected {
+			t.Errorf("%s: CustomCollection encoded as %s, expected %s", c.name, data, c.expected)
+		}
+	}
+}
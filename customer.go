@@ -1,6 +1,7 @@
 package goshopify
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -15,14 +16,19 @@ const customersResourceName = "customers"
 // See: https://help.shopify.com/api/reference/customer
 type CustomerService interface {
 	List(interface{}) ([]Customer, error)
+	ListByIDs(context.Context, []int64) ([]Customer, error)
 	Count(interface{}) (int, error)
 	Get(int64, interface{}) (*Customer, error)
 	Search(interface{}) ([]Customer, error)
 	Create(Customer) (*Customer, error)
 	Update(Customer) (*Customer, error)
+	UpdateFields(int64, *Update) (*Customer, error)
 	Delete(int64) error
 	ListOrders(int64, interface{}) ([]Order, error)
 	ListTags(interface{}) ([]string, error)
+	Merge(int64, int64) (*CustomerMerge, error)
+	MergeStatus(string) (*CustomerMerge, error)
+	WaitMerge(context.Context, string, time.Duration) (*CustomerMerge, error)
 
 	// MetafieldsService used for Customer resource to communicate with Metafields resource
 	MetafieldsService
@@ -42,21 +48,28 @@ type Customer struct {
 	LastName            string             `json:"last_name,omitempty"`
 	State               string             `json:"state,omitempty"`
 	Note                string             `json:"note,omitempty"`
-	VerifiedEmail       bool               `json:"verified_email,omitempty"`
+	VerifiedEmail       *bool              `json:"verified_email,omitempty"`
 	MultipassIdentifier string             `json:"multipass_identifier,omitempty"`
 	OrdersCount         int                `json:"orders_count,omitempty"`
-	TaxExempt           bool               `json:"tax_exempt,omitempty"`
+	TaxExempt           *bool              `json:"tax_exempt,omitempty"`
 	TotalSpent          *decimal.Decimal   `json:"total_spent,omitempty"`
 	Phone               string             `json:"phone,omitempty"`
-	Tags                string             `json:"tags,omitempty"`
+	Tags                Tags               `json:"tags,omitempty"`
 	LastOrderId         int64              `json:"last_order_id,omitempty"`
 	LastOrderName       string             `json:"last_order_name,omitempty"`
-	AcceptsMarketing    bool               `json:"accepts_marketing,omitempty"`
+	AcceptsMarketing    *bool              `json:"accepts_marketing,omitempty"`
 	DefaultAddress      *CustomerAddress   `json:"default_address,omitempty"`
 	Addresses           []*CustomerAddress `json:"addresses,omitempty"`
 	CreatedAt           *time.Time         `json:"created_at,omitempty"`
 	UpdatedAt           *time.Time         `json:"updated_at,omitempty"`
 	Metafields          []Metafield        `json:"metafields,omitempty"`
+	NullFields          NullFields         `json:"-"`
+}
+
+// MarshalJSON encodes the customer, sending the NullFields as null.
+func (c Customer) MarshalJSON() ([]byte, error) {
+	type customer Customer
+	return marshalWithNullFields(customer(c), c.NullFields)
 }
 
 // Represents the result from the customers/X.json endpoint
@@ -86,9 +99,14 @@ type CustomerSearchOptions struct {
 // List customers
 func (s *CustomerServiceOp) List(options interface{}) ([]Customer, error) {
 	path := fmt.Sprintf("%s.json", customersBasePath)
-	resource := new(CustomersResource)
-	err := s.client.Get(path, resource, options)
-	return resource.Customers, err
+	return listResource[Customer](s.client, path, "customers", options)
+}
+
+// ListByIDs lists the customers with the IDs, however many there are, in
+// chunks of 250 IDs listed concurrently. Missing customers are left out.
+func (s *CustomerServiceOp) ListByIDs(ctx context.Context, ids []int64) ([]Customer, error) {
+	path := fmt.Sprintf("%s.json", customersBasePath)
+	return listResourceByIDs[Customer](ctx, s.client, path, "customers", ids, "")
 }
 
 // Count customers
@@ -100,27 +118,25 @@ func (s *CustomerServiceOp) Count(options interface{}) (int, error) {
 // Get customer
 func (s *CustomerServiceOp) Get(customerID int64, options interface{}) (*Customer, error) {
 	path := fmt.Sprintf("%s/%v.json", customersBasePath, customerID)
-	resource := new(CustomerResource)
-	err := s.client.Get(path, resource, options)
-	return resource.Customer, err
+	return getResource[Customer](s.client, path, "customer", options)
 }
 
 // Create a new customer
 func (s *CustomerServiceOp) Create(customer Customer) (*Customer, error) {
 	path := fmt.Sprintf("%s.json", customersBasePath)
-	wrappedData := CustomerResource{Customer: &customer}
-	resource := new(CustomerResource)
-	err := s.client.Post(path, wrappedData, resource)
-	return resource.Customer, err
+	return createResource(s.client, path, "customer", customer)
 }
 
 // Update an existing customer
 func (s *CustomerServiceOp) Update(customer Customer) (*Customer, error) {
 	path := fmt.Sprintf("%s/%d.json", customersBasePath, customer.ID)
-	wrappedData := CustomerResource{Customer: &customer}
-	resource := new(CustomerResource)
-	err := s.client.Put(path, wrappedData, resource)
-	return resource.Customer, err
+	return updateResource(s.client, path, "customer", customer)
+}
+
+// UpdateFields updates only the fields set in update on the customer, see Update.
+func (s *CustomerServiceOp) UpdateFields(customerID int64, update *Update) (*Customer, error) {
+	path := fmt.Sprintf("%s/%d.json", customersBasePath, customerID)
+	return updateFieldsResource[Customer](s.client, path, "customer", customerID, update)
 }
 
 // Delete an existing customer
@@ -132,25 +148,19 @@ func (s *CustomerServiceOp) Delete(customerID int64) error {
 // Search customers
 func (s *CustomerServiceOp) Search(options interface{}) ([]Customer, error) {
 	path := fmt.Sprintf("%s/search.json", customersBasePath)
-	resource := new(CustomersResource)
-	err := s.client.Get(path, resource, options)
-	return resource.Customers, err
+	return listResource[Customer](s.client, path, "customers", options)
 }
 
 // ListOrders retrieves all orders from a customer
 func (s *CustomerServiceOp) ListOrders(customerID int64, options interface{}) ([]Order, error) {
 	path := fmt.Sprintf("%s/%d/orders.json", customersBasePath, customerID)
-	resource := new(OrdersResource)
-	err := s.client.Get(path, resource, options)
-	return resource.Orders, err
+	return listResource[Order](s.client, path, "orders", options)
 }
 
 // ListTags retrieves all unique tags across all customers
 func (s *CustomerServiceOp) ListTags(options interface{}) ([]string, error) {
 	path := fmt.Sprintf("%s/tags.json", customersBasePath)
-	resource := new(CustomerTagsResource)
-	err := s.client.Get(path, resource, options)
-	return resource.Tags, err
+	return listResource[string](s.client, path, "tags", options)
 }
 
 // List metafields for a customer
@@ -39,7 +39,7 @@ type CustomerAddress struct {
 	ProvinceCode string `json:"province_code,omitempty"`
 	CountryCode  string `json:"country_code,omitempty"`
 	CountryName  string `json:"country_name,omitempty"`
-	Default      bool   `json:"default,omitempty"`
+	Default      *bool  `json:"default,omitempty"`
 }
 
 // CustomerAddressResoruce represents the result from the addresses/X.json endpoint
@@ -55,35 +55,25 @@ type CustomerAddressesResource struct {
 // List addresses
 func (s *CustomerAddressServiceOp) List(customerID int64, options interface{}) ([]CustomerAddress, error) {
 	path := fmt.Sprintf("%s/%d/addresses.json", customersBasePath, customerID)
-	resource := new(CustomerAddressesResource)
-	err := s.client.Get(path, resource, options)
-	return resource.Addresses, err
+	return listResource[CustomerAddress](s.client, path, "addresses", options)
 }
 
 // Get address
 func (s *CustomerAddressServiceOp) Get(customerID, addressID int64, options interface{}) (*CustomerAddress, error) {
 	path := fmt.Sprintf("%s/%d/addresses/%d.json", customersBasePath, customerID, addressID)
-	resource := new(CustomerAddressResource)
-	err := s.client.Get(path, resource, options)
-	return resource.Address, err
+	return getResource[CustomerAddress](s.client, path, "customer_address", options)
 }
 
 // Create a new address for given customer
 func (s *CustomerAddressServiceOp) Create(customerID int64, address CustomerAddress) (*CustomerAddress, error) {
 	path := fmt.Sprintf("%s/%d/addresses.json", customersBasePath, customerID)
-	wrappedData := CustomerAddressResource{Address: &address}
-	resource := new(CustomerAddressResource)
-	err := s.client.Post(path, wrappedData, resource)
-	return resource.Address, err
+	return createResource(s.client, path, "customer_address", address)
 }
 
 // Create a new address for given customer
 func (s *CustomerAddressServiceOp) Update(customerID int64, address CustomerAddress) (*CustomerAddress, error) {
 	path := fmt.Sprintf("%s/%d/addresses/%d.json", customersBasePath, customerID, address.ID)
-	wrappedData := CustomerAddressResource{Address: &address}
-	resource := new(CustomerAddressResource)
-	err := s.client.Put(path, wrappedData, resource)
-	return resource.Address, err
+	return updateResource(s.client, path, "customer_address", address)
 }
 
 // Delete an existing address
@@ -0,0 +1,159 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Statuses of a customer merge job.
+const (
+	CustomerMergeStatusRequested  = "REQUESTED"
+	CustomerMergeStatusInProgress = "IN_PROGRESS"
+	CustomerMergeStatusCompleted  = "COMPLETED"
+	CustomerMergeStatusFailed     = "FAILED"
+)
+
+// defaultCustomerMergePollInterval is how often WaitMerge checks on a merge
+// when no interval is given.
+const defaultCustomerMergePollInterval = 2 * time.Second
+
+// CustomerMerge is the state of a merge of two customers, which Shopify runs
+// as a job. The discarded customer is deleted once the merge completed, see
+// Merge. Errors holds the reasons a failed merge failed.
+type CustomerMerge struct {
+	JobID               string
+	Status              string
+	ResultingCustomerID int64
+	Errors              []CustomerMergeError
+}
+
+// CustomerMergeError is a reason a customer merge failed, e.g. both customers
+// having a gift card.
+type CustomerMergeError struct {
+	ErrorFields []string `json:"errorFields"`
+	Message     string   `json:"message"`
+}
+
+// Done reports whether the merge completed or failed.
+func (m CustomerMerge) Done() bool {
+	return m.Status == CustomerMergeStatusCompleted || m.Status == CustomerMergeStatusFailed
+}
+
+const customerMergeMutation = `mutation($customerOneId: ID!, $customerTwoId: ID!) {
+  customerMerge(customerOneId: $customerOneId, customerTwoId: $customerTwoId) {
+    job { id done }
+    resultingCustomerId
+    userErrors { field message }
+  }
+}`
+
+const customerMergeJobStatusQuery = `query($jobId: ID!) {
+  customerMergeJobStatus(jobId: $jobId) {
+    jobId
+    status
+    resultingCustomerId
+    customerMergeErrors { errorFields message }
+  }
+}`
+
+// Merge merges the customer discardID into keepID through the GraphQL
+// customerMerge mutation. The addresses, orders, tags and other records of
+// both customers end up on keepID and discardID is deleted. The merge runs as
+// a job, see WaitMerge.
+func (s *CustomerServiceOp) Merge(keepID, discardID int64) (*CustomerMerge, error) {
+	// the second customer of the mutation is the one that remains
+	vars := map[string]interface{}{
+		"customerOneId": customerGID(discardID),
+		"customerTwoId": customerGID(keepID),
+	}
+	resp := struct {
+		CustomerMerge struct {
+			Job *struct {
+				ID   string `json:"id"`
+				Done bool   `json:"done"`
+			} `json:"job"`
+			ResultingCustomerID string      `json:"resultingCustomerId"`
+			UserErrors          []UserError `json:"userErrors"`
+		} `json:"customerMerge"`
+	}{}
+
+	err := s.client.GraphQL.Query(customerMergeMutation, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if err := userErrorsToError(resp.CustomerMerge.UserErrors); err != nil {
+		return nil, err
+	}
+
+	merge := &CustomerMerge{
+		Status:              CustomerMergeStatusRequested,
+		ResultingCustomerID: gidToID(resp.CustomerMerge.ResultingCustomerID),
+	}
+	if job := resp.CustomerMerge.Job; job != nil {
+		merge.JobID = job.ID
+		if job.Done {
+			merge.Status = CustomerMergeStatusCompleted
+		}
+	}
+	return merge, nil
+}
+
+// MergeStatus gets the state of the customer merge job, nil if there is no
+// such job.
+func (s *CustomerServiceOp) MergeStatus(jobID string) (*CustomerMerge, error) {
+	resp := struct {
+		CustomerMergeJobStatus *struct {
+			JobID               string               `json:"jobId"`
+			Status              string               `json:"status"`
+			ResultingCustomerID string               `json:"resultingCustomerId"`
+			CustomerMergeErrors []CustomerMergeError `json:"customerMergeErrors"`
+		} `json:"customerMergeJobStatus"`
+	}{}
+
+	err := s.client.GraphQL.Query(customerMergeJobStatusQuery, map[string]interface{}{"jobId": jobID}, &resp)
+	if err != nil || resp.CustomerMergeJobStatus == nil {
+		return nil, err
+	}
+
+	status := resp.CustomerMergeJobStatus
+	return &CustomerMerge{
+		JobID:               status.JobID,
+		Status:              status.Status,
+		ResultingCustomerID: gidToID(status.ResultingCustomerID),
+		Errors:              status.CustomerMergeErrors,
+	}, nil
+}
+
+// WaitMerge polls the customer merge job every interval, 2 seconds when
+// zero, until it is done or the context is done. A failed merge is returned
+// along with an error holding its merge errors.
+func (s *CustomerServiceOp) WaitMerge(ctx context.Context, jobID string, interval time.Duration) (*CustomerMerge, error) {
+	if interval <= 0 {
+		interval = defaultCustomerMergePollInterval
+	}
+
+	for {
+		merge, err := s.MergeStatus(jobID)
+		if err != nil {
+			return nil, err
+		}
+		if merge == nil {
+			return nil, fmt.Errorf("customer merge job %s not found", jobID)
+		}
+		if merge.Status == CustomerMergeStatusFailed {
+			messages := make([]string, 0, len(merge.Errors))
+			for _, e := range merge.Errors {
+				messages = append(messages, e.Message)
+			}
+			return merge, fmt.Errorf("customer merge job %s failed: %s", jobID, strings.Join(messages, ", "))
+		}
+		if merge.Done() {
+			return merge, nil
+		}
+		if err := sleepContext(ctx, interval); err != nil {
+			return merge, err
+		}
+	}
+}
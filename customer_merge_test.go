@@ -0,0 +1,102 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestCustomerMerge(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests, `{"data":{"customerMerge":{"job":{"id":"gid://shopify/Job/abc","done":false},"resultingCustomerId":"gid://shopify/Customer/1","userErrors":[]}}}`))
+
+	merge, err := client.Customer.Merge(1, 2)
+	if err != nil {
+		t.Fatalf("Customer.Merge returned error: %v", err)
+	}
+
+	expected := &CustomerMerge{JobID: "gid://shopify/Job/abc", Status: CustomerMergeStatusRequested, ResultingCustomerID: 1}
+	if !reflect.DeepEqual(merge, expected) {
+		t.Errorf("Customer.Merge returned %+v, expected %+v", merge, expected)
+	}
+
+	expectedVars := map[string]interface{}{
+		"customerOneId": "gid://shopify/Customer/2",
+		"customerTwoId": "gid://shopify/Customer/1",
+	}
+	if !reflect.DeepEqual(requests[0].Variables, expectedVars) {
+		t.Errorf("Customer.Merge sent %+v, expected %+v", requests[0].Variables, expectedVars)
+	}
+}
+
+func TestCustomerMergeUserErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"data":{"customerMerge":{"job":null,"resultingCustomerId":null,"userErrors":[{"field":["customerTwoId"],"message":"Customers must be different"}]}}}`))
+
+	if _, err := client.Customer.Merge(1, 1); err == nil {
+		t.Error("Customer.Merge expected the user error")
+	}
+}
+
+func TestCustomerWaitMerge(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests,
+			`{"data":{"customerMergeJobStatus":{"jobId":"gid://shopify/Job/abc","status":"IN_PROGRESS","resultingCustomerId":"gid://shopify/Customer/1","customerMergeErrors":[]}}}`,
+			`{"data":{"customerMergeJobStatus":{"jobId":"gid://shopify/Job/abc","status":"COMPLETED","resultingCustomerId":"gid://shopify/Customer/1","customerMergeErrors":[]}}}`,
+		))
+
+	merge, err := client.Customer.WaitMerge(context.Background(), "gid://shopify/Job/abc", time.Millisecond)
+	if err != nil {
+		t.Fatalf("Customer.WaitMerge returned error: %v", err)
+	}
+	if merge.Status != CustomerMergeStatusCompleted || merge.ResultingCustomerID != 1 || len(requests) != 2 {
+		t.Errorf("Customer.WaitMerge returned %+v after %d requests", merge, len(requests))
+	}
+	if jobID := requests[0].Variables.(map[string]interface{})["jobId"]; jobID != "gid://shopify/Job/abc" {
+		t.Errorf("Customer.WaitMerge sent job %v", jobID)
+	}
+}
+
+func TestCustomerWaitMergeFailed(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"data":{"customerMergeJobStatus":{"jobId":"gid://shopify/Job/abc","status":"FAILED","resultingCustomerId":"gid://shopify/Customer/1","customerMergeErrors":[{"errorFields":["GIFT_CARDS"],"message":"Both customers have gift cards"}]}}}`))
+
+	merge, err := client.Customer.WaitMerge(context.Background(), "gid://shopify/Job/abc", time.Millisecond)
+	if merge == nil || merge.Status != CustomerMergeStatusFailed || len(merge.Errors) != 1 {
+		t.Errorf("Customer.WaitMerge returned %+v, expected the failed merge", merge)
+	}
+	if err == nil || err.Error() != "customer merge job gid://shopify/Job/abc failed: Both customers have gift cards" {
+		t.Errorf("Customer.WaitMerge returned error %v", err)
+	}
+}
+
+func TestCustomerWaitMergeNotFound(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"data":{"customerMergeJobStatus":null}}`))
+
+	_, err := client.Customer.WaitMerge(context.Background(), "gid://shopify/Job/abc", time.Millisecond)
+	if err == nil || err.Error() != "customer merge job gid://shopify/Job/abc not found" {
+		t.Errorf("Customer.WaitMerge returned error %v, expected not found", err)
+	}
+}
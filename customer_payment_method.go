@@ -0,0 +1,277 @@
+package goshopify
+
+import (
+	"fmt"
+	"time"
+)
+
+// Types of the instrument of a payment method.
+const (
+	PaymentInstrumentCreditCard     = "CustomerCreditCard"
+	PaymentInstrumentPaypal         = "CustomerPaypalBillingAgreement"
+	PaymentInstrumentShopPay        = "CustomerShopPayAgreement"
+	PaymentInstrumentRemoteProvided = "CustomerRemotePaymentMethod"
+)
+
+// CustomerPaymentMethodService is an interface for interfacing with the customer payment method endpoints
+// of the Shopify API.
+// See: https://shopify.dev/docs/api/admin-graphql/latest/objects/CustomerPaymentMethod
+type CustomerPaymentMethodService interface {
+	List(int64) ([]CustomerPaymentMethod, error)
+	Get(string) (*CustomerPaymentMethod, error)
+	SendUpdateEmail(string, *CustomerPaymentMethodEmail) error
+	CreateRemote(int64, CustomerPaymentMethodRemoteInput) (*CustomerPaymentMethod, error)
+	Revoke(string) error
+}
+
+// CustomerPaymentMethodServiceOp handles communication with the customer
+// payment method related GraphQL queries and mutations.
+type CustomerPaymentMethodServiceOp struct {
+	client *Client
+}
+
+// CustomerPaymentMethod represents a payment method of a customer. The
+// details of its instrument are flattened, which of them are set depends on
+// the InstrumentType, e.g. a credit card has a Brand and LastDigits. Payment
+// methods imported with CreateRemote have no details until Shopify verified
+// them.
+type CustomerPaymentMethod struct {
+	ID                 string     `json:"id"`
+	InstrumentType     string     `json:"instrumentType,omitempty"`
+	Brand              string     `json:"brand,omitempty"`
+	Name               string     `json:"name,omitempty"`
+	LastDigits         string     `json:"lastDigits,omitempty"`
+	MaskedNumber       string     `json:"maskedNumber,omitempty"`
+	ExpiryMonth        int        `json:"expiryMonth,omitempty"`
+	ExpiryYear         int        `json:"expiryYear,omitempty"`
+	PaypalAccountEmail string     `json:"paypalAccountEmail,omitempty"`
+	RevokedAt          *time.Time `json:"revokedAt,omitempty"`
+	RevokedReason      string     `json:"revokedReason,omitempty"`
+}
+
+// CustomerPaymentMethodEmail customizes the email asking a customer to update
+// a payment method, the defaults of the shop are used when it is nil.
+type CustomerPaymentMethodEmail struct {
+	To            string   `json:"to,omitempty"`
+	From          string   `json:"from,omitempty"`
+	Subject       string   `json:"subject,omitempty"`
+	CustomMessage string   `json:"customMessage,omitempty"`
+	BCC           []string `json:"bcc,omitempty"`
+}
+
+// CustomerPaymentMethodRemoteInput refers to a payment method stored with a
+// payment processor, exactly one of the processors must be set.
+type CustomerPaymentMethodRemoteInput struct {
+	StripePaymentMethod                *RemoteStripePaymentMethod        `json:"stripePaymentMethod,omitempty"`
+	AuthorizeNetCustomerPaymentProfile *RemoteAuthorizeNetPaymentProfile `json:"authorizeNetCustomerPaymentProfile,omitempty"`
+	BraintreePaymentMethod             *RemoteBraintreePaymentMethod     `json:"braintreePaymentMethod,omitempty"`
+}
+
+// RemoteStripePaymentMethod refers to a Stripe customer and optionally one of
+// its payment methods, otherwise its default one.
+type RemoteStripePaymentMethod struct {
+	CustomerID      string `json:"customerId"`
+	PaymentMethodID string `json:"paymentMethodId,omitempty"`
+}
+
+// RemoteAuthorizeNetPaymentProfile refers to an Authorize.net customer
+// payment profile.
+type RemoteAuthorizeNetPaymentProfile struct {
+	CustomerProfileID        string `json:"customerProfileId"`
+	CustomerPaymentProfileID string `json:"customerPaymentProfileId,omitempty"`
+}
+
+// RemoteBraintreePaymentMethod refers to a Braintree customer and optionally
+// one of its payment methods.
+type RemoteBraintreePaymentMethod struct {
+	CustomerID         string `json:"customerId"`
+	PaymentMethodToken string `json:"paymentMethodToken,omitempty"`
+}
+
+// customerPaymentMethodNode is the GraphQL representation of a payment
+// method, its details are nested in a type specific instrument.
+type customerPaymentMethodNode struct {
+	ID            string     `json:"id"`
+	RevokedAt     *time.Time `json:"revokedAt"`
+	RevokedReason string     `json:"revokedReason"`
+	Instrument    *struct {
+		Typename           string `json:"__typename"`
+		Brand              string `json:"brand"`
+		Name               string `json:"name"`
+		LastDigits         string `json:"lastDigits"`
+		MaskedNumber       string `json:"maskedNumber"`
+		ExpiryMonth        int    `json:"expiryMonth"`
+		ExpiryYear         int    `json:"expiryYear"`
+		PaypalAccountEmail string `json:"paypalAccountEmail"`
+	} `json:"instrument"`
+}
+
+func (n *customerPaymentMethodNode) paymentMethod() *CustomerPaymentMethod {
+	if n == nil {
+		return nil
+	}
+	method := &CustomerPaymentMethod{
+		ID:            n.ID,
+		RevokedAt:     n.RevokedAt,
+		RevokedReason: n.RevokedReason,
+	}
+	if i := n.Instrument; i != nil {
+		method.InstrumentType = i.Typename
+		method.Brand = i.Brand
+		method.Name = i.Name
+		method.LastDigits = i.LastDigits
+		method.MaskedNumber = i.MaskedNumber
+		method.ExpiryMonth = i.ExpiryMonth
+		method.ExpiryYear = i.ExpiryYear
+		method.PaypalAccountEmail = i.PaypalAccountEmail
+	}
+	return method
+}
+
+func customerGID(customerID int64) string {
+	return fmt.Sprintf("gid://shopify/Customer/%d", customerID)
+}
+
+const customerPaymentMethodFields = `id revokedAt revokedReason
+  instrument {
+    __typename
+    ... on CustomerCreditCard { brand name lastDigits maskedNumber expiryMonth expiryYear }
+    ... on CustomerShopPayAgreement { name lastDigits maskedNumber expiryMonth expiryYear }
+    ... on CustomerPaypalBillingAgreement { paypalAccountEmail }
+  }`
+
+const customerPaymentMethodsQuery = `query($id: ID!, $after: String) {
+  customer(id: $id) {
+    paymentMethods(first: 50, after: $after, showRevoked: true) {
+      edges { node { ` + customerPaymentMethodFields + ` } }
+      pageInfo { hasNextPage endCursor }
+    }
+  }
+}`
+
+const customerPaymentMethodQuery = `query($id: ID!) {
+  customerPaymentMethod(id: $id, showRevoked: true) { ` + customerPaymentMethodFields + ` }
+}`
+
+const customerPaymentMethodSendUpdateEmailMutation = `mutation($customerPaymentMethodId: ID!, $email: EmailInput) {
+  customerPaymentMethodSendUpdateEmail(customerPaymentMethodId: $customerPaymentMethodId, email: $email) {
+    customer { id }
+    userErrors { field message }
+  }
+}`
+
+const customerPaymentMethodRemoteCreateMutation = `mutation($customerId: ID!, $remoteReference: CustomerPaymentMethodRemoteInput!) {
+  customerPaymentMethodRemoteCreate(customerId: $customerId, remoteReference: $remoteReference) {
+    customerPaymentMethod { ` + customerPaymentMethodFields + ` }
+    userErrors { field message }
+  }
+}`
+
+const customerPaymentMethodRevokeMutation = `mutation($customerPaymentMethodId: ID!) {
+  customerPaymentMethodRevoke(customerPaymentMethodId: $customerPaymentMethodId) {
+    revokedCustomerPaymentMethodId
+    userErrors { field message }
+  }
+}`
+
+// List the payment methods of a customer, including revoked ones.
+func (s *CustomerPaymentMethodServiceOp) List(customerID int64) ([]CustomerPaymentMethod, error) {
+	var methods []CustomerPaymentMethod
+	var after *string
+	for {
+		vars := map[string]interface{}{
+			"id":    customerGID(customerID),
+			"after": after,
+		}
+		resp := struct {
+			Customer *struct {
+				PaymentMethods struct {
+					Edges []struct {
+						Node customerPaymentMethodNode `json:"node"`
+					} `json:"edges"`
+					PageInfo graphQLPageInfo `json:"pageInfo"`
+				} `json:"paymentMethods"`
+			} `json:"customer"`
+		}{}
+
+		err := s.client.GraphQL.Query(customerPaymentMethodsQuery, vars, &resp)
+		if err != nil || resp.Customer == nil {
+			return methods, err
+		}
+
+		for i := range resp.Customer.PaymentMethods.Edges {
+			methods = append(methods, *resp.Customer.PaymentMethods.Edges[i].Node.paymentMethod())
+		}
+
+		pageInfo := resp.Customer.PaymentMethods.PageInfo
+		if !pageInfo.HasNextPage {
+			return methods, nil
+		}
+		after = &pageInfo.EndCursor
+	}
+}
+
+// Get a payment method by its ID, nil if there is none.
+func (s *CustomerPaymentMethodServiceOp) Get(id string) (*CustomerPaymentMethod, error) {
+	resp := struct {
+		CustomerPaymentMethod *customerPaymentMethodNode `json:"customerPaymentMethod"`
+	}{}
+	err := s.client.GraphQL.Query(customerPaymentMethodQuery, map[string]interface{}{"id": id}, &resp)
+	return resp.CustomerPaymentMethod.paymentMethod(), err
+}
+
+// SendUpdateEmail emails the customer a link to update a payment method, e.g.
+// after billing a subscription with it failed.
+func (s *CustomerPaymentMethodServiceOp) SendUpdateEmail(id string, email *CustomerPaymentMethodEmail) error {
+	vars := map[string]interface{}{
+		"customerPaymentMethodId": id,
+		"email":                   email,
+	}
+	resp := struct {
+		CustomerPaymentMethodSendUpdateEmail struct {
+			UserErrors []UserError `json:"userErrors"`
+		} `json:"customerPaymentMethodSendUpdateEmail"`
+	}{}
+
+	err := s.client.GraphQL.Query(customerPaymentMethodSendUpdateEmailMutation, vars, &resp)
+	if err != nil {
+		return err
+	}
+	return userErrorsToError(resp.CustomerPaymentMethodSendUpdateEmail.UserErrors)
+}
+
+// CreateRemote imports a payment method stored with a payment processor for a
+// customer, e.g. when migrating subscriptions to Shopify.
+func (s *CustomerPaymentMethodServiceOp) CreateRemote(customerID int64, input CustomerPaymentMethodRemoteInput) (*CustomerPaymentMethod, error) {
+	vars := map[string]interface{}{
+		"customerId":      customerGID(customerID),
+		"remoteReference": input,
+	}
+	resp := struct {
+		CustomerPaymentMethodRemoteCreate struct {
+			CustomerPaymentMethod *customerPaymentMethodNode `json:"customerPaymentMethod"`
+			UserErrors            []UserError                `json:"userErrors"`
+		} `json:"customerPaymentMethodRemoteCreate"`
+	}{}
+
+	err := s.client.GraphQL.Query(customerPaymentMethodRemoteCreateMutation, vars, &resp)
+	if err == nil {
+		err = userErrorsToError(resp.CustomerPaymentMethodRemoteCreate.UserErrors)
+	}
+	return resp.CustomerPaymentMethodRemoteCreate.CustomerPaymentMethod.paymentMethod(), err
+}
+
+// Revoke a payment method, it can no longer be billed.
+func (s *CustomerPaymentMethodServiceOp) Revoke(id string) error {
+	resp := struct {
+		CustomerPaymentMethodRevoke struct {
+			UserErrors []UserError `json:"userErrors"`
+		} `json:"customerPaymentMethodRevoke"`
+	}{}
+
+	err := s.client.GraphQL.Query(customerPaymentMethodRevokeMutation, map[string]interface{}{"customerPaymentMethodId": id}, &resp)
+	if err != nil {
+		return err
+	}
+	return userErrorsToError(resp.CustomerPaymentMethodRevoke.UserErrors)
+}
@@ -0,0 +1,160 @@
+package goshopify
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestCustomerPaymentMethodList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests,
+			`{"data":{"customer":{"paymentMethods":{"edges":[{"node":{"id":"gid://shopify/CustomerPaymentMethod/a","revokedAt":null,"instrument":{"__typename":"CustomerCreditCard","brand":"visa","name":"Jane Doe","lastDigits":"4242","maskedNumber":"•••• •••• •••• 4242","expiryMonth":12,"expiryYear":2030}}}],"pageInfo":{"hasNextPage":true,"endCursor":"abc"}}}}}`,
+			`{"data":{"customer":{"paymentMethods":{"edges":[{"node":{"id":"gid://shopify/CustomerPaymentMethod/b","revokedAt":"2024-01-02T03:04:05Z","revokedReason":"CUSTOMER_REVOKED","instrument":{"__typename":"CustomerPaypalBillingAgreement","paypalAccountEmail":"jane@example.com"}}}],"pageInfo":{"hasNextPage":false}}}}}`,
+		))
+
+	methods, err := client.CustomerPaymentMethod.List(1)
+	if err != nil {
+		t.Fatalf("CustomerPaymentMethod.List returned error: %v", err)
+	}
+
+	revokedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	expected := []CustomerPaymentMethod{
+		{
+			ID:             "gid://shopify/CustomerPaymentMethod/a",
+			InstrumentType: PaymentInstrumentCreditCard,
+			Brand:          "visa",
+			Name:           "Jane Doe",
+			LastDigits:     "4242",
+			MaskedNumber:   "•••• •••• •••• 4242",
+			ExpiryMonth:    12,
+			ExpiryYear:     2030,
+		},
+		{
+			ID:                 "gid://shopify/CustomerPaymentMethod/b",
+			InstrumentType:     PaymentInstrumentPaypal,
+			PaypalAccountEmail: "jane@example.com",
+			RevokedAt:          &revokedAt,
+			RevokedReason:      "CUSTOMER_REVOKED",
+		},
+	}
+	if !reflect.DeepEqual(methods, expected) {
+		t.Errorf("CustomerPaymentMethod.List returned %+v, expected %+v", methods, expected)
+	}
+	if id := requests[0].Variables.(map[string]interface{})["id"]; id != "gid://shopify/Customer/1" {
+		t.Errorf("CustomerPaymentMethod.List sent customer %v", id)
+	}
+	if len(requests) != 2 || requests[1].Variables.(map[string]interface{})["after"] != "abc" {
+		t.Errorf("CustomerPaymentMethod.List sent %+v, expected the second page after abc", requests)
+	}
+}
+
+func TestCustomerPaymentMethodGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests,
+			`{"data":{"customerPaymentMethod":{"id":"gid://shopify/CustomerPaymentMethod/a","instrument":{"__typename":"CustomerShopPayAgreement","lastDigits":"1111","expiryMonth":1,"expiryYear":2029}}}}`,
+			`{"data":{"customerPaymentMethod":null}}`,
+		))
+
+	method, err := client.CustomerPaymentMethod.Get("gid://shopify/CustomerPaymentMethod/a")
+	if err != nil {
+		t.Fatalf("CustomerPaymentMethod.Get returned error: %v", err)
+	}
+	expected := &CustomerPaymentMethod{
+		ID:             "gid://shopify/CustomerPaymentMethod/a",
+		InstrumentType: PaymentInstrumentShopPay,
+		LastDigits:     "1111",
+		ExpiryMonth:    1,
+		ExpiryYear:     2029,
+	}
+	if !reflect.DeepEqual(method, expected) {
+		t.Errorf("CustomerPaymentMethod.Get returned %+v, expected %+v", method, expected)
+	}
+
+	method, err = client.CustomerPaymentMethod.Get("gid://shopify/CustomerPaymentMethod/z")
+	if err != nil || method != nil {
+		t.Errorf("CustomerPaymentMethod.Get of a missing payment method returned %+v, %v", method, err)
+	}
+}
+
+func TestCustomerPaymentMethodSendUpdateEmail(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests, `{"data":{"customerPaymentMethodSendUpdateEmail":{"customer":{"id":"gid://shopify/Customer/1"},"userErrors":[]}}}`))
+
+	err := client.CustomerPaymentMethod.SendUpdateEmail("gid://shopify/CustomerPaymentMethod/a", &CustomerPaymentMethodEmail{
+		Subject:       "Your card expires soon",
+		CustomMessage: "Please update it to keep your subscription.",
+	})
+	if err != nil {
+		t.Fatalf("CustomerPaymentMethod.SendUpdateEmail returned error: %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"customerPaymentMethodId": "gid://shopify/CustomerPaymentMethod/a",
+		"email": map[string]interface{}{
+			"subject":       "Your card expires soon",
+			"customMessage": "Please update it to keep your subscription.",
+		},
+	}
+	if !reflect.DeepEqual(requests[0].Variables, expected) {
+		t.Errorf("CustomerPaymentMethod.SendUpdateEmail sent %+v, expected %+v", requests[0].Variables, expected)
+	}
+}
+
+func TestCustomerPaymentMethodCreateRemote(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests, `{"data":{"customerPaymentMethodRemoteCreate":{"customerPaymentMethod":{"id":"gid://shopify/CustomerPaymentMethod/c","instrument":null},"userErrors":[]}}}`))
+
+	method, err := client.CustomerPaymentMethod.CreateRemote(1, CustomerPaymentMethodRemoteInput{
+		StripePaymentMethod: &RemoteStripePaymentMethod{CustomerID: "cus_1", PaymentMethodID: "pm_1"},
+	})
+	if err != nil {
+		t.Fatalf("CustomerPaymentMethod.CreateRemote returned error: %v", err)
+	}
+	if expected := (&CustomerPaymentMethod{ID: "gid://shopify/CustomerPaymentMethod/c"}); !reflect.DeepEqual(method, expected) {
+		t.Errorf("CustomerPaymentMethod.CreateRemote returned %+v, expected %+v", method, expected)
+	}
+
+	expected := map[string]interface{}{
+		"customerId": "gid://shopify/Customer/1",
+		"remoteReference": map[string]interface{}{
+			"stripePaymentMethod": map[string]interface{}{"customerId": "cus_1", "paymentMethodId": "pm_1"},
+		},
+	}
+	if !reflect.DeepEqual(requests[0].Variables, expected) {
+		t.Errorf("CustomerPaymentMethod.CreateRemote sent %+v, expected %+v", requests[0].Variables, expected)
+	}
+}
+
+func TestCustomerPaymentMethodRevoke(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests, `{"data":{"customerPaymentMethodRevoke":{"revokedCustomerPaymentMethodId":null,"userErrors":[{"field":["customerPaymentMethodId"],"message":"Customer payment method does not exist"}]}}}`))
+
+	err := client.CustomerPaymentMethod.Revoke("gid://shopify/CustomerPaymentMethod/z")
+	if err == nil || err.Error() != "customerPaymentMethodId: Customer payment method does not exist" {
+		t.Errorf("CustomerPaymentMethod.Revoke returned %v, expected the user error", err)
+	}
+}
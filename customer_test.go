@@ -1,6 +1,7 @@
 package goshopify
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"testing"
@@ -97,7 +98,7 @@ func TestCustomerGet(t *testing.T) {
 	address1 := &CustomerAddress{ID: 1, CustomerID: 1, FirstName: "Test", LastName: "Citizen", Company: "",
 		Address1: "1 Smith St", Address2: "", City: "BRISBANE", Province: "Queensland", Country: "Australia",
 		Zip: "4000", Phone: "1111 111 111", Name: "Test Citizen", ProvinceCode: "QLD", CountryCode: "AU",
-		CountryName: "Australia", Default: true}
+		CountryName: "Australia", Default: Bool(true)}
 	createdAt := time.Date(2017, time.September, 23, 18, 15, 47, 0, time.UTC)
 	updatedAt := time.Date(2017, time.September, 23, 18, 15, 47, 0, time.UTC)
 	totalSpent := decimal.NewFromFloat(278.60)
@@ -107,9 +108,9 @@ func TestCustomerGet(t *testing.T) {
 		Email:            "test@example.com",
 		FirstName:        "Test",
 		LastName:         "Citizen",
-		AcceptsMarketing: true,
-		VerifiedEmail:    true,
-		TaxExempt:        false,
+		AcceptsMarketing: Bool(true),
+		VerifiedEmail:    Bool(true),
+		TaxExempt:        Bool(false),
 		OrdersCount:      4,
 		State:            "enabled",
 		TotalSpent:       &totalSpent,
@@ -134,8 +135,8 @@ func TestCustomerGet(t *testing.T) {
 	if customer.LastName != expectation.LastName {
 		t.Errorf("Customer.LastName returned %+v, expected %+v", customer.LastName, expectation.LastName)
 	}
-	if customer.AcceptsMarketing != expectation.AcceptsMarketing {
-		t.Errorf("Customer.AcceptsMarketing returned %+v, expected %+v", customer.AcceptsMarketing, expectation.AcceptsMarketing)
+	if *customer.AcceptsMarketing != *expectation.AcceptsMarketing {
+		t.Errorf("Customer.AcceptsMarketing returned %+v, expected %+v", *customer.AcceptsMarketing, *expectation.AcceptsMarketing)
 	}
 	if !customer.CreatedAt.Equal(*expectation.CreatedAt) {
 		t.Errorf("Customer.CreatedAt returned %+v, expected %+v", customer.CreatedAt, expectation.CreatedAt)
@@ -158,11 +159,11 @@ func TestCustomerGet(t *testing.T) {
 	if customer.Note != expectation.Note {
 		t.Errorf("Customer.Note returned %+v, expected %+v", customer.Note, expectation.Note)
 	}
-	if customer.VerifiedEmail != expectation.VerifiedEmail {
-		t.Errorf("Customer.Note returned %+v, expected %+v", customer.VerifiedEmail, expectation.VerifiedEmail)
+	if *customer.VerifiedEmail != *expectation.VerifiedEmail {
+		t.Errorf("Customer.Note returned %+v, expected %+v", *customer.VerifiedEmail, *expectation.VerifiedEmail)
 	}
-	if customer.TaxExempt != expectation.TaxExempt {
-		t.Errorf("Customer.TaxExempt returned %+v, expected %+v", customer.TaxExempt, expectation.TaxExempt)
+	if *customer.TaxExempt != *expectation.TaxExempt {
+		t.Errorf("Customer.TaxExempt returned %+v, expected %+v", *customer.TaxExempt, *expectation.TaxExempt)
 	}
 	if customer.Phone != expectation.Phone {
 		t.Errorf("Customer.Phone returned %+v, expected %+v", customer.Phone, expectation.Phone)
@@ -221,8 +222,8 @@ func TestCustomerGet(t *testing.T) {
 		if customer.DefaultAddress.CountryName != expectation.DefaultAddress.CountryName {
 			t.Errorf("Customer.DefaultAddress.CountryName returned %+v, expected %+v", customer.DefaultAddress.CountryName, expectation.DefaultAddress.CountryName)
 		}
-		if customer.DefaultAddress.Default != expectation.DefaultAddress.Default {
-			t.Errorf("Customer.DefaultAddress.Default returned %+v, expected %+v", customer.DefaultAddress.Default, expectation.DefaultAddress.Default)
+		if *customer.DefaultAddress.Default != *expectation.DefaultAddress.Default {
+			t.Errorf("Customer.DefaultAddress.Default returned %+v, expected %+v", *customer.DefaultAddress.Default, *expectation.DefaultAddress.Default)
 		}
 	}
 	if len(customer.Addresses) != len(expectation.Addresses) {
@@ -239,7 +240,7 @@ func TestCustomerUpdate(t *testing.T) {
 
 	customer := Customer{
 		ID:   1,
-		Tags: "new",
+		Tags: Tags{"new"},
 	}
 
 	returnedCustomer, err := client.Customer.Update(customer)
@@ -262,7 +263,7 @@ func TestCustomerCreate(t *testing.T) {
 
 	customer := Customer{
 		ID:   1,
-		Tags: "new",
+		Tags: Tags{"new"},
 	}
 
 	returnedCustomer, err := client.Customer.Create(customer)
@@ -485,3 +486,16 @@ func TestCustomerListTags(t *testing.T) {
 		t.Errorf("Customer.ListTags got %v as the first tag, expected: 'tag1'", tags[0])
 	}
 }
+
+func TestCustomerListByIDsError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/customers.json", client.pathPrefix),
+		httpmock.NewStringResponder(500, `{"errors": "Internal Server Error"}`))
+
+	customers, err := client.Customer.ListByIDs(context.Background(), []int64{1, 2})
+	if err == nil || customers != nil {
+		t.Errorf("Customer.ListByIDs returned %+v, %v, expected an error", customers, err)
+	}
+}
@@ -0,0 +1,63 @@
+package goshopify
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// redactedValue replaces secrets in debug logs.
+const redactedValue = "[REDACTED]"
+
+// redactedHeaders are never logged, see WithDebugDumps.
+var redactedHeaders = map[string]bool{
+	"Authorization":                     true,
+	"X-Shopify-Access-Token":            true,
+	"X-Shopify-Storefront-Access-Token": true,
+	"Cookie":                            true,
+	"Set-Cookie":                        true,
+}
+
+// redactedBodyRegex matches the secrets sent and received by the OAuth and
+// token exchange endpoints, which are redacted from logged bodies.
+var redactedBodyRegex = regexp.MustCompile(`("(?:access_token|client_secret|subject_token|password)"\s*:\s*")[^"]*"`)
+
+// logHeaders logs the headers of a request or response when debug dumps are
+// enabled, with the credentials redacted.
+func (c *Client) logHeaders(header http.Header, format string) {
+	if !c.debugDumps || len(header) == 0 {
+		return
+	}
+	c.log.Debugf(format, dumpHeaders(header))
+}
+
+// dumpHeaders formats headers one per line, sorted by name.
+func dumpHeaders(header http.Header) string {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	lines := make([]string, 0, len(names))
+	for _, name := range names {
+		value := strings.Join(header[name], ", ")
+		if redactedHeaders[http.CanonicalHeaderKey(name)] {
+			value = redactedValue
+		}
+		lines = append(lines, fmt.Sprintf("%s: %s", name, value))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// dumpBody redacts the secrets of a body and, with debug dumps, truncates it
+// to the configured size.
+func (c *Client) dumpBody(body []byte) string {
+	dump := redactedBodyRegex.ReplaceAllString(string(body), `${1}`+redactedValue+`"`)
+	if c.debugDumps && c.debugDumpMaxBody > 0 && len(dump) > c.debugDumpMaxBody {
+		dump = fmt.Sprintf("%s... (%d more bytes)", dump[:c.debugDumpMaxBody], len(dump)-c.debugDumpMaxBody)
+	}
+	return dump
+}
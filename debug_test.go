@@ -0,0 +1,63 @@
+package goshopify
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestDebugDumps(t *testing.T) {
+	out := &bytes.Buffer{}
+	logger := &LeveledLogger{Level: LevelDebug, stdoutOverride: out, stderrOverride: ioutil.Discard}
+	c := NewClient(app, "fooshop", "abcd", WithLogger(logger), WithDebugDumps(10))
+
+	c.logRequest(&http.Request{
+		Method: "POST",
+		URL:    &url.URL{Scheme: "https", Host: "fooshop.myshopify.com", Path: "/foo"},
+		Header: http.Header{
+			"X-Shopify-Access-Token": {"abcd"},
+			"Authorization":          {"Basic Zm9vOmJhcg=="},
+			"Content-Type":           {"application/json"},
+		},
+		Body: ioutil.NopCloser(strings.NewReader(`{"foo":"0123456789"}`)),
+	})
+
+	expected := "[DEBUG] POST: https://fooshop.myshopify.com/foo\n" +
+		"[DEBUG] HEADERS:\nAuthorization: [REDACTED]\nContent-Type: application/json\nX-Shopify-Access-Token: [REDACTED]\n" +
+		"[DEBUG] SENT: {\"foo\":\"01... (10 more bytes)\n"
+	if out.String() != expected {
+		t.Errorf("logRequest logged %q, expected %q", out.String(), expected)
+	}
+}
+
+func TestDebugRedactsBodies(t *testing.T) {
+	setup()
+	defer teardown()
+
+	out := &bytes.Buffer{}
+	logger := &LeveledLogger{Level: LevelDebug, stdoutOverride: out, stderrOverride: ioutil.Discard}
+	WithLogger(logger)(client)
+
+	httpmock.RegisterResponder("POST", "https://fooshop.myshopify.com/admin/oauth/access_token",
+		httpmock.NewStringResponder(200, `{"access_token":"shpat_secret","scope":"read_products"}`))
+
+	app.Client = client
+	if _, err := app.GetAccessToken("fooshop", "foocode"); err != nil {
+		t.Fatalf("App.GetAccessToken(): %v", err)
+	}
+
+	for _, secret := range []string{"shpat_secret", app.ApiSecret} {
+		if strings.Contains(out.String(), secret) {
+			t.Errorf("debug log contains the secret %q: %s", secret, out.String())
+		}
+	}
+	if !strings.Contains(out.String(), fmt.Sprintf(`"access_token":"%s"`, redactedValue)) {
+		t.Errorf("debug log does not contain the redacted access token: %s", out.String())
+	}
+}
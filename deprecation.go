@@ -0,0 +1,52 @@
+package goshopify
+
+import (
+	"net/http"
+	"time"
+)
+
+// Deprecation describes a request Shopify reported as using a deprecated
+// endpoint or field, through the X-Shopify-API-Deprecated-Reason and Sunset
+// headers.
+// See: https://shopify.dev/concepts/about-apis/versioning#deprecation-practices
+type Deprecation struct {
+	Method string
+	URL    string
+
+	// Reason usually links to the changelog entry of the deprecation
+	Reason string
+
+	// Sunset is when the endpoint or field stops working, nil when Shopify
+	// did not announce a date
+	Sunset *time.Time
+}
+
+// DeprecationHandler is called for every response reporting a deprecation.
+type DeprecationHandler func(Deprecation)
+
+// checkDeprecation reports the deprecation a response carries to the
+// configured handler, or logs a warning when there is none.
+func (c *Client) checkDeprecation(req *http.Request, resp *http.Response) {
+	reason := resp.Header.Get("X-Shopify-API-Deprecated-Reason")
+	sunsetHeader := resp.Header.Get("Sunset")
+	if reason == "" && sunsetHeader == "" {
+		return
+	}
+
+	deprecation := Deprecation{Method: req.Method, URL: req.URL.String(), Reason: reason}
+	if sunset, err := http.ParseTime(sunsetHeader); err == nil {
+		deprecation.Sunset = &sunset
+	}
+
+	if c.deprecationHandler != nil {
+		c.deprecationHandler(deprecation)
+		return
+	}
+
+	if deprecation.Sunset != nil {
+		c.log.Warnf("%s %s is deprecated and stops working on %s: %s",
+			deprecation.Method, deprecation.URL, deprecation.Sunset.Format("2006-01-02"), deprecation.Reason)
+		return
+	}
+	c.log.Warnf("%s %s is deprecated: %s", deprecation.Method, deprecation.URL, deprecation.Reason)
+}
@@ -0,0 +1,71 @@
+package goshopify
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+const testDeprecatedReason = "https://shopify.dev/changelog/deprecated-field"
+
+func TestDeprecationHandler(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var reported []Deprecation
+	WithDeprecationHandler(func(d Deprecation) { reported = append(reported, d) })(client)
+
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/foo/1",
+		createResponderWithHeaders(200, `{}`, map[string]string{
+			"X-Shopify-API-Deprecated-Reason": testDeprecatedReason,
+			"Sunset":                          "Sat, 01 Jul 2023 00:00:00 GMT",
+		}))
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/foo/2",
+		createResponderWithHeaders(404, `{"errors":"Not Found"}`, map[string]string{
+			"X-Shopify-API-Deprecated-Reason": testDeprecatedReason,
+		}))
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/foo/3",
+		httpmock.NewStringResponder(200, `{}`))
+
+	for _, p := range []string{"foo/1", "foo/2", "foo/3"} {
+		req, _ := client.NewRequest("GET", p, nil, nil)
+		_ = client.Do(req, nil)
+	}
+
+	sunset := time.Date(2023, time.July, 1, 0, 0, 0, 0, time.UTC)
+	expected := []Deprecation{
+		{Method: "GET", URL: "https://fooshop.myshopify.com/foo/1", Reason: testDeprecatedReason, Sunset: &sunset},
+		{Method: "GET", URL: "https://fooshop.myshopify.com/foo/2", Reason: testDeprecatedReason},
+	}
+	if !reflect.DeepEqual(reported, expected) {
+		t.Errorf("DeprecationHandler called with %+v, expected %+v", reported, expected)
+	}
+}
+
+func TestDeprecationLogged(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var logged bytes.Buffer
+	client.log = &LeveledLogger{Level: LevelWarn, stderrOverride: &logged}
+
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/foo/1",
+		createResponderWithHeaders(200, `{}`, map[string]string{
+			"X-Shopify-API-Deprecated-Reason": testDeprecatedReason,
+			"Sunset":                          "Sat, 01 Jul 2023 00:00:00 GMT",
+		}))
+
+	req, _ := client.NewRequest("GET", "foo/1", nil, nil)
+	if err := client.Do(req, nil); err != nil {
+		t.Fatalf("Do(): errored %s", err)
+	}
+
+	expected := "[WARN] GET https://fooshop.myshopify.com/foo/1 is deprecated and stops working on 2023-07-01: " + testDeprecatedReason
+	if actual := strings.TrimSpace(logged.String()); actual != expected {
+		t.Errorf("logged %q, expected %q", actual, expected)
+	}
+}
@@ -0,0 +1,500 @@
+package goshopify
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Statuses of a discount.
+const (
+	DiscountStatusActive    = "ACTIVE"
+	DiscountStatusScheduled = "SCHEDULED"
+	DiscountStatusExpired   = "EXPIRED"
+)
+
+// Types of discounts, the GraphQL type of the discount.
+const (
+	DiscountTypeCodeBasic             = "DiscountCodeBasic"
+	DiscountTypeCodeBxgy              = "DiscountCodeBxgy"
+	DiscountTypeCodeFreeShipping      = "DiscountCodeFreeShipping"
+	DiscountTypeAutomaticBasic        = "DiscountAutomaticBasic"
+	DiscountTypeAutomaticBxgy         = "DiscountAutomaticBxgy"
+	DiscountTypeAutomaticFreeShipping = "DiscountAutomaticFreeShipping"
+)
+
+// DiscountService is an interface for managing code and automatic discounts
+// through the GraphQL Admin API, which supports buy X get Y and free shipping
+// discounts and discount combinations besides what price rules do.
+// See: https://shopify.dev/docs/apps/selling-strategies/discounts
+type DiscountService interface {
+	List(string) ([]Discount, error)
+	Get(string) (*Discount, error)
+	CreateCodeBasic(DiscountBasicInput) (*Discount, error)
+	CreateAutomaticBasic(DiscountBasicInput) (*Discount, error)
+	CreateCodeBxgy(DiscountBxgyInput) (*Discount, error)
+	CreateAutomaticBxgy(DiscountBxgyInput) (*Discount, error)
+	CreateCodeFreeShipping(DiscountFreeShippingInput) (*Discount, error)
+	CreateAutomaticFreeShipping(DiscountFreeShippingInput) (*Discount, error)
+	Delete(string) error
+}
+
+// DiscountServiceOp handles communication with the discount related GraphQL
+// queries and mutations.
+type DiscountServiceOp struct {
+	client *Client
+}
+
+// Discount represents a code or automatic discount. ID is the ID of the
+// discount node, e.g. gid://shopify/DiscountCodeNode/1, Type the kind of
+// discount and Code the first code of code discounts.
+type Discount struct {
+	ID              string     `json:"id"`
+	Type            string     `json:"type"`
+	Title           string     `json:"title"`
+	Status          string     `json:"status"`
+	Summary         string     `json:"summary,omitempty"`
+	Code            string     `json:"code,omitempty"`
+	UsageLimit      int        `json:"usageLimit,omitempty"`
+	AsyncUsageCount int        `json:"asyncUsageCount"`
+	StartsAt        *time.Time `json:"startsAt,omitempty"`
+	EndsAt          *time.Time `json:"endsAt,omitempty"`
+}
+
+// IsCode reports whether customers enter a code to get the discount.
+func (d *Discount) IsCode() bool {
+	return strings.HasPrefix(d.Type, "DiscountCode")
+}
+
+// DiscountDetails holds the details all kinds of discounts share. Code,
+// UsageLimit and AppliesOncePerCustomer only apply to code discounts and are
+// ignored when creating automatic ones.
+type DiscountDetails struct {
+	Title                  string
+	Code                   string
+	StartsAt               time.Time
+	EndsAt                 *time.Time
+	UsageLimit             int
+	AppliesOncePerCustomer bool
+	CombinesWith           *DiscountCombinesWith
+}
+
+// DiscountCombinesWith sets which other classes of discounts a discount can
+// be combined with.
+type DiscountCombinesWith struct {
+	OrderDiscounts    bool `json:"orderDiscounts"`
+	ProductDiscounts  bool `json:"productDiscounts"`
+	ShippingDiscounts bool `json:"shippingDiscounts"`
+}
+
+// DiscountMinimum is the minimum quantity or subtotal an order needs for a
+// discount to apply, only one of them may be set.
+type DiscountMinimum struct {
+	Quantity int
+	Subtotal *decimal.Decimal
+}
+
+// DiscountItems selects the items a discount applies to: all of them, the
+// products and variants or the collections.
+type DiscountItems struct {
+	All           bool
+	ProductIDs    []int64
+	VariantIDs    []int64
+	CollectionIDs []int64
+}
+
+// DiscountValue is a Percentage between 0 and 1 or a fixed Amount taken off,
+// once for all items or from each of them when AppliesOnEachItem is set.
+type DiscountValue struct {
+	Percentage        *float64
+	Amount            *decimal.Decimal
+	AppliesOnEachItem bool
+}
+
+// DiscountBasicInput describes an amount off products or orders.
+type DiscountBasicInput struct {
+	DiscountDetails
+	Value   DiscountValue
+	Items   DiscountItems
+	Minimum *DiscountMinimum
+}
+
+// DiscountBxgyInput describes a buy X get Y discount, buying BuyQuantity or
+// BuyAmount worth of BuyItems discounts GetQuantity of GetItems by
+// GetPercentage, e.g. 1 for free.
+type DiscountBxgyInput struct {
+	DiscountDetails
+	BuyQuantity       int
+	BuyAmount         *decimal.Decimal
+	BuyItems          DiscountItems
+	GetQuantity       int
+	GetPercentage     float64
+	GetItems          DiscountItems
+	UsesPerOrderLimit int
+}
+
+// DiscountFreeShippingInput describes a free shipping discount to the
+// countries, or anywhere if none are given, for rates up to the
+// MaximumShippingPrice.
+type DiscountFreeShippingInput struct {
+	DiscountDetails
+	CountryCodes         []string
+	IncludeRestOfWorld   bool
+	MaximumShippingPrice *decimal.Decimal
+	Minimum              *DiscountMinimum
+}
+
+// input returns the fields shared by the inputs of all discounts, including
+// those of code discounts if code is set.
+func (d DiscountDetails) input(code bool) map[string]interface{} {
+	input := map[string]interface{}{
+		"title":    d.Title,
+		"startsAt": d.StartsAt.Format(time.RFC3339),
+	}
+	if d.EndsAt != nil {
+		input["endsAt"] = d.EndsAt.Format(time.RFC3339)
+	}
+	if d.CombinesWith != nil {
+		input["combinesWith"] = d.CombinesWith
+	}
+	if code {
+		input["code"] = d.Code
+		input["customerSelection"] = map[string]interface{}{"all": true}
+		input["appliesOncePerCustomer"] = d.AppliesOncePerCustomer
+		if d.UsageLimit != 0 {
+			input["usageLimit"] = d.UsageLimit
+		}
+	}
+	return input
+}
+
+func (m *DiscountMinimum) input() map[string]interface{} {
+	if m.Subtotal != nil {
+		return map[string]interface{}{
+			"subtotal": map[string]interface{}{"greaterThanOrEqualToSubtotal": m.Subtotal.String()},
+		}
+	}
+	return map[string]interface{}{
+		"quantity": map[string]interface{}{"greaterThanOrEqualToQuantity": fmt.Sprint(m.Quantity)},
+	}
+}
+
+func (i DiscountItems) input() map[string]interface{} {
+	switch {
+	case i.All:
+		return map[string]interface{}{"all": true}
+	case len(i.CollectionIDs) > 0:
+		return map[string]interface{}{
+			"collections": map[string]interface{}{"add": gids("Collection", i.CollectionIDs)},
+		}
+	default:
+		return map[string]interface{}{
+			"products": map[string]interface{}{
+				"productsToAdd":        gids("Product", i.ProductIDs),
+				"productVariantsToAdd": gids("ProductVariant", i.VariantIDs),
+			},
+		}
+	}
+}
+
+func (v DiscountValue) input() map[string]interface{} {
+	if v.Amount != nil {
+		return map[string]interface{}{
+			"discountAmount": map[string]interface{}{
+				"amount":            v.Amount.String(),
+				"appliesOnEachItem": v.AppliesOnEachItem,
+			},
+		}
+	}
+	var percentage float64
+	if v.Percentage != nil {
+		percentage = *v.Percentage
+	}
+	return map[string]interface{}{"percentage": percentage}
+}
+
+func (d DiscountBasicInput) input(code bool) map[string]interface{} {
+	input := d.DiscountDetails.input(code)
+	input["customerGets"] = map[string]interface{}{
+		"value": d.Value.input(),
+		"items": d.Items.input(),
+	}
+	if d.Minimum != nil {
+		input["minimumRequirement"] = d.Minimum.input()
+	}
+	return input
+}
+
+func (d DiscountBxgyInput) input(code bool) map[string]interface{} {
+	input := d.DiscountDetails.input(code)
+	buys := map[string]interface{}{"quantity": fmt.Sprint(d.BuyQuantity)}
+	if d.BuyAmount != nil {
+		buys = map[string]interface{}{"amount": d.BuyAmount.String()}
+	}
+	input["customerBuys"] = map[string]interface{}{
+		"value": buys,
+		"items": d.BuyItems.input(),
+	}
+	input["customerGets"] = map[string]interface{}{
+		"value": map[string]interface{}{
+			"discountOnQuantity": map[string]interface{}{
+				"quantity": fmt.Sprint(d.GetQuantity),
+				"effect":   map[string]interface{}{"percentage": d.GetPercentage},
+			},
+		},
+		"items": d.GetItems.input(),
+	}
+	if d.UsesPerOrderLimit != 0 {
+		input["usesPerOrderLimit"] = fmt.Sprint(d.UsesPerOrderLimit)
+	}
+	return input
+}
+
+func (d DiscountFreeShippingInput) input(code bool) map[string]interface{} {
+	input := d.DiscountDetails.input(code)
+	destination := map[string]interface{}{"all": true}
+	if len(d.CountryCodes) > 0 {
+		destination = map[string]interface{}{
+			"countries": map[string]interface{}{
+				"add":                d.CountryCodes,
+				"includeRestOfWorld": d.IncludeRestOfWorld,
+			},
+		}
+	}
+	input["destination"] = destination
+	if d.MaximumShippingPrice != nil {
+		input["maximumShippingPrice"] = d.MaximumShippingPrice.String()
+	}
+	if d.Minimum != nil {
+		input["minimumRequirement"] = d.Minimum.input()
+	}
+	return input
+}
+
+// discountFields is the GraphQL representation of the discount of a node,
+// which is a union of the kinds of discounts.
+type discountFields struct {
+	Typename        string     `json:"__typename"`
+	Title           string     `json:"title"`
+	Status          string     `json:"status"`
+	Summary         string     `json:"summary"`
+	UsageLimit      int        `json:"usageLimit"`
+	AsyncUsageCount int        `json:"asyncUsageCount"`
+	StartsAt        *time.Time `json:"startsAt"`
+	EndsAt          *time.Time `json:"endsAt"`
+	Codes           struct {
+		Edges []struct {
+			Node struct {
+				Code string `json:"code"`
+			} `json:"node"`
+		} `json:"edges"`
+	} `json:"codes"`
+}
+
+// discountNode is a discount node, whose discount is named after the kind of
+// node in mutation payloads.
+type discountNode struct {
+	ID                string          `json:"id"`
+	Discount          *discountFields `json:"discount"`
+	CodeDiscount      *discountFields `json:"codeDiscount"`
+	AutomaticDiscount *discountFields `json:"automaticDiscount"`
+}
+
+func (n *discountNode) discount() *Discount {
+	if n == nil {
+		return nil
+	}
+	fields := n.Discount
+	if fields == nil {
+		fields = n.CodeDiscount
+	}
+	if fields == nil {
+		fields = n.AutomaticDiscount
+	}
+	discount := &Discount{ID: n.ID}
+	if fields == nil {
+		return discount
+	}
+	discount.Type = fields.Typename
+	discount.Title = fields.Title
+	discount.Status = fields.Status
+	discount.Summary = fields.Summary
+	discount.UsageLimit = fields.UsageLimit
+	discount.AsyncUsageCount = fields.AsyncUsageCount
+	discount.StartsAt = fields.StartsAt
+	discount.EndsAt = fields.EndsAt
+	if len(fields.Codes.Edges) > 0 {
+		discount.Code = fields.Codes.Edges[0].Node.Code
+	}
+	return discount
+}
+
+const discountCommonFields = `title status summary startsAt endsAt asyncUsageCount`
+
+const discountCodeFields = discountCommonFields + ` usageLimit codes(first: 1) { edges { node { code } } }`
+
+const discountUnionFields = `__typename
+  ... on DiscountCodeBasic { ` + discountCodeFields + ` }
+  ... on DiscountCodeBxgy { ` + discountCodeFields + ` }
+  ... on DiscountCodeFreeShipping { ` + discountCodeFields + ` }
+  ... on DiscountAutomaticBasic { ` + discountCommonFields + ` }
+  ... on DiscountAutomaticBxgy { ` + discountCommonFields + ` }
+  ... on DiscountAutomaticFreeShipping { ` + discountCommonFields + ` }`
+
+const discountNodesQuery = `query($query: String, $after: String) {
+  discountNodes(first: 250, query: $query, after: $after) {
+    edges { node { id discount { ` + discountUnionFields + ` } } }
+    pageInfo { hasNextPage endCursor }
+  }
+}`
+
+const discountNodeQuery = `query($id: ID!) {
+  discountNode(id: $id) { id discount { ` + discountUnionFields + ` } }
+}`
+
+// discountCreateMutation returns the mutation creating a discount, named
+// after the kind of discount, e.g. discountCodeBasicCreate taking its input
+// as basicCodeDiscount.
+func discountCreateMutation(name, argument, inputType string, code bool) string {
+	node := "automaticDiscountNode { id automaticDiscount { " + discountUnionFields + " } }"
+	if code {
+		node = "codeDiscountNode { id codeDiscount { " + discountUnionFields + " } }"
+	}
+	return fmt.Sprintf(`mutation($input: %s!) {
+  %s(%s: $input) {
+    %s
+    userErrors { field message }
+  }
+}`, inputType, name, argument, node)
+}
+
+const discountCodeDeleteMutation = `mutation($id: ID!) {
+  discountCodeDelete(id: $id) {
+    deletedCodeDiscountId
+    userErrors { field message }
+  }
+}`
+
+const discountAutomaticDeleteMutation = `mutation($id: ID!) {
+  discountAutomaticDelete(id: $id) {
+    deletedAutomaticDiscountId
+    userErrors { field message }
+  }
+}`
+
+// List the discounts matching the search query, e.g. "status:active", or all
+// of them if it is empty.
+func (s *DiscountServiceOp) List(query string) ([]Discount, error) {
+	var discounts []Discount
+	var after *string
+	for {
+		vars := map[string]interface{}{"after": after}
+		if query != "" {
+			vars["query"] = query
+		}
+		resp := struct {
+			DiscountNodes struct {
+				Edges []struct {
+					Node discountNode `json:"node"`
+				} `json:"edges"`
+				PageInfo graphQLPageInfo `json:"pageInfo"`
+			} `json:"discountNodes"`
+		}{}
+
+		err := s.client.GraphQL.Query(discountNodesQuery, vars, &resp)
+		if err != nil {
+			return discounts, err
+		}
+
+		for i := range resp.DiscountNodes.Edges {
+			discounts = append(discounts, *resp.DiscountNodes.Edges[i].Node.discount())
+		}
+
+		pageInfo := resp.DiscountNodes.PageInfo
+		if !pageInfo.HasNextPage {
+			return discounts, nil
+		}
+		after = &pageInfo.EndCursor
+	}
+}
+
+// Get a discount by the ID of its node, nil if there is none.
+func (s *DiscountServiceOp) Get(id string) (*Discount, error) {
+	resp := struct {
+		DiscountNode *discountNode `json:"discountNode"`
+	}{}
+	err := s.client.GraphQL.Query(discountNodeQuery, map[string]interface{}{"id": id}, &resp)
+	return resp.DiscountNode.discount(), err
+}
+
+// CreateCodeBasic creates a code discount taking an amount off products or
+// orders.
+func (s *DiscountServiceOp) CreateCodeBasic(discount DiscountBasicInput) (*Discount, error) {
+	return s.create("discountCodeBasicCreate", "basicCodeDiscount", "DiscountCodeBasicInput", true, discount.input(true))
+}
+
+// CreateAutomaticBasic creates an automatic discount taking an amount off
+// products or orders.
+func (s *DiscountServiceOp) CreateAutomaticBasic(discount DiscountBasicInput) (*Discount, error) {
+	return s.create("discountAutomaticBasicCreate", "automaticBasicDiscount", "DiscountAutomaticBasicInput", false, discount.input(false))
+}
+
+// CreateCodeBxgy creates a buy X get Y code discount.
+func (s *DiscountServiceOp) CreateCodeBxgy(discount DiscountBxgyInput) (*Discount, error) {
+	return s.create("discountCodeBxgyCreate", "bxgyCodeDiscount", "DiscountCodeBxgyInput", true, discount.input(true))
+}
+
+// CreateAutomaticBxgy creates an automatic buy X get Y discount.
+func (s *DiscountServiceOp) CreateAutomaticBxgy(discount DiscountBxgyInput) (*Discount, error) {
+	return s.create("discountAutomaticBxgyCreate", "automaticBxgyDiscount", "DiscountAutomaticBxgyInput", false, discount.input(false))
+}
+
+// CreateCodeFreeShipping creates a free shipping code discount.
+func (s *DiscountServiceOp) CreateCodeFreeShipping(discount DiscountFreeShippingInput) (*Discount, error) {
+	return s.create("discountCodeFreeShippingCreate", "freeShippingCodeDiscount", "DiscountCodeFreeShippingInput", true, discount.input(true))
+}
+
+// CreateAutomaticFreeShipping creates an automatic free shipping discount.
+func (s *DiscountServiceOp) CreateAutomaticFreeShipping(discount DiscountFreeShippingInput) (*Discount, error) {
+	return s.create("discountAutomaticFreeShippingCreate", "freeShippingAutomaticDiscount", "DiscountAutomaticFreeShippingInput", false, discount.input(false))
+}
+
+func (s *DiscountServiceOp) create(name, argument, inputType string, code bool, input map[string]interface{}) (*Discount, error) {
+	resp := map[string]struct {
+		CodeDiscountNode      *discountNode `json:"codeDiscountNode"`
+		AutomaticDiscountNode *discountNode `json:"automaticDiscountNode"`
+		UserErrors            []UserError   `json:"userErrors"`
+	}{}
+
+	mutation := discountCreateMutation(name, argument, inputType, code)
+	err := s.client.GraphQL.Query(mutation, map[string]interface{}{"input": input}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := resp[name]
+	node := payload.AutomaticDiscountNode
+	if code {
+		node = payload.CodeDiscountNode
+	}
+	return node.discount(), userErrorsToError(payload.UserErrors)
+}
+
+// Delete a discount by the ID of its node, code and automatic discounts are
+// told apart by the ID.
+func (s *DiscountServiceOp) Delete(id string) error {
+	mutation, name := discountCodeDeleteMutation, "discountCodeDelete"
+	if strings.HasPrefix(id, "gid://shopify/DiscountAutomaticNode/") {
+		mutation, name = discountAutomaticDeleteMutation, "discountAutomaticDelete"
+	}
+
+	resp := map[string]struct {
+		UserErrors []UserError `json:"userErrors"`
+	}{}
+	if err := s.client.GraphQL.Query(mutation, map[string]interface{}{"id": id}, &resp); err != nil {
+		return err
+	}
+	return userErrorsToError(resp[name].UserErrors)
+}
@@ -1,11 +1,24 @@
 package goshopify
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
 
 const discountCodeBasePath = "price_rules/%d/discount_codes"
+const discountCodeBatchBasePath = "price_rules/%d/batch"
+
+// Statuses of a discount code creation job.
+const (
+	DiscountCodeBatchStatusQueued    = "queued"
+	DiscountCodeBatchStatusRunning   = "running"
+	DiscountCodeBatchStatusCompleted = "completed"
+)
+
+// defaultDiscountCodeBatchPollInterval is how often WaitForCompletion checks
+// on a discount code creation job when no interval is given.
+const defaultDiscountCodeBatchPollInterval = 2 * time.Second
 
 // DiscountCodeService is an interface for interfacing with the discount endpoints
 // of the Shopify API.
@@ -16,6 +29,10 @@ type DiscountCodeService interface {
 	List(int64) ([]PriceRuleDiscountCode, error)
 	Get(int64, int64) (*PriceRuleDiscountCode, error)
 	Delete(int64, int64) error
+	CreateBatch(int64, []PriceRuleDiscountCode) (*DiscountCodeBatch, error)
+	GetBatch(int64, int64) (*DiscountCodeBatch, error)
+	ListBatchCodes(int64, int64) ([]PriceRuleDiscountCode, error)
+	WaitForCompletion(context.Context, int64, int64, time.Duration) (*DiscountCodeBatch, error)
 }
 
 // DiscountCodeServiceOp handles communication with the discount code
@@ -32,6 +49,26 @@ type PriceRuleDiscountCode struct {
 	UsageCount  int        `json:"usage_count,omitempty"`
 	CreatedAt   *time.Time `json:"created_at,omitempty"`
 	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+
+	// Errors by field of a code that a discount code creation job failed to
+	// create, only set by ListBatchCodes
+	Errors map[string][]string `json:"errors,omitempty"`
+}
+
+// DiscountCodeBatch represents a job creating up to 100 discount codes of a
+// price rule asynchronously, see CreateBatch.
+type DiscountCodeBatch struct {
+	ID            int64      `json:"id,omitempty"`
+	PriceRuleID   int64      `json:"price_rule_id,omitempty"`
+	Status        string     `json:"status,omitempty"`
+	CodesCount    int        `json:"codes_count,omitempty"`
+	ImportedCount int        `json:"imported_count,omitempty"`
+	FailedCount   int        `json:"failed_count,omitempty"`
+	Logs          []string   `json:"logs,omitempty"`
+	StartedAt     *time.Time `json:"started_at,omitempty"`
+	CompletedAt   *time.Time `json:"completed_at,omitempty"`
+	CreatedAt     *time.Time `json:"created_at,omitempty"`
+	UpdatedAt     *time.Time `json:"updated_at,omitempty"`
 }
 
 // DiscountCodesResource is the result from the discount_codes.json endpoint
@@ -47,38 +84,83 @@ type DiscountCodeResource struct {
 // Create a discount code
 func (s *DiscountCodeServiceOp) Create(priceRuleID int64, dc PriceRuleDiscountCode) (*PriceRuleDiscountCode, error) {
 	path := fmt.Sprintf(discountCodeBasePath+".json", priceRuleID)
-	wrappedData := DiscountCodeResource{PriceRuleDiscountCode: &dc}
-	resource := new(DiscountCodeResource)
-	err := s.client.Post(path, wrappedData, resource)
-	return resource.PriceRuleDiscountCode, err
+	return createResource(s.client, path, "discount_code", dc)
 }
 
 // Update an existing discount code
 func (s *DiscountCodeServiceOp) Update(priceRuleID int64, dc PriceRuleDiscountCode) (*PriceRuleDiscountCode, error) {
 	path := fmt.Sprintf(discountCodeBasePath+"/%d.json", priceRuleID, dc.ID)
-	wrappedData := DiscountCodeResource{PriceRuleDiscountCode: &dc}
-	resource := new(DiscountCodeResource)
-	err := s.client.Put(path, wrappedData, resource)
-	return resource.PriceRuleDiscountCode, err
+	return updateResource(s.client, path, "discount_code", dc)
 }
 
 // List of discount codes
 func (s *DiscountCodeServiceOp) List(priceRuleID int64) ([]PriceRuleDiscountCode, error) {
 	path := fmt.Sprintf(discountCodeBasePath+".json", priceRuleID)
-	resource := new(DiscountCodesResource)
-	err := s.client.Get(path, resource, nil)
-	return resource.DiscountCodes, err
+	return listResource[PriceRuleDiscountCode](s.client, path, "discount_codes", nil)
 }
 
 // Get a single discount code
 func (s *DiscountCodeServiceOp) Get(priceRuleID int64, discountCodeID int64) (*PriceRuleDiscountCode, error) {
 	path := fmt.Sprintf(discountCodeBasePath+"/%d.json", priceRuleID, discountCodeID)
-	resource := new(DiscountCodeResource)
-	err := s.client.Get(path, resource, nil)
-	return resource.PriceRuleDiscountCode, err
+	return getResource[PriceRuleDiscountCode](s.client, path, "discount_code", nil)
 }
 
 // Delete a discount code
 func (s *DiscountCodeServiceOp) Delete(priceRuleID int64, discountCodeID int64) error {
 	return s.client.Delete(fmt.Sprintf(discountCodeBasePath+"/%d.json", priceRuleID, discountCodeID))
 }
+
+// CreateBatch starts a job creating the discount codes of a price rule, at
+// most 100 per job. Only the Code of the discount codes is sent. See
+// WaitForCompletion and ListBatchCodes for the outcome.
+func (s *DiscountCodeServiceOp) CreateBatch(priceRuleID int64, codes []PriceRuleDiscountCode) (*DiscountCodeBatch, error) {
+	type batchCode struct {
+		Code string `json:"code"`
+	}
+	data := struct {
+		DiscountCodes []batchCode `json:"discount_codes"`
+	}{DiscountCodes: make([]batchCode, 0, len(codes))}
+	for _, code := range codes {
+		data.DiscountCodes = append(data.DiscountCodes, batchCode{Code: code.Code})
+	}
+
+	path := fmt.Sprintf(discountCodeBatchBasePath+".json", priceRuleID)
+	return postResource[DiscountCodeBatch](s.client, path, "discount_code_creation", data)
+}
+
+// GetBatch gets a discount code creation job
+func (s *DiscountCodeServiceOp) GetBatch(priceRuleID int64, batchID int64) (*DiscountCodeBatch, error) {
+	path := fmt.Sprintf(discountCodeBatchBasePath+"/%d.json", priceRuleID, batchID)
+	return getResource[DiscountCodeBatch](s.client, path, "discount_code_creation", nil)
+}
+
+// ListBatchCodes lists the discount codes of a discount code creation job.
+// Codes that failed have no ID and their Errors set.
+func (s *DiscountCodeServiceOp) ListBatchCodes(priceRuleID int64, batchID int64) ([]PriceRuleDiscountCode, error) {
+	path := fmt.Sprintf(discountCodeBatchBasePath+"/%d/discount_codes.json", priceRuleID, batchID)
+	return listResource[PriceRuleDiscountCode](s.client, path, "discount_codes", nil)
+}
+
+// WaitForCompletion polls the discount code creation job every pollInterval,
+// 2 seconds when zero, until it completed or the context is done.
+func (s *DiscountCodeServiceOp) WaitForCompletion(ctx context.Context, priceRuleID int64, batchID int64, pollInterval time.Duration) (*DiscountCodeBatch, error) {
+	if pollInterval <= 0 {
+		pollInterval = defaultDiscountCodeBatchPollInterval
+	}
+
+	for {
+		batch, err := s.GetBatch(priceRuleID, batchID)
+		if err != nil {
+			return nil, err
+		}
+		if batch == nil {
+			return nil, fmt.Errorf("discount code creation job %d not found", batchID)
+		}
+		if batch.Status == DiscountCodeBatchStatusCompleted {
+			return batch, nil
+		}
+		if err := sleepContext(ctx, pollInterval); err != nil {
+			return batch, err
+		}
+	}
+}
@@ -1,8 +1,12 @@
 package goshopify
 
 import (
+	"context"
 	"fmt"
+	"io/ioutil"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/jarcoal/httpmock"
 )
@@ -128,3 +132,100 @@ func TestDiscountCodeDelete(t *testing.T) {
 		t.Errorf("DiscountCode.Delete returned error: %v", err)
 	}
 }
+
+func TestDiscountCodeCreateBatch(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var body string
+	httpmock.RegisterResponder(
+		"POST",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/price_rules/507328175/batch.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			data, _ := ioutil.ReadAll(req.Body)
+			body = string(data)
+			return httpmock.NewStringResponse(201, `{"discount_code_creation":{"id":989355119,"price_rule_id":507328175,"started_at":null,"completed_at":null,"created_at":"2018-07-05T13:04:29-04:00","updated_at":"2018-07-05T13:04:29-04:00","status":"queued","codes_count":3,"imported_count":0,"failed_count":0,"logs":[]}}`), nil
+		},
+	)
+
+	batch, err := client.DiscountCode.CreateBatch(507328175, []PriceRuleDiscountCode{{Code: "SUMMER1"}, {Code: "SUMMER2"}, {Code: "SUMMER3"}})
+	if err != nil {
+		t.Fatalf("DiscountCode.CreateBatch returned error: %v", err)
+	}
+	if batch.ID != 989355119 || batch.Status != DiscountCodeBatchStatusQueued || batch.CodesCount != 3 {
+		t.Errorf("DiscountCode.CreateBatch returned %+v", batch)
+	}
+
+	expected := `{"discount_codes":[{"code":"SUMMER1"},{"code":"SUMMER2"},{"code":"SUMMER3"}]}`
+	if body != expected {
+		t.Errorf("DiscountCode.CreateBatch sent %s, expected %s", body, expected)
+	}
+}
+
+func TestDiscountCodeListBatchCodes(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder(
+		"GET",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/price_rules/507328175/batch/173232803/discount_codes.json", client.pathPrefix),
+		httpmock.NewStringResponder(
+			200,
+			`{"discount_codes":[{"id":null,"code":"foo","errors":{}},{"id":null,"code":"","errors":{"code":["can't be blank"]}},{"id":1,"code":"bar","errors":{}}]}`,
+		),
+	)
+
+	codes, err := client.DiscountCode.ListBatchCodes(507328175, 173232803)
+	if err != nil {
+		t.Fatalf("DiscountCode.ListBatchCodes returned error: %v", err)
+	}
+	if len(codes) != 3 || codes[2].ID != 1 || len(codes[1].Errors["code"]) != 1 || len(codes[0].Errors) != 0 {
+		t.Errorf("DiscountCode.ListBatchCodes returned %+v", codes)
+	}
+}
+
+func TestDiscountCodeWaitForCompletion(t *testing.T) {
+	setup()
+	defer teardown()
+
+	responses := []string{
+		`{"discount_code_creation":{"id":173232803,"price_rule_id":507328175,"status":"running","codes_count":3,"imported_count":1}}`,
+		`{"discount_code_creation":{"id":173232803,"price_rule_id":507328175,"status":"completed","codes_count":3,"imported_count":2,"failed_count":1,"completed_at":"2018-07-05T13:04:31-04:00"}}`,
+	}
+	requests := 0
+	httpmock.RegisterResponder(
+		"GET",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/price_rules/507328175/batch/173232803.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			response := responses[requests]
+			requests++
+			return httpmock.NewStringResponse(200, response), nil
+		},
+	)
+
+	batch, err := client.DiscountCode.WaitForCompletion(context.Background(), 507328175, 173232803, time.Millisecond)
+	if err != nil {
+		t.Fatalf("DiscountCode.WaitForCompletion returned error: %v", err)
+	}
+	if batch.Status != DiscountCodeBatchStatusCompleted || batch.FailedCount != 1 || batch.CompletedAt == nil || requests != 2 {
+		t.Errorf("DiscountCode.WaitForCompletion returned %+v after %d requests", batch, requests)
+	}
+}
+
+func TestDiscountCodeWaitForCompletionCanceled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder(
+		"GET",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/price_rules/507328175/batch/173232803.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"discount_code_creation":{"id":173232803,"status":"queued"}}`),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	batch, err := client.DiscountCode.WaitForCompletion(ctx, 507328175, 173232803, time.Millisecond)
+	if err != context.Canceled || batch == nil || batch.Status != DiscountCodeBatchStatusQueued {
+		t.Errorf("DiscountCode.WaitForCompletion returned %+v, %v, expected the queued batch and context.Canceled", batch, err)
+	}
+}
@@ -0,0 +1,262 @@
+package goshopify
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/shopspring/decimal"
+)
+
+func TestDiscountList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests,
+			`{"data":{"discountNodes":{"edges":[{"node":{"id":"gid://shopify/DiscountCodeNode/1","discount":{"__typename":"DiscountCodeBasic","title":"10% off","status":"ACTIVE","summary":"10% off entire order","startsAt":"2024-01-01T00:00:00Z","endsAt":null,"asyncUsageCount":3,"usageLimit":100,"codes":{"edges":[{"node":{"code":"TEN"}}]}}}}],"pageInfo":{"hasNextPage":true,"endCursor":"abc"}}}}`,
+			`{"data":{"discountNodes":{"edges":[{"node":{"id":"gid://shopify/DiscountAutomaticNode/2","discount":{"__typename":"DiscountAutomaticFreeShipping","title":"Free shipping","status":"SCHEDULED","summary":"Free shipping on all products","asyncUsageCount":0}}}],"pageInfo":{"hasNextPage":false}}}}`,
+		))
+
+	discounts, err := client.Discount.List("status:active")
+	if err != nil {
+		t.Fatalf("Discount.List returned error: %v", err)
+	}
+
+	startsAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	expected := []Discount{
+		{
+			ID:              "gid://shopify/DiscountCodeNode/1",
+			Type:            DiscountTypeCodeBasic,
+			Title:           "10% off",
+			Status:          DiscountStatusActive,
+			Summary:         "10% off entire order",
+			Code:            "TEN",
+			UsageLimit:      100,
+			AsyncUsageCount: 3,
+			StartsAt:        &startsAt,
+		},
+		{
+			ID:      "gid://shopify/DiscountAutomaticNode/2",
+			Type:    DiscountTypeAutomaticFreeShipping,
+			Title:   "Free shipping",
+			Status:  DiscountStatusScheduled,
+			Summary: "Free shipping on all products",
+		},
+	}
+	if !reflect.DeepEqual(discounts, expected) {
+		t.Errorf("Discount.List returned %+v, expected %+v", discounts, expected)
+	}
+	if !discounts[0].IsCode() || discounts[1].IsCode() {
+		t.Errorf("Discount.IsCode did not tell code and automatic discounts apart")
+	}
+	vars := requests[0].Variables.(map[string]interface{})
+	if vars["query"] != "status:active" {
+		t.Errorf("Discount.List sent query %v", vars["query"])
+	}
+	if len(requests) != 2 || requests[1].Variables.(map[string]interface{})["after"] != "abc" {
+		t.Errorf("Discount.List sent %+v, expected the second page after abc", requests)
+	}
+}
+
+func TestDiscountGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests,
+			`{"data":{"discountNode":{"id":"gid://shopify/DiscountAutomaticNode/2","discount":{"__typename":"DiscountAutomaticBxgy","title":"Buy 2 get 1","status":"ACTIVE","asyncUsageCount":1}}}}`,
+			`{"data":{"discountNode":null}}`,
+		))
+
+	discount, err := client.Discount.Get("gid://shopify/DiscountAutomaticNode/2")
+	if err != nil {
+		t.Fatalf("Discount.Get returned error: %v", err)
+	}
+	expected := &Discount{ID: "gid://shopify/DiscountAutomaticNode/2", Type: DiscountTypeAutomaticBxgy, Title: "Buy 2 get 1", Status: DiscountStatusActive, AsyncUsageCount: 1}
+	if !reflect.DeepEqual(discount, expected) {
+		t.Errorf("Discount.Get returned %+v, expected %+v", discount, expected)
+	}
+
+	discount, err = client.Discount.Get("gid://shopify/DiscountAutomaticNode/9")
+	if err != nil || discount != nil {
+		t.Errorf("Discount.Get of a missing discount returned %+v, %v", discount, err)
+	}
+}
+
+func TestDiscountCreateCodeBasic(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests, `{"data":{"discountCodeBasicCreate":{"codeDiscountNode":{"id":"gid://shopify/DiscountCodeNode/1","codeDiscount":{"__typename":"DiscountCodeBasic","title":"10% off","status":"ACTIVE","asyncUsageCount":0,"codes":{"edges":[{"node":{"code":"TEN"}}]}}},"userErrors":[]}}}`))
+
+	percentage := 0.1
+	subtotal := decimal.NewFromInt(50)
+	discount, err := client.Discount.CreateCodeBasic(DiscountBasicInput{
+		DiscountDetails: DiscountDetails{
+			Title:                  "10% off",
+			Code:                   "TEN",
+			StartsAt:               time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			UsageLimit:             100,
+			AppliesOncePerCustomer: true,
+			CombinesWith:           &DiscountCombinesWith{ShippingDiscounts: true},
+		},
+		Value:   DiscountValue{Percentage: &percentage},
+		Items:   DiscountItems{CollectionIDs: []int64{5}},
+		Minimum: &DiscountMinimum{Subtotal: &subtotal},
+	})
+	if err != nil {
+		t.Fatalf("Discount.CreateCodeBasic returned error: %v", err)
+	}
+	expected := &Discount{ID: "gid://shopify/DiscountCodeNode/1", Type: DiscountTypeCodeBasic, Title: "10% off", Status: DiscountStatusActive, Code: "TEN"}
+	if !reflect.DeepEqual(discount, expected) {
+		t.Errorf("Discount.CreateCodeBasic returned %+v, expected %+v", discount, expected)
+	}
+
+	if !strings.Contains(requests[0].Query, "discountCodeBasicCreate(basicCodeDiscount: $input)") ||
+		!strings.Contains(requests[0].Query, "$input: DiscountCodeBasicInput!") {
+		t.Errorf("Discount.CreateCodeBasic sent query %s", requests[0].Query)
+	}
+
+	expectedInput := map[string]interface{}{
+		"title":                  "10% off",
+		"code":                   "TEN",
+		"startsAt":               "2024-01-01T00:00:00Z",
+		"usageLimit":             float64(100),
+		"appliesOncePerCustomer": true,
+		"customerSelection":      map[string]interface{}{"all": true},
+		"combinesWith":           map[string]interface{}{"orderDiscounts": false, "productDiscounts": false, "shippingDiscounts": true},
+		"customerGets": map[string]interface{}{
+			"value": map[string]interface{}{"percentage": 0.1},
+			"items": map[string]interface{}{"collections": map[string]interface{}{"add": []interface{}{"gid://shopify/Collection/5"}}},
+		},
+		"minimumRequirement": map[string]interface{}{
+			"subtotal": map[string]interface{}{"greaterThanOrEqualToSubtotal": "50"},
+		},
+	}
+	if input := requests[0].Variables.(map[string]interface{})["input"]; !reflect.DeepEqual(input, expectedInput) {
+		t.Errorf("Discount.CreateCodeBasic sent %+v, expected %+v", input, expectedInput)
+	}
+}
+
+func TestDiscountCreateAutomaticBxgy(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests, `{"data":{"discountAutomaticBxgyCreate":{"automaticDiscountNode":{"id":"gid://shopify/DiscountAutomaticNode/2","automaticDiscount":{"__typename":"DiscountAutomaticBxgy","title":"Buy 2 get 1","status":"ACTIVE","asyncUsageCount":0}},"userErrors":[]}}}`))
+
+	discount, err := client.Discount.CreateAutomaticBxgy(DiscountBxgyInput{
+		DiscountDetails: DiscountDetails{
+			Title:    "Buy 2 get 1",
+			Code:     "IGNORED",
+			StartsAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		BuyQuantity:       2,
+		BuyItems:          DiscountItems{ProductIDs: []int64{1}},
+		GetQuantity:       1,
+		GetPercentage:     1,
+		GetItems:          DiscountItems{VariantIDs: []int64{2}},
+		UsesPerOrderLimit: 1,
+	})
+	if err != nil {
+		t.Fatalf("Discount.CreateAutomaticBxgy returned error: %v", err)
+	}
+	if discount == nil || discount.ID != "gid://shopify/DiscountAutomaticNode/2" || discount.Type != DiscountTypeAutomaticBxgy {
+		t.Errorf("Discount.CreateAutomaticBxgy returned %+v", discount)
+	}
+
+	expectedInput := map[string]interface{}{
+		"title":    "Buy 2 get 1",
+		"startsAt": "2024-01-01T00:00:00Z",
+		"customerBuys": map[string]interface{}{
+			"value": map[string]interface{}{"quantity": "2"},
+			"items": map[string]interface{}{"products": map[string]interface{}{
+				"productsToAdd":        []interface{}{"gid://shopify/Product/1"},
+				"productVariantsToAdd": []interface{}{},
+			}},
+		},
+		"customerGets": map[string]interface{}{
+			"value": map[string]interface{}{"discountOnQuantity": map[string]interface{}{
+				"quantity": "1",
+				"effect":   map[string]interface{}{"percentage": float64(1)},
+			}},
+			"items": map[string]interface{}{"products": map[string]interface{}{
+				"productsToAdd":        []interface{}{},
+				"productVariantsToAdd": []interface{}{"gid://shopify/ProductVariant/2"},
+			}},
+		},
+		"usesPerOrderLimit": "1",
+	}
+	if input := requests[0].Variables.(map[string]interface{})["input"]; !reflect.DeepEqual(input, expectedInput) {
+		t.Errorf("Discount.CreateAutomaticBxgy sent %+v, expected %+v", input, expectedInput)
+	}
+}
+
+func TestDiscountCreateCodeFreeShipping(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests, `{"data":{"discountCodeFreeShippingCreate":{"codeDiscountNode":null,"userErrors":[{"field":["freeShippingCodeDiscount","code"],"message":"Code must be unique."}]}}}`))
+
+	maximum := decimal.NewFromInt(20)
+	discount, err := client.Discount.CreateCodeFreeShipping(DiscountFreeShippingInput{
+		DiscountDetails:      DiscountDetails{Title: "Free shipping", Code: "SHIP", StartsAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		CountryCodes:         []string{"US", "CA"},
+		MaximumShippingPrice: &maximum,
+		Minimum:              &DiscountMinimum{Quantity: 2},
+	})
+	if err == nil || err.Error() != "freeShippingCodeDiscount.code: Code must be unique." {
+		t.Errorf("Discount.CreateCodeFreeShipping returned %v, expected the user error", err)
+	}
+	if discount != nil {
+		t.Errorf("Discount.CreateCodeFreeShipping returned %+v, expected nil", discount)
+	}
+
+	input := requests[0].Variables.(map[string]interface{})["input"].(map[string]interface{})
+	expectedDestination := map[string]interface{}{"countries": map[string]interface{}{"add": []interface{}{"US", "CA"}, "includeRestOfWorld": false}}
+	if !reflect.DeepEqual(input["destination"], expectedDestination) {
+		t.Errorf("Discount.CreateCodeFreeShipping sent destination %+v, expected %+v", input["destination"], expectedDestination)
+	}
+	if input["maximumShippingPrice"] != "20" {
+		t.Errorf("Discount.CreateCodeFreeShipping sent maximum shipping price %v", input["maximumShippingPrice"])
+	}
+	expectedMinimum := map[string]interface{}{"quantity": map[string]interface{}{"greaterThanOrEqualToQuantity": "2"}}
+	if !reflect.DeepEqual(input["minimumRequirement"], expectedMinimum) {
+		t.Errorf("Discount.CreateCodeFreeShipping sent minimum %+v, expected %+v", input["minimumRequirement"], expectedMinimum)
+	}
+}
+
+func TestDiscountDelete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests,
+			`{"data":{"discountCodeDelete":{"deletedCodeDiscountId":"gid://shopify/DiscountCodeNode/1","userErrors":[]}}}`,
+			`{"data":{"discountAutomaticDelete":{"deletedAutomaticDiscountId":null,"userErrors":[{"field":["id"],"message":"Discount does not exist"}]}}}`,
+		))
+
+	if err := client.Discount.Delete("gid://shopify/DiscountCodeNode/1"); err != nil {
+		t.Errorf("Discount.Delete returned error: %v", err)
+	}
+	err := client.Discount.Delete("gid://shopify/DiscountAutomaticNode/9")
+	if err == nil || err.Error() != "id: Discount does not exist" {
+		t.Errorf("Discount.Delete returned %v, expected the user error", err)
+	}
+
+	if !strings.Contains(requests[0].Query, "discountCodeDelete") || !strings.Contains(requests[1].Query, "discountAutomaticDelete") {
+		t.Errorf("Discount.Delete did not pick the mutation by the kind of discount")
+	}
+}
@@ -25,7 +25,7 @@ type DraftOrderService interface {
 	Invoice(int64, DraftOrderInvoice) (*DraftOrderInvoice, error)
 	Complete(int64, bool) (*DraftOrder, error)
 
-	// MetafieldsService used for DrafT Order resource to communicate with Metafields resource
+	// MetafieldsService used for Draft Order resource to communicate with Metafields resource
 	MetafieldsService
 }
 
@@ -54,7 +54,7 @@ type DraftOrder struct {
 	Tags            string           `json:"tags,omitempty"`
 	TaxLines        []TaxLine        `json:"tax_lines,omitempty"`
 	AppliedDiscount *AppliedDiscount `json:"applied_discount,omitempty"`
-	TaxesIncluded   bool             `json:"taxes_included,omitempty"`
+	TaxesIncluded   *bool            `json:"taxes_included,omitempty"`
 	TotalTax        string           `json:"total_tax,omitempty"`
 	TotalPrice      string           `json:"total_price,omitempty"`
 	SubtotalPrice   *decimal.Decimal `json:"subtotal_price,omitempty"`
@@ -63,7 +63,7 @@ type DraftOrder struct {
 	UpdatedAt       *time.Time       `json:"updated_at,omitempty"`
 	Status          string           `json:"status,omitempty"`
 	// only in request to flag using the customer's default address
-	UseCustomerDefaultAddress bool `json:"use_customer_default_address,omitempty"`
+	UseCustomerDefaultAddress *bool `json:"use_customer_default_address,omitempty"`
 }
 
 // AppliedDiscount is the discount applied to the line item or the draft order object.
@@ -120,18 +120,13 @@ type DraftOrderCountOptions struct {
 // Create draft order
 func (s *DraftOrderServiceOp) Create(draftOrder DraftOrder) (*DraftOrder, error) {
 	path := fmt.Sprintf("%s.json", draftOrdersBasePath)
-	wrappedData := DraftOrderResource{DraftOrder: &draftOrder}
-	resource := new(DraftOrderResource)
-	err := s.client.Post(path, wrappedData, resource)
-	return resource.DraftOrder, err
+	return createResource(s.client, path, "draft_order", draftOrder)
 }
 
 // List draft orders
 func (s *DraftOrderServiceOp) List(options interface{}) ([]DraftOrder, error) {
 	path := fmt.Sprintf("%s.json", draftOrdersBasePath)
-	resource := new(DraftOrdersResource)
-	err := s.client.Get(path, resource, options)
-	return resource.DraftOrders, err
+	return listResource[DraftOrder](s.client, path, "draft_orders", options)
 }
 
 // Count draft orders
@@ -149,27 +144,19 @@ func (s *DraftOrderServiceOp) Delete(draftOrderID int64) error {
 // Invoice a draft order
 func (s *DraftOrderServiceOp) Invoice(draftOrderID int64, draftOrderInvoice DraftOrderInvoice) (*DraftOrderInvoice, error) {
 	path := fmt.Sprintf("%s/%d/send_invoice.json", draftOrdersBasePath, draftOrderID)
-	wrappedData := DraftOrderInvoiceResource{DraftOrderInvoice: &draftOrderInvoice}
-	resource := new(DraftOrderInvoiceResource)
-	err := s.client.Post(path, wrappedData, resource)
-	return resource.DraftOrderInvoice, err
+	return createResource(s.client, path, "draft_order_invoice", draftOrderInvoice)
 }
 
 // Get individual draft order
 func (s *DraftOrderServiceOp) Get(draftOrderID int64, options interface{}) (*DraftOrder, error) {
 	path := fmt.Sprintf("%s/%d.json", draftOrdersBasePath, draftOrderID)
-	resource := new(DraftOrderResource)
-	err := s.client.Get(path, resource, options)
-	return resource.DraftOrder, err
+	return getResource[DraftOrder](s.client, path, "draft_order", options)
 }
 
 // Update draft order
 func (s *DraftOrderServiceOp) Update(draftOrder DraftOrder) (*DraftOrder, error) {
 	path := fmt.Sprintf("%s/%d.json", draftOrdersBasePath, draftOrder.ID)
-	wrappedData := DraftOrderResource{DraftOrder: &draftOrder}
-	resource := new(DraftOrderResource)
-	err := s.client.Put(path, wrappedData, resource)
-	return resource.DraftOrder, err
+	return updateResource(s.client, path, "draft_order", draftOrder)
 }
 
 // Complete draft order
@@ -101,7 +101,7 @@ func TestDraftOrderUpdate(t *testing.T) {
 	draftOrder := DraftOrder{
 		ID:            1,
 		Note:          "slow order",
-		TaxesIncluded: true,
+		TaxesIncluded: Bool(true),
 	}
 
 	d, err := client.DraftOrder.Update(draftOrder)
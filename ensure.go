@@ -0,0 +1,194 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// EnsureStatus describes what an Ensure operation had to do to bring a
+// resource to its desired state.
+type EnsureStatus string
+
+const (
+	EnsureCreated   EnsureStatus = "created"
+	EnsureUpdated   EnsureStatus = "updated"
+	EnsureUnchanged EnsureStatus = "unchanged"
+	EnsureDeleted   EnsureStatus = "deleted"
+)
+
+// EnsureResult is the outcome of ensuring a single resource, Resource holds
+// the resource as it exists in Shopify afterwards.
+type EnsureResult[T any] struct {
+	Resource T
+	Status   EnsureStatus
+}
+
+// The Ensure operations below are idempotent: they compare the desired
+// resources with what already exists in the shop and only create or update
+// what differs, which makes them safe to run on every deploy or boot and
+// suitable for layering infrastructure-as-code tooling on top of.
+
+// EnsureOption configures an Ensure operation.
+type EnsureOption func(*ensureConfig)
+
+type ensureConfig struct {
+	deleteUnknown bool
+}
+
+// WithDeleteUnknown makes Ensure delete the existing resources that are not
+// desired, e.g. webhooks of topics the app no longer handles.
+func WithDeleteUnknown() EnsureOption {
+	return func(c *ensureConfig) {
+		c.deleteUnknown = true
+	}
+}
+
+// EnsureWebhooks makes sure a webhook exists for every desired topic and
+// address, see WebhookService.Ensure.
+func (c *Client) EnsureWebhooks(desired []Webhook, opts ...EnsureOption) ([]EnsureResult[Webhook], error) {
+	return c.Webhook.Ensure(context.Background(), desired, opts...)
+}
+
+func webhookDiffers(have, want Webhook) bool {
+	return (want.Format != "" && have.Format != want.Format) ||
+		!sameStrings(have.Fields, want.Fields) ||
+		!sameStrings(have.MetafieldNamespaces, want.MetafieldNamespaces)
+}
+
+// EnsureScriptTags makes sure a script tag exists for every desired src. An
+// existing script tag with the same src is updated when its event or display
+// scope differ.
+func (c *Client) EnsureScriptTags(desired []ScriptTag) ([]EnsureResult[ScriptTag], error) {
+	path := fmt.Sprintf("%s.json", scriptTagsBasePath)
+	existing, err := listAllPages(func(options interface{}) ([]ScriptTag, *Pagination, error) {
+		return listResourceWithPagination[ScriptTag](c, path, "script_tags", options)
+	}, ListOptions{Limit: 250})
+	if err != nil {
+		return nil, err
+	}
+
+	bySrc := make(map[string]ScriptTag, len(existing))
+	for _, tag := range existing {
+		bySrc[tag.Src] = tag
+	}
+
+	results := make([]EnsureResult[ScriptTag], 0, len(desired))
+	for _, want := range desired {
+		have, ok := bySrc[want.Src]
+		switch {
+		case !ok:
+			created, err := c.ScriptTag.Create(want)
+			if err != nil {
+				return results, fmt.Errorf("creating script tag %s: %w", want.Src, err)
+			}
+			results = append(results, EnsureResult[ScriptTag]{Resource: *created, Status: EnsureCreated})
+		case have.Event != want.Event || (want.DisplayScope != "" && have.DisplayScope != want.DisplayScope):
+			want.ID = have.ID
+			updated, err := c.ScriptTag.Update(want)
+			if err != nil {
+				return results, fmt.Errorf("updating script tag %s: %w", want.Src, err)
+			}
+			results = append(results, EnsureResult[ScriptTag]{Resource: *updated, Status: EnsureUpdated})
+		default:
+			results = append(results, EnsureResult[ScriptTag]{Resource: have, Status: EnsureUnchanged})
+		}
+	}
+
+	return results, nil
+}
+
+// EnsureCarrierService makes sure a carrier service with the desired name
+// exists, updating its callback url, format, active and service discovery
+// settings when they differ.
+func (c *Client) EnsureCarrierService(desired CarrierService) (EnsureResult[CarrierService], error) {
+	existing, err := c.CarrierService.List()
+	if err != nil {
+		return EnsureResult[CarrierService]{}, err
+	}
+
+	for _, have := range existing {
+		if have.Name != desired.Name {
+			continue
+		}
+
+		if !carrierServiceDiffers(have, desired) {
+			return EnsureResult[CarrierService]{Resource: have, Status: EnsureUnchanged}, nil
+		}
+
+		desired.ID = have.ID
+		updated, err := c.CarrierService.Update(desired)
+		if err != nil {
+			return EnsureResult[CarrierService]{}, fmt.Errorf("updating carrier service %s: %w", desired.Name, err)
+		}
+		return EnsureResult[CarrierService]{Resource: *updated, Status: EnsureUpdated}, nil
+	}
+
+	created, err := c.CarrierService.Create(desired)
+	if err != nil {
+		return EnsureResult[CarrierService]{}, fmt.Errorf("creating carrier service %s: %w", desired.Name, err)
+	}
+	return EnsureResult[CarrierService]{Resource: *created, Status: EnsureCreated}, nil
+}
+
+func carrierServiceDiffers(have, want CarrierService) bool {
+	return have.CallbackURL != want.CallbackURL ||
+		(want.Format != "" && have.Format != want.Format) ||
+		(want.Active != nil && (have.Active == nil || *have.Active != *want.Active)) ||
+		(want.ServiceDiscovery != nil && (have.ServiceDiscovery == nil || *have.ServiceDiscovery != *want.ServiceDiscovery))
+}
+
+// EnsureMetafieldDefinitions makes sure a definition exists for every desired
+// owner type, namespace and key. Existing definitions are updated when their
+// name or description differ. Since the type of a definition cannot be
+// changed, a type mismatch is returned as an error.
+func (c *Client) EnsureMetafieldDefinitions(desired []MetafieldDefinition) ([]EnsureResult[MetafieldDefinition], error) {
+	results := make([]EnsureResult[MetafieldDefinition], 0, len(desired))
+	for _, want := range desired {
+		name := fmt.Sprintf("%s %s.%s", want.OwnerType, want.Namespace, want.Key)
+
+		have, err := c.MetafieldDefinition.Get(want.OwnerType, want.Namespace, want.Key)
+		if err != nil {
+			return results, fmt.Errorf("fetching metafield definition %s: %w", name, err)
+		}
+
+		switch {
+		case have == nil:
+			created, err := c.MetafieldDefinition.Create(want)
+			if err != nil {
+				return results, fmt.Errorf("creating metafield definition %s: %w", name, err)
+			}
+			results = append(results, EnsureResult[MetafieldDefinition]{Resource: *created, Status: EnsureCreated})
+		case have.Type != want.Type:
+			return results, fmt.Errorf("metafield definition %s has type %s, expected %s", name, have.Type, want.Type)
+		case have.Name != want.Name || have.Description != want.Description:
+			updated, err := c.MetafieldDefinition.Update(want)
+			if err != nil {
+				return results, fmt.Errorf("updating metafield definition %s: %w", name, err)
+			}
+			results = append(results, EnsureResult[MetafieldDefinition]{Resource: *updated, Status: EnsureUpdated})
+		default:
+			results = append(results, EnsureResult[MetafieldDefinition]{Resource: *have, Status: EnsureUnchanged})
+		}
+	}
+
+	return results, nil
+}
+
+// sameStrings compares two string lists ignoring order, treating nil and
+// empty lists as equal.
+func sameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a = append([]string(nil), a...)
+	b = append([]string(nil), b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,329 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+// echoResource responds with the request body, which already has the shape of
+// a wrapped resource.
+func echoResource(req *http.Request) (*http.Response, error) {
+	body, _ := ioutil.ReadAll(req.Body)
+	return httpmock.NewBytesResponse(200, body), nil
+}
+
+func TestEnsureWebhooks(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/webhooks.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"webhooks": [
+			{"id": 1, "topic": "orders/create", "address": "https://example.com/orders", "format": "json"},
+			{"id": 2, "topic": "products/update", "address": "https://example.com/products", "format": "xml"}
+		]}`))
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/webhooks/2.json", client.pathPrefix), echoResource)
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/webhooks.json", client.pathPrefix), echoResource)
+
+	results, err := client.EnsureWebhooks([]Webhook{
+		{Topic: "orders/create", Address: "https://example.com/orders"},
+		{Topic: "products/update", Address: "https://example.com/products", Format: "json"},
+		{Topic: "app/uninstalled", Address: "https://example.com/uninstalled"},
+	})
+	if err != nil {
+		t.Fatalf("Client.EnsureWebhooks returned error: %v", err)
+	}
+
+	expected := []EnsureStatus{EnsureUnchanged, EnsureUpdated, EnsureCreated}
+	if len(results) != len(expected) {
+		t.Fatalf("Client.EnsureWebhooks returned %d results, expected %d", len(results), len(expected))
+	}
+	for i, result := range results {
+		if result.Status != expected[i] {
+			t.Errorf("Client.EnsureWebhooks result %d status %s, expected %s", i, result.Status, expected[i])
+		}
+	}
+
+	if results[1].Resource.ID != 2 || results[1].Resource.Format != "json" {
+		t.Errorf("Client.EnsureWebhooks updated %+v, expected id 2 with the new format", results[1].Resource)
+	}
+
+	info := httpmock.GetCallCountInfo()
+	if info[fmt.Sprintf("PUT https://fooshop.myshopify.com/%s/webhooks/2.json", client.pathPrefix)] != 1 {
+		t.Errorf("Client.EnsureWebhooks expected a single update, calls: %v", info)
+	}
+}
+
+func TestWebhookEnsureDeleteUnknown(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/webhooks.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"webhooks": [
+			{"id": 1, "topic": "orders/create", "address": "https://example.com/orders", "format": "json"},
+			{"id": 2, "topic": "products/update", "address": "https://example.com/products", "format": "json"}
+		]}`))
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("https://fooshop.myshopify.com/%s/webhooks/2.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, "{}"))
+
+	results, err := client.Webhook.Ensure(context.Background(), []Webhook{
+		{Topic: "orders/create", Address: "https://example.com/orders"},
+	}, WithDeleteUnknown())
+	if err != nil {
+		t.Fatalf("Webhook.Ensure returned error: %v", err)
+	}
+
+	if len(results) != 2 || results[0].Status != EnsureUnchanged || results[1].Status != EnsureDeleted || results[1].Resource.ID != 2 {
+		t.Errorf("Webhook.Ensure returned %+v, expected webhook 1 unchanged and 2 deleted", results)
+	}
+}
+
+func TestWebhookEnsureSameTopic(t *testing.T) {
+	setup()
+	defer teardown()
+
+	arn := "arn:aws:events:us-east-1::event-source/aws.partner/shopify.com/1/orders"
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/webhooks.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"webhooks": [
+			{"id": 1, "topic": "orders/create", "address": "https://example.com/orders", "format": "json"},
+			{"id": 2, "topic": "orders/create", "address": "https://old.example.com/orders", "format": "json"},
+			{"id": 3, "topic": "orders/create", "address": "https://example.com/orders", "format": "json"}
+		]}`))
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/webhooks.json", client.pathPrefix), echoResource)
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/webhooks/2.json", client.pathPrefix), echoResource)
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("https://fooshop.myshopify.com/%s/webhooks/3.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, "{}"))
+
+	results, err := client.Webhook.Ensure(context.Background(), []Webhook{
+		{Topic: "orders/create", Address: "https://example.com/orders"},
+		{Topic: "orders/create", Address: arn},
+	}, WithDeleteUnknown())
+	if err != nil {
+		t.Fatalf("Webhook.Ensure returned error: %v", err)
+	}
+
+	type outcome struct {
+		ID      int64
+		Address string
+		Status  EnsureStatus
+	}
+	var outcomes []outcome
+	for _, result := range results {
+		outcomes = append(outcomes, outcome{result.Resource.ID, result.Resource.Address, result.Status})
+	}
+	expected := []outcome{
+		{1, "https://example.com/orders", EnsureUnchanged},
+		{2, arn, EnsureUpdated},
+		{3, "https://example.com/orders", EnsureDeleted},
+	}
+	if !reflect.DeepEqual(outcomes, expected) {
+		t.Errorf("Webhook.Ensure returned %+v, expected %+v", outcomes, expected)
+	}
+}
+
+func TestWebhookEnsureAddressChange(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/webhooks.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"webhooks": [
+			{"id": 1, "topic": "orders/create", "address": "https://old.example.com/orders", "format": "json"}
+		]}`))
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/webhooks/1.json", client.pathPrefix), echoResource)
+
+	results, err := client.Webhook.Ensure(context.Background(), []Webhook{
+		{Topic: "orders/create", Address: "https://example.com/orders"},
+	})
+	if err != nil {
+		t.Fatalf("Webhook.Ensure returned error: %v", err)
+	}
+
+	if len(results) != 1 || results[0].Status != EnsureUpdated || results[0].Resource.ID != 1 ||
+		results[0].Resource.Address != "https://example.com/orders" {
+		t.Errorf("Webhook.Ensure returned %+v, expected webhook 1 updated to the new address", results)
+	}
+	if count := httpmock.GetTotalCallCount(); count != 2 {
+		t.Errorf("Webhook.Ensure made %d requests, expected the listing and a single update", count)
+	}
+}
+
+func TestWebhookEnsureEmptyResponse(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/webhooks.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"webhooks": [
+			{"id": 1, "topic": "orders/create", "address": "https://example.com/orders", "format": "xml"}
+		]}`))
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/webhooks.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, "{}"))
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/webhooks/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, "{}"))
+
+	cases := []struct {
+		webhook  Webhook
+		expected string
+	}{
+		{Webhook{Topic: "app/uninstalled", Address: "https://example.com/uninstalled"}, "creating webhook app/uninstalled: no webhook in the response"},
+		{Webhook{Topic: "orders/create", Address: "https://example.com/orders", Format: "json"}, "updating webhook orders/create: no webhook in the response"},
+	}
+	for _, c := range cases {
+		_, err := client.Webhook.Ensure(context.Background(), []Webhook{c.webhook})
+		if err == nil || err.Error() != c.expected {
+			t.Errorf("Webhook.Ensure returned error %v, expected %s", err, c.expected)
+		}
+	}
+}
+
+func TestWebhookEnsureContext(t *testing.T) {
+	setup()
+	defer teardown()
+
+	type contextKey struct{}
+	ctx := context.WithValue(context.Background(), contextKey{}, "ensure")
+
+	var calls []string
+	responder := func(body string) httpmock.Responder {
+		return func(req *http.Request) (*http.Response, error) {
+			if req.Context().Value(contextKey{}) == "ensure" {
+				calls = append(calls, req.Method)
+			}
+			return httpmock.NewStringResponse(200, body), nil
+		}
+	}
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/webhooks.json", client.pathPrefix),
+		responder(`{"webhooks": []}`))
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/webhooks.json", client.pathPrefix),
+		responder(`{"webhook": {"id": 1, "topic": "orders/create"}}`))
+
+	_, err := client.Webhook.Ensure(ctx, []Webhook{{Topic: "orders/create", Address: "https://example.com/orders"}})
+	if err != nil {
+		t.Fatalf("Webhook.Ensure returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(calls, []string{"GET", "POST"}) {
+		t.Errorf("Webhook.Ensure made %v requests with the context, expected GET and POST", calls)
+	}
+}
+
+func TestEnsureScriptTags(t *testing.T) {
+	setup()
+	defer teardown()
+
+	listURL := fmt.Sprintf("https://fooshop.myshopify.com/%s/script_tags.json", client.pathPrefix)
+	httpmock.RegisterResponder("GET", listURL,
+		createResponderWithHeaders(200, `{"script_tags": [{"id": 1, "src": "https://example.com/a.js", "event": "onload", "display_scope": "all"}]}`,
+			map[string]string{"Link": fmt.Sprintf(`<%s?page_info=next&limit=250>; rel="next"`, listURL)}))
+	httpmock.RegisterResponderWithQuery("GET", listURL, "page_info=next&limit=250",
+		httpmock.NewStringResponder(200, `{"script_tags": [{"id": 2, "src": "https://example.com/c.js", "event": "onload", "display_scope": "all"}]}`))
+	httpmock.RegisterResponder("POST", listURL, echoResource)
+
+	results, err := client.EnsureScriptTags([]ScriptTag{
+		{Src: "https://example.com/a.js", Event: "onload"},
+		{Src: "https://example.com/b.js", Event: "onload"},
+		{Src: "https://example.com/c.js", Event: "onload"},
+	})
+	if err != nil {
+		t.Fatalf("Client.EnsureScriptTags returned error: %v", err)
+	}
+
+	if results[0].Status != EnsureUnchanged || results[1].Status != EnsureCreated || results[2].Status != EnsureUnchanged {
+		t.Errorf("Client.EnsureScriptTags returned %+v, expected unchanged, created and unchanged", results)
+	}
+}
+
+func TestEnsureCarrierService(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/carrier_services.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("carrier_services.json")))
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/carrier_services/1036894960.json", client.pathPrefix), echoResource)
+
+	result, err := client.EnsureCarrierService(CarrierService{
+		Name:        "Shipping Rate Provider",
+		CallbackURL: "http://shippingrateprovider.com/",
+	})
+	if err != nil || result.Status != EnsureUnchanged {
+		t.Errorf("Client.EnsureCarrierService returned %+v, %v, expected unchanged", result, err)
+	}
+
+	result, err = client.EnsureCarrierService(CarrierService{
+		Name:        "Shipping Rate Provider",
+		CallbackURL: "https://example.com/rates",
+	})
+	if err != nil || result.Status != EnsureUpdated || result.Resource.ID != 1036894960 {
+		t.Errorf("Client.EnsureCarrierService returned %+v, %v, expected updated", result, err)
+	}
+}
+
+func TestEnsureMetafieldDefinitions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	existing := `{"data":{"metafieldDefinitions":{"edges":[{"node":{"id":"gid://shopify/MetafieldDefinition/1","name":"Color","namespace":"app","key":"color","description":"","ownerType":"PRODUCT","type":{"name":"single_line_text_field"}}}]}}}`
+	missing := `{"data":{"metafieldDefinitions":{"edges":[]}}}`
+	created := `{"data":{"metafieldDefinitionCreate":{"createdDefinition":{"id":"gid://shopify/MetafieldDefinition/2","name":"Size","namespace":"app","key":"size","description":"","ownerType":"PRODUCT","type":{"name":"number_integer"}},"userErrors":[]}}}`
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			var sent struct {
+				Query     string                 `json:"query"`
+				Variables map[string]interface{} `json:"variables"`
+			}
+			body, _ := ioutil.ReadAll(req.Body)
+			_ = json.Unmarshal(body, &sent)
+
+			switch {
+			case sent.Query == metafieldDefinitionCreateMutation:
+				return httpmock.NewStringResponse(200, created), nil
+			case sent.Variables["key"] == "color":
+				return httpmock.NewStringResponse(200, existing), nil
+			default:
+				return httpmock.NewStringResponse(200, missing), nil
+			}
+		})
+
+	results, err := client.EnsureMetafieldDefinitions([]MetafieldDefinition{
+		{Name: "Color", Namespace: "app", Key: "color", Type: "single_line_text_field", OwnerType: "PRODUCT"},
+		{Name: "Size", Namespace: "app", Key: "size", Type: "number_integer", OwnerType: "PRODUCT"},
+	})
+	if err != nil {
+		t.Fatalf("Client.EnsureMetafieldDefinitions returned error: %v", err)
+	}
+
+	if results[0].Status != EnsureUnchanged || results[1].Status != EnsureCreated {
+		t.Errorf("Client.EnsureMetafieldDefinitions returned %+v, expected unchanged and created", results)
+	}
+
+	_, err = client.EnsureMetafieldDefinitions([]MetafieldDefinition{
+		{Name: "Color", Namespace: "app", Key: "color", Type: "color", OwnerType: "PRODUCT"},
+	})
+	if err == nil {
+		t.Errorf("Client.EnsureMetafieldDefinitions expected an error for a type mismatch")
+	}
+}
+
+func TestSameStrings(t *testing.T) {
+	cases := []struct {
+		a, b     []string
+		expected bool
+	}{
+		{nil, []string{}, true},
+		{[]string{"a", "b"}, []string{"b", "a"}, true},
+		{[]string{"a"}, []string{"a", "b"}, false},
+		{[]string{"a", "c"}, []string{"a", "b"}, false},
+	}
+
+	for _, c := range cases {
+		if actual := sameStrings(c.a, c.b); actual != c.expected {
+			t.Errorf("sameStrings(%v, %v) = %v, expected %v", c.a, c.b, actual, c.expected)
+		}
+	}
+}
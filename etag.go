@@ -0,0 +1,119 @@
+package goshopify
+
+import (
+	"bytes"
+	"container/list"
+	"io/ioutil"
+	"net/http"
+	"sync"
+)
+
+// CachedResponse is a response kept by an ETagCache, its headers include the
+// ETag and Last-Modified validators.
+type CachedResponse struct {
+	Header http.Header
+	Body   []byte
+}
+
+// ETagCache stores the responses to GET requests that carry an ETag or
+// Last-Modified header, keyed by the request URL, which includes the shop.
+// Implementations must be safe for concurrent use.
+type ETagCache interface {
+	Get(key string) (*CachedResponse, bool)
+	Set(key string, resp *CachedResponse)
+}
+
+// MemoryETagCache is an ETagCache keeping the most recently used responses in
+// memory.
+type MemoryETagCache struct {
+	maxEntries int
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type memoryETagEntry struct {
+	key  string
+	resp *CachedResponse
+}
+
+// NewMemoryETagCache returns a cache holding up to maxEntries responses, the
+// least recently used ones are evicted first. Zero means no limit.
+func NewMemoryETagCache(maxEntries int) *MemoryETagCache {
+	return &MemoryETagCache{
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    map[string]*list.Element{},
+	}
+}
+
+// Get returns the response cached for the key.
+func (m *MemoryETagCache) Get(key string) (*CachedResponse, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	m.order.MoveToFront(e)
+	return e.Value.(*memoryETagEntry).resp, true
+}
+
+// Set caches the response for the key.
+func (m *MemoryETagCache) Set(key string, resp *CachedResponse) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if e, ok := m.entries[key]; ok {
+		e.Value.(*memoryETagEntry).resp = resp
+		m.order.MoveToFront(e)
+		return
+	}
+
+	m.entries[key] = m.order.PushFront(&memoryETagEntry{key: key, resp: resp})
+	if m.maxEntries > 0 && m.order.Len() > m.maxEntries {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoryETagEntry).key)
+	}
+}
+
+// conditionalRequest makes a GET request conditional on the validators of
+// the response cached for it, which it returns.
+func (c *Client) conditionalRequest(req *http.Request) *CachedResponse {
+	if c.etagCache == nil || req.Method != http.MethodGet {
+		return nil
+	}
+
+	cached, ok := c.etagCache.Get(req.URL.String())
+	if !ok {
+		return nil
+	}
+	if etag := cached.Header.Get("ETag"); etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if lastModified := cached.Header.Get("Last-Modified"); lastModified != "" {
+		req.Header.Set("If-Modified-Since", lastModified)
+	}
+	return cached
+}
+
+// useCached replaces the body of a 304 Not Modified response with the cached
+// one, headers missing from the 304, e.g. Link, are taken from the cache.
+func useCached(resp *http.Response, cached *CachedResponse) {
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(cached.Body))
+	for name, values := range cached.Header {
+		if _, ok := resp.Header[name]; !ok {
+			resp.Header[name] = values
+		}
+	}
+}
+
+// cacheable reports whether the response should be kept in the ETag cache.
+func (c *Client) cacheable(req *http.Request, resp *http.Response) bool {
+	return c.etagCache != nil && req.Method == http.MethodGet && resp.StatusCode == http.StatusOK &&
+		(resp.Header.Get("ETag") != "" || resp.Header.Get("Last-Modified") != "")
+}
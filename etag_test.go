@@ -0,0 +1,111 @@
+package goshopify
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestETagCache(t *testing.T) {
+	setup()
+	defer teardown()
+
+	WithETagCache(NewMemoryETagCache(10))(client)
+
+	var ifNoneMatch []string
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shop.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			ifNoneMatch = append(ifNoneMatch, req.Header.Get("If-None-Match"))
+			if req.Header.Get("If-None-Match") == `"v1"` {
+				return httpmock.NewStringResponse(http.StatusNotModified, ""), nil
+			}
+			resp := httpmock.NewStringResponse(200, `{"shop":{"id":1,"name":"Foo"}}`)
+			resp.Header.Set("ETag", `"v1"`)
+			return resp, nil
+		})
+
+	for i := 0; i < 2; i++ {
+		shop, err := client.Shop.Get(nil)
+		if err != nil {
+			t.Fatalf("Shop.Get returned error: %v", err)
+		}
+		if shop.ID != 1 || shop.Name != "Foo" {
+			t.Errorf("Shop.Get returned %+v, expected shop 1", shop)
+		}
+	}
+
+	expected := []string{"", `"v1"`}
+	if fmt.Sprint(ifNoneMatch) != fmt.Sprint(expected) {
+		t.Errorf("Shop.Get sent If-None-Match %q, expected %q", ifNoneMatch, expected)
+	}
+	if status := client.LastResponse().StatusCode; status != http.StatusNotModified {
+		t.Errorf("LastResponse().StatusCode = %d, expected %d", status, http.StatusNotModified)
+	}
+}
+
+func TestETagCacheLastModified(t *testing.T) {
+	setup()
+	defer teardown()
+
+	cache := NewMemoryETagCache(0)
+	WithETagCache(cache)(client)
+
+	var ifModifiedSince string
+	url := fmt.Sprintf("https://fooshop.myshopify.com/%s/locations.json", client.pathPrefix)
+	httpmock.RegisterResponder("GET", url,
+		func(req *http.Request) (*http.Response, error) {
+			ifModifiedSince = req.Header.Get("If-Modified-Since")
+			resp := httpmock.NewStringResponse(200, `{"locations":[{"id":1}]}`)
+			resp.Header.Set("Last-Modified", "Wed, 21 Oct 2015 07:28:00 GMT")
+			return resp, nil
+		})
+
+	client.Location.List(nil)
+	client.Location.List(nil)
+
+	if ifModifiedSince != "Wed, 21 Oct 2015 07:28:00 GMT" {
+		t.Errorf("Location.List sent If-Modified-Since %q", ifModifiedSince)
+	}
+	if _, ok := cache.Get(url); !ok {
+		t.Errorf("MemoryETagCache has no response for %s", url)
+	}
+}
+
+func TestETagCacheNotCached(t *testing.T) {
+	setup()
+	defer teardown()
+
+	WithETagCache(NewMemoryETagCache(10))(client)
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shop.json", client.pathPrefix),
+		httpmock.NewStringResponder(http.StatusNotModified, ""))
+
+	// a 304 without a cached response is an error like any other status
+	if _, err := client.Shop.Get(nil); err == nil {
+		t.Errorf("Shop.Get returned no error for an unexpected 304")
+	}
+}
+
+func TestMemoryETagCacheEviction(t *testing.T) {
+	cache := NewMemoryETagCache(2)
+	cache.Set("a", &CachedResponse{Body: []byte("a")})
+	cache.Set("b", &CachedResponse{Body: []byte("b")})
+	cache.Get("a")
+	cache.Set("c", &CachedResponse{Body: []byte("c")})
+
+	if _, ok := cache.Get("b"); ok {
+		t.Errorf("MemoryETagCache kept the least recently used entry")
+	}
+	for _, key := range []string{"a", "c"} {
+		if resp, ok := cache.Get(key); !ok || string(resp.Body) != key {
+			t.Errorf("MemoryETagCache.Get(%q) = %v, %v", key, resp, ok)
+		}
+	}
+
+	cache.Set("a", &CachedResponse{Body: []byte("updated")})
+	if resp, _ := cache.Get("a"); string(resp.Body) != "updated" {
+		t.Errorf("MemoryETagCache.Get(\"a\") = %s, expected the updated response", resp.Body)
+	}
+}
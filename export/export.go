@@ -0,0 +1,320 @@
+// Package export streams every page of a listable REST resource of the Admin
+// API, e.g. orders or products, to JSONL or CSV. Pages are written as they
+// are fetched, so exports of any size don't have to fit in memory, and an
+// export that failed can be resumed from the cursor of the page it stopped
+// at.
+//
+//	f, _ := os.Create("orders.jsonl")
+//	progress, err := export.Export(ctx, client, export.Orders, f, export.Options{
+//		Query: url.Values{"status": {"any"}, "limit": {"250"}},
+//	})
+//	if err != nil {
+//		// retry later with Options.Cursor set to progress.Cursor
+//	}
+package export
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+
+	goshopify "github.com/myhelix/go-shopify"
+)
+
+// Format is the format resources are exported in.
+type Format string
+
+const (
+	// JSONL writes one JSON object per line.
+	JSONL Format = "jsonl"
+	// CSV writes a header and one row per resource.
+	CSV Format = "csv"
+)
+
+// Resource is a listable REST resource, see NewResource.
+type Resource struct {
+	path  string
+	model reflect.Type
+}
+
+// NewResource describes a resource listed at path, e.g. "orders" for
+// orders.json, whose list responses are keyed by the path and decoded into
+// model, e.g. goshopify.Order{}. Nested resources are supported too, e.g.
+// "products/1/variants".
+func NewResource(path string, model interface{}) Resource {
+	return Resource{path: path, model: reflect.TypeOf(model)}
+}
+
+// The resources most commonly exported.
+var (
+	Orders    = NewResource("orders", goshopify.Order{})
+	Products  = NewResource("products", goshopify.Product{})
+	Customers = NewResource("customers", goshopify.Customer{})
+)
+
+// key returns the key list responses are wrapped in, the last segment of the
+// path.
+func (r Resource) key() string {
+	return r.path[strings.LastIndex(r.path, "/")+1:]
+}
+
+// newList returns a pointer to a wrapper struct for list responses, e.g.
+// struct{ Products []Product `json:"products"` }.
+func (r Resource) newList() reflect.Value {
+	return reflect.New(reflect.StructOf([]reflect.StructField{{
+		Name: "Items",
+		Type: reflect.SliceOf(r.model),
+		Tag:  reflect.StructTag(fmt.Sprintf(`json:"%s"`, r.key())),
+	}}))
+}
+
+// Options configure an export.
+type Options struct {
+	// Format defaults to JSONL
+	Format Format
+
+	// Query holds the list options, e.g. status=any and limit=250
+	Query url.Values
+
+	// Columns are the CSV columns, they default to the fields of the query or
+	// else every field of the model. Nested objects and lists are written as
+	// JSON.
+	Columns []string
+
+	// Cursor resumes an export at the page it points to, see Progress. Only
+	// the limit and fields of the query are kept, as Shopify requires, and no
+	// CSV header is written so the output can be appended to the earlier one.
+	Cursor string
+
+	// PageInterval is the least time between two page requests, on top of
+	// the rate limiter of the client if it has one.
+	PageInterval time.Duration
+
+	// Progress is called after every page that was written.
+	Progress func(Progress)
+}
+
+// Progress reports how far an export got.
+type Progress struct {
+	// Pages and Items count what was written so far
+	Pages int
+	Items int
+
+	// Cursor points to the next page to export, it is empty once the export
+	// is done.
+	Cursor string
+}
+
+// writer writes the exported resources one at a time.
+type writer interface {
+	write(item reflect.Value) error
+	flush() error
+}
+
+// Export streams every page of the resource to w and reports how far it got.
+// When it fails the returned progress holds the cursor of the page that
+// failed, to resume the export with.
+func Export(ctx context.Context, client *goshopify.Client, resource Resource, w io.Writer, opts Options) (Progress, error) {
+	progress := Progress{Cursor: opts.Cursor}
+
+	out, err := newWriter(w, resource, opts)
+	if err != nil {
+		return progress, err
+	}
+
+	query := opts.Query
+	if opts.Cursor != "" {
+		query = cursorQuery(opts.Query, opts.Cursor)
+	}
+
+	for {
+		list := resource.newList()
+		path := fmt.Sprintf("%s.json", resource.path)
+		if len(query) > 0 {
+			path = fmt.Sprintf("%s?%s", path, query.Encode())
+		}
+		resp, err := client.CreateAndDoWithResponse(ctx, "GET", path, nil, nil, list.Interface())
+		if err != nil {
+			return progress, err
+		}
+
+		items := list.Elem().Field(0)
+		for i := 0; i < items.Len(); i++ {
+			if err := out.write(items.Index(i)); err != nil {
+				return progress, err
+			}
+		}
+		if err := out.flush(); err != nil {
+			return progress, err
+		}
+
+		query, err = nextPage(resp)
+		if err != nil {
+			return progress, err
+		}
+		progress.Pages++
+		progress.Items += items.Len()
+		progress.Cursor = query.Get("page_info")
+		if opts.Progress != nil {
+			opts.Progress(progress)
+		}
+
+		if query == nil {
+			return progress, nil
+		}
+		if err := wait(ctx, opts.PageInterval); err != nil {
+			return progress, err
+		}
+	}
+}
+
+// cursorQuery returns the query of the page a cursor points to.
+func cursorQuery(query url.Values, cursor string) url.Values {
+	values := url.Values{"page_info": {cursor}}
+	for _, key := range []string{"limit", "fields"} {
+		if value := query.Get(key); value != "" {
+			values.Set(key, value)
+		}
+	}
+	return values
+}
+
+// nextPage returns the query of the page after the response, or nil when it
+// was the last one.
+func nextPage(resp *goshopify.Response) (url.Values, error) {
+	if resp == nil {
+		return nil, nil
+	}
+
+	link := resp.Header.Get("Link")
+	if link != "" && resp.Pagination == nil {
+		return nil, fmt.Errorf("invalid pagination link %q", link)
+	}
+	if resp.Pagination == nil || resp.Pagination.NextPageURL == "" {
+		return nil, nil
+	}
+
+	next, err := url.Parse(resp.Pagination.NextPageURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid next page link %q: %v", resp.Pagination.NextPageURL, err)
+	}
+	return next.Query(), nil
+}
+
+// wait sleeps for d or until the context is done.
+func wait(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func newWriter(w io.Writer, resource Resource, opts Options) (writer, error) {
+	switch opts.Format {
+	case JSONL, "":
+		return &jsonlWriter{encoder: json.NewEncoder(w)}, nil
+	case CSV:
+		columns := opts.Columns
+		if columns == nil && opts.Query.Get("fields") != "" {
+			columns = strings.Split(opts.Query.Get("fields"), ",")
+		}
+		if columns == nil {
+			columns = jsonFieldNames(resource.model)
+		}
+		return &csvWriter{writer: csv.NewWriter(w), columns: columns, header: opts.Cursor != ""}, nil
+	default:
+		return nil, fmt.Errorf("unknown export format %q, expected jsonl or csv", opts.Format)
+	}
+}
+
+type jsonlWriter struct {
+	encoder *json.Encoder
+}
+
+func (e *jsonlWriter) write(item reflect.Value) error {
+	return e.encoder.Encode(item.Interface())
+}
+
+func (e *jsonlWriter) flush() error {
+	return nil
+}
+
+// csvWriter writes one row per resource, header is set once the header was
+// written.
+type csvWriter struct {
+	writer  *csv.Writer
+	columns []string
+	header  bool
+}
+
+func (e *csvWriter) write(item reflect.Value) error {
+	if !e.header {
+		if err := e.writer.Write(e.columns); err != nil {
+			return err
+		}
+		e.header = true
+	}
+
+	data, err := json.Marshal(item.Interface())
+	if err != nil {
+		return err
+	}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	row := make([]string, len(e.columns))
+	for i, column := range e.columns {
+		row[i] = csvValue(fields[column])
+	}
+	return e.writer.Write(row)
+}
+
+func (e *csvWriter) flush() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+// csvValue formats a JSON value for a CSV cell, strings without their
+// quotes and null as an empty cell.
+func csvValue(raw json.RawMessage) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	return string(raw)
+}
+
+// jsonFieldNames returns the JSON names of the fields of a struct in order.
+func jsonFieldNames(t reflect.Type) []string {
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" || field.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,228 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	goshopify "github.com/myhelix/go-shopify"
+)
+
+const baseURL = "https://fooshop.myshopify.com/admin/api/2021-01"
+
+func setup() *goshopify.Client {
+	client := goshopify.NewClient(goshopify.App{}, "fooshop", "abcd", goshopify.WithVersion("2021-01"))
+	httpmock.ActivateNonDefault(client.Client)
+	return client
+}
+
+func teardown() {
+	httpmock.DeactivateAndReset()
+}
+
+// registerProductPages registers two pages of products, the second one
+// fails with a 500 until failing is false.
+func registerProductPages(queries *[]url.Values, failing *bool) {
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/products.json", baseURL),
+		func(req *http.Request) (*http.Response, error) {
+			*queries = append(*queries, req.URL.Query())
+			if req.URL.Query().Get("page_info") == "abc" {
+				if failing != nil && *failing {
+					return httpmock.NewStringResponse(500, `{"errors": "Internal Server Error"}`), nil
+				}
+				return httpmock.NewStringResponse(200, `{"products": [{"id": 2, "title": "bar, baz"}]}`), nil
+			}
+			resp := httpmock.NewStringResponse(200, `{"products": [{"id": 1, "title": "foo", "tags": "a"}]}`)
+			resp.Header.Set("Link", fmt.Sprintf(`<%s/products.json?limit=1&page_info=abc>; rel="next"`, baseURL))
+			return resp, nil
+		})
+}
+
+func TestExportJSONL(t *testing.T) {
+	client := setup()
+	defer teardown()
+
+	var queries []url.Values
+	registerProductPages(&queries, nil)
+
+	var reported []Progress
+	out := new(bytes.Buffer)
+	progress, err := Export(context.Background(), client, Products, out, Options{
+		Query:    url.Values{"limit": {"1"}, "status": {"active"}},
+		Progress: func(p Progress) { reported = append(reported, p) },
+	})
+	if err != nil {
+		t.Fatalf("Export returned error: %v", err)
+	}
+
+	expected := `{"id":1,"title":"foo","tags":"a","image":{}}` + "\n" + `{"id":2,"title":"bar, baz","image":{}}` + "\n"
+	if out.String() != expected {
+		t.Errorf("Export wrote %q, expected %q", out.String(), expected)
+	}
+
+	expectedProgress := []Progress{{Pages: 1, Items: 1, Cursor: "abc"}, {Pages: 2, Items: 2}}
+	if !reflect.DeepEqual(reported, expectedProgress) {
+		t.Errorf("Export reported %+v, expected %+v", reported, expectedProgress)
+	}
+	if progress != expectedProgress[1] {
+		t.Errorf("Export returned %+v, expected %+v", progress, expectedProgress[1])
+	}
+
+	expectedQueries := []url.Values{
+		{"limit": {"1"}, "status": {"active"}},
+		{"limit": {"1"}, "page_info": {"abc"}},
+	}
+	if !reflect.DeepEqual(queries, expectedQueries) {
+		t.Errorf("Export sent %v, expected %v", queries, expectedQueries)
+	}
+}
+
+func TestExportCSVResume(t *testing.T) {
+	client := setup()
+	defer teardown()
+
+	var queries []url.Values
+	failing := true
+	registerProductPages(&queries, &failing)
+
+	opts := Options{
+		Format: CSV,
+		Query:  url.Values{"limit": {"1"}, "status": {"active"}, "fields": {"id,title,tags"}},
+	}
+	out := new(bytes.Buffer)
+	progress, err := Export(context.Background(), client, Products, out, opts)
+	if err == nil {
+		t.Fatal("Export of a failing page returned no error")
+	}
+	if expected := (Progress{Pages: 1, Items: 1, Cursor: "abc"}); progress != expected {
+		t.Errorf("Export returned %+v, expected %+v", progress, expected)
+	}
+
+	failing = false
+	opts.Cursor = progress.Cursor
+	progress, err = Export(context.Background(), client, Products, out, opts)
+	if err != nil {
+		t.Fatalf("Export returned error on resume: %v", err)
+	}
+	if expected := (Progress{Pages: 1, Items: 1}); progress != expected {
+		t.Errorf("Export returned %+v on resume, expected %+v", progress, expected)
+	}
+
+	expected := "id,title,tags\n1,foo,a\n2,\"bar, baz\",\n"
+	if out.String() != expected {
+		t.Errorf("Export wrote %q, expected %q", out.String(), expected)
+	}
+
+	resumed := queries[len(queries)-1]
+	expectedQuery := url.Values{"limit": {"1"}, "page_info": {"abc"}, "fields": {"id,title,tags"}}
+	if !reflect.DeepEqual(resumed, expectedQuery) {
+		t.Errorf("Export sent %v on resume, expected %v", resumed, expectedQuery)
+	}
+}
+
+func TestExportCSVColumns(t *testing.T) {
+	client := setup()
+	defer teardown()
+
+	var queries []url.Values
+	registerProductPages(&queries, nil)
+
+	out := new(bytes.Buffer)
+	_, err := Export(context.Background(), client, NewResource("products", goshopify.Product{}), out, Options{
+		Format:  CSV,
+		Columns: []string{"title", "id"},
+	})
+	if err != nil {
+		t.Fatalf("Export returned error: %v", err)
+	}
+
+	expected := "title,id\nfoo,1\n\"bar, baz\",2\n"
+	if out.String() != expected {
+		t.Errorf("Export wrote %q, expected %q", out.String(), expected)
+	}
+}
+
+func TestExportUnknownFormat(t *testing.T) {
+	client := setup()
+	defer teardown()
+
+	_, err := Export(context.Background(), client, Orders, new(bytes.Buffer), Options{Format: "xml"})
+	expected := `unknown export format "xml", expected jsonl or csv`
+	if err == nil || err.Error() != expected {
+		t.Errorf("Export returned %v, expected %s", err, expected)
+	}
+}
+
+func TestExportCanceled(t *testing.T) {
+	client := setup()
+	defer teardown()
+
+	var queries []url.Values
+	registerProductPages(&queries, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	progress, err := Export(ctx, client, Products, new(bytes.Buffer), Options{
+		Progress: func(Progress) { cancel() },
+	})
+	if err != context.Canceled {
+		t.Errorf("Export returned %v, expected context.Canceled", err)
+	}
+	if progress.Cursor != "abc" || len(queries) != 1 {
+		t.Errorf("Export returned %+v after %d requests, expected to stop before abc", progress, len(queries))
+	}
+}
+
+// sharingWriter makes a call with the client the export uses on every write,
+// like another goroutine sharing the client would.
+type sharingWriter struct {
+	bytes.Buffer
+	client *goshopify.Client
+}
+
+func (w *sharingWriter) Write(p []byte) (int, error) {
+	if _, err := w.client.Shop.Get(nil); err != nil {
+		return 0, err
+	}
+	return w.Buffer.Write(p)
+}
+
+func TestExportSharedClient(t *testing.T) {
+	client := setup()
+	defer teardown()
+
+	var queries []url.Values
+	registerProductPages(&queries, nil)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/shop.json", baseURL),
+		httpmock.NewStringResponder(200, `{"shop": {"id": 1}}`))
+
+	progress, err := Export(context.Background(), client, Products, &sharingWriter{client: client}, Options{})
+	if err != nil {
+		t.Fatalf("Export returned error: %v", err)
+	}
+	if progress.Pages != 2 || progress.Items != 2 {
+		t.Errorf("Export returned %+v, expected both pages", progress)
+	}
+}
+
+func TestExportInvalidLink(t *testing.T) {
+	client := setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/products.json", baseURL),
+		func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(200, `{"products": [{"id": 1}]}`)
+			resp.Header.Set("Link", `<not a link>; rel="nope"`)
+			return resp, nil
+		})
+
+	_, err := Export(context.Background(), client, Products, new(bytes.Buffer), Options{})
+	expected := `invalid pagination link "<not a link>; rel=\"nope\""`
+	if err == nil || err.Error() != expected {
+		t.Errorf("Export returned %v, expected %s", err, expected)
+	}
+}
@@ -0,0 +1,92 @@
+package goshopify
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Fields returns the value of the fields query parameter selecting the named
+// fields of a resource, e.g. Fields(Product{}, "ID", "Handle", "Variants")
+// returns "id,handle,variants". Fields are named by their Go or JSON name,
+// without names every field of the struct is selected. It panics when the
+// model isn't a struct or has no field of a given name, like a typo in a
+// hand written list would only be noticed at runtime.
+//
+//	products, err := client.Product.List(&ProductListOptions{
+//		ListOptions: ListOptions{Fields: Fields(Product{}, "ID", "Title")},
+//	})
+func Fields(model interface{}, names ...string) string {
+	t := reflect.TypeOf(model)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("goshopify: Fields called with %T, expected a struct", model))
+	}
+
+	fields := structFields(t)
+	if len(names) == 0 {
+		all := make([]string, len(fields))
+		for i, f := range fields {
+			all[i] = f.json
+		}
+		return strings.Join(all, ",")
+	}
+
+	selected := make([]string, len(names))
+	for i, name := range names {
+		found := false
+		for _, f := range fields {
+			if f.name == name || f.json == name {
+				selected[i] = f.json
+				found = true
+				break
+			}
+		}
+		if !found {
+			panic(fmt.Sprintf("goshopify: %s has no field %s", t.Name(), name))
+		}
+	}
+	return strings.Join(selected, ",")
+}
+
+// structField is a field of a struct with its JSON name.
+type structField struct {
+	name string
+	json string
+}
+
+// structFields returns the exported fields of a struct in order, including
+// those of embedded structs.
+func structFields(t reflect.Type) []structField {
+	var fields []structField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				fields = append(fields, structFields(embedded)...)
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		fields = append(fields, structField{name: field.Name, json: name})
+	}
+	return fields
+}
@@ -0,0 +1,82 @@
+package goshopify
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestFields(t *testing.T) {
+	type Embedded struct {
+		Inner string `json:"inner"`
+	}
+	type Model struct {
+		Embedded
+		ID      int64  `json:"id,omitempty"`
+		Name    string `json:"name"`
+		Ignored string `json:"-"`
+		NoTag   string
+		private string
+	}
+
+	cases := []struct {
+		names    []string
+		expected string
+	}{
+		{nil, "inner,id,name,NoTag"},
+		{[]string{"ID", "Name"}, "id,name"},
+		{[]string{"name", "Inner"}, "name,inner"},
+	}
+
+	for _, c := range cases {
+		if fields := Fields(Model{}, c.names...); fields != c.expected {
+			t.Errorf("Fields(Model{}, %v) = %q, expected %q", c.names, fields, c.expected)
+		}
+	}
+
+	if fields := Fields(&Product{}, "ID", "Handle", "Variants"); fields != "id,handle,variants" {
+		t.Errorf("Fields(&Product{}) = %q, expected %q", fields, "id,handle,variants")
+	}
+}
+
+func TestFieldsPanics(t *testing.T) {
+	cases := []struct {
+		model interface{}
+		names []string
+	}{
+		{Product{}, []string{"Handel"}},
+		{Product{}, []string{"Ignored"}},
+		{"product", nil},
+		{nil, nil},
+	}
+
+	for _, c := range cases {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Fields(%#v, %v) did not panic", c.model, c.names)
+				}
+			}()
+			Fields(c.model, c.names...)
+		}()
+	}
+}
+
+func TestFieldsListOption(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponderWithQuery(
+		"GET",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/products.json", client.pathPrefix),
+		map[string]string{"fields": "id,title"},
+		httpmock.NewStringResponder(200, `{"products": [{"id":1,"title":"Shirt"}]}`))
+
+	products, err := client.Product.List(&ProductListOptions{
+		ListOptions: ListOptions{Fields: Fields(Product{}, "ID", "Title")},
+	})
+	if err != nil || len(products) != 1 || products[0].Title != "Shirt" {
+		t.Errorf("Product.List returned %+v, %v", products, err)
+	}
+}
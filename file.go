@@ -0,0 +1,260 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// Content types of files, which decide how Shopify processes them.
+const (
+	FileContentTypeFile  = "FILE"
+	FileContentTypeImage = "IMAGE"
+	FileContentTypeVideo = "VIDEO"
+)
+
+// Statuses of a file, files can only be used once they are READY.
+const (
+	FileStatusUploaded   = "UPLOADED"
+	FileStatusProcessing = "PROCESSING"
+	FileStatusReady      = "READY"
+	FileStatusFailed     = "FAILED"
+)
+
+// FileService is an interface for interfacing with the file endpoints
+// of the Shopify API.
+// See: https://shopify.dev/docs/api/admin-graphql/latest/mutations/fileCreate
+type FileService interface {
+	Create(...FileInput) ([]File, error)
+	Update(...FileInput) ([]File, error)
+	Delete(...string) error
+	Upload(context.Context, FileInput, io.Reader, int64) (*File, error)
+}
+
+// FileServiceOp handles communication with the file related GraphQL queries
+// and mutations.
+type FileServiceOp struct {
+	client *Client
+}
+
+// File represents a file of the shop. ContentType is derived from the GraphQL
+// type of the file and URL is empty until the file is processed.
+type File struct {
+	ID          string     `json:"id"`
+	Alt         string     `json:"alt,omitempty"`
+	ContentType string     `json:"contentType,omitempty"`
+	FileStatus  string     `json:"fileStatus,omitempty"`
+	MimeType    string     `json:"mimeType,omitempty"`
+	URL         string     `json:"url,omitempty"`
+	CreatedAt   *time.Time `json:"createdAt,omitempty"`
+}
+
+// FileInput describes a file to create or update. OriginalSource is the url
+// of the content, either an external url or the resource url of a staged
+// upload. The ContentType is only used when creating a file and the ID only
+// when updating one.
+type FileInput struct {
+	ID             string
+	OriginalSource string
+	Filename       string
+	Alt            string
+	ContentType    string
+}
+
+// fileNode is the GraphQL representation of a file, the url is nested in a
+// type specific object.
+type fileNode struct {
+	Typename   string     `json:"__typename"`
+	ID         string     `json:"id"`
+	Alt        string     `json:"alt"`
+	FileStatus string     `json:"fileStatus"`
+	CreatedAt  *time.Time `json:"createdAt"`
+	MimeType   string     `json:"mimeType"`
+	URL        string     `json:"url"`
+	Image      *struct {
+		URL string `json:"url"`
+	} `json:"image"`
+	Sources []struct {
+		URL string `json:"url"`
+	} `json:"sources"`
+}
+
+func (n fileNode) file() File {
+	file := File{
+		ID:          n.ID,
+		Alt:         n.Alt,
+		ContentType: FileContentTypeFile,
+		FileStatus:  n.FileStatus,
+		MimeType:    n.MimeType,
+		URL:         n.URL,
+		CreatedAt:   n.CreatedAt,
+	}
+	switch n.Typename {
+	case "MediaImage":
+		file.ContentType = FileContentTypeImage
+		if n.Image != nil {
+			file.URL = n.Image.URL
+		}
+	case "Video":
+		file.ContentType = FileContentTypeVideo
+		if len(n.Sources) > 0 {
+			file.URL = n.Sources[0].URL
+		}
+	}
+	return file
+}
+
+func fileNodesToFiles(nodes []fileNode) []File {
+	var files []File
+	for _, node := range nodes {
+		files = append(files, node.file())
+	}
+	return files
+}
+
+const fileFields = `__typename id alt fileStatus createdAt
+  ... on GenericFile { url mimeType }
+  ... on MediaImage { mimeType image { url } }
+  ... on Video { sources { url mimeType } }`
+
+const fileCreateMutation = `mutation($files: [FileCreateInput!]!) {
+  fileCreate(files: $files) {
+    files { ` + fileFields + ` }
+    userErrors { field message }
+  }
+}`
+
+const fileUpdateMutation = `mutation($files: [FileUpdateInput!]!) {
+  fileUpdate(files: $files) {
+    files { ` + fileFields + ` }
+    userErrors { field message }
+  }
+}`
+
+const fileDeleteMutation = `mutation($fileIds: [ID!]!) {
+  fileDelete(fileIds: $fileIds) {
+    deletedFileIds
+    userErrors { field message }
+  }
+}`
+
+// Create files from their original sources. Shopify processes the files
+// asynchronously, so they are usually returned with the UPLOADED status.
+func (s *FileServiceOp) Create(files ...FileInput) ([]File, error) {
+	inputs := make([]map[string]interface{}, 0, len(files))
+	for _, f := range files {
+		input := map[string]interface{}{"originalSource": f.OriginalSource}
+		if f.Filename != "" {
+			input["filename"] = f.Filename
+		}
+		if f.Alt != "" {
+			input["alt"] = f.Alt
+		}
+		if f.ContentType != "" {
+			input["contentType"] = f.ContentType
+		}
+		inputs = append(inputs, input)
+	}
+	resp := struct {
+		FileCreate struct {
+			Files      []fileNode  `json:"files"`
+			UserErrors []UserError `json:"userErrors"`
+		} `json:"fileCreate"`
+	}{}
+
+	err := s.client.GraphQL.Query(fileCreateMutation, map[string]interface{}{"files": inputs}, &resp)
+	if err == nil {
+		err = userErrorsToError(resp.FileCreate.UserErrors)
+	}
+	return fileNodesToFiles(resp.FileCreate.Files), err
+}
+
+// Update the alt text, filename or content of existing files.
+func (s *FileServiceOp) Update(files ...FileInput) ([]File, error) {
+	inputs := make([]map[string]interface{}, 0, len(files))
+	for _, f := range files {
+		input := map[string]interface{}{"id": f.ID}
+		if f.OriginalSource != "" {
+			input["originalSource"] = f.OriginalSource
+		}
+		if f.Filename != "" {
+			input["filename"] = f.Filename
+		}
+		if f.Alt != "" {
+			input["alt"] = f.Alt
+		}
+		inputs = append(inputs, input)
+	}
+	resp := struct {
+		FileUpdate struct {
+			Files      []fileNode  `json:"files"`
+			UserErrors []UserError `json:"userErrors"`
+		} `json:"fileUpdate"`
+	}{}
+
+	err := s.client.GraphQL.Query(fileUpdateMutation, map[string]interface{}{"files": inputs}, &resp)
+	if err == nil {
+		err = userErrorsToError(resp.FileUpdate.UserErrors)
+	}
+	return fileNodesToFiles(resp.FileUpdate.Files), err
+}
+
+// Delete files by their IDs.
+func (s *FileServiceOp) Delete(ids ...string) error {
+	resp := struct {
+		FileDelete struct {
+			UserErrors []UserError `json:"userErrors"`
+		} `json:"fileDelete"`
+	}{}
+
+	err := s.client.GraphQL.Query(fileDeleteMutation, map[string]interface{}{"fileIds": ids}, &resp)
+	if err != nil {
+		return err
+	}
+	return userErrorsToError(resp.FileDelete.UserErrors)
+}
+
+// Upload the content of r, which is size bytes long, as a new file described
+// by the input, which needs a Filename. The content is streamed to a staged
+// upload first, the mime type is guessed from the extension of the filename.
+// The ContentType defaults to FILE, pass IMAGE or VIDEO to have Shopify
+// process images and videos.
+func (s *FileServiceOp) Upload(ctx context.Context, input FileInput, r io.Reader, size int64) (*File, error) {
+	if input.ContentType == "" {
+		input.ContentType = FileContentTypeFile
+	}
+	mimeType := mime.TypeByExtension(filepath.Ext(input.Filename))
+	if mimeType == "" {
+		mimeType = "application/octet-stream"
+	}
+
+	// videos need the size of the upload upfront
+	targets, err := s.client.StagedUpload.Create(StagedUploadInput{
+		Resource:   input.ContentType,
+		Filename:   input.Filename,
+		MimeType:   mimeType,
+		HTTPMethod: "POST",
+		FileSize:   strconv.FormatInt(size, 10),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.client.StagedUpload.Upload(ctx, targets[0], r); err != nil {
+		return nil, err
+	}
+
+	input.OriginalSource = targets[0].ResourceURL
+	files, err := s.Create(input)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no file was created for %s", input.Filename)
+	}
+	return &files[0], nil
+}
@@ -0,0 +1,161 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestFileCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var request graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+				return nil, err
+			}
+			return httpmock.NewStringResponse(200, `{"data":{"fileCreate":{"files":[{"__typename":"MediaImage","id":"gid://shopify/MediaImage/1","alt":"Logo","fileStatus":"READY","mimeType":"image/png","image":{"url":"https://cdn.shopify.com/logo.png"}},{"__typename":"GenericFile","id":"gid://shopify/GenericFile/2","alt":"","fileStatus":"UPLOADED","mimeType":"application/pdf","url":null}],"userErrors":[]}}}`), nil
+		})
+
+	files, err := client.File.Create(
+		FileInput{OriginalSource: "https://example.com/logo.png", Alt: "Logo", ContentType: FileContentTypeImage},
+		FileInput{OriginalSource: "https://example.com/terms.pdf"},
+	)
+	if err != nil {
+		t.Fatalf("File.Create returned error: %v", err)
+	}
+
+	expectedVars := map[string]interface{}{"files": []interface{}{
+		map[string]interface{}{"originalSource": "https://example.com/logo.png", "alt": "Logo", "contentType": "IMAGE"},
+		map[string]interface{}{"originalSource": "https://example.com/terms.pdf"},
+	}}
+	if !reflect.DeepEqual(request.Variables, expectedVars) {
+		t.Errorf("File.Create sent %+v, expected %+v", request.Variables, expectedVars)
+	}
+
+	expected := []File{
+		{ID: "gid://shopify/MediaImage/1", Alt: "Logo", ContentType: FileContentTypeImage, FileStatus: FileStatusReady, MimeType: "image/png", URL: "https://cdn.shopify.com/logo.png"},
+		{ID: "gid://shopify/GenericFile/2", ContentType: FileContentTypeFile, FileStatus: FileStatusUploaded, MimeType: "application/pdf"},
+	}
+	if !reflect.DeepEqual(files, expected) {
+		t.Errorf("File.Create returned %+v, expected %+v", files, expected)
+	}
+}
+
+func TestFileUpdate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var request graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+				return nil, err
+			}
+			return httpmock.NewStringResponse(200, `{"data":{"fileUpdate":{"files":[{"__typename":"Video","id":"gid://shopify/Video/1","alt":"Intro","fileStatus":"READY","sources":[{"url":"https://cdn.shopify.com/intro.mp4","mimeType":"video/mp4"}]}],"userErrors":[]}}}`), nil
+		})
+
+	files, err := client.File.Update(FileInput{ID: "gid://shopify/Video/1", Alt: "Intro", ContentType: FileContentTypeVideo})
+	if err != nil {
+		t.Fatalf("File.Update returned error: %v", err)
+	}
+
+	expectedVars := map[string]interface{}{"files": []interface{}{
+		map[string]interface{}{"id": "gid://shopify/Video/1", "alt": "Intro"},
+	}}
+	if !reflect.DeepEqual(request.Variables, expectedVars) {
+		t.Errorf("File.Update sent %+v, expected %+v", request.Variables, expectedVars)
+	}
+	if len(files) != 1 || files[0].ContentType != FileContentTypeVideo || files[0].URL != "https://cdn.shopify.com/intro.mp4" {
+		t.Errorf("File.Update returned %+v", files)
+	}
+}
+
+func TestFileDeleteUserErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"data":{"fileDelete":{"deletedFileIds":null,"userErrors":[{"field":["fileIds"],"message":"File does not exist"}]}}}`))
+
+	err := client.File.Delete("gid://shopify/GenericFile/1")
+	if err == nil || err.Error() != "fileIds: File does not exist" {
+		t.Errorf("File.Delete returned error %v, expected the user error", err)
+	}
+}
+
+func TestFileUpload(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			var request graphQLRequest
+			if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+				return nil, err
+			}
+			requests = append(requests, request)
+			if strings.Contains(request.Query, "stagedUploadsCreate") {
+				return httpmock.NewStringResponse(200, `{"data":{"stagedUploadsCreate":{"stagedTargets":[{"url":"https://shopify-staged-uploads.storage.googleapis.com","resourceUrl":"https://shopify-staged-uploads.storage.googleapis.com/tmp/terms.pdf","parameters":[{"name":"key","value":"tmp/terms.pdf"}]}],"userErrors":[]}}}`), nil
+			}
+			return httpmock.NewStringResponse(200, `{"data":{"fileCreate":{"files":[{"__typename":"GenericFile","id":"gid://shopify/GenericFile/1","fileStatus":"UPLOADED"}],"userErrors":[]}}}`), nil
+		})
+
+	var uploaded string
+	httpmock.RegisterResponder("POST", "https://shopify-staged-uploads.storage.googleapis.com",
+		func(req *http.Request) (*http.Response, error) {
+			file, _, err := req.FormFile("file")
+			if err != nil {
+				return nil, err
+			}
+			content, _ := ioutil.ReadAll(file)
+			uploaded = string(content)
+			return httpmock.NewStringResponse(204, ""), nil
+		})
+
+	file, err := client.File.Upload(context.Background(), FileInput{Filename: "terms.pdf"}, strings.NewReader("%PDF"), 4)
+	if err != nil {
+		t.Fatalf("File.Upload returned error: %v", err)
+	}
+
+	if uploaded != "%PDF" {
+		t.Errorf("File.Upload uploaded %q, expected %%PDF", uploaded)
+	}
+	if len(requests) != 2 {
+		t.Fatalf("File.Upload made %d GraphQL requests, expected 2", len(requests))
+	}
+
+	expectedInput := map[string]interface{}{"input": []interface{}{map[string]interface{}{
+		"resource":   "FILE",
+		"filename":   "terms.pdf",
+		"mimeType":   "application/pdf",
+		"httpMethod": "POST",
+		"fileSize":   "4",
+	}}}
+	if !reflect.DeepEqual(requests[0].Variables, expectedInput) {
+		t.Errorf("File.Upload staged %+v, expected %+v", requests[0].Variables, expectedInput)
+	}
+
+	expectedFiles := map[string]interface{}{"files": []interface{}{map[string]interface{}{
+		"originalSource": "https://shopify-staged-uploads.storage.googleapis.com/tmp/terms.pdf",
+		"filename":       "terms.pdf",
+		"contentType":    "FILE",
+	}}}
+	if !reflect.DeepEqual(requests[1].Variables, expectedFiles) {
+		t.Errorf("File.Upload created %+v, expected %+v", requests[1].Variables, expectedFiles)
+	}
+
+	if file.ID != "gid://shopify/GenericFile/1" || file.FileStatus != FileStatusUploaded {
+		t.Errorf("File.Upload returned %+v", file)
+	}
+}
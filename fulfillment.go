@@ -17,6 +17,7 @@ type FulfillmentService interface {
 	Complete(int64) (*Fulfillment, error)
 	Transition(int64) (*Fulfillment, error)
 	Cancel(int64) (*Fulfillment, error)
+	UpdateTracking(int64, FulfillmentTrackingInfo, *bool) (*Fulfillment, error)
 }
 
 // FulfillmentsService is an interface for other Shopify resources
@@ -41,24 +42,53 @@ type FulfillmentServiceOp struct {
 	resourceID int64
 }
 
+// FulfillmentStatus is the status of a fulfillment.
+type FulfillmentStatus string
+
+const (
+	FulfillmentStatusPending   FulfillmentStatus = "pending"
+	FulfillmentStatusOpen      FulfillmentStatus = "open"
+	FulfillmentStatusSuccess   FulfillmentStatus = "success"
+	FulfillmentStatusCancelled FulfillmentStatus = "cancelled"
+	FulfillmentStatusError     FulfillmentStatus = "error"
+	FulfillmentStatusFailure   FulfillmentStatus = "failure"
+)
+
 // Fulfillment represents a Shopify fulfillment.
 type Fulfillment struct {
-	ID              int64      `json:"id,omitempty"`
-	OrderID         int64      `json:"order_id,omitempty"`
-	LocationID      int64      `json:"location_id,omitempty"`
-	Status          string     `json:"status,omitempty"`
-	CreatedAt       *time.Time `json:"created_at,omitempty"`
-	Service         string     `json:"service,omitempty"`
-	UpdatedAt       *time.Time `json:"updated_at,omitempty"`
-	TrackingCompany string     `json:"tracking_company,omitempty"`
-	ShipmentStatus  string     `json:"shipment_status,omitempty"`
-	TrackingNumber  string     `json:"tracking_number,omitempty"`
-	TrackingNumbers []string   `json:"tracking_numbers,omitempty"`
-	TrackingUrl     string     `json:"tracking_url,omitempty"`
-	TrackingUrls    []string   `json:"tracking_urls,omitempty"`
-	Receipt         Receipt    `json:"receipt,omitempty"`
-	LineItems       []LineItem `json:"line_items,omitempty"`
-	NotifyCustomer  bool       `json:"notify_customer"`
+	ID              int64             `json:"id,omitempty"`
+	OrderID         int64             `json:"order_id,omitempty"`
+	LocationID      int64             `json:"location_id,omitempty"`
+	Status          FulfillmentStatus `json:"status,omitempty"`
+	CreatedAt       *time.Time        `json:"created_at,omitempty"`
+	Service         string            `json:"service,omitempty"`
+	UpdatedAt       *time.Time        `json:"updated_at,omitempty"`
+	TrackingCompany string            `json:"tracking_company,omitempty"`
+	ShipmentStatus  string            `json:"shipment_status,omitempty"`
+	TrackingNumber  string            `json:"tracking_number,omitempty"`
+	TrackingNumbers []string          `json:"tracking_numbers,omitempty"`
+	TrackingUrl     string            `json:"tracking_url,omitempty"`
+	TrackingUrls    []string          `json:"tracking_urls,omitempty"`
+	Receipt         Receipt           `json:"receipt,omitempty"`
+	LineItems       []LineItem        `json:"line_items,omitempty"`
+
+	// NotifyCustomer is left out of requests when nil, which makes Shopify
+	// apply its default, set it with Bool(true) or Bool(false) to decide
+	// explicitly whether the customer is notified.
+	NotifyCustomer *bool `json:"notify_customer,omitempty"`
+}
+
+// FulfillmentTrackingInfo is the tracking information of a fulfillment, as
+// sent to the update tracking endpoint.
+type FulfillmentTrackingInfo struct {
+	Number  string `json:"number,omitempty"`
+	URL     string `json:"url,omitempty"`
+	Company string `json:"company,omitempty"`
+}
+
+type fulfillmentTrackingUpdate struct {
+	NotifyCustomer *bool                   `json:"notify_customer,omitempty"`
+	TrackingInfo   FulfillmentTrackingInfo `json:"tracking_info"`
 }
 
 // Receipt represents a Shopify receipt.
@@ -82,9 +112,7 @@ type FulfillmentsResource struct {
 func (s *FulfillmentServiceOp) List(options interface{}) ([]Fulfillment, error) {
 	prefix := FulfillmentPathPrefix(s.resource, s.resourceID)
 	path := fmt.Sprintf("%s.json", prefix)
-	resource := new(FulfillmentsResource)
-	err := s.client.Get(path, resource, options)
-	return resource.Fulfillments, err
+	return listResource[Fulfillment](s.client, path, "fulfillments", options)
 }
 
 // Count fulfillments
@@ -98,54 +126,52 @@ func (s *FulfillmentServiceOp) Count(options interface{}) (int, error) {
 func (s *FulfillmentServiceOp) Get(fulfillmentID int64, options interface{}) (*Fulfillment, error) {
 	prefix := FulfillmentPathPrefix(s.resource, s.resourceID)
 	path := fmt.Sprintf("%s/%d.json", prefix, fulfillmentID)
-	resource := new(FulfillmentResource)
-	err := s.client.Get(path, resource, options)
-	return resource.Fulfillment, err
+	return getResource[Fulfillment](s.client, path, "fulfillment", options)
 }
 
 // Create a new fulfillment
 func (s *FulfillmentServiceOp) Create(fulfillment Fulfillment) (*Fulfillment, error) {
 	prefix := FulfillmentPathPrefix(s.resource, s.resourceID)
 	path := fmt.Sprintf("%s.json", prefix)
-	wrappedData := FulfillmentResource{Fulfillment: &fulfillment}
-	resource := new(FulfillmentResource)
-	err := s.client.Post(path, wrappedData, resource)
-	return resource.Fulfillment, err
+	return createResource(s.client, path, "fulfillment", fulfillment)
 }
 
 // Update an existing fulfillment
 func (s *FulfillmentServiceOp) Update(fulfillment Fulfillment) (*Fulfillment, error) {
 	prefix := FulfillmentPathPrefix(s.resource, s.resourceID)
 	path := fmt.Sprintf("%s/%d.json", prefix, fulfillment.ID)
-	wrappedData := FulfillmentResource{Fulfillment: &fulfillment}
-	resource := new(FulfillmentResource)
-	err := s.client.Put(path, wrappedData, resource)
-	return resource.Fulfillment, err
+	return updateResource(s.client, path, "fulfillment", fulfillment)
 }
 
 // Complete an existing fulfillment
 func (s *FulfillmentServiceOp) Complete(fulfillmentID int64) (*Fulfillment, error) {
 	prefix := FulfillmentPathPrefix(s.resource, s.resourceID)
 	path := fmt.Sprintf("%s/%d/complete.json", prefix, fulfillmentID)
-	resource := new(FulfillmentResource)
-	err := s.client.Post(path, nil, resource)
-	return resource.Fulfillment, err
+	return postResource[Fulfillment](s.client, path, "fulfillment", nil)
 }
 
 // Transition an existing fulfillment
 func (s *FulfillmentServiceOp) Transition(fulfillmentID int64) (*Fulfillment, error) {
 	prefix := FulfillmentPathPrefix(s.resource, s.resourceID)
 	path := fmt.Sprintf("%s/%d/open.json", prefix, fulfillmentID)
-	resource := new(FulfillmentResource)
-	err := s.client.Post(path, nil, resource)
-	return resource.Fulfillment, err
+	return postResource[Fulfillment](s.client, path, "fulfillment", nil)
 }
 
 // Cancel an existing fulfillment
 func (s *FulfillmentServiceOp) Cancel(fulfillmentID int64) (*Fulfillment, error) {
 	prefix := FulfillmentPathPrefix(s.resource, s.resourceID)
 	path := fmt.Sprintf("%s/%d/cancel.json", prefix, fulfillmentID)
-	resource := new(FulfillmentResource)
-	err := s.client.Post(path, nil, resource)
-	return resource.Fulfillment, err
+	return postResource[Fulfillment](s.client, path, "fulfillment", nil)
+}
+
+// UpdateTracking replaces the tracking information of a fulfillment. A nil
+// notifyCustomer leaves notifying the customer to Shopify's default. The
+// endpoint is not nested under the order, so it is the same for every
+// resource the service was created for.
+func (s *FulfillmentServiceOp) UpdateTracking(fulfillmentID int64, tracking FulfillmentTrackingInfo, notifyCustomer *bool) (*Fulfillment, error) {
+	path := fmt.Sprintf("fulfillments/%d/update_tracking.json", fulfillmentID)
+	wrappedData := map[string]interface{}{
+		"fulfillment": fulfillmentTrackingUpdate{NotifyCustomer: notifyCustomer, TrackingInfo: tracking},
+	}
+	return postResource[Fulfillment](s.client, path, "fulfillment", wrappedData)
 }
@@ -0,0 +1,273 @@
+package goshopify
+
+import (
+	"fmt"
+	"time"
+)
+
+const fulfillmentOrdersBasePath = "fulfillment_orders"
+
+// FulfillmentOrderService is an interface for interfacing with the fulfillment
+// order endpoints of the Shopify API.
+// See: https://shopify.dev/docs/api/admin-rest/2023-04/resources/fulfillmentorder
+type FulfillmentOrderService interface {
+	List(int64, interface{}) ([]FulfillmentOrder, error)
+	ListScheduled(int64) ([]FulfillmentOrder, error)
+	Get(int64, interface{}) (*FulfillmentOrder, error)
+	Hold(int64, FulfillmentHold) (*FulfillmentOrder, error)
+	ReleaseHold(int64) (*FulfillmentOrder, error)
+	Open(int64) (*FulfillmentOrder, error)
+	Reschedule(int64, time.Time) (*FulfillmentOrder, error)
+	SetDeadline([]int64, time.Time) error
+	LocationsForMove(int64) ([]FulfillmentOrderLocationForMove, error)
+	Move(int64, int64) (*FulfillmentOrderMove, error)
+}
+
+// FulfillmentOrderServiceOp handles communication with the fulfillment order
+// related methods of the Shopify API.
+type FulfillmentOrderServiceOp struct {
+	client *Client
+}
+
+// FulfillmentOrderStatus is the status of a fulfillment order.
+type FulfillmentOrderStatus string
+
+const (
+	FulfillmentOrderStatusOpen       FulfillmentOrderStatus = "open"
+	FulfillmentOrderStatusInProgress FulfillmentOrderStatus = "in_progress"
+	FulfillmentOrderStatusScheduled  FulfillmentOrderStatus = "scheduled"
+	FulfillmentOrderStatusOnHold     FulfillmentOrderStatus = "on_hold"
+	FulfillmentOrderStatusIncomplete FulfillmentOrderStatus = "incomplete"
+	FulfillmentOrderStatusClosed     FulfillmentOrderStatus = "closed"
+	FulfillmentOrderStatusCancelled  FulfillmentOrderStatus = "cancelled"
+)
+
+// FulfillmentHoldReason is why a fulfillment order is on hold.
+type FulfillmentHoldReason string
+
+const (
+	FulfillmentHoldReasonAwaitingPayment     FulfillmentHoldReason = "awaiting_payment"
+	FulfillmentHoldReasonHighRiskOfFraud     FulfillmentHoldReason = "high_risk_of_fraud"
+	FulfillmentHoldReasonIncorrectAddress    FulfillmentHoldReason = "incorrect_address"
+	FulfillmentHoldReasonInventoryOutOfStock FulfillmentHoldReason = "inventory_out_of_stock"
+	FulfillmentHoldReasonUnknownDeliveryDate FulfillmentHoldReason = "unknown_delivery_date"
+	FulfillmentHoldReasonOther               FulfillmentHoldReason = "other"
+)
+
+// DeliveryMethodType is how the items of a fulfillment order get to the
+// customer.
+type DeliveryMethodType string
+
+const (
+	DeliveryMethodTypeShipping DeliveryMethodType = "shipping"
+	DeliveryMethodTypeLocal    DeliveryMethodType = "local"
+	DeliveryMethodTypePickUp   DeliveryMethodType = "pick_up"
+	DeliveryMethodTypeRetail   DeliveryMethodType = "retail"
+	DeliveryMethodTypeNone     DeliveryMethodType = "none"
+)
+
+// FulfillmentOrder represents a Shopify fulfillment order, the group of line
+// items of an order fulfilled from one location.
+type FulfillmentOrder struct {
+	ID                 int64                      `json:"id,omitempty"`
+	ShopID             int64                      `json:"shop_id,omitempty"`
+	OrderID            int64                      `json:"order_id,omitempty"`
+	AssignedLocationID int64                      `json:"assigned_location_id,omitempty"`
+	AssignedLocation   *FulfillmentOrderLocation  `json:"assigned_location,omitempty"`
+	Destination        *FulfillmentOrderAddress   `json:"destination,omitempty"`
+	DeliveryMethod     *DeliveryMethod            `json:"delivery_method,omitempty"`
+	RequestStatus      string                     `json:"request_status,omitempty"`
+	Status             FulfillmentOrderStatus     `json:"status,omitempty"`
+	SupportedActions   []string                   `json:"supported_actions,omitempty"`
+	LineItems          []FulfillmentOrderLineItem `json:"line_items,omitempty"`
+	FulfillmentHolds   []FulfillmentHold          `json:"fulfillment_holds,omitempty"`
+	FulfillAt          *time.Time                 `json:"fulfill_at,omitempty"`
+	FulfillBy          *time.Time                 `json:"fulfill_by,omitempty"`
+	CreatedAt          *time.Time                 `json:"created_at,omitempty"`
+	UpdatedAt          *time.Time                 `json:"updated_at,omitempty"`
+}
+
+// DeliveryMethod is how the items of a fulfillment order get to the customer
+// and, for local deliveries, the window they are delivered in.
+type DeliveryMethod struct {
+	ID                  int64              `json:"id,omitempty"`
+	MethodType          DeliveryMethodType `json:"method_type,omitempty"`
+	MinDeliveryDateTime *time.Time         `json:"min_delivery_date_time,omitempty"`
+	MaxDeliveryDateTime *time.Time         `json:"max_delivery_date_time,omitempty"`
+}
+
+// FulfillmentOrderLocation is the location a fulfillment order is assigned
+// to, which is where the customer picks the items up for pick up orders.
+type FulfillmentOrderLocation struct {
+	LocationID  int64  `json:"location_id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Address1    string `json:"address1,omitempty"`
+	Address2    string `json:"address2,omitempty"`
+	City        string `json:"city,omitempty"`
+	Province    string `json:"province,omitempty"`
+	Zip         string `json:"zip,omitempty"`
+	CountryCode string `json:"country_code,omitempty"`
+	Phone       string `json:"phone,omitempty"`
+}
+
+// FulfillmentOrderAddress is where the items of a fulfillment order are
+// shipped or delivered to.
+type FulfillmentOrderAddress struct {
+	ID        int64  `json:"id,omitempty"`
+	FirstName string `json:"first_name,omitempty"`
+	LastName  string `json:"last_name,omitempty"`
+	Company   string `json:"company,omitempty"`
+	Address1  string `json:"address1,omitempty"`
+	Address2  string `json:"address2,omitempty"`
+	City      string `json:"city,omitempty"`
+	Province  string `json:"province,omitempty"`
+	Zip       string `json:"zip,omitempty"`
+	Country   string `json:"country,omitempty"`
+	Email     string `json:"email,omitempty"`
+	Phone     string `json:"phone,omitempty"`
+}
+
+// FulfillmentOrderLocationForMove is a location a fulfillment order may be
+// moved to, Message tells why when it isn't Movable.
+type FulfillmentOrderLocationForMove struct {
+	Location Location `json:"location"`
+	Message  string   `json:"message,omitempty"`
+	Movable  bool     `json:"movable"`
+}
+
+// FulfillmentOrderMove is the result of moving a fulfillment order: the
+// original one, the one moved to the new location and the one holding the
+// items that couldn't be moved, if any.
+type FulfillmentOrderMove struct {
+	OriginalFulfillmentOrder  *FulfillmentOrder `json:"original_fulfillment_order"`
+	MovedFulfillmentOrder     *FulfillmentOrder `json:"moved_fulfillment_order"`
+	RemainingFulfillmentOrder *FulfillmentOrder `json:"remaining_fulfillment_order"`
+}
+
+// FulfillmentOrderLineItem is a line item of a fulfillment order.
+type FulfillmentOrderLineItem struct {
+	ID                  int64 `json:"id,omitempty"`
+	ShopID              int64 `json:"shop_id,omitempty"`
+	FulfillmentOrderID  int64 `json:"fulfillment_order_id,omitempty"`
+	LineItemID          int64 `json:"line_item_id,omitempty"`
+	InventoryItemID     int64 `json:"inventory_item_id,omitempty"`
+	VariantID           int64 `json:"variant_id,omitempty"`
+	Quantity            int   `json:"quantity,omitempty"`
+	FulfillableQuantity int   `json:"fulfillable_quantity,omitempty"`
+}
+
+// FulfillmentHold is the hold of a fulfillment order, as sent to the hold
+// endpoint. NotifyMerchant is left out of requests when nil, which makes
+// Shopify apply its default.
+type FulfillmentHold struct {
+	Reason         FulfillmentHoldReason `json:"reason,omitempty"`
+	ReasonNotes    string                `json:"reason_notes,omitempty"`
+	NotifyMerchant *bool                 `json:"notify_merchant,omitempty"`
+}
+
+// FulfillmentOrderResource represents the result from the
+// fulfillment_orders/X.json endpoint
+type FulfillmentOrderResource struct {
+	FulfillmentOrder *FulfillmentOrder `json:"fulfillment_order"`
+}
+
+// FulfillmentOrdersResource represents the result from the
+// orders/X/fulfillment_orders.json endpoint
+type FulfillmentOrdersResource struct {
+	FulfillmentOrders []FulfillmentOrder `json:"fulfillment_orders"`
+}
+
+// List the fulfillment orders of an order
+func (s *FulfillmentOrderServiceOp) List(orderID int64, options interface{}) ([]FulfillmentOrder, error) {
+	path := fmt.Sprintf("%s/%d/%s.json", ordersBasePath, orderID, fulfillmentOrdersBasePath)
+	resource := new(FulfillmentOrdersResource)
+	err := s.client.Get(path, resource, options)
+	return resource.FulfillmentOrders, err
+}
+
+// ListScheduled lists the fulfillment orders of an order that are scheduled
+// to be fulfilled later, at their FulfillAt, e.g. for pre-orders and
+// subscriptions.
+func (s *FulfillmentOrderServiceOp) ListScheduled(orderID int64) ([]FulfillmentOrder, error) {
+	fulfillmentOrders, err := s.List(orderID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduled := []FulfillmentOrder{}
+	for _, fulfillmentOrder := range fulfillmentOrders {
+		if fulfillmentOrder.Status == FulfillmentOrderStatusScheduled {
+			scheduled = append(scheduled, fulfillmentOrder)
+		}
+	}
+	return scheduled, nil
+}
+
+// Get individual fulfillment order
+func (s *FulfillmentOrderServiceOp) Get(fulfillmentOrderID int64, options interface{}) (*FulfillmentOrder, error) {
+	path := fmt.Sprintf("%s/%d.json", fulfillmentOrdersBasePath, fulfillmentOrderID)
+	return getResource[FulfillmentOrder](s.client, path, "fulfillment_order", options)
+}
+
+// Hold halts all fulfillment work on a fulfillment order until ReleaseHold
+// is called.
+func (s *FulfillmentOrderServiceOp) Hold(fulfillmentOrderID int64, hold FulfillmentHold) (*FulfillmentOrder, error) {
+	path := fmt.Sprintf("%s/%d/hold.json", fulfillmentOrdersBasePath, fulfillmentOrderID)
+	wrappedData := map[string]interface{}{"fulfillment_hold": hold}
+	return postResource[FulfillmentOrder](s.client, path, "fulfillment_order", wrappedData)
+}
+
+// ReleaseHold releases the hold on a fulfillment order
+func (s *FulfillmentOrderServiceOp) ReleaseHold(fulfillmentOrderID int64) (*FulfillmentOrder, error) {
+	path := fmt.Sprintf("%s/%d/release_hold.json", fulfillmentOrdersBasePath, fulfillmentOrderID)
+	return postResource[FulfillmentOrder](s.client, path, "fulfillment_order", nil)
+}
+
+// Open marks a scheduled fulfillment order as ready for fulfillment before
+// its FulfillAt
+func (s *FulfillmentOrderServiceOp) Open(fulfillmentOrderID int64) (*FulfillmentOrder, error) {
+	path := fmt.Sprintf("%s/%d/open.json", fulfillmentOrdersBasePath, fulfillmentOrderID)
+	return postResource[FulfillmentOrder](s.client, path, "fulfillment_order", nil)
+}
+
+// Reschedule moves the FulfillAt of a scheduled fulfillment order
+func (s *FulfillmentOrderServiceOp) Reschedule(fulfillmentOrderID int64, fulfillAt time.Time) (*FulfillmentOrder, error) {
+	path := fmt.Sprintf("%s/%d/reschedule.json", fulfillmentOrdersBasePath, fulfillmentOrderID)
+	wrappedData := map[string]interface{}{
+		"fulfillment_order": map[string]time.Time{"new_fulfill_at": fulfillAt},
+	}
+	return postResource[FulfillmentOrder](s.client, path, "fulfillment_order", wrappedData)
+}
+
+// SetDeadline sets the deadline by which the fulfillment orders must be
+// fulfilled
+func (s *FulfillmentOrderServiceOp) SetDeadline(fulfillmentOrderIDs []int64, deadline time.Time) error {
+	path := fmt.Sprintf("%s/set_fulfillment_orders_deadline.json", fulfillmentOrdersBasePath)
+	data := map[string]interface{}{
+		"fulfillment_order_ids": fulfillmentOrderIDs,
+		"fulfillment_deadline":  deadline,
+	}
+	return s.client.Post(path, data, nil)
+}
+
+// LocationsForMove lists the locations a fulfillment order may be moved to,
+// e.g. to route a local delivery to the closest store.
+func (s *FulfillmentOrderServiceOp) LocationsForMove(fulfillmentOrderID int64) ([]FulfillmentOrderLocationForMove, error) {
+	path := fmt.Sprintf("%s/%d/locations_for_move.json", fulfillmentOrdersBasePath, fulfillmentOrderID)
+	resource := struct {
+		LocationsForMove []FulfillmentOrderLocationForMove `json:"locations_for_move"`
+	}{}
+	err := s.client.Get(path, &resource, nil)
+	return resource.LocationsForMove, err
+}
+
+// Move assigns a fulfillment order to another location, see LocationsForMove
+func (s *FulfillmentOrderServiceOp) Move(fulfillmentOrderID, locationID int64) (*FulfillmentOrderMove, error) {
+	path := fmt.Sprintf("%s/%d/move.json", fulfillmentOrdersBasePath, fulfillmentOrderID)
+	wrappedData := map[string]interface{}{
+		"fulfillment_order": map[string]int64{"new_location_id": locationID},
+	}
+	resource := new(FulfillmentOrderMove)
+	err := s.client.Post(path, wrappedData, resource)
+	return resource, err
+}
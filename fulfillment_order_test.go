@@ -0,0 +1,234 @@
+package goshopify
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+// registerFulfillmentOrderPost registers a POST to path answering with the
+// fulfillment order 1 and stores the request body in body.
+func registerFulfillmentOrderPost(path string, body *string) {
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/%s", client.pathPrefix, path),
+		func(req *http.Request) (*http.Response, error) {
+			data, _ := ioutil.ReadAll(req.Body)
+			*body = string(data)
+			return httpmock.NewStringResponse(200, `{"fulfillment_order": {"id": 1, "status": "on_hold"}}`), nil
+		})
+}
+
+func TestFulfillmentOrderList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/450789469/fulfillment_orders.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"fulfillment_orders": [
+			{"id": 1, "status": "open"},
+			{"id": 2, "status": "scheduled", "fulfill_at": "2023-06-01T00:00:00-04:00"}
+		]}`))
+
+	fulfillmentOrders, err := client.FulfillmentOrder.List(450789469, nil)
+	if err != nil {
+		t.Fatalf("FulfillmentOrder.List returned error: %v", err)
+	}
+	if len(fulfillmentOrders) != 2 || fulfillmentOrders[0].Status != FulfillmentOrderStatusOpen {
+		t.Errorf("FulfillmentOrder.List returned %+v", fulfillmentOrders)
+	}
+
+	scheduled, err := client.FulfillmentOrder.ListScheduled(450789469)
+	if err != nil {
+		t.Fatalf("FulfillmentOrder.ListScheduled returned error: %v", err)
+	}
+	expectedFulfillAt := time.Date(2023, time.June, 1, 4, 0, 0, 0, time.UTC)
+	if len(scheduled) != 1 || scheduled[0].ID != 2 || !scheduled[0].FulfillAt.Equal(expectedFulfillAt) {
+		t.Errorf("FulfillmentOrder.ListScheduled returned %+v", scheduled)
+	}
+}
+
+func TestFulfillmentOrderGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/fulfillment_orders/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"fulfillment_order": {"id": 1, "fulfillment_holds": [{"reason": "awaiting_payment", "reason_notes": "wire"}]}}`))
+
+	fulfillmentOrder, err := client.FulfillmentOrder.Get(1, nil)
+	if err != nil {
+		t.Fatalf("FulfillmentOrder.Get returned error: %v", err)
+	}
+	if len(fulfillmentOrder.FulfillmentHolds) != 1 || fulfillmentOrder.FulfillmentHolds[0].Reason != FulfillmentHoldReasonAwaitingPayment {
+		t.Errorf("FulfillmentOrder.Get returned %+v", fulfillmentOrder)
+	}
+}
+
+func TestFulfillmentOrderHold(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var body string
+	registerFulfillmentOrderPost("fulfillment_orders/1/hold.json", &body)
+
+	fulfillmentOrder, err := client.FulfillmentOrder.Hold(1, FulfillmentHold{
+		Reason:         FulfillmentHoldReasonInventoryOutOfStock,
+		ReasonNotes:    "restock next week",
+		NotifyMerchant: Bool(true),
+	})
+	if err != nil {
+		t.Fatalf("FulfillmentOrder.Hold returned error: %v", err)
+	}
+	if fulfillmentOrder.Status != FulfillmentOrderStatusOnHold {
+		t.Errorf("FulfillmentOrder.Hold returned %+v", fulfillmentOrder)
+	}
+
+	expected := `{"fulfillment_hold":{"reason":"inventory_out_of_stock","reason_notes":"restock next week","notify_merchant":true}}`
+	if body != expected {
+		t.Errorf("FulfillmentOrder.Hold sent %s, expected %s", body, expected)
+	}
+}
+
+func TestFulfillmentOrderReleaseHoldAndOpen(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var body string
+	registerFulfillmentOrderPost("fulfillment_orders/1/release_hold.json", &body)
+	registerFulfillmentOrderPost("fulfillment_orders/1/open.json", &body)
+
+	if _, err := client.FulfillmentOrder.ReleaseHold(1); err != nil {
+		t.Errorf("FulfillmentOrder.ReleaseHold returned error: %v", err)
+	}
+	if _, err := client.FulfillmentOrder.Open(1); err != nil {
+		t.Errorf("FulfillmentOrder.Open returned error: %v", err)
+	}
+}
+
+func TestFulfillmentOrderReschedule(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var body string
+	registerFulfillmentOrderPost("fulfillment_orders/1/reschedule.json", &body)
+
+	fulfillAt := time.Date(2023, time.July, 1, 0, 0, 0, 0, time.UTC)
+	if _, err := client.FulfillmentOrder.Reschedule(1, fulfillAt); err != nil {
+		t.Fatalf("FulfillmentOrder.Reschedule returned error: %v", err)
+	}
+
+	expected := `{"fulfillment_order":{"new_fulfill_at":"2023-07-01T00:00:00Z"}}`
+	if body != expected {
+		t.Errorf("FulfillmentOrder.Reschedule sent %s, expected %s", body, expected)
+	}
+}
+
+func TestFulfillmentOrderSetDeadline(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var body string
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/fulfillment_orders/set_fulfillment_orders_deadline.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			data, _ := ioutil.ReadAll(req.Body)
+			body = string(data)
+			return httpmock.NewStringResponse(200, `{}`), nil
+		})
+
+	deadline := time.Date(2023, time.July, 1, 0, 0, 0, 0, time.UTC)
+	if err := client.FulfillmentOrder.SetDeadline([]int64{1, 2}, deadline); err != nil {
+		t.Fatalf("FulfillmentOrder.SetDeadline returned error: %v", err)
+	}
+
+	expected := `{"fulfillment_deadline":"2023-07-01T00:00:00Z","fulfillment_order_ids":[1,2]}`
+	if body != expected {
+		t.Errorf("FulfillmentOrder.SetDeadline sent %s, expected %s", body, expected)
+	}
+}
+
+func TestFulfillmentOrderDeliveryMethod(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/fulfillment_orders/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"fulfillment_order": {
+			"id": 1,
+			"assigned_location": {"location_id": 24826418, "name": "Apple Api Shipwire", "city": "Ottawa", "country_code": "CA"},
+			"destination": {"id": 2, "first_name": "Bob", "address1": "Chestnut Street 92", "city": "Louisville"},
+			"delivery_method": {
+				"id": 3,
+				"method_type": "local",
+				"min_delivery_date_time": "2023-06-01T14:00:00Z",
+				"max_delivery_date_time": "2023-06-01T16:00:00Z"
+			}
+		}}`))
+
+	fulfillmentOrder, err := client.FulfillmentOrder.Get(1, nil)
+	if err != nil {
+		t.Fatalf("FulfillmentOrder.Get returned error: %v", err)
+	}
+
+	method := fulfillmentOrder.DeliveryMethod
+	if method == nil || method.MethodType != DeliveryMethodTypeLocal ||
+		!method.MinDeliveryDateTime.Equal(time.Date(2023, time.June, 1, 14, 0, 0, 0, time.UTC)) ||
+		!method.MaxDeliveryDateTime.Equal(time.Date(2023, time.June, 1, 16, 0, 0, 0, time.UTC)) {
+		t.Errorf("FulfillmentOrder.Get returned delivery method %+v", method)
+	}
+	if location := fulfillmentOrder.AssignedLocation; location == nil || location.LocationID != 24826418 || location.City != "Ottawa" {
+		t.Errorf("FulfillmentOrder.Get returned assigned location %+v", location)
+	}
+	if destination := fulfillmentOrder.Destination; destination == nil || destination.City != "Louisville" {
+		t.Errorf("FulfillmentOrder.Get returned destination %+v", destination)
+	}
+}
+
+func TestFulfillmentOrderLocationsForMove(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/fulfillment_orders/1/locations_for_move.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"locations_for_move": [
+			{"location": {"id": 1, "name": "Downtown"}, "message": "", "movable": true},
+			{"location": {"id": 2, "name": "Uptown"}, "message": "No items are stocked at this location.", "movable": false}
+		]}`))
+
+	locations, err := client.FulfillmentOrder.LocationsForMove(1)
+	if err != nil {
+		t.Fatalf("FulfillmentOrder.LocationsForMove returned error: %v", err)
+	}
+	if len(locations) != 2 || !locations[0].Movable || locations[0].Location.Name != "Downtown" ||
+		locations[1].Movable || locations[1].Message == "" {
+		t.Errorf("FulfillmentOrder.LocationsForMove returned %+v", locations)
+	}
+}
+
+func TestFulfillmentOrderMove(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var body string
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/fulfillment_orders/1/move.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			data, _ := ioutil.ReadAll(req.Body)
+			body = string(data)
+			return httpmock.NewStringResponse(200, `{
+				"original_fulfillment_order": {"id": 1, "status": "closed"},
+				"moved_fulfillment_order": {"id": 2, "assigned_location_id": 5, "status": "open"},
+				"remaining_fulfillment_order": null
+			}`), nil
+		})
+
+	move, err := client.FulfillmentOrder.Move(1, 5)
+	if err != nil {
+		t.Fatalf("FulfillmentOrder.Move returned error: %v", err)
+	}
+	if move.MovedFulfillmentOrder == nil || move.MovedFulfillmentOrder.AssignedLocationID != 5 || move.RemainingFulfillmentOrder != nil {
+		t.Errorf("FulfillmentOrder.Move returned %+v", move)
+	}
+
+	expected := `{"fulfillment_order":{"new_location_id":5}}`
+	if body != expected {
+		t.Errorf("FulfillmentOrder.Move sent %s, expected %s", body, expected)
+	}
+}
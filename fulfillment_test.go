@@ -1,7 +1,9 @@
 package goshopify
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"reflect"
 	"testing"
 	"time"
@@ -95,6 +97,27 @@ func TestFulfillmentGet(t *testing.T) {
 	}
 }
 
+func TestFulfillmentStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/123/fulfillments/1.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("fulfillment.json")))
+
+	fulfillmentService := &FulfillmentServiceOp{client: client, resource: ordersResourceName, resourceID: 123}
+
+	fulfillment, err := fulfillmentService.Get(1, nil)
+	if err != nil {
+		t.Fatalf("Fulfillment.Get returned error: %v", err)
+	}
+	if fulfillment.Status != FulfillmentStatusSuccess {
+		t.Errorf("Fulfillment.Status returned %q, expected %q", fulfillment.Status, FulfillmentStatusSuccess)
+	}
+	if fulfillment.LineItems[0].FulfillmentStatus != OrderFulfillmentStatusFulfilled {
+		t.Errorf("Fulfillment.LineItems[0].FulfillmentStatus returned %q, expected %q", fulfillment.LineItems[0].FulfillmentStatus, OrderFulfillmentStatusFulfilled)
+	}
+}
+
 func TestFulfillmentCreate(t *testing.T) {
 	setup()
 	defer teardown()
@@ -111,7 +134,7 @@ func TestFulfillmentCreate(t *testing.T) {
 			"https://shipping.xyz/track.php?num=123456789",
 			"https://anothershipper.corp/track.php?code=abc",
 		},
-		NotifyCustomer: true,
+		NotifyCustomer: Bool(true),
 	}
 
 	returnedFulfillment, err := fulfillmentService.Create(fulfillment)
@@ -144,6 +167,81 @@ func TestFulfillmentUpdate(t *testing.T) {
 	FulfillmentTests(t, *returnedFulfillment)
 }
 
+func TestFulfillmentNotifyCustomer(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []map[string]interface{}
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/123/fulfillments.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			body := map[string]map[string]interface{}{}
+			_ = json.NewDecoder(req.Body).Decode(&body)
+			sent = append(sent, body["fulfillment"])
+			return httpmock.NewBytesResponse(200, loadFixture("fulfillment.json")), nil
+		})
+
+	fulfillmentService := &FulfillmentServiceOp{client: client, resource: ordersResourceName, resourceID: 123}
+
+	cases := []struct {
+		notifyCustomer *bool
+		expected       interface{}
+		present        bool
+	}{
+		{nil, nil, false},
+		{Bool(true), true, true},
+		{Bool(false), false, true},
+	}
+
+	for i, c := range cases {
+		_, err := fulfillmentService.Create(Fulfillment{LocationID: 905684977, NotifyCustomer: c.notifyCustomer})
+		if err != nil {
+			t.Fatalf("Fulfillment.Create returned error: %v", err)
+		}
+
+		actual, present := sent[i]["notify_customer"]
+		if present != c.present || actual != c.expected {
+			t.Errorf("Fulfillment.Create with NotifyCustomer %v sent notify_customer %v (present %v), expected %v (present %v)",
+				c.notifyCustomer, actual, present, c.expected, c.present)
+		}
+	}
+}
+
+func TestFulfillmentUpdateTracking(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent map[string]interface{}
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/fulfillments/1022782888/update_tracking.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			_ = json.NewDecoder(req.Body).Decode(&sent)
+			return httpmock.NewBytesResponse(200, loadFixture("fulfillment.json")), nil
+		})
+
+	fulfillmentService := &FulfillmentServiceOp{client: client, resource: ordersResourceName, resourceID: 123}
+
+	tracking := FulfillmentTrackingInfo{Number: "1111", URL: "http://www.my-url.com", Company: "my-company"}
+	returnedFulfillment, err := fulfillmentService.UpdateTracking(1022782888, tracking, Bool(false))
+	if err != nil {
+		t.Errorf("Fulfillment.UpdateTracking returned error: %v", err)
+	}
+
+	FulfillmentTests(t, *returnedFulfillment)
+
+	expected := map[string]interface{}{
+		"fulfillment": map[string]interface{}{
+			"notify_customer": false,
+			"tracking_info": map[string]interface{}{
+				"number":  "1111",
+				"url":     "http://www.my-url.com",
+				"company": "my-company",
+			},
+		},
+	}
+	if !reflect.DeepEqual(sent, expected) {
+		t.Errorf("Fulfillment.UpdateTracking sent %+v, expected %+v", sent, expected)
+	}
+}
+
 func TestFulfillmentComplete(t *testing.T) {
 	setup()
 	defer teardown()
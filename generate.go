@@ -0,0 +1,5 @@
+package goshopify
+
+// Services are scaffolded from the specs in internal/genservice/specs, see
+// internal/genservice.
+//go:generate go run ./internal/genservice -out . ./internal/genservice/specs
@@ -2,6 +2,7 @@ package goshopify
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/shopspring/decimal"
@@ -9,14 +10,22 @@ import (
 
 const giftCardsBasePath = "gift_cards"
 
+// Statuses of gift cards to search by, see GiftCardQuery.Status.
+const (
+	GiftCardStatusEnabled  = "enabled"
+	GiftCardStatusDisabled = "disabled"
+)
+
 // GiftCardService is an interface for interfacing with the gift card endpoints
 // of the Shopify API.
 // https://help.shopify.com/en/api/reference/plus/giftcard
 type GiftCardService interface {
 	List(interface{}) ([]GiftCard, error)
+	ListWithPagination(interface{}) ([]GiftCard, *Pagination, error)
 	Count(interface{}) (int, error)
 	Get(int64, interface{}) (*GiftCard, error)
 	Search(interface{}) ([]GiftCard, error)
+	SearchWithPagination(interface{}) ([]GiftCard, *Pagination, error)
 	Create(GiftCard) (*GiftCard, error)
 	Update(GiftCard) (*GiftCard, error)
 	Disable(int64) (*GiftCard, error)
@@ -60,21 +69,66 @@ type GiftCardsResource struct {
 	GiftCards []GiftCard `json:"gift_cards"`
 }
 
-// Represents the options available when searching for a gift card
+// Represents the options available when searching for a gift card. Page is
+// the legacy page based pagination, SearchWithPagination returns the
+// PageInfo of the following pages instead, pass the NextPageOptions of the
+// pagination as options to get them. Query can be built with GiftCardQuery.
 type GiftCardSearchOptions struct {
-	Page   int    `url:"page,omitempty"`
-	Limit  int    `url:"limit,omitempty"`
-	Fields string `url:"fields,omitempty"`
-	Order  string `url:"order,omitempty"`
-	Query  string `url:"query,omitempty"`
+	Page     int    `url:"page,omitempty"`
+	PageInfo string `url:"page_info,omitempty"`
+	Limit    int    `url:"limit,omitempty"`
+	Fields   string `url:"fields,omitempty"`
+	Order    string `url:"order,omitempty"`
+	Query    string `url:"query,omitempty"`
+}
+
+// GiftCardQuery builds the query of a gift card search from field:value
+// terms, which must all match:
+//
+//	query := goshopify.GiftCardQuery{}.LastCharacters("mnop").Status(goshopify.GiftCardStatusEnabled)
+//	options := goshopify.GiftCardSearchOptions{Query: query.String()}
+type GiftCardQuery []string
+
+// Field adds a term matching the value of a field, e.g. balance or email.
+// Values with spaces are quoted.
+func (q GiftCardQuery) Field(name, value string) GiftCardQuery {
+	if strings.ContainsAny(value, " \t") {
+		value = fmt.Sprintf("%q", value)
+	}
+	return append(q[:len(q):len(q)], name+":"+value)
+}
+
+// LastCharacters matches gift cards whose code ends with the characters.
+func (q GiftCardQuery) LastCharacters(characters string) GiftCardQuery {
+	return q.Field("last_characters", characters)
+}
+
+// Status matches enabled or disabled gift cards, see GiftCardStatusEnabled.
+func (q GiftCardQuery) Status(status string) GiftCardQuery {
+	return q.Field("status", status)
+}
+
+// Email matches gift cards of the customer with the email.
+func (q GiftCardQuery) Email(email string) GiftCardQuery {
+	return q.Field("email", email)
+}
+
+// String returns the query to use as GiftCardSearchOptions.Query.
+func (q GiftCardQuery) String() string {
+	return strings.Join(q, " ")
 }
 
 // List gift cards
 func (s *GiftCardServiceOp) List(options interface{}) ([]GiftCard, error) {
 	path := fmt.Sprintf("%s.json", giftCardsBasePath)
-	resource := new(GiftCardsResource)
-	err := s.client.Get(path, resource, options)
-	return resource.GiftCards, err
+	return listResource[GiftCard](s.client, path, "gift_cards", options)
+}
+
+// ListWithPagination lists gift cards and returns pagination to retrieve the
+// next/previous page.
+func (s *GiftCardServiceOp) ListWithPagination(options interface{}) ([]GiftCard, *Pagination, error) {
+	path := fmt.Sprintf("%s.json", giftCardsBasePath)
+	return listResourceWithPagination[GiftCard](s.client, path, "gift_cards", options)
 }
 
 // Count gift cards
@@ -86,41 +140,44 @@ func (s *GiftCardServiceOp) Count(options interface{}) (int, error) {
 // Get gift card
 func (s *GiftCardServiceOp) Get(giftCardID int64, options interface{}) (*GiftCard, error) {
 	path := fmt.Sprintf("%s/%v.json", giftCardsBasePath, giftCardID)
-	resource := new(GiftCardResource)
-	err := s.client.Get(path, resource, options)
-	return resource.GiftCard, err
+	return getResource[GiftCard](s.client, path, "gift_card", options)
 }
 
 // Search gift cards
 func (s *GiftCardServiceOp) Search(options interface{}) ([]GiftCard, error) {
 	path := fmt.Sprintf("%s/search.json", giftCardsBasePath)
-	resource := new(GiftCardsResource)
-	err := s.client.Get(path, resource, options)
-	return resource.GiftCards, err
+	return listResource[GiftCard](s.client, path, "gift_cards", options)
+}
+
+// SearchWithPagination searches gift cards and returns pagination to
+// retrieve the next/previous page of the search.
+func (s *GiftCardServiceOp) SearchWithPagination(options interface{}) ([]GiftCard, *Pagination, error) {
+	path := fmt.Sprintf("%s/search.json", giftCardsBasePath)
+	return listResourceWithPagination[GiftCard](s.client, path, "gift_cards", options)
 }
 
-// Create gift card
+// Create gift card, a code that is set is validated before it is sent, see
+// ValidateGiftCardCode. Shopify generates a code when it is left empty.
 func (s *GiftCardServiceOp) Create(giftCard GiftCard) (*GiftCard, error) {
+	if giftCard.Code != "" {
+		if err := ValidateGiftCardCode(giftCard.Code); err != nil {
+			return nil, err
+		}
+	}
 	path := fmt.Sprintf("%s.json", giftCardsBasePath)
-	wrappedData := GiftCardResource{GiftCard: &giftCard}
-	resource := new(GiftCardResource)
-	err := s.client.Post(path, wrappedData, resource)
-	return resource.GiftCard, err
+	return createResource(s.client, path, "gift_card", giftCard)
 }
 
-// Update gift card
+// Update gift card. The code can only be set on create, so Code is left out
+// of the update.
 func (s *GiftCardServiceOp) Update(giftCard GiftCard) (*GiftCard, error) {
+	giftCard.Code = ""
 	path := fmt.Sprintf("%s/%d.json", giftCardsBasePath, giftCard.ID)
-	wrappedData := GiftCardResource{GiftCard: &giftCard}
-	resource := new(GiftCardResource)
-	err := s.client.Put(path, wrappedData, resource)
-	return resource.GiftCard, err
+	return updateResource(s.client, path, "gift_card", giftCard)
 }
 
 // Disable gift card
 func (s *GiftCardServiceOp) Disable(giftCardID int64) (*GiftCard, error) {
 	path := fmt.Sprintf("%s/%d/disable.json", giftCardsBasePath, giftCardID)
-	resource := new(GiftCardResource)
-	err := s.client.Post(path, nil, resource)
-	return resource.GiftCard, err
+	return postResource[GiftCard](s.client, path, "gift_card", nil)
 }
@@ -0,0 +1,118 @@
+package goshopify
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	giftCardCodeMinLength = 8
+	giftCardCodeMaxLength = 20
+
+	// giftCardCodeVisible is the number of trailing characters left readable
+	// by MaskGiftCardCode, matching Shopify's masked_code.
+	giftCardCodeVisible = 4
+	giftCardCodeMask    = "•"
+
+	// giftCardCodeAlphabet is what GenerateGiftCardCode draws from: letters
+	// and digits without 0, 1, i, l and o, which are easily mistaken for each
+	// other when a code is typed in.
+	giftCardCodeAlphabet = "23456789abcdefghjkmnpqrstuvwxyz"
+)
+
+// ErrInvalidGiftCardCode is returned, wrapped with the reason, for gift card
+// codes Shopify would reject.
+var ErrInvalidGiftCardCode = errors.New("invalid gift card code")
+
+// NormalizeGiftCardCode strips the spaces and dashes codes are often written
+// with and lower cases it, which is how Shopify compares codes.
+func NormalizeGiftCardCode(code string) string {
+	return strings.ToLower(strings.NewReplacer(" ", "", "-", "").Replace(code))
+}
+
+// ValidateGiftCardCode checks that a code is made of 8 to 20 letters and
+// digits, ignoring spaces and dashes.
+func ValidateGiftCardCode(code string) error {
+	code = NormalizeGiftCardCode(code)
+	if n := utf8.RuneCountInString(code); n < giftCardCodeMinLength || n > giftCardCodeMaxLength {
+		return fmt.Errorf("%w: must be between %d and %d characters, got %d",
+			ErrInvalidGiftCardCode, giftCardCodeMinLength, giftCardCodeMaxLength, n)
+	}
+	for _, r := range code {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') {
+			return fmt.Errorf("%w: must only contain letters and digits", ErrInvalidGiftCardCode)
+		}
+	}
+	return nil
+}
+
+// GenerateGiftCardCode returns a random code of length characters, which must
+// be between 8 and 20, drawn from a crypto/rand source. Codes are made of
+// lower case letters and digits without the ambiguous 0, 1, i, l and o.
+func GenerateGiftCardCode(length int) (string, error) {
+	if length < giftCardCodeMinLength || length > giftCardCodeMaxLength {
+		return "", fmt.Errorf("%w: must be between %d and %d characters, got %d",
+			ErrInvalidGiftCardCode, giftCardCodeMinLength, giftCardCodeMaxLength, length)
+	}
+
+	max := big.NewInt(int64(len(giftCardCodeAlphabet)))
+	code := make([]byte, length)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", fmt.Errorf("generating gift card code: %w", err)
+		}
+		code[i] = giftCardCodeAlphabet[n.Int64()]
+	}
+	return string(code), nil
+}
+
+// GiftCardLastCharacters returns the last four characters of a normalized
+// code, as Shopify reports them in GiftCard.LastCharacters and searches them
+// with GiftCardQuery.LastCharacters.
+func GiftCardLastCharacters(code string) string {
+	runes := []rune(NormalizeGiftCardCode(code))
+	if len(runes) <= giftCardCodeVisible {
+		return string(runes)
+	}
+	return string(runes[len(runes)-giftCardCodeVisible:])
+}
+
+// FormatGiftCardCode normalizes a code and splits it into groups of four
+// characters for display, e.g. "f59e f7ef acb3 c2g8".
+func FormatGiftCardCode(code string) string {
+	return groupGiftCardCode(NormalizeGiftCardCode(code))
+}
+
+// MaskGiftCardCode formats a code with everything but its last four
+// characters masked, e.g. "•••• •••• •••• c2g8", so it can be logged or shown
+// without exposing the code.
+func MaskGiftCardCode(code string) string {
+	runes := []rune(NormalizeGiftCardCode(code))
+	visible := giftCardCodeVisible
+	if len(runes) <= visible {
+		// too short to leave anything readable
+		visible = 0
+	}
+
+	masked := strings.Repeat(giftCardCodeMask, len(runes)-visible) + string(runes[len(runes)-visible:])
+	return groupGiftCardCode(masked)
+}
+
+// groupGiftCardCode separates groups of four characters with spaces.
+func groupGiftCardCode(code string) string {
+	runes := []rune(code)
+	groups := make([]string, 0, len(runes)/4+1)
+	for len(runes) > 4 {
+		groups = append(groups, string(runes[:4]))
+		runes = runes[4:]
+	}
+	if len(runes) > 0 {
+		groups = append(groups, string(runes))
+	}
+	return strings.Join(groups, " ")
+}
@@ -0,0 +1,124 @@
+package goshopify
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeGiftCardCode(t *testing.T) {
+	cases := map[string]string{
+		"f59ef7efacb3c2g8":    "f59ef7efacb3c2g8",
+		"F59E F7EF ACB3 C2G8": "f59ef7efacb3c2g8",
+		"f59e-f7ef-acb3-c2g8": "f59ef7efacb3c2g8",
+	}
+	for in, expected := range cases {
+		if actual := NormalizeGiftCardCode(in); actual != expected {
+			t.Errorf("NormalizeGiftCardCode(%q) = %q, expected %q", in, actual, expected)
+		}
+	}
+}
+
+func TestValidateGiftCardCode(t *testing.T) {
+	cases := []struct {
+		code  string
+		valid bool
+	}{
+		{"f59ef7efacb3c2g8", true},
+		{"F59E-F7EF-ACB3-C2G8", true},
+		{"abcd1234", true},
+		{"abcd123", false},
+		{"abcd1234abcd1234abcd1", false},
+		{"abcd_1234", false},
+		{"abcdé1234", false},
+		{"", false},
+	}
+
+	for _, c := range cases {
+		err := ValidateGiftCardCode(c.code)
+		if c.valid && err != nil {
+			t.Errorf("ValidateGiftCardCode(%q) returned %v, expected nil", c.code, err)
+		}
+		if !c.valid && !errors.Is(err, ErrInvalidGiftCardCode) {
+			t.Errorf("ValidateGiftCardCode(%q) returned %v, expected %v", c.code, err, ErrInvalidGiftCardCode)
+		}
+	}
+}
+
+func TestFormatGiftCardCode(t *testing.T) {
+	cases := map[string]string{
+		"f59ef7efacb3c2g8":  "f59e f7ef acb3 c2g8",
+		"F59EF7EFACB3C2G8X": "f59e f7ef acb3 c2g8 x",
+		"abcd-1234":         "abcd 1234",
+		"":                  "",
+	}
+	for in, expected := range cases {
+		if actual := FormatGiftCardCode(in); actual != expected {
+			t.Errorf("FormatGiftCardCode(%q) = %q, expected %q", in, actual, expected)
+		}
+	}
+}
+
+func TestMaskGiftCardCode(t *testing.T) {
+	cases := map[string]string{
+		"f59ef7efacb3c2g8":  "•••• •••• •••• c2g8",
+		"f59e f7ef acb3 c2": "•••• •••• ••b3 c2",
+		"abcd1234":          "•••• 1234",
+		"abcd":              "••••",
+		"":                  "",
+	}
+	for in, expected := range cases {
+		if actual := MaskGiftCardCode(in); actual != expected {
+			t.Errorf("MaskGiftCardCode(%q) = %q, expected %q", in, actual, expected)
+		}
+	}
+}
+
+func TestGenerateGiftCardCode(t *testing.T) {
+	seen := map[string]bool{}
+	for _, length := range []int{8, 16, 20} {
+		code, err := GenerateGiftCardCode(length)
+		if err != nil {
+			t.Fatalf("GenerateGiftCardCode(%d) returned error: %v", length, err)
+		}
+		if len(code) != length {
+			t.Errorf("GenerateGiftCardCode(%d) returned %q of length %d", length, code, len(code))
+		}
+		if err := ValidateGiftCardCode(code); err != nil {
+			t.Errorf("GenerateGiftCardCode(%d) returned invalid code %q: %v", length, code, err)
+		}
+		if strings.ContainsAny(code, "01ilo") {
+			t.Errorf("GenerateGiftCardCode(%d) returned %q with ambiguous characters", length, code)
+		}
+		if seen[code] {
+			t.Errorf("GenerateGiftCardCode(%d) returned %q twice", length, code)
+		}
+		seen[code] = true
+	}
+
+	for _, length := range []int{0, 7, 21} {
+		if _, err := GenerateGiftCardCode(length); !errors.Is(err, ErrInvalidGiftCardCode) {
+			t.Errorf("GenerateGiftCardCode(%d) returned error %v, expected ErrInvalidGiftCardCode", length, err)
+		}
+	}
+}
+
+func TestGiftCardLastCharacters(t *testing.T) {
+	cases := map[string]string{
+		"f59ef7efacb3c2g8":    "c2g8",
+		"F59E-F7EF-ACB3-C2G8": "c2g8",
+		"abc":                 "abc",
+		"":                    "",
+	}
+	for in, expected := range cases {
+		if actual := GiftCardLastCharacters(in); actual != expected {
+			t.Errorf("GiftCardLastCharacters(%q) = %q, expected %q", in, actual, expected)
+		}
+	}
+
+	// the mask leaves the last characters readable
+	code := "f59e f7ef acb3 c2g8"
+	if !strings.HasSuffix(MaskGiftCardCode(code), GiftCardLastCharacters(code)) {
+		t.Errorf("MaskGiftCardCode(%q) = %q doesn't end with %q", code, MaskGiftCardCode(code), GiftCardLastCharacters(code))
+	}
+}
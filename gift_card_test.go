@@ -1,7 +1,10 @@
 package goshopify
 
 import (
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"net/http"
 	"reflect"
 	"testing"
 	"time"
@@ -120,6 +123,97 @@ func TestGiftCardSearch(t *testing.T) {
 	}
 }
 
+func TestGiftCardSearchWithPagination(t *testing.T) {
+	setup()
+	defer teardown()
+
+	searchURL := fmt.Sprintf("https://fooshop.myshopify.com/%s/gift_cards/search.json", client.pathPrefix)
+	httpmock.RegisterResponderWithQuery(
+		"GET",
+		searchURL,
+		map[string]string{"limit": "2", "query": "last_characters:mnop status:enabled"},
+		httpmock.ResponderFromResponse(&http.Response{
+			StatusCode: 200,
+			Body:       httpmock.NewRespBodyFromString(`{"gift_cards": [{"id":1},{"id":2}]}`),
+			Header: http.Header{
+				"Link": {`<` + searchURL + `?limit=2&page_info=abc>; rel="next"`},
+			},
+		}))
+	httpmock.RegisterResponderWithQuery(
+		"GET",
+		searchURL,
+		map[string]string{"limit": "2", "page_info": "abc"},
+		httpmock.NewStringResponder(200, `{"gift_cards": [{"id":3}]}`))
+
+	query := GiftCardQuery{}.LastCharacters("mnop").Status(GiftCardStatusEnabled)
+	giftCards, pagination, err := client.GiftCard.SearchWithPagination(GiftCardSearchOptions{Limit: 2, Query: query.String()})
+	if err != nil {
+		t.Fatalf("GiftCard.SearchWithPagination returned error: %v", err)
+	}
+	if !reflect.DeepEqual(giftCards, []GiftCard{{ID: 1}, {ID: 2}}) {
+		t.Errorf("GiftCard.SearchWithPagination returned %+v", giftCards)
+	}
+	if pagination == nil || pagination.NextPageOptions == nil || pagination.NextPageOptions.PageInfo != "abc" {
+		t.Fatalf("GiftCard.SearchWithPagination returned pagination %+v", pagination)
+	}
+
+	giftCards, pagination, err = client.GiftCard.SearchWithPagination(pagination.NextPageOptions)
+	if err != nil {
+		t.Fatalf("GiftCard.SearchWithPagination of the next page returned error: %v", err)
+	}
+	if !reflect.DeepEqual(giftCards, []GiftCard{{ID: 3}}) || pagination.NextPageOptions != nil {
+		t.Errorf("GiftCard.SearchWithPagination of the next page returned %+v, %+v", giftCards, pagination)
+	}
+}
+
+func TestGiftCardListWithPagination(t *testing.T) {
+	setup()
+	defer teardown()
+
+	listURL := fmt.Sprintf("https://fooshop.myshopify.com/%s/gift_cards.json", client.pathPrefix)
+	httpmock.RegisterResponder("GET", listURL, httpmock.ResponderFromResponse(&http.Response{
+		StatusCode: 200,
+		Body:       httpmock.NewRespBodyFromString(`{"gift_cards": [{"id":1}]}`),
+		Header: http.Header{
+			"Link": {`<` + listURL + `?page_info=abc>; rel="next"`},
+		},
+	}))
+
+	giftCards, pagination, err := client.GiftCard.ListWithPagination(nil)
+	if err != nil {
+		t.Fatalf("GiftCard.ListWithPagination returned error: %v", err)
+	}
+	if len(giftCards) != 1 || pagination.NextPageOptions == nil || pagination.NextPageOptions.PageInfo != "abc" {
+		t.Errorf("GiftCard.ListWithPagination returned %+v, %+v", giftCards, pagination)
+	}
+}
+
+func TestGiftCardQuery(t *testing.T) {
+	cases := []struct {
+		query    GiftCardQuery
+		expected string
+	}{
+		{GiftCardQuery{}, ""},
+		{GiftCardQuery{}.LastCharacters("mnop"), "last_characters:mnop"},
+		{GiftCardQuery{}.Status(GiftCardStatusDisabled).Email("bob@example.com"), "status:disabled email:bob@example.com"},
+		{GiftCardQuery{}.Field("note", "for Bob"), `note:"for Bob"`},
+	}
+
+	for _, c := range cases {
+		if actual := c.query.String(); actual != c.expected {
+			t.Errorf("GiftCardQuery %v returned %q, expected %q", []string(c.query), actual, c.expected)
+		}
+	}
+
+	// building on a query doesn't change it
+	base := GiftCardQuery{}.Status(GiftCardStatusEnabled)
+	enabled := base.LastCharacters("abcd")
+	disabled := base.LastCharacters("efgh")
+	if enabled.String() != "status:enabled last_characters:abcd" || disabled.String() != "status:enabled last_characters:efgh" {
+		t.Errorf("GiftCardQuery returned %q and %q", enabled, disabled)
+	}
+}
+
 func TestGiftCardCreate(t *testing.T) {
 	setup()
 	defer teardown()
@@ -144,6 +238,40 @@ func TestGiftCardCreate(t *testing.T) {
 	}
 }
 
+func TestGiftCardUpdateLeavesOutCode(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var body string
+	httpmock.RegisterResponder(
+		"PUT",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/gift_cards/1.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			data, _ := ioutil.ReadAll(req.Body)
+			body = string(data)
+			return httpmock.NewBytesResponse(200, loadFixture("gift_card.json")), nil
+		})
+
+	if _, err := client.GiftCard.Update(GiftCard{ID: 1, Code: "abcd1234efgh", Note: "for Bob"}); err != nil {
+		t.Fatalf("GiftCard.Update returned error: %v", err)
+	}
+
+	expected := `{"gift_card":{"id":1,"note":"for Bob"}}`
+	if body != expected {
+		t.Errorf("GiftCard.Update sent %s, expected %s", body, expected)
+	}
+}
+
+func TestGiftCardCreateInvalidCode(t *testing.T) {
+	setup()
+	defer teardown()
+
+	_, err := client.GiftCard.Create(GiftCard{Code: "short"})
+	if !errors.Is(err, ErrInvalidGiftCardCode) {
+		t.Errorf("GiftCard.Create returned error %v, expected %v", err, ErrInvalidGiftCardCode)
+	}
+}
+
 func TestGiftCardUpdate(t *testing.T) {
 	setup()
 	defer teardown()
@@ -3,6 +3,7 @@ package goshopify
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -15,6 +16,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/go-querystring/query"
@@ -29,11 +31,18 @@ const (
 	defaultApiPathPrefix = "admin/api/2021-01"
 	defaultApiVersion    = "stable"
 	defaultHttpTimeout   = 10
+
+	// Shopify's documented back off for rate limited requests
+	defaultRetryAfter = 2 * time.Second
 )
 
 var (
 	// version regex match
 	apiVersionRegex = regexp.MustCompile(`^[0-9]{4}-[0-9]{2}$`)
+
+	// adminPathRegex matches the admin prefix of legacy and versioned paths,
+	// e.g. "admin/" or "admin/api/2020-01/"
+	adminPathRegex = regexp.MustCompile(`^admin/(api/([0-9]{4}-[0-9]{2}|unstable)/)?`)
 )
 
 // App represents basic app settings such as Api key, secret, scope, and redirect url.
@@ -47,10 +56,29 @@ type App struct {
 	Client      *Client // see GetAccessToken
 }
 
+// bucketLeakRate is the rate at which Shopify's REST call limit bucket drains
+// for standard plans.
+const bucketLeakRate = 2
+
+// RateLimitInfo holds the rate limit state reported by the last response,
+// which callers can use to throttle themselves.
 type RateLimitInfo struct {
+	// REST call limit bucket, from the X-Shopify-Shop-Api-Call-Limit header
 	RequestCount      int
 	BucketSize        int
 	RetryAfterSeconds float64
+
+	// Cost of the last GraphQL query, see GraphQLCost
+	GraphQLCost GraphQLCost
+}
+
+// Remaining returns the number of REST requests that can be made before the
+// call limit bucket is full, or -1 when no call limit was reported yet.
+func (r RateLimitInfo) Remaining() int {
+	if r.BucketSize == 0 {
+		return -1
+	}
+	return r.BucketSize - r.RequestCount
 }
 
 // Client manages communication with the Shopify API.
@@ -67,19 +95,89 @@ type Client struct {
 	// its own client.
 	baseURL *url.URL
 
-	// URL Prefix, defaults to "admin" see WithVersion
+	// URL Prefix, defaults to "admin/api/2021-01" see WithVersion
 	pathPrefix string
 
 	// version you're currently using of the api, defaults to "stable"
 	apiVersion string
 
+	// VersionLatest or VersionUnstable when the client was configured with
+	// them, see WithVersion
+	versionAlias string
+
 	// A permanent access token
 	token string
 
+	// Storefront API access token, only set for clients created by
+	// NewStorefrontClient
+	storefrontToken string
+
+	// which credentials are sent, see WithAccessToken and WithPrivateAppAuth
+	authMode authMode
+
 	// max number of retries, defaults to 0 for no retries see WithRetry option
 	retries  int
 	attempts int
 
+	// guards attempts and RateLimits when the client is shared by goroutines
+	mu sync.Mutex
+
+	// backoff for 5xx responses and network errors, nil disables those
+	// retries, see WithRetryPolicy
+	retryPolicy *RetryPolicy
+
+	// fails requests fast while the shop keeps failing, nil when disabled,
+	// see WithCircuitBreaker
+	breaker *circuitBreaker
+
+	// called for responses reporting deprecations, see WithDeprecationHandler
+	deprecationHandler DeprecationHandler
+
+	// client side rate limiting, nil when disabled, see WithRateLimiter
+	restBucket    *leakyBucket
+	graphQLBucket *leakyBucket
+
+	// called before waiting for the rate limit, see WithOnRateLimited
+	onRateLimited func(shop string, wait time.Duration)
+
+	// reject response fields not modelled by the destination struct, see
+	// WithStrictDecoding
+	strictDecoding bool
+
+	// called with the response fields not modelled by the destination
+	// struct, see WithUnknownFieldHandler
+	unknownFieldHandler UnknownFieldHandler
+
+	// keep the body of the last response, see WithRawResponses
+	rawResponses bool
+
+	// answers conditional GET requests, see WithETagCache
+	etagCache ETagCache
+
+	// answers GET requests for the cache resources, all when empty, see
+	// WithCache
+	cache          Cache
+	cacheTTL       time.Duration
+	cacheResources []string
+
+	// sent with every request, see WithHeaders
+	headers http.Header
+
+	// identifies the app in the User-Agent, see WithUserAgent
+	userAgent string
+
+	// the last response and its X-Shopify-* headers, see LastResponse and
+	// ShopifyHeaders
+	lastResponse   *Response
+	shopifyHeaders http.Header
+
+	// told about every request, see WithMetrics
+	metrics Metrics
+
+	// log headers and truncate logged bodies, see WithDebugDumps
+	debugDumps       bool
+	debugDumpMaxBody int
+
 	RateLimits RateLimitInfo
 
 	// Services used for communicating with the API
@@ -113,9 +211,29 @@ type Client struct {
 	DiscountCode               DiscountCodeService
 	PriceRule                  PriceRuleService
 	InventoryItem              InventoryItemService
+	InventoryLevel             InventoryLevelService
 	ShippingZone               ShippingZoneService
 	ProductListing             ProductListingService
 	GiftCard                   GiftCardService
+	CarrierService             CarrierServiceService
+	GraphQL                    GraphQLService
+	MetafieldDefinition        MetafieldDefinitionService
+	BulkOperation              BulkOperationService
+	WebhookSubscription        WebhookSubscriptionService
+	File                       FileService
+	StagedUpload               StagedUploadService
+	ProductMedia               ProductMediaService
+	SellingPlanGroup           SellingPlanGroupService
+	SubscriptionContract       SubscriptionContractService
+	CustomerPaymentMethod      CustomerPaymentMethodService
+	Discount                   DiscountService
+	Company                    CompanyService
+	Publication                PublicationService
+	PriceList                  PriceListService
+	Country                    CountryService
+	FulfillmentOrder           FulfillmentOrderService
+	ProductTaxonomy            ProductTaxonomyService
+	AppSubscription            AppSubscriptionService
 }
 
 // A general response error that follows a similar layout to Shopify's response
@@ -124,6 +242,10 @@ type ResponseError struct {
 	Status  int
 	Message string
 	Errors  []string
+
+	// RequestID is the X-Request-Id of the response, Shopify support asks
+	// for it when investigating failed requests
+	RequestID string
 }
 
 // GetStatus returns http  response status
@@ -168,6 +290,18 @@ func (e ResponseDecodingError) Error() string {
 	return e.Message
 }
 
+// NotFoundError occurs when a lookup by a natural key, e.g. GetByHandle,
+// matches no resource.
+type NotFoundError struct {
+	// Resource is the REST resource name, e.g. "products"
+	Resource string
+	Handle   string
+}
+
+func (e NotFoundError) Error() string {
+	return fmt.Sprintf("%s with handle %q not found", e.Resource, e.Handle)
+}
+
 // An error specific to a rate-limiting response. Embeds the ResponseError to
 // allow consumers to handle it the same was a normal ResponseError.
 type RateLimitError struct {
@@ -182,7 +316,7 @@ type RateLimitError struct {
 func (c *Client) NewRequest(method, relPath string, body, options interface{}) (*http.Request, error) {
 	rel, err := url.Parse(relPath)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%s %s: %w", method, relPath, err)
 	}
 
 	// Make the full url based on the relative path
@@ -192,7 +326,10 @@ func (c *Client) NewRequest(method, relPath string, body, options interface{}) (
 	if options != nil {
 		optionsQuery, err := query.Values(options)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("%s %s: encoding options: %w", method, u.Path, err)
+		}
+		if err := validateOptions(optionsQuery); err != nil {
+			return nil, fmt.Errorf("%s %s: %w", method, u.Path, err)
 		}
 
 		for k, values := range u.Query() {
@@ -207,25 +344,22 @@ func (c *Client) NewRequest(method, relPath string, body, options interface{}) (
 	var js []byte = nil
 
 	if body != nil {
-		js, err = json.Marshal(body)
+		js, err = marshalForVersion(body, c.requestApiVersion())
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("%s %s: encoding body: %w", method, u.Path, err)
 		}
 	}
 
 	req, err := http.NewRequest(method, u.String(), bytes.NewBuffer(js))
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%s %s: %w", method, u.Path, err)
 	}
 
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("Accept", "application/json")
-	req.Header.Add("User-Agent", UserAgent)
-	if c.token != "" {
-		req.Header.Add("X-Shopify-Access-Token", c.token)
-	} else if c.app.Password != "" {
-		req.SetBasicAuth(c.app.ApiKey, c.app.Password)
-	}
+	req.Header.Add("User-Agent", c.userAgentHeader())
+	c.setHeaders(req)
+	c.authenticate(req)
 	return req, nil
 }
 
@@ -288,14 +422,36 @@ func NewClient(app App, shopName, token string, opts ...Option) *Client {
 	c.DiscountCode = &DiscountCodeServiceOp{client: c}
 	c.PriceRule = &PriceRuleServiceOp{client: c}
 	c.InventoryItem = &InventoryItemServiceOp{client: c}
+	c.InventoryLevel = &InventoryLevelServiceOp{client: c}
 	c.ShippingZone = &ShippingZoneServiceOp{client: c}
 	c.ProductListing = &ProductListingServiceOp{client: c}
 	c.GiftCard = &GiftCardServiceOp{client: c}
+	c.CarrierService = &CarrierServiceServiceOp{client: c}
+	c.GraphQL = &GraphQLServiceOp{client: c}
+	c.MetafieldDefinition = &MetafieldDefinitionServiceOp{client: c}
+	c.BulkOperation = &BulkOperationServiceOp{client: c}
+	c.WebhookSubscription = &WebhookSubscriptionServiceOp{client: c}
+	c.File = &FileServiceOp{client: c}
+	c.StagedUpload = &StagedUploadServiceOp{client: c}
+	c.ProductMedia = &ProductMediaServiceOp{client: c}
+	c.SellingPlanGroup = &SellingPlanGroupServiceOp{client: c}
+	c.SubscriptionContract = &SubscriptionContractServiceOp{client: c}
+	c.CustomerPaymentMethod = &CustomerPaymentMethodServiceOp{client: c}
+	c.Discount = &DiscountServiceOp{client: c}
+	c.Company = &CompanyServiceOp{client: c}
+	c.Publication = &PublicationServiceOp{client: c}
+	c.PriceList = &PriceListServiceOp{client: c}
+	c.Country = &CountryServiceOp{client: c}
+	c.FulfillmentOrder = &FulfillmentOrderServiceOp{client: c}
+	c.ProductTaxonomy = &ProductTaxonomyServiceOp{client: c}
+	c.AppSubscription = &AppSubscriptionServiceOp{client: c}
 
 	// apply any options
 	for _, opt := range opts {
 		opt(c)
 	}
+	c.warnAmbiguousAuth()
+	c.logApiVersion()
 
 	return c
 }
@@ -317,15 +473,72 @@ func (c *Client) doGetHeaders(req *http.Request, v interface{}) (http.Header, er
 	var resp *http.Response
 	var err error
 	retries := c.retries
-	c.attempts = 0
+	transientRetries := 0
+	attempts := 0
+	// whether the attempt is a retry spending the breaker's retry budget,
+	// waiting out the rate limit doesn't
+	budgetedRetry := false
+	if cached := c.fromCache(req); cached != nil && v != nil {
+		if err := c.newDecoder(bytes.NewReader(cached.Body)).Decode(&v); err == nil {
+			return cached.Header, nil
+		}
+	}
+
+	cached := c.conditionalRequest(req)
 	c.logRequest(req)
 
 	for {
-		c.attempts++
+		attempts++
+		c.mu.Lock()
+		c.attempts = attempts
+		c.mu.Unlock()
+		if attempts > 1 && req.GetBody != nil {
+			// the body was consumed by the previous attempt
+			req.Body, err = req.GetBody()
+			if err != nil {
+				return nil, wrapRequestError(req, err)
+			}
+		}
+
+		if err := c.throttle(req); err != nil {
+			return nil, wrapRequestError(req, err)
+		}
+		if err := c.breaker.allow(budgetedRetry); err != nil {
+			return nil, wrapRequestError(req, err)
+		}
+
+		start := time.Now()
 		resp, err = c.Client.Do(req)
+		c.breaker.record(attemptOutcomeOf(req, resp, err))
 		c.logResponse(resp)
+		if err == nil {
+			if resp.Request == nil {
+				// not every transport sets it, responses are captured by it
+				resp.Request = req
+			}
+			c.updateRateLimits(resp)
+			c.recordResponse(resp)
+			c.checkDeprecation(req, resp)
+		}
+		c.reportRequest(req, resp, time.Since(start))
 		if err != nil {
-			return nil, err //http client errors, not api responses
+			if c.retryPolicy != nil && c.retryPolicy.retryableMethod(req.Method) && req.Context().Err() == nil && isTemporaryNetworkError(err) &&
+				transientRetries < c.retryPolicy.MaxRetries && c.breaker.allowRetry() {
+				wait := c.retryPolicy.backoff(transientRetries)
+				c.log.Debugf("network error %s, retrying in %s", err, wait.String())
+				if err := sleepContext(req.Context(), wait); err != nil {
+					return nil, wrapRequestError(req, err)
+				}
+				transientRetries++
+				budgetedRetry = true
+				continue
+			}
+			return nil, wrapRequestError(req, err) //http client errors, not api responses
+		}
+
+		if cached != nil && resp.StatusCode == http.StatusNotModified {
+			useCached(resp, cached)
+			break
 		}
 
 		respErr := CheckResponseError(resp)
@@ -336,29 +549,53 @@ func (c *Client) doGetHeaders(req *http.Request, v interface{}) (http.Header, er
 		// retry scenario, close resp and any continue will retry
 		resp.Body.Close()
 
+		if c.retryPolicy != nil && c.retryPolicy.retryableMethod(req.Method) && c.retryPolicy.retryableStatus(resp.StatusCode) {
+			if transientRetries >= c.retryPolicy.MaxRetries || !c.breaker.allowRetry() {
+				return nil, respErr
+			}
+			wait := c.retryPolicy.backoff(transientRetries)
+			c.log.Debugf("status %d, retrying in %s", resp.StatusCode, wait.String())
+			if err := sleepContext(req.Context(), wait); err != nil {
+				return nil, wrapRequestError(req, err)
+			}
+			transientRetries++
+			budgetedRetry = true
+			continue
+		}
+
 		if retries <= 1 {
 			return nil, respErr
 		}
 
-		if rateLimitErr, isRetryErr := respErr.(RateLimitError); isRetryErr {
+		if _, isRetryErr := respErr.(RateLimitError); isRetryErr {
 			// back off and retry
-
-			wait := time.Duration(rateLimitErr.RetryAfter) * time.Second
+			wait := retryAfter(resp)
 			c.log.Debugf("rate limited waiting %s", wait.String())
-			time.Sleep(wait)
+			c.rateLimited(wait)
+			if err := sleepContext(req.Context(), wait); err != nil {
+				return nil, wrapRequestError(req, err)
+			}
 			retries--
+			budgetedRetry = false
 			continue
 		}
 
+		if !c.breaker.allowRetry() {
+			return nil, respErr
+		}
+
 		var doRetry bool
 		switch resp.StatusCode {
 		case http.StatusServiceUnavailable:
-			c.log.Debugf("service unavailable, retrying")
-			doRetry = true
-			retries--
+			if c.retryableMethod(req.Method) {
+				c.log.Debugf("service unavailable, retrying")
+				doRetry = true
+				retries--
+			}
 		}
 
 		if doRetry {
+			budgetedRetry = true
 			continue
 		}
 
@@ -376,21 +613,130 @@ func (c *Client) doGetHeaders(req *http.Request, v interface{}) (http.Header, er
 	}
 
 	if v != nil {
-		decoder := json.NewDecoder(resp.Body)
+		var body io.Reader = resp.Body
+		var data []byte
+		cacheable := c.cacheable(req, resp)
+		_, storable := c.cacheKey(req)
+		if c.unknownFieldHandler != nil || c.rawResponses || cacheable || storable {
+			// keep the body to look for unknown fields, retain it or cache
+			// it once decoded
+			data, err = ioutil.ReadAll(resp.Body)
+			if err != nil {
+				return nil, wrapRequestError(req, err)
+			}
+			body = bytes.NewReader(data)
+		}
+
+		decoder := c.newDecoder(body)
 		err := decoder.Decode(&v)
 		if err != nil {
-			return nil, err
+			return nil, wrapRequestError(req, fmt.Errorf("decoding response: %w", err))
+		}
+
+		if cacheable {
+			c.etagCache.Set(req.URL.String(), &CachedResponse{Header: resp.Header.Clone(), Body: data})
+		}
+		if storable {
+			c.toCache(req, resp, data)
+		}
+		if data != nil {
+			c.recordRaw(resp, data)
+			c.checkUnknownFields(req.Method, req.URL.String(), data, v)
 		}
 	}
 
+	return resp.Header, nil
+}
+
+// updateRateLimits records the REST call limit reported by a response, which
+// may also be an error response.
+func (c *Client) updateRateLimits(resp *http.Response) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	if s := strings.Split(resp.Header.Get("X-Shopify-Shop-Api-Call-Limit"), "/"); len(s) == 2 {
 		c.RateLimits.RequestCount, _ = strconv.Atoi(s[0])
 		c.RateLimits.BucketSize, _ = strconv.Atoi(s[1])
+		if c.restBucket != nil {
+			c.restBucket.sync(float64(c.RateLimits.RequestCount))
+		}
 	}
 
 	c.RateLimits.RetryAfterSeconds, _ = strconv.ParseFloat(resp.Header.Get("Retry-After"), 64)
+}
 
-	return resp.Header, nil
+// rateLimits returns a snapshot of RateLimits that is safe to take while other
+// goroutines use the client.
+func (c *Client) rateLimits() RateLimitInfo {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.RateLimits
+}
+
+// retryAfter returns how long Shopify asked us to back off for, falling back
+// to the default of 2 seconds when the Retry-After header is missing.
+func retryAfter(resp *http.Response) time.Duration {
+	seconds, err := strconv.ParseFloat(resp.Header.Get("Retry-After"), 64)
+	if err != nil || seconds <= 0 {
+		return defaultRetryAfter
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// sleepContext waits for the duration or until the context is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// transferClient returns a copy of the HTTP client without a timeout, for
+// downloads and uploads of files of any size, e.g. bulk operation results.
+// The client's timeout covers reading the whole body, so those are bounded by
+// their context instead.
+func (c *Client) transferClient() *http.Client {
+	client := *c.Client
+	client.Timeout = 0
+	return &client
+}
+
+// newDecoder returns a JSON decoder for response bodies, honouring the strict
+// decoding setting of the client.
+func (c *Client) newDecoder(r io.Reader) *json.Decoder {
+	decoder := json.NewDecoder(r)
+	if c.strictDecoding {
+		decoder.DisallowUnknownFields()
+	}
+	return decoder
+}
+
+// decodeJSON decodes data that was already read from a response into v.
+func (c *Client) decodeJSON(data []byte, v interface{}) error {
+	return c.newDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+// waitForRateLimit blocks until the client's call limit bucket, as reported by
+// the last response, has room for another request or the context is done.
+func waitForRateLimit(ctx context.Context, c *Client) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	limits := c.rateLimits()
+	if limits.BucketSize == 0 || limits.RequestCount < limits.BucketSize {
+		return nil
+	}
+
+	wait := time.Second / bucketLeakRate
+	c.log.Debugf("call limit bucket full, waiting %s", wait.String())
+	c.rateLimited(wait)
+	return sleepContext(ctx, wait)
 }
 
 func (c *Client) logRequest(req *http.Request) {
@@ -400,6 +746,7 @@ func (c *Client) logRequest(req *http.Request) {
 	if req.URL != nil {
 		c.log.Debugf("%s: %s", req.Method, req.URL.String())
 	}
+	c.logHeaders(req.Header, "HEADERS:\n%s")
 	c.logBody(&req.Body, "SENT: %s")
 }
 
@@ -408,6 +755,7 @@ func (c *Client) logResponse(res *http.Response) {
 		return
 	}
 	c.log.Debugf("RECV %d: %s", res.StatusCode, res.Status)
+	c.logHeaders(res.Header, "HEADERS:\n%s")
 	c.logBody(&res.Body, "RESP: %s")
 }
 
@@ -417,11 +765,19 @@ func (c *Client) logBody(body *io.ReadCloser, format string) {
 	}
 	b, _ := ioutil.ReadAll(*body)
 	if len(b) > 0 {
-		c.log.Debugf(format, string(b))
+		c.log.Debugf(format, c.dumpBody(b))
 	}
 	*body = ioutil.NopCloser(bytes.NewBuffer(b))
 }
 
+// wrapRequestError attaches the method and path of a request to an error that
+// isn't an API error response, e.g. a network error, a cancelled context or a
+// response that couldn't be decoded. The cause, including the *url.Error of
+// a failed request, stays available to errors.Is and errors.As.
+func wrapRequestError(req *http.Request, err error) error {
+	return fmt.Errorf("%s %s: %w", req.Method, req.URL.Path, err)
+}
+
 func wrapSpecificError(r *http.Response, err ResponseError) error {
 	// see https://www.shopify.dev/concepts/about-apis/response-codes
 	if err.Status == http.StatusTooManyRequests {
@@ -476,8 +832,9 @@ func CheckResponseError(r *http.Response) error {
 
 	// Create the response error from the Shopify error.
 	responseError := ResponseError{
-		Status:  r.StatusCode,
-		Message: shopifyError.Error,
+		Status:    r.StatusCode,
+		Message:   shopifyError.Error,
+		RequestID: r.Header.Get(RequestIDHeader),
 	}
 
 	// If the errors field is not filled out, we can return here.
@@ -561,12 +918,21 @@ type ListOptions struct {
 	IDs          []int64   `url:"ids,omitempty,comma"`
 }
 
-// General count options that can be used for most collection counts.
+// General count options that can be used for most collection counts. They
+// mirror the filters of the list options, endpoints ignore the ones they
+// don't support, e.g. only products are counted by Vendor.
 type CountOptions struct {
-	CreatedAtMin time.Time `url:"created_at_min,omitempty"`
-	CreatedAtMax time.Time `url:"created_at_max,omitempty"`
-	UpdatedAtMin time.Time `url:"updated_at_min,omitempty"`
-	UpdatedAtMax time.Time `url:"updated_at_max,omitempty"`
+	CreatedAtMin   time.Time `url:"created_at_min,omitempty"`
+	CreatedAtMax   time.Time `url:"created_at_max,omitempty"`
+	UpdatedAtMin   time.Time `url:"updated_at_min,omitempty"`
+	UpdatedAtMax   time.Time `url:"updated_at_max,omitempty"`
+	PublishedAtMin time.Time `url:"published_at_min,omitempty"`
+	PublishedAtMax time.Time `url:"published_at_max,omitempty"`
+	// PublishedStatus is published, unpublished or any
+	PublishedStatus string `url:"published_status,omitempty"`
+	Vendor          string `url:"vendor,omitempty"`
+	ProductType     string `url:"product_type,omitempty"`
+	CollectionID    int64  `url:"collection_id,omitempty"`
 }
 
 func (c *Client) Count(path string, options interface{}) (int, error) {
@@ -578,7 +944,9 @@ func (c *Client) Count(path string, options interface{}) (int, error) {
 }
 
 // CreateAndDo performs a web request to Shopify with the given method (GET,
-// POST, PUT, DELETE) and relative path (e.g. "/admin/orders.json").
+// POST, PUT, DELETE) and relative path (e.g. "orders.json"). The path is
+// prefixed with the configured API version, a legacy "admin/" prefix is
+// replaced by it.
 // The data, options and resource arguments are optional and only relevant in
 // certain situations.
 // If the data argument is non-nil, it will be used as the body of the request
@@ -587,27 +955,48 @@ func (c *Client) Count(path string, options interface{}) (int, error) {
 // parameters like created_at_min
 // Any data returned from Shopify will be marshalled into resource argument.
 func (c *Client) CreateAndDo(method, relPath string, data, options, resource interface{}) error {
-	_, err := c.createAndDoGetHeaders(method, relPath, data, options, resource)
-	if err != nil {
-		return err
+	return c.CreateAndDoWithContext(context.Background(), method, relPath, data, options, resource)
+}
+
+// CreateAndDoWithContext is CreateAndDo bound to a context, which cancels the
+// request as well as any wait for the rate limit or a retry. It is the way to
+// call endpoints that have no service yet with the same authentication,
+// versioning, retries and error handling as the services. Request options,
+// e.g. RequestHeader, apply to this call only.
+func (c *Client) CreateAndDoWithContext(ctx context.Context, method, relPath string, data, options, resource interface{}, opts ...RequestOption) error {
+	if len(opts) > 0 {
+		ctx = WithRequestOptions(ctx, opts...)
 	}
-	return nil
+	_, err := c.createAndDoGetHeaders(ctx, method, relPath, data, options, resource)
+	return err
 }
 
 // createAndDoGetHeaders creates an executes a request while returning the response headers.
-func (c *Client) createAndDoGetHeaders(method, relPath string, data, options, resource interface{}) (http.Header, error) {
+func (c *Client) createAndDoGetHeaders(ctx context.Context, method, relPath string, data, options, resource interface{}) (http.Header, error) {
 	if strings.HasPrefix(relPath, "/") {
 		// make sure it's a relative path
 		relPath = strings.TrimLeft(relPath, "/")
 	}
 
+	// paths written for the unversioned or another version of the API are
+	// sent to the configured version
+	relPath = adminPathRegex.ReplaceAllString(relPath, "")
+
+	rc := newRequestConfig(ctx, nil)
+	if rc.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, rc.timeout)
+		defer cancel()
+	}
+
 	relPath = path.Join(c.pathPrefix, relPath)
 	req, err := c.NewRequest(method, relPath, data, options)
 	if err != nil {
 		return nil, err
 	}
+	rc.apply(req)
 
-	return c.doGetHeaders(req, resource)
+	return c.doGetHeaders(req.WithContext(ctx), resource)
 }
 
 // Get performs a GET request for the given path and saves the result in the
@@ -616,6 +1005,15 @@ func (c *Client) Get(path string, resource, options interface{}) error {
 	return c.CreateAndDo("GET", path, nil, options, resource)
 }
 
+// GetRaw performs a GET request for the given path and returns the response
+// body as is, e.g. to persist the exact payload or to decode fields the
+// structs don't model.
+func (c *Client) GetRaw(path string, options interface{}) (json.RawMessage, error) {
+	var raw json.RawMessage
+	err := c.Get(path, &raw, options)
+	return raw, err
+}
+
 // Post performs a POST request for the given path and saves the result in the
 // given resource.
 func (c *Client) Post(path string, data, resource interface{}) error {
@@ -1,6 +1,7 @@
 package goshopify
 
 import (
+	"context"
 	"crypto/tls"
 	"encoding/json"
 	"errors"
@@ -335,9 +336,15 @@ func TestDo(t *testing.T) {
 
 		err = client.Do(req, body)
 		if err != nil {
-			if e, ok := err.(*url.Error); ok {
-				err = e.Err
-			} else if e, ok := err.(*json.SyntaxError); ok {
+			// errors without an API response wrap their cause
+			var urlErr *url.Error
+			if errors.As(err, &urlErr) {
+				err = urlErr.Err
+			}
+			for errors.Unwrap(err) != nil {
+				err = errors.Unwrap(err)
+			}
+			if e, ok := err.(*json.SyntaxError); ok {
 				err = errors.New(e.Error())
 			}
 
@@ -350,6 +357,30 @@ func TestDo(t *testing.T) {
 	}
 }
 
+func TestDoWrapsErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	cause := errors.New("connection reset")
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix),
+		httpmock.NewErrorResponder(cause))
+
+	_, err := client.Product.Get(1, nil)
+	if !errors.Is(err, cause) {
+		t.Errorf("Product.Get returned %v, expected it to wrap %v", err, cause)
+	}
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) || urlErr.Op != "Get" {
+		t.Errorf("Product.Get returned %v, expected it to wrap the *url.Error", err)
+	}
+
+	expected := fmt.Sprintf(`GET /%s/products/1.json: Get "https://fooshop.myshopify.com/%s/products/1.json": connection reset`,
+		client.pathPrefix, client.pathPrefix)
+	if err == nil || err.Error() != expected {
+		t.Errorf("Product.Get returned %v, expected %s", err, expected)
+	}
+}
+
 func TestRetry(t *testing.T) {
 	setup()
 	defer teardown()
@@ -462,6 +493,87 @@ func TestRetry(t *testing.T) {
 	}
 }
 
+func TestRetryResendsBody(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var bodies []string
+	httpmock.RegisterResponder("POST", "https://fooshop.myshopify.com/foo/1", func(req *http.Request) (*http.Response, error) {
+		b, _ := ioutil.ReadAll(req.Body)
+		bodies = append(bodies, string(b))
+		if len(bodies) == 1 {
+			resp := httpmock.NewStringResponse(http.StatusTooManyRequests, `{"errors":"Exceeded 2 calls per second for api client."}`)
+			resp.Header.Add("Retry-After", "0.01")
+			return resp, nil
+		}
+		return httpmock.NewStringResponse(http.StatusOK, `{}`), nil
+	})
+
+	req, err := client.NewRequest("POST", "foo/1", map[string]string{"foo": "bar"}, nil)
+	if err != nil {
+		t.Fatal("error creating request: ", err)
+	}
+
+	err = client.Do(req, nil)
+	if err != nil {
+		t.Errorf("Do(): errored %s", err)
+	}
+
+	expected := []string{`{"foo":"bar"}`, `{"foo":"bar"}`}
+	if !reflect.DeepEqual(bodies, expected) {
+		t.Errorf("Do(): sent bodies %v, expected %v", bodies, expected)
+	}
+}
+
+func TestRetryContextCanceled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/foo/1", func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(http.StatusTooManyRequests, `{"errors":"Exceeded 2 calls per second for api client."}`)
+		resp.Header.Add("Retry-After", "10.0")
+		return resp, nil
+	})
+
+	req, err := client.NewRequest("GET", "foo/1", nil, nil)
+	if err != nil {
+		t.Fatal("error creating request: ", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err = client.Do(req.WithContext(ctx), nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Do(): expected error %v, actual %v", context.DeadlineExceeded, err)
+	}
+
+	if time.Since(start) > time.Second {
+		t.Errorf("Do(): waited %s, expected to stop on context cancellation", time.Since(start))
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	cases := []struct {
+		header   string
+		expected time.Duration
+	}{
+		{"2.0", 2 * time.Second},
+		{"0.5", 500 * time.Millisecond},
+		{"", defaultRetryAfter},
+		{"invalid", defaultRetryAfter},
+	}
+
+	for _, c := range cases {
+		resp := &http.Response{Header: http.Header{}}
+		resp.Header.Set("Retry-After", c.header)
+		if actual := retryAfter(resp); actual != c.expected {
+			t.Errorf("retryAfter(%q) = %s, expected %s", c.header, actual, c.expected)
+		}
+	}
+}
+
 func TestClientDoAutoApiVersion(t *testing.T) {
 	u := "foo/1"
 	responder := func(req *http.Request) (*http.Response, error) {
@@ -493,6 +605,55 @@ func TestClientDoAutoApiVersion(t *testing.T) {
 	}
 }
 
+func TestDoStrictDecoding(t *testing.T) {
+	setup()
+	defer teardown()
+
+	type MyStruct struct {
+		Foo string `json:"foo"`
+	}
+
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/foo/1",
+		httpmock.NewStringResponder(200, `{"foo": "bar", "baz": "qux"}`))
+
+	req, err := client.NewRequest("GET", "foo/1", nil, nil)
+	if err != nil {
+		t.Fatalf("NewRequest(): errored %s", err)
+	}
+
+	body := new(MyStruct)
+	err = client.Do(req, body)
+	if err != nil {
+		t.Errorf("Do(): lenient decoding errored %s", err)
+	}
+
+	WithStrictDecoding()(client)
+	req, _ = client.NewRequest("GET", "foo/1", nil, nil)
+	err = client.Do(req, body)
+	expected := `GET /foo/1: decoding response: json: unknown field "baz"`
+	if err == nil || err.Error() != expected {
+		t.Errorf("Do(): strict decoding expected error %s, actual %v", expected, err)
+	}
+}
+
+func TestGetRaw(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"product":{"id":1,"not_modelled":true}}`))
+
+	raw, err := client.GetRaw("products/1.json", nil)
+	if err != nil {
+		t.Fatalf("GetRaw(): errored %s", err)
+	}
+
+	expected := `{"product":{"id":1,"not_modelled":true}}`
+	if string(raw) != expected {
+		t.Errorf("GetRaw() returned %s, expected %s", raw, expected)
+	}
+}
+
 func TestCustomHTTPClientDo(t *testing.T) {
 	setup()
 	defer teardown()
@@ -622,7 +783,7 @@ func TestCreateAndDo(t *testing.T) {
 			"foo/1",
 			httpmock.NewStringResponder(500, ""),
 			123,
-			errors.New("query: Values() expects struct input. Got int"),
+			fmt.Errorf("GET /%s/foo/1: encoding options: query: Values() expects struct input. Got int", client.pathPrefix),
 		},
 	}
 
@@ -898,3 +1059,141 @@ func TestDoRateLimit(t *testing.T) {
 		})
 	}
 }
+
+func TestRateLimitsOnErrorResponse(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/foo/1",
+		createResponderWithHeaders(http.StatusTooManyRequests, `{"errors":"Exceeded 2 calls per second for api client."}`, map[string]string{
+			"X-Shopify-Shop-Api-Call-Limit": "40/40",
+			"Retry-After":                   "2.0",
+		}))
+
+	req, _ := client.NewRequest("GET", "foo/1", nil, nil)
+	client.retries = 0
+	if err := client.Do(req, nil); err == nil {
+		t.Errorf("Do(): expected a rate limit error")
+	}
+
+	expected := RateLimitInfo{RequestCount: 40, BucketSize: 40, RetryAfterSeconds: 2}
+	if !reflect.DeepEqual(client.RateLimits, expected) {
+		t.Errorf("RateLimits = %#v, expected %#v", client.RateLimits, expected)
+	}
+}
+
+func TestRateLimitInfoRemaining(t *testing.T) {
+	cases := []struct {
+		limits   RateLimitInfo
+		expected int
+	}{
+		{RateLimitInfo{}, -1},
+		{RateLimitInfo{RequestCount: 10, BucketSize: 40}, 30},
+		{RateLimitInfo{RequestCount: 40, BucketSize: 40}, 0},
+	}
+
+	for _, c := range cases {
+		if actual := c.limits.Remaining(); actual != c.expected {
+			t.Errorf("RateLimitInfo%+v.Remaining() = %d, expected %d", c.limits, actual, c.expected)
+		}
+	}
+}
+
+func TestCreateAndDoAdminPaths(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{}`))
+
+	paths := []string{
+		"products/1.json",
+		"admin/products/1.json",
+		"/admin/products/1.json",
+		"admin/api/2020-01/products/1.json",
+		"admin/api/unstable/products/1.json",
+	}
+
+	for _, p := range paths {
+		if err := client.CreateAndDo("GET", p, nil, nil, nil); err != nil {
+			t.Errorf("CreateAndDo(%q) returned error: %v", p, err)
+		}
+	}
+}
+
+func TestCreateAndDoWithContext(t *testing.T) {
+	setup()
+	defer teardown()
+
+	type ctxKey struct{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "hot path")
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/things/1/activate.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			if req.Context().Value(ctxKey{}) != "hot path" {
+				return nil, errors.New("request is not bound to the context")
+			}
+			if req.Header.Get("X-Shopify-Access-Token") != "abcd" {
+				return httpmock.NewStringResponse(401, `{"errors":"unauthorized"}`), nil
+			}
+			return httpmock.NewStringResponse(200, `{"thing":{"id":1,"active":true}}`), nil
+		})
+
+	out := struct {
+		Thing struct {
+			ID     int64 `json:"id"`
+			Active bool  `json:"active"`
+		} `json:"thing"`
+	}{}
+	body := map[string]interface{}{"thing": map[string]bool{"active": true}}
+	err := client.CreateAndDoWithContext(ctx, "POST", "things/1/activate.json", body, nil, &out)
+	if err != nil {
+		t.Fatalf("CreateAndDoWithContext returned error: %v", err)
+	}
+	if out.Thing.ID != 1 || !out.Thing.Active {
+		t.Errorf("CreateAndDoWithContext decoded %+v, expected an active thing 1", out.Thing)
+	}
+}
+
+func TestCreateAndDoWithContextCancelled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/things.json", client.pathPrefix),
+		httpmock.NewStringResponder(429, `{"errors":"Exceeded 2 calls per second for api client. Slow down!"}`))
+
+	// the cancelled context stops the wait before the retry
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := client.CreateAndDoWithContext(ctx, "GET", "things.json", nil, nil, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("CreateAndDoWithContext with a cancelled context returned %v, expected %v", err, context.Canceled)
+	}
+}
+
+func TestServicesUseConfiguredVersion(t *testing.T) {
+	c := NewClient(app, "fooshop", "abcd", WithVersion("2024-01"))
+	httpmock.ActivateNonDefault(c.Client)
+	defer httpmock.DeactivateAndReset()
+
+	prefix := "https://fooshop.myshopify.com/admin/api/2024-01"
+	httpmock.RegisterResponder("GET", prefix+"/products/1.json", httpmock.NewStringResponder(200, `{"product":{"id":1}}`))
+	httpmock.RegisterResponder("GET", prefix+"/variants/1.json", httpmock.NewStringResponder(200, `{"variant":{"id":1}}`))
+	httpmock.RegisterResponder("GET", prefix+"/price_rules/1.json", httpmock.NewStringResponder(200, `{"price_rule":{"id":1}}`))
+	httpmock.RegisterResponder("GET", prefix+"/gift_cards/1.json", httpmock.NewStringResponder(200, `{"gift_card":{"id":1}}`))
+	httpmock.RegisterResponder("GET", prefix+"/locations/1.json", httpmock.NewStringResponder(200, `{"location":{"id":1}}`))
+
+	calls := map[string]func() error{
+		"Product":   func() error { _, err := c.Product.Get(1, nil); return err },
+		"Variant":   func() error { _, err := c.Variant.Get(1, nil); return err },
+		"PriceRule": func() error { _, err := c.PriceRule.Get(1); return err },
+		"GiftCard":  func() error { _, err := c.GiftCard.Get(1, nil); return err },
+		"Location":  func() error { _, err := c.Location.Get(1, nil); return err },
+	}
+
+	for name, call := range calls {
+		if err := call(); err != nil {
+			t.Errorf("%s.Get with version 2024-01 returned error: %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const graphQLPath = "graphql.json"
+
+// GraphQLService is an interface for interfacing with the Admin GraphQL API.
+// Resources that are only available through GraphQL are built on top of it.
+// See: https://shopify.dev/docs/admin-api/graphql
+type GraphQLService interface {
+	Query(string, interface{}, interface{}) error
+}
+
+// GraphQLServiceOp handles communication with the GraphQL endpoint of the
+// Shopify API.
+type GraphQLServiceOp struct {
+	client *Client
+}
+
+// graphQLRequest is the payload posted to the GraphQL endpoint.
+type graphQLRequest struct {
+	Query     string      `json:"query"`
+	Variables interface{} `json:"variables,omitempty"`
+}
+
+// graphQLResponse is the envelope every GraphQL response is wrapped in.
+type graphQLResponse struct {
+	Data       json.RawMessage `json:"data"`
+	Errors     []GraphQLError  `json:"errors"`
+	Extensions json.RawMessage `json:"extensions"`
+}
+
+// graphQLExtensions holds the extensions of a GraphQL response the client
+// knows about.
+type graphQLExtensions struct {
+	Cost *GraphQLCost `json:"cost"`
+}
+
+// GraphQLCost is the calculated cost of a GraphQL query and the state of the
+// shop's GraphQL cost bucket afterwards.
+// See: https://shopify.dev/concepts/about-apis/rate-limits#graphql-admin-api-rate-limits
+type GraphQLCost struct {
+	RequestedQueryCost int                   `json:"requestedQueryCost"`
+	ActualQueryCost    int                   `json:"actualQueryCost"`
+	ThrottleStatus     GraphQLThrottleStatus `json:"throttleStatus"`
+}
+
+// GraphQLThrottleStatus is the state of the GraphQL cost bucket.
+type GraphQLThrottleStatus struct {
+	MaximumAvailable   float64 `json:"maximumAvailable"`
+	CurrentlyAvailable float64 `json:"currentlyAvailable"`
+	RestoreRate        float64 `json:"restoreRate"`
+}
+
+// GraphQLError is a top level error returned by the GraphQL API, e.g. a
+// syntax error in the query or a throttled request.
+type GraphQLError struct {
+	Message    string                 `json:"message"`
+	Locations  []GraphQLErrorLocation `json:"locations,omitempty"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+// GraphQLErrorLocation points at the part of the query an error refers to.
+type GraphQLErrorLocation struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// graphQLPageInfo is the page info of a GraphQL connection, used to fetch
+// the following pages.
+type graphQLPageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+// gidToID returns the numeric ID of a global ID like gid://shopify/Product/1,
+// or 0 when it has none.
+func gidToID(gid string) int64 {
+	if i := strings.LastIndex(gid, "/"); i >= 0 {
+		gid = gid[i+1:]
+	}
+	if i := strings.Index(gid, "?"); i >= 0 {
+		gid = gid[:i]
+	}
+	id, _ := strconv.ParseInt(gid, 10, 64)
+	return id
+}
+
+// UserError is returned by mutations when the input is invalid.
+type UserError struct {
+	Field   []string `json:"field"`
+	Message string   `json:"message"`
+}
+
+// Query runs a GraphQL query or mutation with the given variables and decodes
+// the data of the response into resp. Top level GraphQL errors are returned as
+// a ResponseError.
+func (s *GraphQLServiceOp) Query(q string, vars, resp interface{}) error {
+	return s.client.graphQLQuery(context.Background(), q, vars, resp)
+}
+
+// graphQLQuery is GraphQL.Query bound to a context, for helpers that run
+// their queries as jobs of a Scheduler.
+func (c *Client) graphQLQuery(ctx context.Context, q string, vars, resp interface{}) error {
+	data := graphQLRequest{Query: q, Variables: vars}
+	envelope := new(graphQLResponse)
+
+	err := c.CreateAndDoWithContext(ctx, "POST", graphQLPath, data, nil, envelope)
+	if err != nil {
+		return err
+	}
+
+	if len(envelope.Extensions) > 0 {
+		extensions := graphQLExtensions{}
+		if json.Unmarshal(envelope.Extensions, &extensions) == nil && extensions.Cost != nil {
+			c.mu.Lock()
+			c.RateLimits.GraphQLCost = *extensions.Cost
+			c.mu.Unlock()
+			if bucket := c.graphQLBucket; bucket != nil {
+				status := extensions.Cost.ThrottleStatus
+				bucket.sync(status.MaximumAvailable - status.CurrentlyAvailable)
+				bucket.setCost(float64(extensions.Cost.RequestedQueryCost))
+			}
+		}
+	}
+
+	if len(envelope.Errors) > 0 {
+		responseError := ResponseError{Status: 200}
+		for _, e := range envelope.Errors {
+			responseError.Errors = append(responseError.Errors, e.Message)
+		}
+		responseError.Message = strings.Join(responseError.Errors, ", ")
+		return responseError
+	}
+
+	if resp == nil || len(envelope.Data) == 0 {
+		return nil
+	}
+
+	if err := c.decodeJSON(envelope.Data, resp); err != nil {
+		return fmt.Errorf("POST /%s/%s: decoding data: %w", c.pathPrefix, graphQLPath, err)
+	}
+	c.checkUnknownFields("POST", graphQLPath, envelope.Data, resp)
+	return nil
+}
+
+// userErrorsToError converts the user errors of a mutation into a
+// ResponseError, returning nil when there are none.
+func userErrorsToError(userErrors []UserError) error {
+	if len(userErrors) == 0 {
+		return nil
+	}
+
+	responseError := ResponseError{Status: 200}
+	for _, e := range userErrors {
+		msg := e.Message
+		if len(e.Field) > 0 {
+			msg = strings.Join(e.Field, ".") + ": " + msg
+		}
+		responseError.Errors = append(responseError.Errors, msg)
+	}
+	responseError.Message = strings.Join(responseError.Errors, ", ")
+	return responseError
+}
+
+// graphQLMutate runs a mutation whose payload, named name, is only checked
+// for user errors.
+func graphQLMutate(client *Client, mutation, name string, vars map[string]interface{}) error {
+	resp := map[string]struct {
+		UserErrors []UserError `json:"userErrors"`
+	}{}
+	if err := client.GraphQL.Query(mutation, vars, &resp); err != nil {
+		return err
+	}
+	return userErrorsToError(resp[name].UserErrors)
+}
@@ -0,0 +1,117 @@
+package goshopify
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestGraphQLQuery(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := ioutil.ReadAll(req.Body)
+			_ = json.Unmarshal(body, &sent)
+			return httpmock.NewStringResponse(200, `{"data":{"shop":{"name":"foo"}},"extensions":{"cost":{"requestedQueryCost":1}}}`), nil
+		})
+
+	resp := struct {
+		Shop struct {
+			Name string `json:"name"`
+		} `json:"shop"`
+	}{}
+	err := client.GraphQL.Query("query($id: ID!) { shop { name } }", map[string]interface{}{"id": "1"}, &resp)
+	if err != nil {
+		t.Errorf("GraphQL.Query returned error: %v", err)
+	}
+
+	if resp.Shop.Name != "foo" {
+		t.Errorf("GraphQL.Query returned %+v, expected shop name foo", resp)
+	}
+
+	expectedVars := map[string]interface{}{"id": "1"}
+	if sent.Query != "query($id: ID!) { shop { name } }" || !reflect.DeepEqual(sent.Variables, expectedVars) {
+		t.Errorf("GraphQL.Query sent %+v", sent)
+	}
+}
+
+func TestGraphQLQueryError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"errors":[{"message":"Field 'foo' doesn't exist on type 'QueryRoot'","locations":[{"line":1,"column":3}],"path":["query","foo"]}]}`))
+
+	err := client.GraphQL.Query("{ foo }", nil, nil)
+	expected := ResponseError{
+		Status:  200,
+		Message: "Field 'foo' doesn't exist on type 'QueryRoot'",
+		Errors:  []string{"Field 'foo' doesn't exist on type 'QueryRoot'"},
+	}
+	if !reflect.DeepEqual(err, expected) {
+		t.Errorf("GraphQL.Query returned error %#v, expected %#v", err, expected)
+	}
+}
+
+func TestGraphQLQueryCost(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"errors":[{"message":"Throttled","extensions":{"code":"THROTTLED"}}],"extensions":{"cost":{"requestedQueryCost":202,"actualQueryCost":null,"throttleStatus":{"maximumAvailable":1000.0,"currentlyAvailable":102,"restoreRate":50.0}}}}`))
+
+	err := client.GraphQL.Query("{ shop { name } }", nil, nil)
+	if err == nil {
+		t.Errorf("GraphQL.Query expected a throttled error")
+	}
+
+	expected := GraphQLCost{
+		RequestedQueryCost: 202,
+		ThrottleStatus: GraphQLThrottleStatus{
+			MaximumAvailable:   1000,
+			CurrentlyAvailable: 102,
+			RestoreRate:        50,
+		},
+	}
+	if !reflect.DeepEqual(client.RateLimits.GraphQLCost, expected) {
+		t.Errorf("RateLimits.GraphQLCost = %+v, expected %+v", client.RateLimits.GraphQLCost, expected)
+	}
+}
+
+func TestUserErrorsToError(t *testing.T) {
+	if err := userErrorsToError(nil); err != nil {
+		t.Errorf("userErrorsToError(nil) returned %v, expected nil", err)
+	}
+
+	err := userErrorsToError([]UserError{
+		{Field: []string{"definition", "key"}, Message: "is taken"},
+		{Message: "something else"},
+	})
+	expected := "definition.key: is taken, something else"
+	if err == nil || err.Error() != expected {
+		t.Errorf("userErrorsToError returned %v, expected %s", err, expected)
+	}
+}
+
+func TestGIDToID(t *testing.T) {
+	cases := map[string]int64{
+		"gid://shopify/Product/123":                                  123,
+		"gid://shopify/InventoryLevel/548380009?inventory_item_id=1": 548380009,
+		"123":                       123,
+		"":                          0,
+		"gid://shopify/Product/abc": 0,
+	}
+	for gid, expected := range cases {
+		if actual := gidToID(gid); actual != expected {
+			t.Errorf("gidToID(%q) = %d, expected %d", gid, actual, expected)
+		}
+	}
+}
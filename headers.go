@@ -0,0 +1,51 @@
+package goshopify
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// shopifyHeaderPrefix is the prefix of the headers Shopify uses to describe
+// a response, e.g. X-Shopify-API-Version or X-Shopify-Shop-Api-Call-Limit.
+const shopifyHeaderPrefix = "X-Shopify-"
+
+// ApiFeaturesHeader opts a request into API features, e.g.
+// "include-presentment-prices", see WithApiFeatures.
+const ApiFeaturesHeader = "X-Shopify-Api-Features"
+
+// authHeaders are set from the client's credentials only.
+var authHeaders = map[string]bool{
+	"Authorization":                     true,
+	"X-Shopify-Access-Token":            true,
+	"X-Shopify-Storefront-Access-Token": true,
+}
+
+// setHeaders adds the headers configured with WithHeaders to a request.
+func (c *Client) setHeaders(req *http.Request) {
+	for name, values := range c.headers {
+		if authHeaders[http.CanonicalHeaderKey(name)] {
+			continue
+		}
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+}
+
+// userAgentHeader returns the User-Agent of the requests, the app's followed
+// by the library's.
+func (c *Client) userAgentHeader() string {
+	if c.userAgent == "" {
+		return UserAgent
+	}
+	return fmt.Sprintf("%s %s", c.userAgent, UserAgent)
+}
+
+// ShopifyHeaders returns the X-Shopify-* headers of the last response, e.g.
+// the API version that served it, so apps taking part in Shopify programs can
+// report on them. The returned headers are a copy.
+func (c *Client) ShopifyHeaders() http.Header {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.shopifyHeaders.Clone()
+}
@@ -0,0 +1,64 @@
+package goshopify
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestWithHeaders(t *testing.T) {
+	setup()
+	defer teardown()
+
+	c := NewClient(app, "fooshop", "abcd",
+		WithHeaders(http.Header{"X-Partner-Id": {"42"}, "x-shopify-access-token": {"stolen"}}),
+		WithApiFeatures("include-presentment-prices", "other-feature"))
+
+	req, err := c.NewRequest("GET", "foo", nil, nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+
+	if got := req.Header.Get("X-Partner-Id"); got != "42" {
+		t.Errorf("X-Partner-Id header returned %q, expected %q", got, "42")
+	}
+	if got := req.Header.Get(ApiFeaturesHeader); got != "include-presentment-prices,other-feature" {
+		t.Errorf("%s header returned %q, expected %q", ApiFeaturesHeader, got, "include-presentment-prices,other-feature")
+	}
+	if got := req.Header.Values("X-Shopify-Access-Token"); len(got) != 1 || got[0] != "abcd" {
+		t.Errorf("X-Shopify-Access-Token header returned %v, expected [abcd]", got)
+	}
+}
+
+func TestShopifyHeaders(t *testing.T) {
+	setup()
+	defer teardown()
+
+	if headers := client.ShopifyHeaders(); len(headers) != 0 {
+		t.Errorf("ShopifyHeaders returned %v before any request, expected none", headers)
+	}
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/foo", client.pathPrefix),
+		createResponderWithHeaders(200, `{}`, map[string]string{
+			"X-Shopify-API-Version": "2022-01",
+			"X-Shopify-Stage":       "production",
+			"Content-Type":          "application/json",
+		}))
+
+	if err := client.Get("foo", nil, nil); err != nil {
+		t.Fatalf("Client.Get returned error: %v", err)
+	}
+
+	headers := client.ShopifyHeaders()
+	if got := headers.Get("X-Shopify-API-Version"); got != "2022-01" {
+		t.Errorf("X-Shopify-API-Version returned %q, expected %q", got, "2022-01")
+	}
+	if got := headers.Get("X-Shopify-Stage"); got != "production" {
+		t.Errorf("X-Shopify-Stage returned %q, expected %q", got, "production")
+	}
+	if got := headers.Get("Content-Type"); got != "" {
+		t.Errorf("ShopifyHeaders returned Content-Type %q, expected only X-Shopify-* headers", got)
+	}
+}
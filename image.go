@@ -1,7 +1,11 @@
 package goshopify
 
 import (
+	"bytes"
+	"encoding/base64"
 	"fmt"
+	"io"
+	"reflect"
 	"time"
 )
 
@@ -13,6 +17,7 @@ type ImageService interface {
 	Count(int64, interface{}) (int, error)
 	Get(int64, int64, interface{}) (*Image, error)
 	Create(int64, Image) (*Image, error)
+	CreateFromReader(int64, string, io.Reader) (*Image, error)
 	Update(int64, Image) (*Image, error)
 	Delete(int64, int64) error
 }
@@ -33,11 +38,36 @@ type Image struct {
 	Width      int        `json:"width,omitempty"`
 	Height     int        `json:"height,omitempty"`
 	Src        string     `json:"src,omitempty"`
+	Alt        string     `json:"alt,omitempty"`
 	Attachment string     `json:"attachment,omitempty"`
 	Filename   string     `json:"filename,omitempty"`
 	VariantIds []int64    `json:"variant_ids,omitempty"`
 }
 
+// SetAttachment reads the content of the image from r and sets it as the
+// base64 encoded attachment, to upload the image instead of passing a Src.
+func (i *Image) SetAttachment(r io.Reader) error {
+	var buf bytes.Buffer
+	encoder := base64.NewEncoder(base64.StdEncoding, &buf)
+	if _, err := io.Copy(encoder, r); err != nil {
+		return fmt.Errorf("reading attachment: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return err
+	}
+	i.Attachment = buf.String()
+	return nil
+}
+
+// orNil returns nil for the zero image, which the image of a collection is
+// unless it was set, so that updates leave the image of the collection alone.
+func (i Image) orNil() *Image {
+	if reflect.ValueOf(i).IsZero() {
+		return nil
+	}
+	return &i
+}
+
 // ImageResource represents the result form the products/X/images/Y.json endpoint
 type ImageResource struct {
 	Image *Image `json:"image"`
@@ -51,9 +81,7 @@ type ImagesResource struct {
 // List images
 func (s *ImageServiceOp) List(productID int64, options interface{}) ([]Image, error) {
 	path := fmt.Sprintf("%s/%d/images.json", productsBasePath, productID)
-	resource := new(ImagesResource)
-	err := s.client.Get(path, resource, options)
-	return resource.Images, err
+	return listResource[Image](s.client, path, "images", options)
 }
 
 // Count images
@@ -65,9 +93,7 @@ func (s *ImageServiceOp) Count(productID int64, options interface{}) (int, error
 // Get individual image
 func (s *ImageServiceOp) Get(productID int64, imageID int64, options interface{}) (*Image, error) {
 	path := fmt.Sprintf("%s/%d/images/%d.json", productsBasePath, productID, imageID)
-	resource := new(ImageResource)
-	err := s.client.Get(path, resource, options)
-	return resource.Image, err
+	return getResource[Image](s.client, path, "image", options)
 }
 
 // Create a new image
@@ -85,19 +111,23 @@ func (s *ImageServiceOp) Get(productID int64, imageID int64, options interface{}
 // Shopify will accept Image.Attachment without Image.Filename.
 func (s *ImageServiceOp) Create(productID int64, image Image) (*Image, error) {
 	path := fmt.Sprintf("%s/%d/images.json", productsBasePath, productID)
-	wrappedData := ImageResource{Image: &image}
-	resource := new(ImageResource)
-	err := s.client.Post(path, wrappedData, resource)
-	return resource.Image, err
+	return createResource(s.client, path, "image", image)
+}
+
+// CreateFromReader uploads the content of r as a new image of the product,
+// e.g. an opened file.
+func (s *ImageServiceOp) CreateFromReader(productID int64, filename string, r io.Reader) (*Image, error) {
+	image := Image{Filename: filename}
+	if err := image.SetAttachment(r); err != nil {
+		return nil, err
+	}
+	return s.Create(productID, image)
 }
 
 // Update an existing image
 func (s *ImageServiceOp) Update(productID int64, image Image) (*Image, error) {
 	path := fmt.Sprintf("%s/%d/images/%d.json", productsBasePath, productID, image.ID)
-	wrappedData := ImageResource{Image: &image}
-	resource := new(ImageResource)
-	err := s.client.Put(path, wrappedData, resource)
-	return resource.Image, err
+	return updateResource(s.client, path, "image", image)
 }
 
 // Delete an existing image
@@ -1,8 +1,13 @@
 package goshopify
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"strings"
 	"testing"
+	"testing/iotest"
 	"time"
 
 	"github.com/jarcoal/httpmock"
@@ -164,6 +169,52 @@ func TestImageCreate(t *testing.T) {
 	imageTests(t, *returnedImage)
 }
 
+func TestImageSetAttachment(t *testing.T) {
+	image := Image{}
+	if err := image.SetAttachment(strings.NewReader("hello")); err != nil {
+		t.Fatalf("Image.SetAttachment returned error: %v", err)
+	}
+
+	expected := "aGVsbG8="
+	if image.Attachment != expected {
+		t.Errorf("Image.Attachment = %q, expected %q", image.Attachment, expected)
+	}
+}
+
+func TestImageCreateFromReader(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent ImageResource
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1/images.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			if err := json.NewDecoder(req.Body).Decode(&sent); err != nil {
+				return nil, err
+			}
+			return httpmock.NewBytesResponse(200, loadFixture("image.json")), nil
+		})
+
+	returnedImage, err := client.Image.CreateFromReader(1, "ipod-nano.png", strings.NewReader("hello"))
+	if err != nil {
+		t.Fatalf("Image.CreateFromReader returned error %v", err)
+	}
+
+	if sent.Image == nil || sent.Image.Filename != "ipod-nano.png" || sent.Image.Attachment != "aGVsbG8=" {
+		t.Errorf("Image.CreateFromReader sent %+v", sent.Image)
+	}
+	imageTests(t, *returnedImage)
+}
+
+func TestImageCreateFromReaderError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	_, err := client.Image.CreateFromReader(1, "ipod-nano.png", iotest.ErrReader(errors.New("broken")))
+	if err == nil || err.Error() != "reading attachment: broken" {
+		t.Errorf("Image.CreateFromReader returned error %v, expected reading attachment: broken", err)
+	}
+}
+
 func TestImageUpdate(t *testing.T) {
 	setup()
 	defer teardown()
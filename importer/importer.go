@@ -0,0 +1,285 @@
+// Package importer creates and updates REST resources of the Admin API, e.g.
+// products or customers, from a JSONL stream with one resource per line, as
+// written by the export package. Records with an id are updated, the others
+// created. A record that fails doesn't stop the import, its error, including
+// the field errors of a 422 response, is collected in a report instead.
+//
+//	f, _ := os.Open("products.jsonl")
+//	report, err := importer.Import(ctx, client, importer.Products, f, importer.Options{Concurrency: 4})
+//	if err != nil {
+//		// the stream couldn't be read or the context is done
+//	}
+//	report.WriteJSON(os.Stdout)
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	goshopify "github.com/myhelix/go-shopify"
+)
+
+// DefaultConcurrency is the number of records imported at the same time when
+// Options.Concurrency isn't set.
+const DefaultConcurrency = 4
+
+// Action is what was done with a record.
+type Action string
+
+const (
+	Created Action = "created"
+	Updated Action = "updated"
+	Failed  Action = "failed"
+)
+
+// Resource is a REST resource that can be created and updated, see
+// NewResource.
+type Resource struct {
+	path     string
+	singular string
+}
+
+// NewResource describes a resource created at path, e.g. "products" for
+// products.json, and updated at path/id.json, whose requests and responses
+// are wrapped in the singular key, e.g. "product". Nested resources are
+// supported too, e.g. "products/1/variants".
+func NewResource(path, singular string) Resource {
+	return Resource{path: path, singular: singular}
+}
+
+// The resources most commonly imported.
+var (
+	Products          = NewResource("products", "product")
+	Customers         = NewResource("customers", "customer")
+	CustomCollections = NewResource("custom_collections", "custom_collection")
+)
+
+// Options configure an import.
+type Options struct {
+	// Concurrency is the number of records imported at the same time, it
+	// defaults to DefaultConcurrency. The rate limiter and retries of the
+	// client apply to every request.
+	Concurrency int
+
+	// Result is called after every record was imported, from the goroutine
+	// that imported it.
+	Result func(Result)
+}
+
+// Result is the outcome of importing a record.
+type Result struct {
+	// Line is the line of the record in the stream, starting at 1
+	Line   int    `json:"line"`
+	Action Action `json:"action"`
+
+	// ID is the id of the created or updated resource, or of the resource
+	// that failed to update
+	ID int64 `json:"id,omitempty"`
+
+	// Status is the HTTP status of a failed request, if there was one
+	Status      int          `json:"status,omitempty"`
+	Error       string       `json:"error,omitempty"`
+	FieldErrors []FieldError `json:"field_errors,omitempty"`
+}
+
+// FieldError is an error of a field of a record, e.g. "title" and "can't be
+// blank". Errors of the record as a whole have the field "base".
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Report collects the results of an import ordered by line.
+type Report struct {
+	Created int      `json:"created"`
+	Updated int      `json:"updated"`
+	Failed  int      `json:"failed"`
+	Results []Result `json:"results"`
+}
+
+// Failures returns the results of the records that failed.
+func (r *Report) Failures() []Result {
+	var failures []Result
+	for _, result := range r.Results {
+		if result.Action == Failed {
+			failures = append(failures, result)
+		}
+	}
+	return failures
+}
+
+// WriteJSON writes the report as indented JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+func (r *Report) add(result Result) {
+	switch result.Action {
+	case Created:
+		r.Created++
+	case Updated:
+		r.Updated++
+	case Failed:
+		r.Failed++
+	}
+	r.Results = append(r.Results, result)
+}
+
+// record is a line of the stream.
+type record struct {
+	line int
+	data json.RawMessage
+}
+
+// Import creates or updates a resource for every line of the JSONL stream
+// and reports the outcome of each. Empty lines are skipped. It only returns
+// an error when the stream can't be read or the context is done, the report
+// then holds the records imported so far.
+func Import(ctx context.Context, client *goshopify.Client, resource Resource, r io.Reader, opts Options) (*Report, error) {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+
+	records := make(chan record)
+	results := make(chan Result)
+
+	var workers sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for rec := range records {
+				result := importRecord(ctx, client, resource, rec)
+				if opts.Result != nil {
+					opts.Result(result)
+				}
+				results <- result
+			}
+		}()
+	}
+
+	var readErr error
+	go func() {
+		defer close(records)
+		readErr = readRecords(ctx, r, records)
+	}()
+
+	go func() {
+		workers.Wait()
+		close(results)
+	}()
+
+	report := &Report{Results: []Result{}}
+	for result := range results {
+		report.add(result)
+	}
+	sort.Slice(report.Results, func(i, j int) bool {
+		return report.Results[i].Line < report.Results[j].Line
+	})
+
+	if readErr != nil {
+		return report, readErr
+	}
+	return report, ctx.Err()
+}
+
+// readRecords sends the non-empty lines of the stream to records until the
+// stream ends or the context is done.
+func readRecords(ctx context.Context, r io.Reader, records chan<- record) error {
+	reader := bufio.NewReader(r)
+	for line := 1; ; line++ {
+		data, err := reader.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+
+		if data = bytes.TrimSpace(data); len(data) > 0 {
+			// select picks at random when both are ready
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			select {
+			case records <- record{line: line, data: data}:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		if err == io.EOF {
+			return nil
+		}
+	}
+}
+
+func importRecord(ctx context.Context, client *goshopify.Client, resource Resource, rec record) Result {
+	result := Result{Line: rec.line}
+
+	var fields struct {
+		ID int64 `json:"id"`
+	}
+	if err := json.Unmarshal(rec.data, &fields); err != nil {
+		result.Action = Failed
+		result.Error = fmt.Sprintf("invalid record: %v", err)
+		return result
+	}
+
+	method, path := "POST", fmt.Sprintf("%s.json", resource.path)
+	result.Action = Created
+	if fields.ID != 0 {
+		method, path = "PUT", fmt.Sprintf("%s/%d.json", resource.path, fields.ID)
+		result.Action = Updated
+		result.ID = fields.ID
+	}
+
+	data := map[string]json.RawMessage{resource.singular: rec.data}
+	resp := map[string]struct {
+		ID int64 `json:"id"`
+	}{}
+	if err := client.CreateAndDoWithContext(ctx, method, path, data, nil, &resp); err != nil {
+		result.Action = Failed
+		result.Error = err.Error()
+
+		var respErr goshopify.ResponseError
+		var rateLimitErr goshopify.RateLimitError
+		if errors.As(err, &respErr) {
+			result.Status = respErr.Status
+			result.FieldErrors = fieldErrors(respErr)
+		} else if errors.As(err, &rateLimitErr) {
+			result.Status = rateLimitErr.Status
+		}
+		return result
+	}
+
+	if id := resp[resource.singular].ID; id != 0 {
+		result.ID = id
+	}
+	return result
+}
+
+// fieldErrors parses the "field: message" errors of a response, the
+// remaining ones are errors of the record as a whole.
+func fieldErrors(err goshopify.ResponseError) []FieldError {
+	var fieldErrors []FieldError
+	for _, e := range err.Errors {
+		fieldError := FieldError{Field: "base", Message: e}
+		if kv := strings.SplitN(e, ": ", 2); len(kv) == 2 {
+			fieldError = FieldError{Field: kv[0], Message: kv[1]}
+		}
+		fieldErrors = append(fieldErrors, fieldError)
+	}
+	sort.SliceStable(fieldErrors, func(i, j int) bool {
+		return fieldErrors[i].Field < fieldErrors[j].Field
+	})
+	return fieldErrors
+}
@@ -0,0 +1,147 @@
+package importer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	goshopify "github.com/myhelix/go-shopify"
+)
+
+const baseURL = "https://fooshop.myshopify.com/admin/api/2021-01"
+
+func setup() *goshopify.Client {
+	client := goshopify.NewClient(goshopify.App{}, "fooshop", "abcd", goshopify.WithVersion("2021-01"))
+	httpmock.ActivateNonDefault(client.Client)
+	return client
+}
+
+func teardown() {
+	httpmock.DeactivateAndReset()
+}
+
+// recordBodies registers a responder that records the request bodies and
+// replies with the response.
+func recordBodies(method, path string, bodies *[]string, mu *sync.Mutex, status int, response string) {
+	httpmock.RegisterResponder(method, fmt.Sprintf("%s/%s", baseURL, path),
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+			mu.Lock()
+			*bodies = append(*bodies, string(body))
+			mu.Unlock()
+			return httpmock.NewStringResponse(status, response), nil
+		})
+}
+
+func TestImport(t *testing.T) {
+	client := setup()
+	defer teardown()
+
+	var mu sync.Mutex
+	var created, updated []string
+	recordBodies("POST", "products.json", &created, &mu, 201, `{"product": {"id": 3, "title": "new"}}`)
+	recordBodies("PUT", "products/1.json", &updated, &mu, 200, `{"product": {"id": 1, "title": "foo"}}`)
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("%s/products/2.json", baseURL),
+		httpmock.NewStringResponder(422, `{"errors": {"title": ["can't be blank", "is too short"]}}`))
+
+	input := strings.Join([]string{
+		`{"id": 1, "title": "foo"}`,
+		``,
+		`{"title": "new", "tags": "a, b"}`,
+		`{"id": 2, "title": ""}`,
+		`{"id": "oops"`,
+	}, "\n")
+
+	var mutex sync.Mutex
+	var reported int
+	report, err := Import(context.Background(), client, Products, strings.NewReader(input), Options{
+		Concurrency: 2,
+		Result: func(Result) {
+			mutex.Lock()
+			reported++
+			mutex.Unlock()
+		},
+	})
+	if err != nil {
+		t.Fatalf("Import returned error: %v", err)
+	}
+
+	expected := &Report{
+		Created: 1,
+		Updated: 1,
+		Failed:  2,
+		Results: []Result{
+			{Line: 1, Action: Updated, ID: 1},
+			{Line: 3, Action: Created, ID: 3},
+			{Line: 4, Action: Failed, ID: 2, Status: 422, Error: "title: can't be blank",
+				FieldErrors: []FieldError{{Field: "title", Message: "can't be blank"}, {Field: "title", Message: "is too short"}}},
+			{Line: 5, Action: Failed, Error: "invalid record: unexpected end of JSON input"},
+		},
+	}
+	if !reflect.DeepEqual(report, expected) {
+		t.Errorf("Import returned %+v, expected %+v", report, expected)
+	}
+	if reported != 4 {
+		t.Errorf("Import reported %d results, expected 4", reported)
+	}
+	if failures := report.Failures(); len(failures) != 2 || failures[0].Line != 4 {
+		t.Errorf("Report.Failures returned %+v", failures)
+	}
+
+	if expected := []string{`{"product":{"title":"new","tags":"a, b"}}`}; !reflect.DeepEqual(created, expected) {
+		t.Errorf("Import created %v, expected %v", created, expected)
+	}
+	if expected := []string{`{"product":{"id":1,"title":"foo"}}`}; !reflect.DeepEqual(updated, expected) {
+		t.Errorf("Import updated %v, expected %v", updated, expected)
+	}
+}
+
+func TestImportCanceled(t *testing.T) {
+	client := setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/customers.json", baseURL),
+		httpmock.NewStringResponder(201, `{"customer": {"id": 1}}`))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	report, err := Import(ctx, client, Customers, strings.NewReader(`{"email": "a@example.com"}`), Options{})
+	if err != context.Canceled {
+		t.Errorf("Import returned %v, expected context.Canceled", err)
+	}
+	if report.Created != 0 {
+		t.Errorf("Import created %d customers after the context was canceled", report.Created)
+	}
+}
+
+func TestReportWriteJSON(t *testing.T) {
+	report := &Report{Created: 1, Results: []Result{{Line: 1, Action: Created, ID: 3}}}
+
+	out := new(bytes.Buffer)
+	if err := report.WriteJSON(out); err != nil {
+		t.Fatalf("Report.WriteJSON returned error: %v", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatalf("Report.WriteJSON wrote invalid JSON %s: %v", out, err)
+	}
+	expected := map[string]interface{}{
+		"created": float64(1),
+		"updated": float64(0),
+		"failed":  float64(0),
+		"results": []interface{}{map[string]interface{}{"line": float64(1), "action": "created", "id": float64(3)}},
+	}
+	if !reflect.DeepEqual(decoded, expected) {
+		t.Errorf("Report.WriteJSON wrote %+v, expected %+v", decoded, expected)
+	}
+}
@@ -0,0 +1,112 @@
+// Command genservice scaffolds a REST resource service of goshopify from a
+// small JSON spec: the resource struct, its resource wrappers, a Service
+// interface with its ServiceOp implementing the requested CRUD operations and
+// pagination, and their tests. See Spec for the format.
+//
+// It is run by go generate from the root of the repository for every spec in
+// internal/genservice/specs:
+//
+//	go generate ./...
+//
+// Existing files are never overwritten, so the generated code is a starting
+// point to edit like any other service. The new service still has to be added
+// to the Client in goshopify.go, genservice prints the lines to add.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+func main() {
+	out := flag.String("out", ".", "directory the services are written to")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: genservice [-out dir] spec.json|specdir...\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	specs, err := specPaths(flag.Args())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "genservice:", err)
+		os.Exit(1)
+	}
+
+	for _, path := range specs {
+		spec, err := loadSpec(path)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "genservice:", err)
+			os.Exit(1)
+		}
+		written, err := generate(spec, *out)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "genservice:", err)
+			os.Exit(1)
+		}
+		if len(written) > 0 {
+			fmt.Printf("wrote %s, register the service in goshopify.go:\n", strings.Join(written, " and "))
+			fmt.Printf("\t%s %sService\n", spec.Name, spec.Name)
+			fmt.Printf("\tc.%s = &%sServiceOp{client: c}\n", spec.Name, spec.Name)
+		}
+	}
+}
+
+// specPaths expands the directories among args to the .json specs they hold.
+func specPaths(args []string) ([]string, error) {
+	var paths []string
+	for _, arg := range args {
+		info, err := os.Stat(arg)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			paths = append(paths, arg)
+			continue
+		}
+		matches, err := filepath.Glob(filepath.Join(arg, "*.json"))
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(matches)
+		paths = append(paths, matches...)
+	}
+	return paths, nil
+}
+
+// generate writes the service and test files of the spec to dir, unless they
+// exist, and returns the names of the files written.
+func generate(spec *Spec, dir string) ([]string, error) {
+	files := []struct {
+		name string
+		data func() ([]byte, error)
+	}{
+		{spec.FileName() + ".go", func() ([]byte, error) { return render(serviceTemplate, spec) }},
+		{spec.FileName() + "_test.go", func() ([]byte, error) { return render(testTemplate, spec) }},
+	}
+
+	var written []string
+	for _, f := range files {
+		path := filepath.Join(dir, f.name)
+		if _, err := os.Stat(path); err == nil {
+			continue
+		}
+		data, err := f.data()
+		if err != nil {
+			return written, err
+		}
+		if err := ioutil.WriteFile(path, data, 0o644); err != nil {
+			return written, err
+		}
+		written = append(written, f.name)
+	}
+	return written, nil
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	dir := t.TempDir()
+	spec := &Spec{Name: "Country", Path: "countries", Fields: []Field{{Name: "Name", Type: "string"}}}
+	if err := spec.complete(); err != nil {
+		t.Fatalf("complete returned error: %v", err)
+	}
+
+	os.WriteFile(filepath.Join(dir, "country_test.go"), []byte("package goshopify\n"), 0o644)
+
+	written, err := generate(spec, dir)
+	if err != nil {
+		t.Fatalf("generate returned error: %v", err)
+	}
+	if expected := []string{"country.go"}; !reflect.DeepEqual(written, expected) {
+		t.Errorf("generate wrote %v, expected %v", written, expected)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "country_test.go")); string(data) != "package goshopify\n" {
+		t.Errorf("generate overwrote country_test.go with:\n%s", data)
+	}
+
+	written, err = generate(spec, dir)
+	if err != nil || len(written) != 0 {
+		t.Errorf("generate wrote %v, %v when both files exist", written, err)
+	}
+}
+
+func TestSpecPaths(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"b.json", "a.json", "notes.txt"} {
+		os.WriteFile(filepath.Join(dir, name), []byte("{}"), 0o644)
+	}
+	other := filepath.Join(t.TempDir(), "other.json")
+	os.WriteFile(other, []byte("{}"), 0o644)
+
+	paths, err := specPaths([]string{dir, other})
+	if err != nil {
+		t.Fatalf("specPaths returned error: %v", err)
+	}
+	expected := []string{filepath.Join(dir, "a.json"), filepath.Join(dir, "b.json"), other}
+	if !reflect.DeepEqual(paths, expected) {
+		t.Errorf("specPaths returned %v, expected %v", paths, expected)
+	}
+
+	if _, err := specPaths([]string{filepath.Join(dir, "missing.json")}); err == nil {
+		t.Error("specPaths returned no error for a missing spec")
+	}
+}
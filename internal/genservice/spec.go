@@ -0,0 +1,189 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// operations are the methods a spec can ask for, list adds List and
+// ListWithPagination.
+var operations = []string{"list", "count", "get", "create", "update", "delete"}
+
+var nameRegex = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+
+// Spec describes a REST resource to generate a service for.
+//
+//	{
+//		"name": "Country",
+//		"path": "countries",
+//		"doc": "https://shopify.dev/docs/api/admin-rest/latest/resources/country",
+//		"fields": [
+//			{"name": "Name", "type": "string"},
+//			{"name": "Tax", "type": "float64"}
+//		]
+//	}
+type Spec struct {
+	// Name of the resource type, e.g. "Country"
+	Name string `json:"name"`
+
+	// Path of the endpoints relative to the API version or the parent
+	// resource, e.g. "countries"
+	Path string `json:"path"`
+
+	// JSON keys wrapping a single resource and a list of them, derived from
+	// Name and Path when empty
+	Singular string `json:"singular,omitempty"`
+	Plural   string `json:"plural,omitempty"`
+
+	// Doc is the URL of the reference documentation.
+	Doc string `json:"doc,omitempty"`
+
+	// Parent nests the endpoints under a parent resource, e.g.
+	// customers/{customer_id}/addresses.
+	Parent *Parent `json:"parent,omitempty"`
+
+	// Operations to generate, all of them when empty.
+	Operations []string `json:"operations,omitempty"`
+
+	// Fields of the resource besides ID.
+	Fields []Field `json:"fields"`
+}
+
+// Parent is the resource a nested resource belongs to.
+type Parent struct {
+	// Name of the parent in lowerCamelCase, e.g. "customer" for the
+	// customerID parameter
+	Name string `json:"name"`
+	// Path of the parent's endpoints, e.g. "customers"
+	Path string `json:"path"`
+}
+
+// Field is a field of the resource.
+type Field struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	// JSON key, the snake_case Name when empty
+	JSON string `json:"json,omitempty"`
+}
+
+// loadSpec reads and validates the spec at path, filling in its defaults.
+func loadSpec(path string) (*Spec, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var spec Spec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := spec.complete(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &spec, nil
+}
+
+// complete validates the spec and fills in its defaults.
+func (s *Spec) complete() error {
+	if !nameRegex.MatchString(s.Name) {
+		return fmt.Errorf("name %q is not an exported Go identifier", s.Name)
+	}
+	s.Path = strings.Trim(s.Path, "/")
+	if s.Path == "" {
+		return fmt.Errorf("path is required")
+	}
+	if s.Parent != nil && (s.Parent.Name == "" || s.Parent.Path == "") {
+		return fmt.Errorf("parent needs a name and a path")
+	}
+
+	if s.Singular == "" {
+		s.Singular = snakeCase(s.Name)
+	}
+	if s.Plural == "" {
+		s.Plural = s.Path[strings.LastIndex(s.Path, "/")+1:]
+	}
+
+	if len(s.Operations) == 0 {
+		s.Operations = operations
+	}
+	for _, op := range s.Operations {
+		if !contains(operations, op) {
+			return fmt.Errorf("unknown operation %q, expected one of %s", op, strings.Join(operations, ", "))
+		}
+	}
+
+	for i, f := range s.Fields {
+		if !nameRegex.MatchString(f.Name) || f.Type == "" {
+			return fmt.Errorf("field %d needs an exported name and a type", i)
+		}
+		if f.Name == "ID" {
+			return fmt.Errorf("field ID is always generated")
+		}
+		if f.JSON == "" {
+			s.Fields[i].JSON = snakeCase(f.Name)
+		}
+	}
+	return nil
+}
+
+// Has reports whether the operation is generated.
+func (s *Spec) Has(op string) bool {
+	return contains(s.Operations, op)
+}
+
+// FileName is the name of the generated service file without extension.
+func (s *Spec) FileName() string {
+	return snakeCase(s.Name)
+}
+
+// snakeCase turns a Go name into snake_case, keeping initialisms together:
+// GiftCardID becomes gift_card_id.
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			lowerBefore := i > 0 && unicode.IsLower(runes[i-1])
+			lowerAfter := i > 0 && i+1 < len(runes) && unicode.IsUpper(runes[i-1]) && unicode.IsLower(runes[i+1])
+			if lowerBefore || lowerAfter {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+// lowerCamel turns a snake_case or Go name into lowerCamelCase.
+func lowerCamel(name string) string {
+	parts := strings.Split(snakeCase(name), "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] == "id" {
+			parts[i] = "ID"
+		} else if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// exported turns a lowerCamelCase name into an exported one.
+func exported(name string) string {
+	if name == "" {
+		return name
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSnakeCase(t *testing.T) {
+	cases := map[string]string{
+		"Country":            "country",
+		"GiftCardAdjustment": "gift_card_adjustment",
+		"GiftCardID":         "gift_card_id",
+		"HTMLPage":           "html_page",
+	}
+	for name, expected := range cases {
+		if actual := snakeCase(name); actual != expected {
+			t.Errorf("snakeCase(%q) = %q, expected %q", name, actual, expected)
+		}
+	}
+}
+
+func TestLowerCamel(t *testing.T) {
+	cases := map[string]string{
+		"Country":               "country",
+		"gift_card_adjustments": "giftCardAdjustments",
+		"OwnerID":               "ownerID",
+	}
+	for name, expected := range cases {
+		if actual := lowerCamel(name); actual != expected {
+			t.Errorf("lowerCamel(%q) = %q, expected %q", name, actual, expected)
+		}
+	}
+}
+
+func TestLoadSpec(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spec.json")
+	os.WriteFile(path, []byte(`{
+		"name": "GiftCardAdjustment",
+		"path": "/adjustments/",
+		"parent": {"name": "giftCard", "path": "gift_cards"},
+		"operations": ["list", "get", "create"],
+		"fields": [{"name": "Amount", "type": "string"}, {"name": "Note", "type": "string", "json": "note_text"}]
+	}`), 0o644)
+
+	spec, err := loadSpec(path)
+	if err != nil {
+		t.Fatalf("loadSpec returned error: %v", err)
+	}
+	if spec.Path != "adjustments" || spec.Singular != "gift_card_adjustment" || spec.Plural != "adjustments" {
+		t.Errorf("loadSpec returned path %q, singular %q and plural %q", spec.Path, spec.Singular, spec.Plural)
+	}
+	expected := []Field{{Name: "Amount", Type: "string", JSON: "amount"}, {Name: "Note", Type: "string", JSON: "note_text"}}
+	if !reflect.DeepEqual(spec.Fields, expected) {
+		t.Errorf("loadSpec returned fields %+v, expected %+v", spec.Fields, expected)
+	}
+	if !spec.Has("list") || spec.Has("delete") {
+		t.Errorf("loadSpec returned operations %v", spec.Operations)
+	}
+}
+
+func TestSpecInvalid(t *testing.T) {
+	cases := []Spec{
+		{Name: "country", Path: "countries"},
+		{Name: "Country"},
+		{Name: "Country", Path: "countries", Operations: []string{"archive"}},
+		{Name: "Country", Path: "countries", Fields: []Field{{Name: "ID", Type: "int64"}}},
+		{Name: "Country", Path: "countries", Fields: []Field{{Name: "Name"}}},
+		{Name: "Province", Path: "provinces", Parent: &Parent{Name: "country"}},
+	}
+	for _, c := range cases {
+		if err := c.complete(); err == nil {
+			t.Errorf("complete returned no error for %+v", c)
+		}
+	}
+}
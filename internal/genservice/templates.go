@@ -0,0 +1,357 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+	"text/template"
+)
+
+// templateData is a spec with the names derived from it.
+type templateData struct {
+	*Spec
+
+	// Var is the lowerCamelCase name, e.g. giftCardAdjustment
+	Var       string
+	PluralVar string
+	// PluralName is the exported plural, e.g. Countries
+	PluralName string
+	// Const is the base path constant, e.g. countriesBasePath
+	Const string
+	// Human and HumanPlural name the resource in comments
+	Human       string
+	HumanPlural string
+
+	// parent parameters of the interface, the methods, the calls and the
+	// tests, all empty without a parent
+	ParentIn   string
+	ParentArg  string
+	ParentPass string
+	ParentTest string
+}
+
+func newTemplateData(s *Spec) templateData {
+	d := templateData{
+		Spec:        s,
+		Var:         lowerCamel(s.Name),
+		PluralVar:   lowerCamel(s.Plural),
+		PluralName:  exported(lowerCamel(s.Plural)),
+		Const:       lowerCamel(s.Plural) + "BasePath",
+		Human:       strings.ReplaceAll(snakeCase(s.Name), "_", " "),
+		HumanPlural: strings.ReplaceAll(s.Plural, "_", " "),
+	}
+	if s.Parent != nil {
+		param := lowerCamel(s.Parent.Name) + "ID"
+		d.ParentIn = "int64, "
+		d.ParentArg = param + " int64, "
+		d.ParentPass = param + ", "
+		d.ParentTest = "1, "
+	}
+	return d
+}
+
+// CollectionPath is the Go expression of the path of the collection
+// endpoint with the suffix, e.g. "/count".
+func (d templateData) CollectionPath(suffix string) string {
+	if d.Parent == nil {
+		return fmt.Sprintf(`fmt.Sprintf("%%s%s.json", %s)`, suffix, d.Const)
+	}
+	return fmt.Sprintf(`fmt.Sprintf("%s/%%d/%%s%s.json", %s, %s)`, d.Parent.Path, suffix, lowerCamel(d.Parent.Name)+"ID", d.Const)
+}
+
+// ItemPath is the Go expression of the path of the resource with the id
+// expression.
+func (d templateData) ItemPath(id string) string {
+	if d.Parent == nil {
+		return fmt.Sprintf(`fmt.Sprintf("%%s/%%d.json", %s, %s)`, d.Const, id)
+	}
+	return fmt.Sprintf(`fmt.Sprintf("%s/%%d/%%s/%%d.json", %s, %s, %s)`, d.Parent.Path, lowerCamel(d.Parent.Name)+"ID", d.Const, id)
+}
+
+// TestPath is the path requested in the tests, with 1 as parent ID.
+func (d templateData) TestPath(suffix string) string {
+	if d.Parent == nil {
+		return d.Path + suffix + ".json"
+	}
+	return d.Parent.Path + "/1/" + d.Path + suffix + ".json"
+}
+
+var serviceTemplate = template.Must(template.New("service").Parse(`package goshopify
+
+import (
+	"fmt"
+)
+
+const {{.Const}} = "{{.Path}}"
+
+// {{.Name}}Service is an interface for interacting with the {{.HumanPlural}}
+// endpoints of the Shopify API.
+{{- if .Doc}}
+// See {{.Doc}}
+{{- end}}
+type {{.Name}}Service interface {
+{{- if .Has "list"}}
+	List({{.ParentIn}}interface{}) ([]{{.Name}}, error)
+	ListWithPagination({{.ParentIn}}interface{}) ([]{{.Name}}, *Pagination, error)
+{{- end}}
+{{- if .Has "count"}}
+	Count({{.ParentIn}}interface{}) (int, error)
+{{- end}}
+{{- if .Has "get"}}
+	Get({{.ParentIn}}int64, interface{}) (*{{.Name}}, error)
+{{- end}}
+{{- if .Has "create"}}
+	Create({{.ParentIn}}{{.Name}}) (*{{.Name}}, error)
+{{- end}}
+{{- if .Has "update"}}
+	Update({{.ParentIn}}{{.Name}}) (*{{.Name}}, error)
+{{- end}}
+{{- if .Has "delete"}}
+	Delete({{.ParentIn}}int64) error
+{{- end}}
+}
+
+// {{.Name}}ServiceOp handles communication with the {{.Human}} related methods of
+// the Shopify API.
+type {{.Name}}ServiceOp struct {
+	client *Client
+}
+
+// {{.Name}} represents a Shopify {{.Human}}.
+type {{.Name}} struct {
+	ID int64 ` + "`" + `json:"id,omitempty"` + "`" + `
+{{- range .Fields}}
+	{{.Name}} {{.Type}} ` + "`" + `json:"{{.JSON}},omitempty"` + "`" + `
+{{- end}}
+}
+
+// {{.Name}}Resource represents the result from the {{.Path}}/X.json endpoint
+type {{.Name}}Resource struct {
+	{{.Name}} *{{.Name}} ` + "`" + `json:"{{.Singular}}"` + "`" + `
+}
+
+// {{.PluralName}}Resource represents the result from the {{.Path}}.json endpoint
+type {{.PluralName}}Resource struct {
+	{{.PluralName}} []{{.Name}} ` + "`" + `json:"{{.Plural}}"` + "`" + `
+}
+{{- if .Has "list"}}
+
+// List {{.HumanPlural}}
+func (s *{{.Name}}ServiceOp) List({{.ParentArg}}options interface{}) ([]{{.Name}}, error) {
+	{{.PluralVar}}, _, err := s.ListWithPagination({{.ParentPass}}options)
+	if err != nil {
+		return nil, err
+	}
+	return {{.PluralVar}}, nil
+}
+
+// List {{.HumanPlural}} with pagination
+func (s *{{.Name}}ServiceOp) ListWithPagination({{.ParentArg}}options interface{}) ([]{{.Name}}, *Pagination, error) {
+	path := {{.CollectionPath ""}}
+	return listResourceWithPagination[{{.Name}}](s.client, path, "{{.Plural}}", options)
+}
+{{- end}}
+{{- if .Has "count"}}
+
+// Count {{.HumanPlural}}
+func (s *{{.Name}}ServiceOp) Count({{.ParentArg}}options interface{}) (int, error) {
+	path := {{.CollectionPath "/count"}}
+	return s.client.Count(path, options)
+}
+{{- end}}
+{{- if .Has "get"}}
+
+// Get individual {{.Human}}
+func (s *{{.Name}}ServiceOp) Get({{.ParentArg}}{{.Var}}ID int64, options interface{}) (*{{.Name}}, error) {
+	path := {{.ItemPath (print .Var "ID")}}
+	return getResource[{{.Name}}](s.client, path, "{{.Singular}}", options)
+}
+{{- end}}
+{{- if .Has "create"}}
+
+// Create a new {{.Human}}
+func (s *{{.Name}}ServiceOp) Create({{.ParentArg}}{{.Var}} {{.Name}}) (*{{.Name}}, error) {
+	path := {{.CollectionPath ""}}
+	return createResource(s.client, path, "{{.Singular}}", {{.Var}})
+}
+{{- end}}
+{{- if .Has "update"}}
+
+// Update an existing {{.Human}}
+func (s *{{.Name}}ServiceOp) Update({{.ParentArg}}{{.Var}} {{.Name}}) (*{{.Name}}, error) {
+	path := {{.ItemPath (print .Var ".ID")}}
+	return updateResource(s.client, path, "{{.Singular}}", {{.Var}})
+}
+{{- end}}
+{{- if .Has "delete"}}
+
+// Delete an existing {{.Human}}.
+func (s *{{.Name}}ServiceOp) Delete({{.ParentArg}}{{.Var}}ID int64) error {
+	return s.client.Delete({{.ItemPath (print .Var "ID")}})
+}
+{{- end}}
+`))
+
+var testTemplate = template.Must(template.New("test").Parse(`package goshopify
+
+import (
+	"fmt"
+{{- if .Has "list"}}
+	"net/http"
+	"reflect"
+{{- end}}
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+{{- if .Has "list"}}
+
+func Test{{.Name}}List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/{{.TestPath ""}}", client.pathPrefix),
+		httpmock.NewStringResponder(200, ` + "`" + `{"{{.Plural}}": [{"id":1},{"id":2}]}` + "`" + `))
+
+	{{.PluralVar}}, err := client.{{.Name}}.List({{.ParentTest}}nil)
+	if err != nil {
+		t.Errorf("{{.Name}}.List returned error: %v", err)
+	}
+
+	expected := []{{.Name}}{{"{{"}}ID: 1}, {ID: 2}}
+	if !reflect.DeepEqual({{.PluralVar}}, expected) {
+		t.Errorf("{{.Name}}.List returned %+v, expected %+v", {{.PluralVar}}, expected)
+	}
+}
+
+func Test{{.Name}}ListWithPagination(t *testing.T) {
+	setup()
+	defer teardown()
+
+	listURL := fmt.Sprintf("https://fooshop.myshopify.com/%s/{{.TestPath ""}}", client.pathPrefix)
+	httpmock.RegisterResponder("GET", listURL,
+		func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(200, ` + "`" + `{"{{.Plural}}": [{"id":1}]}` + "`" + `)
+			resp.Header.Set("Link", fmt.Sprintf(` + "`" + `<%s?page_info=abc&limit=1>; rel="next"` + "`" + `, listURL))
+			return resp, nil
+		})
+
+	{{.PluralVar}}, pagination, err := client.{{.Name}}.ListWithPagination({{.ParentTest}}nil)
+	if err != nil {
+		t.Fatalf("{{.Name}}.ListWithPagination returned error: %v", err)
+	}
+	if len({{.PluralVar}}) != 1 || {{.PluralVar}}[0].ID != 1 {
+		t.Errorf("{{.Name}}.ListWithPagination returned %+v", {{.PluralVar}})
+	}
+	if pagination == nil || pagination.NextPageOptions == nil || pagination.NextPageOptions.PageInfo != "abc" {
+		t.Errorf("{{.Name}}.ListWithPagination returned pagination %+v", pagination)
+	}
+}
+{{- end}}
+{{- if .Has "count"}}
+
+func Test{{.Name}}Count(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/{{.TestPath "/count"}}", client.pathPrefix),
+		httpmock.NewStringResponder(200, ` + "`" + `{"count": 3}` + "`" + `))
+
+	cnt, err := client.{{.Name}}.Count({{.ParentTest}}nil)
+	if err != nil {
+		t.Errorf("{{.Name}}.Count returned error: %v", err)
+	}
+
+	expected := 3
+	if cnt != expected {
+		t.Errorf("{{.Name}}.Count returned %d, expected %d", cnt, expected)
+	}
+}
+{{- end}}
+{{- if .Has "get"}}
+
+func Test{{.Name}}Get(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/{{.TestPath "/1"}}", client.pathPrefix),
+		httpmock.NewStringResponder(200, ` + "`" + `{"{{.Singular}}": {"id":1}}` + "`" + `))
+
+	{{.Var}}, err := client.{{.Name}}.Get({{.ParentTest}}1, nil)
+	if err != nil {
+		t.Errorf("{{.Name}}.Get returned error: %v", err)
+	}
+
+	if {{.Var}}.ID != 1 {
+		t.Errorf("{{.Name}}.Get returned %+v, expected ID 1", {{.Var}})
+	}
+}
+{{- end}}
+{{- if .Has "create"}}
+
+func Test{{.Name}}Create(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/{{.TestPath ""}}", client.pathPrefix),
+		httpmock.NewStringResponder(201, ` + "`" + `{"{{.Singular}}": {"id":1}}` + "`" + `))
+
+	{{.Var}}, err := client.{{.Name}}.Create({{.ParentTest}}{{.Name}}{})
+	if err != nil {
+		t.Errorf("{{.Name}}.Create returned error: %v", err)
+	}
+
+	if {{.Var}}.ID != 1 {
+		t.Errorf("{{.Name}}.Create returned %+v, expected ID 1", {{.Var}})
+	}
+}
+{{- end}}
+{{- if .Has "update"}}
+
+func Test{{.Name}}Update(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/{{.TestPath "/1"}}", client.pathPrefix),
+		httpmock.NewStringResponder(200, ` + "`" + `{"{{.Singular}}": {"id":1}}` + "`" + `))
+
+	{{.Var}}, err := client.{{.Name}}.Update({{.ParentTest}}{{.Name}}{ID: 1})
+	if err != nil {
+		t.Errorf("{{.Name}}.Update returned error: %v", err)
+	}
+
+	if {{.Var}}.ID != 1 {
+		t.Errorf("{{.Name}}.Update returned %+v, expected ID 1", {{.Var}})
+	}
+}
+{{- end}}
+{{- if .Has "delete"}}
+
+func Test{{.Name}}Delete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("https://fooshop.myshopify.com/%s/{{.TestPath "/1"}}", client.pathPrefix),
+		httpmock.NewStringResponder(200, "{}"))
+
+	err := client.{{.Name}}.Delete({{.ParentTest}}1)
+	if err != nil {
+		t.Errorf("{{.Name}}.Delete returned error: %v", err)
+	}
+}
+{{- end}}
+`))
+
+// render executes the template for the spec and formats the result.
+func render(tmpl *template.Template, s *Spec) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, newTemplateData(s)); err != nil {
+		return nil, err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting %s for %s: %w", tmpl.Name(), s.Name, err)
+	}
+	return src, nil
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+func TestRenderNested(t *testing.T) {
+	spec := &Spec{
+		Name:       "Province",
+		Path:       "provinces",
+		Parent:     &Parent{Name: "country", Path: "countries"},
+		Operations: []string{"list", "update"},
+		Fields:     []Field{{Name: "Name", Type: "string"}},
+	}
+	if err := spec.complete(); err != nil {
+		t.Fatalf("complete returned error: %v", err)
+	}
+
+	src, err := render(serviceTemplate, spec)
+	if err != nil {
+		t.Fatalf("render returned error: %v", err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "province.go", src, 0); err != nil {
+		t.Fatalf("the service doesn't parse: %v\n%s", err, src)
+	}
+	for _, expected := range []string{
+		"List(int64, interface{}) ([]Province, error)",
+		`fmt.Sprintf("countries/%d/%s/%d.json", countryID, provincesBasePath, province.ID)`,
+		"type ProvincesResource struct",
+	} {
+		if !strings.Contains(string(src), expected) {
+			t.Errorf("the service doesn't contain %s:\n%s", expected, src)
+		}
+	}
+	if strings.Contains(string(src), "Delete(") {
+		t.Errorf("the service has a Delete method it wasn't asked for:\n%s", src)
+	}
+
+	src, err = render(testTemplate, spec)
+	if err != nil {
+		t.Fatalf("render returned error: %v", err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "province_test.go", src, 0); err != nil {
+		t.Fatalf("the tests don't parse: %v\n%s", err, src)
+	}
+	if !strings.Contains(string(src), "countries/1/provinces.json") {
+		t.Errorf("the tests don't request the nested path:\n%s", src)
+	}
+}
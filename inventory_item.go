@@ -47,24 +47,17 @@ type InventoryItemsResource struct {
 // List inventory items
 func (s *InventoryItemServiceOp) List(options interface{}) ([]InventoryItem, error) {
 	path := fmt.Sprintf("%s.json", inventoryItemsBasePath)
-	resource := new(InventoryItemsResource)
-	err := s.client.Get(path, resource, options)
-	return resource.InventoryItems, err
+	return listResource[InventoryItem](s.client, path, "inventory_items", options)
 }
 
 // Get a inventory item
 func (s *InventoryItemServiceOp) Get(id int64, options interface{}) (*InventoryItem, error) {
 	path := fmt.Sprintf("%s/%d.json", inventoryItemsBasePath, id)
-	resource := new(InventoryItemResource)
-	err := s.client.Get(path, resource, options)
-	return resource.InventoryItem, err
+	return getResource[InventoryItem](s.client, path, "inventory_item", options)
 }
 
 // Update a inventory item
 func (s *InventoryItemServiceOp) Update(item InventoryItem) (*InventoryItem, error) {
 	path := fmt.Sprintf("%s/%d.json", inventoryItemsBasePath, item.ID)
-	wrappedData := InventoryItemResource{InventoryItem: &item}
-	resource := new(InventoryItemResource)
-	err := s.client.Put(path, wrappedData, resource)
-	return resource.InventoryItem, err
+	return updateResource(s.client, path, "inventory_item", item)
 }
@@ -0,0 +1,395 @@
+package goshopify
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const inventoryLevelsBasePath = "inventory_levels"
+
+// Names of the inventory quantities, i.e. the states stock at a location can
+// be in. Only available is reported by the REST API, the others are read
+// through GraphQL with ListQuantities.
+// See: https://shopify.dev/apps/fulfillment/inventory-management-apps#inventory-states
+const (
+	InventoryQuantityAvailable      = "available"
+	InventoryQuantityCommitted      = "committed"
+	InventoryQuantityIncoming       = "incoming"
+	InventoryQuantityOnHand         = "on_hand"
+	InventoryQuantityReserved       = "reserved"
+	InventoryQuantityDamaged        = "damaged"
+	InventoryQuantityQualityControl = "quality_control"
+	InventoryQuantitySafetyStock    = "safety_stock"
+)
+
+// InventoryQuantityNames are all quantity names, which ListQuantities reads
+// when no names are given.
+var InventoryQuantityNames = []string{
+	InventoryQuantityAvailable,
+	InventoryQuantityCommitted,
+	InventoryQuantityIncoming,
+	InventoryQuantityOnHand,
+	InventoryQuantityReserved,
+	InventoryQuantityDamaged,
+	InventoryQuantityQualityControl,
+	InventoryQuantitySafetyStock,
+}
+
+// Reasons of inventory adjustments, shown in the inventory history of the
+// admin.
+// See: https://shopify.dev/docs/apps/build/orders-fulfillment/inventory-management-apps#inventory-state-reasons
+const (
+	InventoryAdjustReasonCorrection          = "correction"
+	InventoryAdjustReasonCycleCountAvailable = "cycle_count_available"
+	InventoryAdjustReasonDamaged             = "damaged"
+	InventoryAdjustReasonMovementCreated     = "movement_created"
+	InventoryAdjustReasonMovementUpdated     = "movement_updated"
+	InventoryAdjustReasonMovementReceived    = "movement_received"
+	InventoryAdjustReasonMovementCanceled    = "movement_canceled"
+	InventoryAdjustReasonOther               = "other"
+	InventoryAdjustReasonPromotion           = "promotion"
+	InventoryAdjustReasonQualityControl      = "quality_control"
+	InventoryAdjustReasonReceived            = "received"
+	InventoryAdjustReasonReservationCreated  = "reservation_created"
+	InventoryAdjustReasonReservationDeleted  = "reservation_deleted"
+	InventoryAdjustReasonReservationUpdated  = "reservation_updated"
+	InventoryAdjustReasonRestock             = "restock"
+	InventoryAdjustReasonSafetyStock         = "safety_stock"
+	InventoryAdjustReasonShrinkage           = "shrinkage"
+)
+
+// InventoryLevelService is an interface for interacting with the inventory
+// level endpoints of the Shopify API.
+// See: https://shopify.dev/docs/admin-api/rest/reference/inventory/inventorylevel
+type InventoryLevelService interface {
+	List(interface{}) ([]InventoryLevel, error)
+	ListWithPagination(interface{}) ([]InventoryLevel, *Pagination, error)
+	ListQuantities(int64, []string) ([]InventoryLevel, error)
+	GetQuantities(int64, int64, []string) (*InventoryLevel, error)
+	AdjustQuantities(InventoryAdjustment) (*InventoryAdjustmentGroup, error)
+}
+
+// InventoryLevelServiceOp is the default implementation of the
+// InventoryLevelService interface
+type InventoryLevelServiceOp struct {
+	client *Client
+}
+
+// InventoryLevel represents the stock of an inventory item at a location.
+type InventoryLevel struct {
+	InventoryItemID   int64      `json:"inventory_item_id,omitempty"`
+	LocationID        int64      `json:"location_id,omitempty"`
+	Available         int        `json:"available"`
+	UpdatedAt         *time.Time `json:"updated_at,omitempty"`
+	AdminGraphqlAPIID string     `json:"admin_graphql_api_id,omitempty"`
+
+	// Quantities by name, e.g. InventoryQuantityCommitted, only set by
+	// ListQuantities
+	Quantities map[string]int `json:"-"`
+}
+
+// Quantity returns the quantity with the given name, falling back to the
+// Available field for InventoryQuantityAvailable on levels read through REST.
+func (l InventoryLevel) Quantity(name string) int {
+	if quantity, ok := l.Quantities[name]; ok {
+		return quantity
+	}
+	if name == InventoryQuantityAvailable {
+		return l.Available
+	}
+	return 0
+}
+
+// InventoryAdjustment changes one quantity, e.g. InventoryQuantityAvailable,
+// of inventory items at locations by the deltas of its Changes. The Reason,
+// e.g. InventoryAdjustReasonCorrection, and the ReferenceDocumentURI, e.g.
+// gid://my-app/PurchaseOrder/1, are recorded in the inventory history.
+// Adjusting any quantity but available and damaged needs a LedgerDocumentURI
+// on every change.
+type InventoryAdjustment struct {
+	Reason               string            `json:"reason"`
+	Name                 string            `json:"name"`
+	ReferenceDocumentURI string            `json:"referenceDocumentUri,omitempty"`
+	Changes              []InventoryChange `json:"changes"`
+}
+
+// InventoryChange is the change of a quantity of an inventory item at a
+// location. QuantityAfterChange and Name are only set on the changes of an
+// InventoryAdjustmentGroup, which also holds the changes Shopify made as a
+// consequence, e.g. to on_hand when available was adjusted.
+type InventoryChange struct {
+	InventoryItemID     int64  `json:"-"`
+	LocationID          int64  `json:"-"`
+	Delta               int    `json:"delta"`
+	LedgerDocumentURI   string `json:"ledgerDocumentUri,omitempty"`
+	Name                string `json:"-"`
+	QuantityAfterChange *int   `json:"-"`
+}
+
+// MarshalJSON encodes the change as an InventoryChangeInput, with the global
+// IDs of the inventory item and location.
+func (c InventoryChange) MarshalJSON() ([]byte, error) {
+	type inventoryChange InventoryChange
+	return json.Marshal(struct {
+		inventoryChange
+		InventoryItemID string `json:"inventoryItemId"`
+		LocationID      string `json:"locationId"`
+	}{
+		inventoryChange: inventoryChange(c),
+		InventoryItemID: inventoryItemGID(c.InventoryItemID),
+		LocationID:      locationGID(c.LocationID),
+	})
+}
+
+// InventoryAdjustmentGroup is the record of an adjustment in the inventory
+// history.
+type InventoryAdjustmentGroup struct {
+	ID                   string            `json:"id"`
+	CreatedAt            *time.Time        `json:"createdAt"`
+	Reason               string            `json:"reason"`
+	ReferenceDocumentURI string            `json:"referenceDocumentUri"`
+	Changes              []InventoryChange `json:"-"`
+}
+
+// InventoryLevelListOptions filters inventory levels, at least one inventory
+// item or location ID is required.
+type InventoryLevelListOptions struct {
+	ListOptions
+	InventoryItemIDs []int64 `url:"inventory_item_ids,omitempty,comma"`
+	LocationIDs      []int64 `url:"location_ids,omitempty,comma"`
+}
+
+// InventoryLevelsResource is used for handling multiple level responses
+type InventoryLevelsResource struct {
+	InventoryLevels []InventoryLevel `json:"inventory_levels"`
+}
+
+// List inventory levels
+func (s *InventoryLevelServiceOp) List(options interface{}) ([]InventoryLevel, error) {
+	levels, _, err := s.ListWithPagination(options)
+	return levels, err
+}
+
+// ListWithPagination lists inventory levels and returns pagination to
+// retrieve the next/previous page.
+func (s *InventoryLevelServiceOp) ListWithPagination(options interface{}) ([]InventoryLevel, *Pagination, error) {
+	path := fmt.Sprintf("%s.json", inventoryLevelsBasePath)
+	return listResourceWithPagination[InventoryLevel](s.client, path, "inventory_levels", options)
+}
+
+func inventoryItemGID(inventoryItemID int64) string {
+	return fmt.Sprintf("gid://shopify/InventoryItem/%d", inventoryItemID)
+}
+
+func locationGID(locationID int64) string {
+	return fmt.Sprintf("gid://shopify/Location/%d", locationID)
+}
+
+const inventoryLevelQuantitiesQuery = `query($id: ID!, $names: [String!]!, $after: String) {
+  inventoryItem(id: $id) {
+    inventoryLevels(first: 50, after: $after) {
+      edges {
+        node {
+          id
+          updatedAt
+          location { id }
+          quantities(names: $names) { name quantity }
+        }
+      }
+      pageInfo { hasNextPage endCursor }
+    }
+  }
+}`
+
+// ListQuantities reads the named quantities, e.g. committed and on_hand, of
+// an inventory item at every location it is stocked at through GraphQL. All
+// quantities are read when names is empty.
+func (s *InventoryLevelServiceOp) ListQuantities(inventoryItemID int64, names []string) ([]InventoryLevel, error) {
+	if len(names) == 0 {
+		names = InventoryQuantityNames
+	}
+
+	var levels []InventoryLevel
+	var after *string
+	for {
+		vars := map[string]interface{}{
+			"id":    inventoryItemGID(inventoryItemID),
+			"names": names,
+			"after": after,
+		}
+		resp := struct {
+			InventoryItem *struct {
+				InventoryLevels struct {
+					Edges []struct {
+						Node struct {
+							ID        string     `json:"id"`
+							UpdatedAt *time.Time `json:"updatedAt"`
+							Location  struct {
+								ID string `json:"id"`
+							} `json:"location"`
+							Quantities []struct {
+								Name     string `json:"name"`
+								Quantity int    `json:"quantity"`
+							} `json:"quantities"`
+						} `json:"node"`
+					} `json:"edges"`
+					PageInfo graphQLPageInfo `json:"pageInfo"`
+				} `json:"inventoryLevels"`
+			} `json:"inventoryItem"`
+		}{}
+
+		err := s.client.GraphQL.Query(inventoryLevelQuantitiesQuery, vars, &resp)
+		if err != nil || resp.InventoryItem == nil {
+			return levels, err
+		}
+
+		for _, edge := range resp.InventoryItem.InventoryLevels.Edges {
+			level := InventoryLevel{
+				InventoryItemID:   inventoryItemID,
+				LocationID:        gidToID(edge.Node.Location.ID),
+				UpdatedAt:         edge.Node.UpdatedAt,
+				AdminGraphqlAPIID: edge.Node.ID,
+				Quantities:        make(map[string]int, len(edge.Node.Quantities)),
+			}
+			for _, q := range edge.Node.Quantities {
+				level.Quantities[q.Name] = q.Quantity
+			}
+			level.Available = level.Quantities[InventoryQuantityAvailable]
+			levels = append(levels, level)
+		}
+
+		pageInfo := resp.InventoryItem.InventoryLevels.PageInfo
+		if !pageInfo.HasNextPage {
+			return levels, nil
+		}
+		after = &pageInfo.EndCursor
+	}
+}
+
+const inventoryLevelQuery = `query($id: ID!, $locationId: ID!, $names: [String!]!) {
+  inventoryItem(id: $id) {
+    inventoryLevel(locationId: $locationId) {
+      id
+      updatedAt
+      quantities(names: $names) { name quantity }
+    }
+  }
+}`
+
+// GetQuantities reads the named quantities of an inventory item at a
+// location through GraphQL, all of them when names is empty. It returns nil if
+// the item isn't stocked at the location.
+func (s *InventoryLevelServiceOp) GetQuantities(inventoryItemID, locationID int64, names []string) (*InventoryLevel, error) {
+	if len(names) == 0 {
+		names = InventoryQuantityNames
+	}
+
+	vars := map[string]interface{}{
+		"id":         inventoryItemGID(inventoryItemID),
+		"locationId": locationGID(locationID),
+		"names":      names,
+	}
+	resp := struct {
+		InventoryItem *struct {
+			InventoryLevel *struct {
+				ID         string     `json:"id"`
+				UpdatedAt  *time.Time `json:"updatedAt"`
+				Quantities []struct {
+					Name     string `json:"name"`
+					Quantity int    `json:"quantity"`
+				} `json:"quantities"`
+			} `json:"inventoryLevel"`
+		} `json:"inventoryItem"`
+	}{}
+
+	err := s.client.GraphQL.Query(inventoryLevelQuery, vars, &resp)
+	if err != nil || resp.InventoryItem == nil || resp.InventoryItem.InventoryLevel == nil {
+		return nil, err
+	}
+
+	node := resp.InventoryItem.InventoryLevel
+	level := &InventoryLevel{
+		InventoryItemID:   inventoryItemID,
+		LocationID:        locationID,
+		UpdatedAt:         node.UpdatedAt,
+		AdminGraphqlAPIID: node.ID,
+		Quantities:        make(map[string]int, len(node.Quantities)),
+	}
+	for _, q := range node.Quantities {
+		level.Quantities[q.Name] = q.Quantity
+	}
+	level.Available = level.Quantities[InventoryQuantityAvailable]
+	return level, nil
+}
+
+const inventoryAdjustQuantitiesMutation = `mutation($input: InventoryAdjustQuantitiesInput!) {
+  inventoryAdjustQuantities(input: $input) {
+    inventoryAdjustmentGroup {
+      id
+      createdAt
+      reason
+      referenceDocumentUri
+      changes {
+        name
+        delta
+        quantityAfterChange
+        ledgerDocumentUri
+        item { id }
+        location { id }
+      }
+    }
+    userErrors { field message }
+  }
+}`
+
+// AdjustQuantities adjusts a quantity of inventory items at locations by
+// deltas through the inventoryAdjustQuantities mutation and returns the
+// adjustment recorded.
+func (s *InventoryLevelServiceOp) AdjustQuantities(adjustment InventoryAdjustment) (*InventoryAdjustmentGroup, error) {
+	resp := struct {
+		InventoryAdjustQuantities struct {
+			InventoryAdjustmentGroup *struct {
+				InventoryAdjustmentGroup
+				Changes []struct {
+					Name                string `json:"name"`
+					Delta               int    `json:"delta"`
+					QuantityAfterChange *int   `json:"quantityAfterChange"`
+					LedgerDocumentURI   string `json:"ledgerDocumentUri"`
+					Item                struct {
+						ID string `json:"id"`
+					} `json:"item"`
+					Location struct {
+						ID string `json:"id"`
+					} `json:"location"`
+				} `json:"changes"`
+			} `json:"inventoryAdjustmentGroup"`
+			UserErrors []UserError `json:"userErrors"`
+		} `json:"inventoryAdjustQuantities"`
+	}{}
+
+	err := s.client.GraphQL.Query(inventoryAdjustQuantitiesMutation, map[string]interface{}{"input": adjustment}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if err := userErrorsToError(resp.InventoryAdjustQuantities.UserErrors); err != nil {
+		return nil, err
+	}
+
+	node := resp.InventoryAdjustQuantities.InventoryAdjustmentGroup
+	if node == nil {
+		return nil, nil
+	}
+	group := node.InventoryAdjustmentGroup
+	for _, change := range node.Changes {
+		group.Changes = append(group.Changes, InventoryChange{
+			InventoryItemID:     gidToID(change.Item.ID),
+			LocationID:          gidToID(change.Location.ID),
+			Delta:               change.Delta,
+			LedgerDocumentURI:   change.LedgerDocumentURI,
+			Name:                change.Name,
+			QuantityAfterChange: change.QuantityAfterChange,
+		})
+	}
+	return &group, nil
+}
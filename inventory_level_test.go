@@ -0,0 +1,209 @@
+package goshopify
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestInventoryLevelList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	params := map[string]string{"inventory_item_ids": "808950810,39072856"}
+	httpmock.RegisterResponderWithQuery("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/inventory_levels.json", client.pathPrefix),
+		params, httpmock.NewBytesResponder(200, loadFixture("inventory_levels.json")))
+
+	options := InventoryLevelListOptions{InventoryItemIDs: []int64{808950810, 39072856}}
+	levels, err := client.InventoryLevel.List(options)
+	if err != nil {
+		t.Errorf("InventoryLevel.List returned error: %v", err)
+	}
+
+	if len(levels) != 2 {
+		t.Fatalf("InventoryLevel.List returned %d levels, expected 2", len(levels))
+	}
+	if levels[0].InventoryItemID != 808950810 || levels[0].LocationID != 487838322 || levels[0].Available != 9 {
+		t.Errorf("InventoryLevel.List returned %+v", levels[0])
+	}
+	if levels[1].Quantity(InventoryQuantityAvailable) != 27 {
+		t.Errorf("InventoryLevel.Quantity(available) returned %d, expected 27", levels[1].Quantity(InventoryQuantityAvailable))
+	}
+}
+
+func TestInventoryLevelListQuantities(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []map[string]interface{}
+	pages := []string{
+		`{"data":{"inventoryItem":{"inventoryLevels":{"edges":[{"node":{"id":"gid://shopify/InventoryLevel/1?inventory_item_id=808950810","location":{"id":"gid://shopify/Location/487838322"},"quantities":[{"name":"available","quantity":9},{"name":"committed","quantity":2},{"name":"on_hand","quantity":11}]}}],"pageInfo":{"hasNextPage":true,"endCursor":"abc"}}}}}`,
+		`{"data":{"inventoryItem":{"inventoryLevels":{"edges":[{"node":{"id":"gid://shopify/InventoryLevel/2?inventory_item_id=808950810","location":{"id":"gid://shopify/Location/905684977"},"quantities":[{"name":"available","quantity":0},{"name":"committed","quantity":1},{"name":"on_hand","quantity":1}]}}],"pageInfo":{"hasNextPage":false,"endCursor":"def"}}}}}`,
+	}
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			body := struct {
+				Variables map[string]interface{} `json:"variables"`
+			}{}
+			_ = json.NewDecoder(req.Body).Decode(&body)
+			sent = append(sent, body.Variables)
+			return httpmock.NewStringResponse(200, pages[len(sent)-1]), nil
+		})
+
+	names := []string{InventoryQuantityAvailable, InventoryQuantityCommitted, InventoryQuantityOnHand}
+	levels, err := client.InventoryLevel.ListQuantities(808950810, names)
+	if err != nil {
+		t.Errorf("InventoryLevel.ListQuantities returned error: %v", err)
+	}
+
+	expected := []InventoryLevel{
+		{
+			InventoryItemID:   808950810,
+			LocationID:        487838322,
+			Available:         9,
+			AdminGraphqlAPIID: "gid://shopify/InventoryLevel/1?inventory_item_id=808950810",
+			Quantities:        map[string]int{"available": 9, "committed": 2, "on_hand": 11},
+		},
+		{
+			InventoryItemID:   808950810,
+			LocationID:        905684977,
+			Available:         0,
+			AdminGraphqlAPIID: "gid://shopify/InventoryLevel/2?inventory_item_id=808950810",
+			Quantities:        map[string]int{"available": 0, "committed": 1, "on_hand": 1},
+		},
+	}
+	if !reflect.DeepEqual(levels, expected) {
+		t.Errorf("InventoryLevel.ListQuantities returned %+v, expected %+v", levels, expected)
+	}
+
+	if len(sent) != 2 || sent[0]["id"] != "gid://shopify/InventoryItem/808950810" || sent[0]["after"] != nil || sent[1]["after"] != "abc" {
+		t.Errorf("InventoryLevel.ListQuantities sent variables %+v", sent)
+	}
+	if levels[0].Quantity(InventoryQuantityCommitted) != 2 || levels[0].Quantity(InventoryQuantityIncoming) != 0 {
+		t.Errorf("InventoryLevel.Quantity returned unexpected quantities for %+v", levels[0])
+	}
+}
+
+func TestInventoryLevelListQuantitiesAllNames(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var names []interface{}
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			body := struct {
+				Variables map[string]interface{} `json:"variables"`
+			}{}
+			_ = json.NewDecoder(req.Body).Decode(&body)
+			names, _ = body.Variables["names"].([]interface{})
+			return httpmock.NewStringResponse(200, `{"data":{"inventoryItem":null}}`), nil
+		})
+
+	levels, err := client.InventoryLevel.ListQuantities(1, nil)
+	if err != nil || levels != nil {
+		t.Errorf("InventoryLevel.ListQuantities returned %+v, %v, expected nil, nil", levels, err)
+	}
+	if len(names) != len(InventoryQuantityNames) {
+		t.Errorf("InventoryLevel.ListQuantities requested %v, expected all quantity names", names)
+	}
+}
+
+func TestInventoryLevelGetQuantities(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests,
+			`{"data":{"inventoryItem":{"inventoryLevel":{"id":"gid://shopify/InventoryLevel/1?inventory_item_id=808950810","quantities":[{"name":"available","quantity":9},{"name":"incoming","quantity":20},{"name":"reserved","quantity":1}]}}}}`,
+			`{"data":{"inventoryItem":{"inventoryLevel":null}}}`,
+		))
+
+	names := []string{InventoryQuantityAvailable, InventoryQuantityIncoming, InventoryQuantityReserved}
+	level, err := client.InventoryLevel.GetQuantities(808950810, 487838322, names)
+	if err != nil {
+		t.Fatalf("InventoryLevel.GetQuantities returned error: %v", err)
+	}
+	if level == nil || level.LocationID != 487838322 || level.Available != 9 ||
+		level.Quantity(InventoryQuantityIncoming) != 20 || level.Quantity(InventoryQuantityReserved) != 1 {
+		t.Errorf("InventoryLevel.GetQuantities returned %+v", level)
+	}
+
+	expectedVars := map[string]interface{}{
+		"id":         "gid://shopify/InventoryItem/808950810",
+		"locationId": "gid://shopify/Location/487838322",
+		"names":      []interface{}{"available", "incoming", "reserved"},
+	}
+	if !reflect.DeepEqual(requests[0].Variables, expectedVars) {
+		t.Errorf("InventoryLevel.GetQuantities sent %+v, expected %+v", requests[0].Variables, expectedVars)
+	}
+
+	level, err = client.InventoryLevel.GetQuantities(808950810, 1, nil)
+	if err != nil || level != nil {
+		t.Errorf("InventoryLevel.GetQuantities at a location without stock returned %+v, %v", level, err)
+	}
+}
+
+func TestInventoryLevelAdjustQuantities(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests,
+			`{"data":{"inventoryAdjustQuantities":{"inventoryAdjustmentGroup":{"id":"gid://shopify/InventoryAdjustmentGroup/1","createdAt":"2023-06-01T12:00:00Z","reason":"received","referenceDocumentUri":"gid://my-app/PurchaseOrder/7","changes":[
+				{"name":"available","delta":5,"quantityAfterChange":null,"ledgerDocumentUri":null,"item":{"id":"gid://shopify/InventoryItem/808950810"},"location":{"id":"gid://shopify/Location/487838322"}},
+				{"name":"on_hand","delta":5,"quantityAfterChange":16,"ledgerDocumentUri":null,"item":{"id":"gid://shopify/InventoryItem/808950810"},"location":{"id":"gid://shopify/Location/487838322"}}
+			]},"userErrors":[]}}}`,
+			`{"data":{"inventoryAdjustQuantities":{"inventoryAdjustmentGroup":null,"userErrors":[{"field":["input","changes","0","ledgerDocumentUri"],"message":"A ledger document URI is required"}]}}}`,
+		))
+
+	group, err := client.InventoryLevel.AdjustQuantities(InventoryAdjustment{
+		Reason:               InventoryAdjustReasonReceived,
+		Name:                 InventoryQuantityAvailable,
+		ReferenceDocumentURI: "gid://my-app/PurchaseOrder/7",
+		Changes:              []InventoryChange{{InventoryItemID: 808950810, LocationID: 487838322, Delta: 5}},
+	})
+	if err != nil {
+		t.Fatalf("InventoryLevel.AdjustQuantities returned error: %v", err)
+	}
+	if group == nil || group.Reason != InventoryAdjustReasonReceived || len(group.Changes) != 2 {
+		t.Fatalf("InventoryLevel.AdjustQuantities returned %+v", group)
+	}
+	onHand := group.Changes[1]
+	if onHand.Name != InventoryQuantityOnHand || onHand.InventoryItemID != 808950810 || onHand.LocationID != 487838322 ||
+		onHand.QuantityAfterChange == nil || *onHand.QuantityAfterChange != 16 {
+		t.Errorf("InventoryLevel.AdjustQuantities returned change %+v", onHand)
+	}
+
+	expectedVars := map[string]interface{}{
+		"input": map[string]interface{}{
+			"reason":               "received",
+			"name":                 "available",
+			"referenceDocumentUri": "gid://my-app/PurchaseOrder/7",
+			"changes": []interface{}{
+				map[string]interface{}{
+					"delta":           float64(5),
+					"inventoryItemId": "gid://shopify/InventoryItem/808950810",
+					"locationId":      "gid://shopify/Location/487838322",
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(requests[0].Variables, expectedVars) {
+		t.Errorf("InventoryLevel.AdjustQuantities sent %+v, expected %+v", requests[0].Variables, expectedVars)
+	}
+
+	_, err = client.InventoryLevel.AdjustQuantities(InventoryAdjustment{
+		Reason:  InventoryAdjustReasonMovementCreated,
+		Name:    InventoryQuantityIncoming,
+		Changes: []InventoryChange{{InventoryItemID: 808950810, LocationID: 487838322, Delta: 10}},
+	})
+	if err == nil {
+		t.Error("InventoryLevel.AdjustQuantities expected the user error")
+	}
+}
@@ -0,0 +1,105 @@
+package goshopify
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxLimit is the largest page size Shopify accepts for a listing.
+const maxLimit = 250
+
+// OptionsError is returned by NewRequest, and so by every call taking
+// options, for options Shopify would reject with a 400, before the request
+// is made. Option is the name of the query parameter that is wrong.
+type OptionsError struct {
+	Option  string
+	Message string
+}
+
+func (e OptionsError) Error() string {
+	return fmt.Sprintf("invalid option %s: %s", e.Option, e.Message)
+}
+
+// pageInfoOptions are the only options Shopify accepts along with page_info,
+// the filters of a listing are carried by the cursor.
+var pageInfoOptions = map[string]bool{
+	"page_info": true,
+	"limit":     true,
+	"fields":    true,
+}
+
+// validateOptions checks the encoded options of a request: a limit between 1
+// and 250, no filters with page_info and RFC 3339 dates for the *_at_min and
+// *_at_max filters, with the min not after the max.
+func validateOptions(values url.Values) error {
+	if limit := values.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 1 || n > maxLimit {
+			return OptionsError{Option: "limit", Message: fmt.Sprintf("%q is not between 1 and %d", limit, maxLimit)}
+		}
+	}
+
+	if values.Get("page_info") != "" {
+		var filters []string
+		for k := range values {
+			if !pageInfoOptions[k] {
+				filters = append(filters, k)
+			}
+		}
+		if len(filters) > 0 {
+			sort.Strings(filters)
+			return OptionsError{
+				Option:  "page_info",
+				Message: fmt.Sprintf("cannot be combined with %s, only limit and fields are allowed on a cursor page", strings.Join(filters, ", ")),
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if !strings.HasSuffix(k, "_at_min") {
+			continue
+		}
+		min, err := parseDateOption(k, values.Get(k))
+		if err != nil {
+			return err
+		}
+		maxKey := strings.TrimSuffix(k, "_min") + "_max"
+		if values.Get(maxKey) == "" {
+			continue
+		}
+		max, err := parseDateOption(maxKey, values.Get(maxKey))
+		if err != nil {
+			return err
+		}
+		if min.After(max) {
+			return OptionsError{Option: k, Message: fmt.Sprintf("%s is after %s %s", values.Get(k), maxKey, values.Get(maxKey))}
+		}
+	}
+	for _, k := range keys {
+		if strings.HasSuffix(k, "_at_max") {
+			if _, err := parseDateOption(k, values.Get(k)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// parseDateOption parses the value of a date filter, which Shopify takes as
+// an ISO 8601 date and time, e.g. 2014-04-25T16:15:47-04:00.
+func parseDateOption(option, value string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return t, OptionsError{Option: option, Message: fmt.Sprintf("%q is not a date like 2014-04-25T16:15:47-04:00", value)}
+	}
+	return t, nil
+}
@@ -0,0 +1,86 @@
+package goshopify
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestValidateOptions(t *testing.T) {
+	cases := []struct {
+		values   url.Values
+		expected error
+	}{
+		{url.Values{}, nil},
+		{url.Values{"limit": {"250"}, "vendor": {"Acme"}}, nil},
+		{url.Values{"page_info": {"abc"}, "limit": {"50"}, "fields": {"id"}}, nil},
+		{url.Values{"created_at_min": {"2023-01-01T00:00:00Z"}, "created_at_max": {"2023-02-01T00:00:00-05:00"}}, nil},
+		{url.Values{"processed_at_max": {"2023-02-01T00:00:00Z"}}, nil},
+		{
+			url.Values{"limit": {"251"}},
+			OptionsError{Option: "limit", Message: `"251" is not between 1 and 250`},
+		},
+		{
+			url.Values{"limit": {"-1"}},
+			OptionsError{Option: "limit", Message: `"-1" is not between 1 and 250`},
+		},
+		{
+			url.Values{"page_info": {"abc"}, "status": {"any"}, "created_at_min": {"2023-01-01T00:00:00Z"}},
+			OptionsError{Option: "page_info", Message: "cannot be combined with created_at_min, status, only limit and fields are allowed on a cursor page"},
+		},
+		{
+			url.Values{"updated_at_min": {"2023-01-01"}},
+			OptionsError{Option: "updated_at_min", Message: `"2023-01-01" is not a date like 2014-04-25T16:15:47-04:00`},
+		},
+		{
+			url.Values{"published_at_max": {"yesterday"}},
+			OptionsError{Option: "published_at_max", Message: `"yesterday" is not a date like 2014-04-25T16:15:47-04:00`},
+		},
+		{
+			url.Values{"created_at_min": {"2023-02-01T00:00:00Z"}, "created_at_max": {"2023-01-01T00:00:00Z"}},
+			OptionsError{Option: "created_at_min", Message: "2023-02-01T00:00:00Z is after created_at_max 2023-01-01T00:00:00Z"},
+		},
+	}
+
+	for _, c := range cases {
+		err := validateOptions(c.values)
+		if !reflect.DeepEqual(err, c.expected) {
+			t.Errorf("validateOptions(%v) returned %v, expected %v", c.values, err, c.expected)
+		}
+	}
+}
+
+func TestListOptionsValidatedBeforeRequest(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var optionsErr OptionsError
+
+	_, err := client.Product.List(&ProductListOptions{ListOptions: ListOptions{Limit: 500}})
+	if !errors.As(err, &optionsErr) || optionsErr.Option != "limit" {
+		t.Errorf("Product.List returned error %v, expected an invalid limit", err)
+	}
+
+	_, err = client.Product.List(&ProductListOptions{ListOptions: ListOptions{PageInfo: "abc"}, ProductType: "shoes"})
+	if !errors.As(err, &optionsErr) || optionsErr.Option != "page_info" {
+		t.Errorf("Product.List returned error %v, expected filters with page_info", err)
+	}
+
+	_, err = client.Order.List(&OrderListOptions{
+		ListOptions: ListOptions{
+			CreatedAtMin: time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC),
+			CreatedAtMax: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	})
+	if !errors.As(err, &optionsErr) || optionsErr.Option != "created_at_min" {
+		t.Errorf("Order.List returned error %v, expected an invalid date range", err)
+	}
+
+	if count := httpmock.GetTotalCallCount(); count != 0 {
+		t.Errorf("invalid options made %d requests, expected none", count)
+	}
+}
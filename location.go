@@ -5,7 +5,10 @@ import (
 	"time"
 )
 
-const locationsBasePath = "locations"
+const (
+	locationsBasePath     = "locations"
+	locationsResourceName = "locations"
+)
 
 // LocationService is an interface for interfacing with the location endpoints
 // of the Shopify API.
@@ -13,10 +16,19 @@ const locationsBasePath = "locations"
 type LocationService interface {
 	// Retrieves a list of locations
 	List(options interface{}) ([]Location, error)
+	// Retrieves a page of locations along with the options of the next and previous pages
+	ListWithPagination(options interface{}) ([]Location, *Pagination, error)
 	// Retrieves a single location by its ID
 	Get(ID int64, options interface{}) (*Location, error)
 	// Retrieves a count of locations
 	Count(options interface{}) (int, error)
+	// Retrieves the inventory levels of a location
+	ListInventoryLevels(ID int64, options interface{}) ([]InventoryLevel, error)
+	// Retrieves a page of the inventory levels of a location along with the options of the next and previous pages
+	ListInventoryLevelsWithPagination(ID int64, options interface{}) ([]InventoryLevel, *Pagination, error)
+
+	// MetafieldsService used for Location resource to communicate with Metafields resource
+	MetafieldsService
 }
 
 type Location struct {
@@ -82,16 +94,17 @@ type LocationServiceOp struct {
 
 func (s *LocationServiceOp) List(options interface{}) ([]Location, error) {
 	path := fmt.Sprintf("%s.json", locationsBasePath)
-	resource := new(LocationsResource)
-	err := s.client.Get(path, resource, options)
-	return resource.Locations, err
+	return listResource[Location](s.client, path, "locations", options)
+}
+
+func (s *LocationServiceOp) ListWithPagination(options interface{}) ([]Location, *Pagination, error) {
+	path := fmt.Sprintf("%s.json", locationsBasePath)
+	return listResourceWithPagination[Location](s.client, path, "locations", options)
 }
 
 func (s *LocationServiceOp) Get(ID int64, options interface{}) (*Location, error) {
 	path := fmt.Sprintf("%s/%d.json", locationsBasePath, ID)
-	resource := new(LocationResource)
-	err := s.client.Get(path, resource, options)
-	return resource.Location, err
+	return getResource[Location](s.client, path, "location", options)
 }
 
 func (s *LocationServiceOp) Count(options interface{}) (int, error) {
@@ -99,6 +112,19 @@ func (s *LocationServiceOp) Count(options interface{}) (int, error) {
 	return s.client.Count(path, options)
 }
 
+func (s *LocationServiceOp) ListInventoryLevels(ID int64, options interface{}) ([]InventoryLevel, error) {
+	levels, _, err := s.ListInventoryLevelsWithPagination(ID, options)
+	if err != nil {
+		return nil, err
+	}
+	return levels, nil
+}
+
+func (s *LocationServiceOp) ListInventoryLevelsWithPagination(ID int64, options interface{}) ([]InventoryLevel, *Pagination, error) {
+	path := fmt.Sprintf("%s/%d/%s.json", locationsBasePath, ID, inventoryLevelsBasePath)
+	return listResourceWithPagination[InventoryLevel](s.client, path, "inventory_levels", options)
+}
+
 // Represents the result from the locations/X.json endpoint
 type LocationResource struct {
 	Location *Location `json:"location"`
@@ -108,3 +134,39 @@ type LocationResource struct {
 type LocationsResource struct {
 	Locations []Location `json:"locations"`
 }
+
+// List metafields for a location
+func (s *LocationServiceOp) ListMetafields(locationID int64, options interface{}) ([]Metafield, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: locationsResourceName, resourceID: locationID}
+	return metafieldService.List(options)
+}
+
+// Count metafields for a location
+func (s *LocationServiceOp) CountMetafields(locationID int64, options interface{}) (int, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: locationsResourceName, resourceID: locationID}
+	return metafieldService.Count(options)
+}
+
+// Get individual metafield for a location
+func (s *LocationServiceOp) GetMetafield(locationID int64, metafieldID int64, options interface{}) (*Metafield, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: locationsResourceName, resourceID: locationID}
+	return metafieldService.Get(metafieldID, options)
+}
+
+// Create a new metafield for a location
+func (s *LocationServiceOp) CreateMetafield(locationID int64, metafield Metafield) (*Metafield, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: locationsResourceName, resourceID: locationID}
+	return metafieldService.Create(metafield)
+}
+
+// Update an existing metafield for a location
+func (s *LocationServiceOp) UpdateMetafield(locationID int64, metafield Metafield) (*Metafield, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: locationsResourceName, resourceID: locationID}
+	return metafieldService.Update(metafield)
+}
+
+// Delete an existing metafield for a location
+func (s *LocationServiceOp) DeleteMetafield(locationID int64, metafieldID int64) error {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: locationsResourceName, resourceID: locationID}
+	return metafieldService.Delete(metafieldID)
+}
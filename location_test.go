@@ -102,3 +102,177 @@ func TestLocationServiceOp_Count(t *testing.T) {
 		t.Errorf("Location.Count returned %d, expected %d", cnt, expected)
 	}
 }
+
+func TestLocationServiceOp_ListWithPagination(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/locations.json", client.pathPrefix),
+		createResponderWithHeaders(200, string(loadFixture("locations.json")), map[string]string{
+			"Link": `<https://fooshop.myshopify.com/admin/api/2020-01/locations.json?page_info=abc&limit=1>; rel="next"`,
+		}))
+
+	locations, pagination, err := client.Location.ListWithPagination(ListOptions{Limit: 1})
+	if err != nil {
+		t.Fatalf("Location.ListWithPagination returned error: %v", err)
+	}
+
+	if len(locations) != 1 || locations[0].ID != 4688969785 {
+		t.Errorf("Location.ListWithPagination returned %+v", locations)
+	}
+
+	expected := &ListOptions{PageInfo: "abc", Limit: 1}
+	if pagination == nil || !reflect.DeepEqual(pagination.NextPageOptions, expected) {
+		t.Errorf("Location.ListWithPagination returned pagination %+v, expected next page %+v", pagination, expected)
+	}
+}
+
+func TestLocationServiceOp_ListInventoryLevels(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/locations/487838322/inventory_levels.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("inventory_levels.json")))
+
+	levels, err := client.Location.ListInventoryLevels(487838322, nil)
+	if err != nil {
+		t.Fatalf("Location.ListInventoryLevels returned error: %v", err)
+	}
+
+	if len(levels) != 2 {
+		t.Fatalf("Location.ListInventoryLevels returned %d levels, expected 2", len(levels))
+	}
+	if levels[1].InventoryItemID != 39072856 || levels[1].LocationID != 487838322 || levels[1].Available != 27 {
+		t.Errorf("Location.ListInventoryLevels returned %+v", levels[1])
+	}
+}
+
+func TestLocationListMetafields(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/locations/1/metafields.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"metafields": [{"id":1},{"id":2}]}`))
+
+	metafields, err := client.Location.ListMetafields(1, nil)
+	if err != nil {
+		t.Errorf("Location.ListMetafields() returned error: %v", err)
+	}
+
+	expected := []Metafield{{ID: 1}, {ID: 2}}
+	if !reflect.DeepEqual(metafields, expected) {
+		t.Errorf("Location.ListMetafields() returned %+v, expected %+v", metafields, expected)
+	}
+}
+
+func TestLocationCountMetafields(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/locations/1/metafields/count.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"count": 3}`))
+
+	params := map[string]string{"created_at_min": "2016-01-01T00:00:00Z"}
+	httpmock.RegisterResponderWithQuery(
+		"GET",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/locations/1/metafields/count.json", client.pathPrefix),
+		params,
+		httpmock.NewStringResponder(200, `{"count": 2}`))
+
+	cnt, err := client.Location.CountMetafields(1, nil)
+	if err != nil {
+		t.Errorf("Location.CountMetafields() returned error: %v", err)
+	}
+
+	expected := 3
+	if cnt != expected {
+		t.Errorf("Location.CountMetafields() returned %d, expected %d", cnt, expected)
+	}
+
+	date := time.Date(2016, time.January, 1, 0, 0, 0, 0, time.UTC)
+	cnt, err = client.Location.CountMetafields(1, CountOptions{CreatedAtMin: date})
+	if err != nil {
+		t.Errorf("Location.CountMetafields() returned error: %v", err)
+	}
+
+	expected = 2
+	if cnt != expected {
+		t.Errorf("Location.CountMetafields() returned %d, expected %d", cnt, expected)
+	}
+}
+
+func TestLocationGetMetafield(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/locations/1/metafields/2.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"metafield": {"id":2}}`))
+
+	metafield, err := client.Location.GetMetafield(1, 2, nil)
+	if err != nil {
+		t.Errorf("Location.GetMetafield() returned error: %v", err)
+	}
+
+	expected := &Metafield{ID: 2}
+	if !reflect.DeepEqual(metafield, expected) {
+		t.Errorf("Location.GetMetafield() returned %+v, expected %+v", metafield, expected)
+	}
+}
+
+func TestLocationCreateMetafield(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/locations/1/metafields.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("metafield.json")))
+
+	metafield := Metafield{
+		Key:       "app_key",
+		Value:     "app_value",
+		ValueType: "string",
+		Namespace: "affiliates",
+	}
+
+	returnedMetafield, err := client.Location.CreateMetafield(1, metafield)
+	if err != nil {
+		t.Errorf("Location.CreateMetafield() returned error: %v", err)
+	}
+
+	MetafieldTests(t, *returnedMetafield)
+}
+
+func TestLocationUpdateMetafield(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/locations/1/metafields/2.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("metafield.json")))
+
+	metafield := Metafield{
+		ID:        2,
+		Key:       "app_key",
+		Value:     "app_value",
+		ValueType: "string",
+		Namespace: "affiliates",
+	}
+
+	returnedMetafield, err := client.Location.UpdateMetafield(1, metafield)
+	if err != nil {
+		t.Errorf("Location.UpdateMetafield() returned error: %v", err)
+	}
+
+	MetafieldTests(t, *returnedMetafield)
+}
+
+func TestLocationDeleteMetafield(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("https://fooshop.myshopify.com/%s/locations/1/metafields/2.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, "{}"))
+
+	err := client.Location.DeleteMetafield(1, 2)
+	if err != nil {
+		t.Errorf("Location.DeleteMetafield() returned error: %v", err)
+	}
+}
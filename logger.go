@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"time"
 )
 
 // idea from https://github.com/stripe/stripe-go/blob/master/log.go
@@ -22,6 +23,27 @@ type LeveledLoggerInterface interface {
 	Warnf(format string, v ...interface{})
 }
 
+// RequestLog is a structured record of one attempt at a request.
+type RequestLog struct {
+	Shop   string
+	Method string
+	Path   string
+	// Status is 0 when no response was received
+	Status   int
+	Duration time.Duration
+	// RateRemaining is how many REST calls, or for GraphQL how many query
+	// cost points, are left, -1 when unknown
+	RateRemaining int
+}
+
+// RequestLoggerInterface is implemented by loggers that record requests as
+// structured entries rather than formatted messages, like the log/slog
+// adapter returned by NewSlogLogger, which is only built with Go 1.21 and
+// later. Such loggers passed to WithLogger receive an entry for every attempt.
+type RequestLoggerInterface interface {
+	LogRequest(RequestLog)
+}
+
 // It prints warnings and errors to `os.Stderr` and other messages to
 // `os.Stdout`.
 type LeveledLogger struct {
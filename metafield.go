@@ -1,8 +1,9 @@
 package goshopify
 
 import (
+	"context"
 	"fmt"
-	"net/http"
+	"strings"
 	"time"
 )
 
@@ -45,6 +46,7 @@ type Metafield struct {
 	Key               string      `json:"key,omitempty"`
 	Value             interface{} `json:"value,omitempty"`
 	ValueType         string      `json:"value_type,omitempty"`
+	Type              string      `json:"type,omitempty"`
 	Namespace         string      `json:"namespace,omitempty"`
 	Description       string      `json:"description,omitempty"`
 	OwnerId           int64       `json:"owner_id,omitempty"`
@@ -91,23 +93,7 @@ func (s *MetafieldServiceOp) List(options interface{}) ([]Metafield, error) {
 func (s *MetafieldServiceOp) ListWithPagination(options interface{}) ([]Metafield, *Pagination, error) {
 	prefix := MetafieldPathPrefix(s.resource, s.resourceID)
 	path := fmt.Sprintf("%s.json", prefix)
-	resource := new(MetafieldsResource)
-	headers := http.Header{}
-
-	headers, err := s.client.createAndDoGetHeaders("GET", path, nil, options, resource)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	// Extract pagination info from header
-	linkHeader := headers.Get("Link")
-
-	pagination, err := extractPagination(linkHeader)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	return resource.Metafields, pagination, nil
+	return listResourceWithPagination[Metafield](s.client, path, "metafields", options)
 }
 
 // Count metafields
@@ -121,29 +107,21 @@ func (s *MetafieldServiceOp) Count(options interface{}) (int, error) {
 func (s *MetafieldServiceOp) Get(metafieldID int64, options interface{}) (*Metafield, error) {
 	prefix := MetafieldPathPrefix(s.resource, s.resourceID)
 	path := fmt.Sprintf("%s/%d.json", prefix, metafieldID)
-	resource := new(MetafieldResource)
-	err := s.client.Get(path, resource, options)
-	return resource.Metafield, err
+	return getResource[Metafield](s.client, path, "metafield", options)
 }
 
 // Create a new metafield
 func (s *MetafieldServiceOp) Create(metafield Metafield) (*Metafield, error) {
 	prefix := MetafieldPathPrefix(s.resource, s.resourceID)
 	path := fmt.Sprintf("%s.json", prefix)
-	wrappedData := MetafieldResource{Metafield: &metafield}
-	resource := new(MetafieldResource)
-	err := s.client.Post(path, wrappedData, resource)
-	return resource.Metafield, err
+	return createResource(s.client, path, "metafield", metafield)
 }
 
 // Update an existing metafield
 func (s *MetafieldServiceOp) Update(metafield Metafield) (*Metafield, error) {
 	prefix := MetafieldPathPrefix(s.resource, s.resourceID)
 	path := fmt.Sprintf("%s/%d.json", prefix, metafield.ID)
-	wrappedData := MetafieldResource{Metafield: &metafield}
-	resource := new(MetafieldResource)
-	err := s.client.Put(path, wrappedData, resource)
-	return resource.Metafield, err
+	return updateResource(s.client, path, "metafield", metafield)
 }
 
 // Delete an existing metafield
@@ -151,3 +129,317 @@ func (s *MetafieldServiceOp) Delete(metafieldID int64) error {
 	prefix := MetafieldPathPrefix(s.resource, s.resourceID)
 	return s.client.Delete(fmt.Sprintf("%s/%d.json", prefix, metafieldID))
 }
+
+// metafieldNamespaceOptions filters a metafield listing by namespace.
+type metafieldNamespaceOptions struct {
+	ListOptions
+	Namespace string `url:"namespace,omitempty"`
+}
+
+// appMetafieldNamespace is the namespace reserved to the app calling the API,
+// "$app:" followed by a name reserves more of them. Shopify resolves them to
+// app--{app id} and app--{app id}--{name}, which no other app can write to.
+const appMetafieldNamespace = "$app"
+
+// metafieldsDeleteBatchSize is how many metafields a metafieldsDelete
+// mutation deletes at most.
+const metafieldsDeleteBatchSize = 250
+
+// metafieldIdentifier identifies a metafield in the metafieldsDelete input.
+type metafieldIdentifier struct {
+	OwnerID   string `json:"ownerId"`
+	Namespace string `json:"namespace"`
+	Key       string `json:"key"`
+}
+
+const shopIDQuery = `{ shop { id } }`
+
+const ownerMetafieldsQuery = `query($id: ID!, $namespace: String!, $after: String) {
+  node(id: $id) {
+    ... on HasMetafields {
+      metafields(namespace: $namespace, first: 250, after: $after) {
+        edges { node { namespace key } }
+        pageInfo { hasNextPage endCursor }
+      }
+    }
+  }
+}`
+
+const metafieldsDeleteMutation = `mutation($metafields: [MetafieldIdentifierInput!]!) {
+  metafieldsDelete(metafields: $metafields) {
+    deletedMetafields { key }
+    userErrors { field message }
+  }
+}`
+
+// DeleteMetafieldsByNamespace deletes every metafield in one of the app's
+// reserved namespaces, "$app" or "$app:{name}", from the owners given by
+// their GraphQL IDs, e.g. gid://shopify/Product/1, or from the shop when no
+// owners are given, which is meant for cleaning up after an app is
+// uninstalled. Other namespaces are an error, so metafields written by other
+// apps or merchants are never touched. The GraphQL calls are throttled by a
+// Scheduler configured with opts and stop when ctx is done. Deleting
+// continues past failures, the number of deleted metafields is returned along
+// with the first error.
+func (c *Client) DeleteMetafieldsByNamespace(ctx context.Context, namespace string, ownerIDs []string, opts ...SchedulerOption) (int, error) {
+	if namespace != appMetafieldNamespace && !strings.HasPrefix(namespace, appMetafieldNamespace+":") {
+		return 0, fmt.Errorf("namespace %s is not reserved to the app, only %s and %s:{name} are deleted", namespace, appMetafieldNamespace, appMetafieldNamespace)
+	}
+	if len(ownerIDs) == 0 {
+		ownerIDs = []string{""}
+	}
+
+	scheduler := NewScheduler(ctx, c, opts...)
+	defer scheduler.Close()
+
+	found := make([][]metafieldIdentifier, len(ownerIDs))
+	listed := make([]<-chan error, len(ownerIDs))
+	for i, ownerID := range ownerIDs {
+		i, ownerID := i, ownerID
+		listed[i] = scheduler.Enqueue(PriorityNormal, func(ctx context.Context, c *Client) error {
+			metafields, err := c.listOwnerMetafields(ctx, ownerID, namespace)
+			found[i] = metafields
+			return err
+		})
+	}
+
+	var firstErr error
+	var metafields []metafieldIdentifier
+	for i, ownerID := range ownerIDs {
+		if err := <-listed[i]; err != nil {
+			if ownerID == "" {
+				ownerID = "the shop"
+			}
+			if firstErr == nil {
+				firstErr = fmt.Errorf("listing metafields of %s: %w", ownerID, err)
+			}
+			continue
+		}
+		metafields = append(metafields, found[i]...)
+	}
+
+	batches := (len(metafields) + metafieldsDeleteBatchSize - 1) / metafieldsDeleteBatchSize
+	counts := make([]int, batches)
+	deleted := make([]<-chan error, batches)
+	for i := range deleted {
+		i, batch := i, metafields[i*metafieldsDeleteBatchSize:]
+		if len(batch) > metafieldsDeleteBatchSize {
+			batch = batch[:metafieldsDeleteBatchSize]
+		}
+		deleted[i] = scheduler.Enqueue(PriorityNormal, func(ctx context.Context, c *Client) error {
+			count, err := c.deleteMetafields(ctx, batch)
+			counts[i] = count
+			return err
+		})
+	}
+
+	total := 0
+	for i, result := range deleted {
+		if err := <-result; err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("deleting metafields: %w", err)
+		}
+		total += counts[i]
+	}
+
+	return total, firstErr
+}
+
+// listOwnerMetafields lists the metafields of an owner in a namespace, the
+// owner being the shop when ownerID is empty.
+func (c *Client) listOwnerMetafields(ctx context.Context, ownerID, namespace string) ([]metafieldIdentifier, error) {
+	if ownerID == "" {
+		resp := struct {
+			Shop struct {
+				ID string `json:"id"`
+			} `json:"shop"`
+		}{}
+		if err := c.graphQLQuery(ctx, shopIDQuery, nil, &resp); err != nil {
+			return nil, err
+		}
+		ownerID = resp.Shop.ID
+	}
+
+	var metafields []metafieldIdentifier
+	vars := map[string]interface{}{"id": ownerID, "namespace": namespace}
+	for {
+		resp := struct {
+			Node *struct {
+				Metafields struct {
+					Edges []struct {
+						Node struct {
+							Namespace string `json:"namespace"`
+							Key       string `json:"key"`
+						} `json:"node"`
+					} `json:"edges"`
+					PageInfo graphQLPageInfo `json:"pageInfo"`
+				} `json:"metafields"`
+			} `json:"node"`
+		}{}
+		if err := c.graphQLQuery(ctx, ownerMetafieldsQuery, vars, &resp); err != nil {
+			return nil, err
+		}
+		if resp.Node == nil {
+			return nil, fmt.Errorf("owner %s not found", ownerID)
+		}
+
+		for _, edge := range resp.Node.Metafields.Edges {
+			metafields = append(metafields, metafieldIdentifier{OwnerID: ownerID, Namespace: edge.Node.Namespace, Key: edge.Node.Key})
+		}
+		if !resp.Node.Metafields.PageInfo.HasNextPage {
+			return metafields, nil
+		}
+		vars["after"] = resp.Node.Metafields.PageInfo.EndCursor
+	}
+}
+
+// deleteMetafields deletes the identified metafields and returns how many
+// were deleted, metafields that no longer exist are not counted.
+func (c *Client) deleteMetafields(ctx context.Context, metafields []metafieldIdentifier) (int, error) {
+	resp := struct {
+		MetafieldsDelete struct {
+			DeletedMetafields []*struct {
+				Key string `json:"key"`
+			} `json:"deletedMetafields"`
+			UserErrors []UserError `json:"userErrors"`
+		} `json:"metafieldsDelete"`
+	}{}
+	vars := map[string]interface{}{"metafields": metafields}
+	if err := c.graphQLQuery(ctx, metafieldsDeleteMutation, vars, &resp); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, deleted := range resp.MetafieldsDelete.DeletedMetafields {
+		if deleted != nil {
+			count++
+		}
+	}
+	return count, userErrorsToError(resp.MetafieldsDelete.UserErrors)
+}
+
+// metafieldKeyOptions filters a metafield listing by namespace and key.
+type metafieldKeyOptions struct {
+	ListOptions
+	Namespace string `url:"namespace,omitempty"`
+	Key       string `url:"key,omitempty"`
+}
+
+// UpsertMetafield creates the metafield of an owner, e.g. "products" and its
+// ID or "" and 0 for the shop, or updates the metafield with the same
+// namespace and key when there already is one.
+func (c *Client) UpsertMetafield(ownerResource string, ownerID int64, metafield Metafield) (*Metafield, error) {
+	service := &MetafieldServiceOp{client: c, resource: ownerResource, resourceID: ownerID}
+
+	options := metafieldKeyOptions{ListOptions: ListOptions{Limit: 250}, Namespace: metafield.Namespace, Key: metafield.Key}
+	existing, err := listAllPages(service.ListWithPagination, options)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range existing {
+		if m.Namespace == metafield.Namespace && m.Key == metafield.Key {
+			metafield.ID = m.ID
+			return service.Update(metafield)
+		}
+	}
+
+	metafield.ID = 0
+	return service.Create(metafield)
+}
+
+// MetafieldSyncResult reports the changes SyncMetafields made.
+type MetafieldSyncResult struct {
+	Created   []Metafield
+	Updated   []Metafield
+	Deleted   []Metafield
+	Unchanged int
+}
+
+// SyncMetafields makes the metafields of an owner in a namespace match the
+// desired ones: missing metafields are created, those whose value or type
+// differ are updated and those in the namespace that aren't desired are
+// deleted. Desired metafields without a namespace get the namespace, other
+// namespaces are an error. Syncing stops at the first failure, the result
+// reports the changes made until then.
+func (c *Client) SyncMetafields(ownerResource string, ownerID int64, namespace string, desired []Metafield) (*MetafieldSyncResult, error) {
+	result := &MetafieldSyncResult{}
+	// normalized copy, the caller's slice is left as it was
+	desired = append([]Metafield(nil), desired...)
+	for i := range desired {
+		if desired[i].Namespace == "" {
+			desired[i].Namespace = namespace
+		}
+		if desired[i].Namespace != namespace {
+			return result, fmt.Errorf("metafield %s.%s is not in namespace %s", desired[i].Namespace, desired[i].Key, namespace)
+		}
+	}
+
+	service := &MetafieldServiceOp{client: c, resource: ownerResource, resourceID: ownerID}
+	options := metafieldNamespaceOptions{ListOptions: ListOptions{Limit: 250}, Namespace: namespace}
+	existing, err := listAllPages(service.ListWithPagination, options)
+	if err != nil {
+		return result, err
+	}
+
+	byKey := map[string]Metafield{}
+	for _, m := range existing {
+		if m.Namespace == namespace {
+			byKey[m.Key] = m
+		}
+	}
+
+	for _, metafield := range desired {
+		current, ok := byKey[metafield.Key]
+		delete(byKey, metafield.Key)
+
+		if !ok {
+			metafield.ID = 0
+			created, err := service.Create(metafield)
+			if err != nil {
+				return result, fmt.Errorf("creating metafield %s.%s: %w", namespace, metafield.Key, err)
+			}
+			result.Created = append(result.Created, *created)
+			continue
+		}
+
+		if metafieldEqual(current, metafield) {
+			result.Unchanged++
+			continue
+		}
+
+		metafield.ID = current.ID
+		updated, err := service.Update(metafield)
+		if err != nil {
+			return result, fmt.Errorf("updating metafield %s.%s: %w", namespace, metafield.Key, err)
+		}
+		result.Updated = append(result.Updated, *updated)
+	}
+
+	for _, m := range existing {
+		if _, ok := byKey[m.Key]; !ok || m.Namespace != namespace {
+			continue
+		}
+		if err := service.Delete(m.ID); err != nil {
+			return result, fmt.Errorf("deleting metafield %s.%s: %w", namespace, m.Key, err)
+		}
+		result.Deleted = append(result.Deleted, m)
+	}
+
+	return result, nil
+}
+
+// metafieldEqual reports whether the current metafield already has the
+// desired value and type, values are compared as text since the API returns
+// most of them as strings.
+func metafieldEqual(current, desired Metafield) bool {
+	if desired.Type != "" && desired.Type != current.Type {
+		return false
+	}
+	if desired.ValueType != "" && desired.ValueType != current.ValueType {
+		return false
+	}
+	if desired.Description != "" && desired.Description != current.Description {
+		return false
+	}
+	return fmt.Sprint(desired.Value) == fmt.Sprint(current.Value)
+}
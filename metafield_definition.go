@@ -0,0 +1,148 @@
+package goshopify
+
+// MetafieldDefinitionService is an interface for managing metafield
+// definitions, which are only available through the GraphQL Admin API.
+// See: https://shopify.dev/docs/admin-api/graphql/reference/metafields/metafielddefinition
+type MetafieldDefinitionService interface {
+	Get(ownerType, namespace, key string) (*MetafieldDefinition, error)
+	Create(MetafieldDefinition) (*MetafieldDefinition, error)
+	Update(MetafieldDefinition) (*MetafieldDefinition, error)
+}
+
+// MetafieldDefinitionServiceOp handles communication with the metafield
+// definition related GraphQL queries and mutations.
+type MetafieldDefinitionServiceOp struct {
+	client *Client
+}
+
+// MetafieldDefinition represents a Shopify metafield definition. OwnerType is
+// the GraphQL owner type, e.g. PRODUCT or CUSTOMER, and Type the metafield
+// type name, e.g. single_line_text_field.
+type MetafieldDefinition struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Namespace   string `json:"namespace,omitempty"`
+	Key         string `json:"key,omitempty"`
+	Description string `json:"description,omitempty"`
+	Type        string `json:"type,omitempty"`
+	OwnerType   string `json:"ownerType,omitempty"`
+}
+
+// metafieldDefinitionNode is the GraphQL representation of a definition, the
+// type is an object rather than the plain name used for inputs.
+type metafieldDefinitionNode struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Namespace   string `json:"namespace"`
+	Key         string `json:"key"`
+	Description string `json:"description"`
+	OwnerType   string `json:"ownerType"`
+	Type        struct {
+		Name string `json:"name"`
+	} `json:"type"`
+}
+
+func (n *metafieldDefinitionNode) definition() *MetafieldDefinition {
+	if n == nil {
+		return nil
+	}
+	return &MetafieldDefinition{
+		ID:          n.ID,
+		Name:        n.Name,
+		Namespace:   n.Namespace,
+		Key:         n.Key,
+		Description: n.Description,
+		Type:        n.Type.Name,
+		OwnerType:   n.OwnerType,
+	}
+}
+
+const metafieldDefinitionFields = `id name namespace key description ownerType type { name }`
+
+const metafieldDefinitionQuery = `query($ownerType: MetafieldOwnerType!, $namespace: String, $key: String) {
+  metafieldDefinitions(first: 1, ownerType: $ownerType, namespace: $namespace, key: $key) {
+    edges { node { ` + metafieldDefinitionFields + ` } }
+  }
+}`
+
+const metafieldDefinitionCreateMutation = `mutation($definition: MetafieldDefinitionInput!) {
+  metafieldDefinitionCreate(definition: $definition) {
+    createdDefinition { ` + metafieldDefinitionFields + ` }
+    userErrors { field message }
+  }
+}`
+
+const metafieldDefinitionUpdateMutation = `mutation($definition: MetafieldDefinitionUpdateInput!) {
+  metafieldDefinitionUpdate(definition: $definition) {
+    updatedDefinition { ` + metafieldDefinitionFields + ` }
+    userErrors { field message }
+  }
+}`
+
+// Get the definition for the owner type, namespace and key. It returns nil
+// without an error when no such definition exists.
+func (s *MetafieldDefinitionServiceOp) Get(ownerType, namespace, key string) (*MetafieldDefinition, error) {
+	vars := map[string]interface{}{
+		"ownerType": ownerType,
+		"namespace": namespace,
+		"key":       key,
+	}
+	resp := struct {
+		MetafieldDefinitions struct {
+			Edges []struct {
+				Node metafieldDefinitionNode `json:"node"`
+			} `json:"edges"`
+		} `json:"metafieldDefinitions"`
+	}{}
+
+	err := s.client.GraphQL.Query(metafieldDefinitionQuery, vars, &resp)
+	if err != nil || len(resp.MetafieldDefinitions.Edges) == 0 {
+		return nil, err
+	}
+	return resp.MetafieldDefinitions.Edges[0].Node.definition(), nil
+}
+
+// Create a new metafield definition
+func (s *MetafieldDefinitionServiceOp) Create(definition MetafieldDefinition) (*MetafieldDefinition, error) {
+	definition.ID = ""
+	vars := map[string]interface{}{"definition": definition}
+	resp := struct {
+		MetafieldDefinitionCreate struct {
+			CreatedDefinition *metafieldDefinitionNode `json:"createdDefinition"`
+			UserErrors        []UserError              `json:"userErrors"`
+		} `json:"metafieldDefinitionCreate"`
+	}{}
+
+	err := s.client.GraphQL.Query(metafieldDefinitionCreateMutation, vars, &resp)
+	if err == nil {
+		err = userErrorsToError(resp.MetafieldDefinitionCreate.UserErrors)
+	}
+	return resp.MetafieldDefinitionCreate.CreatedDefinition.definition(), err
+}
+
+// Update the name and description of an existing metafield definition, which
+// is identified by its owner type, namespace and key. The type of a
+// definition cannot be changed.
+func (s *MetafieldDefinitionServiceOp) Update(definition MetafieldDefinition) (*MetafieldDefinition, error) {
+	vars := map[string]interface{}{
+		"definition": map[string]interface{}{
+			"name":        definition.Name,
+			"namespace":   definition.Namespace,
+			"key":         definition.Key,
+			"description": definition.Description,
+			"ownerType":   definition.OwnerType,
+		},
+	}
+	resp := struct {
+		MetafieldDefinitionUpdate struct {
+			UpdatedDefinition *metafieldDefinitionNode `json:"updatedDefinition"`
+			UserErrors        []UserError              `json:"userErrors"`
+		} `json:"metafieldDefinitionUpdate"`
+	}{}
+
+	err := s.client.GraphQL.Query(metafieldDefinitionUpdateMutation, vars, &resp)
+	if err == nil {
+		err = userErrorsToError(resp.MetafieldDefinitionUpdate.UserErrors)
+	}
+	return resp.MetafieldDefinitionUpdate.UpdatedDefinition.definition(), err
+}
@@ -0,0 +1,65 @@
+package goshopify
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestMetafieldDefinitionGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"data":{"metafieldDefinitions":{"edges":[{"node":{"id":"gid://shopify/MetafieldDefinition/1","name":"Color","namespace":"app","key":"color","description":"","ownerType":"PRODUCT","type":{"name":"single_line_text_field"}}}]}}}`))
+
+	definition, err := client.MetafieldDefinition.Get("PRODUCT", "app", "color")
+	if err != nil {
+		t.Errorf("MetafieldDefinition.Get returned error: %v", err)
+	}
+
+	expected := &MetafieldDefinition{
+		ID:        "gid://shopify/MetafieldDefinition/1",
+		Name:      "Color",
+		Namespace: "app",
+		Key:       "color",
+		Type:      "single_line_text_field",
+		OwnerType: "PRODUCT",
+	}
+	if !reflect.DeepEqual(definition, expected) {
+		t.Errorf("MetafieldDefinition.Get returned %+v, expected %+v", definition, expected)
+	}
+}
+
+func TestMetafieldDefinitionGetNotFound(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"data":{"metafieldDefinitions":{"edges":[]}}}`))
+
+	definition, err := client.MetafieldDefinition.Get("PRODUCT", "app", "color")
+	if err != nil || definition != nil {
+		t.Errorf("MetafieldDefinition.Get returned %+v, %v, expected nil, nil", definition, err)
+	}
+}
+
+func TestMetafieldDefinitionCreateUserErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"data":{"metafieldDefinitionCreate":{"createdDefinition":null,"userErrors":[{"field":["definition","key"],"message":"Key is in use"}]}}}`))
+
+	definition, err := client.MetafieldDefinition.Create(MetafieldDefinition{Namespace: "app", Key: "color"})
+	if definition != nil {
+		t.Errorf("MetafieldDefinition.Create returned %+v, expected nil", definition)
+	}
+
+	expected := "definition.key: Key is in use"
+	if err == nil || err.Error() != expected {
+		t.Errorf("MetafieldDefinition.Create err returned %v, expected %s", err, expected)
+	}
+}
@@ -1,6 +1,7 @@
 package goshopify
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -127,6 +128,7 @@ func TestMetafieldListWithPagination(t *testing.T) {
 			[]Metafield{{ID: 1}},
 			&Pagination{
 				NextPageOptions: &ListOptions{PageInfo: "foo", Limit: 2},
+				NextPageURL:     "http://valid.url?page_info=foo&limit=2",
 			},
 			nil,
 		},
@@ -137,6 +139,8 @@ func TestMetafieldListWithPagination(t *testing.T) {
 			&Pagination{
 				NextPageOptions:     &ListOptions{PageInfo: "foo"},
 				PreviousPageOptions: &ListOptions{PageInfo: "bar"},
+				NextPageURL:         "http://valid.url?page_info=foo",
+				PreviousPageURL:     "http://valid.url?page_info=bar",
 			},
 			nil,
 		},
@@ -300,3 +304,242 @@ func TestMetafieldDelete(t *testing.T) {
 		t.Errorf("Metafield.Delete returned error: %v", err)
 	}
 }
+
+func TestDeleteMetafieldsByNamespace(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests,
+			`{"data":{"node":{"metafields":{"edges":[{"node":{"namespace":"app--1--cleanup","key":"a"}}],"pageInfo":{"hasNextPage":true,"endCursor":"abc"}}}}}`,
+			`{"data":{"node":{"metafields":{"edges":[{"node":{"namespace":"app--1--cleanup","key":"b"}}],"pageInfo":{"hasNextPage":false}}}}}`,
+			`{"data":{"node":{"metafields":{"edges":[{"node":{"namespace":"app--1--cleanup","key":"c"}}],"pageInfo":{"hasNextPage":false}}}}}`,
+			`{"data":{"metafieldsDelete":{"deletedMetafields":[{"key":"a"},null,{"key":"c"}],"userErrors":[]}}}`,
+		))
+
+	owners := []string{"gid://shopify/Product/1", "gid://shopify/Customer/2"}
+	count, err := client.DeleteMetafieldsByNamespace(context.Background(), "$app:cleanup", owners, WithSchedulerRate(1000))
+	if err != nil {
+		t.Fatalf("DeleteMetafieldsByNamespace returned error: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("DeleteMetafieldsByNamespace deleted %d metafields, expected 2", count)
+	}
+
+	if len(requests) != 4 {
+		t.Fatalf("DeleteMetafieldsByNamespace sent %d requests, expected 4", len(requests))
+	}
+	listing := requests[0].Variables.(map[string]interface{})
+	if listing["id"] != owners[0] || listing["namespace"] != "$app:cleanup" {
+		t.Errorf("DeleteMetafieldsByNamespace listed %v", listing)
+	}
+	if after := requests[1].Variables.(map[string]interface{})["after"]; after != "abc" {
+		t.Errorf("DeleteMetafieldsByNamespace listed the second page after %v, expected abc", after)
+	}
+	if id := requests[2].Variables.(map[string]interface{})["id"]; id != owners[1] {
+		t.Errorf("DeleteMetafieldsByNamespace listed owner %v, expected %s", id, owners[1])
+	}
+
+	expected := []interface{}{
+		map[string]interface{}{"ownerId": owners[0], "namespace": "app--1--cleanup", "key": "a"},
+		map[string]interface{}{"ownerId": owners[0], "namespace": "app--1--cleanup", "key": "b"},
+		map[string]interface{}{"ownerId": owners[1], "namespace": "app--1--cleanup", "key": "c"},
+	}
+	if deleted := requests[3].Variables.(map[string]interface{})["metafields"]; !reflect.DeepEqual(deleted, expected) {
+		t.Errorf("DeleteMetafieldsByNamespace deleted %v, expected %v", deleted, expected)
+	}
+}
+
+func TestDeleteMetafieldsByNamespaceShop(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests,
+			`{"data":{"shop":{"id":"gid://shopify/Shop/1"}}}`,
+			`{"data":{"node":{"metafields":{"edges":[{"node":{"namespace":"app--1","key":"a"}}],"pageInfo":{"hasNextPage":false}}}}}`,
+			`{"data":{"metafieldsDelete":{"deletedMetafields":[{"key":"a"}],"userErrors":[]}}}`,
+		))
+
+	count, err := client.DeleteMetafieldsByNamespace(context.Background(), "$app", nil, WithSchedulerRate(1000))
+	if err != nil {
+		t.Fatalf("DeleteMetafieldsByNamespace returned error: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("DeleteMetafieldsByNamespace deleted %d metafields, expected 1", count)
+	}
+	if len(requests) != 3 || requests[1].Variables.(map[string]interface{})["id"] != "gid://shopify/Shop/1" {
+		t.Errorf("DeleteMetafieldsByNamespace sent %+v, expected the shop's metafields to be listed", requests)
+	}
+}
+
+func TestDeleteMetafieldsByNamespaceOtherNamespace(t *testing.T) {
+	setup()
+	defer teardown()
+
+	for _, namespace := range []string{"my_app", "app--1--cleanup", "$application"} {
+		_, err := client.DeleteMetafieldsByNamespace(context.Background(), namespace, nil, WithSchedulerRate(1000))
+		if err == nil {
+			t.Errorf("DeleteMetafieldsByNamespace(%s) returned no error", namespace)
+		}
+	}
+	if calls := httpmock.GetTotalCallCount(); calls != 0 {
+		t.Errorf("DeleteMetafieldsByNamespace sent %d requests, expected none", calls)
+	}
+}
+
+func TestDeleteMetafieldsByNamespaceUserErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests,
+			`{"data":{"node":{"metafields":{"edges":[{"node":{"namespace":"app--1","key":"a"}}],"pageInfo":{"hasNextPage":false}}}}}`,
+			`{"data":{"metafieldsDelete":{"deletedMetafields":[null],"userErrors":[{"field":["metafields","0"],"message":"Access denied"}]}}}`,
+		))
+
+	count, err := client.DeleteMetafieldsByNamespace(context.Background(), "$app", []string{"gid://shopify/Product/1"}, WithSchedulerRate(1000))
+	if count != 0 {
+		t.Errorf("DeleteMetafieldsByNamespace deleted %d metafields, expected 0", count)
+	}
+
+	var responseErr ResponseError
+	if !errors.As(err, &responseErr) || responseErr.Message != "metafields.0: Access denied" {
+		t.Errorf("DeleteMetafieldsByNamespace returned error %v, expected the user error", err)
+	}
+}
+
+func TestDeleteMetafieldsByNamespaceOwnerNotFound(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests,
+			`{"data":{"node":null}}`,
+			`{"data":{"node":{"metafields":{"edges":[{"node":{"namespace":"app--1","key":"a"}}],"pageInfo":{"hasNextPage":false}}}}}`,
+			`{"data":{"metafieldsDelete":{"deletedMetafields":[{"key":"a"}],"userErrors":[]}}}`,
+		))
+
+	owners := []string{"gid://shopify/Product/1", "gid://shopify/Product/2"}
+	count, err := client.DeleteMetafieldsByNamespace(context.Background(), "$app", owners, WithSchedulerRate(1000))
+	if count != 1 {
+		t.Errorf("DeleteMetafieldsByNamespace deleted %d metafields, expected the other owner's metafield to be deleted", count)
+	}
+	if err == nil || err.Error() != "listing metafields of gid://shopify/Product/1: owner gid://shopify/Product/1 not found" {
+		t.Errorf("DeleteMetafieldsByNamespace returned error %v, expected the missing owner", err)
+	}
+}
+
+func TestUpsertMetafield(t *testing.T) {
+	setup()
+	defer teardown()
+
+	prefix := fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1/metafields", client.pathPrefix)
+	httpmock.RegisterResponder("GET", prefix+".json",
+		func(req *http.Request) (*http.Response, error) {
+			if req.URL.Query().Get("key") == "color" && req.URL.Query().Get("namespace") == "my_app" {
+				return httpmock.NewStringResponse(200, `{"metafields":[{"id":7,"namespace":"my_app","key":"color","value":"red"}]}`), nil
+			}
+			return httpmock.NewStringResponse(200, `{"metafields":[]}`), nil
+		})
+	httpmock.RegisterResponder("PUT", prefix+"/7.json",
+		httpmock.NewStringResponder(200, `{"metafield":{"id":7,"namespace":"my_app","key":"color","value":"blue"}}`))
+	httpmock.RegisterResponder("POST", prefix+".json",
+		httpmock.NewStringResponder(201, `{"metafield":{"id":8,"namespace":"my_app","key":"size","value":"L"}}`))
+
+	updated, err := client.UpsertMetafield(productsResourceName, 1, Metafield{Namespace: "my_app", Key: "color", Value: "blue", Type: "single_line_text_field"})
+	if err != nil {
+		t.Fatalf("UpsertMetafield returned error: %v", err)
+	}
+	if updated.ID != 7 || updated.Value != "blue" {
+		t.Errorf("UpsertMetafield returned %+v, expected the updated metafield 7", updated)
+	}
+
+	created, err := client.UpsertMetafield(productsResourceName, 1, Metafield{Namespace: "my_app", Key: "size", Value: "L", Type: "single_line_text_field"})
+	if err != nil {
+		t.Fatalf("UpsertMetafield returned error: %v", err)
+	}
+	if created.ID != 8 {
+		t.Errorf("UpsertMetafield returned %+v, expected the created metafield 8", created)
+	}
+
+	info := httpmock.GetCallCountInfo()
+	if info["PUT "+prefix+"/7.json"] != 1 || info["POST "+prefix+".json"] != 1 {
+		t.Errorf("UpsertMetafield sent %v, expected one update and one create", info)
+	}
+}
+
+func TestSyncMetafields(t *testing.T) {
+	setup()
+	defer teardown()
+
+	prefix := fmt.Sprintf("https://fooshop.myshopify.com/%s/metafields", client.pathPrefix)
+	httpmock.RegisterResponder("GET", prefix+".json",
+		httpmock.NewStringResponder(200, `{"metafields":[
+			{"id":1,"namespace":"my_app","key":"color","value":"red","type":"single_line_text_field"},
+			{"id":2,"namespace":"my_app","key":"size","value":"L","type":"single_line_text_field"},
+			{"id":3,"namespace":"my_app","key":"legacy","value":"x","type":"single_line_text_field"},
+			{"id":4,"namespace":"my_app","key":"count","value":5,"type":"number_integer"}
+		]}`))
+
+	var requests []string
+	recorder := func(status int, body string) httpmock.Responder {
+		return func(req *http.Request) (*http.Response, error) {
+			requests = append(requests, req.Method+" "+req.URL.Path)
+			return httpmock.NewStringResponse(status, body), nil
+		}
+	}
+	httpmock.RegisterResponder("PUT", prefix+"/1.json", recorder(200, `{"metafield":{"id":1,"namespace":"my_app","key":"color","value":"blue"}}`))
+	httpmock.RegisterResponder("POST", prefix+".json", recorder(201, `{"metafield":{"id":5,"namespace":"my_app","key":"material","value":"wool"}}`))
+	httpmock.RegisterResponder("DELETE", prefix+"/3.json", recorder(200, `{}`))
+
+	desired := []Metafield{
+		{Key: "color", Value: "blue", Type: "single_line_text_field"},
+		{Key: "size", Value: "L", Type: "single_line_text_field"},
+		{Key: "count", Value: 5, Type: "number_integer"},
+		{Key: "material", Value: "wool", Type: "single_line_text_field"},
+	}
+	result, err := client.SyncMetafields("", 0, "my_app", desired)
+	if err != nil {
+		t.Fatalf("SyncMetafields returned error: %v", err)
+	}
+
+	expected := &MetafieldSyncResult{
+		Created:   []Metafield{{ID: 5, Namespace: "my_app", Key: "material", Value: "wool"}},
+		Updated:   []Metafield{{ID: 1, Namespace: "my_app", Key: "color", Value: "blue"}},
+		Deleted:   []Metafield{{ID: 3, Namespace: "my_app", Key: "legacy", Value: "x", Type: "single_line_text_field"}},
+		Unchanged: 2,
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("SyncMetafields returned %+v, expected %+v", result, expected)
+	}
+
+	expectedRequests := []string{
+		"PUT /" + client.pathPrefix + "/metafields/1.json",
+		"POST /" + client.pathPrefix + "/metafields.json",
+		"DELETE /" + client.pathPrefix + "/metafields/3.json",
+	}
+	if !reflect.DeepEqual(requests, expectedRequests) {
+		t.Errorf("SyncMetafields sent %v, expected %v", requests, expectedRequests)
+	}
+
+	for _, metafield := range desired {
+		if metafield.Namespace != "" {
+			t.Errorf("SyncMetafields set the namespace of the desired %s to %q", metafield.Key, metafield.Namespace)
+		}
+	}
+}
+
+func TestSyncMetafieldsOtherNamespace(t *testing.T) {
+	setup()
+	defer teardown()
+
+	_, err := client.SyncMetafields(productsResourceName, 1, "my_app", []Metafield{{Namespace: "other", Key: "color"}})
+	if err == nil || err.Error() != "metafield other.color is not in namespace my_app" {
+		t.Errorf("SyncMetafields returned %v, expected a namespace error", err)
+	}
+}
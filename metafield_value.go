@@ -0,0 +1,193 @@
+package goshopify
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// Metafield types, see https://shopify.dev/apps/metafields/types. List
+// types are prefixed with "list.", e.g. "list.single_line_text_field".
+const (
+	MetafieldTypeSingleLineText = "single_line_text_field"
+	MetafieldTypeMultiLineText  = "multi_line_text_field"
+	MetafieldTypeNumberInteger  = "number_integer"
+	MetafieldTypeNumberDecimal  = "number_decimal"
+	MetafieldTypeBoolean        = "boolean"
+	MetafieldTypeJSON           = "json"
+	MetafieldTypeDate           = "date"
+	MetafieldTypeDateTime       = "date_time"
+	MetafieldTypeURL            = "url"
+	MetafieldTypeColor          = "color"
+	MetafieldTypeDimension      = "dimension"
+	MetafieldTypeVolume         = "volume"
+	MetafieldTypeWeight         = "weight"
+	MetafieldTypeRating         = "rating"
+
+	metafieldListTypePrefix = "list."
+)
+
+// Value types of metafields before the 2021-07 API version introduced types.
+const (
+	MetafieldValueTypeString     = "string"
+	MetafieldValueTypeInteger    = "integer"
+	MetafieldValueTypeJSONString = "json_string"
+)
+
+// metafieldJSONTypes are the types whose values are JSON encoded.
+var metafieldJSONTypes = map[string]bool{
+	MetafieldTypeJSON:      true,
+	MetafieldTypeDimension: true,
+	MetafieldTypeVolume:    true,
+	MetafieldTypeWeight:    true,
+	MetafieldTypeRating:    true,
+}
+
+// SetJSONValue sets the value to v encoded as JSON, with the json type unless
+// the metafield already has one, e.g. a list type.
+func (m *Metafield) SetJSONValue(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encoding metafield %s.%s: %w", m.Namespace, m.Key, err)
+	}
+	m.Value = string(data)
+	if m.Type == "" && m.ValueType == "" {
+		m.Type = MetafieldTypeJSON
+	}
+	return nil
+}
+
+// DecodeValue decodes the value into out. Values of the JSON and list types
+// are decoded from their JSON encoding, text values can be decoded into a
+// string.
+func (m Metafield) DecodeValue(out interface{}) error {
+	var err error
+	switch v := m.Value.(type) {
+	case nil:
+		err = fmt.Errorf("no value")
+	case string:
+		if s, ok := out.(*string); ok && !m.jsonValue() {
+			*s = v
+			return nil
+		}
+		err = json.Unmarshal([]byte(v), out)
+	default:
+		// numbers, booleans and objects that were not sent as strings
+		var data []byte
+		data, err = json.Marshal(v)
+		if err == nil {
+			err = json.Unmarshal(data, out)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("decoding metafield %s.%s: %w", m.Namespace, m.Key, err)
+	}
+	return nil
+}
+
+// Int returns the value of a number_integer metafield.
+func (m Metafield) Int() (int64, error) {
+	if err := m.checkType(MetafieldTypeNumberInteger, MetafieldValueTypeInteger); err != nil {
+		return 0, err
+	}
+
+	var i int64
+	switch v := m.Value.(type) {
+	case string:
+		parsed, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("decoding metafield %s.%s: %w", m.Namespace, m.Key, err)
+		}
+		i = parsed
+	case float64:
+		if v != float64(int64(v)) {
+			return 0, fmt.Errorf("decoding metafield %s.%s: %v is not an integer", m.Namespace, m.Key, v)
+		}
+		i = int64(v)
+	default:
+		if err := m.DecodeValue(&i); err != nil {
+			return 0, err
+		}
+	}
+	return i, nil
+}
+
+// Decimal returns the value of a number_decimal or number_integer metafield.
+func (m Metafield) Decimal() (decimal.Decimal, error) {
+	if err := m.checkType(MetafieldTypeNumberDecimal, MetafieldTypeNumberInteger, MetafieldValueTypeInteger); err != nil {
+		return decimal.Zero, err
+	}
+
+	switch v := m.Value.(type) {
+	case string:
+		d, err := decimal.NewFromString(strings.TrimSpace(v))
+		if err != nil {
+			return decimal.Zero, fmt.Errorf("decoding metafield %s.%s: %w", m.Namespace, m.Key, err)
+		}
+		return d, nil
+	case float64:
+		return decimal.NewFromFloat(v), nil
+	}
+
+	var d decimal.Decimal
+	err := m.DecodeValue(&d)
+	return d, err
+}
+
+// Bool returns the value of a boolean metafield.
+func (m Metafield) Bool() (bool, error) {
+	if err := m.checkType(MetafieldTypeBoolean); err != nil {
+		return false, err
+	}
+
+	if v, ok := m.Value.(string); ok {
+		b, err := strconv.ParseBool(strings.TrimSpace(v))
+		if err != nil {
+			return false, fmt.Errorf("decoding metafield %s.%s: %w", m.Namespace, m.Key, err)
+		}
+		return b, nil
+	}
+
+	var b bool
+	err := m.DecodeValue(&b)
+	return b, err
+}
+
+// StringList returns the values of a list metafield of a text based type,
+// e.g. list.single_line_text_field or list.url.
+func (m Metafield) StringList() ([]string, error) {
+	if m.Type != "" && !strings.HasPrefix(m.Type, metafieldListTypePrefix) {
+		return nil, fmt.Errorf("metafield %s.%s is a %s, not a list", m.Namespace, m.Key, m.Type)
+	}
+
+	var list []string
+	err := m.DecodeValue(&list)
+	return list, err
+}
+
+// jsonValue reports whether the value is JSON encoded.
+func (m Metafield) jsonValue() bool {
+	return metafieldJSONTypes[m.Type] || strings.HasPrefix(m.Type, metafieldListTypePrefix) ||
+		m.ValueType == MetafieldValueTypeJSONString
+}
+
+// checkType returns an error when the type, or the value type of older
+// metafields, is known and not one of the given ones.
+func (m Metafield) checkType(types ...string) error {
+	t := m.Type
+	if t == "" {
+		t = m.ValueType
+	}
+	if t == "" {
+		return nil
+	}
+	for _, allowed := range types {
+		if t == allowed {
+			return nil
+		}
+	}
+	return fmt.Errorf("metafield %s.%s is a %s, not a %s", m.Namespace, m.Key, t, types[0])
+}
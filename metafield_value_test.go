@@ -0,0 +1,100 @@
+package goshopify
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestMetafieldSetJSONValue(t *testing.T) {
+	m := Metafield{Namespace: "app", Key: "settings"}
+	if err := m.SetJSONValue(map[string]interface{}{"enabled": true}); err != nil {
+		t.Fatalf("Metafield.SetJSONValue returned error: %v", err)
+	}
+	if m.Value != `{"enabled":true}` || m.Type != MetafieldTypeJSON {
+		t.Errorf("Metafield.SetJSONValue set value %v and type %q", m.Value, m.Type)
+	}
+
+	list := Metafield{Namespace: "app", Key: "colors", Type: "list.single_line_text_field"}
+	if err := list.SetJSONValue([]string{"red", "blue"}); err != nil {
+		t.Fatalf("Metafield.SetJSONValue returned error: %v", err)
+	}
+	if list.Value != `["red","blue"]` || list.Type != "list.single_line_text_field" {
+		t.Errorf("Metafield.SetJSONValue set value %v and type %q", list.Value, list.Type)
+	}
+
+	if err := m.SetJSONValue(make(chan int)); err == nil {
+		t.Errorf("Metafield.SetJSONValue returned no error for a channel")
+	}
+}
+
+func TestMetafieldDecodeValue(t *testing.T) {
+	settings := struct {
+		Enabled bool `json:"enabled"`
+	}{}
+	m := Metafield{Namespace: "app", Key: "settings", Type: MetafieldTypeJSON, Value: `{"enabled":true}`}
+	if err := m.DecodeValue(&settings); err != nil || !settings.Enabled {
+		t.Errorf("Metafield.DecodeValue returned %+v, %v", settings, err)
+	}
+
+	var text string
+	m = Metafield{Type: MetafieldTypeSingleLineText, Value: "not json"}
+	if err := m.DecodeValue(&text); err != nil || text != "not json" {
+		t.Errorf("Metafield.DecodeValue returned %q, %v", text, err)
+	}
+
+	var n int
+	m = Metafield{ValueType: MetafieldValueTypeInteger, Value: float64(3)}
+	if err := m.DecodeValue(&n); err != nil || n != 3 {
+		t.Errorf("Metafield.DecodeValue returned %d, %v", n, err)
+	}
+
+	m = Metafield{Namespace: "app", Key: "empty"}
+	if err := m.DecodeValue(&n); err == nil || !strings.Contains(err.Error(), "app.empty") {
+		t.Errorf("Metafield.DecodeValue returned %v, expected an error naming the metafield", err)
+	}
+}
+
+func TestMetafieldTypedValues(t *testing.T) {
+	i, err := Metafield{Type: MetafieldTypeNumberInteger, Value: "42"}.Int()
+	if err != nil || i != 42 {
+		t.Errorf("Metafield.Int returned %d, %v", i, err)
+	}
+	i, err = Metafield{ValueType: MetafieldValueTypeInteger, Value: float64(7)}.Int()
+	if err != nil || i != 7 {
+		t.Errorf("Metafield.Int returned %d, %v", i, err)
+	}
+	if _, err := (Metafield{Value: 1.5}).Int(); err == nil {
+		t.Errorf("Metafield.Int returned no error for 1.5")
+	}
+	if _, err := (Metafield{Type: MetafieldTypeBoolean, Value: "true"}).Int(); err == nil {
+		t.Errorf("Metafield.Int returned no error for a boolean")
+	}
+
+	d, err := Metafield{Type: MetafieldTypeNumberDecimal, Value: "19.99"}.Decimal()
+	if err != nil || !d.Equal(decimal.RequireFromString("19.99")) {
+		t.Errorf("Metafield.Decimal returned %s, %v", d, err)
+	}
+	if _, err := (Metafield{Type: MetafieldTypeNumberDecimal, Value: "abc"}).Decimal(); err == nil {
+		t.Errorf("Metafield.Decimal returned no error for abc")
+	}
+
+	b, err := Metafield{Type: MetafieldTypeBoolean, Value: "true"}.Bool()
+	if err != nil || !b {
+		t.Errorf("Metafield.Bool returned %v, %v", b, err)
+	}
+	b, err = Metafield{Value: false}.Bool()
+	if err != nil || b {
+		t.Errorf("Metafield.Bool returned %v, %v", b, err)
+	}
+
+	list, err := Metafield{Type: "list.single_line_text_field", Value: `["a","b"]`}.StringList()
+	if err != nil || !reflect.DeepEqual(list, []string{"a", "b"}) {
+		t.Errorf("Metafield.StringList returned %v, %v", list, err)
+	}
+	if _, err := (Metafield{Type: MetafieldTypeSingleLineText, Value: "a"}).StringList(); err == nil {
+		t.Errorf("Metafield.StringList returned no error for a single line text")
+	}
+}
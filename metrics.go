@@ -0,0 +1,75 @@
+package goshopify
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Metrics receives the outcome of every request the client sends, so counters
+// and histograms can be kept, e.g. with Prometheus or StatsD. It is called once
+// per attempt, retries included.
+type Metrics interface {
+	// OnRequest is called after a response was received or the request
+	// failed, in which case status is 0. The resource is the path with IDs
+	// replaced, e.g. "products/:id/metafields" or "graphql", which keeps the
+	// number of distinct values low. rateRemaining is how many REST calls, or
+	// for GraphQL how many query cost points, are left, -1 when unknown.
+	OnRequest(resource, method string, status int, duration time.Duration, rateRemaining int)
+}
+
+// reportRequest passes the outcome of a request to the configured metrics and
+// structured logger.
+func (c *Client) reportRequest(req *http.Request, resp *http.Response, duration time.Duration) {
+	requestLogger, _ := c.log.(RequestLoggerInterface)
+	if c.metrics == nil && requestLogger == nil {
+		return
+	}
+
+	status := 0
+	if resp != nil {
+		status = resp.StatusCode
+	}
+
+	limits := c.rateLimits()
+	rateRemaining := limits.Remaining()
+	if strings.HasSuffix(req.URL.Path, "/"+graphQLPath) {
+		// the query cost is in the body, which isn't decoded yet, so this
+		// is what the previous query left
+		rateRemaining = -1
+		if throttle := limits.GraphQLCost.ThrottleStatus; throttle.MaximumAvailable > 0 {
+			rateRemaining = int(throttle.CurrentlyAvailable)
+		}
+	}
+
+	if c.metrics != nil {
+		c.metrics.OnRequest(c.metricsResource(req), req.Method, status, duration, rateRemaining)
+	}
+	if requestLogger != nil {
+		requestLogger.LogRequest(RequestLog{
+			Shop:          req.URL.Host,
+			Method:        req.Method,
+			Path:          req.URL.Path,
+			Status:        status,
+			Duration:      duration,
+			RateRemaining: rateRemaining,
+		})
+	}
+}
+
+// metricsResource returns the path of a request relative to the API prefix,
+// without the .json extension and with numeric IDs replaced by ":id".
+func (c *Client) metricsResource(req *http.Request) string {
+	p := strings.TrimPrefix(req.URL.Path, "/")
+	p = strings.TrimPrefix(p, c.pathPrefix+"/")
+	p = strings.TrimSuffix(p, ".json")
+
+	segments := strings.Split(p, "/")
+	for i, segment := range segments {
+		if _, err := strconv.ParseInt(segment, 10, 64); err == nil {
+			segments[i] = ":id"
+		}
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,92 @@
+package goshopify
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+type recordedRequest struct {
+	resource      string
+	method        string
+	status        int
+	duration      time.Duration
+	rateRemaining int
+}
+
+type metricsRecorder struct {
+	mu       sync.Mutex
+	requests []recordedRequest
+}
+
+func (m *metricsRecorder) OnRequest(resource, method string, status int, duration time.Duration, rateRemaining int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests = append(m.requests, recordedRequest{resource, method, status, duration, rateRemaining})
+}
+
+func TestWithMetrics(t *testing.T) {
+	setup()
+	defer teardown()
+
+	metrics := &metricsRecorder{}
+	WithMetrics(metrics)(client)
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1/metafields.json", client.pathPrefix),
+		createResponderWithHeaders(200, `{"metafields":[]}`, map[string]string{"X-Shopify-Shop-Api-Call-Limit": "3/40"}))
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(404, `{"errors":"Not Found"}`))
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		httpmock.NewErrorResponder(errors.New("connection lost")))
+
+	_, _ = client.Product.ListMetafields(1, nil)
+	_ = client.Product.Delete(1)
+	_ = client.GraphQL.Query("{ shop { id } }", nil, nil)
+
+	expected := []recordedRequest{
+		{resource: "products/:id/metafields", method: "GET", status: 200, rateRemaining: 37},
+		{resource: "products/:id", method: "DELETE", status: 404, rateRemaining: 37},
+		{resource: "graphql", method: "POST", status: 0, rateRemaining: -1},
+	}
+	if len(metrics.requests) != len(expected) {
+		t.Fatalf("OnRequest was called %d times, expected %d", len(metrics.requests), len(expected))
+	}
+	for i, got := range metrics.requests {
+		if got.duration < 0 {
+			t.Errorf("OnRequest duration %v is negative", got.duration)
+		}
+		got.duration = 0
+		if got != expected[i] {
+			t.Errorf("OnRequest returned %+v, expected %+v", got, expected[i])
+		}
+	}
+}
+
+func TestMetricsGraphQLRateRemaining(t *testing.T) {
+	setup()
+	defer teardown()
+
+	metrics := &metricsRecorder{}
+	WithMetrics(metrics)(client)
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"data":{},"extensions":{"cost":{"requestedQueryCost":10,"actualQueryCost":10,"throttleStatus":{"maximumAvailable":1000,"currentlyAvailable":990,"restoreRate":50}}}}`))
+
+	for i := 0; i < 2; i++ {
+		if err := client.GraphQL.Query("{ shop { id } }", nil, nil); err != nil {
+			t.Fatalf("GraphQL.Query returned error: %v", err)
+		}
+	}
+
+	// the cost of a query is only known once its response is decoded
+	if got := metrics.requests[0].rateRemaining; got != -1 {
+		t.Errorf("first OnRequest rateRemaining returned %d, expected -1", got)
+	}
+	if got := metrics.requests[1].rateRemaining; got != 990 {
+		t.Errorf("second OnRequest rateRemaining returned %d, expected 990", got)
+	}
+}
@@ -0,0 +1,37 @@
+package goshopify
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// NullFields lists the JSON names of fields that are sent as an explicit null,
+// e.g. "compare_at_price" or "published_at". Since zero values are omitted
+// from requests, this is the only way to clear a value on update.
+//
+//	variant := goshopify.Variant{ID: 1, NullFields: goshopify.NullFields{"compare_at_price"}}
+type NullFields []string
+
+// marshalWithNullFields encodes v and sets the null fields to null. v must be
+// a value of a type without a MarshalJSON method of its own to avoid a
+// recursion.
+func marshalWithNullFields(v interface{}, nullFields NullFields) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(nullFields) == 0 {
+		return data, err
+	}
+
+	known := jsonFields(reflect.TypeOf(v))
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for _, name := range nullFields {
+		if _, ok := known[name]; !ok {
+			return nil, fmt.Errorf("unknown null field %q", name)
+		}
+		fields[name] = json.RawMessage("null")
+	}
+	return json.Marshal(fields)
+}
@@ -0,0 +1,68 @@
+package goshopify
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestMarshalWithNullFields(t *testing.T) {
+	cases := []struct {
+		in       interface{}
+		expected string
+	}{
+		{Variant{ID: 1}, `{"id":1}`},
+		{Variant{ID: 1, NullFields: NullFields{"compare_at_price"}}, `{"compare_at_price":null,"id":1}`},
+		{&Product{ID: 1, NullFields: NullFields{"published_at"}}, `{"id":1,"image":{},"published_at":null}`},
+		{Customer{ID: 1, NullFields: NullFields{"note"}}, `{"id":1,"note":null}`},
+		{Order{ID: 1, NullFields: NullFields{"note"}}, `{"id":1,"note":null}`},
+		{Page{ID: 1, NullFields: NullFields{"published_at"}}, `{"id":1,"published_at":null}`},
+		{CustomCollection{ID: 1, NullFields: NullFields{"published_at"}}, `{"id":1,"published_at":null}`},
+		{SmartCollection{ID: 1, NullFields: NullFields{"published_at"}}, `{"id":1,"published_at":null}`},
+	}
+
+	for _, c := range cases {
+		data, err := json.Marshal(c.in)
+		if err != nil {
+			t.Errorf("json.Marshal(%#v) returned error: %v", c.in, err)
+			continue
+		}
+		if string(data) != c.expected {
+			t.Errorf("json.Marshal(%#v) = %s, expected %s", c.in, data, c.expected)
+		}
+	}
+}
+
+func TestMarshalWithUnknownNullField(t *testing.T) {
+	_, err := json.Marshal(Variant{NullFields: NullFields{"compare_price"}})
+	if err == nil {
+		t.Errorf("json.Marshal returned no error for an unknown null field")
+	}
+}
+
+func TestVariantUpdateNullFields(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var body string
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/variants/1.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			data, _ := io.ReadAll(req.Body)
+			body = string(data)
+			return httpmock.NewBytesResponse(200, loadFixture("variant.json")), nil
+		})
+
+	variant := Variant{ID: 1, NullFields: NullFields{"compare_at_price"}}
+	if _, err := client.Variant.Update(variant); err != nil {
+		t.Fatalf("Variant.Update returned error: %v", err)
+	}
+
+	expected := `{"variant":{"compare_at_price":null,"id":1}}`
+	if body != expected {
+		t.Errorf("Variant.Update sent %s, expected %s", body, expected)
+	}
+}
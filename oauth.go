@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"crypto/hmac"
 	"crypto/sha256"
+	"crypto/subtle"
 	"encoding/base64"
 	"encoding/hex"
 	"errors"
@@ -11,33 +12,135 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"regexp"
 )
 
 const shopifyChecksumHeader = "X-Shopify-Hmac-Sha256"
 
 var accessTokenRelPath = "admin/oauth/access_token"
 
+// Grant options of the authorization url.
+// See: https://shopify.dev/apps/auth/oauth/access-modes
+const (
+	// GrantOptionPerUser asks for an online access token tied to the user
+	// installing the app, which expires with their session
+	GrantOptionPerUser = "per-user"
+)
+
+// Errors returned by VerifyCallback.
+var (
+	ErrInvalidCallbackSignature = errors.New("invalid oauth callback signature")
+	ErrInvalidCallbackState     = errors.New("oauth callback state does not match")
+	ErrInvalidShopDomain        = errors.New("invalid shop domain")
+)
+
+// shopDomainRegex matches the myshopify domains Shopify redirects back from.
+var shopDomainRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9\-]*\.myshopify\.com$`)
+
+// AuthorizeOptions customizes the authorization url built by
+// AuthorizeUrlWithOptions. Empty fields fall back to the app's settings.
+type AuthorizeOptions struct {
+	Scope        string
+	RedirectUrl  string
+	GrantOptions []string
+}
+
+// AccessToken is the result of exchanging an authorization code. Online
+// tokens, requested with GrantOptionPerUser, expire and carry the user they
+// act for.
+type AccessToken struct {
+	AccessToken         string          `json:"access_token"`
+	Scope               string          `json:"scope"`
+	ExpiresIn           int             `json:"expires_in,omitempty"`
+	AssociatedUserScope string          `json:"associated_user_scope,omitempty"`
+	AssociatedUser      *AssociatedUser `json:"associated_user,omitempty"`
+}
+
+// AssociatedUser is the user an online access token acts for.
+type AssociatedUser struct {
+	ID            int64  `json:"id"`
+	FirstName     string `json:"first_name"`
+	LastName      string `json:"last_name"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	AccountOwner  bool   `json:"account_owner"`
+	Locale        string `json:"locale"`
+	Collaborator  bool   `json:"collaborator"`
+}
+
 // Returns a Shopify oauth authorization url for the given shopname and state.
 //
 // State is a unique value that can be used to check the authenticity during a
 // callback from Shopify.
 func (app App) AuthorizeUrl(shopName string, state string) string {
+	return app.AuthorizeUrlWithOptions(shopName, state, AuthorizeOptions{})
+}
+
+// AuthorizeUrlWithOptions returns a Shopify oauth authorization url like
+// AuthorizeUrl, with the scope, redirect url or grant options given in
+// options.
+func (app App) AuthorizeUrlWithOptions(shopName string, state string, options AuthorizeOptions) string {
+	scope := app.Scope
+	if options.Scope != "" {
+		scope = options.Scope
+	}
+	redirectUrl := app.RedirectUrl
+	if options.RedirectUrl != "" {
+		redirectUrl = options.RedirectUrl
+	}
+
 	shopUrl, _ := url.Parse(ShopBaseUrl(shopName))
 	shopUrl.Path = "/admin/oauth/authorize"
 	query := shopUrl.Query()
 	query.Set("client_id", app.ApiKey)
-	query.Set("redirect_uri", app.RedirectUrl)
-	query.Set("scope", app.Scope)
+	query.Set("redirect_uri", redirectUrl)
+	query.Set("scope", scope)
 	query.Set("state", state)
+	for _, option := range options.GrantOptions {
+		query.Add("grant_options[]", option)
+	}
 	shopUrl.RawQuery = query.Encode()
 	return shopUrl.String()
 }
 
+// VerifyCallback checks the url Shopify redirected to after authorization:
+// the hmac must be valid, the state must be the one the authorization url
+// was built with and the shop must be a myshopify domain. Only then is it
+// safe to exchange the code with ExchangeCode.
+func (app App) VerifyCallback(u *url.URL, state string) error {
+	if ok, err := app.VerifyAuthorizationURL(u); !ok || err != nil {
+		return ErrInvalidCallbackSignature
+	}
+
+	query := u.Query()
+	if subtle.ConstantTimeCompare([]byte(query.Get("state")), []byte(state)) != 1 {
+		return ErrInvalidCallbackState
+	}
+	if !ValidShopDomain(query.Get("shop")) {
+		return ErrInvalidShopDomain
+	}
+	return nil
+}
+
+// ValidShopDomain reports whether shop is a myshopify domain, e.g.
+// "theshop.myshopify.com". Shop names taken from requests must be checked
+// before sending credentials to them.
+func ValidShopDomain(shop string) bool {
+	return shopDomainRegex.MatchString(shop)
+}
+
 func (app App) GetAccessToken(shopName string, code string) (string, error) {
-	type Token struct {
-		Token string `json:"access_token"`
+	token, err := app.ExchangeCode(shopName, code)
+	if err != nil {
+		return "", err
 	}
+	return token.AccessToken, nil
+}
 
+// ExchangeCode exchanges the authorization code of a callback for an access
+// token, returning the granted scope and, for online tokens, the expiry and
+// associated user along with it.
+func (app App) ExchangeCode(shopName string, code string) (*AccessToken, error) {
 	data := struct {
 		ClientId     string `json:"client_id"`
 		ClientSecret string `json:"client_secret"`
@@ -47,7 +150,11 @@ func (app App) GetAccessToken(shopName string, code string) (string, error) {
 		ClientSecret: app.ApiSecret,
 		Code:         code,
 	}
+	return app.requestAccessToken(shopName, data)
+}
 
+// requestAccessToken posts data to the shop's access token endpoint.
+func (app App) requestAccessToken(shopName string, data interface{}) (*AccessToken, error) {
 	client := app.Client
 	if client == nil {
 		client = NewClient(app, shopName, "")
@@ -55,12 +162,15 @@ func (app App) GetAccessToken(shopName string, code string) (string, error) {
 
 	req, err := client.NewRequest("POST", accessTokenRelPath, data, nil)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	token := new(Token)
+	token := new(AccessToken)
 	err = client.Do(req, token)
-	return token.Token, err
+	if err != nil {
+		return nil, err
+	}
+	return token, nil
 }
 
 // Verify a message against a message HMAC
@@ -1,11 +1,15 @@
 package goshopify
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -69,6 +73,7 @@ func TestAppGetAccessTokenError(t *testing.T) {
 	}
 
 	expectedError = errors.New("parse ://example.com: missing protocol scheme")
+	defer func(relPath string) { accessTokenRelPath = relPath }(accessTokenRelPath)
 	accessTokenRelPath = "://example.com" // cause NewRequest to trip a parse error
 	token, err = app.GetAccessToken("fooshop", "")
 	if err == nil || !strings.Contains(err.Error(), "missing protocol scheme") {
@@ -235,3 +240,118 @@ func TestVerifyWebhookRequestVerbose(t *testing.T) {
 	}
 
 }
+
+func TestAppAuthorizeUrlWithOptions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	actual := app.AuthorizeUrlWithOptions("fooshop", "thenonce", AuthorizeOptions{
+		Scope:        "read_orders,write_products",
+		GrantOptions: []string{GrantOptionPerUser},
+	})
+	expected := "https://fooshop.myshopify.com/admin/oauth/authorize?client_id=apikey&grant_options%5B%5D=per-user&redirect_uri=https%3A%2F%2Fexample.com%2Fcallback&scope=read_orders%2Cwrite_products&state=thenonce"
+	if actual != expected {
+		t.Errorf("App.AuthorizeUrlWithOptions(): expected %s, actual %s", expected, actual)
+	}
+
+	actual = app.AuthorizeUrlWithOptions("fooshop", "thenonce", AuthorizeOptions{RedirectUrl: "https://example.com/other"})
+	expected = "https://fooshop.myshopify.com/admin/oauth/authorize?client_id=apikey&redirect_uri=https%3A%2F%2Fexample.com%2Fother&scope=read_products&state=thenonce"
+	if actual != expected {
+		t.Errorf("App.AuthorizeUrlWithOptions(): expected %s, actual %s", expected, actual)
+	}
+}
+
+// signedCallbackURL builds an oauth callback url signed with the test app's
+// secret.
+func signedCallbackURL(query url.Values) *url.URL {
+	message, _ := url.QueryUnescape(query.Encode())
+	mac := hmac.New(sha256.New, []byte(app.ApiSecret))
+	mac.Write([]byte(message))
+	query.Set("hmac", hex.EncodeToString(mac.Sum(nil)))
+
+	u, _ := url.Parse("https://example.com/callback")
+	u.RawQuery = query.Encode()
+	return u
+}
+
+func TestAppVerifyCallback(t *testing.T) {
+	setup()
+	defer teardown()
+
+	valid := signedCallbackURL(url.Values{"code": {"abc"}, "shop": {"some-shop.myshopify.com"}, "state": {"thenonce"}, "timestamp": {"1337178173"}})
+	otherShop := signedCallbackURL(url.Values{"code": {"abc"}, "shop": {"evil.example.com"}, "state": {"thenonce"}, "timestamp": {"1337178173"}})
+	tampered, _ := url.Parse(valid.String())
+	q := tampered.Query()
+	q.Set("shop", "other-shop.myshopify.com")
+	tampered.RawQuery = q.Encode()
+
+	cases := []struct {
+		description string
+		u           *url.URL
+		state       string
+		expected    error
+	}{
+		{"valid", valid, "thenonce", nil},
+		{"other state", valid, "othernonce", ErrInvalidCallbackState},
+		{"tampered", tampered, "thenonce", ErrInvalidCallbackSignature},
+		{"not a myshopify domain", otherShop, "thenonce", ErrInvalidShopDomain},
+	}
+
+	for _, c := range cases {
+		if err := app.VerifyCallback(c.u, c.state); err != c.expected {
+			t.Errorf("%s: App.VerifyCallback returned %v, expected %v", c.description, err, c.expected)
+		}
+	}
+}
+
+func TestValidShopDomain(t *testing.T) {
+	cases := map[string]bool{
+		"fooshop.myshopify.com":          true,
+		"foo-shop-2.myshopify.com":       true,
+		"fooshop":                        false,
+		"-foo.myshopify.com":             false,
+		"fooshop.myshopify.com.evil.com": false,
+		"evil.com/fooshop.myshopify.com": false,
+		"https://fooshop.myshopify.com":  false,
+		"foo.shop.myshopify.com":         false,
+	}
+
+	for shop, expected := range cases {
+		if actual := ValidShopDomain(shop); actual != expected {
+			t.Errorf("ValidShopDomain(%q) returned %v, expected %v", shop, actual, expected)
+		}
+	}
+}
+
+func TestAppExchangeCode(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", "https://fooshop.myshopify.com/admin/oauth/access_token",
+		httpmock.NewStringResponder(200, `{"access_token":"footoken","scope":"write_orders,read_customers","expires_in":86399,"associated_user_scope":"write_orders","associated_user":{"id":902541635,"first_name":"John","last_name":"Smith","email":"john@example.com","email_verified":true,"account_owner":true,"locale":"en","collaborator":false}}`))
+
+	app.Client = client
+	token, err := app.ExchangeCode("fooshop", "foocode")
+	if err != nil {
+		t.Fatalf("App.ExchangeCode(): %v", err)
+	}
+
+	expected := &AccessToken{
+		AccessToken:         "footoken",
+		Scope:               "write_orders,read_customers",
+		ExpiresIn:           86399,
+		AssociatedUserScope: "write_orders",
+		AssociatedUser: &AssociatedUser{
+			ID:            902541635,
+			FirstName:     "John",
+			LastName:      "Smith",
+			Email:         "john@example.com",
+			EmailVerified: true,
+			AccountOwner:  true,
+			Locale:        "en",
+		},
+	}
+	if !reflect.DeepEqual(token, expected) {
+		t.Errorf("App.ExchangeCode() returned %+v, expected %+v", token, expected)
+	}
+}
@@ -3,14 +3,26 @@ package goshopify
 import (
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
 )
 
 // Option is used to configure client with options
 type Option func(c *Client)
 
-// WithVersion optionally sets the api-version if the passed string is valid
+// WithVersion optionally sets the api-version if the passed string is valid.
+// VersionLatest pins the client to the latest stable version at the time it
+// is created, the version is logged at the info level.
 func WithVersion(apiVersion string) Option {
 	return func(c *Client) {
+		c.versionAlias = ""
+		switch apiVersion {
+		case VersionLatest:
+			c.versionAlias = VersionLatest
+			apiVersion = LatestStableVersion(time.Now())
+		case VersionUnstable:
+			c.versionAlias = VersionUnstable
+		}
 		pathPrefix := defaultApiPathPrefix
 		if len(apiVersion) > 0 && (apiVersionRegex.MatchString(apiVersion) || apiVersion == UnstableApiVersion) {
 			pathPrefix = fmt.Sprintf("admin/api/%s", apiVersion)
@@ -20,21 +32,208 @@ func WithVersion(apiVersion string) Option {
 	}
 }
 
+// WithAccessToken authenticates requests with an Admin API access token,
+// e.g. the token of a custom app created in the Shopify admin, even when the
+// App has private app credentials.
+func WithAccessToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+		c.authMode = authModeAccessToken
+	}
+}
+
+// WithPrivateAppAuth authenticates requests with the API key and password of
+// a private app through basic auth, even when an access token is set.
+func WithPrivateAppAuth(apiKey, password string) Option {
+	return func(c *Client) {
+		c.app.ApiKey = apiKey
+		c.app.Password = password
+		c.authMode = authModePrivateApp
+	}
+}
+
 func WithRetry(retries int) Option {
 	return func(c *Client) {
 		c.retries = retries
 	}
 }
 
+// WithRetryPolicy retries 5xx responses and temporary network errors with
+// exponential backoff as described by the policy. Rate limited responses keep
+// being retried according to WithRetry.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retryPolicy = &policy
+	}
+}
+
+// WithCircuitBreaker stops sending requests for a while once the shop keeps
+// failing, so an outage fails fast with ErrCircuitOpen instead of tying up
+// workers with requests and retries bound to fail. The breaker is shared by
+// all goroutines using the client.
+func WithCircuitBreaker(breaker CircuitBreaker) Option {
+	return func(c *Client) {
+		c.breaker = newCircuitBreaker(breaker)
+	}
+}
+
+// WithRateLimiter makes the client throttle itself so it never exceeds the
+// given REST and GraphQL buckets, even when it is shared by many goroutines.
+// Use the Plus limits for Shopify Plus stores. A zero BucketLimits disables
+// throttling of that API.
+//
+// The buckets are kept in sync with the call limit and query cost reported
+// by Shopify. Since the cost of a GraphQL query is only known afterwards, the
+// requested cost of the previous query is reserved for the next one.
+func WithRateLimiter(rest, graphQL BucketLimits) Option {
+	return func(c *Client) {
+		c.restBucket = newLeakyBucket(rest, 1)
+		c.graphQLBucket = newLeakyBucket(graphQL, defaultGraphQLCost)
+	}
+}
+
+// WithOnRateLimited sets a hook called with the shop's domain and the wait
+// whenever the client is about to wait for the rate limit: before retrying a
+// 429 response, while the bucket of WithRateLimiter is full and while the call
+// limit of paginators and batches is reached. It is called from the goroutine
+// making the request and must not block.
+func WithOnRateLimited(onRateLimited func(shop string, wait time.Duration)) Option {
+	return func(c *Client) {
+		c.onRateLimited = onRateLimited
+	}
+}
+
+// WithDeprecationHandler sets a handler that is called whenever Shopify
+// reports a request used a deprecated endpoint or field. Without a handler
+// deprecations are logged as warnings.
+func WithDeprecationHandler(handler DeprecationHandler) Option {
+	return func(c *Client) {
+		c.deprecationHandler = handler
+	}
+}
+
+// WithHeaders sets headers that are sent with every request, e.g. attribution
+// headers requested by a Shopify program. They can't replace the
+// authentication headers.
+func WithHeaders(headers http.Header) Option {
+	return func(c *Client) {
+		if c.headers == nil {
+			c.headers = http.Header{}
+		}
+		for name, values := range headers {
+			for _, value := range values {
+				c.headers.Add(name, value)
+			}
+		}
+	}
+}
+
+// WithUserAgent identifies the app in the User-Agent of every request, e.g.
+// "my-app/2.3.1", so Shopify can attribute the traffic to the app and its
+// build. The library's own User-Agent is appended.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithApiFeatures opts every request into the given API features through the
+// X-Shopify-Api-Features header, e.g. "include-presentment-prices".
+func WithApiFeatures(features ...string) Option {
+	return WithHeaders(http.Header{ApiFeaturesHeader: {strings.Join(features, ",")}})
+}
+
+// WithMetrics reports the outcome of every request to metrics.
+func WithMetrics(metrics Metrics) Option {
+	return func(c *Client) {
+		c.metrics = metrics
+	}
+}
+
 func WithLogger(logger LeveledLoggerInterface) Option {
 	return func(c *Client) {
 		c.log = logger
 	}
 }
 
+// WithDebugDumps makes the client log the headers of every request and
+// response along with their bodies, truncated to maxBodySize bytes unless it
+// is zero. Dumps are logged at the debug level, use WithLogger to enable it.
+// Credentials like the access token are always redacted from the logs.
+func WithDebugDumps(maxBodySize int) Option {
+	return func(c *Client) {
+		c.debugDumps = true
+		c.debugDumpMaxBody = maxBodySize
+	}
+}
+
 // WithHTTPClient is used to set a custom http client
 func WithHTTPClient(client *http.Client) Option {
 	return func(c *Client) {
 		c.Client = client
 	}
 }
+
+// WithTransport sets the transport of the http client, e.g. to go through a
+// proxy, use client certificates or share a connection pool. The http client
+// is copied first so a client passed to WithHTTPClient is not modified.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *Client) {
+		client := *c.Client
+		client.Transport = transport
+		c.Client = &client
+	}
+}
+
+// WithStrictDecoding makes the client fail when a response contains fields
+// that the destination struct does not model. This is meant for tests that
+// want to surface schema drift between the structs and Shopify's responses,
+// production clients should keep the default lenient decoding and use
+// WithUnknownFieldHandler instead.
+func WithStrictDecoding() Option {
+	return func(c *Client) {
+		c.strictDecoding = true
+	}
+}
+
+// WithRawResponses keeps the body of every decoded response in the Raw field
+// of LastResponse, e.g. to persist the exact payload for audits. Use
+// Client.GetRaw to get the body of a single call.
+func WithRawResponses() Option {
+	return func(c *Client) {
+		c.rawResponses = true
+	}
+}
+
+// WithETagCache makes GET requests conditional on the ETag and Last-Modified
+// headers of the responses kept in the cache. When Shopify responds with 304
+// Not Modified the cached response is decoded instead, which makes polling
+// cheaper.
+func WithETagCache(cache ETagCache) Option {
+	return func(c *Client) {
+		c.etagCache = cache
+	}
+}
+
+// WithCache makes the client answer GET requests from the cache while their
+// responses are younger than the ttl, without calling the API. Pass the top
+// level resources to cache, e.g. "shop" or "locations", to leave the others
+// uncached, by default every GET request is cached. Changes made in the
+// meantime are only seen once the cached responses expired.
+func WithCache(cache Cache, ttl time.Duration, resources ...string) Option {
+	return func(c *Client) {
+		c.cache = cache
+		c.cacheTTL = ttl
+		c.cacheResources = resources
+	}
+}
+
+// WithUnknownFieldHandler sets a handler that is called with the fields of a
+// response the destination struct does not model, which are otherwise
+// silently dropped. Decoding still succeeds, so this can be used in
+// production to notice when Shopify adds or renames fields.
+func WithUnknownFieldHandler(handler UnknownFieldHandler) Option {
+	return func(c *Client) {
+		c.unknownFieldHandler = handler
+	}
+}
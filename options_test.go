@@ -72,3 +72,122 @@ func TestWithHTTPClient(t *testing.T) {
 		t.Errorf("WithVersion client.Client = %s, expected %s", c.Client.Timeout, expected)
 	}
 }
+
+func TestWithStrictDecoding(t *testing.T) {
+	c := NewClient(app, "fooshop", "abcd")
+	if c.strictDecoding {
+		t.Errorf("NewClient client.strictDecoding = %v, expected %v", c.strictDecoding, false)
+	}
+
+	c = NewClient(app, "fooshop", "abcd", WithStrictDecoding())
+	if !c.strictDecoding {
+		t.Errorf("WithStrictDecoding client.strictDecoding = %v, expected %v", c.strictDecoding, true)
+	}
+}
+
+func TestWithRawResponses(t *testing.T) {
+	c := NewClient(app, "fooshop", "abcd")
+	if c.rawResponses {
+		t.Errorf("NewClient client.rawResponses = %v, expected %v", c.rawResponses, false)
+	}
+
+	c = NewClient(app, "fooshop", "abcd", WithRawResponses())
+	if !c.rawResponses {
+		t.Errorf("WithRawResponses client.rawResponses = %v, expected %v", c.rawResponses, true)
+	}
+}
+
+func TestWithETagCache(t *testing.T) {
+	cache := NewMemoryETagCache(0)
+	c := NewClient(app, "fooshop", "abcd", WithETagCache(cache))
+	if c.etagCache != cache {
+		t.Errorf("WithETagCache client.etagCache = %v, expected %v", c.etagCache, cache)
+	}
+}
+
+func TestWithCache(t *testing.T) {
+	cache := NewMemoryCache()
+	c := NewClient(app, "fooshop", "abcd", WithCache(cache, time.Minute, "shop"))
+	if c.cache != cache || c.cacheTTL != time.Minute || len(c.cacheResources) != 1 {
+		t.Errorf("WithCache client.cache = %v, cacheTTL = %s, cacheResources = %v", c.cache, c.cacheTTL, c.cacheResources)
+	}
+}
+
+func TestWithDebugDumps(t *testing.T) {
+	c := NewClient(app, "fooshop", "abcd", WithDebugDumps(1024))
+	if !c.debugDumps || c.debugDumpMaxBody != 1024 {
+		t.Errorf("WithDebugDumps client.debugDumps = %v, debugDumpMaxBody = %d", c.debugDumps, c.debugDumpMaxBody)
+	}
+}
+
+func TestWithUnknownFieldHandler(t *testing.T) {
+	c := NewClient(app, "fooshop", "abcd")
+	if c.unknownFieldHandler != nil {
+		t.Errorf("NewClient client.unknownFieldHandler is set, expected nil")
+	}
+
+	c = NewClient(app, "fooshop", "abcd", WithUnknownFieldHandler(func(UnknownFields) {}))
+	if c.unknownFieldHandler == nil {
+		t.Errorf("WithUnknownFieldHandler client.unknownFieldHandler is nil")
+	}
+}
+
+func TestWithRetryPolicy(t *testing.T) {
+	c := NewClient(app, "fooshop", "abcd")
+	if c.retryPolicy != nil {
+		t.Errorf("NewClient client.retryPolicy = %v, expected nil", c.retryPolicy)
+	}
+
+	policy := DefaultRetryPolicy()
+	c = NewClient(app, "fooshop", "abcd", WithRetryPolicy(policy))
+	if c.retryPolicy == nil || c.retryPolicy.MaxRetries != policy.MaxRetries {
+		t.Errorf("WithRetryPolicy client.retryPolicy = %v, expected %v", c.retryPolicy, policy)
+	}
+}
+
+func TestWithDeprecationHandler(t *testing.T) {
+	called := false
+	c := NewClient(app, "fooshop", "abcd", WithDeprecationHandler(func(Deprecation) { called = true }))
+	if c.deprecationHandler == nil {
+		t.Fatalf("WithDeprecationHandler client.deprecationHandler = nil")
+	}
+
+	c.deprecationHandler(Deprecation{})
+	if !called {
+		t.Errorf("WithDeprecationHandler did not set the given handler")
+	}
+}
+
+func TestWithTransport(t *testing.T) {
+	transport := &http.Transport{MaxIdleConnsPerHost: 42}
+	c := NewClient(app, "fooshop", "abcd", WithTransport(transport))
+	if c.Client.Transport != transport {
+		t.Errorf("WithTransport client.Client.Transport = %v, expected %v", c.Client.Transport, transport)
+	}
+	if c.Client.Timeout != time.Second*defaultHttpTimeout {
+		t.Errorf("WithTransport client.Client.Timeout = %s, expected the default", c.Client.Timeout)
+	}
+
+	// the client passed to WithHTTPClient is left untouched
+	httpClient := &http.Client{Timeout: time.Second}
+	c = NewClient(app, "fooshop", "abcd", WithHTTPClient(httpClient), WithTransport(transport))
+	if httpClient.Transport != nil {
+		t.Errorf("WithTransport modified the client passed to WithHTTPClient")
+	}
+	if c.Client.Transport != transport || c.Client.Timeout != time.Second {
+		t.Errorf("WithTransport client.Client = %+v, expected the transport on a copy of the http client", c.Client)
+	}
+}
+
+func TestWithUserAgent(t *testing.T) {
+	c := NewClient(app, "fooshop", "abcd", WithUserAgent("my-app/2.3.1"))
+	req, err := c.NewRequest("GET", "foo", nil, nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+
+	expected := "my-app/2.3.1 " + UserAgent
+	if got := req.Header.Get("User-Agent"); got != expected {
+		t.Errorf("WithUserAgent User-Agent = %q, expected %q", got, expected)
+	}
+}
@@ -1,9 +1,9 @@
 package goshopify
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
 	"time"
 
 	"github.com/shopspring/decimal"
@@ -16,15 +16,21 @@ const ordersResourceName = "orders"
 // the Shopify API.
 // See: https://help.shopify.com/api/reference/order
 type OrderService interface {
-	List(interface{}) ([]Order, error)
-	ListWithPagination(interface{}) ([]Order, *Pagination, error)
+	List(*OrderListOptions) ([]Order, error)
+	ListWithPagination(*OrderListOptions) ([]Order, *Pagination, error)
 	Count(interface{}) (int, error)
 	Get(int64, interface{}) (*Order, error)
 	Create(Order) (*Order, error)
 	Update(Order) (*Order, error)
+	UpdateFields(int64, *Update) (*Order, error)
 	Cancel(int64, interface{}) (*Order, error)
 	Close(int64) (*Order, error)
 	Open(int64) (*Order, error)
+	Iterate(context.Context, *OrderListOptions) *OrderIterator
+	Paginate(*OrderListOptions) *Paginator[Order]
+	ListByIDs(context.Context, []int64) ([]Order, error)
+	CalculateRefund(int64, RefundCalculation) (*Refund, error)
+	PreviewCancel(int64, bool) (*RefundPreview, error)
 
 	// MetafieldsService used for Order resource to communicate with Metafields resource
 	MetafieldsService
@@ -39,32 +45,80 @@ type OrderServiceOp struct {
 	client *Client
 }
 
+// OrderStatus filters orders by their status. Orders are listed as open by
+// default, OrderStatusAny includes the closed and cancelled ones.
+type OrderStatus string
+
+const (
+	OrderStatusOpen      OrderStatus = "open"
+	OrderStatusClosed    OrderStatus = "closed"
+	OrderStatusCancelled OrderStatus = "cancelled"
+	OrderStatusAny       OrderStatus = "any"
+)
+
+// OrderFinancialStatus is the status of the payments of an order, which
+// orders are also filtered by. Unpaid and Any only filter orders.
+type OrderFinancialStatus string
+
+const (
+	OrderFinancialStatusAuthorized        OrderFinancialStatus = "authorized"
+	OrderFinancialStatusPending           OrderFinancialStatus = "pending"
+	OrderFinancialStatusPaid              OrderFinancialStatus = "paid"
+	OrderFinancialStatusPartiallyPaid     OrderFinancialStatus = "partially_paid"
+	OrderFinancialStatusRefunded          OrderFinancialStatus = "refunded"
+	OrderFinancialStatusVoided            OrderFinancialStatus = "voided"
+	OrderFinancialStatusPartiallyRefunded OrderFinancialStatus = "partially_refunded"
+	OrderFinancialStatusExpired           OrderFinancialStatus = "expired"
+	OrderFinancialStatusUnpaid            OrderFinancialStatus = "unpaid"
+	OrderFinancialStatusAny               OrderFinancialStatus = "any"
+)
+
+// OrderFulfillmentStatus is the status of the fulfillments of an order or a
+// line item, which orders are also filtered by. Shipped, Unshipped,
+// Unfulfilled and Any only filter orders, while Fulfilled, Restocked and
+// NotEligible are only reported. Unfulfilled orders and line items have an
+// empty status.
+type OrderFulfillmentStatus string
+
+const (
+	OrderFulfillmentStatusShipped     OrderFulfillmentStatus = "shipped"
+	OrderFulfillmentStatusPartial     OrderFulfillmentStatus = "partial"
+	OrderFulfillmentStatusUnshipped   OrderFulfillmentStatus = "unshipped"
+	OrderFulfillmentStatusUnfulfilled OrderFulfillmentStatus = "unfulfilled"
+	OrderFulfillmentStatusAny         OrderFulfillmentStatus = "any"
+	OrderFulfillmentStatusFulfilled   OrderFulfillmentStatus = "fulfilled"
+	OrderFulfillmentStatusRestocked   OrderFulfillmentStatus = "restocked"
+	OrderFulfillmentStatusNotEligible OrderFulfillmentStatus = "not_eligible"
+)
+
 // A struct for all available order count options
 type OrderCountOptions struct {
-	Page              int       `url:"page,omitempty"`
-	Limit             int       `url:"limit,omitempty"`
-	SinceID           int64     `url:"since_id,omitempty"`
-	CreatedAtMin      time.Time `url:"created_at_min,omitempty"`
-	CreatedAtMax      time.Time `url:"created_at_max,omitempty"`
-	UpdatedAtMin      time.Time `url:"updated_at_min,omitempty"`
-	UpdatedAtMax      time.Time `url:"updated_at_max,omitempty"`
-	Order             string    `url:"order,omitempty"`
-	Fields            string    `url:"fields,omitempty"`
-	Status            string    `url:"status,omitempty"`
-	FinancialStatus   string    `url:"financial_status,omitempty"`
-	FulfillmentStatus string    `url:"fulfillment_status,omitempty"`
-}
-
-// A struct for all available order list options.
+	Page              int                    `url:"page,omitempty"`
+	Limit             int                    `url:"limit,omitempty"`
+	SinceID           int64                  `url:"since_id,omitempty"`
+	CreatedAtMin      time.Time              `url:"created_at_min,omitempty"`
+	CreatedAtMax      time.Time              `url:"created_at_max,omitempty"`
+	UpdatedAtMin      time.Time              `url:"updated_at_min,omitempty"`
+	UpdatedAtMax      time.Time              `url:"updated_at_max,omitempty"`
+	Order             string                 `url:"order,omitempty"`
+	Fields            string                 `url:"fields,omitempty"`
+	Status            OrderStatus            `url:"status,omitempty"`
+	FinancialStatus   OrderFinancialStatus   `url:"financial_status,omitempty"`
+	FulfillmentStatus OrderFulfillmentStatus `url:"fulfillment_status,omitempty"`
+}
+
+// A struct for all available order list options. Following pages only
+// accept the page info, limit and fields, use
+// &OrderListOptions{ListOptions: *pagination.NextPageOptions}.
 // See: https://help.shopify.com/api/reference/order#index
 type OrderListOptions struct {
 	ListOptions
-	Status            string    `url:"status,omitempty"`
-	FinancialStatus   string    `url:"financial_status,omitempty"`
-	FulfillmentStatus string    `url:"fulfillment_status,omitempty"`
-	ProcessedAtMin    time.Time `url:"processed_at_min,omitempty"`
-	ProcessedAtMax    time.Time `url:"processed_at_max,omitempty"`
-	Order             string    `url:"order,omitempty"`
+	Status            OrderStatus            `url:"status,omitempty"`
+	FinancialStatus   OrderFinancialStatus   `url:"financial_status,omitempty"`
+	FulfillmentStatus OrderFulfillmentStatus `url:"fulfillment_status,omitempty"`
+	ProcessedAtMin    time.Time              `url:"processed_at_min,omitempty"`
+	ProcessedAtMax    time.Time              `url:"processed_at_max,omitempty"`
+	Order             string                 `url:"order,omitempty"`
 }
 
 // A struct of all available order cancel options.
@@ -80,69 +134,90 @@ type OrderCancelOptions struct {
 
 // Order represents a Shopify order
 type Order struct {
-	ID                    int64            `json:"id,omitempty"`
-	Name                  string           `json:"name,omitempty"`
-	Email                 string           `json:"email,omitempty"`
-	CreatedAt             *time.Time       `json:"created_at,omitempty"`
-	UpdatedAt             *time.Time       `json:"updated_at,omitempty"`
-	CancelledAt           *time.Time       `json:"cancelled_at,omitempty"`
-	ClosedAt              *time.Time       `json:"closed_at,omitempty"`
-	ProcessedAt           *time.Time       `json:"processed_at,omitempty"`
-	Customer              *Customer        `json:"customer,omitempty"`
-	BillingAddress        *Address         `json:"billing_address,omitempty"`
-	ShippingAddress       *Address         `json:"shipping_address,omitempty"`
-	Currency              string           `json:"currency,omitempty"`
-	TotalPrice            *decimal.Decimal `json:"total_price,omitempty"`
-	SubtotalPrice         *decimal.Decimal `json:"subtotal_price,omitempty"`
-	TotalDiscounts        *decimal.Decimal `json:"total_discounts,omitempty"`
-	TotalLineItemsPrice   *decimal.Decimal `json:"total_line_items_price,omitempty"`
-	TaxesIncluded         bool             `json:"taxes_included,omitempty"`
-	TotalTax              *decimal.Decimal `json:"total_tax,omitempty"`
-	TaxLines              []TaxLine        `json:"tax_lines,omitempty"`
-	TotalWeight           int              `json:"total_weight,omitempty"`
-	FinancialStatus       string           `json:"financial_status,omitempty"`
-	Fulfillments          []Fulfillment    `json:"fulfillments,omitempty"`
-	FulfillmentStatus     string           `json:"fulfillment_status,omitempty"`
-	Token                 string           `json:"token,omitempty"`
-	CartToken             string           `json:"cart_token,omitempty"`
-	Number                int              `json:"number,omitempty"`
-	OrderNumber           int              `json:"order_number,omitempty"`
-	Note                  string           `json:"note,omitempty"`
-	Test                  bool             `json:"test,omitempty"`
-	BrowserIp             string           `json:"browser_ip,omitempty"`
-	BuyerAcceptsMarketing bool             `json:"buyer_accepts_marketing,omitempty"`
-	CancelReason          string           `json:"cancel_reason,omitempty"`
-	NoteAttributes        []NoteAttribute  `json:"note_attributes,omitempty"`
-	DiscountCodes         []DiscountCode   `json:"discount_codes,omitempty"`
-	LineItems             []LineItem       `json:"line_items,omitempty"`
-	ShippingLines         []ShippingLines  `json:"shipping_lines,omitempty"`
-	Transactions          []Transaction    `json:"transactions,omitempty"`
-	AppID                 int              `json:"app_id,omitempty"`
-	CustomerLocale        string           `json:"customer_locale,omitempty"`
-	LandingSite           string           `json:"landing_site,omitempty"`
-	ReferringSite         string           `json:"referring_site,omitempty"`
-	SourceName            string           `json:"source_name,omitempty"`
-	ClientDetails         *ClientDetails   `json:"client_details,omitempty"`
-	Tags                  string           `json:"tags,omitempty"`
-	LocationId            int64            `json:"location_id,omitempty"`
-	PaymentGatewayNames   []string         `json:"payment_gateway_names,omitempty"`
-	ProcessingMethod      string           `json:"processing_method,omitempty"`
-	Refunds               []Refund         `json:"refunds,omitempty"`
-	UserId                int64            `json:"user_id,omitempty"`
-	OrderStatusUrl        string           `json:"order_status_url,omitempty"`
-	Gateway               string           `json:"gateway,omitempty"`
-	Confirmed             bool             `json:"confirmed,omitempty"`
-	TotalPriceUSD         *decimal.Decimal `json:"total_price_usd,omitempty"`
-	CheckoutToken         string           `json:"checkout_token,omitempty"`
-	Reference             string           `json:"reference,omitempty"`
-	SourceIdentifier      string           `json:"source_identifier,omitempty"`
-	SourceURL             string           `json:"source_url,omitempty"`
-	DeviceID              int64            `json:"device_id,omitempty"`
-	Phone                 string           `json:"phone,omitempty"`
-	LandingSiteRef        string           `json:"landing_site_ref,omitempty"`
-	CheckoutID            int64            `json:"checkout_id,omitempty"`
-	ContactEmail          string           `json:"contact_email,omitempty"`
-	Metafields            []Metafield      `json:"metafields,omitempty"`
+	ID                    int64                  `json:"id,omitempty"`
+	Name                  string                 `json:"name,omitempty"`
+	Email                 string                 `json:"email,omitempty"`
+	CreatedAt             *time.Time             `json:"created_at,omitempty"`
+	UpdatedAt             *time.Time             `json:"updated_at,omitempty"`
+	CancelledAt           *time.Time             `json:"cancelled_at,omitempty"`
+	ClosedAt              *time.Time             `json:"closed_at,omitempty"`
+	ProcessedAt           *time.Time             `json:"processed_at,omitempty"`
+	Customer              *Customer              `json:"customer,omitempty"`
+	BillingAddress        *Address               `json:"billing_address,omitempty"`
+	ShippingAddress       *Address               `json:"shipping_address,omitempty"`
+	Currency              string                 `json:"currency,omitempty"`
+	TotalPrice            *decimal.Decimal       `json:"total_price,omitempty"`
+	SubtotalPrice         *decimal.Decimal       `json:"subtotal_price,omitempty"`
+	TotalDiscounts        *decimal.Decimal       `json:"total_discounts,omitempty"`
+	TotalLineItemsPrice   *decimal.Decimal       `json:"total_line_items_price,omitempty"`
+	TaxesIncluded         *bool                  `json:"taxes_included,omitempty"`
+	TotalTax              *decimal.Decimal       `json:"total_tax,omitempty"`
+	TaxLines              []TaxLine              `json:"tax_lines,omitempty"`
+	TotalWeight           int                    `json:"total_weight,omitempty"`
+	FinancialStatus       OrderFinancialStatus   `json:"financial_status,omitempty"`
+	Fulfillments          []Fulfillment          `json:"fulfillments,omitempty"`
+	FulfillmentStatus     OrderFulfillmentStatus `json:"fulfillment_status,omitempty"`
+	Token                 string                 `json:"token,omitempty"`
+	CartToken             string                 `json:"cart_token,omitempty"`
+	Number                int                    `json:"number,omitempty"`
+	OrderNumber           int                    `json:"order_number,omitempty"`
+	Note                  string                 `json:"note,omitempty"`
+	Test                  *bool                  `json:"test,omitempty"`
+	BrowserIp             string                 `json:"browser_ip,omitempty"`
+	BuyerAcceptsMarketing *bool                  `json:"buyer_accepts_marketing,omitempty"`
+	CancelReason          string                 `json:"cancel_reason,omitempty"`
+	NoteAttributes        []NoteAttribute        `json:"note_attributes,omitempty"`
+	DiscountCodes         []DiscountCode         `json:"discount_codes,omitempty"`
+	LineItems             []LineItem             `json:"line_items,omitempty"`
+	ShippingLines         []ShippingLines        `json:"shipping_lines,omitempty"`
+	Transactions          []Transaction          `json:"transactions,omitempty"`
+	AppID                 int                    `json:"app_id,omitempty"`
+	CustomerLocale        string                 `json:"customer_locale,omitempty"`
+	LandingSite           string                 `json:"landing_site,omitempty"`
+	ReferringSite         string                 `json:"referring_site,omitempty"`
+	SourceName            string                 `json:"source_name,omitempty"`
+	ClientDetails         *ClientDetails         `json:"client_details,omitempty"`
+	Tags                  Tags                   `json:"tags,omitempty"`
+	LocationId            int64                  `json:"location_id,omitempty"`
+	PaymentGatewayNames   []string               `json:"payment_gateway_names,omitempty"`
+	ProcessingMethod      string                 `json:"processing_method,omitempty"`
+	Refunds               []Refund               `json:"refunds,omitempty"`
+	UserId                int64                  `json:"user_id,omitempty"`
+	OrderStatusUrl        string                 `json:"order_status_url,omitempty"`
+	Gateway               string                 `json:"gateway,omitempty"`
+	Confirmed             bool                   `json:"confirmed,omitempty"`
+	TotalPriceUSD         *decimal.Decimal       `json:"total_price_usd,omitempty"`
+	CheckoutToken         string                 `json:"checkout_token,omitempty"`
+	Reference             string                 `json:"reference,omitempty"`
+	SourceIdentifier      string                 `json:"source_identifier,omitempty"`
+	SourceURL             string                 `json:"source_url,omitempty"`
+	DeviceID              int64                  `json:"device_id,omitempty"`
+	Phone                 string                 `json:"phone,omitempty"`
+	LandingSiteRef        string                 `json:"landing_site_ref,omitempty"`
+	CheckoutID            int64                  `json:"checkout_id,omitempty"`
+	ContactEmail          string                 `json:"contact_email,omitempty"`
+	Metafields            []Metafield            `json:"metafields,omitempty"`
+
+	// The totals in the shop and presentment currencies, see AmountSet.
+	PresentmentCurrency      string     `json:"presentment_currency,omitempty"`
+	TotalPriceSet            *AmountSet `json:"total_price_set,omitempty"`
+	SubtotalPriceSet         *AmountSet `json:"subtotal_price_set,omitempty"`
+	TotalDiscountsSet        *AmountSet `json:"total_discounts_set,omitempty"`
+	TotalLineItemsPriceSet   *AmountSet `json:"total_line_items_price_set,omitempty"`
+	TotalShippingPriceSet    *AmountSet `json:"total_shipping_price_set,omitempty"`
+	TotalTaxSet              *AmountSet `json:"total_tax_set,omitempty"`
+	CurrentTotalPriceSet     *AmountSet `json:"current_total_price_set,omitempty"`
+	CurrentSubtotalPriceSet  *AmountSet `json:"current_subtotal_price_set,omitempty"`
+	CurrentTotalDiscountsSet *AmountSet `json:"current_total_discounts_set,omitempty"`
+	CurrentTotalTaxSet       *AmountSet `json:"current_total_tax_set,omitempty"`
+
+	NullFields NullFields `json:"-"`
+}
+
+// MarshalJSON encodes the order, sending the NullFields as null.
+func (o Order) MarshalJSON() ([]byte, error) {
+	type order Order
+	return marshalWithNullFields(order(o), o.NullFields)
 }
 
 type Address struct {
@@ -171,33 +246,36 @@ type DiscountCode struct {
 }
 
 type LineItem struct {
-	ID                         int64                 `json:"id,omitempty"`
-	ProductID                  int64                 `json:"product_id,omitempty"`
-	VariantID                  int64                 `json:"variant_id,omitempty"`
-	Quantity                   int                   `json:"quantity,omitempty"`
-	Price                      *decimal.Decimal      `json:"price,omitempty"`
-	TotalDiscount              *decimal.Decimal      `json:"total_discount,omitempty"`
-	Title                      string                `json:"title,omitempty"`
-	VariantTitle               string                `json:"variant_title,omitempty"`
-	Name                       string                `json:"name,omitempty"`
-	SKU                        string                `json:"sku,omitempty"`
-	Vendor                     string                `json:"vendor,omitempty"`
-	GiftCard                   bool                  `json:"gift_card,omitempty"`
-	Taxable                    bool                  `json:"taxable,omitempty"`
-	FulfillmentService         string                `json:"fulfillment_service,omitempty"`
-	RequiresShipping           bool                  `json:"requires_shipping,omitempty"`
-	VariantInventoryManagement string                `json:"variant_inventory_management,omitempty"`
-	PreTaxPrice                *decimal.Decimal      `json:"pre_tax_price,omitempty"`
-	Properties                 []NoteAttribute       `json:"properties,omitempty"`
-	ProductExists              bool                  `json:"product_exists,omitempty"`
-	FulfillableQuantity        int                   `json:"fulfillable_quantity,omitempty"`
-	Grams                      int                   `json:"grams,omitempty"`
-	FulfillmentStatus          string                `json:"fulfillment_status,omitempty"`
-	TaxLines                   []TaxLine             `json:"tax_lines,omitempty"`
-	OriginLocation             *Address              `json:"origin_location,omitempty"`
-	DestinationLocation        *Address              `json:"destination_location,omitempty"`
-	AppliedDiscount            *AppliedDiscount      `json:"applied_discount,omitempty"`
-	DiscountAllocations        []DiscountAllocations `json:"discount_allocations,omitempty"`
+	ID                         int64                  `json:"id,omitempty"`
+	ProductID                  int64                  `json:"product_id,omitempty"`
+	VariantID                  int64                  `json:"variant_id,omitempty"`
+	Quantity                   int                    `json:"quantity,omitempty"`
+	Price                      *decimal.Decimal       `json:"price,omitempty"`
+	TotalDiscount              *decimal.Decimal       `json:"total_discount,omitempty"`
+	Title                      string                 `json:"title,omitempty"`
+	VariantTitle               string                 `json:"variant_title,omitempty"`
+	Name                       string                 `json:"name,omitempty"`
+	SKU                        string                 `json:"sku,omitempty"`
+	Vendor                     string                 `json:"vendor,omitempty"`
+	GiftCard                   *bool                  `json:"gift_card,omitempty"`
+	Taxable                    *bool                  `json:"taxable,omitempty"`
+	FulfillmentService         string                 `json:"fulfillment_service,omitempty"`
+	RequiresShipping           *bool                  `json:"requires_shipping,omitempty"`
+	VariantInventoryManagement string                 `json:"variant_inventory_management,omitempty"`
+	PreTaxPrice                *decimal.Decimal       `json:"pre_tax_price,omitempty"`
+	Properties                 []NoteAttribute        `json:"properties,omitempty"`
+	ProductExists              bool                   `json:"product_exists,omitempty"`
+	FulfillableQuantity        int                    `json:"fulfillable_quantity,omitempty"`
+	Grams                      int                    `json:"grams,omitempty"`
+	FulfillmentStatus          OrderFulfillmentStatus `json:"fulfillment_status,omitempty"`
+	TaxLines                   []TaxLine              `json:"tax_lines,omitempty"`
+	OriginLocation             *Address               `json:"origin_location,omitempty"`
+	DestinationLocation        *Address               `json:"destination_location,omitempty"`
+	AppliedDiscount            *AppliedDiscount       `json:"applied_discount,omitempty"`
+	DiscountAllocations        []DiscountAllocations  `json:"discount_allocations,omitempty"`
+	PriceSet                   *AmountSet             `json:"price_set,omitempty"`
+	TotalDiscountSet           *AmountSet             `json:"total_discount_set,omitempty"`
+	PreTaxPriceSet             *AmountSet             `json:"pre_tax_price_set,omitempty"`
 }
 
 type DiscountAllocations struct {
@@ -206,11 +284,14 @@ type DiscountAllocations struct {
 	AmountSet                AmountSet        `json:"amount_set,omitempty"`
 }
 
+// AmountSet is an amount in both the shop's currency and the currency the
+// customer was presented with, which differ on multi-currency stores.
 type AmountSet struct {
 	ShopMoney        AmountSetEntry `json:"shop_money,omitempty"`
 	PresentmentMoney AmountSetEntry `json:"presentment_money,omitempty"`
 }
 
+// AmountSetEntry is an amount in a currency, e.g. USD.
 type AmountSetEntry struct {
 	Amount       *decimal.Decimal `json:"amount,omitempty"`
 	CurrencyCode string           `json:"currency_code,omitempty"`
@@ -293,6 +374,8 @@ type ShippingLines struct {
 	DeliveryCategory              string           `json:"delivery_category,omitempty"`
 	CarrierIdentifier             string           `json:"carrier_identifier,omitempty"`
 	TaxLines                      []TaxLine        `json:"tax_lines,omitempty"`
+	PriceSet                      *AmountSet       `json:"price_set,omitempty"`
+	DiscountedPriceSet            *AmountSet       `json:"discounted_price_set,omitempty"`
 }
 
 // UnmarshalJSON custom unmarshaller for ShippingLines implemented to handle requested_fulfillment_service_id being
@@ -320,9 +403,10 @@ func (sl *ShippingLines) UnmarshalJSON(data []byte) error {
 }
 
 type TaxLine struct {
-	Title string           `json:"title,omitempty"`
-	Price *decimal.Decimal `json:"price,omitempty"`
-	Rate  *decimal.Decimal `json:"rate,omitempty"`
+	Title    string           `json:"title,omitempty"`
+	Price    *decimal.Decimal `json:"price,omitempty"`
+	Rate     *decimal.Decimal `json:"rate,omitempty"`
+	PriceSet *AmountSet       `json:"price_set,omitempty"`
 }
 
 type Transaction struct {
@@ -345,6 +429,12 @@ type Transaction struct {
 	SourceName     string           `json:"source_name,omitempty"`
 	Source         string           `json:"source,omitempty"`
 	PaymentDetails *PaymentDetails  `json:"payment_details,omitempty"`
+
+	AmountSet         *AmountSet `json:"amount_set,omitempty"`
+	TotalUnsettledSet *AmountSet `json:"total_unsettled_set,omitempty"`
+
+	// only set on suggested refund transactions, see CalculateRefund
+	MaximumRefundable *decimal.Decimal `json:"maximum_refundable,omitempty"`
 }
 
 type ClientDetails struct {
@@ -363,21 +453,28 @@ type Refund struct {
 	Note            string           `json:"note,omitempty"`
 	Restock         bool             `json:"restock,omitempty"`
 	UserId          int64            `json:"user_id,omitempty"`
+	Currency        string           `json:"currency,omitempty"`
+	Shipping        *RefundShipping  `json:"shipping,omitempty"`
 	RefundLineItems []RefundLineItem `json:"refund_line_items,omitempty"`
 	Transactions    []Transaction    `json:"transactions,omitempty"`
 }
 
 type RefundLineItem struct {
-	Id         int64            `json:"id,omitempty"`
-	Quantity   int              `json:"quantity,omitempty"`
-	LineItemId int64            `json:"line_item_id,omitempty"`
-	LineItem   *LineItem        `json:"line_item,omitempty"`
-	Subtotal   *decimal.Decimal `json:"subtotal,omitempty"`
-	TotalTax   *decimal.Decimal `json:"total_tax,omitempty"`
+	Id          int64            `json:"id,omitempty"`
+	Quantity    int              `json:"quantity,omitempty"`
+	LineItemId  int64            `json:"line_item_id,omitempty"`
+	LineItem    *LineItem        `json:"line_item,omitempty"`
+	RestockType string           `json:"restock_type,omitempty"`
+	LocationId  int64            `json:"location_id,omitempty"`
+	Price       *decimal.Decimal `json:"price,omitempty"`
+	Subtotal    *decimal.Decimal `json:"subtotal,omitempty"`
+	TotalTax    *decimal.Decimal `json:"total_tax,omitempty"`
+	SubtotalSet *AmountSet       `json:"subtotal_set,omitempty"`
+	TotalTaxSet *AmountSet       `json:"total_tax_set,omitempty"`
 }
 
 // List orders
-func (s *OrderServiceOp) List(options interface{}) ([]Order, error) {
+func (s *OrderServiceOp) List(options *OrderListOptions) ([]Order, error) {
 	orders, _, err := s.ListWithPagination(options)
 	if err != nil {
 		return nil, err
@@ -385,25 +482,109 @@ func (s *OrderServiceOp) List(options interface{}) ([]Order, error) {
 	return orders, nil
 }
 
-func (s *OrderServiceOp) ListWithPagination(options interface{}) ([]Order, *Pagination, error) {
+func (s *OrderServiceOp) ListWithPagination(options *OrderListOptions) ([]Order, *Pagination, error) {
 	path := fmt.Sprintf("%s.json", ordersBasePath)
-	resource := new(OrdersResource)
-	headers := http.Header{}
+	return listResourceWithPagination[Order](s.client, path, "orders", options)
+}
 
-	headers, err := s.client.createAndDoGetHeaders("GET", path, nil, options, resource)
-	if err != nil {
-		return nil, nil, err
+// ListByIDs lists the orders with the IDs whatever their status, however many
+// there are, in chunks of 250 IDs listed concurrently. Missing orders are
+// left out.
+func (s *OrderServiceOp) ListByIDs(ctx context.Context, ids []int64) ([]Order, error) {
+	path := fmt.Sprintf("%s.json", ordersBasePath)
+	return listResourceByIDs[Order](ctx, s.client, path, "orders", ids, string(OrderStatusAny))
+}
+
+// Paginate returns a paginator over the pages of orders matching the options.
+func (s *OrderServiceOp) Paginate(options *OrderListOptions) *Paginator[Order] {
+	path := fmt.Sprintf("%s.json", ordersBasePath)
+	list := func(ctx context.Context, options interface{}) ([]Order, *Pagination, error) {
+		return listResourceWithPaginationContext[Order](ctx, s.client, path, "orders", options)
 	}
+	return newPaginator(s.client, list, options, func(next *ListOptions) interface{} {
+		return &OrderListOptions{ListOptions: *next}
+	})
+}
+
+// Iterate returns an iterator over every order matching the options. Pages are
+// only requested as the iterator advances, so large shops can be walked without
+// holding all of their orders in memory.
+func (s *OrderServiceOp) Iterate(ctx context.Context, options *OrderListOptions) *OrderIterator {
+	return &OrderIterator{ctx: ctx, service: s, options: options, index: -1}
+}
+
+// OrderIterator walks the orders returned by OrderService.Iterate.
+//
+//	it := client.Order.Iterate(ctx, &OrderListOptions{Status: OrderStatusAny})
+//	for it.Next() {
+//		order := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type OrderIterator struct {
+	ctx     context.Context
+	service *OrderServiceOp
+	options *OrderListOptions
+	page    []Order
+	index   int
+	done    bool
+	err     error
+}
+
+// Next advances the iterator to the next order, fetching the next page when
+// the current one is exhausted. It returns false once all orders have been
+// read or an error occurred, see Err.
+func (it *OrderIterator) Next() bool {
+	for it.err == nil {
+		if it.index+1 < len(it.page) {
+			it.index++
+			return true
+		}
+
+		if it.done {
+			return false
+		}
 
-	// Extract pagination info from header
-	linkHeader := headers.Get("Link")
+		it.err = it.fetch()
+	}
 
-	pagination, err := extractPagination(linkHeader)
+	return false
+}
+
+// Value returns the current order.
+func (it *OrderIterator) Value() Order {
+	if it.index < 0 || it.index >= len(it.page) {
+		return Order{}
+	}
+	return it.page[it.index]
+}
+
+// Err returns the first error encountered while fetching pages.
+func (it *OrderIterator) Err() error {
+	return it.err
+}
+
+func (it *OrderIterator) fetch() error {
+	if err := waitForRateLimit(it.ctx, it.service.client); err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("%s.json", ordersBasePath)
+	orders, pagination, err := listResourceWithPaginationContext[Order](it.ctx, it.service.client, path, "orders", it.options)
 	if err != nil {
-		return nil, nil, err
+		return err
+	}
+
+	it.page = orders
+	it.index = -1
+	if pagination == nil || pagination.NextPageOptions == nil {
+		it.done = true
+	} else {
+		it.options = &OrderListOptions{ListOptions: *pagination.NextPageOptions}
 	}
 
-	return resource.Orders, pagination, nil
+	return nil
 }
 
 // Count orders
@@ -415,51 +596,43 @@ func (s *OrderServiceOp) Count(options interface{}) (int, error) {
 // Get individual order
 func (s *OrderServiceOp) Get(orderID int64, options interface{}) (*Order, error) {
 	path := fmt.Sprintf("%s/%d.json", ordersBasePath, orderID)
-	resource := new(OrderResource)
-	err := s.client.Get(path, resource, options)
-	return resource.Order, err
+	return getResource[Order](s.client, path, "order", options)
 }
 
 // Create order
 func (s *OrderServiceOp) Create(order Order) (*Order, error) {
 	path := fmt.Sprintf("%s.json", ordersBasePath)
-	wrappedData := OrderResource{Order: &order}
-	resource := new(OrderResource)
-	err := s.client.Post(path, wrappedData, resource)
-	return resource.Order, err
+	return createResource(s.client, path, "order", order)
 }
 
 // Update order
 func (s *OrderServiceOp) Update(order Order) (*Order, error) {
 	path := fmt.Sprintf("%s/%d.json", ordersBasePath, order.ID)
-	wrappedData := OrderResource{Order: &order}
-	resource := new(OrderResource)
-	err := s.client.Put(path, wrappedData, resource)
-	return resource.Order, err
+	return updateResource(s.client, path, "order", order)
+}
+
+// UpdateFields updates only the fields set in update on the order, see Update.
+func (s *OrderServiceOp) UpdateFields(orderID int64, update *Update) (*Order, error) {
+	path := fmt.Sprintf("%s/%d.json", ordersBasePath, orderID)
+	return updateFieldsResource[Order](s.client, path, "order", orderID, update)
 }
 
 // Cancel order
 func (s *OrderServiceOp) Cancel(orderID int64, options interface{}) (*Order, error) {
 	path := fmt.Sprintf("%s/%d/cancel.json", ordersBasePath, orderID)
-	resource := new(OrderResource)
-	err := s.client.Post(path, options, resource)
-	return resource.Order, err
+	return postResource[Order](s.client, path, "order", options)
 }
 
 // Close order
 func (s *OrderServiceOp) Close(orderID int64) (*Order, error) {
 	path := fmt.Sprintf("%s/%d/close.json", ordersBasePath, orderID)
-	resource := new(OrderResource)
-	err := s.client.Post(path, nil, resource)
-	return resource.Order, err
+	return postResource[Order](s.client, path, "order", nil)
 }
 
 // Open order
 func (s *OrderServiceOp) Open(orderID int64) (*Order, error) {
 	path := fmt.Sprintf("%s/%d/open.json", ordersBasePath, orderID)
-	resource := new(OrderResource)
-	err := s.client.Post(path, nil, resource)
-	return resource.Order, err
+	return postResource[Order](s.client, path, "order", nil)
 }
 
 // List metafields for an order
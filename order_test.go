@@ -1,6 +1,8 @@
 package goshopify
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -103,6 +105,7 @@ func TestOrderListWithPagination(t *testing.T) {
 			[]Order{{ID: 1}},
 			&Pagination{
 				NextPageOptions: &ListOptions{PageInfo: "foo", Limit: 2},
+				NextPageURL:     "http://valid.url?page_info=foo&limit=2",
 			},
 			nil,
 		},
@@ -113,6 +116,8 @@ func TestOrderListWithPagination(t *testing.T) {
 			&Pagination{
 				NextPageOptions:     &ListOptions{PageInfo: "foo"},
 				PreviousPageOptions: &ListOptions{PageInfo: "bar"},
+				NextPageURL:         "http://valid.url?page_info=foo",
+				PreviousPageURL:     "http://valid.url?page_info=bar",
 			},
 			nil,
 		},
@@ -153,6 +158,94 @@ func TestOrderListWithPagination(t *testing.T) {
 	}
 }
 
+func TestOrderIterate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	listURL := fmt.Sprintf("https://fooshop.myshopify.com/%s/orders.json", client.pathPrefix)
+
+	httpmock.RegisterResponderWithQuery("GET", listURL, "status=any", func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, `{"orders": [{"id":1},{"id":2}]}`)
+		resp.Header.Set("Link", fmt.Sprintf(`<%s?page_info=foo&limit=2>; rel="next"`, listURL))
+		return resp, nil
+	})
+	httpmock.RegisterResponderWithQuery("GET", listURL, "page_info=foo&limit=2",
+		httpmock.NewStringResponder(200, `{"orders": [{"id":3}]}`))
+
+	it := client.Order.Iterate(context.Background(), &OrderListOptions{Status: OrderStatusAny})
+	var ids []int64
+	for it.Next() {
+		ids = append(ids, it.Value().ID)
+	}
+
+	if err := it.Err(); err != nil {
+		t.Errorf("Order.Iterate returned error: %v", err)
+	}
+
+	expected := []int64{1, 2, 3}
+	if !reflect.DeepEqual(ids, expected) {
+		t.Errorf("Order.Iterate returned %v, expected %v", ids, expected)
+	}
+}
+
+func TestOrderIterateError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders.json", client.pathPrefix),
+		httpmock.NewStringResponder(500, ""))
+
+	it := client.Order.Iterate(context.Background(), nil)
+	if it.Next() {
+		t.Errorf("Order.Iterate Next returned true, expected false")
+	}
+
+	expectedErrMessage := "Unknown Error"
+	if err := it.Err(); err == nil || err.Error() != expectedErrMessage {
+		t.Errorf("Order.Iterate err returned %+v, expected %+v", err, expectedErrMessage)
+	}
+}
+
+func TestOrderIterateCanceled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	it := client.Order.Iterate(ctx, nil)
+	if it.Next() {
+		t.Errorf("Order.Iterate Next returned true, expected false")
+	}
+
+	if it.Err() != context.Canceled {
+		t.Errorf("Order.Iterate err returned %+v, expected %+v", it.Err(), context.Canceled)
+	}
+}
+
+func TestOrderIteratePassesContext(t *testing.T) {
+	setup()
+	defer teardown()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var requestErr error
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			cancel()
+			requestErr = req.Context().Err()
+			return httpmock.NewStringResponse(200, `{"orders": [{"id":1}]}`), nil
+		})
+
+	it := client.Order.Iterate(ctx, nil)
+	it.Next()
+
+	if requestErr != context.Canceled {
+		t.Errorf("Order.Iterate sent a request with context error %v, expected %v", requestErr, context.Canceled)
+	}
+}
+
 func orderTests(t *testing.T, order Order) {
 	// Check that dates are parsed
 	d := time.Date(2016, time.May, 17, 4, 14, 36, 0, time.UTC)
@@ -254,7 +347,7 @@ func TestOrderListOptions(t *testing.T) {
 		Status: "any",
 	}
 
-	orders, err := client.Order.List(options)
+	orders, err := client.Order.List(&options)
 	if err != nil {
 		t.Errorf("Order.List returned error: %v", err)
 	}
@@ -268,6 +361,35 @@ func TestOrderListOptions(t *testing.T) {
 	orderTests(t, order)
 }
 
+func TestOrderListStatusOptions(t *testing.T) {
+	setup()
+	defer teardown()
+	params := map[string]string{
+		"status":             "closed",
+		"financial_status":   "partially_refunded",
+		"fulfillment_status": "shipped",
+		"processed_at_min":   "2021-01-01T00:00:00Z",
+	}
+	httpmock.RegisterResponderWithQuery(
+		"GET",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/orders.json", client.pathPrefix),
+		params,
+		httpmock.NewStringResponder(200, `{"orders": [{"id": 1}]}`))
+
+	orders, err := client.Order.List(&OrderListOptions{
+		Status:            OrderStatusClosed,
+		FinancialStatus:   OrderFinancialStatusPartiallyRefunded,
+		FulfillmentStatus: OrderFulfillmentStatusShipped,
+		ProcessedAtMin:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Errorf("Order.List returned error: %v", err)
+	}
+	if len(orders) != 1 || orders[0].ID != 1 {
+		t.Errorf("Order.List returned %+v, expected order 1", orders)
+	}
+}
+
 func TestOrderGet(t *testing.T) {
 	setup()
 	defer teardown()
@@ -291,6 +413,73 @@ func TestOrderGet(t *testing.T) {
 	orderTests(t, *order)
 }
 
+func TestOrderStatuses(t *testing.T) {
+	order := Order{}
+	err := json.Unmarshal([]byte(`{"financial_status":"partially_refunded","fulfillment_status":"partial","line_items":[{"fulfillment_status":"fulfilled"},{"fulfillment_status":null}]}`), &order)
+	if err != nil {
+		t.Fatalf("Order unmarshal returned error: %v", err)
+	}
+
+	if order.FinancialStatus != OrderFinancialStatusPartiallyRefunded {
+		t.Errorf("Order.FinancialStatus returned %q, expected %q", order.FinancialStatus, OrderFinancialStatusPartiallyRefunded)
+	}
+	if order.FulfillmentStatus != OrderFulfillmentStatusPartial {
+		t.Errorf("Order.FulfillmentStatus returned %q, expected %q", order.FulfillmentStatus, OrderFulfillmentStatusPartial)
+	}
+	if order.LineItems[0].FulfillmentStatus != OrderFulfillmentStatusFulfilled || order.LineItems[1].FulfillmentStatus != "" {
+		t.Errorf("LineItem.FulfillmentStatus returned %+v", order.LineItems)
+	}
+}
+
+func TestOrderGetWithMoneySets(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/1.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("order_with_money_sets.json")))
+
+	order, err := client.Order.Get(1, nil)
+	if err != nil {
+		t.Fatalf("Order.Get returned error: %v", err)
+	}
+
+	shopAmount := decimal.NewFromInt(11)
+	presentmentAmount := decimal.NewFromInt(10)
+	expected := &AmountSet{
+		ShopMoney:        AmountSetEntry{Amount: &shopAmount, CurrencyCode: "USD"},
+		PresentmentMoney: AmountSetEntry{Amount: &presentmentAmount, CurrencyCode: "EUR"},
+	}
+
+	if order.PresentmentCurrency != "EUR" {
+		t.Errorf("Order.PresentmentCurrency returned %q, expected EUR", order.PresentmentCurrency)
+	}
+
+	cases := []struct {
+		field  string
+		actual *AmountSet
+	}{
+		{"Order.TotalPriceSet", order.TotalPriceSet},
+		{"LineItem.PriceSet", order.LineItems[0].PriceSet},
+		{"Transaction.AmountSet", order.Transactions[0].AmountSet},
+		{"RefundLineItem.SubtotalSet", order.Refunds[0].RefundLineItems[0].SubtotalSet},
+	}
+	for _, c := range cases {
+		if c.actual == nil || !c.actual.ShopMoney.Amount.Equal(*expected.ShopMoney.Amount) ||
+			!c.actual.PresentmentMoney.Amount.Equal(*expected.PresentmentMoney.Amount) ||
+			c.actual.ShopMoney.CurrencyCode != "USD" || c.actual.PresentmentMoney.CurrencyCode != "EUR" {
+			t.Errorf("%s returned %+v, expected %+v", c.field, c.actual, expected)
+		}
+	}
+
+	taxSet := order.LineItems[0].TaxLines[0].PriceSet
+	if taxSet == nil || taxSet.PresentmentMoney.Amount.String() != "1" {
+		t.Errorf("TaxLine.PriceSet returned %+v", taxSet)
+	}
+	if order.ShippingLines[0].DiscountedPriceSet == nil {
+		t.Errorf("ShippingLines.DiscountedPriceSet was not decoded")
+	}
+}
+
 func TestOrderGetWithTransactions(t *testing.T) {
 	setup()
 	defer teardown()
@@ -682,7 +871,7 @@ func TestOrderCreateFulfillment(t *testing.T) {
 			"https://shipping.xyz/track.php?num=123456789",
 			"https://anothershipper.corp/track.php?code=abc",
 		},
-		NotifyCustomer: true,
+		NotifyCustomer: Bool(true),
 	}
 
 	returnedFulfillment, err := client.Order.CreateFulfillment(1, fulfillment)
@@ -989,11 +1178,11 @@ func testLineItem(t *testing.T, expected, actual LineItem) {
 		t.Errorf("LineItem.Vendor should be (%v), was (%v)", expected.Vendor, actual.Vendor)
 	}
 
-	if actual.GiftCard != expected.GiftCard {
+	if !reflect.DeepEqual(actual.GiftCard, expected.GiftCard) {
 		t.Errorf("LineItem.GiftCard should be (%v), was (%v)", expected.GiftCard, actual.GiftCard)
 	}
 
-	if actual.Taxable != expected.Taxable {
+	if !reflect.DeepEqual(actual.Taxable, expected.Taxable) {
 		t.Errorf("LineItem.Taxable should be (%v), was (%v)", expected.Taxable, actual.Taxable)
 	}
 
@@ -1001,7 +1190,7 @@ func testLineItem(t *testing.T, expected, actual LineItem) {
 		t.Errorf("LineItem.FulfillmentService should be (%v), was (%v)", expected.FulfillmentService, actual.FulfillmentService)
 	}
 
-	if actual.RequiresShipping != expected.RequiresShipping {
+	if !reflect.DeepEqual(actual.RequiresShipping, expected.RequiresShipping) {
 		t.Errorf("LineItem.RequiresShipping should be (%v), was (%v)", expected.RequiresShipping, actual.RequiresShipping)
 	}
 
@@ -1184,10 +1373,10 @@ func validLineItem() LineItem {
 		Name:                       "Soda",
 		SKU:                        "sku-123",
 		Vendor:                     "Test Vendor",
-		GiftCard:                   true,
-		Taxable:                    true,
+		GiftCard:                   Bool(true),
+		Taxable:                    Bool(true),
 		FulfillmentService:         "manual",
-		RequiresShipping:           true,
+		RequiresShipping:           Bool(true),
 		VariantInventoryManagement: "shopify",
 		PreTaxPrice:                &preTaxPrice,
 		Properties: []NoteAttribute{
@@ -1308,3 +1497,20 @@ func validShippingLines() ShippingLines {
 		},
 	}
 }
+
+func TestOrderListByIDs(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponderWithQuery("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders.json", client.pathPrefix),
+		map[string]string{"ids": "1,2", "status": "any", "limit": "250"},
+		httpmock.NewStringResponder(200, `{"orders": [{"id":1},{"id":2}]}`))
+
+	orders, err := client.Order.ListByIDs(context.Background(), []int64{1, 2})
+	if err != nil {
+		t.Fatalf("Order.ListByIDs returned error: %v", err)
+	}
+	if expected := []Order{{ID: 1}, {ID: 2}}; !reflect.DeepEqual(orders, expected) {
+		t.Errorf("Order.ListByIDs returned %+v, expected %+v", orders, expected)
+	}
+}
@@ -2,7 +2,6 @@ package goshopify
 
 import (
 	"fmt"
-	"net/http"
 	"time"
 )
 
@@ -17,8 +16,10 @@ type PageService interface {
 	ListWithPagination(interface{}) ([]Page, *Pagination, error)
 	Count(interface{}) (int, error)
 	Get(int64, interface{}) (*Page, error)
+	GetByHandle(string) (*Page, error)
 	Create(Page) (*Page, error)
 	Update(Page) (*Page, error)
+	UpdateFields(int64, *Update) (*Page, error)
 	Delete(int64) error
 
 	// MetafieldsService used for Pages resource to communicate with Metafields
@@ -45,6 +46,13 @@ type Page struct {
 	PublishedAt    *time.Time  `json:"published_at,omitempty"`
 	ShopID         int64       `json:"shop_id,omitempty"`
 	Metafields     []Metafield `json:"metafields,omitempty"`
+	NullFields     NullFields  `json:"-"`
+}
+
+// MarshalJSON encodes the page, sending the NullFields as null.
+func (p Page) MarshalJSON() ([]byte, error) {
+	type page Page
+	return marshalWithNullFields(page(p), p.NullFields)
 }
 
 // PageResource represents the result from the pages/X.json endpoint
@@ -69,23 +77,7 @@ func (s *PageServiceOp) List(options interface{}) ([]Page, error) {
 // List pages with pagination
 func (s *PageServiceOp) ListWithPagination(options interface{}) ([]Page, *Pagination, error) {
 	path := fmt.Sprintf("%s.json", pagesBasePath)
-	resource := new(PagesResource)
-	headers := http.Header{}
-
-	headers, err := s.client.createAndDoGetHeaders("GET", path, nil, options, resource)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	// Extract pagination info from header
-	linkHeader := headers.Get("Link")
-
-	pagination, err := extractPagination(linkHeader)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	return resource.Pages, pagination, nil
+	return listResourceWithPagination[Page](s.client, path, "pages", options)
 }
 
 // Count pages
@@ -97,27 +89,31 @@ func (s *PageServiceOp) Count(options interface{}) (int, error) {
 // Get individual page
 func (s *PageServiceOp) Get(pageID int64, options interface{}) (*Page, error) {
 	path := fmt.Sprintf("%s/%d.json", pagesBasePath, pageID)
-	resource := new(PageResource)
-	err := s.client.Get(path, resource, options)
-	return resource.Page, err
+	return getResource[Page](s.client, path, "page", options)
+}
+
+// GetByHandle gets the page with the handle, or returns a NotFoundError
+func (s *PageServiceOp) GetByHandle(handle string) (*Page, error) {
+	path := fmt.Sprintf("%s.json", pagesBasePath)
+	return getResourceByHandle(s.client, path, "pages", handle, func(page Page) string { return page.Handle })
 }
 
 // Create a new page
 func (s *PageServiceOp) Create(page Page) (*Page, error) {
 	path := fmt.Sprintf("%s.json", pagesBasePath)
-	wrappedData := PageResource{Page: &page}
-	resource := new(PageResource)
-	err := s.client.Post(path, wrappedData, resource)
-	return resource.Page, err
+	return createResource(s.client, path, "page", page)
 }
 
 // Update an existing page
 func (s *PageServiceOp) Update(page Page) (*Page, error) {
 	path := fmt.Sprintf("%s/%d.json", pagesBasePath, page.ID)
-	wrappedData := PageResource{Page: &page}
-	resource := new(PageResource)
-	err := s.client.Put(path, wrappedData, resource)
-	return resource.Page, err
+	return updateResource(s.client, path, "page", page)
+}
+
+// UpdateFields updates only the fields set in update on the page, see Update.
+func (s *PageServiceOp) UpdateFields(pageID int64, update *Update) (*Page, error) {
+	path := fmt.Sprintf("%s/%d.json", pagesBasePath, pageID)
+	return updateFieldsResource[Page](s.client, path, "page", pageID, update)
 }
 
 // Delete an existing page.
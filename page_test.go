@@ -127,6 +127,7 @@ func TestPageListWithPagination(t *testing.T) {
 			[]Page{{ID: 1}},
 			&Pagination{
 				NextPageOptions: &ListOptions{PageInfo: "foo", Limit: 2},
+				NextPageURL:     "http://valid.url?page_info=foo&limit=2",
 			},
 			nil,
 		},
@@ -137,6 +138,8 @@ func TestPageListWithPagination(t *testing.T) {
 			&Pagination{
 				NextPageOptions:     &ListOptions{PageInfo: "foo"},
 				PreviousPageOptions: &ListOptions{PageInfo: "bar"},
+				NextPageURL:         "http://valid.url?page_info=foo",
+				PreviousPageURL:     "http://valid.url?page_info=bar",
 			},
 			nil,
 		},
@@ -412,3 +415,33 @@ func TestPageDeleteMetafield(t *testing.T) {
 		t.Errorf("Page.DeleteMetafield() returned error: %v", err)
 	}
 }
+
+func TestPageGetByHandle(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponderWithQuery(
+		"GET",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/pages.json", client.pathPrefix),
+		map[string]string{"handle": "summer"},
+		httpmock.NewStringResponder(200, `{"pages": [{"id": 1, "handle": "summer-sale"}, {"id": 2, "handle": "summer"}]}`))
+	httpmock.RegisterResponderWithQuery(
+		"GET",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/pages.json", client.pathPrefix),
+		map[string]string{"handle": "winter"},
+		httpmock.NewStringResponder(200, `{"pages": []}`))
+
+	found, err := client.Page.GetByHandle("summer")
+	if err != nil {
+		t.Fatalf("Page.GetByHandle returned error: %v", err)
+	}
+	if found.ID != 2 {
+		t.Errorf("Page.GetByHandle returned %+v, expected ID 2", found)
+	}
+
+	_, err = client.Page.GetByHandle("winter")
+	expected := NotFoundError{Resource: "pages", Handle: "winter"}
+	if err != expected {
+		t.Errorf("Page.GetByHandle returned error %v, expected %v", err, expected)
+	}
+}
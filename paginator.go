@@ -0,0 +1,77 @@
+package goshopify
+
+import "context"
+
+// Paginator walks the pages of a listing one page at a time, threading the
+// next page options of the Link header back into the list call.
+//
+//	p := goshopify.NewPaginator[goshopify.Page](client, "pages.json", "pages", goshopify.ListOptions{Limit: 250})
+//	for p.HasNext() {
+//		pages, err := p.Next(ctx)
+//		...
+//	}
+type Paginator[T any] struct {
+	client  *Client
+	list    func(context.Context, interface{}) ([]T, *Pagination, error)
+	next    func(*ListOptions) interface{}
+	options interface{}
+	done    bool
+	err     error
+}
+
+// NewPaginator returns a paginator over the listing at path, e.g.
+// "pages.json", whose responses are keyed by key, e.g. "pages". Products and
+// orders have typed options and are paginated with their Paginate methods
+// instead.
+func NewPaginator[T any](client *Client, path, key string, options interface{}) *Paginator[T] {
+	list := func(ctx context.Context, options interface{}) ([]T, *Pagination, error) {
+		return listResourceWithPaginationContext[T](ctx, client, path, key, options)
+	}
+	return newPaginator(client, list, options, func(next *ListOptions) interface{} { return next })
+}
+
+// newPaginator returns a paginator passing the options returned by next to
+// list for the pages after the first.
+func newPaginator[T any](client *Client, list func(context.Context, interface{}) ([]T, *Pagination, error), options interface{}, next func(*ListOptions) interface{}) *Paginator[T] {
+	return &Paginator[T]{client: client, list: list, next: next, options: options}
+}
+
+// HasNext reports whether there is another page to get, it is false after the
+// last page or an error.
+func (p *Paginator[T]) HasNext() bool {
+	return !p.done && p.err == nil
+}
+
+// Next gets the next page. It returns ErrNoPage after the last page and the
+// first error again after an error, see Err.
+func (p *Paginator[T]) Next(ctx context.Context) ([]T, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	if p.done {
+		return nil, ErrNoPage
+	}
+
+	if err := waitForRateLimit(ctx, p.client); err != nil {
+		p.err = err
+		return nil, err
+	}
+
+	items, pagination, err := p.list(ctx, p.options)
+	if err != nil {
+		p.err = err
+		return nil, err
+	}
+
+	if pagination == nil || pagination.NextPageOptions == nil {
+		p.done = true
+	} else {
+		p.options = p.next(pagination.NextPageOptions)
+	}
+	return items, nil
+}
+
+// Err returns the error that stopped the paginator, if any.
+func (p *Paginator[T]) Err() error {
+	return p.err
+}
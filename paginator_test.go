@@ -0,0 +1,144 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+// registerTwoPages registers a listing at path whose first page links to a
+// second page with page_info abc.
+func registerTwoPages(path, first, second string) {
+	url := fmt.Sprintf("https://fooshop.myshopify.com/%s/%s", client.pathPrefix, path)
+	httpmock.RegisterResponder("GET", url,
+		func(req *http.Request) (*http.Response, error) {
+			if req.URL.Query().Get("page_info") == "abc" {
+				return httpmock.NewStringResponse(200, second), nil
+			}
+			resp := httpmock.NewStringResponse(200, first)
+			resp.Header.Set("Link", fmt.Sprintf(`<%s?page_info=abc&limit=1>; rel="next"`, url))
+			return resp, nil
+		})
+}
+
+func TestPaginator(t *testing.T) {
+	setup()
+	defer teardown()
+
+	registerTwoPages("pages.json", `{"pages": [{"id":1}]}`, `{"pages": [{"id":2}]}`)
+
+	p := NewPaginator[Page](client, "pages.json", "pages", ListOptions{Limit: 1})
+	var pages []Page
+	for p.HasNext() {
+		page, err := p.Next(context.Background())
+		if err != nil {
+			t.Fatalf("Paginator.Next returned error: %v", err)
+		}
+		pages = append(pages, page...)
+	}
+
+	if expected := []Page{{ID: 1}, {ID: 2}}; !reflect.DeepEqual(pages, expected) {
+		t.Errorf("Paginator returned %+v, expected %+v", pages, expected)
+	}
+	if _, err := p.Next(context.Background()); err != ErrNoPage {
+		t.Errorf("Paginator.Next after the last page returned %v, expected ErrNoPage", err)
+	}
+	if p.Err() != nil {
+		t.Errorf("Paginator.Err returned %v", p.Err())
+	}
+}
+
+func TestProductPaginate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	registerTwoPages("products.json", `{"products": [{"id":1}]}`, `{"products": [{"id":2}]}`)
+
+	p := client.Product.Paginate(&ProductListOptions{Vendor: "Acme"})
+	first, err := p.Next(context.Background())
+	if err != nil {
+		t.Fatalf("Paginator.Next returned error: %v", err)
+	}
+	second, err := p.Next(context.Background())
+	if err != nil {
+		t.Fatalf("Paginator.Next returned error: %v", err)
+	}
+	if first[0].ID != 1 || second[0].ID != 2 || p.HasNext() {
+		t.Errorf("Product.Paginate returned %+v and %+v", first, second)
+	}
+}
+
+func TestOrderPaginateError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders.json", client.pathPrefix),
+		httpmock.NewStringResponder(500, `{"errors": "Internal Server Error"}`))
+
+	p := client.Order.Paginate(nil)
+	_, err := p.Next(context.Background())
+
+	var responseErr ResponseError
+	if !errors.As(err, &responseErr) || responseErr.Status != 500 {
+		t.Errorf("Paginator.Next returned %v, expected a 500 ResponseError", err)
+	}
+	if p.HasNext() || p.Err() == nil {
+		t.Errorf("Paginator kept going after error %v", err)
+	}
+}
+
+func TestPaginatorCanceled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	p := NewPaginator[Redirect](client, "redirects.json", "redirects", nil)
+	if _, err := p.Next(ctx); err != context.Canceled {
+		t.Errorf("Paginator.Next returned %v, expected context.Canceled", err)
+	}
+}
+
+func TestPaginatorContext(t *testing.T) {
+	setup()
+	defer teardown()
+
+	type contextKey struct{}
+	ctx := context.WithValue(context.Background(), contextKey{}, "paginate")
+
+	var paths []string
+	for _, path := range []string{"pages.json", "products.json", "orders.json"} {
+		httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/%s", client.pathPrefix, path),
+			func(req *http.Request) (*http.Response, error) {
+				if req.Context().Value(contextKey{}) == "paginate" {
+					paths = append(paths, req.URL.Path)
+				}
+				return httpmock.NewStringResponse(200, `{}`), nil
+			})
+	}
+
+	if _, err := NewPaginator[Page](client, "pages.json", "pages", nil).Next(ctx); err != nil {
+		t.Errorf("Paginator.Next returned error: %v", err)
+	}
+	if _, err := client.Product.Paginate(nil).Next(ctx); err != nil {
+		t.Errorf("Paginator.Next returned error: %v", err)
+	}
+	if _, err := client.Order.Paginate(nil).Next(ctx); err != nil {
+		t.Errorf("Paginator.Next returned error: %v", err)
+	}
+
+	expected := []string{
+		"/" + client.pathPrefix + "/pages.json",
+		"/" + client.pathPrefix + "/products.json",
+		"/" + client.pathPrefix + "/orders.json",
+	}
+	if !reflect.DeepEqual(paths, expected) {
+		t.Errorf("Paginator sent %v with the context, expected %v", paths, expected)
+	}
+}
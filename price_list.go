@@ -0,0 +1,429 @@
+package goshopify
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// Types of price list adjustments, relative to the prices of the products.
+const (
+	PriceListAdjustmentPercentageDecrease = "PERCENTAGE_DECREASE"
+	PriceListAdjustmentPercentageIncrease = "PERCENTAGE_INCREASE"
+)
+
+// Origins of the prices of a price list, fixed prices were set for the
+// variant and relative ones derived from the adjustment.
+const (
+	PriceListPriceOriginFixed    = "FIXED"
+	PriceListPriceOriginRelative = "RELATIVE"
+)
+
+// PriceListService is an interface for interfacing with the price list endpoints
+// of the Shopify API.
+// See: https://shopify.dev/docs/api/admin-graphql/latest/objects/PriceList
+type PriceListService interface {
+	List() ([]PriceList, error)
+	Get(string) (*PriceList, error)
+	Create(PriceListInput) (*PriceList, error)
+	Update(string, PriceListInput) (*PriceList, error)
+	Delete(string) error
+	ListPrices(string) ([]PriceListPrice, error)
+	GetPrice(string, int64) (*PriceListPrice, error)
+	AddFixedPrices(string, ...PriceListPriceInput) error
+	DeleteFixedPrices(string, ...int64) error
+}
+
+// PriceListServiceOp handles communication with the price list related
+// GraphQL queries and mutations.
+type PriceListServiceOp struct {
+	client *Client
+}
+
+// PriceList represents the prices of a catalog in a currency. Prices are
+// the product prices adjusted by the Adjustment unless a fixed price was set
+// for a variant.
+type PriceList struct {
+	ID               string               `json:"id"`
+	Name             string               `json:"name"`
+	Currency         string               `json:"currency"`
+	FixedPricesCount int                  `json:"fixedPricesCount"`
+	Adjustment       *PriceListAdjustment `json:"adjustment,omitempty"`
+	CatalogID        string               `json:"catalogId,omitempty"`
+}
+
+// PriceListAdjustment raises or lowers prices by a percentage, e.g. 10.
+type PriceListAdjustment struct {
+	Type  string  `json:"type"`
+	Value float64 `json:"value"`
+}
+
+// PriceListInput describes a price list to create or update, the catalog can
+// be set later on.
+type PriceListInput struct {
+	Name       string
+	Currency   string
+	Adjustment *PriceListAdjustment
+	CatalogID  string
+}
+
+// PriceListPrice represents the price of a variant in a price list.
+type PriceListPrice struct {
+	VariantID      string           `json:"variantId"`
+	Price          *decimal.Decimal `json:"price"`
+	CompareAtPrice *decimal.Decimal `json:"compareAtPrice,omitempty"`
+	Currency       string           `json:"currency"`
+	OriginType     string           `json:"originType"`
+}
+
+// PriceListPriceInput sets the fixed price of a variant, the Currency must be
+// the currency of the price list.
+type PriceListPriceInput struct {
+	VariantID      int64
+	Price          decimal.Decimal
+	CompareAtPrice *decimal.Decimal
+	Currency       string
+}
+
+func (p PriceListInput) input() map[string]interface{} {
+	input := map[string]interface{}{}
+	if p.Name != "" {
+		input["name"] = p.Name
+	}
+	if p.Currency != "" {
+		input["currency"] = p.Currency
+	}
+	if p.Adjustment != nil {
+		input["parent"] = map[string]interface{}{"adjustment": p.Adjustment}
+	}
+	if p.CatalogID != "" {
+		input["catalogId"] = p.CatalogID
+	}
+	return input
+}
+
+func (p PriceListPriceInput) input() map[string]interface{} {
+	input := map[string]interface{}{
+		"variantId": variantGID(p.VariantID),
+		"price":     map[string]interface{}{"amount": p.Price.String(), "currencyCode": p.Currency},
+	}
+	if p.CompareAtPrice != nil {
+		input["compareAtPrice"] = map[string]interface{}{"amount": p.CompareAtPrice.String(), "currencyCode": p.Currency}
+	}
+	return input
+}
+
+// priceListNode is the GraphQL representation of a price list, its
+// adjustment is nested in its parent.
+type priceListNode struct {
+	ID               string `json:"id"`
+	Name             string `json:"name"`
+	Currency         string `json:"currency"`
+	FixedPricesCount int    `json:"fixedPricesCount"`
+	Parent           *struct {
+		Adjustment *PriceListAdjustment `json:"adjustment"`
+	} `json:"parent"`
+	Catalog *struct {
+		ID string `json:"id"`
+	} `json:"catalog"`
+}
+
+func (n *priceListNode) priceList() *PriceList {
+	if n == nil {
+		return nil
+	}
+	priceList := &PriceList{
+		ID:               n.ID,
+		Name:             n.Name,
+		Currency:         n.Currency,
+		FixedPricesCount: n.FixedPricesCount,
+	}
+	if n.Parent != nil {
+		priceList.Adjustment = n.Parent.Adjustment
+	}
+	if n.Catalog != nil {
+		priceList.CatalogID = n.Catalog.ID
+	}
+	return priceList
+}
+
+// moneyV2 is the GraphQL representation of an amount in a currency.
+type moneyV2 struct {
+	Amount       *decimal.Decimal `json:"amount"`
+	CurrencyCode string           `json:"currencyCode"`
+}
+
+// priceListPriceNode is the GraphQL representation of a price.
+type priceListPriceNode struct {
+	OriginType string `json:"originType"`
+	Variant    *struct {
+		ID string `json:"id"`
+	} `json:"variant"`
+	Price          *moneyV2 `json:"price"`
+	CompareAtPrice *moneyV2 `json:"compareAtPrice"`
+}
+
+func (n *priceListPriceNode) price() PriceListPrice {
+	price := PriceListPrice{OriginType: n.OriginType}
+	if n.Variant != nil {
+		price.VariantID = n.Variant.ID
+	}
+	if n.Price != nil {
+		price.Price = n.Price.Amount
+		price.Currency = n.Price.CurrencyCode
+	}
+	if n.CompareAtPrice != nil {
+		price.CompareAtPrice = n.CompareAtPrice.Amount
+	}
+	return price
+}
+
+const priceListFields = `id name currency fixedPricesCount parent { adjustment { type value } } catalog { id }`
+
+const priceListsQuery = `query($after: String) {
+  priceLists(first: 50, after: $after) {
+    edges { node { ` + priceListFields + ` } }
+    pageInfo { hasNextPage endCursor }
+  }
+}`
+
+const priceListQuery = `query($id: ID!) {
+  priceList(id: $id) { ` + priceListFields + ` }
+}`
+
+const priceListPricesQuery = `query($id: ID!, $query: String, $after: String) {
+  priceList(id: $id) {
+    prices(first: 250, query: $query, after: $after) {
+      edges { node {
+        originType
+        variant { id }
+        price { amount currencyCode }
+        compareAtPrice { amount currencyCode }
+      } }
+      pageInfo { hasNextPage endCursor }
+    }
+  }
+}`
+
+const priceListCreateMutation = `mutation($input: PriceListCreateInput!) {
+  priceListCreate(input: $input) {
+    priceList { ` + priceListFields + ` }
+    userErrors { field message }
+  }
+}`
+
+const priceListUpdateMutation = `mutation($id: ID!, $input: PriceListUpdateInput!) {
+  priceListUpdate(id: $id, input: $input) {
+    priceList { ` + priceListFields + ` }
+    userErrors { field message }
+  }
+}`
+
+const priceListDeleteMutation = `mutation($id: ID!) {
+  priceListDelete(id: $id) {
+    deletedId
+    userErrors { field message }
+  }
+}`
+
+const priceListFixedPricesAddMutation = `mutation($priceListId: ID!, $prices: [PriceListPriceInput!]!) {
+  priceListFixedPricesAdd(priceListId: $priceListId, prices: $prices) {
+    prices { variant { id } }
+    userErrors { field message }
+  }
+}`
+
+const priceListFixedPricesDeleteMutation = `mutation($priceListId: ID!, $variantIds: [ID!]!) {
+  priceListFixedPricesDelete(priceListId: $priceListId, variantIds: $variantIds) {
+    deletedFixedPriceVariantIds
+    userErrors { field message }
+  }
+}`
+
+// List the price lists of the shop.
+func (s *PriceListServiceOp) List() ([]PriceList, error) {
+	var priceLists []PriceList
+	var after *string
+	for {
+		resp := struct {
+			PriceLists struct {
+				Edges []struct {
+					Node priceListNode `json:"node"`
+				} `json:"edges"`
+				PageInfo graphQLPageInfo `json:"pageInfo"`
+			} `json:"priceLists"`
+		}{}
+
+		err := s.client.GraphQL.Query(priceListsQuery, map[string]interface{}{"after": after}, &resp)
+		if err != nil {
+			return priceLists, err
+		}
+
+		for i := range resp.PriceLists.Edges {
+			priceLists = append(priceLists, *resp.PriceLists.Edges[i].Node.priceList())
+		}
+
+		pageInfo := resp.PriceLists.PageInfo
+		if !pageInfo.HasNextPage {
+			return priceLists, nil
+		}
+		after = &pageInfo.EndCursor
+	}
+}
+
+// Get a price list by its ID, nil if there is none.
+func (s *PriceListServiceOp) Get(id string) (*PriceList, error) {
+	resp := struct {
+		PriceList *priceListNode `json:"priceList"`
+	}{}
+	err := s.client.GraphQL.Query(priceListQuery, map[string]interface{}{"id": id}, &resp)
+	return resp.PriceList.priceList(), err
+}
+
+// Create a new price list
+func (s *PriceListServiceOp) Create(priceList PriceListInput) (*PriceList, error) {
+	resp := struct {
+		PriceListCreate struct {
+			PriceList  *priceListNode `json:"priceList"`
+			UserErrors []UserError    `json:"userErrors"`
+		} `json:"priceListCreate"`
+	}{}
+
+	err := s.client.GraphQL.Query(priceListCreateMutation, map[string]interface{}{"input": priceList.input()}, &resp)
+	if err == nil {
+		err = userErrorsToError(resp.PriceListCreate.UserErrors)
+	}
+	return resp.PriceListCreate.PriceList.priceList(), err
+}
+
+// Update an existing price list, only the fields that are set are changed.
+func (s *PriceListServiceOp) Update(id string, priceList PriceListInput) (*PriceList, error) {
+	vars := map[string]interface{}{
+		"id":    id,
+		"input": priceList.input(),
+	}
+	resp := struct {
+		PriceListUpdate struct {
+			PriceList  *priceListNode `json:"priceList"`
+			UserErrors []UserError    `json:"userErrors"`
+		} `json:"priceListUpdate"`
+	}{}
+
+	err := s.client.GraphQL.Query(priceListUpdateMutation, vars, &resp)
+	if err == nil {
+		err = userErrorsToError(resp.PriceListUpdate.UserErrors)
+	}
+	return resp.PriceListUpdate.PriceList.priceList(), err
+}
+
+// Delete an existing price list
+func (s *PriceListServiceOp) Delete(id string) error {
+	resp := struct {
+		PriceListDelete struct {
+			UserErrors []UserError `json:"userErrors"`
+		} `json:"priceListDelete"`
+	}{}
+
+	err := s.client.GraphQL.Query(priceListDeleteMutation, map[string]interface{}{"id": id}, &resp)
+	if err != nil {
+		return err
+	}
+	return userErrorsToError(resp.PriceListDelete.UserErrors)
+}
+
+// ListPrices lists the prices of all variants in a price list.
+func (s *PriceListServiceOp) ListPrices(id string) ([]PriceListPrice, error) {
+	return s.listPrices(id, "")
+}
+
+// GetPrice gets the price of a variant in a price list, nil if there is none.
+func (s *PriceListServiceOp) GetPrice(id string, variantID int64) (*PriceListPrice, error) {
+	prices, err := s.listPrices(id, fmt.Sprintf("variant_id:%d", variantID))
+	if err != nil || len(prices) == 0 {
+		return nil, err
+	}
+	return &prices[0], nil
+}
+
+func (s *PriceListServiceOp) listPrices(id, query string) ([]PriceListPrice, error) {
+	var prices []PriceListPrice
+	var after *string
+	for {
+		vars := map[string]interface{}{
+			"id":    id,
+			"after": after,
+		}
+		if query != "" {
+			vars["query"] = query
+		}
+		resp := struct {
+			PriceList *struct {
+				Prices struct {
+					Edges []struct {
+						Node priceListPriceNode `json:"node"`
+					} `json:"edges"`
+					PageInfo graphQLPageInfo `json:"pageInfo"`
+				} `json:"prices"`
+			} `json:"priceList"`
+		}{}
+
+		err := s.client.GraphQL.Query(priceListPricesQuery, vars, &resp)
+		if err != nil || resp.PriceList == nil {
+			return prices, err
+		}
+
+		for i := range resp.PriceList.Prices.Edges {
+			prices = append(prices, resp.PriceList.Prices.Edges[i].Node.price())
+		}
+
+		pageInfo := resp.PriceList.Prices.PageInfo
+		if !pageInfo.HasNextPage {
+			return prices, nil
+		}
+		after = &pageInfo.EndCursor
+	}
+}
+
+// AddFixedPrices sets fixed prices for variants in a price list, replacing
+// the prices they had.
+func (s *PriceListServiceOp) AddFixedPrices(id string, prices ...PriceListPriceInput) error {
+	inputs := make([]map[string]interface{}, 0, len(prices))
+	for _, price := range prices {
+		inputs = append(inputs, price.input())
+	}
+	vars := map[string]interface{}{
+		"priceListId": id,
+		"prices":      inputs,
+	}
+	resp := struct {
+		PriceListFixedPricesAdd struct {
+			UserErrors []UserError `json:"userErrors"`
+		} `json:"priceListFixedPricesAdd"`
+	}{}
+
+	err := s.client.GraphQL.Query(priceListFixedPricesAddMutation, vars, &resp)
+	if err != nil {
+		return err
+	}
+	return userErrorsToError(resp.PriceListFixedPricesAdd.UserErrors)
+}
+
+// DeleteFixedPrices deletes the fixed prices of variants in a price list,
+// their prices are derived from the adjustment again.
+func (s *PriceListServiceOp) DeleteFixedPrices(id string, variantIDs ...int64) error {
+	vars := map[string]interface{}{
+		"priceListId": id,
+		"variantIds":  gids("ProductVariant", variantIDs),
+	}
+	resp := struct {
+		PriceListFixedPricesDelete struct {
+			UserErrors []UserError `json:"userErrors"`
+		} `json:"priceListFixedPricesDelete"`
+	}{}
+
+	err := s.client.GraphQL.Query(priceListFixedPricesDeleteMutation, vars, &resp)
+	if err != nil {
+		return err
+	}
+	return userErrorsToError(resp.PriceListFixedPricesDelete.UserErrors)
+}
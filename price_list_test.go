@@ -0,0 +1,226 @@
+package goshopify
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/shopspring/decimal"
+)
+
+func priceListTests(t *testing.T, priceList PriceList) {
+	expected := PriceList{
+		ID:               "gid://shopify/PriceList/1",
+		Name:             "Wholesale",
+		Currency:         "CAD",
+		FixedPricesCount: 2,
+		Adjustment:       &PriceListAdjustment{Type: PriceListAdjustmentPercentageDecrease, Value: 15},
+		CatalogID:        "gid://shopify/CompanyLocationCatalog/2",
+	}
+	if !reflect.DeepEqual(priceList, expected) {
+		t.Errorf("PriceList returned %+v, expected %+v", priceList, expected)
+	}
+}
+
+func TestPriceListList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests,
+			string(loadFixture("price_lists.json")),
+			`{"data":{"priceLists":{"edges":[{"node":{"id":"gid://shopify/PriceList/3","name":"Europe","currency":"EUR","fixedPricesCount":0,"parent":null,"catalog":null}}],"pageInfo":{"hasNextPage":false}}}}`,
+		))
+
+	priceLists, err := client.PriceList.List()
+	if err != nil {
+		t.Fatalf("PriceList.List returned error: %v", err)
+	}
+
+	if len(priceLists) != 2 {
+		t.Fatalf("PriceList.List returned %d price lists, expected 2", len(priceLists))
+	}
+	priceListTests(t, priceLists[0])
+	expected := PriceList{ID: "gid://shopify/PriceList/3", Name: "Europe", Currency: "EUR"}
+	if !reflect.DeepEqual(priceLists[1], expected) {
+		t.Errorf("PriceList.List returned %+v, expected %+v", priceLists[1], expected)
+	}
+	if len(requests) != 2 || requests[1].Variables.(map[string]interface{})["after"] != "abc" {
+		t.Errorf("PriceList.List sent %+v, expected the second page after abc", requests)
+	}
+}
+
+func TestPriceListGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests,
+			string(loadFixture("price_list.json")),
+			`{"data":{"priceList":null}}`,
+		))
+
+	priceList, err := client.PriceList.Get("gid://shopify/PriceList/1")
+	if err != nil {
+		t.Fatalf("PriceList.Get returned error: %v", err)
+	}
+	priceListTests(t, *priceList)
+
+	priceList, err = client.PriceList.Get("gid://shopify/PriceList/9")
+	if err != nil || priceList != nil {
+		t.Errorf("PriceList.Get of a missing price list returned %+v, %v", priceList, err)
+	}
+}
+
+func TestPriceListCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests, string(loadFixture("price_list_create.json"))))
+
+	priceList, err := client.PriceList.Create(PriceListInput{
+		Name:       "Wholesale",
+		Currency:   "CAD",
+		Adjustment: &PriceListAdjustment{Type: PriceListAdjustmentPercentageDecrease, Value: 15},
+		CatalogID:  "gid://shopify/CompanyLocationCatalog/2",
+	})
+	if err != nil {
+		t.Fatalf("PriceList.Create returned error: %v", err)
+	}
+	priceListTests(t, *priceList)
+
+	expected := map[string]interface{}{
+		"name":      "Wholesale",
+		"currency":  "CAD",
+		"parent":    map[string]interface{}{"adjustment": map[string]interface{}{"type": "PERCENTAGE_DECREASE", "value": float64(15)}},
+		"catalogId": "gid://shopify/CompanyLocationCatalog/2",
+	}
+	if input := requests[0].Variables.(map[string]interface{})["input"]; !reflect.DeepEqual(input, expected) {
+		t.Errorf("PriceList.Create sent %+v, expected %+v", input, expected)
+	}
+}
+
+func TestPriceListUpdate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests, string(loadFixture("price_list_update.json"))))
+
+	_, err := client.PriceList.Update("gid://shopify/PriceList/1", PriceListInput{Name: "Wholesale 2024"})
+	if err != nil {
+		t.Fatalf("PriceList.Update returned error: %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"id":    "gid://shopify/PriceList/1",
+		"input": map[string]interface{}{"name": "Wholesale 2024"},
+	}
+	if !reflect.DeepEqual(requests[0].Variables, expected) {
+		t.Errorf("PriceList.Update sent %+v, expected %+v", requests[0].Variables, expected)
+	}
+}
+
+func TestPriceListDelete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests, `{"data":{"priceListDelete":{"deletedId":null,"userErrors":[{"field":["id"],"message":"Price list does not exist."}]}}}`))
+
+	err := client.PriceList.Delete("gid://shopify/PriceList/9")
+	if err == nil || err.Error() != "id: Price list does not exist." {
+		t.Errorf("PriceList.Delete returned %v, expected the user error", err)
+	}
+}
+
+func TestPriceListPrices(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests,
+			`{"data":{"priceList":{"prices":{"edges":[{"node":{"originType":"FIXED","variant":{"id":"gid://shopify/ProductVariant/4"},"price":{"amount":"8.0","currencyCode":"CAD"},"compareAtPrice":{"amount":"10.0","currencyCode":"CAD"}}}],"pageInfo":{"hasNextPage":true,"endCursor":"abc"}}}}}`,
+			`{"data":{"priceList":{"prices":{"edges":[{"node":{"originType":"RELATIVE","variant":{"id":"gid://shopify/ProductVariant/5"},"price":{"amount":"17.0","currencyCode":"CAD"},"compareAtPrice":null}}],"pageInfo":{"hasNextPage":false}}}}}`,
+			`{"data":{"priceList":{"prices":{"edges":[],"pageInfo":{"hasNextPage":false}}}}}`,
+		))
+
+	prices, err := client.PriceList.ListPrices("gid://shopify/PriceList/1")
+	if err != nil {
+		t.Fatalf("PriceList.ListPrices returned error: %v", err)
+	}
+
+	price, compareAtPrice, relativePrice := decimal.RequireFromString("8.0"), decimal.RequireFromString("10.0"), decimal.RequireFromString("17.0")
+	expected := []PriceListPrice{
+		{VariantID: "gid://shopify/ProductVariant/4", Price: &price, CompareAtPrice: &compareAtPrice, Currency: "CAD", OriginType: PriceListPriceOriginFixed},
+		{VariantID: "gid://shopify/ProductVariant/5", Price: &relativePrice, Currency: "CAD", OriginType: PriceListPriceOriginRelative},
+	}
+	if !reflect.DeepEqual(prices, expected) {
+		t.Errorf("PriceList.ListPrices returned %+v, expected %+v", prices, expected)
+	}
+	if len(requests) != 2 || requests[1].Variables.(map[string]interface{})["after"] != "abc" {
+		t.Errorf("PriceList.ListPrices sent %+v, expected the second page after abc", requests)
+	}
+
+	missing, err := client.PriceList.GetPrice("gid://shopify/PriceList/1", 6)
+	if err != nil || missing != nil {
+		t.Errorf("PriceList.GetPrice of a variant without a price returned %+v, %v", missing, err)
+	}
+	if query := requests[2].Variables.(map[string]interface{})["query"]; query != "variant_id:6" {
+		t.Errorf("PriceList.GetPrice sent query %v", query)
+	}
+}
+
+func TestPriceListFixedPrices(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests,
+			`{"data":{"priceListFixedPricesAdd":{"prices":[{"variant":{"id":"gid://shopify/ProductVariant/4"}}],"userErrors":[]}}}`,
+			`{"data":{"priceListFixedPricesDelete":{"deletedFixedPriceVariantIds":["gid://shopify/ProductVariant/4"],"userErrors":[]}}}`,
+		))
+
+	compareAtPrice := decimal.NewFromInt(10)
+	err := client.PriceList.AddFixedPrices("gid://shopify/PriceList/1", PriceListPriceInput{
+		VariantID:      4,
+		Price:          decimal.NewFromInt(8),
+		CompareAtPrice: &compareAtPrice,
+		Currency:       "CAD",
+	})
+	if err != nil {
+		t.Fatalf("PriceList.AddFixedPrices returned error: %v", err)
+	}
+	if err := client.PriceList.DeleteFixedPrices("gid://shopify/PriceList/1", 4); err != nil {
+		t.Fatalf("PriceList.DeleteFixedPrices returned error: %v", err)
+	}
+
+	expected := []map[string]interface{}{
+		{
+			"priceListId": "gid://shopify/PriceList/1",
+			"prices": []interface{}{map[string]interface{}{
+				"variantId":      "gid://shopify/ProductVariant/4",
+				"price":          map[string]interface{}{"amount": "8", "currencyCode": "CAD"},
+				"compareAtPrice": map[string]interface{}{"amount": "10", "currencyCode": "CAD"},
+			}},
+		},
+		{
+			"priceListId": "gid://shopify/PriceList/1",
+			"variantIds":  []interface{}{"gid://shopify/ProductVariant/4"},
+		},
+	}
+	for i := range expected {
+		if !reflect.DeepEqual(requests[i].Variables, expected[i]) {
+			t.Errorf("request %d sent %+v, expected %+v", i, requests[i].Variables, expected[i])
+		}
+	}
+}
@@ -2,7 +2,6 @@ package goshopify
 
 import (
 	"fmt"
-	"net/http"
 	"time"
 
 	"github.com/shopspring/decimal"
@@ -38,7 +37,7 @@ type PriceRule struct {
 	TargetSelection                        string                                  `json:"target_selection,omitempty"`
 	AllocationMethod                       string                                  `json:"allocation_method,omitempty"`
 	AllocationLimit                        string                                  `json:"allocation_limit,omitempty"`
-	OncePerCustomer                        bool                                    `json:"once_per_customer,omitempty"`
+	OncePerCustomer                        *bool                                   `json:"once_per_customer,omitempty"`
 	UsageLimit                             int                                     `json:"usage_limit,omitempty"`
 	StartsAt                               *time.Time                              `json:"starts_at,omitempty"`
 	EndsAt                                 *time.Time                              `json:"ends_at,omitempty"`
@@ -156,9 +155,7 @@ func (pr *PriceRule) SetPrerequisiteToEntitlementQuantityRatio(prerequisiteQuant
 // Get retrieves a single price rules
 func (s *PriceRuleServiceOp) Get(priceRuleID int64) (*PriceRule, error) {
 	path := fmt.Sprintf("%s/%d.json", priceRulesBasePath, priceRuleID)
-	resource := new(PriceRuleResource)
-	err := s.client.Get(path, resource, nil)
-	return resource.PriceRule, err
+	return getResource[PriceRule](s.client, path, "price_rule", nil)
 }
 
 // List retrieves a list of price rules
@@ -174,41 +171,19 @@ func (s *PriceRuleServiceOp) List() ([]PriceRule, error) {
 // ListWithPagination retrieves a list of price rules with pagination
 func (s *PriceRuleServiceOp) ListWithPagination(options interface{}) ([]PriceRule, *Pagination, error) {
 	path := fmt.Sprintf("%s.json", priceRulesBasePath)
-	resource := new(PriceRulesResource)
-	headers := http.Header{}
-
-	headers, err := s.client.createAndDoGetHeaders("GET", path, nil, options, resource)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	// Extract pagination info from header
-	linkHeader := headers.Get("Link")
-
-	pagination, err := extractPagination(linkHeader)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	return resource.PriceRules, pagination, nil
+	return listResourceWithPagination[PriceRule](s.client, path, "price_rules", options)
 }
 
 // Create creates a price rule
 func (s *PriceRuleServiceOp) Create(pr PriceRule) (*PriceRule, error) {
 	path := fmt.Sprintf("%s.json", priceRulesBasePath)
-	resource := new(PriceRuleResource)
-	wrappedData := PriceRuleResource{PriceRule: &pr}
-	err := s.client.Post(path, wrappedData, resource)
-	return resource.PriceRule, err
+	return createResource(s.client, path, "price_rule", pr)
 }
 
 // Update updates an existing a price rule
 func (s *PriceRuleServiceOp) Update(pr PriceRule) (*PriceRule, error) {
 	path := fmt.Sprintf("%s/%d.json", priceRulesBasePath, pr.ID)
-	resource := new(PriceRuleResource)
-	wrappedData := PriceRuleResource{PriceRule: &pr}
-	err := s.client.Put(path, wrappedData, resource)
-	return resource.PriceRule, err
+	return updateResource(s.client, path, "price_rule", pr)
 }
 
 // Delete deletes a price rule
@@ -148,6 +148,7 @@ func TestPriceRuleListWithPagination(t *testing.T) {
 			[]PriceRule{{ID: 1}},
 			&Pagination{
 				NextPageOptions: &ListOptions{PageInfo: "foo", Limit: 2},
+				NextPageURL:     "http://valid.url?page_info=foo&limit=2",
 			},
 			nil,
 		},
@@ -158,6 +159,8 @@ func TestPriceRuleListWithPagination(t *testing.T) {
 			&Pagination{
 				NextPageOptions:     &ListOptions{PageInfo: "foo"},
 				PreviousPageOptions: &ListOptions{PageInfo: "bar"},
+				NextPageURL:         "http://valid.url?page_info=foo",
+				PreviousPageURL:     "http://valid.url?page_info=bar",
 			},
 			nil,
 		},
@@ -1,8 +1,9 @@
 package goshopify
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"net/http"
 	"net/url"
 	"regexp"
 	"strconv"
@@ -20,13 +21,20 @@ var linkRegex = regexp.MustCompile(`^ *<([^>]+)>; rel="(previous|next)" *$`)
 // of the Shopify API.
 // See: https://help.shopify.com/api/reference/product
 type ProductService interface {
-	List(interface{}) ([]Product, error)
-	ListWithPagination(interface{}) ([]Product, *Pagination, error)
+	List(*ProductListOptions) ([]Product, error)
+	ListWithPagination(*ProductListOptions) ([]Product, *Pagination, error)
+	Paginate(*ProductListOptions) *Paginator[Product]
+	ListByIDs(context.Context, []int64) ([]Product, error)
 	Count(interface{}) (int, error)
 	Get(int64, interface{}) (*Product, error)
+	GetByHandle(string) (*Product, error)
 	Create(Product) (*Product, error)
 	Update(Product) (*Product, error)
+	UpdateFields(int64, *Update) (*Product, error)
 	Delete(int64) error
+	Publish(int64) (*Product, error)
+	Unpublish(int64) (*Product, error)
+	Archive(int64) (*Product, error)
 
 	// MetafieldsService used for Product resource to communicate with Metafields resource
 	MetafieldsService
@@ -38,6 +46,21 @@ type ProductServiceOp struct {
 	client *Client
 }
 
+// ProductStatus is the lifecycle status of a product.
+type ProductStatus string
+
+const (
+	// ProductStatusActive products are ready to sell on the channels they
+	// are published to.
+	ProductStatusActive ProductStatus = "active"
+	// ProductStatusDraft products are not ready to sell and hidden from every
+	// channel.
+	ProductStatusDraft ProductStatus = "draft"
+	// ProductStatusArchived products are no longer sold and hidden from every
+	// channel.
+	ProductStatusArchived ProductStatus = "archived"
+)
+
 // Product represents a Shopify product
 type Product struct {
 	ID                             int64           `json:"id,omitempty"`
@@ -48,9 +71,11 @@ type Product struct {
 	Handle                         string          `json:"handle,omitempty"`
 	CreatedAt                      *time.Time      `json:"created_at,omitempty"`
 	UpdatedAt                      *time.Time      `json:"updated_at,omitempty"`
+	Status                         ProductStatus   `json:"status,omitempty"`
+	Published                      *bool           `json:"published,omitempty"`
 	PublishedAt                    *time.Time      `json:"published_at,omitempty"`
 	PublishedScope                 string          `json:"published_scope,omitempty"`
-	Tags                           string          `json:"tags,omitempty"`
+	Tags                           Tags            `json:"tags,omitempty"`
 	Options                        []ProductOption `json:"options,omitempty"`
 	Variants                       []Variant       `json:"variants,omitempty"`
 	Image                          Image           `json:"image,omitempty"`
@@ -60,6 +85,13 @@ type Product struct {
 	MetafieldsGlobalDescriptionTag string          `json:"metafields_global_description_tag,omitempty"`
 	Metafields                     []Metafield     `json:"metafields,omitempty"`
 	AdminGraphqlAPIID              string          `json:"admin_graphql_api_id,omitempty"`
+	NullFields                     NullFields      `json:"-"`
+}
+
+// MarshalJSON encodes the product, sending the NullFields as null.
+func (p Product) MarshalJSON() ([]byte, error) {
+	type product Product
+	return marshalWithNullFields(product(p), p.NullFields)
 }
 
 // The options provided by Shopify
@@ -71,16 +103,25 @@ type ProductOption struct {
 	Values    []string `json:"values,omitempty"`
 }
 
+// ProductListOptions filters the products returned by List and
+// ListWithPagination. The ids, limit, since_id, fields and created and
+// updated ranges are set through the embedded ListOptions. Following pages
+// only accept the page info, limit and fields, use
+// &ProductListOptions{ListOptions: *pagination.NextPageOptions}.
 type ProductListOptions struct {
 	ListOptions
-	CollectionID          int64     `url:"collection_id,omitempty"`
-	ProductType           string    `url:"product_type,omitempty"`
-	Vendor                string    `url:"vendor,omitempty"`
-	Handle                string    `url:"handle,omitempty"`
-	PublishedAtMin        time.Time `url:"published_at_min,omitempty"`
-	PublishedAtMax        time.Time `url:"published_at_max,omitempty"`
-	PublishedStatus       string    `url:"published_status,omitempty"`
-	PresentmentCurrencies string    `url:"presentment_currencies,omitempty"`
+	Title        string `url:"title,omitempty"`
+	CollectionID int64  `url:"collection_id,omitempty"`
+	ProductType  string `url:"product_type,omitempty"`
+	Vendor       string `url:"vendor,omitempty"`
+	Handle       string `url:"handle,omitempty"`
+	// Status is a comma separated list of active, archived and draft
+	Status         string    `url:"status,omitempty"`
+	PublishedAtMin time.Time `url:"published_at_min,omitempty"`
+	PublishedAtMax time.Time `url:"published_at_max,omitempty"`
+	// PublishedStatus is published, unpublished or any
+	PublishedStatus       string `url:"published_status,omitempty"`
+	PresentmentCurrencies string `url:"presentment_currencies,omitempty"`
 }
 
 // Represents the result from the products/X.json endpoint
@@ -93,14 +134,23 @@ type ProductsResource struct {
 	Products []Product `json:"products"`
 }
 
+// ErrNoPage is returned by FollowNext and FollowPrevious when there is no
+// page to follow.
+var ErrNoPage = errors.New("no page to follow")
+
 // Pagination of results
 type Pagination struct {
 	NextPageOptions     *ListOptions
 	PreviousPageOptions *ListOptions
+
+	// The links of the next and previous pages as Shopify sent them, with
+	// every query parameter, see FollowNext
+	NextPageURL     string
+	PreviousPageURL string
 }
 
 // List products
-func (s *ProductServiceOp) List(options interface{}) ([]Product, error) {
+func (s *ProductServiceOp) List(options *ProductListOptions) ([]Product, error) {
 	products, _, err := s.ListWithPagination(options)
 	if err != nil {
 		return nil, err
@@ -109,25 +159,28 @@ func (s *ProductServiceOp) List(options interface{}) ([]Product, error) {
 }
 
 // ListWithPagination lists products and return pagination to retrieve next/previous results.
-func (s *ProductServiceOp) ListWithPagination(options interface{}) ([]Product, *Pagination, error) {
+func (s *ProductServiceOp) ListWithPagination(options *ProductListOptions) ([]Product, *Pagination, error) {
 	path := fmt.Sprintf("%s.json", productsBasePath)
-	resource := new(ProductsResource)
-	headers := http.Header{}
-
-	headers, err := s.client.createAndDoGetHeaders("GET", path, nil, options, resource)
-	if err != nil {
-		return nil, nil, err
-	}
+	return listResourceWithPagination[Product](s.client, path, "products", options)
+}
 
-	// Extract pagination info from header
-	linkHeader := headers.Get("Link")
+// ListByIDs lists the products with the IDs, however many there are, in
+// chunks of 250 IDs listed concurrently. Missing products are left out.
+func (s *ProductServiceOp) ListByIDs(ctx context.Context, ids []int64) ([]Product, error) {
+	path := fmt.Sprintf("%s.json", productsBasePath)
+	return listResourceByIDs[Product](ctx, s.client, path, "products", ids, "")
+}
 
-	pagination, err := extractPagination(linkHeader)
-	if err != nil {
-		return nil, nil, err
+// Paginate returns a paginator over the pages of products matching the
+// options.
+func (s *ProductServiceOp) Paginate(options *ProductListOptions) *Paginator[Product] {
+	path := fmt.Sprintf("%s.json", productsBasePath)
+	list := func(ctx context.Context, options interface{}) ([]Product, *Pagination, error) {
+		return listResourceWithPaginationContext[Product](ctx, s.client, path, "products", options)
 	}
-
-	return resource.Products, pagination, nil
+	return newPaginator(s.client, list, options, func(next *ListOptions) interface{} {
+		return &ProductListOptions{ListOptions: *next}
+	})
 }
 
 // extractPagination extracts pagination info from linkHeader.
@@ -187,14 +240,54 @@ func extractPagination(linkHeader string) (*Pagination, error) {
 		// 'rel' is either next or previous
 		if match[2] == "next" {
 			pagination.NextPageOptions = &paginationListOptions
+			pagination.NextPageURL = match[1]
 		} else {
 			pagination.PreviousPageOptions = &paginationListOptions
+			pagination.PreviousPageURL = match[1]
 		}
 	}
 
 	return pagination, nil
 }
 
+// FollowNext gets the next page of a listing into resource, e.g.
+// &ProductsResource{}, from the exact link Shopify sent, so listings with any
+// filters are continued faithfully. It returns the pagination of that page,
+// or ErrNoPage when there is no next page.
+func (c *Client) FollowNext(pagination *Pagination, resource interface{}) (*Pagination, error) {
+	if pagination == nil || pagination.NextPageURL == "" {
+		return nil, ErrNoPage
+	}
+	return c.followPage(context.Background(), pagination.NextPageURL, resource)
+}
+
+// FollowPrevious gets the previous page of a listing into resource, see
+// FollowNext.
+func (c *Client) FollowPrevious(pagination *Pagination, resource interface{}) (*Pagination, error) {
+	if pagination == nil || pagination.PreviousPageURL == "" {
+		return nil, ErrNoPage
+	}
+	return c.followPage(context.Background(), pagination.PreviousPageURL, resource)
+}
+
+func (c *Client) followPage(ctx context.Context, link string, resource interface{}) (*Pagination, error) {
+	u, err := url.Parse(link)
+	if err != nil {
+		return nil, ResponseDecodingError{Message: "pagination does not contain a valid URL"}
+	}
+
+	// the path is sent to the configured version like any other
+	relPath := u.Path
+	if u.RawQuery != "" {
+		relPath = fmt.Sprintf("%s?%s", relPath, u.RawQuery)
+	}
+	headers, err := c.createAndDoGetHeaders(ctx, "GET", relPath, nil, nil, resource)
+	if err != nil {
+		return nil, err
+	}
+	return extractPagination(headers.Get("Link"))
+}
+
 // Count products
 func (s *ProductServiceOp) Count(options interface{}) (int, error) {
 	path := fmt.Sprintf("%s/count.json", productsBasePath)
@@ -204,27 +297,31 @@ func (s *ProductServiceOp) Count(options interface{}) (int, error) {
 // Get individual product
 func (s *ProductServiceOp) Get(productID int64, options interface{}) (*Product, error) {
 	path := fmt.Sprintf("%s/%d.json", productsBasePath, productID)
-	resource := new(ProductResource)
-	err := s.client.Get(path, resource, options)
-	return resource.Product, err
+	return getResource[Product](s.client, path, "product", options)
+}
+
+// GetByHandle gets the product with the handle, or returns a NotFoundError
+func (s *ProductServiceOp) GetByHandle(handle string) (*Product, error) {
+	path := fmt.Sprintf("%s.json", productsBasePath)
+	return getResourceByHandle(s.client, path, "products", handle, func(product Product) string { return product.Handle })
 }
 
 // Create a new product
 func (s *ProductServiceOp) Create(product Product) (*Product, error) {
 	path := fmt.Sprintf("%s.json", productsBasePath)
-	wrappedData := ProductResource{Product: &product}
-	resource := new(ProductResource)
-	err := s.client.Post(path, wrappedData, resource)
-	return resource.Product, err
+	return createResource(s.client, path, "product", product)
 }
 
 // Update an existing product
 func (s *ProductServiceOp) Update(product Product) (*Product, error) {
 	path := fmt.Sprintf("%s/%d.json", productsBasePath, product.ID)
-	wrappedData := ProductResource{Product: &product}
-	resource := new(ProductResource)
-	err := s.client.Put(path, wrappedData, resource)
-	return resource.Product, err
+	return updateResource(s.client, path, "product", product)
+}
+
+// UpdateFields updates only the fields set in update on the product, see Update.
+func (s *ProductServiceOp) UpdateFields(productID int64, update *Update) (*Product, error) {
+	path := fmt.Sprintf("%s/%d.json", productsBasePath, productID)
+	return updateFieldsResource[Product](s.client, path, "product", productID, update)
 }
 
 // Delete an existing product
@@ -232,6 +329,38 @@ func (s *ProductServiceOp) Delete(productID int64) error {
 	return s.client.Delete(fmt.Sprintf("%s/%d.json", productsBasePath, productID))
 }
 
+// productLifecycle is the update payload of Publish, Unpublish and Archive,
+// it leaves out the fields of Product that always encode, such as Image.
+type productLifecycle struct {
+	ID        int64         `json:"id"`
+	Status    ProductStatus `json:"status,omitempty"`
+	Published *bool         `json:"published,omitempty"`
+}
+
+// updateLifecycle puts the lifecycle payload to the product and returns the
+// updated product.
+func (s *ProductServiceOp) updateLifecycle(update productLifecycle) (*Product, error) {
+	path := fmt.Sprintf("%s/%d.json", productsBasePath, update.ID)
+	resource := new(ProductResource)
+	err := s.client.Put(path, map[string]productLifecycle{"product": update}, resource)
+	return resource.Product, err
+}
+
+// Publish makes the product active and publishes it to the online store
+func (s *ProductServiceOp) Publish(productID int64) (*Product, error) {
+	return s.updateLifecycle(productLifecycle{ID: productID, Status: ProductStatusActive, Published: Bool(true)})
+}
+
+// Unpublish hides the product from the online store, leaving its status as is
+func (s *ProductServiceOp) Unpublish(productID int64) (*Product, error) {
+	return s.updateLifecycle(productLifecycle{ID: productID, Published: Bool(false)})
+}
+
+// Archive archives the product, hiding it from every channel
+func (s *ProductServiceOp) Archive(productID int64) (*Product, error) {
+	return s.updateLifecycle(productLifecycle{ID: productID, Status: ProductStatusArchived})
+}
+
 // ListMetafields for a product
 func (s *ProductServiceOp) ListMetafields(productID int64, options interface{}) ([]Metafield, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: productsResourceName, resourceID: productID}
@@ -2,7 +2,6 @@ package goshopify
 
 import (
 	"fmt"
-	"net/http"
 	"time"
 )
 
@@ -86,23 +85,7 @@ func (s *ProductListingServiceOp) List(options interface{}) ([]ProductListing, e
 // ListWithPagination lists products and return pagination to retrieve next/previous results.
 func (s *ProductListingServiceOp) ListWithPagination(options interface{}) ([]ProductListing, *Pagination, error) {
 	path := fmt.Sprintf("%s.json", productListingBasePath)
-	resource := new(ProductsListingsResource)
-	headers := http.Header{}
-
-	headers, err := s.client.createAndDoGetHeaders("GET", path, nil, options, resource)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	// Extract pagination info from header
-	linkHeader := headers.Get("Link")
-
-	pagination, err := extractPagination(linkHeader)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	return resource.ProductListings, pagination, nil
+	return listResourceWithPagination[ProductListing](s.client, path, "product_listings", options)
 }
 
 // Count products listings published to your sales channel app
@@ -114,17 +97,13 @@ func (s *ProductListingServiceOp) Count(options interface{}) (int, error) {
 // Get individual product_listing by product ID
 func (s *ProductListingServiceOp) Get(productID int64, options interface{}) (*ProductListing, error) {
 	path := fmt.Sprintf("%s/%d.json", productListingBasePath, productID)
-	resource := new(ProductListingResource)
-	err := s.client.Get(path, resource, options)
-	return resource.ProductListing, err
+	return getResource[ProductListing](s.client, path, "product_listing", options)
 }
 
 // GetProductIDs lists all product IDs that are published to your sales channel
 func (s *ProductListingServiceOp) GetProductIDs(options interface{}) ([]int64, error) {
 	path := fmt.Sprintf("%s/product_ids.json", productListingBasePath)
-	resource := new(ProductListingIDsResource)
-	err := s.client.Get(path, resource, options)
-	return resource.ProductIDs, err
+	return listResource[int64](s.client, path, "product_ids", options)
 }
 
 // Publish an existing product listing to your sales channel app
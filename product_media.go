@@ -0,0 +1,298 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Content types of product media.
+const (
+	MediaContentTypeImage         = "IMAGE"
+	MediaContentTypeVideo         = "VIDEO"
+	MediaContentTypeExternalVideo = "EXTERNAL_VIDEO"
+	MediaContentTypeModel3D       = "MODEL_3D"
+)
+
+// Statuses of product media, media is processed after it was created and can
+// only be shown once it is READY.
+const (
+	MediaStatusUploaded   = "UPLOADED"
+	MediaStatusProcessing = "PROCESSING"
+	MediaStatusReady      = "READY"
+	MediaStatusFailed     = "FAILED"
+)
+
+// defaultMediaPollInterval is how often WaitReady checks on media when no
+// interval is given.
+const defaultMediaPollInterval = 2 * time.Second
+
+// ProductMediaService is an interface for interfacing with the product media endpoints
+// of the Shopify API.
+// See: https://shopify.dev/docs/apps/online-store/media/products
+type ProductMediaService interface {
+	List(int64) ([]ProductMedia, error)
+	Get(string) (*ProductMedia, error)
+	Create(int64, ...ProductMediaInput) ([]ProductMedia, error)
+	Reorder(int64, ...ProductMediaMove) error
+	Delete(int64, ...string) error
+	WaitReady(context.Context, string, time.Duration) (*ProductMedia, error)
+}
+
+// ProductMediaServiceOp handles communication with the product media related
+// GraphQL queries and mutations.
+type ProductMediaServiceOp struct {
+	client *Client
+}
+
+// ProductMedia represents an image, video, external video or 3D model of a
+// product. Errors holds the reasons processing failed.
+type ProductMedia struct {
+	ID               string   `json:"id"`
+	Alt              string   `json:"alt,omitempty"`
+	MediaContentType string   `json:"mediaContentType,omitempty"`
+	Status           string   `json:"status,omitempty"`
+	PreviewURL       string   `json:"previewUrl,omitempty"`
+	Errors           []string `json:"errors,omitempty"`
+}
+
+// Ready reports whether the media was processed, successfully or not.
+func (m *ProductMedia) Ready() bool {
+	return m.Status == MediaStatusReady || m.Status == MediaStatusFailed
+}
+
+// ProductMediaInput describes media to add to a product. OriginalSource is
+// the url of the media, e.g. the resource url of a staged upload or the url
+// of a YouTube video for EXTERNAL_VIDEO.
+type ProductMediaInput struct {
+	OriginalSource   string `json:"originalSource"`
+	Alt              string `json:"alt,omitempty"`
+	MediaContentType string `json:"mediaContentType"`
+}
+
+// ProductMediaMove moves media to a new zero based position.
+type ProductMediaMove struct {
+	ID          string `json:"id"`
+	NewPosition int64  `json:"newPosition,string"`
+}
+
+// productMediaNode is the GraphQL representation of media.
+type productMediaNode struct {
+	ID               string `json:"id"`
+	Alt              string `json:"alt"`
+	MediaContentType string `json:"mediaContentType"`
+	Status           string `json:"status"`
+	MediaErrors      []struct {
+		Message string `json:"message"`
+	} `json:"mediaErrors"`
+	Preview *struct {
+		Image *struct {
+			URL string `json:"url"`
+		} `json:"image"`
+	} `json:"preview"`
+}
+
+func (n *productMediaNode) media() *ProductMedia {
+	if n == nil {
+		return nil
+	}
+	media := &ProductMedia{
+		ID:               n.ID,
+		Alt:              n.Alt,
+		MediaContentType: n.MediaContentType,
+		Status:           n.Status,
+	}
+	if n.Preview != nil && n.Preview.Image != nil {
+		media.PreviewURL = n.Preview.Image.URL
+	}
+	for _, e := range n.MediaErrors {
+		media.Errors = append(media.Errors, e.Message)
+	}
+	return media
+}
+
+func productMediaNodesToMedia(nodes []productMediaNode) []ProductMedia {
+	var media []ProductMedia
+	for i := range nodes {
+		media = append(media, *nodes[i].media())
+	}
+	return media
+}
+
+func productGID(productID int64) string {
+	return fmt.Sprintf("gid://shopify/Product/%d", productID)
+}
+
+const productMediaFields = `id alt mediaContentType status mediaErrors { message } preview { image { url } }`
+
+const productMediaQuery = `query($id: ID!, $after: String) {
+  product(id: $id) {
+    media(first: 250, after: $after) {
+      edges { node { ` + productMediaFields + ` } }
+      pageInfo { hasNextPage endCursor }
+    }
+  }
+}`
+
+const productMediaNodeQuery = `query($id: ID!) {
+  node(id: $id) { ... on Media { ` + productMediaFields + ` } }
+}`
+
+const productCreateMediaMutation = `mutation($productId: ID!, $media: [CreateMediaInput!]!) {
+  productCreateMedia(productId: $productId, media: $media) {
+    media { ` + productMediaFields + ` }
+    mediaUserErrors { field message }
+  }
+}`
+
+const productReorderMediaMutation = `mutation($id: ID!, $moves: [MoveInput!]!) {
+  productReorderMedia(id: $id, moves: $moves) {
+    job { id }
+    mediaUserErrors { field message }
+  }
+}`
+
+const productDeleteMediaMutation = `mutation($productId: ID!, $mediaIds: [ID!]!) {
+  productDeleteMedia(productId: $productId, mediaIds: $mediaIds) {
+    deletedMediaIds
+    mediaUserErrors { field message }
+  }
+}`
+
+// List the media of a product in order.
+func (s *ProductMediaServiceOp) List(productID int64) ([]ProductMedia, error) {
+	var media []ProductMedia
+	var after *string
+	for {
+		vars := map[string]interface{}{
+			"id":    productGID(productID),
+			"after": after,
+		}
+		resp := struct {
+			Product *struct {
+				Media struct {
+					Edges []struct {
+						Node productMediaNode `json:"node"`
+					} `json:"edges"`
+					PageInfo graphQLPageInfo `json:"pageInfo"`
+				} `json:"media"`
+			} `json:"product"`
+		}{}
+
+		err := s.client.GraphQL.Query(productMediaQuery, vars, &resp)
+		if err != nil || resp.Product == nil {
+			return media, err
+		}
+
+		for i := range resp.Product.Media.Edges {
+			media = append(media, *resp.Product.Media.Edges[i].Node.media())
+		}
+
+		pageInfo := resp.Product.Media.PageInfo
+		if !pageInfo.HasNextPage {
+			return media, nil
+		}
+		after = &pageInfo.EndCursor
+	}
+}
+
+// Get media by its ID, nil if there is none.
+func (s *ProductMediaServiceOp) Get(id string) (*ProductMedia, error) {
+	resp := struct {
+		Node *productMediaNode `json:"node"`
+	}{}
+	err := s.client.GraphQL.Query(productMediaNodeQuery, map[string]interface{}{"id": id}, &resp)
+	if err != nil || resp.Node == nil || resp.Node.ID == "" {
+		return nil, err
+	}
+	return resp.Node.media(), nil
+}
+
+// Create adds media to a product. The media is processed asynchronously, see
+// WaitReady.
+func (s *ProductMediaServiceOp) Create(productID int64, media ...ProductMediaInput) ([]ProductMedia, error) {
+	vars := map[string]interface{}{
+		"productId": productGID(productID),
+		"media":     media,
+	}
+	resp := struct {
+		ProductCreateMedia struct {
+			Media           []productMediaNode `json:"media"`
+			MediaUserErrors []UserError        `json:"mediaUserErrors"`
+		} `json:"productCreateMedia"`
+	}{}
+
+	err := s.client.GraphQL.Query(productCreateMediaMutation, vars, &resp)
+	if err == nil {
+		err = userErrorsToError(resp.ProductCreateMedia.MediaUserErrors)
+	}
+	return productMediaNodesToMedia(resp.ProductCreateMedia.Media), err
+}
+
+// Reorder moves media of a product to new positions. Shopify applies the
+// moves asynchronously.
+func (s *ProductMediaServiceOp) Reorder(productID int64, moves ...ProductMediaMove) error {
+	vars := map[string]interface{}{
+		"id":    productGID(productID),
+		"moves": moves,
+	}
+	resp := struct {
+		ProductReorderMedia struct {
+			MediaUserErrors []UserError `json:"mediaUserErrors"`
+		} `json:"productReorderMedia"`
+	}{}
+
+	err := s.client.GraphQL.Query(productReorderMediaMutation, vars, &resp)
+	if err != nil {
+		return err
+	}
+	return userErrorsToError(resp.ProductReorderMedia.MediaUserErrors)
+}
+
+// Delete media of a product by their IDs.
+func (s *ProductMediaServiceOp) Delete(productID int64, mediaIDs ...string) error {
+	vars := map[string]interface{}{
+		"productId": productGID(productID),
+		"mediaIds":  mediaIDs,
+	}
+	resp := struct {
+		ProductDeleteMedia struct {
+			MediaUserErrors []UserError `json:"mediaUserErrors"`
+		} `json:"productDeleteMedia"`
+	}{}
+
+	err := s.client.GraphQL.Query(productDeleteMediaMutation, vars, &resp)
+	if err != nil {
+		return err
+	}
+	return userErrorsToError(resp.ProductDeleteMedia.MediaUserErrors)
+}
+
+// WaitReady polls the media every interval, 2 seconds when zero, until it was
+// processed or the context is done. Media that failed processing is returned
+// along with an error holding its media errors.
+func (s *ProductMediaServiceOp) WaitReady(ctx context.Context, id string, interval time.Duration) (*ProductMedia, error) {
+	if interval <= 0 {
+		interval = defaultMediaPollInterval
+	}
+
+	for {
+		media, err := s.Get(id)
+		if err != nil {
+			return nil, err
+		}
+		if media == nil {
+			return nil, fmt.Errorf("media %s not found", id)
+		}
+		if media.Status == MediaStatusFailed {
+			return media, fmt.Errorf("processing media %s failed: %s", id, strings.Join(media.Errors, ", "))
+		}
+		if media.Ready() {
+			return media, nil
+		}
+		if err := sleepContext(ctx, interval); err != nil {
+			return media, err
+		}
+	}
+}
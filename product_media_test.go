@@ -0,0 +1,175 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+// recordGraphQL responds to GraphQL requests with the responses in order and
+// records the requests.
+func recordGraphQL(requests *[]graphQLRequest, responses ...string) httpmock.Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		var request graphQLRequest
+		if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+			return nil, err
+		}
+		*requests = append(*requests, request)
+		response := responses[len(responses)-1]
+		if len(*requests) <= len(responses) {
+			response = responses[len(*requests)-1]
+		}
+		return httpmock.NewStringResponse(200, response), nil
+	}
+}
+
+func TestProductMediaList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests,
+			`{"data":{"product":{"media":{"edges":[{"node":{"id":"gid://shopify/MediaImage/1","alt":"Front","mediaContentType":"IMAGE","status":"READY","mediaErrors":[],"preview":{"image":{"url":"https://cdn.shopify.com/front.png"}}}}],"pageInfo":{"hasNextPage":true,"endCursor":"abc"}}}}}`,
+			`{"data":{"product":{"media":{"edges":[{"node":{"id":"gid://shopify/Video/2","alt":"","mediaContentType":"VIDEO","status":"PROCESSING","mediaErrors":[],"preview":{"image":null}}}],"pageInfo":{"hasNextPage":false}}}}}`,
+		))
+
+	media, err := client.ProductMedia.List(1)
+	if err != nil {
+		t.Fatalf("ProductMedia.List returned error: %v", err)
+	}
+
+	expected := []ProductMedia{
+		{ID: "gid://shopify/MediaImage/1", Alt: "Front", MediaContentType: MediaContentTypeImage, Status: MediaStatusReady, PreviewURL: "https://cdn.shopify.com/front.png"},
+		{ID: "gid://shopify/Video/2", MediaContentType: MediaContentTypeVideo, Status: MediaStatusProcessing},
+	}
+	if !reflect.DeepEqual(media, expected) {
+		t.Errorf("ProductMedia.List returned %+v, expected %+v", media, expected)
+	}
+	if len(requests) != 2 || requests[1].Variables.(map[string]interface{})["after"] != "abc" {
+		t.Errorf("ProductMedia.List sent %+v, expected the second page after abc", requests)
+	}
+	if id := requests[0].Variables.(map[string]interface{})["id"]; id != "gid://shopify/Product/1" {
+		t.Errorf("ProductMedia.List sent product %v", id)
+	}
+}
+
+func TestProductMediaCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests, `{"data":{"productCreateMedia":{"media":[{"id":"gid://shopify/ExternalVideo/1","alt":"Demo","mediaContentType":"EXTERNAL_VIDEO","status":"UPLOADED","mediaErrors":[]}],"mediaUserErrors":[]}}}`))
+
+	media, err := client.ProductMedia.Create(1, ProductMediaInput{
+		OriginalSource:   "https://www.youtube.com/watch?v=1",
+		Alt:              "Demo",
+		MediaContentType: MediaContentTypeExternalVideo,
+	})
+	if err != nil {
+		t.Fatalf("ProductMedia.Create returned error: %v", err)
+	}
+
+	expectedVars := map[string]interface{}{
+		"productId": "gid://shopify/Product/1",
+		"media": []interface{}{map[string]interface{}{
+			"originalSource":   "https://www.youtube.com/watch?v=1",
+			"alt":              "Demo",
+			"mediaContentType": "EXTERNAL_VIDEO",
+		}},
+	}
+	if !reflect.DeepEqual(requests[0].Variables, expectedVars) {
+		t.Errorf("ProductMedia.Create sent %+v, expected %+v", requests[0].Variables, expectedVars)
+	}
+	if len(media) != 1 || media[0].ID != "gid://shopify/ExternalVideo/1" || media[0].Status != MediaStatusUploaded {
+		t.Errorf("ProductMedia.Create returned %+v", media)
+	}
+}
+
+func TestProductMediaReorder(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests, `{"data":{"productReorderMedia":{"job":{"id":"gid://shopify/Job/1"},"mediaUserErrors":[]}}}`))
+
+	err := client.ProductMedia.Reorder(1, ProductMediaMove{ID: "gid://shopify/Video/2", NewPosition: 0})
+	if err != nil {
+		t.Fatalf("ProductMedia.Reorder returned error: %v", err)
+	}
+
+	expectedMoves := []interface{}{map[string]interface{}{"id": "gid://shopify/Video/2", "newPosition": "0"}}
+	if moves := requests[0].Variables.(map[string]interface{})["moves"]; !reflect.DeepEqual(moves, expectedMoves) {
+		t.Errorf("ProductMedia.Reorder sent moves %+v, expected %+v", moves, expectedMoves)
+	}
+}
+
+func TestProductMediaDeleteUserErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"data":{"productDeleteMedia":{"deletedMediaIds":null,"mediaUserErrors":[{"field":["mediaIds"],"message":"Media does not exist"}]}}}`))
+
+	err := client.ProductMedia.Delete(1, "gid://shopify/MediaImage/9")
+	if err == nil || err.Error() != "mediaIds: Media does not exist" {
+		t.Errorf("ProductMedia.Delete returned error %v, expected the user error", err)
+	}
+}
+
+func TestProductMediaWaitReady(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests,
+			`{"data":{"node":{"id":"gid://shopify/Video/1","mediaContentType":"VIDEO","status":"PROCESSING","mediaErrors":[]}}}`,
+			`{"data":{"node":{"id":"gid://shopify/Video/1","mediaContentType":"VIDEO","status":"READY","mediaErrors":[]}}}`,
+		))
+
+	media, err := client.ProductMedia.WaitReady(context.Background(), "gid://shopify/Video/1", time.Millisecond)
+	if err != nil {
+		t.Fatalf("ProductMedia.WaitReady returned error: %v", err)
+	}
+	if media.Status != MediaStatusReady || len(requests) != 2 {
+		t.Errorf("ProductMedia.WaitReady returned %+v after %d requests", media, len(requests))
+	}
+}
+
+func TestProductMediaWaitReadyFailed(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"data":{"node":{"id":"gid://shopify/Model3d/1","mediaContentType":"MODEL_3D","status":"FAILED","mediaErrors":[{"message":"Invalid file"}]}}}`))
+
+	media, err := client.ProductMedia.WaitReady(context.Background(), "gid://shopify/Model3d/1", time.Millisecond)
+	if media == nil || media.Status != MediaStatusFailed {
+		t.Errorf("ProductMedia.WaitReady returned %+v, expected the failed media", media)
+	}
+	if err == nil || err.Error() != "processing media gid://shopify/Model3d/1 failed: Invalid file" {
+		t.Errorf("ProductMedia.WaitReady returned error %v", err)
+	}
+}
+
+func TestProductMediaWaitReadyNotFound(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"data":{"node":null}}`))
+
+	_, err := client.ProductMedia.WaitReady(context.Background(), "gid://shopify/Video/1", time.Millisecond)
+	if err == nil || err.Error() != "media gid://shopify/Video/1 not found" {
+		t.Errorf("ProductMedia.WaitReady returned error %v, expected not found", err)
+	}
+}
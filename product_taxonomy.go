@@ -0,0 +1,149 @@
+package goshopify
+
+// ProductTaxonomyService is an interface for the standardized product
+// taxonomy of Shopify: searching its categories and reading and setting the
+// category of a product together with its product type. Categories are only
+// available through the GraphQL Admin API, productUpdate takes them from
+// version 2024-10.
+// See: https://shopify.dev/docs/apps/build/product-merchandising/products-and-collections/product-taxonomy
+type ProductTaxonomyService interface {
+	SearchCategories(string) ([]TaxonomyCategory, error)
+	GetProductCategory(int64) (*ProductCategorization, error)
+	SetProductCategory(int64, ProductCategorization) (*ProductCategorization, error)
+}
+
+// ProductTaxonomyServiceOp handles communication with the taxonomy related
+// GraphQL queries and mutations.
+type ProductTaxonomyServiceOp struct {
+	client *Client
+}
+
+// TaxonomyCategory represents a node of the standardized product taxonomy,
+// e.g. gid://shopify/TaxonomyCategory/aa-1-13-8 for Apparel & Accessories >
+// Clothing > Tops > T-Shirts. Products should be categorized with leaves.
+type TaxonomyCategory struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	FullName    string   `json:"fullName"`
+	IsLeaf      bool     `json:"isLeaf"`
+	IsRoot      bool     `json:"isRoot"`
+	Level       int      `json:"level"`
+	ParentID    string   `json:"parentId,omitempty"`
+	AncestorIDs []string `json:"ancestorIds,omitempty"`
+}
+
+// ProductCategorization is the standardized category of a product with its
+// product type, the merchant's own classification. Category is nil for
+// uncategorized products. When setting it only the ID of the Category is
+// sent, an empty ProductType leaves the product type as is.
+type ProductCategorization struct {
+	ProductType string            `json:"productType"`
+	Category    *TaxonomyCategory `json:"category"`
+}
+
+const taxonomyCategoryFields = `id name fullName isLeaf isRoot level parentId ancestorIds`
+
+const taxonomyCategoriesQuery = `query($search: String, $after: String) {
+  taxonomy {
+    categories(first: 250, search: $search, after: $after) {
+      edges { node { ` + taxonomyCategoryFields + ` } }
+      pageInfo { hasNextPage endCursor }
+    }
+  }
+}`
+
+const productCategoryQuery = `query($id: ID!) {
+  product(id: $id) {
+    productType
+    category { ` + taxonomyCategoryFields + ` }
+  }
+}`
+
+const productCategoryUpdateMutation = `mutation($product: ProductUpdateInput!) {
+  productUpdate(product: $product) {
+    product {
+      productType
+      category { ` + taxonomyCategoryFields + ` }
+    }
+    userErrors { field message }
+  }
+}`
+
+// SearchCategories lists the taxonomy categories matching search, e.g.
+// "t-shirts", or every category when search is empty.
+func (s *ProductTaxonomyServiceOp) SearchCategories(search string) ([]TaxonomyCategory, error) {
+	var categories []TaxonomyCategory
+	var after *string
+	for {
+		vars := map[string]interface{}{"after": after}
+		if search != "" {
+			vars["search"] = search
+		}
+		resp := struct {
+			Taxonomy struct {
+				Categories struct {
+					Edges []struct {
+						Node TaxonomyCategory `json:"node"`
+					} `json:"edges"`
+					PageInfo graphQLPageInfo `json:"pageInfo"`
+				} `json:"categories"`
+			} `json:"taxonomy"`
+		}{}
+
+		err := s.client.GraphQL.Query(taxonomyCategoriesQuery, vars, &resp)
+		if err != nil {
+			return categories, err
+		}
+
+		for _, edge := range resp.Taxonomy.Categories.Edges {
+			categories = append(categories, edge.Node)
+		}
+
+		pageInfo := resp.Taxonomy.Categories.PageInfo
+		if !pageInfo.HasNextPage {
+			return categories, nil
+		}
+		after = &pageInfo.EndCursor
+	}
+}
+
+// GetProductCategory gets the category and product type of a product, nil if
+// there is no such product.
+func (s *ProductTaxonomyServiceOp) GetProductCategory(productID int64) (*ProductCategorization, error) {
+	resp := struct {
+		Product *ProductCategorization `json:"product"`
+	}{}
+
+	err := s.client.GraphQL.Query(productCategoryQuery, map[string]interface{}{"id": productGID(productID)}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Product, nil
+}
+
+// SetProductCategory sets the category of a product, and its product type
+// unless empty, in a single update and returns both as saved.
+func (s *ProductTaxonomyServiceOp) SetProductCategory(productID int64, categorization ProductCategorization) (*ProductCategorization, error) {
+	product := map[string]interface{}{"id": productGID(productID)}
+	if categorization.Category != nil {
+		product["category"] = categorization.Category.ID
+	}
+	if categorization.ProductType != "" {
+		product["productType"] = categorization.ProductType
+	}
+	resp := struct {
+		ProductUpdate struct {
+			Product    *ProductCategorization `json:"product"`
+			UserErrors []UserError            `json:"userErrors"`
+		} `json:"productUpdate"`
+	}{}
+
+	err := s.client.GraphQL.Query(productCategoryUpdateMutation, map[string]interface{}{"product": product}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if err := userErrorsToError(resp.ProductUpdate.UserErrors); err != nil {
+		return nil, err
+	}
+	return resp.ProductUpdate.Product, nil
+}
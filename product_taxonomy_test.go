@@ -0,0 +1,118 @@
+package goshopify
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestProductTaxonomySearchCategories(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests,
+			`{"data":{"taxonomy":{"categories":{"edges":[{"node":{"id":"gid://shopify/TaxonomyCategory/aa-1-13-8","name":"T-Shirts","fullName":"Apparel & Accessories > Clothing > Clothing Tops > T-Shirts","isLeaf":true,"isRoot":false,"level":4,"parentId":"gid://shopify/TaxonomyCategory/aa-1-13","ancestorIds":["gid://shopify/TaxonomyCategory/aa-1-13","gid://shopify/TaxonomyCategory/aa-1","gid://shopify/TaxonomyCategory/aa"]}}],"pageInfo":{"hasNextPage":true,"endCursor":"abc"}}}}}`,
+			`{"data":{"taxonomy":{"categories":{"edges":[{"node":{"id":"gid://shopify/TaxonomyCategory/bt-3-2","name":"Baby T-Shirts","fullName":"Baby & Toddler > Baby Clothing > Baby T-Shirts","isLeaf":true,"isRoot":false,"level":3}}],"pageInfo":{"hasNextPage":false}}}}}`,
+		))
+
+	categories, err := client.ProductTaxonomy.SearchCategories("t-shirts")
+	if err != nil {
+		t.Fatalf("ProductTaxonomy.SearchCategories returned error: %v", err)
+	}
+
+	if len(categories) != 2 || categories[0].Name != "T-Shirts" || !categories[0].IsLeaf ||
+		len(categories[0].AncestorIDs) != 3 || categories[1].ID != "gid://shopify/TaxonomyCategory/bt-3-2" {
+		t.Errorf("ProductTaxonomy.SearchCategories returned %+v", categories)
+	}
+	if len(requests) != 2 {
+		t.Fatalf("ProductTaxonomy.SearchCategories sent %d requests, expected 2", len(requests))
+	}
+	first := requests[0].Variables.(map[string]interface{})
+	second := requests[1].Variables.(map[string]interface{})
+	if first["search"] != "t-shirts" || second["search"] != "t-shirts" || second["after"] != "abc" {
+		t.Errorf("ProductTaxonomy.SearchCategories sent %+v", requests)
+	}
+}
+
+func TestProductTaxonomyGetProductCategory(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests,
+			`{"data":{"product":{"productType":"Tee","category":{"id":"gid://shopify/TaxonomyCategory/aa-1-13-8","name":"T-Shirts","fullName":"Apparel & Accessories > Clothing > Clothing Tops > T-Shirts","isLeaf":true,"isRoot":false,"level":4}}}}`,
+			`{"data":{"product":null}}`,
+		))
+
+	categorization, err := client.ProductTaxonomy.GetProductCategory(1)
+	if err != nil {
+		t.Fatalf("ProductTaxonomy.GetProductCategory returned error: %v", err)
+	}
+	if categorization == nil || categorization.ProductType != "Tee" || categorization.Category == nil ||
+		categorization.Category.ID != "gid://shopify/TaxonomyCategory/aa-1-13-8" {
+		t.Errorf("ProductTaxonomy.GetProductCategory returned %+v", categorization)
+	}
+	if id := requests[0].Variables.(map[string]interface{})["id"]; id != "gid://shopify/Product/1" {
+		t.Errorf("ProductTaxonomy.GetProductCategory sent product %v", id)
+	}
+
+	categorization, err = client.ProductTaxonomy.GetProductCategory(2)
+	if err != nil || categorization != nil {
+		t.Errorf("ProductTaxonomy.GetProductCategory of a missing product returned %+v, %v", categorization, err)
+	}
+}
+
+func TestProductTaxonomySetProductCategory(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests,
+			`{"data":{"productUpdate":{"product":{"productType":"Tee","category":{"id":"gid://shopify/TaxonomyCategory/aa-1-13-8","name":"T-Shirts"}},"userErrors":[]}}}`,
+			`{"data":{"productUpdate":{"product":null,"userErrors":[{"field":["product","category"],"message":"Category does not exist"}]}}}`,
+		))
+
+	categorization, err := client.ProductTaxonomy.SetProductCategory(1, ProductCategorization{
+		ProductType: "Tee",
+		Category:    &TaxonomyCategory{ID: "gid://shopify/TaxonomyCategory/aa-1-13-8"},
+	})
+	if err != nil {
+		t.Fatalf("ProductTaxonomy.SetProductCategory returned error: %v", err)
+	}
+	if categorization == nil || categorization.Category == nil || categorization.Category.Name != "T-Shirts" {
+		t.Errorf("ProductTaxonomy.SetProductCategory returned %+v", categorization)
+	}
+
+	expectedVars := map[string]interface{}{
+		"product": map[string]interface{}{
+			"id":          "gid://shopify/Product/1",
+			"category":    "gid://shopify/TaxonomyCategory/aa-1-13-8",
+			"productType": "Tee",
+		},
+	}
+	if !reflect.DeepEqual(requests[0].Variables, expectedVars) {
+		t.Errorf("ProductTaxonomy.SetProductCategory sent %+v, expected %+v", requests[0].Variables, expectedVars)
+	}
+
+	_, err = client.ProductTaxonomy.SetProductCategory(1, ProductCategorization{
+		Category: &TaxonomyCategory{ID: "gid://shopify/TaxonomyCategory/nope"},
+	})
+	if err == nil {
+		t.Error("ProductTaxonomy.SetProductCategory expected the user error")
+	}
+	expectedVars = map[string]interface{}{
+		"product": map[string]interface{}{
+			"id":       "gid://shopify/Product/1",
+			"category": "gid://shopify/TaxonomyCategory/nope",
+		},
+	}
+	if !reflect.DeepEqual(requests[1].Variables, expectedVars) {
+		t.Errorf("ProductTaxonomy.SetProductCategory sent %+v, expected %+v", requests[1].Variables, expectedVars)
+	}
+}
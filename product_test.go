@@ -1,11 +1,16 @@
 package goshopify
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"reflect"
 	"runtime"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -49,7 +54,7 @@ func TestProductListFilterByIds(t *testing.T) {
 		params,
 		httpmock.NewStringResponder(200, `{"products": [{"id":1},{"id":2},{"id":3}]}`))
 
-	listOptions := ListOptions{IDs: []int64{1, 2, 3}}
+	listOptions := &ProductListOptions{ListOptions: ListOptions{IDs: []int64{1, 2, 3}}}
 
 	products, err := client.Product.List(listOptions)
 	if err != nil {
@@ -81,6 +86,43 @@ func TestProductListError(t *testing.T) {
 	}
 }
 
+func TestProductListOptions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	params := map[string]string{
+		"title":            "Shirt",
+		"vendor":           "Acme",
+		"status":           "active,draft",
+		"collection_id":    "7",
+		"published_status": "published",
+		"fields":           "id,title",
+		"limit":            "50",
+	}
+	httpmock.RegisterResponderWithQuery(
+		"GET",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/products.json", client.pathPrefix),
+		params,
+		httpmock.NewStringResponder(200, `{"products": [{"id":1}]}`))
+
+	products, err := client.Product.List(&ProductListOptions{
+		ListOptions:     ListOptions{Limit: 50, Fields: "id,title"},
+		Title:           "Shirt",
+		Vendor:          "Acme",
+		Status:          "active,draft",
+		CollectionID:    7,
+		PublishedStatus: "published",
+	})
+	if err != nil {
+		t.Errorf("Product.List returned error: %v", err)
+	}
+
+	expected := []Product{{ID: 1}}
+	if !reflect.DeepEqual(products, expected) {
+		t.Errorf("Product.List returned %+v, expected %+v", products, expected)
+	}
+}
+
 func TestProductListWithPagination(t *testing.T) {
 	setup()
 	defer teardown()
@@ -151,6 +193,7 @@ func TestProductListWithPagination(t *testing.T) {
 			[]Product{{ID: 1}},
 			&Pagination{
 				NextPageOptions: &ListOptions{PageInfo: "foo", Limit: 2},
+				NextPageURL:     "http://valid.url?page_info=foo&limit=2",
 			},
 			nil,
 		},
@@ -161,6 +204,8 @@ func TestProductListWithPagination(t *testing.T) {
 			&Pagination{
 				NextPageOptions:     &ListOptions{PageInfo: "foo"},
 				PreviousPageOptions: &ListOptions{PageInfo: "bar"},
+				NextPageURL:         "http://valid.url?page_info=foo",
+				PreviousPageURL:     "http://valid.url?page_info=bar",
 			},
 			nil,
 		},
@@ -237,6 +282,42 @@ func TestProductCount(t *testing.T) {
 	}
 }
 
+func TestProductCountFilters(t *testing.T) {
+	setup()
+	defer teardown()
+
+	params := map[string]string{
+		"updated_at_min":   "2016-01-01T00:00:00Z",
+		"published_at_max": "2016-02-01T00:00:00Z",
+		"published_status": "published",
+		"vendor":           "Acme",
+		"product_type":     "Shirts",
+		"collection_id":    "841564295",
+	}
+	httpmock.RegisterResponderWithQuery(
+		"GET",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/products/count.json", client.pathPrefix),
+		params,
+		httpmock.NewStringResponder(200, `{"count": 4}`))
+
+	cnt, err := client.Product.Count(CountOptions{
+		UpdatedAtMin:    time.Date(2016, time.January, 1, 0, 0, 0, 0, time.UTC),
+		PublishedAtMax:  time.Date(2016, time.February, 1, 0, 0, 0, 0, time.UTC),
+		PublishedStatus: "published",
+		Vendor:          "Acme",
+		ProductType:     "Shirts",
+		CollectionID:    841564295,
+	})
+	if err != nil {
+		t.Errorf("Product.Count returned error: %v", err)
+	}
+
+	expected := 4
+	if cnt != expected {
+		t.Errorf("Product.Count returned %d, expected %d", cnt, expected)
+	}
+}
+
 func TestProductGet(t *testing.T) {
 	setup()
 	defer teardown()
@@ -297,6 +378,58 @@ func TestProductUpdate(t *testing.T) {
 	productTests(t, *returnedProduct)
 }
 
+func TestProductLifecycle(t *testing.T) {
+	cases := []struct {
+		name     string
+		call     func(ProductService, int64) (*Product, error)
+		expected string
+	}{
+		{"Publish", ProductService.Publish, `{"product":{"id":1,"status":"active","published":true}}`},
+		{"Unpublish", ProductService.Unpublish, `{"product":{"id":1,"published":false}}`},
+		{"Archive", ProductService.Archive, `{"product":{"id":1,"status":"archived"}}`},
+	}
+
+	for _, c := range cases {
+		setup()
+
+		var body string
+		httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix),
+			func(req *http.Request) (*http.Response, error) {
+				data, _ := ioutil.ReadAll(req.Body)
+				body = string(data)
+				return httpmock.NewStringResponse(200, `{"product": {"id": 1, "status": "archived"}}`), nil
+			})
+
+		product, err := c.call(client.Product, 1)
+		if err != nil {
+			t.Errorf("Product.%s returned error: %v", c.name, err)
+		} else if product.ID != 1 {
+			t.Errorf("Product.%s returned %+v", c.name, product)
+		}
+		if body != c.expected {
+			t.Errorf("Product.%s sent %s, expected %s", c.name, body, c.expected)
+		}
+
+		teardown()
+	}
+}
+
+func TestProductStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"product": {"id": 1, "status": "draft"}}`))
+
+	product, err := client.Product.Get(1, nil)
+	if err != nil {
+		t.Fatalf("Product.Get returned error: %v", err)
+	}
+	if product.Status != ProductStatusDraft {
+		t.Errorf("Product.Status returned %q, expected %q", product.Status, ProductStatusDraft)
+	}
+}
+
 func TestProductDelete(t *testing.T) {
 	setup()
 	defer teardown()
@@ -439,3 +572,113 @@ func TestProductDeleteMetafield(t *testing.T) {
 		t.Errorf("Product.DeleteMetafield() returned error: %v", err)
 	}
 }
+
+func TestProductGetByHandle(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponderWithQuery(
+		"GET",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/products.json", client.pathPrefix),
+		map[string]string{"handle": "summer"},
+		httpmock.NewStringResponder(200, `{"products": [{"id": 1, "handle": "summer-sale"}, {"id": 2, "handle": "summer"}]}`))
+	httpmock.RegisterResponderWithQuery(
+		"GET",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/products.json", client.pathPrefix),
+		map[string]string{"handle": "winter"},
+		httpmock.NewStringResponder(200, `{"products": []}`))
+
+	found, err := client.Product.GetByHandle("summer")
+	if err != nil {
+		t.Fatalf("Product.GetByHandle returned error: %v", err)
+	}
+	if found.ID != 2 {
+		t.Errorf("Product.GetByHandle returned %+v, expected ID 2", found)
+	}
+
+	_, err = client.Product.GetByHandle("winter")
+	expected := NotFoundError{Resource: "products", Handle: "winter"}
+	if err != expected {
+		t.Errorf("Product.GetByHandle returned error %v, expected %v", err, expected)
+	}
+	if err.Error() != `products with handle "winter" not found` {
+		t.Errorf("NotFoundError.Error returned %q", err.Error())
+	}
+}
+
+func TestFollowNext(t *testing.T) {
+	setup()
+	defer teardown()
+
+	listURL := fmt.Sprintf("https://fooshop.myshopify.com/%s/products.json", client.pathPrefix)
+	httpmock.RegisterResponderWithQuery("GET", listURL, map[string]string{"vendor": "Acme", "limit": "1"},
+		func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(200, `{"products": [{"id":1}]}`)
+			resp.Header.Set("Link", fmt.Sprintf(`<%s?limit=1&vendor=Acme&page_info=abc>; rel="next"`, listURL))
+			return resp, nil
+		})
+	httpmock.RegisterResponderWithQuery("GET", listURL, map[string]string{"vendor": "Acme", "limit": "1", "page_info": "abc"},
+		func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(200, `{"products": [{"id":2}]}`)
+			resp.Header.Set("Link", fmt.Sprintf(`<%s?limit=1&vendor=Acme&page_info=xyz>; rel="previous"`, listURL))
+			return resp, nil
+		})
+
+	_, pagination, err := client.Product.ListWithPagination(&ProductListOptions{ListOptions: ListOptions{Limit: 1}, Vendor: "Acme"})
+	if err != nil {
+		t.Fatalf("Product.ListWithPagination returned error: %v", err)
+	}
+	if expected := listURL + "?limit=1&vendor=Acme&page_info=abc"; pagination.NextPageURL != expected {
+		t.Errorf("Pagination.NextPageURL is %q, expected %q", pagination.NextPageURL, expected)
+	}
+
+	var page ProductsResource
+	pagination, err = client.FollowNext(pagination, &page)
+	if err != nil {
+		t.Fatalf("FollowNext returned error: %v", err)
+	}
+	if expected := []Product{{ID: 2}}; !reflect.DeepEqual(page.Products, expected) {
+		t.Errorf("FollowNext got %+v, expected %+v", page.Products, expected)
+	}
+
+	if _, err = client.FollowNext(pagination, &page); err != ErrNoPage {
+		t.Errorf("FollowNext of the last page returned %v, expected ErrNoPage", err)
+	}
+	if pagination.PreviousPageURL == "" {
+		t.Errorf("FollowNext returned no previous page")
+	}
+}
+
+func TestProductListByIDs(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var mu sync.Mutex
+	var chunks []int
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			ids := strings.Split(req.URL.Query().Get("ids"), ",")
+			mu.Lock()
+			chunks = append(chunks, len(ids))
+			mu.Unlock()
+			return httpmock.NewStringResponse(200, fmt.Sprintf(`{"products": [{"id":%s}]}`, ids[0])), nil
+		})
+
+	ids := make([]int64, 0, 600)
+	for id := int64(1); id <= 600; id++ {
+		ids = append(ids, id)
+	}
+	products, err := client.Product.ListByIDs(context.Background(), ids)
+	if err != nil {
+		t.Fatalf("Product.ListByIDs returned error: %v", err)
+	}
+
+	expected := []Product{{ID: 1}, {ID: 251}, {ID: 501}}
+	if !reflect.DeepEqual(products, expected) {
+		t.Errorf("Product.ListByIDs returned %+v, expected %+v", products, expected)
+	}
+	sort.Ints(chunks)
+	if !reflect.DeepEqual(chunks, []int{100, 250, 250}) {
+		t.Errorf("Product.ListByIDs requested chunks of %v IDs, expected 250, 250 and 100", chunks)
+	}
+}
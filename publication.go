@@ -0,0 +1,164 @@
+package goshopify
+
+import (
+	"fmt"
+	"time"
+)
+
+// PublicationService is an interface for listing the publications of the
+// shop, i.e. the sales channels and catalogs, and for publishing products and
+// collections to them through the GraphQL Admin API.
+// See: https://shopify.dev/docs/api/admin-graphql/latest/objects/Publication
+type PublicationService interface {
+	List() ([]Publication, error)
+	Publish(string, ...PublicationInput) error
+	Unpublish(string, ...string) error
+	PublishProduct(int64, ...string) error
+	UnpublishProduct(int64, ...string) error
+	PublishCollection(int64, ...string) error
+	UnpublishCollection(int64, ...string) error
+}
+
+// PublicationServiceOp handles communication with the publication related
+// GraphQL queries and mutations.
+type PublicationServiceOp struct {
+	client *Client
+}
+
+// Publication represents a sales channel or catalog resources are published
+// to. AutoPublish publications get new products automatically.
+type Publication struct {
+	ID                       string `json:"id"`
+	Name                     string `json:"name"`
+	AutoPublish              bool   `json:"autoPublish"`
+	SupportsFuturePublishing bool   `json:"supportsFuturePublishing"`
+}
+
+// PublicationInput publishes a resource to a publication, at the PublishDate
+// if the publication supports future publishing, otherwise right away.
+type PublicationInput struct {
+	PublicationID string     `json:"publicationId"`
+	PublishDate   *time.Time `json:"publishDate,omitempty"`
+}
+
+func collectionGID(collectionID int64) string {
+	return fmt.Sprintf("gid://shopify/Collection/%d", collectionID)
+}
+
+const publicationsQuery = `query($after: String) {
+  publications(first: 250, after: $after) {
+    edges { node { id name autoPublish supportsFuturePublishing } }
+    pageInfo { hasNextPage endCursor }
+  }
+}`
+
+const publishablePublishMutation = `mutation($id: ID!, $input: [PublicationInput!]!) {
+  publishablePublish(id: $id, input: $input) {
+    userErrors { field message }
+  }
+}`
+
+const publishableUnpublishMutation = `mutation($id: ID!, $input: [PublicationInput!]!) {
+  publishableUnpublish(id: $id, input: $input) {
+    userErrors { field message }
+  }
+}`
+
+// List the publications of the shop.
+func (s *PublicationServiceOp) List() ([]Publication, error) {
+	var publications []Publication
+	var after *string
+	for {
+		resp := struct {
+			Publications struct {
+				Edges []struct {
+					Node Publication `json:"node"`
+				} `json:"edges"`
+				PageInfo graphQLPageInfo `json:"pageInfo"`
+			} `json:"publications"`
+		}{}
+
+		err := s.client.GraphQL.Query(publicationsQuery, map[string]interface{}{"after": after}, &resp)
+		if err != nil {
+			return publications, err
+		}
+
+		for _, edge := range resp.Publications.Edges {
+			publications = append(publications, edge.Node)
+		}
+
+		pageInfo := resp.Publications.PageInfo
+		if !pageInfo.HasNextPage {
+			return publications, nil
+		}
+		after = &pageInfo.EndCursor
+	}
+}
+
+// Publish publishes a resource, e.g. gid://shopify/Product/1, to publications.
+func (s *PublicationServiceOp) Publish(id string, publications ...PublicationInput) error {
+	vars := map[string]interface{}{
+		"id":    id,
+		"input": publications,
+	}
+	resp := struct {
+		PublishablePublish struct {
+			UserErrors []UserError `json:"userErrors"`
+		} `json:"publishablePublish"`
+	}{}
+
+	err := s.client.GraphQL.Query(publishablePublishMutation, vars, &resp)
+	if err != nil {
+		return err
+	}
+	return userErrorsToError(resp.PublishablePublish.UserErrors)
+}
+
+// Unpublish unpublishes a resource from publications by their IDs.
+func (s *PublicationServiceOp) Unpublish(id string, publicationIDs ...string) error {
+	vars := map[string]interface{}{
+		"id":    id,
+		"input": publicationInputs(publicationIDs),
+	}
+	resp := struct {
+		PublishableUnpublish struct {
+			UserErrors []UserError `json:"userErrors"`
+		} `json:"publishableUnpublish"`
+	}{}
+
+	err := s.client.GraphQL.Query(publishableUnpublishMutation, vars, &resp)
+	if err != nil {
+		return err
+	}
+	return userErrorsToError(resp.PublishableUnpublish.UserErrors)
+}
+
+// PublishProduct publishes a product to publications by their IDs right away.
+func (s *PublicationServiceOp) PublishProduct(productID int64, publicationIDs ...string) error {
+	return s.Publish(productGID(productID), publicationInputs(publicationIDs)...)
+}
+
+// UnpublishProduct unpublishes a product from publications by their IDs.
+func (s *PublicationServiceOp) UnpublishProduct(productID int64, publicationIDs ...string) error {
+	return s.Unpublish(productGID(productID), publicationIDs...)
+}
+
+// PublishCollection publishes a collection to publications by their IDs right
+// away.
+func (s *PublicationServiceOp) PublishCollection(collectionID int64, publicationIDs ...string) error {
+	return s.Publish(collectionGID(collectionID), publicationInputs(publicationIDs)...)
+}
+
+// UnpublishCollection unpublishes a collection from publications by their
+// IDs.
+func (s *PublicationServiceOp) UnpublishCollection(collectionID int64, publicationIDs ...string) error {
+	return s.Unpublish(collectionGID(collectionID), publicationIDs...)
+}
+
+func publicationInputs(publicationIDs []string) []PublicationInput {
+	input := make([]PublicationInput, 0, len(publicationIDs))
+	for _, publicationID := range publicationIDs {
+		input = append(input, PublicationInput{PublicationID: publicationID})
+	}
+	return input
+}
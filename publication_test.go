@@ -0,0 +1,129 @@
+package goshopify
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestPublicationList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests,
+			`{"data":{"publications":{"edges":[{"node":{"id":"gid://shopify/Publication/1","name":"Online Store","autoPublish":false,"supportsFuturePublishing":true}}],"pageInfo":{"hasNextPage":true,"endCursor":"abc"}}}}`,
+			`{"data":{"publications":{"edges":[{"node":{"id":"gid://shopify/Publication/2","name":"Point of Sale","autoPublish":true,"supportsFuturePublishing":false}}],"pageInfo":{"hasNextPage":false}}}}`,
+		))
+
+	publications, err := client.Publication.List()
+	if err != nil {
+		t.Fatalf("Publication.List returned error: %v", err)
+	}
+
+	expected := []Publication{
+		{ID: "gid://shopify/Publication/1", Name: "Online Store", SupportsFuturePublishing: true},
+		{ID: "gid://shopify/Publication/2", Name: "Point of Sale", AutoPublish: true},
+	}
+	if !reflect.DeepEqual(publications, expected) {
+		t.Errorf("Publication.List returned %+v, expected %+v", publications, expected)
+	}
+	if len(requests) != 2 || requests[1].Variables.(map[string]interface{})["after"] != "abc" {
+		t.Errorf("Publication.List sent %+v, expected the second page after abc", requests)
+	}
+}
+
+func TestPublicationPublish(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests, `{"data":{"publishablePublish":{"userErrors":[]}}}`))
+
+	publishDate := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	err := client.Publication.Publish("gid://shopify/Product/1", PublicationInput{
+		PublicationID: "gid://shopify/Publication/1",
+		PublishDate:   &publishDate,
+	})
+	if err != nil {
+		t.Fatalf("Publication.Publish returned error: %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"id": "gid://shopify/Product/1",
+		"input": []interface{}{
+			map[string]interface{}{"publicationId": "gid://shopify/Publication/1", "publishDate": "2024-03-01T09:00:00Z"},
+		},
+	}
+	if !reflect.DeepEqual(requests[0].Variables, expected) {
+		t.Errorf("Publication.Publish sent %+v, expected %+v", requests[0].Variables, expected)
+	}
+}
+
+func TestPublicationProductsAndCollections(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests,
+			`{"data":{"publishablePublish":{"userErrors":[]}}}`,
+			`{"data":{"publishableUnpublish":{"userErrors":[]}}}`,
+			`{"data":{"publishablePublish":{"userErrors":[]}}}`,
+			`{"data":{"publishableUnpublish":{"userErrors":[]}}}`,
+		))
+
+	if err := client.Publication.PublishProduct(1, "gid://shopify/Publication/1", "gid://shopify/Publication/2"); err != nil {
+		t.Errorf("Publication.PublishProduct returned error: %v", err)
+	}
+	if err := client.Publication.UnpublishProduct(1, "gid://shopify/Publication/2"); err != nil {
+		t.Errorf("Publication.UnpublishProduct returned error: %v", err)
+	}
+	if err := client.Publication.PublishCollection(3, "gid://shopify/Publication/1"); err != nil {
+		t.Errorf("Publication.PublishCollection returned error: %v", err)
+	}
+	if err := client.Publication.UnpublishCollection(3, "gid://shopify/Publication/1"); err != nil {
+		t.Errorf("Publication.UnpublishCollection returned error: %v", err)
+	}
+
+	publication := func(id string) interface{} {
+		return map[string]interface{}{"publicationId": id}
+	}
+	expected := []map[string]interface{}{
+		{"id": "gid://shopify/Product/1", "input": []interface{}{publication("gid://shopify/Publication/1"), publication("gid://shopify/Publication/2")}},
+		{"id": "gid://shopify/Product/1", "input": []interface{}{publication("gid://shopify/Publication/2")}},
+		{"id": "gid://shopify/Collection/3", "input": []interface{}{publication("gid://shopify/Publication/1")}},
+		{"id": "gid://shopify/Collection/3", "input": []interface{}{publication("gid://shopify/Publication/1")}},
+	}
+	if len(requests) != len(expected) {
+		t.Fatalf("Publication sent %d requests, expected %d", len(requests), len(expected))
+	}
+	for i := range expected {
+		if !reflect.DeepEqual(requests[i].Variables, expected[i]) {
+			t.Errorf("request %d sent %+v, expected %+v", i, requests[i].Variables, expected[i])
+		}
+	}
+	if !strings.Contains(requests[1].Query, "publishableUnpublish") {
+		t.Errorf("Publication.UnpublishProduct sent query %s", requests[1].Query)
+	}
+}
+
+func TestPublicationPublishUserError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests, `{"data":{"publishablePublish":{"userErrors":[{"field":["id"],"message":"Product does not exist"}]}}}`))
+
+	err := client.Publication.PublishProduct(9, "gid://shopify/Publication/1")
+	if err == nil || err.Error() != "id: Product does not exist" {
+		t.Errorf("Publication.PublishProduct returned %v, expected the user error", err)
+	}
+}
@@ -2,7 +2,6 @@ package goshopify
 
 import (
 	"fmt"
-	"net/http"
 )
 
 const redirectsBasePath = "redirects"
@@ -55,23 +54,7 @@ func (s *RedirectServiceOp) List(options interface{}) ([]Redirect, error) {
 // List redirects with pagination
 func (s *RedirectServiceOp) ListWithPagination(options interface{}) ([]Redirect, *Pagination, error) {
 	path := fmt.Sprintf("%s.json", redirectsBasePath)
-	resource := new(RedirectsResource)
-	headers := http.Header{}
-
-	headers, err := s.client.createAndDoGetHeaders("GET", path, nil, options, resource)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	// Extract pagination info from header
-	linkHeader := headers.Get("Link")
-
-	pagination, err := extractPagination(linkHeader)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	return resource.Redirects, pagination, nil
+	return listResourceWithPagination[Redirect](s.client, path, "redirects", options)
 }
 
 // Count redirects
@@ -83,27 +66,19 @@ func (s *RedirectServiceOp) Count(options interface{}) (int, error) {
 // Get individual redirect
 func (s *RedirectServiceOp) Get(redirectID int64, options interface{}) (*Redirect, error) {
 	path := fmt.Sprintf("%s/%d.json", redirectsBasePath, redirectID)
-	resource := new(RedirectResource)
-	err := s.client.Get(path, resource, options)
-	return resource.Redirect, err
+	return getResource[Redirect](s.client, path, "redirect", options)
 }
 
 // Create a new redirect
 func (s *RedirectServiceOp) Create(redirect Redirect) (*Redirect, error) {
 	path := fmt.Sprintf("%s.json", redirectsBasePath)
-	wrappedData := RedirectResource{Redirect: &redirect}
-	resource := new(RedirectResource)
-	err := s.client.Post(path, wrappedData, resource)
-	return resource.Redirect, err
+	return createResource(s.client, path, "redirect", redirect)
 }
 
 // Update an existing redirect
 func (s *RedirectServiceOp) Update(redirect Redirect) (*Redirect, error) {
 	path := fmt.Sprintf("%s/%d.json", redirectsBasePath, redirect.ID)
-	wrappedData := RedirectResource{Redirect: &redirect}
-	resource := new(RedirectResource)
-	err := s.client.Put(path, wrappedData, resource)
-	return resource.Redirect, err
+	return updateResource(s.client, path, "redirect", redirect)
 }
 
 // Delete an existing redirect.
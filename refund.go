@@ -0,0 +1,132 @@
+package goshopify
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// Restock types of refund line items.
+// See: https://shopify.dev/docs/admin-api/rest/reference/orders/refund
+const (
+	RestockTypeNoRestock = "no_restock"
+	RestockTypeCancel    = "cancel"
+	RestockTypeReturn    = "return"
+)
+
+// RefundShipping is the shipping part of a refund, set FullRefund to refund
+// all of the shipping costs.
+type RefundShipping struct {
+	FullRefund        bool             `json:"full_refund,omitempty"`
+	Amount            *decimal.Decimal `json:"amount,omitempty"`
+	Tax               *decimal.Decimal `json:"tax,omitempty"`
+	MaximumRefundable *decimal.Decimal `json:"maximum_refundable,omitempty"`
+}
+
+// RefundCalculation is what CalculateRefund calculates a refund for.
+type RefundCalculation struct {
+	Currency        string           `json:"currency,omitempty"`
+	Shipping        *RefundShipping  `json:"shipping,omitempty"`
+	RefundLineItems []RefundLineItem `json:"refund_line_items,omitempty"`
+}
+
+// RefundPreview summarizes the refund Shopify would make, so it can be
+// confirmed by the merchant before anything is changed.
+type RefundPreview struct {
+	// Refund is the calculated refund, its transactions are the suggested
+	// refund per payment gateway
+	Refund *Refund
+
+	// Total is the sum of the suggested transactions
+	Total    decimal.Decimal
+	Currency string
+
+	// Gateways holds the amount refunded per payment gateway
+	Gateways map[string]decimal.Decimal
+
+	// Restock are the line items going back into stock
+	Restock []RefundLineItem
+}
+
+// CalculateRefund asks Shopify to calculate the refund for the given line
+// items and shipping, without creating it.
+func (s *OrderServiceOp) CalculateRefund(orderID int64, calculation RefundCalculation) (*Refund, error) {
+	path := fmt.Sprintf("%s/%d/refunds/calculate.json", ordersBasePath, orderID)
+	wrappedData := map[string]interface{}{"refund": calculation}
+	return postResource[Refund](s.client, path, "refund", wrappedData)
+}
+
+// PreviewCancel calculates the refund cancelling the order would make: all
+// line items that were not refunded yet and the full shipping, restocking
+// the items when restock is set. Nothing is changed, call Cancel to go ahead.
+func (s *OrderServiceOp) PreviewCancel(orderID int64, restock bool) (*RefundPreview, error) {
+	order, err := s.Get(orderID, nil)
+	if err != nil {
+		return nil, err
+	}
+	if order == nil {
+		return nil, fmt.Errorf("order %d not found", orderID)
+	}
+
+	refunded := map[int64]int{}
+	for _, refund := range order.Refunds {
+		for _, item := range refund.RefundLineItems {
+			refunded[item.LineItemId] += item.Quantity
+		}
+	}
+
+	restockType := RestockTypeNoRestock
+	if restock {
+		restockType = RestockTypeCancel
+	}
+
+	calculation := RefundCalculation{Shipping: &RefundShipping{FullRefund: true}}
+	for _, item := range order.LineItems {
+		if quantity := item.Quantity - refunded[item.ID]; quantity > 0 {
+			calculation.RefundLineItems = append(calculation.RefundLineItems, RefundLineItem{
+				LineItemId:  item.ID,
+				Quantity:    quantity,
+				RestockType: restockType,
+			})
+		}
+	}
+
+	refund, err := s.CalculateRefund(orderID, calculation)
+	if err != nil {
+		return nil, err
+	}
+
+	return newRefundPreview(refund)
+}
+
+func newRefundPreview(refund *Refund) (*RefundPreview, error) {
+	if refund == nil {
+		return nil, errors.New("refund calculation returned no refund")
+	}
+
+	preview := &RefundPreview{
+		Refund:   refund,
+		Currency: refund.Currency,
+		Gateways: map[string]decimal.Decimal{},
+	}
+
+	for _, transaction := range refund.Transactions {
+		if transaction.Amount == nil {
+			continue
+		}
+		preview.Total = preview.Total.Add(*transaction.Amount)
+		preview.Gateways[transaction.Gateway] = preview.Gateways[transaction.Gateway].Add(*transaction.Amount)
+		if preview.Currency == "" {
+			preview.Currency = transaction.Currency
+		}
+	}
+
+	for _, item := range refund.RefundLineItems {
+		if item.RestockType != "" && item.RestockType != RestockTypeNoRestock {
+			preview.Restock = append(preview.Restock, item)
+		}
+	}
+
+	return preview, nil
+}
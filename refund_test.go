@@ -0,0 +1,150 @@
+package goshopify
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/shopspring/decimal"
+)
+
+func TestOrderCalculateRefund(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/123456/refunds/calculate.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("refund_calculate.json")))
+
+	refund, err := client.Order.CalculateRefund(123456, RefundCalculation{
+		Shipping:        &RefundShipping{FullRefund: true},
+		RefundLineItems: []RefundLineItem{{LineItemId: 5, Quantity: 1, RestockType: RestockTypeCancel}},
+	})
+	if err != nil {
+		t.Fatalf("Order.CalculateRefund returned error: %v", err)
+	}
+
+	if refund.Currency != "USD" {
+		t.Errorf("Refund.Currency returned %s, expected USD", refund.Currency)
+	}
+	if len(refund.Transactions) != 2 {
+		t.Fatalf("Refund.Transactions returned %d transactions, expected 2", len(refund.Transactions))
+	}
+	if !refund.Transactions[0].MaximumRefundable.Equal(decimal.NewFromInt(10)) {
+		t.Errorf("Transaction.MaximumRefundable returned %v, expected 10", refund.Transactions[0].MaximumRefundable)
+	}
+	if !refund.Shipping.Amount.Equal(decimal.NewFromInt(10)) {
+		t.Errorf("Refund.Shipping.Amount returned %v, expected 10", refund.Shipping.Amount)
+	}
+}
+
+func TestOrderPreviewCancel(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/123456.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"order":{"id":123456,"line_items":[{"id":254721536,"quantity":1},{"id":5,"quantity":2}],"refunds":[{"refund_line_items":[{"line_item_id":5,"quantity":1}]}]}}`))
+
+	var calculation map[string]RefundCalculation
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/123456/refunds/calculate.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := ioutil.ReadAll(req.Body)
+			if err := json.Unmarshal(body, &calculation); err != nil {
+				t.Fatalf("decoding calculation: %v", err)
+			}
+			return httpmock.NewBytesResponse(200, loadFixture("refund_calculate.json")), nil
+		})
+
+	preview, err := client.Order.PreviewCancel(123456, true)
+	if err != nil {
+		t.Fatalf("Order.PreviewCancel returned error: %v", err)
+	}
+
+	sent := calculation["refund"]
+	if sent.Shipping == nil || !sent.Shipping.FullRefund {
+		t.Errorf("PreviewCancel sent shipping %+v, expected a full refund", sent.Shipping)
+	}
+	expectedItems := []RefundLineItem{
+		{LineItemId: 254721536, Quantity: 1, RestockType: RestockTypeCancel},
+		{LineItemId: 5, Quantity: 1, RestockType: RestockTypeCancel},
+	}
+	if len(sent.RefundLineItems) != len(expectedItems) {
+		t.Fatalf("PreviewCancel sent %d line items, expected %d", len(sent.RefundLineItems), len(expectedItems))
+	}
+	for i, item := range sent.RefundLineItems {
+		expected := expectedItems[i]
+		if item.LineItemId != expected.LineItemId || item.Quantity != expected.Quantity || item.RestockType != expected.RestockType {
+			t.Errorf("PreviewCancel sent line item %+v, expected %+v", item, expected)
+		}
+	}
+
+	if !preview.Total.Equal(decimal.NewFromInt(15)) {
+		t.Errorf("RefundPreview.Total returned %v, expected 15", preview.Total)
+	}
+	if preview.Currency != "USD" {
+		t.Errorf("RefundPreview.Currency returned %s, expected USD", preview.Currency)
+	}
+	if g := preview.Gateways["visa"]; !g.Equal(decimal.NewFromInt(10)) {
+		t.Errorf("RefundPreview.Gateways[visa] returned %v, expected 10", g)
+	}
+	if g := preview.Gateways["bogus"]; !g.Equal(decimal.NewFromInt(5)) {
+		t.Errorf("RefundPreview.Gateways[bogus] returned %v, expected 5", g)
+	}
+	if len(preview.Restock) != 2 {
+		t.Errorf("RefundPreview.Restock returned %d items, expected 2", len(preview.Restock))
+	}
+}
+
+func TestOrderPreviewCancelWithoutRestock(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/123456.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"order":{"id":123456,"line_items":[{"id":5,"quantity":1}]}}`))
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/123456/refunds/calculate.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"refund":{"refund_line_items":[{"line_item_id":5,"quantity":1,"restock_type":"no_restock"}],"transactions":[{"amount":"5.00","gateway":"visa","currency":"EUR"}]}}`))
+
+	preview, err := client.Order.PreviewCancel(123456, false)
+	if err != nil {
+		t.Fatalf("Order.PreviewCancel returned error: %v", err)
+	}
+	if len(preview.Restock) != 0 {
+		t.Errorf("RefundPreview.Restock returned %d items, expected none", len(preview.Restock))
+	}
+	if preview.Currency != "EUR" {
+		t.Errorf("RefundPreview.Currency returned %s, expected EUR", preview.Currency)
+	}
+}
+
+func TestOrderPreviewCancelEmptyCalculation(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/123456.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"order":{"id":123456,"line_items":[{"id":254721536,"quantity":1}]}}`))
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/123456/refunds/calculate.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{}`))
+
+	preview, err := client.Order.PreviewCancel(123456, true)
+	if err == nil || preview != nil {
+		t.Errorf("Order.PreviewCancel returned %+v, %v, expected an error for an empty calculation", preview, err)
+	}
+}
+
+func TestOrderPreviewCancelEmptyOrder(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/123456.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{}`))
+
+	preview, err := client.Order.PreviewCancel(123456, true)
+	if err == nil || preview != nil {
+		t.Errorf("Order.PreviewCancel returned %+v, %v, expected an error for an empty order", preview, err)
+	}
+	if count := httpmock.GetTotalCallCount(); count != 1 {
+		t.Errorf("Order.PreviewCancel made %d requests, expected only the order lookup", count)
+	}
+}
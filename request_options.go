@@ -0,0 +1,96 @@
+package goshopify
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// RequestOption configures a single call, unlike an Option which configures
+// every call of a client. Request options are passed to CreateAndDoWithContext
+// or attached to the context of any call with WithRequestOptions.
+type RequestOption func(*requestConfig)
+
+type requestConfig struct {
+	headers http.Header
+	query   url.Values
+	timeout time.Duration
+}
+
+// RequestHeader sets a header of the request, e.g. ApiFeaturesHeader. The
+// credentials of the client can't be overridden.
+func RequestHeader(name, value string) RequestOption {
+	return func(rc *requestConfig) {
+		if rc.headers == nil {
+			rc.headers = http.Header{}
+		}
+		rc.headers.Set(name, value)
+	}
+}
+
+// RequestQuery adds a query parameter to the request, e.g. one the options
+// structs don't model.
+func RequestQuery(name, value string) RequestOption {
+	return func(rc *requestConfig) {
+		if rc.query == nil {
+			rc.query = url.Values{}
+		}
+		rc.query.Add(name, value)
+	}
+}
+
+// RequestTimeout bounds the request, including retries and waits for the
+// rate limit, by a deadline narrower than the one of the HTTP client or the
+// context.
+func RequestTimeout(timeout time.Duration) RequestOption {
+	return func(rc *requestConfig) {
+		rc.timeout = timeout
+	}
+}
+
+// requestOptionsKey is the context key of the request options attached with
+// WithRequestOptions.
+type requestOptionsKey struct{}
+
+// WithRequestOptions returns a context that applies the request options to
+// the calls made with it, in addition to those attached already.
+func WithRequestOptions(ctx context.Context, opts ...RequestOption) context.Context {
+	existing, _ := ctx.Value(requestOptionsKey{}).([]RequestOption)
+	all := append(append([]RequestOption(nil), existing...), opts...)
+	return context.WithValue(ctx, requestOptionsKey{}, all)
+}
+
+// newRequestConfig collects the request options of the context followed by
+// opts.
+func newRequestConfig(ctx context.Context, opts []RequestOption) requestConfig {
+	rc := requestConfig{}
+	existing, _ := ctx.Value(requestOptionsKey{}).([]RequestOption)
+	for _, opt := range existing {
+		opt(&rc)
+	}
+	for _, opt := range opts {
+		opt(&rc)
+	}
+	return rc
+}
+
+// apply sets the headers and query parameters of the request options.
+func (rc requestConfig) apply(req *http.Request) {
+	for name, values := range rc.headers {
+		if authHeaders[http.CanonicalHeaderKey(name)] {
+			continue
+		}
+		req.Header[http.CanonicalHeaderKey(name)] = values
+	}
+
+	if len(rc.query) > 0 {
+		query := req.URL.Query()
+		for name, values := range rc.query {
+			for _, value := range values {
+				query.Add(name, value)
+			}
+		}
+		req.URL.RawQuery = query.Encode()
+	}
+}
@@ -0,0 +1,83 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestRequestOptions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var req *http.Request
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products.json", client.pathPrefix),
+		func(r *http.Request) (*http.Response, error) {
+			req = r
+			return httpmock.NewStringResponse(200, `{"products": []}`), nil
+		})
+
+	ctx := WithRequestOptions(context.Background(), RequestHeader(ApiFeaturesHeader, "include-presentment-prices"))
+	err := client.CreateAndDoWithContext(ctx, "GET", "products.json", nil, ListOptions{Limit: 5}, nil,
+		RequestQuery("presentment_currencies", "USD"),
+		RequestHeader("X-Shopify-Access-Token", "stolen"),
+	)
+	if err != nil {
+		t.Fatalf("CreateAndDoWithContext returned error: %v", err)
+	}
+
+	if got := req.Header.Get(ApiFeaturesHeader); got != "include-presentment-prices" {
+		t.Errorf("request sent %s %q, expected include-presentment-prices", ApiFeaturesHeader, got)
+	}
+	if got := req.Header.Get("X-Shopify-Access-Token"); got != "abcd" {
+		t.Errorf("request sent access token %q, expected the client's", got)
+	}
+	query := req.URL.Query()
+	if query.Get("presentment_currencies") != "USD" || query.Get("limit") != "5" {
+		t.Errorf("request sent query %v, expected presentment_currencies and limit", query)
+	}
+}
+
+func TestRequestOptionsOnlyApplyToTheirCall(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var headers []string
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shop.json", client.pathPrefix),
+		func(r *http.Request) (*http.Response, error) {
+			headers = append(headers, r.Header.Get("X-Custom"))
+			return httpmock.NewStringResponse(200, `{"shop": {}}`), nil
+		})
+
+	if err := client.CreateAndDoWithContext(context.Background(), "GET", "shop.json", nil, nil, nil, RequestHeader("X-Custom", "1")); err != nil {
+		t.Fatalf("CreateAndDoWithContext returned error: %v", err)
+	}
+	if _, err := client.Shop.Get(nil); err != nil {
+		t.Fatalf("Shop.Get returned error: %v", err)
+	}
+
+	if len(headers) != 2 || headers[0] != "1" || headers[1] != "" {
+		t.Errorf("requests sent X-Custom %q, expected it on the first only", headers)
+	}
+}
+
+func TestRequestTimeout(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shop.json", client.pathPrefix),
+		func(r *http.Request) (*http.Response, error) {
+			<-r.Context().Done()
+			return nil, r.Context().Err()
+		})
+
+	err := client.CreateAndDoWithContext(context.Background(), "GET", "shop.json", nil, nil, nil, RequestTimeout(10*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("CreateAndDoWithContext returned %v, expected context.DeadlineExceeded", err)
+	}
+}
@@ -0,0 +1,238 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// The functions below implement the CRUD plumbing shared by the REST
+// services. Shopify wraps resources in an object keyed by the resource name,
+// e.g. {"product": {...}} or {"products": [...]}, so every helper takes the key
+// the payload is wrapped in alongside the relative path.
+
+// wrapper is a response wrapping a resource in an object keyed by the
+// resource name. Its type is a struct generated for the key, e.g.
+// struct{ Resource *Product `json:"product"` }, so responses are decoded like
+// into a declared wrapper struct: other top-level keys are ignored, rejected
+// by strict decoding and reported to the unknown field handler.
+type wrapper[T any] struct {
+	v reflect.Value
+}
+
+// newWrapper returns an empty wrapper of a T keyed by key.
+func newWrapper[T any](key string) wrapper[T] {
+	t := reflect.StructOf([]reflect.StructField{{
+		Name: "Resource",
+		Type: reflect.TypeOf((*T)(nil)).Elem(),
+		Tag:  reflect.StructTag(fmt.Sprintf(`json:%q`, key)),
+	}})
+	return wrapper[T]{v: reflect.New(t)}
+}
+
+// target returns the pointer to decode the response into.
+func (w wrapper[T]) target() interface{} {
+	return w.v.Interface()
+}
+
+// value returns the decoded resource.
+func (w wrapper[T]) value() T {
+	return w.v.Elem().Field(0).Interface().(T)
+}
+
+// listResource fetches the resources at path, e.g. products.json.
+func listResource[T any](c *Client, path, key string, options interface{}) ([]T, error) {
+	resource := newWrapper[[]T](key)
+	err := c.Get(path, resource.target(), options)
+	return resource.value(), err
+}
+
+// listResourceWithPagination fetches a single page of the resources at path
+// along with the options to fetch the next and previous pages.
+func listResourceWithPagination[T any](c *Client, path, key string, options interface{}) ([]T, *Pagination, error) {
+	return listResourceWithPaginationContext[T](context.Background(), c, path, key, options)
+}
+
+// listResourceWithPaginationContext is listResourceWithPagination bound to a
+// context.
+func listResourceWithPaginationContext[T any](ctx context.Context, c *Client, path, key string, options interface{}) ([]T, *Pagination, error) {
+	resource := newWrapper[[]T](key)
+
+	headers, err := c.createAndDoGetHeaders(ctx, "GET", path, nil, options, resource.target())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Extract pagination info from header
+	linkHeader := headers.Get("Link")
+
+	pagination, err := extractPagination(linkHeader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resource.value(), pagination, nil
+}
+
+// getResource fetches a single resource.
+func getResource[T any](c *Client, path, key string, options interface{}) (*T, error) {
+	resource := newWrapper[*T](key)
+	err := c.Get(path, resource.target(), options)
+	return resource.value(), err
+}
+
+// createResource posts data wrapped in key to path and returns the created
+// resource.
+func createResource[T any](c *Client, path, key string, data T) (*T, error) {
+	return postResource[T](c, path, key, map[string]*T{key: &data})
+}
+
+// updateResource puts data wrapped in key to path and returns the updated
+// resource.
+func updateResource[T any](c *Client, path, key string, data T) (*T, error) {
+	resource := newWrapper[*T](key)
+	err := c.Put(path, map[string]*T{key: &data}, resource.target())
+	return resource.value(), err
+}
+
+// updateFieldsResource puts the fields of update for the resource id wrapped
+// in key to path and returns the updated resource. The fields are checked
+// against the JSON fields of T.
+func updateFieldsResource[T any](c *Client, path, key string, id int64, update *Update) (*T, error) {
+	fields, err := update.forResource(reflect.TypeOf((*T)(nil)).Elem(), id)
+	if err != nil {
+		return nil, err
+	}
+	resource := newWrapper[*T](key)
+	err = c.Put(path, map[string]interface{}{key: fields}, resource.target())
+	return resource.value(), err
+}
+
+// postResource posts an arbitrary payload to path and returns the resource
+// wrapped in key, used for actions such as orders/X/close.json.
+func postResource[T any](c *Client, path, key string, data interface{}) (*T, error) {
+	resource := newWrapper[*T](key)
+	err := c.Post(path, data, resource.target())
+	return resource.value(), err
+}
+
+// handleOptions filters a listing by handle.
+type handleOptions struct {
+	Handle string `url:"handle"`
+}
+
+// getResourceByHandle lists the resources at path filtered by handle and
+// returns the one whose handle matches exactly, or a NotFoundError.
+func getResourceByHandle[T any](c *Client, path, key, handle string, handleOf func(T) string) (*T, error) {
+	resources, err := listAllPages(func(options interface{}) ([]T, *Pagination, error) {
+		return listResourceWithPagination[T](c, path, key, options)
+	}, handleOptions{Handle: handle})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range resources {
+		if handleOf(resources[i]) == handle {
+			return &resources[i], nil
+		}
+	}
+	return nil, NotFoundError{Resource: key, Handle: handle}
+}
+
+// listAllPages calls a ListWithPagination method until every page has been
+// fetched, following the next page options from the Link header.
+func listAllPages[T any](list func(interface{}) ([]T, *Pagination, error), options interface{}) ([]T, error) {
+	var all []T
+	for {
+		page, pagination, err := list(options)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+		if pagination == nil || pagination.NextPageOptions == nil {
+			return all, nil
+		}
+		options = pagination.NextPageOptions
+	}
+}
+
+const (
+	// maxIDsPerRequest is the most IDs the ids filter of a listing takes
+	maxIDsPerRequest = 250
+
+	// listByIDsConcurrency is the number of chunks of IDs listed at the same
+	// time, the client's rate limiter and call limit still apply
+	listByIDsConcurrency = 4
+)
+
+// idsOptions filters a listing by IDs, Status is for orders which are only
+// listed when open otherwise.
+type idsOptions struct {
+	ListOptions
+	Status string `url:"status,omitempty"`
+}
+
+// listResourceByIDs lists the resources at path with the IDs, split into
+// chunks of at most maxIDsPerRequest IDs that are listed concurrently. The
+// results are in the order of the chunks, the first error is returned.
+func listResourceByIDs[T any](ctx context.Context, c *Client, path, key string, ids []int64, status string) ([]T, error) {
+	chunks := chunkIDs(ids, maxIDsPerRequest)
+	results := make([][]T, len(chunks))
+	errs := make([]error, len(chunks))
+
+	list := func(options interface{}) ([]T, *Pagination, error) {
+		if err := waitForRateLimit(ctx, c); err != nil {
+			return nil, nil, err
+		}
+		return listResourceWithPaginationContext[T](ctx, c, path, key, options)
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, listByIDsConcurrency)
+	for i, chunk := range chunks {
+		wg.Add(1)
+		go func(i int, chunk []int64) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			options := idsOptions{ListOptions: ListOptions{IDs: chunk, Limit: maxIDsPerRequest}, Status: status}
+			results[i], errs[i] = listAllPages(list, options)
+		}(i, chunk)
+	}
+	wg.Wait()
+
+	var all []T
+	for i := range chunks {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		all = append(all, results[i]...)
+	}
+	return all, nil
+}
+
+// chunkIDs splits the IDs, without duplicates, into chunks of at most size
+// IDs.
+func chunkIDs(ids []int64, size int) [][]int64 {
+	seen := make(map[int64]bool, len(ids))
+	var chunks [][]int64
+	var chunk []int64
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		chunk = append(chunk, id)
+		if len(chunk) == size {
+			chunks = append(chunks, chunk)
+			chunk = nil
+		}
+	}
+	if len(chunk) > 0 {
+		chunks = append(chunks, chunk)
+	}
+	return chunks
+}
@@ -0,0 +1,183 @@
+package goshopify
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+type widget struct {
+	ID   int64  `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+func TestListResource(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/widgets.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"widgets": [{"id":1},{"id":2}]}`))
+
+	widgets, err := listResource[widget](client, "widgets.json", "widgets", nil)
+	if err != nil {
+		t.Errorf("listResource returned error: %v", err)
+	}
+
+	expected := []widget{{ID: 1}, {ID: 2}}
+	if !reflect.DeepEqual(widgets, expected) {
+		t.Errorf("listResource returned %+v, expected %+v", widgets, expected)
+	}
+}
+
+func TestGetResourceIgnoresOtherKeys(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/widgets/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"widget": {"id":1}, "errors": {"base": ["warning"]}, "gadgets": [1, 2]}`))
+
+	widget, err := getResource[widget](client, "widgets/1.json", "widget", nil)
+	if err != nil {
+		t.Fatalf("getResource returned error: %v", err)
+	}
+	if widget == nil || widget.ID != 1 {
+		t.Errorf("getResource returned %+v, expected widget 1", widget)
+	}
+}
+
+func TestResourceUnknownTopLevelKeys(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/widgets.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"widgets": [{"id":1,"color":"red"}], "extra": true}`))
+
+	var unknown []string
+	WithUnknownFieldHandler(func(fields UnknownFields) { unknown = fields.Fields })(client)
+	if _, err := listResource[widget](client, "widgets.json", "widgets", nil); err != nil {
+		t.Fatalf("listResource returned error: %v", err)
+	}
+	expected := []string{"extra", "widgets[0].color"}
+	if !reflect.DeepEqual(unknown, expected) {
+		t.Errorf("listResource reported unknown fields %v, expected %v", unknown, expected)
+	}
+
+	WithStrictDecoding()(client)
+	_, err := listResource[widget](client, "widgets.json", "widgets", nil)
+	if err == nil {
+		t.Errorf("listResource with strict decoding expected an error for the unknown keys")
+	}
+}
+
+func TestListResourceWithPagination(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/widgets.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(200, `{"widgets": [{"id":1}]}`)
+			resp.Header.Set("Link", `<http://valid.url?page_info=foo&limit=1>; rel="next"`)
+			return resp, nil
+		})
+
+	widgets, pagination, err := listResourceWithPagination[widget](client, "widgets.json", "widgets", nil)
+	if err != nil {
+		t.Errorf("listResourceWithPagination returned error: %v", err)
+	}
+
+	expected := []widget{{ID: 1}}
+	if !reflect.DeepEqual(widgets, expected) {
+		t.Errorf("listResourceWithPagination returned %+v, expected %+v", widgets, expected)
+	}
+
+	expectedPagination := &Pagination{
+		NextPageOptions: &ListOptions{PageInfo: "foo", Limit: 1},
+		NextPageURL:     "http://valid.url?page_info=foo&limit=1",
+	}
+	if !reflect.DeepEqual(pagination, expectedPagination) {
+		t.Errorf("listResourceWithPagination pagination returned %+v, expected %+v", pagination, expectedPagination)
+	}
+}
+
+func TestGetResource(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/widgets/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"widget": {"id":1,"name":"foo"}}`))
+
+	w, err := getResource[widget](client, "widgets/1.json", "widget", nil)
+	if err != nil {
+		t.Errorf("getResource returned error: %v", err)
+	}
+
+	expected := &widget{ID: 1, Name: "foo"}
+	if !reflect.DeepEqual(w, expected) {
+		t.Errorf("getResource returned %+v, expected %+v", w, expected)
+	}
+}
+
+func TestGetResourceError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/widgets/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(404, `{"errors": "Not Found"}`))
+
+	w, err := getResource[widget](client, "widgets/1.json", "widget", nil)
+	if w != nil {
+		t.Errorf("getResource returned %+v, expected nil", w)
+	}
+
+	if err == nil || err.Error() != "Not Found" {
+		t.Errorf("getResource err returned %+v, expected Not Found", err)
+	}
+}
+
+func TestCreateAndUpdateResource(t *testing.T) {
+	setup()
+	defer teardown()
+
+	echo := func(req *http.Request) (*http.Response, error) {
+		body, _ := ioutil.ReadAll(req.Body)
+		return httpmock.NewBytesResponse(200, body), nil
+	}
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/widgets.json", client.pathPrefix), echo)
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/widgets/1.json", client.pathPrefix), echo)
+
+	created, err := createResource(client, "widgets.json", "widget", widget{Name: "foo"})
+	if err != nil {
+		t.Errorf("createResource returned error: %v", err)
+	}
+
+	expected := &widget{Name: "foo"}
+	if !reflect.DeepEqual(created, expected) {
+		t.Errorf("createResource returned %+v, expected %+v", created, expected)
+	}
+
+	updated, err := updateResource(client, "widgets/1.json", "widget", widget{ID: 1, Name: "bar"})
+	if err != nil {
+		t.Errorf("updateResource returned error: %v", err)
+	}
+
+	expected = &widget{ID: 1, Name: "bar"}
+	if !reflect.DeepEqual(updated, expected) {
+		t.Errorf("updateResource returned %+v, expected %+v", updated, expected)
+	}
+}
+
+func TestChunkIDs(t *testing.T) {
+	chunks := chunkIDs([]int64{1, 2, 2, 3, 4, 5, 1}, 2)
+	expected := [][]int64{{1, 2}, {3, 4}, {5}}
+	if !reflect.DeepEqual(chunks, expected) {
+		t.Errorf("chunkIDs returned %v, expected %v", chunks, expected)
+	}
+
+	if chunks := chunkIDs(nil, 2); chunks != nil {
+		t.Errorf("chunkIDs of no IDs returned %v, expected nil", chunks)
+	}
+}
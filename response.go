@@ -0,0 +1,131 @@
+package goshopify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// RequestIDHeader is the header Shopify identifies every request with.
+const RequestIDHeader = "X-Request-Id"
+
+// Response wraps the http.Response of a call. Its body has already been read
+// and closed.
+type Response struct {
+	*http.Response
+
+	// RequestID is the X-Request-Id Shopify assigned to the request, quote
+	// it in support tickets about the call
+	RequestID string
+
+	// RateLimits is the REST call limit the response reported
+	RateLimits RateLimitInfo
+
+	// Pagination holds the pages the Link header points to, nil when the
+	// response links no pages
+	Pagination *Pagination
+
+	// Raw is the body of the response as is, only kept when the client was
+	// created with WithRawResponses
+	Raw json.RawMessage
+}
+
+func newResponse(r *http.Response) *Response {
+	resp := &Response{Response: r, RequestID: r.Header.Get(RequestIDHeader)}
+
+	if s := strings.Split(r.Header.Get("X-Shopify-Shop-Api-Call-Limit"), "/"); len(s) == 2 {
+		resp.RateLimits.RequestCount, _ = strconv.Atoi(s[0])
+		resp.RateLimits.BucketSize, _ = strconv.Atoi(s[1])
+	}
+	resp.RateLimits.RetryAfterSeconds, _ = strconv.ParseFloat(r.Header.Get("Retry-After"), 64)
+
+	if link := r.Header.Get("Link"); link != "" {
+		// a malformed header fails the listing itself, not the response
+		resp.Pagination, _ = extractPagination(link)
+	}
+	return resp
+}
+
+// responseKey is the context key of the response a call is captured into,
+// see CaptureResponse.
+type responseKey struct{}
+
+// CaptureResponse returns a context that stores the response to the call
+// made with it in resp, e.g. with CreateAndDoWithContext or Webhook.Ensure. The
+// last response is kept when the call retried or sent several requests.
+// Unlike LastResponse it is the response to this call even when the client is
+// shared by goroutines.
+func CaptureResponse(ctx context.Context, resp **Response) context.Context {
+	return context.WithValue(ctx, responseKey{}, resp)
+}
+
+// captureResponse stores the response in the capture of its request's
+// context, if there is one.
+func captureResponse(resp *Response) {
+	if resp.Request == nil {
+		return
+	}
+	if target, ok := resp.Request.Context().Value(responseKey{}).(**Response); ok {
+		*target = resp
+	}
+}
+
+// CreateAndDoWithResponse is CreateAndDoWithContext returning the response to
+// the call along with its status, headers, request ID, rate limits and
+// pagination. The response is also returned for failed calls that got one.
+func (c *Client) CreateAndDoWithResponse(ctx context.Context, method, relPath string, data, options, resource interface{}, opts ...RequestOption) (*Response, error) {
+	var resp *Response
+	err := c.CreateAndDoWithContext(CaptureResponse(ctx, &resp), method, relPath, data, options, resource, opts...)
+	return resp, err
+}
+
+// recordResponse keeps a response for LastResponse and its X-Shopify-*
+// headers for ShopifyHeaders.
+func (c *Client) recordResponse(resp *http.Response) {
+	headers := http.Header{}
+	for name, values := range resp.Header {
+		if strings.HasPrefix(name, shopifyHeaderPrefix) {
+			headers[name] = append([]string(nil), values...)
+		}
+	}
+
+	r := newResponse(resp)
+	captureResponse(r)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lastResponse = r
+	c.shopifyHeaders = headers
+}
+
+// recordRaw keeps the body of the last response when raw responses are
+// retained.
+func (c *Client) recordRaw(resp *http.Response, body []byte) {
+	if !c.rawResponses {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.lastResponse != nil && c.lastResponse.Response == resp {
+		// copy it, the previous one may have been handed out already
+		r := *c.lastResponse
+		r.Raw = json.RawMessage(bytes.TrimSpace(body))
+		c.lastResponse = &r
+		captureResponse(&r)
+	}
+}
+
+// LastResponse returns the response to the last request the client sent,
+// including failed ones, nil before the first response. When the client is
+// shared by goroutines this may be the response to another goroutine's call,
+// errors returned for failed calls carry their own request ID, see
+// ResponseError.
+func (c *Client) LastResponse() *Response {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lastResponse
+}
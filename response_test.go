@@ -0,0 +1,132 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestLastResponse(t *testing.T) {
+	setup()
+	defer teardown()
+
+	if resp := client.LastResponse(); resp != nil {
+		t.Errorf("LastResponse returned %+v before any request, expected nil", resp)
+	}
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shop.json", client.pathPrefix),
+		createResponderWithHeaders(200, `{"shop":{"id":1}}`, map[string]string{RequestIDHeader: "abc-123"}))
+
+	if _, err := client.Shop.Get(nil); err != nil {
+		t.Fatalf("Shop.Get returned error: %v", err)
+	}
+
+	resp := client.LastResponse()
+	if resp == nil {
+		t.Fatal("LastResponse returned nil")
+	}
+	if resp.StatusCode != 200 {
+		t.Errorf("Response.StatusCode returned %d, expected 200", resp.StatusCode)
+	}
+	if resp.RequestID != "abc-123" {
+		t.Errorf("Response.RequestID returned %q, expected %q", resp.RequestID, "abc-123")
+	}
+}
+
+func TestResponseErrorRequestID(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shop.json", client.pathPrefix),
+		createResponderWithHeaders(422, `{"errors":"invalid"}`, map[string]string{RequestIDHeader: "def-456"}))
+
+	_, err := client.Shop.Get(nil)
+
+	var responseError ResponseError
+	if !errors.As(err, &responseError) {
+		t.Fatalf("Shop.Get returned %#v, expected a ResponseError", err)
+	}
+	if responseError.RequestID != "def-456" {
+		t.Errorf("ResponseError.RequestID returned %q, expected %q", responseError.RequestID, "def-456")
+	}
+	if resp := client.LastResponse(); resp == nil || resp.StatusCode != 422 || resp.RequestID != "def-456" {
+		t.Errorf("LastResponse returned %+v, expected the failed response", resp)
+	}
+}
+
+func TestLastResponseRaw(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shop.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"shop":{"id":1,"not_modelled":true}}`+"\n"))
+
+	if _, err := client.Shop.Get(nil); err != nil {
+		t.Fatalf("Shop.Get returned error: %v", err)
+	}
+	if raw := client.LastResponse().Raw; raw != nil {
+		t.Errorf("Response.Raw returned %s without WithRawResponses, expected nil", raw)
+	}
+
+	WithRawResponses()(client)
+	if _, err := client.Shop.Get(nil); err != nil {
+		t.Fatalf("Shop.Get returned error: %v", err)
+	}
+
+	expected := `{"shop":{"id":1,"not_modelled":true}}`
+	if raw := string(client.LastResponse().Raw); raw != expected {
+		t.Errorf("Response.Raw returned %s, expected %s", raw, expected)
+	}
+}
+
+func TestCreateAndDoWithResponse(t *testing.T) {
+	setup()
+	defer teardown()
+
+	listURL := fmt.Sprintf("https://fooshop.myshopify.com/%s/products.json", client.pathPrefix)
+	httpmock.RegisterResponder("GET", listURL,
+		createResponderWithHeaders(200, `{"products":[{"id":1}]}`, map[string]string{
+			RequestIDHeader:                 "abc-123",
+			"X-Shopify-Shop-Api-Call-Limit": "3/40",
+			"Link":                          fmt.Sprintf(`<%s?page_info=foo&limit=1>; rel="next"`, listURL),
+		}))
+
+	var products ProductsResource
+	resp, err := client.CreateAndDoWithResponse(context.Background(), "GET", "products.json", nil, nil, &products)
+	if err != nil {
+		t.Fatalf("CreateAndDoWithResponse returned error: %v", err)
+	}
+
+	if resp.StatusCode != 200 || resp.RequestID != "abc-123" {
+		t.Errorf("CreateAndDoWithResponse returned status %d and request ID %q", resp.StatusCode, resp.RequestID)
+	}
+	if resp.RateLimits.RequestCount != 3 || resp.RateLimits.BucketSize != 40 {
+		t.Errorf("CreateAndDoWithResponse returned rate limits %+v, expected 3/40", resp.RateLimits)
+	}
+	if resp.Pagination == nil || resp.Pagination.NextPageOptions.PageInfo != "foo" {
+		t.Errorf("CreateAndDoWithResponse returned pagination %+v, expected the next page foo", resp.Pagination)
+	}
+	if len(products.Products) != 1 {
+		t.Errorf("CreateAndDoWithResponse decoded %+v", products)
+	}
+}
+
+func TestCaptureResponseError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shop.json", client.pathPrefix),
+		createResponderWithHeaders(404, `{"errors":"Not Found"}`, map[string]string{RequestIDHeader: "def-456"}))
+
+	var resp *Response
+	err := client.CreateAndDoWithContext(CaptureResponse(context.Background(), &resp), "GET", "shop.json", nil, nil, nil)
+	if err == nil {
+		t.Fatal("CreateAndDoWithContext returned no error for a 404")
+	}
+	if resp == nil || resp.StatusCode != 404 || resp.RequestID != "def-456" || resp.Pagination != nil {
+		t.Errorf("CaptureResponse captured %+v, expected the 404 response", resp)
+	}
+}
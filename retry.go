@@ -0,0 +1,128 @@
+package goshopify
+
+import (
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy configures how transient failures, i.e. 5xx responses and
+// temporary network errors, are retried with exponential backoff. It is
+// independent of the rate limit handling configured with WithRetry.
+type RetryPolicy struct {
+	// MaxRetries is the number of times a request is retried after the
+	// first attempt failed.
+	MaxRetries int
+
+	// BaseDelay is the wait before the first retry, every following retry
+	// waits Multiplier times longer up to MaxDelay.
+	BaseDelay  time.Duration
+	MaxDelay   time.Duration
+	Multiplier float64
+
+	// Jitter is the fraction, between 0 and 1, by which each delay is
+	// randomly varied to avoid many clients retrying in lockstep.
+	Jitter float64
+
+	// RetryableStatusCodes are the response status codes that are retried.
+	RetryableStatusCodes []int
+
+	// RetryPost also retries POST requests. They are not retried by
+	// default since a request that failed with a timeout or a server error
+	// may still have been processed, and retrying would e.g. create an
+	// order or a refund twice. GraphQL requests are POST requests too.
+	RetryPost bool
+}
+
+// DefaultRetryPolicy returns a policy retrying 500, 502, 503 and 504 responses
+// and temporary network errors of idempotent requests up to 3 times, starting
+// at half a second.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries: 3,
+		BaseDelay:  500 * time.Millisecond,
+		MaxDelay:   10 * time.Second,
+		Multiplier: 2,
+		Jitter:     0.2,
+		RetryableStatusCodes: []int{
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// backoff returns the delay before the given retry, starting at 0.
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	delay := float64(p.BaseDelay) * math.Pow(multiplier, float64(retry))
+	if p.MaxDelay > 0 && delay > float64(p.MaxDelay) {
+		delay = float64(p.MaxDelay)
+	}
+
+	if p.Jitter > 0 {
+		delay *= 1 - p.Jitter + rand.Float64()*2*p.Jitter
+	}
+
+	return time.Duration(delay)
+}
+
+// retryableMethod reports whether requests with the method are retried, GET,
+// HEAD, PUT and DELETE requests are idempotent and POST requests are only
+// retried with RetryPost.
+func (p RetryPolicy) retryableMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	case http.MethodPost:
+		return p.RetryPost
+	}
+	return false
+}
+
+// retryableMethod reports whether the client retries requests with the
+// method, POST requests only when its retry policy sets RetryPost.
+func (c *Client) retryableMethod(method string) bool {
+	if c.retryPolicy == nil {
+		return RetryPolicy{}.retryableMethod(method)
+	}
+	return c.retryPolicy.retryableMethod(method)
+}
+
+// retryableStatus reports whether responses with the status code are retried.
+func (p RetryPolicy) retryableStatus(status int) bool {
+	for _, code := range p.RetryableStatusCodes {
+		if code == status {
+			return true
+		}
+	}
+	return false
+}
+
+// isTemporaryNetworkError reports whether err is a network failure that is
+// likely to succeed when retried, e.g. a timeout or a reset connection.
+func isTemporaryNetworkError(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTimeout || dnsErr.IsTemporary
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
@@ -0,0 +1,266 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+// timeoutError is a net.Error as returned by dials and reads timing out
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func testRetryPolicy(maxRetries int) RetryPolicy {
+	policy := DefaultRetryPolicy()
+	policy.MaxRetries = maxRetries
+	policy.BaseDelay = time.Millisecond
+	policy.MaxDelay = 5 * time.Millisecond
+	return policy
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{
+		BaseDelay:  100 * time.Millisecond,
+		MaxDelay:   time.Second,
+		Multiplier: 2,
+	}
+
+	cases := []struct {
+		retry    int
+		expected time.Duration
+	}{
+		{0, 100 * time.Millisecond},
+		{1, 200 * time.Millisecond},
+		{2, 400 * time.Millisecond},
+		{3, 800 * time.Millisecond},
+		{4, time.Second},
+		{10, time.Second},
+	}
+
+	for _, c := range cases {
+		if actual := policy.backoff(c.retry); actual != c.expected {
+			t.Errorf("backoff(%d) = %s, expected %s", c.retry, actual, c.expected)
+		}
+	}
+}
+
+func TestRetryPolicyBackoffJitter(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, Multiplier: 2, Jitter: 0.5}
+
+	for i := 0; i < 100; i++ {
+		actual := policy.backoff(1)
+		if actual < 100*time.Millisecond || actual > 300*time.Millisecond {
+			t.Fatalf("backoff(1) = %s, expected between 100ms and 300ms", actual)
+		}
+	}
+}
+
+func TestIsTemporaryNetworkError(t *testing.T) {
+	cases := []struct {
+		err      error
+		expected bool
+	}{
+		{timeoutError{}, true},
+		{&net.OpError{Op: "read", Err: syscall.ECONNRESET}, true},
+		{&net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}, true},
+		{io.ErrUnexpectedEOF, true},
+		{&net.DNSError{Err: "no such host", IsNotFound: true}, false},
+		{&net.DNSError{Err: "server misbehaving", IsTemporary: true}, true},
+		{fmt.Errorf("boom"), false},
+		{context.Canceled, false},
+	}
+
+	for _, c := range cases {
+		if actual := isTemporaryNetworkError(c.err); actual != c.expected {
+			t.Errorf("isTemporaryNetworkError(%v) = %v, expected %v", c.err, actual, c.expected)
+		}
+	}
+}
+
+func TestRetryPolicyStatus(t *testing.T) {
+	setup()
+	defer teardown()
+	WithRetryPolicy(testRetryPolicy(3))(client)
+
+	calls := 0
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/foo/1", func(req *http.Request) (*http.Response, error) {
+		calls++
+		if calls < 3 {
+			return httpmock.NewStringResponse(http.StatusBadGateway, `{"errors":"bad gateway"}`), nil
+		}
+		return httpmock.NewStringResponse(http.StatusOK, `{}`), nil
+	})
+
+	req, _ := client.NewRequest("GET", "foo/1", nil, nil)
+	if err := client.Do(req, nil); err != nil {
+		t.Errorf("Do(): errored %s", err)
+	}
+	if calls != 3 {
+		t.Errorf("Do(): made %d calls, expected 3", calls)
+	}
+}
+
+func TestRetryPolicyStatusExhausted(t *testing.T) {
+	setup()
+	defer teardown()
+	WithRetryPolicy(testRetryPolicy(2))(client)
+
+	calls := 0
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/foo/1", func(req *http.Request) (*http.Response, error) {
+		calls++
+		return httpmock.NewStringResponse(http.StatusServiceUnavailable, `{"errors":"unavailable"}`), nil
+	})
+
+	req, _ := client.NewRequest("GET", "foo/1", nil, nil)
+	err := client.Do(req, nil)
+	if respErr, ok := err.(ResponseError); !ok || respErr.Status != http.StatusServiceUnavailable {
+		t.Errorf("Do(): returned %#v, expected a 503 ResponseError", err)
+	}
+	if calls != 3 {
+		t.Errorf("Do(): made %d calls, expected 3", calls)
+	}
+}
+
+func TestRetryPolicyIgnoresOtherStatus(t *testing.T) {
+	setup()
+	defer teardown()
+	WithRetryPolicy(testRetryPolicy(3))(client)
+
+	calls := 0
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/foo/1", func(req *http.Request) (*http.Response, error) {
+		calls++
+		return httpmock.NewStringResponse(http.StatusNotFound, `{"errors":"Not Found"}`), nil
+	})
+
+	req, _ := client.NewRequest("GET", "foo/1", nil, nil)
+	if err := client.Do(req, nil); err == nil {
+		t.Errorf("Do(): expected an error")
+	}
+	if calls != 1 {
+		t.Errorf("Do(): made %d calls, expected 1", calls)
+	}
+}
+
+func TestRetryPolicyNetworkError(t *testing.T) {
+	setup()
+	defer teardown()
+	WithRetryPolicy(testRetryPolicy(3))(client)
+
+	calls := 0
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/foo/1", func(req *http.Request) (*http.Response, error) {
+		calls++
+		if calls == 1 {
+			return nil, timeoutError{}
+		}
+		return httpmock.NewStringResponse(http.StatusOK, `{}`), nil
+	})
+
+	req, _ := client.NewRequest("GET", "foo/1", nil, nil)
+	if err := client.Do(req, nil); err != nil {
+		t.Errorf("Do(): errored %s", err)
+	}
+	if calls != 2 {
+		t.Errorf("Do(): made %d calls, expected 2", calls)
+	}
+}
+
+func TestRetryPolicyNetworkErrorNotTemporary(t *testing.T) {
+	setup()
+	defer teardown()
+	WithRetryPolicy(testRetryPolicy(3))(client)
+
+	calls := 0
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/foo/1", func(req *http.Request) (*http.Response, error) {
+		calls++
+		return nil, fmt.Errorf("certificate signed by unknown authority")
+	})
+
+	req, _ := client.NewRequest("GET", "foo/1", nil, nil)
+	if err := client.Do(req, nil); err == nil {
+		t.Errorf("Do(): expected an error")
+	}
+	if calls != 1 {
+		t.Errorf("Do(): made %d calls, expected 1", calls)
+	}
+}
+
+func TestRetryPolicyPost(t *testing.T) {
+	cases := []struct {
+		retryPost bool
+		expected  int
+	}{
+		{false, 1},
+		{true, 3},
+	}
+
+	for _, c := range cases {
+		setup()
+		policy := testRetryPolicy(2)
+		policy.RetryPost = c.retryPost
+		WithRetryPolicy(policy)(client)
+
+		calls := 0
+		httpmock.RegisterResponder("POST", "https://fooshop.myshopify.com/foo", func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls == 1 {
+				return nil, timeoutError{}
+			}
+			return httpmock.NewStringResponse(http.StatusBadGateway, `{"errors":"bad gateway"}`), nil
+		})
+
+		req, _ := client.NewRequest("POST", "foo", map[string]string{"foo": "bar"}, nil)
+		if err := client.Do(req, nil); err == nil {
+			t.Errorf("Do(): expected an error")
+		}
+		if calls != c.expected {
+			t.Errorf("Do() with RetryPost %v: made %d calls, expected %d", c.retryPost, calls, c.expected)
+		}
+		teardown()
+	}
+}
+
+func TestRetryPostServiceUnavailable(t *testing.T) {
+	setup()
+	defer teardown()
+
+	// WithRetry alone retries 503 responses, but not those to a POST
+	calls := 0
+	httpmock.RegisterResponder("POST", "https://fooshop.myshopify.com/foo", func(req *http.Request) (*http.Response, error) {
+		calls++
+		return httpmock.NewStringResponse(http.StatusServiceUnavailable, ""), nil
+	})
+
+	req, _ := client.NewRequest("POST", "foo", map[string]string{"foo": "bar"}, nil)
+	if err := client.Do(req, nil); err == nil {
+		t.Errorf("Do(): expected an error")
+	}
+	if calls != 1 {
+		t.Errorf("Do(): made %d calls for a POST answered with 503, expected 1", calls)
+	}
+}
+
+func TestRetryPolicyRetryableMethod(t *testing.T) {
+	policy := DefaultRetryPolicy()
+	for _, method := range []string{http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete} {
+		if !policy.retryableMethod(method) {
+			t.Errorf("retryableMethod(%s) = false, expected true", method)
+		}
+	}
+	if policy.retryableMethod(http.MethodPost) || policy.retryableMethod(http.MethodPatch) {
+		t.Errorf("retryableMethod retries POST or PATCH by default")
+	}
+	policy.RetryPost = true
+	if !policy.retryableMethod(http.MethodPost) {
+		t.Errorf("retryableMethod(POST) = false with RetryPost")
+	}
+}
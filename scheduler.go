@@ -0,0 +1,280 @@
+package goshopify
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrSchedulerClosed is returned for jobs enqueued after a scheduler was
+// closed or its context was cancelled.
+var ErrSchedulerClosed = errors.New("scheduler closed")
+
+// Job is an API call, or a sequence of them, run by a Scheduler.
+type Job func(ctx context.Context, c *Client) error
+
+// Priority orders the jobs of a Scheduler, higher priorities run first and
+// jobs with the same priority run in the order they were enqueued.
+type Priority int
+
+const (
+	PriorityLow    Priority = -1
+	PriorityNormal Priority = 0
+	PriorityHigh   Priority = 1
+)
+
+// SchedulerMetrics is a snapshot of what a Scheduler has done so far.
+type SchedulerMetrics struct {
+	Enqueued  int
+	Pending   int
+	Completed int
+	Failed    int
+	// Throttled is the total time jobs were held back to respect the rate
+	// limits.
+	Throttled time.Duration
+}
+
+// SchedulerOption is used to configure a Scheduler.
+type SchedulerOption func(s *Scheduler)
+
+// WithSchedulerRate sets the number of jobs started per second once the call
+// limit bucket is half full, defaults to the REST leak rate of 2 per second.
+// Shopify Plus stores leak at 4 requests per second.
+func WithSchedulerRate(perSecond float64) SchedulerOption {
+	return func(s *Scheduler) {
+		if perSecond > 0 {
+			s.interval = time.Duration(float64(time.Second) / perSecond)
+		}
+	}
+}
+
+// Scheduler runs queued jobs against a single shop one at a time, as fast as
+// its call limit allows. It is the shared foundation for helpers that issue
+// many requests, which only have to enqueue their calls.
+//
+// While the last response reported at least half of the call limit bucket as
+// free, jobs start immediately so bursts can use the bucket's capacity.
+// Otherwise they are paced at the scheduler's rate and wait for the bucket to
+// drain when it is full.
+type Scheduler struct {
+	client   *Client
+	ctx      context.Context
+	interval time.Duration
+
+	mu        sync.Mutex
+	wake      chan struct{}
+	queue     jobQueue
+	seq       int
+	paused    bool
+	closed    bool
+	lastStart time.Time
+	metrics   SchedulerMetrics
+	done      chan struct{}
+}
+
+// NewScheduler creates a scheduler for the client and starts running jobs
+// until the context is cancelled or Close is called.
+func NewScheduler(ctx context.Context, client *Client, opts ...SchedulerOption) *Scheduler {
+	s := &Scheduler{
+		client:   client,
+		ctx:      ctx,
+		interval: time.Second / bucketLeakRate,
+		wake:     make(chan struct{}, 1),
+		done:     make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	go s.run()
+	return s
+}
+
+// Enqueue adds a job to the queue. The returned channel receives the job's
+// error, or nil, once it has run.
+func (s *Scheduler) Enqueue(priority Priority, job Job) <-chan error {
+	result := make(chan error, 1)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		result <- ErrSchedulerClosed
+		return result
+	}
+
+	s.seq++
+	heap.Push(&s.queue, &queuedJob{job: job, priority: priority, seq: s.seq, result: result})
+	s.metrics.Enqueued++
+	s.signal()
+	return result
+}
+
+// Pause stops the scheduler from starting new jobs, a job that is already
+// running is not interrupted.
+func (s *Scheduler) Pause() {
+	s.mu.Lock()
+	s.paused = true
+	s.mu.Unlock()
+}
+
+// Resume starts running jobs again after Pause.
+func (s *Scheduler) Resume() {
+	s.mu.Lock()
+	s.paused = false
+	s.signal()
+	s.mu.Unlock()
+}
+
+// Close stops accepting new jobs and blocks until the queued jobs have run.
+// A paused scheduler is resumed to drain its queue.
+func (s *Scheduler) Close() {
+	s.mu.Lock()
+	s.closed = true
+	s.paused = false
+	s.signal()
+	s.mu.Unlock()
+
+	<-s.done
+}
+
+// Metrics returns a snapshot of the scheduler's counters.
+func (s *Scheduler) Metrics() SchedulerMetrics {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	metrics := s.metrics
+	metrics.Pending = s.queue.Len()
+	return metrics
+}
+
+// signal wakes up the run loop, it must be called with the lock held.
+func (s *Scheduler) signal() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Scheduler) run() {
+	defer close(s.done)
+
+	for {
+		next := s.next()
+		if next == nil {
+			return
+		}
+
+		err := s.throttle()
+		if err == nil {
+			err = next.job(s.ctx, s.client)
+		}
+
+		s.mu.Lock()
+		if err != nil {
+			s.metrics.Failed++
+		} else {
+			s.metrics.Completed++
+		}
+		s.mu.Unlock()
+
+		next.result <- err
+	}
+}
+
+// next blocks until a job may run, it returns nil once the scheduler is closed
+// and drained, or its context is done in which case pending jobs fail.
+func (s *Scheduler) next() *queuedJob {
+	for {
+		s.mu.Lock()
+		if s.ctx.Err() != nil {
+			s.failPending(s.ctx.Err())
+			s.closed = true
+			s.mu.Unlock()
+			return nil
+		}
+		if !s.paused && s.queue.Len() > 0 {
+			next := heap.Pop(&s.queue).(*queuedJob)
+			s.mu.Unlock()
+			return next
+		}
+		if s.closed && s.queue.Len() == 0 {
+			s.mu.Unlock()
+			return nil
+		}
+		s.mu.Unlock()
+
+		select {
+		case <-s.wake:
+		case <-s.ctx.Done():
+		}
+	}
+}
+
+// throttle waits until the next job may start without exceeding the rate
+// limits, it returns early with an error when the context is done.
+func (s *Scheduler) throttle() error {
+	start := time.Now()
+	defer func() {
+		s.mu.Lock()
+		s.metrics.Throttled += time.Since(start)
+		s.lastStart = time.Now()
+		s.mu.Unlock()
+	}()
+
+	limits := s.client.rateLimits()
+	if limits.BucketSize == 0 || limits.RequestCount*2 > limits.BucketSize {
+		s.mu.Lock()
+		wait := time.Until(s.lastStart.Add(s.interval))
+		s.mu.Unlock()
+		if err := sleepContext(s.ctx, wait); err != nil {
+			return err
+		}
+	}
+
+	return waitForRateLimit(s.ctx, s.client)
+}
+
+// failPending sends err to all queued jobs, it must be called with the lock
+// held.
+func (s *Scheduler) failPending(err error) {
+	for s.queue.Len() > 0 {
+		job := heap.Pop(&s.queue).(*queuedJob)
+		job.result <- err
+		s.metrics.Failed++
+	}
+}
+
+type queuedJob struct {
+	job      Job
+	priority Priority
+	seq      int
+	result   chan error
+}
+
+// jobQueue implements heap.Interface ordering jobs by priority and then by
+// the order they were enqueued in.
+type jobQueue []*queuedJob
+
+func (q jobQueue) Len() int { return len(q) }
+
+func (q jobQueue) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority > q[j].priority
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q jobQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *jobQueue) Push(x interface{}) { *q = append(*q, x.(*queuedJob)) }
+
+func (q *jobQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	job := old[n-1]
+	*q = old[:n-1]
+	return job
+}
@@ -0,0 +1,139 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSchedulerPriority(t *testing.T) {
+	setup()
+	defer teardown()
+
+	s := NewScheduler(context.Background(), client, WithSchedulerRate(1000))
+	s.Pause()
+
+	var mu sync.Mutex
+	var order []string
+	record := func(name string) Job {
+		return func(ctx context.Context, c *Client) error {
+			mu.Lock()
+			order = append(order, name)
+			mu.Unlock()
+			return nil
+		}
+	}
+
+	s.Enqueue(PriorityLow, record("low"))
+	s.Enqueue(PriorityNormal, record("normal 1"))
+	s.Enqueue(PriorityHigh, record("high"))
+	s.Enqueue(PriorityNormal, record("normal 2"))
+
+	if m := s.Metrics(); m.Pending != 4 || m.Completed != 0 {
+		t.Errorf("Scheduler.Metrics() while paused returned %+v", m)
+	}
+
+	s.Resume()
+	s.Close()
+
+	expected := []string{"high", "normal 1", "normal 2", "low"}
+	if !reflect.DeepEqual(order, expected) {
+		t.Errorf("Scheduler ran jobs in order %v, expected %v", order, expected)
+	}
+}
+
+func TestSchedulerResults(t *testing.T) {
+	setup()
+	defer teardown()
+
+	s := NewScheduler(context.Background(), client, WithSchedulerRate(1000))
+	boom := errors.New("boom")
+
+	ok := s.Enqueue(PriorityNormal, func(ctx context.Context, c *Client) error { return nil })
+	failed := s.Enqueue(PriorityNormal, func(ctx context.Context, c *Client) error { return boom })
+
+	if err := <-ok; err != nil {
+		t.Errorf("Scheduler job returned %v, expected nil", err)
+	}
+	if err := <-failed; err != boom {
+		t.Errorf("Scheduler job returned %v, expected %v", err, boom)
+	}
+
+	s.Close()
+
+	expected := SchedulerMetrics{Enqueued: 2, Completed: 1, Failed: 1}
+	actual := s.Metrics()
+	actual.Throttled = 0
+	if actual != expected {
+		t.Errorf("Scheduler.Metrics() returned %+v, expected %+v", actual, expected)
+	}
+
+	if err := <-s.Enqueue(PriorityNormal, func(ctx context.Context, c *Client) error { return nil }); err != ErrSchedulerClosed {
+		t.Errorf("Scheduler.Enqueue() after Close returned %v, expected %v", err, ErrSchedulerClosed)
+	}
+}
+
+func TestSchedulerRate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	s := NewScheduler(context.Background(), client, WithSchedulerRate(50))
+	var results []<-chan error
+	for i := 0; i < 4; i++ {
+		results = append(results, s.Enqueue(PriorityNormal, func(ctx context.Context, c *Client) error { return nil }))
+	}
+
+	start := time.Now()
+	for _, result := range results {
+		<-result
+	}
+	// the first job starts immediately, the other three are 20ms apart
+	if elapsed := time.Since(start); elapsed < 60*time.Millisecond {
+		t.Errorf("Scheduler ran 4 jobs in %s, expected at least 60ms", elapsed)
+	}
+	s.Close()
+}
+
+func TestSchedulerBurstsWithBucketRoom(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.RateLimits = RateLimitInfo{RequestCount: 1, BucketSize: 40}
+	s := NewScheduler(context.Background(), client, WithSchedulerRate(1))
+	var results []<-chan error
+	for i := 0; i < 4; i++ {
+		results = append(results, s.Enqueue(PriorityNormal, func(ctx context.Context, c *Client) error { return nil }))
+	}
+
+	start := time.Now()
+	for _, result := range results {
+		<-result
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Scheduler ran 4 jobs in %s, expected no pacing", elapsed)
+	}
+	s.Close()
+}
+
+func TestSchedulerContextCanceled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := NewScheduler(ctx, client)
+	s.Pause()
+	result := s.Enqueue(PriorityNormal, func(ctx context.Context, c *Client) error { return nil })
+
+	cancel()
+	if err := <-result; err != context.Canceled {
+		t.Errorf("Scheduler job returned %v, expected %v", err, context.Canceled)
+	}
+	s.Close()
+
+	if err := <-s.Enqueue(PriorityNormal, func(ctx context.Context, c *Client) error { return nil }); err != ErrSchedulerClosed {
+		t.Errorf("Scheduler.Enqueue() after cancel returned %v, expected %v", err, ErrSchedulerClosed)
+	}
+}
@@ -0,0 +1,97 @@
+package goshopify
+
+import (
+	"sort"
+	"strings"
+)
+
+// Scopes is a set of access scopes, e.g. read_products, sorted and without
+// duplicates.
+// See: https://shopify.dev/api/usage/access-scopes
+type Scopes []string
+
+// ParseScopes parses a comma separated scope string like the one of
+// App.Scope or an access token.
+func ParseScopes(s string) Scopes {
+	var scopes Scopes
+	for _, scope := range strings.Split(s, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes.dedupe()
+}
+
+// String returns the scopes comma separated.
+func (s Scopes) String() string {
+	return strings.Join(s, ",")
+}
+
+// Normalize returns the scopes with the scopes they imply added, write access
+// to a resource implies read access, e.g. write_products implies
+// read_products.
+func (s Scopes) Normalize() Scopes {
+	normalized := append(Scopes(nil), s...)
+	for _, scope := range s {
+		if implied := impliedScope(scope); implied != "" {
+			normalized = append(normalized, implied)
+		}
+	}
+	return normalized.dedupe()
+}
+
+// Has reports whether the scope is granted, directly or implied.
+func (s Scopes) Has(scope string) bool {
+	normalized := s.Normalize()
+	i := sort.SearchStrings(normalized, scope)
+	return i < len(normalized) && normalized[i] == scope
+}
+
+// Covers reports whether the scopes grant everything required grants. An app
+// whose required scopes are no longer covered by the granted ones must have
+// the merchant authorize it again.
+func (s Scopes) Covers(required Scopes) bool {
+	return len(s.Missing(required)) == 0
+}
+
+// Missing returns the required scopes that aren't granted.
+func (s Scopes) Missing(required Scopes) Scopes {
+	var missing Scopes
+	for _, scope := range required.Normalize() {
+		if !s.Has(scope) {
+			missing = append(missing, scope)
+		}
+	}
+	return missing
+}
+
+// Scopes returns the scopes granted to the access token.
+func (t *AccessToken) Scopes() Scopes {
+	return ParseScopes(t.Scope)
+}
+
+// impliedScope returns the read scope a write scope implies, e.g.
+// read_products for write_products and unauthenticated_read_checkouts for
+// unauthenticated_write_checkouts.
+func impliedScope(scope string) string {
+	prefix := ""
+	if strings.HasPrefix(scope, "unauthenticated_") {
+		prefix = "unauthenticated_"
+		scope = strings.TrimPrefix(scope, prefix)
+	}
+	if !strings.HasPrefix(scope, "write_") {
+		return ""
+	}
+	return prefix + "read_" + strings.TrimPrefix(scope, "write_")
+}
+
+func (s Scopes) dedupe() Scopes {
+	sort.Strings(s)
+	deduped := s[:0]
+	for i, scope := range s {
+		if i == 0 || scope != s[i-1] {
+			deduped = append(deduped, scope)
+		}
+	}
+	return deduped
+}
@@ -0,0 +1,68 @@
+package goshopify
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseScopes(t *testing.T) {
+	cases := []struct {
+		in       string
+		expected Scopes
+	}{
+		{"", nil},
+		{"read_products", Scopes{"read_products"}},
+		{" write_orders, read_products ,,write_orders", Scopes{"read_products", "write_orders"}},
+	}
+
+	for _, c := range cases {
+		actual := ParseScopes(c.in)
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("ParseScopes(%q) returned %#v, expected %#v", c.in, actual, c.expected)
+		}
+	}
+
+	if s := ParseScopes("write_orders,read_products").String(); s != "read_products,write_orders" {
+		t.Errorf("Scopes.String returned %q", s)
+	}
+}
+
+func TestScopesNormalize(t *testing.T) {
+	actual := ParseScopes("write_products,read_products,unauthenticated_write_checkouts,read_orders").Normalize()
+	expected := Scopes{"read_orders", "read_products", "unauthenticated_read_checkouts", "unauthenticated_write_checkouts", "write_products"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Scopes.Normalize returned %v, expected %v", actual, expected)
+	}
+}
+
+func TestScopesCovers(t *testing.T) {
+	cases := []struct {
+		granted  string
+		required string
+		missing  Scopes
+	}{
+		{"write_products", "read_products", nil},
+		{"write_products", "read_products,write_products", nil},
+		{"read_products", "write_products", Scopes{"write_products"}},
+		{"write_products,read_orders", "read_products,write_orders", Scopes{"write_orders"}},
+		{"read_orders", "", nil},
+		{"", "read_orders", Scopes{"read_orders"}},
+	}
+
+	for _, c := range cases {
+		granted, required := ParseScopes(c.granted), ParseScopes(c.required)
+		if covers := granted.Covers(required); covers != (len(c.missing) == 0) {
+			t.Errorf("Scopes(%s).Covers(%s) returned %v", c.granted, c.required, covers)
+		}
+		if missing := granted.Missing(required); !reflect.DeepEqual(missing, c.missing) {
+			t.Errorf("Scopes(%s).Missing(%s) returned %v, expected %v", c.granted, c.required, missing, c.missing)
+		}
+	}
+}
+
+func TestAccessTokenScopes(t *testing.T) {
+	token := &AccessToken{Scope: "write_orders,read_customers"}
+	if !token.Scopes().Has("read_orders") {
+		t.Errorf("AccessToken.Scopes returned %v, expected it to have read_orders", token.Scopes())
+	}
+}
@@ -0,0 +1,456 @@
+package goshopify
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// Categories of selling plans.
+const (
+	SellingPlanCategorySubscription    = "SUBSCRIPTION"
+	SellingPlanCategoryPreOrder        = "PRE_ORDER"
+	SellingPlanCategoryTryBeforeYouBuy = "TRY_BEFORE_YOU_BUY"
+	SellingPlanCategoryOther           = "OTHER"
+)
+
+// Intervals of recurring selling plan policies.
+const (
+	SellingPlanIntervalDay   = "DAY"
+	SellingPlanIntervalWeek  = "WEEK"
+	SellingPlanIntervalMonth = "MONTH"
+	SellingPlanIntervalYear  = "YEAR"
+)
+
+// Adjustment types of selling plan pricing policies. A percentage is taken
+// off the price, a fixed amount is taken off the price or the price is
+// replaced.
+const (
+	SellingPlanAdjustmentPercentage  = "PERCENTAGE"
+	SellingPlanAdjustmentFixedAmount = "FIXED_AMOUNT"
+	SellingPlanAdjustmentPrice       = "PRICE"
+)
+
+// maxSellingPlans is the number of selling plans a group can have at most.
+const maxSellingPlans = 31
+
+// SellingPlanGroupService is an interface for interfacing with the selling plan group endpoints
+// of the Shopify API.
+// See: https://shopify.dev/docs/apps/selling-strategies/subscriptions/selling-plans
+type SellingPlanGroupService interface {
+	List() ([]SellingPlanGroup, error)
+	Get(string) (*SellingPlanGroup, error)
+	Create(SellingPlanGroup) (*SellingPlanGroup, error)
+	Update(SellingPlanGroup) (*SellingPlanGroup, error)
+	Delete(string) error
+	DeletePlans(string, ...string) error
+	AddProducts(string, ...int64) error
+	RemoveProducts(string, ...int64) error
+	AddVariants(string, ...int64) error
+	RemoveVariants(string, ...int64) error
+}
+
+// SellingPlanGroupServiceOp handles communication with the selling plan
+// group related GraphQL queries and mutations.
+type SellingPlanGroupServiceOp struct {
+	client *Client
+}
+
+// SellingPlanGroup represents a group of selling plans, e.g. "Subscribe and
+// save" with a plan for every delivery frequency. The options name the plans
+// to customers, every plan has a value for each of them.
+type SellingPlanGroup struct {
+	ID           string        `json:"id,omitempty"`
+	Name         string        `json:"name"`
+	MerchantCode string        `json:"merchantCode"`
+	Description  string        `json:"description,omitempty"`
+	Options      []string      `json:"options"`
+	Position     int           `json:"position,omitempty"`
+	SellingPlans []SellingPlan `json:"sellingPlans,omitempty"`
+}
+
+// SellingPlan represents a way to purchase the products of its group. Only
+// recurring billing and delivery policies and fixed pricing policies are
+// modelled.
+type SellingPlan struct {
+	ID              string                      `json:"id,omitempty"`
+	Name            string                      `json:"name"`
+	Description     string                      `json:"description,omitempty"`
+	Options         []string                    `json:"options"`
+	Position        int                         `json:"position,omitempty"`
+	Category        string                      `json:"category,omitempty"`
+	BillingPolicy   *SellingPlanRecurringPolicy `json:"billingPolicy,omitempty"`
+	DeliveryPolicy  *SellingPlanRecurringPolicy `json:"deliveryPolicy,omitempty"`
+	PricingPolicies []SellingPlanPricingPolicy  `json:"pricingPolicies,omitempty"`
+}
+
+// SellingPlanRecurringPolicy bills or delivers every IntervalCount intervals,
+// e.g. every 2 WEEK.
+type SellingPlanRecurringPolicy struct {
+	Interval      string `json:"interval"`
+	IntervalCount int    `json:"intervalCount"`
+}
+
+// SellingPlanPricingPolicy adjusts the price of the products bought with a
+// plan, by a Percentage for the PERCENTAGE adjustment type or by an Amount for
+// the others.
+type SellingPlanPricingPolicy struct {
+	AdjustmentType string           `json:"adjustmentType"`
+	Percentage     *float64         `json:"percentage,omitempty"`
+	Amount         *decimal.Decimal `json:"amount,omitempty"`
+}
+
+// input returns the SellingPlanGroupInput of the group, plans without an ID
+// are created and the others updated.
+func (g SellingPlanGroup) input() map[string]interface{} {
+	input := map[string]interface{}{
+		"name":         g.Name,
+		"merchantCode": g.MerchantCode,
+		"options":      g.Options,
+	}
+	if g.Description != "" {
+		input["description"] = g.Description
+	}
+	if g.Position != 0 {
+		input["position"] = g.Position
+	}
+
+	var create, update []map[string]interface{}
+	for _, plan := range g.SellingPlans {
+		if plan.ID == "" {
+			create = append(create, plan.input())
+		} else {
+			update = append(update, plan.input())
+		}
+	}
+	if len(create) > 0 {
+		input["sellingPlansToCreate"] = create
+	}
+	if len(update) > 0 {
+		input["sellingPlansToUpdate"] = update
+	}
+	return input
+}
+
+// input returns the SellingPlanInput of the plan, whose policies are wrapped
+// in an object naming their kind.
+func (p SellingPlan) input() map[string]interface{} {
+	input := map[string]interface{}{
+		"name":    p.Name,
+		"options": p.Options,
+	}
+	if p.ID != "" {
+		input["id"] = p.ID
+	}
+	if p.Description != "" {
+		input["description"] = p.Description
+	}
+	if p.Position != 0 {
+		input["position"] = p.Position
+	}
+	if p.Category != "" {
+		input["category"] = p.Category
+	}
+	if p.BillingPolicy != nil {
+		input["billingPolicy"] = map[string]interface{}{"recurring": p.BillingPolicy}
+	}
+	if p.DeliveryPolicy != nil {
+		input["deliveryPolicy"] = map[string]interface{}{"recurring": p.DeliveryPolicy}
+	}
+	if len(p.PricingPolicies) > 0 {
+		var policies []map[string]interface{}
+		for _, policy := range p.PricingPolicies {
+			value := map[string]interface{}{}
+			if policy.Percentage != nil {
+				value["percentage"] = *policy.Percentage
+			}
+			if policy.Amount != nil {
+				value["fixedValue"] = policy.Amount.String()
+			}
+			policies = append(policies, map[string]interface{}{
+				"fixed": map[string]interface{}{
+					"adjustmentType":  policy.AdjustmentType,
+					"adjustmentValue": value,
+				},
+			})
+		}
+		input["pricingPolicies"] = policies
+	}
+	return input
+}
+
+// sellingPlanGroupNode is the GraphQL representation of a group, its plans
+// are a connection and the adjustment values of their pricing policies a
+// union.
+type sellingPlanGroupNode struct {
+	ID           string   `json:"id"`
+	Name         string   `json:"name"`
+	MerchantCode string   `json:"merchantCode"`
+	Description  string   `json:"description"`
+	Options      []string `json:"options"`
+	Position     int      `json:"position"`
+	SellingPlans struct {
+		Edges []struct {
+			Node struct {
+				ID              string                      `json:"id"`
+				Name            string                      `json:"name"`
+				Description     string                      `json:"description"`
+				Options         []string                    `json:"options"`
+				Position        int                         `json:"position"`
+				Category        string                      `json:"category"`
+				BillingPolicy   *SellingPlanRecurringPolicy `json:"billingPolicy"`
+				DeliveryPolicy  *SellingPlanRecurringPolicy `json:"deliveryPolicy"`
+				PricingPolicies []struct {
+					AdjustmentType  string `json:"adjustmentType"`
+					AdjustmentValue struct {
+						Percentage *float64         `json:"percentage"`
+						Amount     *decimal.Decimal `json:"amount"`
+					} `json:"adjustmentValue"`
+				} `json:"pricingPolicies"`
+			} `json:"node"`
+		} `json:"edges"`
+	} `json:"sellingPlans"`
+}
+
+func (n *sellingPlanGroupNode) group() *SellingPlanGroup {
+	if n == nil {
+		return nil
+	}
+	group := &SellingPlanGroup{
+		ID:           n.ID,
+		Name:         n.Name,
+		MerchantCode: n.MerchantCode,
+		Description:  n.Description,
+		Options:      n.Options,
+		Position:     n.Position,
+	}
+	for _, edge := range n.SellingPlans.Edges {
+		plan := SellingPlan{
+			ID:             edge.Node.ID,
+			Name:           edge.Node.Name,
+			Description:    edge.Node.Description,
+			Options:        edge.Node.Options,
+			Position:       edge.Node.Position,
+			Category:       edge.Node.Category,
+			BillingPolicy:  edge.Node.BillingPolicy,
+			DeliveryPolicy: edge.Node.DeliveryPolicy,
+		}
+		for _, policy := range edge.Node.PricingPolicies {
+			plan.PricingPolicies = append(plan.PricingPolicies, SellingPlanPricingPolicy{
+				AdjustmentType: policy.AdjustmentType,
+				Percentage:     policy.AdjustmentValue.Percentage,
+				Amount:         policy.AdjustmentValue.Amount,
+			})
+		}
+		group.SellingPlans = append(group.SellingPlans, plan)
+	}
+	return group
+}
+
+var sellingPlanGroupFields = fmt.Sprintf(`id name merchantCode description options position
+  sellingPlans(first: %d) {
+    edges { node {
+      id name description options position category
+      billingPolicy { ... on SellingPlanRecurringBillingPolicy { interval intervalCount } }
+      deliveryPolicy { ... on SellingPlanRecurringDeliveryPolicy { interval intervalCount } }
+      pricingPolicies { ... on SellingPlanFixedPricingPolicy {
+        adjustmentType
+        adjustmentValue {
+          ... on SellingPlanPricingPolicyPercentageValue { percentage }
+          ... on MoneyV2 { amount }
+        }
+      } }
+    } }
+  }`, maxSellingPlans)
+
+var sellingPlanGroupsQuery = `query($after: String) {
+  sellingPlanGroups(first: 50, after: $after) {
+    edges { node { ` + sellingPlanGroupFields + ` } }
+    pageInfo { hasNextPage endCursor }
+  }
+}`
+
+var sellingPlanGroupQuery = `query($id: ID!) {
+  sellingPlanGroup(id: $id) { ` + sellingPlanGroupFields + ` }
+}`
+
+var sellingPlanGroupCreateMutation = `mutation($input: SellingPlanGroupInput!) {
+  sellingPlanGroupCreate(input: $input) {
+    sellingPlanGroup { ` + sellingPlanGroupFields + ` }
+    userErrors { field message }
+  }
+}`
+
+var sellingPlanGroupUpdateMutation = `mutation($id: ID!, $input: SellingPlanGroupInput!) {
+  sellingPlanGroupUpdate(id: $id, input: $input) {
+    sellingPlanGroup { ` + sellingPlanGroupFields + ` }
+    userErrors { field message }
+  }
+}`
+
+const sellingPlanGroupDeleteMutation = `mutation($id: ID!) {
+  sellingPlanGroupDelete(id: $id) {
+    deletedSellingPlanGroupId
+    userErrors { field message }
+  }
+}`
+
+const sellingPlanGroupDeletePlansMutation = `mutation($id: ID!, $input: SellingPlanGroupInput!) {
+  sellingPlanGroupUpdate(id: $id, input: $input) {
+    deletedSellingPlanIds
+    userErrors { field message }
+  }
+}`
+
+const sellingPlanGroupAddProductsMutation = `mutation($id: ID!, $productIds: [ID!]!) {
+  sellingPlanGroupAddProducts(id: $id, productIds: $productIds) {
+    sellingPlanGroup { id }
+    userErrors { field message }
+  }
+}`
+
+const sellingPlanGroupRemoveProductsMutation = `mutation($id: ID!, $productIds: [ID!]!) {
+  sellingPlanGroupRemoveProducts(id: $id, productIds: $productIds) {
+    removedProductIds
+    userErrors { field message }
+  }
+}`
+
+const sellingPlanGroupAddProductVariantsMutation = `mutation($id: ID!, $productVariantIds: [ID!]!) {
+  sellingPlanGroupAddProductVariants(id: $id, productVariantIds: $productVariantIds) {
+    sellingPlanGroup { id }
+    userErrors { field message }
+  }
+}`
+
+const sellingPlanGroupRemoveProductVariantsMutation = `mutation($id: ID!, $productVariantIds: [ID!]!) {
+  sellingPlanGroupRemoveProductVariants(id: $id, productVariantIds: $productVariantIds) {
+    removedProductVariantIds
+    userErrors { field message }
+  }
+}`
+
+// List the selling plan groups of the app along with their plans.
+func (s *SellingPlanGroupServiceOp) List() ([]SellingPlanGroup, error) {
+	var groups []SellingPlanGroup
+	var after *string
+	for {
+		resp := struct {
+			SellingPlanGroups struct {
+				Edges []struct {
+					Node sellingPlanGroupNode `json:"node"`
+				} `json:"edges"`
+				PageInfo graphQLPageInfo `json:"pageInfo"`
+			} `json:"sellingPlanGroups"`
+		}{}
+
+		err := s.client.GraphQL.Query(sellingPlanGroupsQuery, map[string]interface{}{"after": after}, &resp)
+		if err != nil {
+			return groups, err
+		}
+
+		for i := range resp.SellingPlanGroups.Edges {
+			groups = append(groups, *resp.SellingPlanGroups.Edges[i].Node.group())
+		}
+
+		pageInfo := resp.SellingPlanGroups.PageInfo
+		if !pageInfo.HasNextPage {
+			return groups, nil
+		}
+		after = &pageInfo.EndCursor
+	}
+}
+
+// Get a selling plan group by its ID, nil if there is none.
+func (s *SellingPlanGroupServiceOp) Get(id string) (*SellingPlanGroup, error) {
+	resp := struct {
+		SellingPlanGroup *sellingPlanGroupNode `json:"sellingPlanGroup"`
+	}{}
+	err := s.client.GraphQL.Query(sellingPlanGroupQuery, map[string]interface{}{"id": id}, &resp)
+	return resp.SellingPlanGroup.group(), err
+}
+
+// Create a new selling plan group along with its plans
+func (s *SellingPlanGroupServiceOp) Create(group SellingPlanGroup) (*SellingPlanGroup, error) {
+	resp := struct {
+		SellingPlanGroupCreate struct {
+			SellingPlanGroup *sellingPlanGroupNode `json:"sellingPlanGroup"`
+			UserErrors       []UserError           `json:"userErrors"`
+		} `json:"sellingPlanGroupCreate"`
+	}{}
+
+	err := s.client.GraphQL.Query(sellingPlanGroupCreateMutation, map[string]interface{}{"input": group.input()}, &resp)
+	if err == nil {
+		err = userErrorsToError(resp.SellingPlanGroupCreate.UserErrors)
+	}
+	return resp.SellingPlanGroupCreate.SellingPlanGroup.group(), err
+}
+
+// Update an existing selling plan group. Its plans without an ID are created
+// and the others updated, plans that are left out are kept, see DeletePlans.
+func (s *SellingPlanGroupServiceOp) Update(group SellingPlanGroup) (*SellingPlanGroup, error) {
+	vars := map[string]interface{}{
+		"id":    group.ID,
+		"input": group.input(),
+	}
+	resp := struct {
+		SellingPlanGroupUpdate struct {
+			SellingPlanGroup *sellingPlanGroupNode `json:"sellingPlanGroup"`
+			UserErrors       []UserError           `json:"userErrors"`
+		} `json:"sellingPlanGroupUpdate"`
+	}{}
+
+	err := s.client.GraphQL.Query(sellingPlanGroupUpdateMutation, vars, &resp)
+	if err == nil {
+		err = userErrorsToError(resp.SellingPlanGroupUpdate.UserErrors)
+	}
+	return resp.SellingPlanGroupUpdate.SellingPlanGroup.group(), err
+}
+
+// Delete an existing selling plan group
+func (s *SellingPlanGroupServiceOp) Delete(id string) error {
+	return graphQLMutate(s.client, sellingPlanGroupDeleteMutation, "sellingPlanGroupDelete", map[string]interface{}{"id": id})
+}
+
+// DeletePlans deletes plans of a selling plan group by their IDs.
+func (s *SellingPlanGroupServiceOp) DeletePlans(id string, planIDs ...string) error {
+	vars := map[string]interface{}{
+		"id":    id,
+		"input": map[string]interface{}{"sellingPlansToDelete": planIDs},
+	}
+	return graphQLMutate(s.client, sellingPlanGroupDeletePlansMutation, "sellingPlanGroupUpdate", vars)
+}
+
+// AddProducts makes the products purchasable with the plans of the group.
+func (s *SellingPlanGroupServiceOp) AddProducts(id string, productIDs ...int64) error {
+	vars := map[string]interface{}{"id": id, "productIds": gids("Product", productIDs)}
+	return graphQLMutate(s.client, sellingPlanGroupAddProductsMutation, "sellingPlanGroupAddProducts", vars)
+}
+
+// RemoveProducts removes products from the group.
+func (s *SellingPlanGroupServiceOp) RemoveProducts(id string, productIDs ...int64) error {
+	vars := map[string]interface{}{"id": id, "productIds": gids("Product", productIDs)}
+	return graphQLMutate(s.client, sellingPlanGroupRemoveProductsMutation, "sellingPlanGroupRemoveProducts", vars)
+}
+
+// AddVariants makes single variants purchasable with the plans of the group.
+func (s *SellingPlanGroupServiceOp) AddVariants(id string, variantIDs ...int64) error {
+	vars := map[string]interface{}{"id": id, "productVariantIds": gids("ProductVariant", variantIDs)}
+	return graphQLMutate(s.client, sellingPlanGroupAddProductVariantsMutation, "sellingPlanGroupAddProductVariants", vars)
+}
+
+// RemoveVariants removes variants from the group.
+func (s *SellingPlanGroupServiceOp) RemoveVariants(id string, variantIDs ...int64) error {
+	vars := map[string]interface{}{"id": id, "productVariantIds": gids("ProductVariant", variantIDs)}
+	return graphQLMutate(s.client, sellingPlanGroupRemoveProductVariantsMutation, "sellingPlanGroupRemoveProductVariants", vars)
+}
+
+// gids returns the global IDs of the numeric IDs of a resource type, e.g.
+// gid://shopify/Product/1 for Product.
+func gids(resource string, ids []int64) []string {
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, fmt.Sprintf("gid://shopify/%s/%d", resource, id))
+	}
+	return result
+}
@@ -0,0 +1,265 @@
+package goshopify
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/shopspring/decimal"
+)
+
+func sellingPlanGroupTests(t *testing.T, group SellingPlanGroup) {
+	percentage := 10.0
+	amount := decimal.NewFromFloat(2.5)
+	expected := SellingPlanGroup{
+		ID:           "gid://shopify/SellingPlanGroup/1",
+		Name:         "Subscribe and save",
+		MerchantCode: "subscribe",
+		Options:      []string{"Delivery every"},
+		Position:     1,
+		SellingPlans: []SellingPlan{{
+			ID:             "gid://shopify/SellingPlan/2",
+			Name:           "Monthly",
+			Options:        []string{"Month"},
+			Position:       1,
+			Category:       SellingPlanCategorySubscription,
+			BillingPolicy:  &SellingPlanRecurringPolicy{Interval: SellingPlanIntervalMonth, IntervalCount: 1},
+			DeliveryPolicy: &SellingPlanRecurringPolicy{Interval: SellingPlanIntervalMonth, IntervalCount: 1},
+			PricingPolicies: []SellingPlanPricingPolicy{
+				{AdjustmentType: SellingPlanAdjustmentPercentage, Percentage: &percentage},
+				{AdjustmentType: SellingPlanAdjustmentFixedAmount, Amount: &amount},
+			},
+		}},
+	}
+	if !reflect.DeepEqual(group, expected) {
+		t.Errorf("SellingPlanGroup returned %+v, expected %+v", group, expected)
+	}
+}
+
+func TestSellingPlanGroupList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests,
+			string(loadFixture("selling_plan_groups.json")),
+			`{"data":{"sellingPlanGroups":{"edges":[{"node":{"id":"gid://shopify/SellingPlanGroup/3","name":"Pre-order","merchantCode":"pre-order","options":["Ships"],"sellingPlans":{"edges":[]}}}],"pageInfo":{"hasNextPage":false}}}}`,
+		))
+
+	groups, err := client.SellingPlanGroup.List()
+	if err != nil {
+		t.Fatalf("SellingPlanGroup.List returned error: %v", err)
+	}
+
+	if len(groups) != 2 {
+		t.Fatalf("SellingPlanGroup.List returned %d groups, expected 2", len(groups))
+	}
+	sellingPlanGroupTests(t, groups[0])
+	expected := SellingPlanGroup{ID: "gid://shopify/SellingPlanGroup/3", Name: "Pre-order", MerchantCode: "pre-order", Options: []string{"Ships"}}
+	if !reflect.DeepEqual(groups[1], expected) {
+		t.Errorf("SellingPlanGroup.List returned %+v, expected %+v", groups[1], expected)
+	}
+	if len(requests) != 2 || requests[1].Variables.(map[string]interface{})["after"] != "abc" {
+		t.Errorf("SellingPlanGroup.List sent %+v, expected the second page after abc", requests)
+	}
+}
+
+func TestSellingPlanGroupGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests,
+			string(loadFixture("selling_plan_group.json")),
+			`{"data":{"sellingPlanGroup":null}}`,
+		))
+
+	group, err := client.SellingPlanGroup.Get("gid://shopify/SellingPlanGroup/1")
+	if err != nil {
+		t.Fatalf("SellingPlanGroup.Get returned error: %v", err)
+	}
+	sellingPlanGroupTests(t, *group)
+
+	group, err = client.SellingPlanGroup.Get("gid://shopify/SellingPlanGroup/9")
+	if err != nil || group != nil {
+		t.Errorf("SellingPlanGroup.Get of a missing group returned %+v, %v", group, err)
+	}
+}
+
+func TestSellingPlanGroupCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests, string(loadFixture("selling_plan_group_create.json"))))
+
+	percentage := 10.0
+	amount := decimal.NewFromFloat(2.5)
+	group, err := client.SellingPlanGroup.Create(SellingPlanGroup{
+		Name:         "Subscribe and save",
+		MerchantCode: "subscribe",
+		Options:      []string{"Delivery every"},
+		SellingPlans: []SellingPlan{{
+			Name:           "Monthly",
+			Options:        []string{"Month"},
+			Category:       SellingPlanCategorySubscription,
+			BillingPolicy:  &SellingPlanRecurringPolicy{Interval: SellingPlanIntervalMonth, IntervalCount: 1},
+			DeliveryPolicy: &SellingPlanRecurringPolicy{Interval: SellingPlanIntervalMonth, IntervalCount: 1},
+			PricingPolicies: []SellingPlanPricingPolicy{
+				{AdjustmentType: SellingPlanAdjustmentPercentage, Percentage: &percentage},
+				{AdjustmentType: SellingPlanAdjustmentFixedAmount, Amount: &amount},
+			},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("SellingPlanGroup.Create returned error: %v", err)
+	}
+	sellingPlanGroupTests(t, *group)
+
+	recurring := map[string]interface{}{"recurring": map[string]interface{}{"interval": "MONTH", "intervalCount": float64(1)}}
+	expectedInput := map[string]interface{}{
+		"name":         "Subscribe and save",
+		"merchantCode": "subscribe",
+		"options":      []interface{}{"Delivery every"},
+		"sellingPlansToCreate": []interface{}{map[string]interface{}{
+			"name":           "Monthly",
+			"options":        []interface{}{"Month"},
+			"category":       "SUBSCRIPTION",
+			"billingPolicy":  recurring,
+			"deliveryPolicy": recurring,
+			"pricingPolicies": []interface{}{
+				map[string]interface{}{"fixed": map[string]interface{}{
+					"adjustmentType":  "PERCENTAGE",
+					"adjustmentValue": map[string]interface{}{"percentage": float64(10)},
+				}},
+				map[string]interface{}{"fixed": map[string]interface{}{
+					"adjustmentType":  "FIXED_AMOUNT",
+					"adjustmentValue": map[string]interface{}{"fixedValue": "2.5"},
+				}},
+			},
+		}},
+	}
+	if input := requests[0].Variables.(map[string]interface{})["input"]; !reflect.DeepEqual(input, expectedInput) {
+		t.Errorf("SellingPlanGroup.Create sent %+v, expected %+v", input, expectedInput)
+	}
+}
+
+func TestSellingPlanGroupUpdate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests, string(loadFixture("selling_plan_group_update.json"))))
+
+	_, err := client.SellingPlanGroup.Update(SellingPlanGroup{
+		ID:           "gid://shopify/SellingPlanGroup/1",
+		Name:         "Subscribe and save",
+		MerchantCode: "subscribe",
+		Options:      []string{"Delivery every"},
+		SellingPlans: []SellingPlan{
+			{ID: "gid://shopify/SellingPlan/2", Name: "Monthly", Options: []string{"Month"}},
+			{Name: "Weekly", Options: []string{"Week"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("SellingPlanGroup.Update returned error: %v", err)
+	}
+
+	vars := requests[0].Variables.(map[string]interface{})
+	if vars["id"] != "gid://shopify/SellingPlanGroup/1" {
+		t.Errorf("SellingPlanGroup.Update sent id %v", vars["id"])
+	}
+	input := vars["input"].(map[string]interface{})
+	expectedCreate := []interface{}{map[string]interface{}{"name": "Weekly", "options": []interface{}{"Week"}}}
+	expectedUpdate := []interface{}{map[string]interface{}{"id": "gid://shopify/SellingPlan/2", "name": "Monthly", "options": []interface{}{"Month"}}}
+	if !reflect.DeepEqual(input["sellingPlansToCreate"], expectedCreate) {
+		t.Errorf("SellingPlanGroup.Update created %+v, expected %+v", input["sellingPlansToCreate"], expectedCreate)
+	}
+	if !reflect.DeepEqual(input["sellingPlansToUpdate"], expectedUpdate) {
+		t.Errorf("SellingPlanGroup.Update updated %+v, expected %+v", input["sellingPlansToUpdate"], expectedUpdate)
+	}
+}
+
+func TestSellingPlanGroupDeletePlans(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests, `{"data":{"sellingPlanGroupUpdate":{"deletedSellingPlanIds":["gid://shopify/SellingPlan/2"],"userErrors":[]}}}`))
+
+	err := client.SellingPlanGroup.DeletePlans("gid://shopify/SellingPlanGroup/1", "gid://shopify/SellingPlan/2")
+	if err != nil {
+		t.Fatalf("SellingPlanGroup.DeletePlans returned error: %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"id":    "gid://shopify/SellingPlanGroup/1",
+		"input": map[string]interface{}{"sellingPlansToDelete": []interface{}{"gid://shopify/SellingPlan/2"}},
+	}
+	if !reflect.DeepEqual(requests[0].Variables, expected) {
+		t.Errorf("SellingPlanGroup.DeletePlans sent %+v, expected %+v", requests[0].Variables, expected)
+	}
+}
+
+func TestSellingPlanGroupDelete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests, `{"data":{"sellingPlanGroupDelete":{"deletedSellingPlanGroupId":null,"userErrors":[{"field":["id"],"message":"Selling plan group does not exist."}]}}}`))
+
+	err := client.SellingPlanGroup.Delete("gid://shopify/SellingPlanGroup/9")
+	if err == nil || err.Error() != "id: Selling plan group does not exist." {
+		t.Errorf("SellingPlanGroup.Delete returned %v, expected the user error", err)
+	}
+}
+
+func TestSellingPlanGroupProducts(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests,
+			`{"data":{"sellingPlanGroupAddProducts":{"sellingPlanGroup":{"id":"gid://shopify/SellingPlanGroup/1"},"userErrors":[]}}}`,
+			`{"data":{"sellingPlanGroupRemoveProducts":{"removedProductIds":["gid://shopify/Product/1"],"userErrors":[]}}}`,
+			`{"data":{"sellingPlanGroupAddProductVariants":{"sellingPlanGroup":{"id":"gid://shopify/SellingPlanGroup/1"},"userErrors":[]}}}`,
+			`{"data":{"sellingPlanGroupRemoveProductVariants":{"removedProductVariantIds":["gid://shopify/ProductVariant/3"],"userErrors":[]}}}`,
+		))
+
+	id := "gid://shopify/SellingPlanGroup/1"
+	if err := client.SellingPlanGroup.AddProducts(id, 1, 2); err != nil {
+		t.Errorf("SellingPlanGroup.AddProducts returned error: %v", err)
+	}
+	if err := client.SellingPlanGroup.RemoveProducts(id, 1); err != nil {
+		t.Errorf("SellingPlanGroup.RemoveProducts returned error: %v", err)
+	}
+	if err := client.SellingPlanGroup.AddVariants(id, 3); err != nil {
+		t.Errorf("SellingPlanGroup.AddVariants returned error: %v", err)
+	}
+	if err := client.SellingPlanGroup.RemoveVariants(id, 3); err != nil {
+		t.Errorf("SellingPlanGroup.RemoveVariants returned error: %v", err)
+	}
+
+	expected := []map[string]interface{}{
+		{"id": id, "productIds": []interface{}{"gid://shopify/Product/1", "gid://shopify/Product/2"}},
+		{"id": id, "productIds": []interface{}{"gid://shopify/Product/1"}},
+		{"id": id, "productVariantIds": []interface{}{"gid://shopify/ProductVariant/3"}},
+		{"id": id, "productVariantIds": []interface{}{"gid://shopify/ProductVariant/3"}},
+	}
+	if len(requests) != len(expected) {
+		t.Fatalf("SellingPlanGroup sent %d requests, expected %d", len(requests), len(expected))
+	}
+	for i := range expected {
+		if !reflect.DeepEqual(requests[i].Variables, expected[i]) {
+			t.Errorf("request %d sent %+v, expected %+v", i, requests[i].Variables, expected[i])
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package goshopify
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Token types an access token can be requested as by ExchangeSessionToken.
+// See: https://shopify.dev/apps/auth/get-access-tokens/token-exchange
+const (
+	OfflineAccessTokenType = "urn:shopify:params:oauth:token-type:offline-access-token"
+	OnlineAccessTokenType  = "urn:shopify:params:oauth:token-type:online-access-token"
+
+	tokenExchangeGrantType = "urn:ietf:params:oauth:grant-type:token-exchange"
+	idTokenType            = "urn:ietf:params:oauth:token-type:id_token"
+)
+
+// sessionTokenLeeway is the clock skew tolerated when checking the validity
+// period of a session token.
+const sessionTokenLeeway = 5 * time.Second
+
+// ErrInvalidSessionToken is returned, wrapped with the reason, for session
+// tokens that fail verification.
+var ErrInvalidSessionToken = errors.New("invalid session token")
+
+// SessionToken holds the claims of a session token, the JWT App Bridge
+// passes to the backend of an embedded app.
+// See: https://shopify.dev/apps/auth/oauth/session-tokens
+type SessionToken struct {
+	Issuer      string `json:"iss"`
+	Destination string `json:"dest"`
+	Audience    string `json:"aud"`
+	Subject     string `json:"sub"`
+	ExpiresAt   int64  `json:"exp"`
+	NotBefore   int64  `json:"nbf"`
+	IssuedAt    int64  `json:"iat"`
+	ID          string `json:"jti"`
+	SessionID   string `json:"sid"`
+
+	// raw is the token itself, which is what is exchanged
+	raw string
+}
+
+// ShopDomain returns the myshopify domain of the shop the token was issued
+// for, e.g. "theshop.myshopify.com".
+func (t *SessionToken) ShopDomain() string {
+	u, err := url.Parse(t.Destination)
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
+
+// VerifySessionToken verifies the signature of a session token with the app
+// secret, checks that it was issued for the app and a myshopify shop and
+// that it is valid at this moment.
+func (app App) VerifySessionToken(token string) (*SessionToken, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, sessionTokenError("malformed token")
+	}
+
+	header := struct {
+		Alg string `json:"alg"`
+	}{}
+	if err := decodeJWTSegment(parts[0], &header); err != nil || header.Alg != "HS256" {
+		return nil, sessionTokenError("unsupported signing algorithm")
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, sessionTokenError("malformed signature")
+	}
+	mac := hmac.New(sha256.New, []byte(app.ApiSecret))
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, sessionTokenError("signature does not match")
+	}
+
+	claims := &SessionToken{raw: token}
+	if err := decodeJWTSegment(parts[1], claims); err != nil {
+		return nil, sessionTokenError("malformed claims")
+	}
+
+	now := time.Now()
+	if now.After(time.Unix(claims.ExpiresAt, 0).Add(sessionTokenLeeway)) {
+		return nil, sessionTokenError("token expired")
+	}
+	if now.Before(time.Unix(claims.NotBefore, 0).Add(-sessionTokenLeeway)) {
+		return nil, sessionTokenError("token not valid yet")
+	}
+	if claims.Audience != app.ApiKey {
+		return nil, sessionTokenError("token was issued for another app")
+	}
+	if !ValidShopDomain(claims.ShopDomain()) {
+		return nil, sessionTokenError("token was issued for an invalid shop")
+	}
+	return claims, nil
+}
+
+// ExchangeSessionToken verifies a session token and exchanges it for an
+// access token of the given type, OfflineAccessTokenType or
+// OnlineAccessTokenType, for the shop it was issued for. This lets embedded
+// apps get an access token without redirecting through the oauth flow.
+func (app App) ExchangeSessionToken(sessionToken, requestedTokenType string) (*AccessToken, error) {
+	claims, err := app.VerifySessionToken(sessionToken)
+	if err != nil {
+		return nil, err
+	}
+
+	data := struct {
+		ClientId           string `json:"client_id"`
+		ClientSecret       string `json:"client_secret"`
+		GrantType          string `json:"grant_type"`
+		SubjectToken       string `json:"subject_token"`
+		SubjectTokenType   string `json:"subject_token_type"`
+		RequestedTokenType string `json:"requested_token_type"`
+	}{
+		ClientId:           app.ApiKey,
+		ClientSecret:       app.ApiSecret,
+		GrantType:          tokenExchangeGrantType,
+		SubjectToken:       claims.raw,
+		SubjectTokenType:   idTokenType,
+		RequestedTokenType: requestedTokenType,
+	}
+	return app.requestAccessToken(claims.ShopDomain(), data)
+}
+
+func decodeJWTSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func sessionTokenError(reason string) error {
+	return fmt.Errorf("%w: %s", ErrInvalidSessionToken, reason)
+}
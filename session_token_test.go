@@ -0,0 +1,137 @@
+package goshopify
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+// signSessionToken builds a session token for claims signed with secret.
+func signSessionToken(claims map[string]interface{}, alg, secret string) string {
+	header, _ := json.Marshal(map[string]string{"alg": alg, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unsigned))
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func sessionTokenClaims(overrides map[string]interface{}) map[string]interface{} {
+	now := time.Now().Unix()
+	claims := map[string]interface{}{
+		"iss":  "https://fooshop.myshopify.com/admin",
+		"dest": "https://fooshop.myshopify.com",
+		"aud":  app.ApiKey,
+		"sub":  "42",
+		"exp":  now + 60,
+		"nbf":  now - 1,
+		"iat":  now - 1,
+		"jti":  "f8912129-1af6-4cad-9ca3-76b0f7621087",
+		"sid":  "aaea182f2732d44c23057c0fea584021a4485b2bd25d3eb7fd349313ad24c685",
+	}
+	for k, v := range overrides {
+		claims[k] = v
+	}
+	return claims
+}
+
+func TestAppVerifySessionToken(t *testing.T) {
+	setup()
+	defer teardown()
+
+	token, err := app.VerifySessionToken(signSessionToken(sessionTokenClaims(nil), "HS256", app.ApiSecret))
+	if err != nil {
+		t.Fatalf("App.VerifySessionToken returned error: %v", err)
+	}
+	if token.ShopDomain() != "fooshop.myshopify.com" || token.Subject != "42" || token.Audience != app.ApiKey {
+		t.Errorf("App.VerifySessionToken returned %+v", token)
+	}
+
+	now := time.Now().Unix()
+	cases := []struct {
+		description string
+		token       string
+	}{
+		{"malformed", "not.a-token"},
+		{"other secret", signSessionToken(sessionTokenClaims(nil), "HS256", "other")},
+		{"other algorithm", signSessionToken(sessionTokenClaims(nil), "none", app.ApiSecret)},
+		{"expired", signSessionToken(sessionTokenClaims(map[string]interface{}{"exp": now - 60}), "HS256", app.ApiSecret)},
+		{"not valid yet", signSessionToken(sessionTokenClaims(map[string]interface{}{"nbf": now + 60}), "HS256", app.ApiSecret)},
+		{"other app", signSessionToken(sessionTokenClaims(map[string]interface{}{"aud": "otherkey"}), "HS256", app.ApiSecret)},
+		{"other shop", signSessionToken(sessionTokenClaims(map[string]interface{}{"dest": "https://evil.example.com"}), "HS256", app.ApiSecret)},
+	}
+
+	for _, c := range cases {
+		_, err := app.VerifySessionToken(c.token)
+		if !errors.Is(err, ErrInvalidSessionToken) {
+			t.Errorf("%s: App.VerifySessionToken returned %v, expected %v", c.description, err, ErrInvalidSessionToken)
+		}
+	}
+}
+
+func TestAppExchangeSessionToken(t *testing.T) {
+	setup()
+	defer teardown()
+
+	sessionToken := signSessionToken(sessionTokenClaims(nil), "HS256", app.ApiSecret)
+
+	httpmock.RegisterResponder("POST", "https://fooshop.myshopify.com/admin/oauth/access_token",
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := ioutil.ReadAll(req.Body)
+			data := map[string]string{}
+			if err := json.Unmarshal(body, &data); err != nil {
+				t.Fatalf("decoding token exchange: %v", err)
+			}
+			expected := map[string]string{
+				"client_id":            app.ApiKey,
+				"client_secret":        app.ApiSecret,
+				"grant_type":           "urn:ietf:params:oauth:grant-type:token-exchange",
+				"subject_token":        sessionToken,
+				"subject_token_type":   "urn:ietf:params:oauth:token-type:id_token",
+				"requested_token_type": OfflineAccessTokenType,
+			}
+			for k, v := range expected {
+				if data[k] != v {
+					t.Errorf("token exchange %s = %q, expected %q", k, data[k], v)
+				}
+			}
+			return httpmock.NewStringResponse(200, `{"access_token":"offlinetoken","scope":"write_products"}`), nil
+		})
+
+	app.Client = client
+	token, err := app.ExchangeSessionToken(sessionToken, OfflineAccessTokenType)
+	if err != nil {
+		t.Fatalf("App.ExchangeSessionToken returned error: %v", err)
+	}
+	if token.AccessToken != "offlinetoken" || token.Scope != "write_products" {
+		t.Errorf("App.ExchangeSessionToken returned %+v", token)
+	}
+}
+
+func TestAppExchangeSessionTokenErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	app.Client = client
+	_, err := app.ExchangeSessionToken(signSessionToken(sessionTokenClaims(nil), "HS256", "other"), OnlineAccessTokenType)
+	if !errors.Is(err, ErrInvalidSessionToken) {
+		t.Errorf("App.ExchangeSessionToken returned %v, expected %v", err, ErrInvalidSessionToken)
+	}
+
+	httpmock.RegisterResponder("POST", "https://fooshop.myshopify.com/admin/oauth/access_token",
+		httpmock.NewStringResponder(400, `{"error":"invalid_subject_token","error_description":"Token has been used"}`))
+	_, err = app.ExchangeSessionToken(signSessionToken(sessionTokenClaims(nil), "HS256", app.ApiSecret), OnlineAccessTokenType)
+	if err == nil || !strings.Contains(err.Error(), "invalid_subject_token") {
+		t.Errorf("App.ExchangeSessionToken returned %v, expected invalid_subject_token", err)
+	}
+}
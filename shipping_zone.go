@@ -91,7 +91,5 @@ type ShippingZonesResource struct {
 
 // List shipping zones
 func (s *ShippingZoneServiceOp) List() ([]ShippingZone, error) {
-	resource := new(ShippingZonesResource)
-	err := s.client.Get("shipping_zones.json", resource, nil)
-	return resource.ShippingZones, err
+	return listResource[ShippingZone](s.client, "shipping_zones.json", "shipping_zones", nil)
 }
@@ -9,6 +9,14 @@ import (
 // See: https://help.shopify.com/api/reference/shop
 type ShopService interface {
 	Get(options interface{}) (*Shop, error)
+
+	// Metafields owned by the shop itself, e.g. app-level configuration
+	ListMetafields(interface{}) ([]Metafield, error)
+	CountMetafields(interface{}) (int, error)
+	GetMetafield(int64, interface{}) (*Metafield, error)
+	CreateMetafield(Metafield) (*Metafield, error)
+	UpdateMetafield(Metafield) (*Metafield, error)
+	DeleteMetafield(int64) error
 }
 
 // ShopServiceOp handles communication with the shop related methods of the
@@ -77,7 +85,41 @@ type ShopResource struct {
 
 // Get shop
 func (s *ShopServiceOp) Get(options interface{}) (*Shop, error) {
-	resource := new(ShopResource)
-	err := s.client.Get("shop.json", resource, options)
-	return resource.Shop, err
+	return getResource[Shop](s.client, "shop.json", "shop", options)
+}
+
+// List metafields for the shop
+func (s *ShopServiceOp) ListMetafields(options interface{}) ([]Metafield, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client}
+	return metafieldService.List(options)
+}
+
+// Count metafields for the shop
+func (s *ShopServiceOp) CountMetafields(options interface{}) (int, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client}
+	return metafieldService.Count(options)
+}
+
+// Get individual metafield for the shop
+func (s *ShopServiceOp) GetMetafield(metafieldID int64, options interface{}) (*Metafield, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client}
+	return metafieldService.Get(metafieldID, options)
+}
+
+// Create a new metafield for the shop
+func (s *ShopServiceOp) CreateMetafield(metafield Metafield) (*Metafield, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client}
+	return metafieldService.Create(metafield)
+}
+
+// Update an existing metafield for the shop
+func (s *ShopServiceOp) UpdateMetafield(metafield Metafield) (*Metafield, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client}
+	return metafieldService.Update(metafield)
+}
+
+// Delete an existing metafield for the shop
+func (s *ShopServiceOp) DeleteMetafield(metafieldID int64) error {
+	metafieldService := &MetafieldServiceOp{client: s.client}
+	return metafieldService.Delete(metafieldID)
 }
@@ -0,0 +1,312 @@
+package shopifytest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	goshopify "github.com/myhelix/go-shopify"
+)
+
+// Mode tells a Recorder whether to record interactions with the Admin API or
+// replay recorded ones.
+type Mode int
+
+const (
+	// Replaying answers requests from the fixture file without any network
+	// access.
+	Replaying Mode = iota
+	// Recording sends requests to the Admin API and keeps the interactions
+	// for Save.
+	Recording
+)
+
+// Redacted replaces credentials in recorded interactions.
+const Redacted = "REDACTED"
+
+// credentialHeaders are the request headers whose values are redacted from
+// the recorded interactions, they are never recorded themselves.
+var credentialHeaders = []string{
+	"X-Shopify-Access-Token",
+	"X-Shopify-Storefront-Access-Token",
+	"Authorization",
+}
+
+// secretBodyRegex matches the secrets sent and received by the OAuth and
+// token exchange endpoints, like the client redacts them from logged bodies.
+var secretBodyRegex = regexp.MustCompile(`("(?:access_token|client_secret|subject_token|password)"\s*:\s*")[^"]*"`)
+
+// dropHeaders are response headers that aren't recorded.
+var dropHeaders = []string{"Set-Cookie", "Date"}
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a recorded request, its URL is relative to the shop,
+// e.g. "/admin/api/2021-01/products.json?limit=5".
+type RecordedRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+// RecordedResponse is a recorded response.
+type RecordedResponse struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body"`
+}
+
+// Recorder is a transport recording the interactions of a client with the
+// Admin API to a fixture file and replaying them in later runs, so tests get
+// realistic responses without hand written fixtures. Access tokens and
+// private app credentials are redacted from the file.
+//
+//	mode := shopifytest.Replaying
+//	if os.Getenv("SHOPIFY_RECORD") != "" {
+//		mode = shopifytest.Recording
+//	}
+//	rec, err := shopifytest.NewRecorder("testdata/products.json", mode)
+//	...
+//	defer rec.Save()
+//	client := rec.Client(app, "shopname", os.Getenv("SHOPIFY_TOKEN"))
+//
+// Requests are replayed by method and URL, ignoring the shop's domain. A
+// request recorded several times is answered with its responses in order.
+type Recorder struct {
+	// Transport sends the requests while recording, http.DefaultTransport
+	// when nil.
+	Transport http.RoundTripper
+
+	path string
+	mode Mode
+
+	mu           sync.Mutex
+	interactions []Interaction
+	replayed     []bool
+	secrets      []string
+}
+
+// NewRecorder returns a recorder for the fixture file at path. When
+// replaying, the file is read and must exist.
+func NewRecorder(path string, mode Mode) (*Recorder, error) {
+	r := &Recorder{path: path, mode: mode}
+	if mode == Recording {
+		return r, nil
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("shopifytest: reading fixture: %w", err)
+	}
+	var fixture struct {
+		Interactions []Interaction `json:"interactions"`
+	}
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		return nil, fmt.Errorf("shopifytest: decoding fixture %s: %w", path, err)
+	}
+	r.interactions = fixture.Interactions
+	r.replayed = make([]bool, len(r.interactions))
+	return r, nil
+}
+
+// Redact replaces the secrets, e.g. the shop's domain or a customer's email,
+// with Redacted in the interactions recorded from then on.
+func (r *Recorder) Redact(secrets ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.addSecrets(secrets...)
+}
+
+// Client returns a client for the shop using the recorder as transport. The
+// options are applied after the transport is set.
+func (r *Recorder) Client(app goshopify.App, shopName, token string, opts ...goshopify.Option) *goshopify.Client {
+	return goshopify.NewClient(app, shopName, token, append([]goshopify.Option{goshopify.WithTransport(r)}, opts...)...)
+}
+
+// Interactions returns the interactions recorded or read from the fixture.
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Interaction(nil), r.interactions...)
+}
+
+// Save writes the recorded interactions to the fixture file, creating its
+// directory. It does nothing when replaying.
+func (r *Recorder) Save() error {
+	if r.mode != Recording {
+		return nil
+	}
+
+	r.mu.Lock()
+	fixture := struct {
+		Interactions []Interaction `json:"interactions"`
+	}{r.interactions}
+	data, err := json.MarshalIndent(fixture, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("shopifytest: encoding fixture: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("shopifytest: writing fixture: %w", err)
+	}
+	if err := ioutil.WriteFile(r.path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("shopifytest: writing fixture: %w", err)
+	}
+	return nil
+}
+
+// RoundTrip records or replays the request.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.mode == Recording {
+		return r.record(req)
+	}
+	return r.replay(req)
+}
+
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		reqBody, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req = req.Clone(req.Context())
+		req.Body = ioutil.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	header := resp.Header.Clone()
+	for _, name := range dropHeaders {
+		header.Del(name)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, name := range credentialHeaders {
+		value := req.Header.Get(name)
+		r.addSecrets(value, strings.TrimPrefix(value, "Basic "))
+	}
+	if password, ok := req.URL.User.Password(); ok {
+		r.addSecrets(password)
+	}
+
+	for name, values := range header {
+		for i, value := range values {
+			header[name][i] = r.redact(value)
+		}
+	}
+	r.interactions = append(r.interactions, Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			URL:    r.redact(requestKey(req.URL)),
+			Body:   redactBody(r.redact(string(reqBody))),
+		},
+		Response: RecordedResponse{
+			Status: resp.StatusCode,
+			Header: header,
+			Body:   redactBody(r.redact(string(respBody))),
+		},
+	})
+	return resp, nil
+}
+
+// redactBody redacts the OAuth and token exchange secrets of a body.
+func redactBody(body string) string {
+	return secretBodyRegex.ReplaceAllString(body, `${1}`+Redacted+`"`)
+}
+
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := r.redact(requestKey(req.URL))
+	for i, interaction := range r.interactions {
+		if r.replayed[i] || interaction.Request.Method != req.Method || interaction.Request.URL != key {
+			continue
+		}
+		r.replayed[i] = true
+
+		header := interaction.Response.Header.Clone()
+		if header == nil {
+			header = http.Header{}
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.Status, http.StatusText(interaction.Response.Status)),
+			StatusCode:    interaction.Response.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          ioutil.NopCloser(strings.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("shopifytest: no recorded interaction left for %s %s in %s", req.Method, key, r.path)
+}
+
+// addSecrets adds the non empty secrets, it must be called with the lock
+// held.
+func (r *Recorder) addSecrets(secrets ...string) {
+	for _, secret := range secrets {
+		if secret == "" {
+			continue
+		}
+		known := false
+		for _, s := range r.secrets {
+			known = known || s == secret
+		}
+		if !known {
+			r.secrets = append(r.secrets, secret)
+		}
+	}
+}
+
+// redact replaces the secrets in s, it must be called with the lock held.
+func (r *Recorder) redact(s string) string {
+	for _, secret := range r.secrets {
+		s = strings.ReplaceAll(s, secret, Redacted)
+	}
+	return s
+}
+
+// requestKey is the URL without scheme and host, with the query parameters
+// sorted so replaying doesn't depend on their order.
+func requestKey(u *url.URL) string {
+	key := u.EscapedPath()
+	if u.RawQuery != "" {
+		key += "?" + u.Query().Encode()
+	}
+	return key
+}
@@ -0,0 +1,125 @@
+package shopifytest
+
+import (
+	"io/ioutil"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	goshopify "github.com/myhelix/go-shopify"
+)
+
+// recordPages records listing pages and creating a storefront access token
+// on a fake server to the fixture at path.
+func recordPages(t *testing.T, path string) {
+	srv := NewServer()
+	defer srv.Close()
+	srv.SetResources("pages", "pages", []goshopify.Page{{ID: 1, Title: "One"}, {ID: 2, Title: "Two"}, {ID: 3, Title: "Three"}})
+	srv.Handle("POST", "storefront_access_tokens", 200, `{"storefront_access_token": {"access_token": "shpat_new", "title": "Storefront"}}`)
+
+	rec, err := NewRecorder(path, Recording)
+	if err != nil {
+		t.Fatalf("NewRecorder returned error: %v", err)
+	}
+	target, _ := url.Parse(srv.URL)
+	rec.Transport = &rewriteTransport{target: target, next: srv.server.Client().Transport}
+	rec.Redact("Three")
+
+	client := rec.Client(goshopify.App{}, "fooshop", "shpat_secret", goshopify.WithVersion("2021-01"))
+	pages, _, err := client.Page.ListWithPagination(goshopify.ListOptions{Limit: 2})
+	if err != nil || len(pages) != 2 {
+		t.Fatalf("Page.ListWithPagination returned %+v, %v while recording", pages, err)
+	}
+	if _, _, err := client.Page.ListWithPagination(goshopify.ListOptions{Limit: 2, PageInfo: "2"}); err != nil {
+		t.Fatalf("Page.ListWithPagination returned error while recording: %v", err)
+	}
+	if _, err := client.StorefrontAccessToken.Create(goshopify.StorefrontAccessToken{Title: "Storefront"}); err != nil {
+		t.Fatalf("StorefrontAccessToken.Create returned error while recording: %v", err)
+	}
+
+	if err := rec.Save(); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fixtures", "pages.json")
+	recordPages(t, path)
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("the fixture wasn't written: %v", err)
+	}
+	for _, secret := range []string{"shpat_secret", "shpat_new", "Three"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("the fixture contains %q:\n%s", secret, data)
+		}
+	}
+
+	rec, err := NewRecorder(path, Replaying)
+	if err != nil {
+		t.Fatalf("NewRecorder returned error: %v", err)
+	}
+	if len(rec.Interactions()) != 3 {
+		t.Fatalf("the fixture has %d interactions, expected 3", len(rec.Interactions()))
+	}
+
+	client := rec.Client(goshopify.App{}, "othershop", "", goshopify.WithVersion("2021-01"))
+	pages, pagination, err := client.Page.ListWithPagination(goshopify.ListOptions{Limit: 2})
+	if err != nil {
+		t.Fatalf("Page.ListWithPagination returned error while replaying: %v", err)
+	}
+	if len(pages) != 2 || pages[1].Title != "Two" {
+		t.Errorf("Page.ListWithPagination replayed %+v", pages)
+	}
+	if pagination == nil || pagination.NextPageOptions == nil || pagination.NextPageOptions.PageInfo != "2" {
+		t.Fatalf("Page.ListWithPagination replayed pagination %+v", pagination)
+	}
+
+	pages, _, err = client.Page.ListWithPagination(pagination.NextPageOptions)
+	if err != nil || len(pages) != 1 || pages[0].Title != Redacted {
+		t.Errorf("Page.ListWithPagination replayed %+v, %v for the next page", pages, err)
+	}
+
+	token, err := client.StorefrontAccessToken.Create(goshopify.StorefrontAccessToken{Title: "Storefront"})
+	if err != nil || token.AccessToken != Redacted {
+		t.Errorf("StorefrontAccessToken.Create replayed %+v, %v", token, err)
+	}
+
+	if _, _, err := client.Page.ListWithPagination(goshopify.ListOptions{Limit: 2}); err == nil {
+		t.Error("Page.ListWithPagination replayed an interaction twice")
+	}
+}
+
+func TestRecorderRedactsRequestBodies(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	srv.Handle("POST", "storefront_access_tokens", 200, `{}`)
+
+	path := filepath.Join(t.TempDir(), "token.json")
+	rec, err := NewRecorder(path, Recording)
+	if err != nil {
+		t.Fatalf("NewRecorder returned error: %v", err)
+	}
+	target, _ := url.Parse(srv.URL)
+	rec.Transport = &rewriteTransport{target: target, next: srv.server.Client().Transport}
+
+	client := rec.Client(goshopify.App{}, "fooshop", "shpat_secret", goshopify.WithVersion("2021-01"))
+	data := map[string]string{"client_secret": "hush", "subject_token": "header.claims.signature"}
+	if err := client.Post("storefront_access_tokens.json", data, nil); err != nil {
+		t.Fatalf("Client.Post returned error while recording: %v", err)
+	}
+
+	body := rec.Interactions()[0].Request.Body
+	expected := `{"client_secret":"` + Redacted + `","subject_token":"` + Redacted + `"}`
+	if body != expected {
+		t.Errorf("the recorded request body is %s, expected %s", body, expected)
+	}
+}
+
+func TestRecorderMissingFixture(t *testing.T) {
+	if _, err := NewRecorder(filepath.Join(t.TempDir(), "missing.json"), Replaying); err == nil {
+		t.Error("NewRecorder returned no error for a missing fixture")
+	}
+}
@@ -0,0 +1,273 @@
+// Package shopifytest provides a fake Shopify Admin API server for testing
+// apps built with goshopify without mocking the http client.
+//
+// The server serves canned REST resources with pagination Link headers, can
+// be told to rate limit requests and records every request it receives. Its
+// Client method returns a goshopify client talking to it:
+//
+//	srv := shopifytest.NewServer()
+//	defer srv.Close()
+//	srv.SetResources("products", "products", []goshopify.Product{{ID: 1}, {ID: 2}})
+//	client := srv.Client(goshopify.App{}, "fooshop", "token")
+//	products, err := client.Product.List(nil)
+package shopifytest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	goshopify "github.com/myhelix/go-shopify"
+)
+
+// defaultPageSize is the page size of lists requested without a limit, like
+// Shopify's.
+const defaultPageSize = 50
+
+// apiPathRegex matches the prefix of Admin API paths, versioned or not.
+var apiPathRegex = regexp.MustCompile(`^/admin/(api/[^/]+/)?`)
+
+// Request is a request received by the server.
+type Request struct {
+	Method string
+	// Path is relative to the API version, e.g. "products/1.json"
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+// Server is a fake Shopify Admin API server. Requests for paths it has no
+// resource or response for are answered with 404.
+type Server struct {
+	// URL of the server, e.g. http://127.0.0.1:1234
+	URL string
+
+	server *httptest.Server
+
+	mu          sync.Mutex
+	lists       map[string]list
+	resources   map[string]resource
+	responses   map[string]response
+	rateLimited int
+	retryAfter  time.Duration
+	requests    []Request
+}
+
+type list struct {
+	key   string
+	items []json.RawMessage
+}
+
+type resource struct {
+	key  string
+	item json.RawMessage
+}
+
+type response struct {
+	status int
+	body   string
+}
+
+// NewServer starts a fake Shopify server, Close it when done.
+func NewServer() *Server {
+	s := &Server{
+		lists:     map[string]list{},
+		resources: map[string]resource{},
+		responses: map[string]response{},
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.server.URL
+	return s
+}
+
+// Close shuts the server down.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Client returns a client for the shop that sends every request to the
+// server, whatever the shop's domain. The options are applied after the
+// server's transport is set.
+func (s *Server) Client(app goshopify.App, shopName, token string, opts ...goshopify.Option) *goshopify.Client {
+	target, _ := url.Parse(s.URL)
+	transport := &rewriteTransport{target: target, next: s.server.Client().Transport}
+	return goshopify.NewClient(app, shopName, token, append([]goshopify.Option{goshopify.WithTransport(transport)}, opts...)...)
+}
+
+// SetResources serves items, a slice of resources, at path, e.g. "products"
+// or "products/1/metafields", wrapped in key, e.g. "products". Lists are
+// paginated by the limit and page_info parameters and their count is served
+// at path/count.json.
+func (s *Server) SetResources(path, key string, items interface{}) {
+	v := reflect.ValueOf(items)
+	if v.Kind() != reflect.Slice {
+		panic("shopifytest: SetResources needs a slice of items")
+	}
+
+	l := list{key: key}
+	for i := 0; i < v.Len(); i++ {
+		l.items = append(l.items, mustMarshal(v.Index(i).Interface()))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lists[strings.Trim(path, "/")] = l
+}
+
+// SetResource serves a single resource at path, e.g. "products/1" or "shop",
+// wrapped in key, e.g. "product".
+func (s *Server) SetResource(path, key string, item interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.resources[strings.Trim(path, "/")] = resource{key: key, item: mustMarshal(item)}
+}
+
+// Handle answers requests with the method for path, e.g. "orders/1/close",
+// with the status and body. It takes precedence over resources.
+func (s *Server) Handle(method, path string, status int, body string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.responses[method+" "+strings.Trim(path, "/")] = response{status: status, body: body}
+}
+
+// RateLimit answers the next n requests with 429 Too Many Requests and a
+// Retry-After header of retryAfter.
+func (s *Server) RateLimit(n int, retryAfter time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rateLimited = n
+	s.retryAfter = retryAfter
+}
+
+// Requests returns the requests the server received, in order.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	p := strings.TrimSuffix(apiPathRegex.ReplaceAllString(r.URL.Path, ""), ".json")
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{
+		Method: r.Method,
+		Path:   p + ".json",
+		Query:  r.URL.Query(),
+		Header: r.Header.Clone(),
+		Body:   body,
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Shopify-Shop-Api-Call-Limit", "1/40")
+
+	if s.rateLimited > 0 {
+		s.rateLimited--
+		retryAfter := s.retryAfter
+		s.mu.Unlock()
+		w.Header().Set("Retry-After", strconv.FormatFloat(retryAfter.Seconds(), 'f', -1, 64))
+		writeJSON(w, http.StatusTooManyRequests, `{"errors":"Exceeded 2 calls per second for api client. Reduce request rates to resume uninterrupted service."}`)
+		return
+	}
+
+	resp, hasResponse := s.responses[r.Method+" "+p]
+	l, hasList := s.lists[p]
+	counted, hasCount := s.lists[strings.TrimSuffix(p, "/count")]
+	res, hasResource := s.resources[p]
+	s.mu.Unlock()
+
+	switch {
+	case hasResponse:
+		writeJSON(w, resp.status, resp.body)
+	case r.Method == http.MethodGet && hasList:
+		s.writePage(w, r, p, l)
+	case r.Method == http.MethodGet && strings.HasSuffix(p, "/count") && hasCount:
+		writeJSON(w, http.StatusOK, fmt.Sprintf(`{"count":%d}`, len(counted.items)))
+	case r.Method == http.MethodGet && hasResource:
+		writeJSON(w, http.StatusOK, fmt.Sprintf(`{%q:%s}`, res.key, res.item))
+	default:
+		writeJSON(w, http.StatusNotFound, `{"errors":"Not Found"}`)
+	}
+}
+
+// writePage writes the page of the list requested by the limit and page_info
+// parameters, with Link headers pointing at the previous and next pages. The
+// page info is the offset of the page.
+func (s *Server) writePage(w http.ResponseWriter, r *http.Request, p string, l list) {
+	query := r.URL.Query()
+	limit, err := strconv.Atoi(query.Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = defaultPageSize
+	}
+	offset, _ := strconv.Atoi(query.Get("page_info"))
+	if offset < 0 || offset > len(l.items) {
+		offset = len(l.items)
+	}
+	end := offset + limit
+	if end > len(l.items) {
+		end = len(l.items)
+	}
+
+	pageLink := func(offset int, rel string) string {
+		u := url.URL{Scheme: "https", Host: r.Host, Path: r.URL.Path}
+		u.RawQuery = url.Values{"limit": {strconv.Itoa(limit)}, "page_info": {strconv.Itoa(offset)}}.Encode()
+		return fmt.Sprintf(`<%s>; rel="%s"`, u.String(), rel)
+	}
+	var links []string
+	if offset > 0 {
+		previous := offset - limit
+		if previous < 0 {
+			previous = 0
+		}
+		links = append(links, pageLink(previous, "previous"))
+	}
+	if end < len(l.items) {
+		links = append(links, pageLink(end, "next"))
+	}
+	if len(links) > 0 {
+		w.Header().Set("Link", strings.Join(links, ", "))
+	}
+
+	items := make([]string, 0, end-offset)
+	for _, item := range l.items[offset:end] {
+		items = append(items, string(item))
+	}
+	writeJSON(w, http.StatusOK, fmt.Sprintf(`{%q:[%s]}`, l.key, strings.Join(items, ",")))
+}
+
+func writeJSON(w http.ResponseWriter, status int, body string) {
+	w.WriteHeader(status)
+	w.Write([]byte(body))
+}
+
+func mustMarshal(v interface{}) json.RawMessage {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("shopifytest: %v", err))
+	}
+	return data
+}
+
+// rewriteTransport sends every request to the target server.
+type rewriteTransport struct {
+	target *url.URL
+	next   http.RoundTripper
+}
+
+func (t *rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,129 @@
+package shopifytest
+
+import (
+	"testing"
+	"time"
+
+	goshopify "github.com/myhelix/go-shopify"
+)
+
+func TestServerResources(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	srv.SetResources("products", "products", []goshopify.Product{{ID: 1, Title: "One"}, {ID: 2, Title: "Two"}, {ID: 3, Title: "Three"}})
+	srv.SetResource("products/2", "product", goshopify.Product{ID: 2, Title: "Two"})
+
+	client := srv.Client(goshopify.App{}, "fooshop", "token", goshopify.WithVersion("2022-01"))
+
+	product, err := client.Product.Get(2, nil)
+	if err != nil {
+		t.Fatalf("Product.Get returned error: %v", err)
+	}
+	if product.ID != 2 || product.Title != "Two" {
+		t.Errorf("Product.Get returned %+v", product)
+	}
+
+	count, err := client.Product.Count(nil)
+	if err != nil || count != 3 {
+		t.Errorf("Product.Count returned %d, %v, expected 3", count, err)
+	}
+
+	if _, err := client.Product.Get(4, nil); err == nil {
+		t.Error("Product.Get returned no error for a product the server doesn't have")
+	}
+
+	requests := srv.Requests()
+	if len(requests) != 3 {
+		t.Fatalf("Requests returned %d requests, expected 3", len(requests))
+	}
+	if requests[0].Method != "GET" || requests[0].Path != "products/2.json" || requests[0].Header.Get("X-Shopify-Access-Token") != "token" {
+		t.Errorf("Requests returned %+v", requests[0])
+	}
+}
+
+func TestServerPagination(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	var products []goshopify.Product
+	for i := int64(1); i <= 5; i++ {
+		products = append(products, goshopify.Product{ID: i})
+	}
+	srv.SetResources("products", "products", products)
+
+	client := srv.Client(goshopify.App{}, "fooshop", "token")
+
+	page, pagination, err := client.Product.ListWithPagination(&goshopify.ProductListOptions{ListOptions: goshopify.ListOptions{Limit: 2}})
+	if err != nil {
+		t.Fatalf("Product.ListWithPagination returned error: %v", err)
+	}
+	if len(page) != 2 || page[0].ID != 1 || pagination.PreviousPageOptions != nil || pagination.NextPageOptions == nil {
+		t.Fatalf("first page returned %+v, %+v", page, pagination)
+	}
+
+	page, pagination, err = client.Product.ListWithPagination(&goshopify.ProductListOptions{ListOptions: *pagination.NextPageOptions})
+	if err != nil {
+		t.Fatalf("Product.ListWithPagination returned error: %v", err)
+	}
+	if len(page) != 2 || page[0].ID != 3 || pagination.PreviousPageOptions == nil || pagination.NextPageOptions == nil {
+		t.Fatalf("second page returned %+v, %+v", page, pagination)
+	}
+
+	page, pagination, err = client.Product.ListWithPagination(&goshopify.ProductListOptions{ListOptions: *pagination.NextPageOptions})
+	if err != nil {
+		t.Fatalf("Product.ListWithPagination returned error: %v", err)
+	}
+	if len(page) != 1 || page[0].ID != 5 || pagination.NextPageOptions != nil {
+		t.Fatalf("last page returned %+v, %+v", page, pagination)
+	}
+
+	all, err := client.Product.List(nil)
+	if err != nil || len(all) != 5 {
+		t.Errorf("Product.List returned %d products, %v, expected 5", len(all), err)
+	}
+}
+
+func TestServerHandle(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	srv.Handle("POST", "orders/1/close", 200, `{"order":{"id":1,"closed_at":"2022-01-01T10:00:00Z"}}`)
+	srv.Handle("DELETE", "products/1", 422, `{"errors":"can't delete"}`)
+
+	client := srv.Client(goshopify.App{}, "fooshop", "token")
+
+	order, err := client.Order.Close(1)
+	if err != nil || order.ClosedAt == nil {
+		t.Errorf("Order.Close returned %+v, %v", order, err)
+	}
+
+	err = client.Product.Delete(1)
+	if responseError, ok := err.(goshopify.ResponseError); !ok || responseError.Status != 422 {
+		t.Errorf("Product.Delete returned %v, expected a 422 ResponseError", err)
+	}
+}
+
+func TestServerRateLimit(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	srv.SetResource("shop", "shop", goshopify.Shop{ID: 1})
+	srv.RateLimit(2, 10*time.Millisecond)
+
+	client := srv.Client(goshopify.App{}, "fooshop", "token", goshopify.WithRetry(3))
+	shop, err := client.Shop.Get(nil)
+	if err != nil || shop.ID != 1 {
+		t.Fatalf("Shop.Get returned %+v, %v", shop, err)
+	}
+	if n := len(srv.Requests()); n != 3 {
+		t.Errorf("the server received %d requests, expected 2 rate limited ones and a retry", n)
+	}
+
+	srv.RateLimit(1, 10*time.Millisecond)
+	client = srv.Client(goshopify.App{}, "fooshop", "token")
+	_, err = client.Shop.Get(nil)
+	if _, ok := err.(goshopify.RateLimitError); !ok {
+		t.Errorf("Shop.Get returned %v, expected a RateLimitError", err)
+	}
+}
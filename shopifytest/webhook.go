@@ -0,0 +1,46 @@
+package shopifytest
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+)
+
+// NewWebhookRequest returns a webhook delivery of the payload for the topic,
+// e.g. "orders/create", signed with the app secret like Shopify does. Pass it
+// to the ServeHTTP method of the handler under test.
+func NewWebhookRequest(secret, topic, shopDomain string, payload interface{}) *http.Request {
+	body := mustMarshal(payload)
+	req := httptest.NewRequest("POST", "/webhooks", bytes.NewReader(body))
+	signWebhook(req, secret, topic, shopDomain, body)
+	return req
+}
+
+// SendWebhook posts a signed webhook delivery of the payload for the topic to
+// the url, e.g. of the app's test server.
+func SendWebhook(ctx context.Context, url, secret, topic, shopDomain string, payload interface{}) (*http.Response, error) {
+	body := mustMarshal(payload)
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	signWebhook(req, secret, topic, shopDomain, body)
+	return http.DefaultClient.Do(req)
+}
+
+func signWebhook(req *http.Request, secret, topic, shopDomain string, body json.RawMessage) {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Shopify-Hmac-Sha256", base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+	req.Header.Set("X-Shopify-Topic", topic)
+	req.Header.Set("X-Shopify-Shop-Domain", shopDomain)
+	req.Header.Set("X-Shopify-Webhook-Id", "shopifytest")
+	req.Header.Set("X-Shopify-API-Version", "unstable")
+}
@@ -0,0 +1,55 @@
+package shopifytest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	goshopify "github.com/myhelix/go-shopify"
+)
+
+func TestNewWebhookRequest(t *testing.T) {
+	app := goshopify.App{ApiSecret: "hush"}
+
+	var received goshopify.Order
+	router := goshopify.NewWebhookRouter(app)
+	goshopify.HandleWebhook(router, "orders/create", func(e goshopify.WebhookEvent, o goshopify.Order) error {
+		received = o
+		return nil
+	})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, NewWebhookRequest("hush", "orders/create", "fooshop.myshopify.com", goshopify.Order{ID: 1}))
+	if rec.Code != http.StatusOK || received.ID != 1 {
+		t.Errorf("the signed delivery was answered with %d and decoded as %+v", rec.Code, received)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, NewWebhookRequest("other", "orders/create", "fooshop.myshopify.com", goshopify.Order{ID: 1}))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("a delivery signed with another secret was answered with %d, expected 401", rec.Code)
+	}
+}
+
+func TestSendWebhook(t *testing.T) {
+	app := goshopify.App{ApiSecret: "hush"}
+
+	var topic string
+	router := goshopify.NewWebhookRouter(app)
+	router.HandleDefault(func(e goshopify.WebhookEvent) error {
+		topic = e.Topic
+		return nil
+	})
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	resp, err := SendWebhook(context.Background(), server.URL, "hush", "app/uninstalled", "fooshop.myshopify.com", goshopify.Shop{ID: 1})
+	if err != nil {
+		t.Fatalf("SendWebhook returned error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || topic != "app/uninstalled" {
+		t.Errorf("SendWebhook was answered with %d for topic %q", resp.StatusCode, topic)
+	}
+}
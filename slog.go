@@ -0,0 +1,69 @@
+//go:build go1.21
+
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// SlogLogger adapts a log/slog logger to LeveledLoggerInterface. Requests
+// are logged as records with the shop, method, path, status, duration and
+// rate_remaining attributes, see RequestLoggerInterface.
+type SlogLogger struct {
+	logger *slog.Logger
+}
+
+// NewSlogLogger returns an adapter for the logger, slog.Default() when nil.
+func NewSlogLogger(logger *slog.Logger) *SlogLogger {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &SlogLogger{logger: logger}
+}
+
+// Debugf logs a debug message using Printf conventions.
+func (l *SlogLogger) Debugf(format string, v ...interface{}) {
+	l.logf(slog.LevelDebug, format, v...)
+}
+
+// Errorf logs an error message using Printf conventions.
+func (l *SlogLogger) Errorf(format string, v ...interface{}) {
+	l.logf(slog.LevelError, format, v...)
+}
+
+// Infof logs an informational message using Printf conventions.
+func (l *SlogLogger) Infof(format string, v ...interface{}) {
+	l.logf(slog.LevelInfo, format, v...)
+}
+
+// Warnf logs a warning message using Printf conventions.
+func (l *SlogLogger) Warnf(format string, v ...interface{}) {
+	l.logf(slog.LevelWarn, format, v...)
+}
+
+// LogRequest logs a request at the info level, failed requests and error
+// responses at the warning level.
+func (l *SlogLogger) LogRequest(r RequestLog) {
+	level := slog.LevelInfo
+	if r.Status == 0 || r.Status >= 400 {
+		level = slog.LevelWarn
+	}
+	l.logger.LogAttrs(context.Background(), level, "shopify request",
+		slog.String("shop", r.Shop),
+		slog.String("method", r.Method),
+		slog.String("path", r.Path),
+		slog.Int("status", r.Status),
+		slog.Duration("duration", r.Duration),
+		slog.Int("rate_remaining", r.RateRemaining),
+	)
+}
+
+func (l *SlogLogger) logf(level slog.Level, format string, v ...interface{}) {
+	ctx := context.Background()
+	if !l.logger.Enabled(ctx, level) {
+		return
+	}
+	l.logger.Log(ctx, level, fmt.Sprintf(format, v...))
+}
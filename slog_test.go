@@ -0,0 +1,70 @@
+//go:build go1.21
+
+package goshopify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestSlogLogger(t *testing.T) {
+	out := &bytes.Buffer{}
+	logger := NewSlogLogger(slog.New(slog.NewTextHandler(out, &slog.HandlerOptions{Level: slog.LevelInfo})))
+
+	logger.Debugf("debug %s", "log")
+	logger.Infof("info %s", "log")
+	logger.Warnf("warn %s", "log")
+	logger.Errorf("error %s", "log")
+
+	for _, expected := range []string{"level=INFO msg=\"info log\"", "level=WARN msg=\"warn log\"", "level=ERROR msg=\"error log\""} {
+		if !bytes.Contains(out.Bytes(), []byte(expected)) {
+			t.Errorf("SlogLogger logged %q, expected it to contain %q", out.String(), expected)
+		}
+	}
+	if bytes.Contains(out.Bytes(), []byte("debug log")) {
+		t.Errorf("SlogLogger logged a debug message below the handler's level: %s", out.String())
+	}
+}
+
+func TestSlogLoggerRequests(t *testing.T) {
+	setup()
+	defer teardown()
+
+	out := &bytes.Buffer{}
+	WithLogger(NewSlogLogger(slog.New(slog.NewJSONHandler(out, nil))))(client)
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shop.json", client.pathPrefix),
+		createResponderWithHeaders(200, `{"shop":{"id":1}}`, map[string]string{"X-Shopify-Shop-Api-Call-Limit": "1/40"}))
+
+	if _, err := client.Shop.Get(nil); err != nil {
+		t.Fatalf("Shop.Get returned error: %v", err)
+	}
+
+	record := map[string]interface{}{}
+	if err := json.Unmarshal(out.Bytes(), &record); err != nil {
+		t.Fatalf("SlogLogger logged %q, expected a single JSON record: %v", out.String(), err)
+	}
+
+	expected := map[string]interface{}{
+		"level":          "INFO",
+		"msg":            "shopify request",
+		"shop":           "fooshop.myshopify.com",
+		"method":         "GET",
+		"path":           fmt.Sprintf("/%s/shop.json", client.pathPrefix),
+		"status":         float64(200),
+		"rate_remaining": float64(39),
+	}
+	for key, value := range expected {
+		if record[key] != value {
+			t.Errorf("SlogLogger record[%q] = %v, expected %v", key, record[key], value)
+		}
+	}
+	if _, ok := record["duration"]; !ok {
+		t.Errorf("SlogLogger record has no duration: %v", record)
+	}
+}
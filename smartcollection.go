@@ -2,7 +2,6 @@ package goshopify
 
 import (
 	"fmt"
-	"net/http"
 	"time"
 )
 
@@ -17,9 +16,12 @@ type SmartCollectionService interface {
 	ListWithPagination(interface{}) ([]SmartCollection, *Pagination, error)
 	Count(interface{}) (int, error)
 	Get(int64, interface{}) (*SmartCollection, error)
+	GetByHandle(string) (*SmartCollection, error)
 	Create(SmartCollection) (*SmartCollection, error)
 	Update(SmartCollection) (*SmartCollection, error)
+	UpdateFields(int64, *Update) (*SmartCollection, error)
 	Delete(int64) error
+	UpdateOrder(int64, []int64, string) error
 
 	// MetafieldsService used for SmartCollection resource to communicate with Metafields resource
 	MetafieldsService
@@ -47,12 +49,30 @@ type SmartCollection struct {
 	SortOrder      string      `json:"sort_order,omitempty"`
 	TemplateSuffix string      `json:"template_suffix,omitempty"`
 	Image          Image       `json:"image,omitempty"`
-	Published      bool        `json:"published,omitempty"`
+	Published      *bool       `json:"published,omitempty"`
 	PublishedAt    *time.Time  `json:"published_at,omitempty"`
 	PublishedScope string      `json:"published_scope,omitempty"`
 	Rules          []Rule      `json:"rules,omitempty"`
-	Disjunctive    bool        `json:"disjunctive,omitempty"`
+	Disjunctive    *bool       `json:"disjunctive,omitempty"`
 	Metafields     []Metafield `json:"metafields,omitempty"`
+	NullFields     NullFields  `json:"-"`
+}
+
+// MarshalJSON encodes the smart collection, sending the NullFields as null. The
+// image is left out unless set, add "image" to the NullFields to remove it.
+func (s SmartCollection) MarshalJSON() ([]byte, error) {
+	type smartCollection SmartCollection
+	return marshalWithNullFields(struct {
+		smartCollection
+		Image *Image `json:"image,omitempty"`
+	}{smartCollection(s), s.Image.orNil()}, s.NullFields)
+}
+
+// smartCollectionOrderOptions are the query parameters of the
+// smart_collections/X/order.json endpoint.
+type smartCollectionOrderOptions struct {
+	Products  []int64 `url:"products,brackets,omitempty"`
+	SortOrder string  `url:"sort_order,omitempty"`
 }
 
 // SmartCollectionResource represents the result from the smart_collections/X.json endpoint
@@ -77,23 +97,7 @@ func (s *SmartCollectionServiceOp) List(options interface{}) ([]SmartCollection,
 // List smart collections with pagination
 func (s *SmartCollectionServiceOp) ListWithPagination(options interface{}) ([]SmartCollection, *Pagination, error) {
 	path := fmt.Sprintf("%s.json", smartCollectionsBasePath)
-	resource := new(SmartCollectionsResource)
-	headers := http.Header{}
-
-	headers, err := s.client.createAndDoGetHeaders("GET", path, nil, options, resource)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	// Extract pagination info from header
-	linkHeader := headers.Get("Link")
-
-	pagination, err := extractPagination(linkHeader)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	return resource.Collections, pagination, nil
+	return listResourceWithPagination[SmartCollection](s.client, path, "smart_collections", options)
 }
 
 // Count smart collections
@@ -105,28 +109,32 @@ func (s *SmartCollectionServiceOp) Count(options interface{}) (int, error) {
 // Get individual smart collection
 func (s *SmartCollectionServiceOp) Get(collectionID int64, options interface{}) (*SmartCollection, error) {
 	path := fmt.Sprintf("%s/%d.json", smartCollectionsBasePath, collectionID)
-	resource := new(SmartCollectionResource)
-	err := s.client.Get(path, resource, options)
-	return resource.Collection, err
+	return getResource[SmartCollection](s.client, path, "smart_collection", options)
+}
+
+// GetByHandle gets the smart collection with the handle, or returns a NotFoundError
+func (s *SmartCollectionServiceOp) GetByHandle(handle string) (*SmartCollection, error) {
+	path := fmt.Sprintf("%s.json", smartCollectionsBasePath)
+	return getResourceByHandle(s.client, path, "smart_collections", handle, func(collection SmartCollection) string { return collection.Handle })
 }
 
 // Create a new smart collection
 // See Image for the details of the Image creation for a collection.
 func (s *SmartCollectionServiceOp) Create(collection SmartCollection) (*SmartCollection, error) {
 	path := fmt.Sprintf("%s.json", smartCollectionsBasePath)
-	wrappedData := SmartCollectionResource{Collection: &collection}
-	resource := new(SmartCollectionResource)
-	err := s.client.Post(path, wrappedData, resource)
-	return resource.Collection, err
+	return createResource(s.client, path, "smart_collection", collection)
 }
 
 // Update an existing smart collection
 func (s *SmartCollectionServiceOp) Update(collection SmartCollection) (*SmartCollection, error) {
 	path := fmt.Sprintf("%s/%d.json", smartCollectionsBasePath, collection.ID)
-	wrappedData := SmartCollectionResource{Collection: &collection}
-	resource := new(SmartCollectionResource)
-	err := s.client.Put(path, wrappedData, resource)
-	return resource.Collection, err
+	return updateResource(s.client, path, "smart_collection", collection)
+}
+
+// UpdateFields updates only the fields set in update on the smart collection, see Update.
+func (s *SmartCollectionServiceOp) UpdateFields(collectionID int64, update *Update) (*SmartCollection, error) {
+	path := fmt.Sprintf("%s/%d.json", smartCollectionsBasePath, collectionID)
+	return updateFieldsResource[SmartCollection](s.client, path, "smart_collection", collectionID, update)
 }
 
 // Delete an existing smart collection.
@@ -134,6 +142,16 @@ func (s *SmartCollectionServiceOp) Delete(collectionID int64) error {
 	return s.client.Delete(fmt.Sprintf("%s/%d.json", smartCollectionsBasePath, collectionID))
 }
 
+// UpdateOrder arranges the products of a smart collection in the order of
+// productIDs, which only applies when its sort order is "manual". The sort
+// order is changed as well unless it is empty, e.g. to "best-selling" without
+// any products.
+func (s *SmartCollectionServiceOp) UpdateOrder(collectionID int64, productIDs []int64, sortOrder string) error {
+	path := fmt.Sprintf("%s/%d/order.json", smartCollectionsBasePath, collectionID)
+	options := smartCollectionOrderOptions{Products: productIDs, SortOrder: sortOrder}
+	return s.client.CreateAndDo("PUT", path, struct{}{}, options, nil)
+}
+
 // List metafields for a smart collection
 func (s *SmartCollectionServiceOp) ListMetafields(smartCollectionID int64, options interface{}) ([]Metafield, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: smartCollectionsResourceName, resourceID: smartCollectionID}
@@ -1,11 +1,14 @@
 package goshopify
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"reflect"
 	"runtime"
+	"strings"
 	"testing"
 	"time"
 
@@ -27,7 +30,7 @@ func smartCollectionTests(t *testing.T, collection SmartCollection) {
 		{"Column", "title", collection.Rules[0].Column},
 		{"Relation", "contains", collection.Rules[0].Relation},
 		{"Condition", "mac", collection.Rules[0].Condition},
-		{"Disjunctive", true, collection.Disjunctive},
+		{"Disjunctive", true, *collection.Disjunctive},
 	}
 
 	for _, c := range cases {
@@ -144,6 +147,7 @@ func TestSmartCollectionListWithPagination(t *testing.T) {
 			[]SmartCollection{{ID: 1}},
 			&Pagination{
 				NextPageOptions: &ListOptions{PageInfo: "foo", Limit: 2},
+				NextPageURL:     "http://valid.url?page_info=foo&limit=2",
 			},
 			nil,
 		},
@@ -154,6 +158,8 @@ func TestSmartCollectionListWithPagination(t *testing.T) {
 			&Pagination{
 				NextPageOptions:     &ListOptions{PageInfo: "foo"},
 				PreviousPageOptions: &ListOptions{PageInfo: "bar"},
+				NextPageURL:         "http://valid.url?page_info=foo",
+				PreviousPageURL:     "http://valid.url?page_info=bar",
 			},
 			nil,
 		},
@@ -300,6 +306,28 @@ func TestSmartCollectionDelete(t *testing.T) {
 	}
 }
 
+func TestSmartCollectionUpdateOrder(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var query url.Values
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/smart_collections/1/order.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			query = req.URL.Query()
+			return httpmock.NewStringResponse(200, "{}"), nil
+		})
+
+	err := client.SmartCollection.UpdateOrder(1, []int64{3, 2}, "manual")
+	if err != nil {
+		t.Fatalf("SmartCollection.UpdateOrder returned error: %v", err)
+	}
+
+	expected := url.Values{"products[]": {"3", "2"}, "sort_order": {"manual"}}
+	if !reflect.DeepEqual(query, expected) {
+		t.Errorf("SmartCollection.UpdateOrder sent %v, expected %v", query, expected)
+	}
+}
+
 func TestSmartCollectionListMetafields(t *testing.T) {
 	setup()
 	defer teardown()
@@ -429,3 +457,60 @@ func TestSmartCollectionDeleteMetafield(t *testing.T) {
 		t.Errorf("SmartCollection.DeleteMetafield() returned error: %v", err)
 	}
 }
+
+func TestSmartCollectionGetByHandle(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponderWithQuery(
+		"GET",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/smart_collections.json", client.pathPrefix),
+		map[string]string{"handle": "summer"},
+		httpmock.NewStringResponder(200, `{"smart_collections": [{"id": 1, "handle": "summer-sale"}, {"id": 2, "handle": "summer"}]}`))
+	httpmock.RegisterResponderWithQuery(
+		"GET",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/smart_collections.json", client.pathPrefix),
+		map[string]string{"handle": "winter"},
+		httpmock.NewStringResponder(200, `{"smart_collections": []}`))
+
+	found, err := client.SmartCollection.GetByHandle("summer")
+	if err != nil {
+		t.Fatalf("SmartCollection.GetByHandle returned error: %v", err)
+	}
+	if found.ID != 2 {
+		t.Errorf("SmartCollection.GetByHandle returned %+v, expected ID 2", found)
+	}
+
+	_, err = client.SmartCollection.GetByHandle("winter")
+	expected := NotFoundError{Resource: "smart_collections", Handle: "winter"}
+	if err != expected {
+		t.Errorf("SmartCollection.GetByHandle returned error %v, expected %v", err, expected)
+	}
+}
+
+func TestSmartCollectionMarshalImage(t *testing.T) {
+	image := Image{Alt: "Macbooks on a desk"}
+	if err := image.SetAttachment(strings.NewReader("png")); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name       string
+		collection SmartCollection
+		expected   string
+	}{
+		{"unset", SmartCollection{ID: 1, Title: "Macbooks"}, `{"id":1,"title":"Macbooks"}`},
+		{"attachment", SmartCollection{ID: 1, Image: image}, `{"id":1,"image":{"alt":"Macbooks on a desk","attachment":"cG5n"}}`},
+		{"alt", SmartCollection{ID: 1, Image: Image{Alt: "Macbooks"}}, `{"id":1,"image":{"alt":"Macbooks"}}`},
+		{"removed", SmartCollection{ID: 1, NullFields: NullFields{"image"}}, `{"id":1,"image":null}`},
+	}
+
+	for _, c := range cases {
+		data, err := json.Marshal(c.collection)
+		if err != nil {
+			t.Errorf("%s: json.Marshal returned error: %v", c.name, err)
+		} else if string(data) != c.expected {
+			t.Errorf("%s: SmartCollection encoded as %s, expected %s", c.name, data, c.expected)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+)
+
+// Resources a staged upload can be created for.
+const (
+	StagedUploadResourceBulkMutationVariables = "BULK_MUTATION_VARIABLES"
+	StagedUploadResourceFile                  = "FILE"
+	StagedUploadResourceImage                 = "IMAGE"
+	StagedUploadResourceVideo                 = "VIDEO"
+	StagedUploadResourceProductImage          = "PRODUCT_IMAGE"
+)
+
+// StagedUploadService is an interface for uploading files before referring
+// to them in a mutation, e.g. the variables of a bulk mutation or the content
+// of a file or product media.
+// See: https://shopify.dev/docs/api/admin-graphql/latest/mutations/stagedUploadsCreate
+type StagedUploadService interface {
+	Create(...StagedUploadInput) ([]StagedUploadTarget, error)
+	Upload(context.Context, StagedUploadTarget, io.Reader) error
+}
+
+// StagedUploadServiceOp handles communication with the staged upload
+// mutation and the upload of the files.
+type StagedUploadServiceOp struct {
+	client *Client
+}
+
+// StagedUploadInput describes a file to upload before referring to it in a
+// mutation. The HTTPMethod defaults to POST, videos and 3D models need their
+// FileSize.
+type StagedUploadInput struct {
+	Resource   string `json:"resource"`
+	Filename   string `json:"filename"`
+	MimeType   string `json:"mimeType"`
+	HTTPMethod string `json:"httpMethod,omitempty"`
+	FileSize   string `json:"fileSize,omitempty"`
+}
+
+// StagedUploadTarget is where a staged upload is sent to. The parameters are
+// sent as form fields before the file for POST uploads and as headers for PUT
+// uploads. Mutations refer to the uploaded file by its ResourceURL.
+type StagedUploadTarget struct {
+	URL         string                  `json:"url"`
+	ResourceURL string                  `json:"resourceUrl"`
+	Parameters  []StagedUploadParameter `json:"parameters"`
+
+	// The method and filename of the input the target was created for.
+	HTTPMethod string `json:"-"`
+	Filename   string `json:"-"`
+}
+
+// StagedUploadParameter is a form field or header of a staged upload.
+type StagedUploadParameter struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Parameter returns the value of the named parameter, e.g. "key" which is the
+// path bulk mutations refer to the upload by.
+func (t StagedUploadTarget) Parameter(name string) string {
+	for _, p := range t.Parameters {
+		if p.Name == name {
+			return p.Value
+		}
+	}
+	return ""
+}
+
+const stagedUploadsCreateMutation = `mutation($input: [StagedUploadInput!]!) {
+  stagedUploadsCreate(input: $input) {
+    stagedTargets { url resourceUrl parameters { name value } }
+    userErrors { field message }
+  }
+}`
+
+// Create a staged upload target for every input, in the same order.
+func (s *StagedUploadServiceOp) Create(inputs ...StagedUploadInput) ([]StagedUploadTarget, error) {
+	resp := struct {
+		StagedUploadsCreate struct {
+			StagedTargets []StagedUploadTarget `json:"stagedTargets"`
+			UserErrors    []UserError          `json:"userErrors"`
+		} `json:"stagedUploadsCreate"`
+	}{}
+
+	vars := map[string]interface{}{"input": inputs}
+	if err := s.client.GraphQL.Query(stagedUploadsCreateMutation, vars, &resp); err != nil {
+		return nil, err
+	}
+	if err := userErrorsToError(resp.StagedUploadsCreate.UserErrors); err != nil {
+		return nil, err
+	}
+
+	targets := resp.StagedUploadsCreate.StagedTargets
+	if len(targets) != len(inputs) {
+		return nil, fmt.Errorf("%d staged upload targets were created for %d inputs", len(targets), len(inputs))
+	}
+	for i := range targets {
+		targets[i].HTTPMethod = inputs[i].HTTPMethod
+		targets[i].Filename = inputs[i].Filename
+	}
+	return targets, nil
+}
+
+// Upload sends the file to the staged upload target, as a multipart form
+// after the parameters for POST targets or as the body with the parameters as
+// headers for PUT targets. The target URL is signed, so the access token is
+// not sent along.
+func (s *StagedUploadServiceOp) Upload(ctx context.Context, target StagedUploadTarget, file io.Reader) error {
+	var req *http.Request
+	var err error
+	if strings.EqualFold(target.HTTPMethod, "PUT") {
+		req, err = http.NewRequestWithContext(ctx, "PUT", target.URL, file)
+		if err != nil {
+			return err
+		}
+		for _, p := range target.Parameters {
+			req.Header.Set(p.Name, p.Value)
+		}
+	} else {
+		req, err = stagedUploadForm(ctx, target, file)
+		if err != nil {
+			return err
+		}
+		// Stops writing the form when the body is not read to the end.
+		defer req.Body.Close()
+	}
+
+	resp, err := s.client.transferClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return ResponseError{Status: resp.StatusCode, Message: fmt.Sprintf("uploading %s: %s", target.Filename, resp.Status)}
+	}
+	return nil
+}
+
+// stagedUploadForm builds the multipart form POST request of an upload. The
+// form is written to the body as it is sent, so the file is never held in
+// memory.
+func stagedUploadForm(ctx context.Context, target StagedUploadTarget, file io.Reader) (*http.Request, error) {
+	filename := target.Filename
+	if filename == "" {
+		filename = "file"
+	}
+
+	body, w := io.Pipe()
+	form := multipart.NewWriter(w)
+	req, err := http.NewRequestWithContext(ctx, "POST", target.URL, body)
+	if err != nil {
+		body.Close()
+		return nil, err
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+
+	go func() {
+		w.CloseWithError(writeStagedUploadForm(form, target.Parameters, filename, file))
+	}()
+	return req, nil
+}
+
+// writeStagedUploadForm writes the parameters and then the file to the form.
+func writeStagedUploadForm(form *multipart.Writer, params []StagedUploadParameter, filename string, file io.Reader) error {
+	for _, p := range params {
+		if err := form.WriteField(p.Name, p.Value); err != nil {
+			return err
+		}
+	}
+	part, err := form.CreateFormFile("file", filename)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(part, file); err != nil {
+		return err
+	}
+	return form.Close()
+}
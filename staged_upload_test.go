@@ -0,0 +1,175 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/jarcoal/httpmock"
+)
+
+const stagedUploadResponse = `{"data":{"stagedUploadsCreate":{"stagedTargets":[{"url":"https://shopify.s3.amazonaws.com","resourceUrl":null,"parameters":[{"name":"key","value":"tmp/1/bulk/abc/bulk_mutation_variables.jsonl"},{"name":"policy","value":"p0l1cy"}]}],"userErrors":[]}}}`
+
+func TestStagedUploadCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, stagedUploadResponse))
+
+	targets, err := client.StagedUpload.Create(StagedUploadInput{
+		Resource:   StagedUploadResourceBulkMutationVariables,
+		Filename:   "vars.jsonl",
+		MimeType:   "text/jsonl",
+		HTTPMethod: "POST",
+	})
+	if err != nil {
+		t.Fatalf("StagedUpload.Create returned error: %v", err)
+	}
+	if len(targets) != 1 {
+		t.Fatalf("StagedUpload.Create returned %d targets, expected 1", len(targets))
+	}
+	target := targets[0]
+	if target.HTTPMethod != "POST" || target.Filename != "vars.jsonl" {
+		t.Errorf("StagedUpload.Create returned method %q and filename %q of the input", target.HTTPMethod, target.Filename)
+	}
+	if target.URL != "https://shopify.s3.amazonaws.com" {
+		t.Errorf("StagedUploadTarget.URL returned %s", target.URL)
+	}
+	if key := target.Parameter("key"); key != "tmp/1/bulk/abc/bulk_mutation_variables.jsonl" {
+		t.Errorf("StagedUploadTarget.Parameter(key) returned %q", key)
+	}
+	if missing := target.Parameter("missing"); missing != "" {
+		t.Errorf("StagedUploadTarget.Parameter(missing) returned %q, expected none", missing)
+	}
+}
+
+func TestStagedUploadCreateUserErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"data":{"stagedUploadsCreate":{"stagedTargets":[],"userErrors":[{"field":["input","0","mimeType"],"message":"is invalid"}]}}}`))
+
+	_, err := client.StagedUpload.Create(StagedUploadInput{Resource: StagedUploadResourceBulkMutationVariables})
+	if err == nil || err.Error() != "input.0.mimeType: is invalid" {
+		t.Errorf("StagedUpload.Create returned %v, expected the user error", err)
+	}
+}
+
+func TestStagedUploadCreateMissingTargets(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, stagedUploadResponse))
+
+	_, err := client.StagedUpload.Create(StagedUploadInput{Resource: StagedUploadResourceFile}, StagedUploadInput{Resource: StagedUploadResourceImage})
+	if err == nil || err.Error() != "1 staged upload targets were created for 2 inputs" {
+		t.Errorf("StagedUpload.Create returned %v, expected an error about the missing target", err)
+	}
+}
+
+func TestStagedUploadUpload(t *testing.T) {
+	setup()
+	defer teardown()
+
+	target := StagedUploadTarget{
+		URL:        "https://shopify.s3.amazonaws.com",
+		HTTPMethod: "POST",
+		Filename:   "vars.jsonl",
+		Parameters: []StagedUploadParameter{{Name: "key", Value: "tmp/vars.jsonl"}, {Name: "policy", Value: "p0l1cy"}},
+	}
+
+	httpmock.RegisterResponder("POST", target.URL, func(req *http.Request) (*http.Response, error) {
+		if req.Header.Get("X-Shopify-Access-Token") != "" {
+			t.Error("the access token was sent to the upload url")
+		}
+		if err := req.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("parsing upload: %v", err)
+		}
+		if key := req.FormValue("key"); key != "tmp/vars.jsonl" {
+			t.Errorf("upload key returned %q", key)
+		}
+		if policy := req.FormValue("policy"); policy != "p0l1cy" {
+			t.Errorf("upload policy returned %q", policy)
+		}
+		file, header, err := req.FormFile("file")
+		if err != nil {
+			t.Fatalf("reading uploaded file: %v", err)
+		}
+		if header.Filename != "vars.jsonl" {
+			t.Errorf("uploaded filename returned %q", header.Filename)
+		}
+		content, _ := ioutil.ReadAll(file)
+		if string(content) != "{\"input\":{}}\n" {
+			t.Errorf("uploaded file returned %q", content)
+		}
+		return httpmock.NewStringResponse(204, ""), nil
+	})
+
+	err := client.StagedUpload.Upload(context.Background(), target, strings.NewReader("{\"input\":{}}\n"))
+	if err != nil {
+		t.Errorf("StagedUpload.Upload returned error: %v", err)
+	}
+
+	httpmock.RegisterResponder("POST", target.URL, httpmock.NewStringResponder(403, "denied"))
+	err = client.StagedUpload.Upload(context.Background(), target, strings.NewReader(""))
+	if responseError, ok := err.(ResponseError); !ok || responseError.Status != 403 {
+		t.Errorf("StagedUpload.Upload returned %v, expected a 403 ResponseError", err)
+	}
+}
+
+func TestStagedUploadUploadReadError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	target := StagedUploadTarget{URL: "https://shopify.s3.amazonaws.com", Filename: "vars.jsonl"}
+	httpmock.RegisterResponder("POST", target.URL, func(req *http.Request) (*http.Response, error) {
+		if _, err := ioutil.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		return httpmock.NewStringResponse(204, ""), nil
+	})
+
+	readErr := errors.New("disk failure")
+	file := io.MultiReader(strings.NewReader("{\"input\":{}}\n"), iotest.ErrReader(readErr))
+	err := client.StagedUpload.Upload(context.Background(), target, file)
+	if !errors.Is(err, readErr) {
+		t.Errorf("StagedUpload.Upload returned %v, expected the read error", err)
+	}
+}
+
+func TestStagedUploadUploadPut(t *testing.T) {
+	setup()
+	defer teardown()
+
+	target := StagedUploadTarget{
+		URL:        "https://shopify-staged-uploads.storage.googleapis.com/tmp/logo.png",
+		HTTPMethod: "PUT",
+		Filename:   "logo.png",
+		Parameters: []StagedUploadParameter{{Name: "content_type", Value: "image/png"}, {Name: "acl", Value: "private"}},
+	}
+
+	httpmock.RegisterResponder("PUT", target.URL, func(req *http.Request) (*http.Response, error) {
+		if req.Header.Get("content_type") != "image/png" || req.Header.Get("acl") != "private" {
+			t.Errorf("upload headers returned %v", req.Header)
+		}
+		content, _ := ioutil.ReadAll(req.Body)
+		if string(content) != "png" {
+			t.Errorf("uploaded body returned %q", content)
+		}
+		return httpmock.NewStringResponse(200, ""), nil
+	})
+
+	err := client.StagedUpload.Upload(context.Background(), target, strings.NewReader("png"))
+	if err != nil {
+		t.Errorf("StagedUpload.Upload returned error: %v", err)
+	}
+}
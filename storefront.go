@@ -0,0 +1,37 @@
+package goshopify
+
+import "strings"
+
+// defaultStorefrontApiVersion is the oldest Storefront API version with the
+// cart API, used when no version is configured.
+const defaultStorefrontApiVersion = "2022-01"
+
+// StorefrontClient manages communication with the Storefront GraphQL API of a
+// shop, which is what headless storefronts use. It authenticates with a
+// Storefront access token and accepts the same options as Client.
+// See: https://shopify.dev/api/storefront
+type StorefrontClient struct {
+	client *Client
+
+	GraphQL GraphQLService
+	Cart    StorefrontCartService
+}
+
+// NewStorefrontClient returns a new Storefront API client for the shop,
+// authenticated with a Storefront access token.
+func NewStorefrontClient(shopName, token string, opts ...Option) *StorefrontClient {
+	c := NewClient(App{}, shopName, "", opts...)
+	c.storefrontToken = token
+	if c.pathPrefix == defaultApiPathPrefix && c.apiVersion == defaultApiVersion {
+		c.pathPrefix = "api/" + defaultStorefrontApiVersion
+	} else {
+		c.pathPrefix = strings.TrimPrefix(c.pathPrefix, "admin/")
+	}
+
+	graphQL := &GraphQLServiceOp{client: c}
+	return &StorefrontClient{
+		client:  c,
+		GraphQL: graphQL,
+		Cart:    &StorefrontCartServiceOp{graphQL: graphQL},
+	}
+}
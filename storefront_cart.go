@@ -0,0 +1,110 @@
+package goshopify
+
+// StorefrontCartService is an interface for updating the metadata of
+// Storefront API carts. Cart attributes and the note are carried over to the
+// order, as its note attributes and note, once the cart is checked out.
+// See: https://shopify.dev/api/storefront/reference/cart/cart
+type StorefrontCartService interface {
+	UpdateAttributes(string, []CartAttribute) (*Cart, error)
+	UpdateNote(string, string) (*Cart, error)
+	UpdateDiscountCodes(string, []string) (*Cart, error)
+}
+
+// StorefrontCartServiceOp handles communication with the cart related
+// mutations of the Storefront API.
+type StorefrontCartServiceOp struct {
+	graphQL GraphQLService
+}
+
+// Cart represents a Storefront API cart.
+type Cart struct {
+	ID            string             `json:"id"`
+	CheckoutURL   string             `json:"checkoutUrl"`
+	Note          string             `json:"note"`
+	Attributes    []CartAttribute    `json:"attributes"`
+	DiscountCodes []CartDiscountCode `json:"discountCodes"`
+}
+
+// CartAttribute is a custom key value pair attached to a cart.
+type CartAttribute struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// CartDiscountCode is a discount code applied to a cart, Applicable reports
+// whether it applies to the cart's current contents.
+type CartDiscountCode struct {
+	Code       string `json:"code"`
+	Applicable bool   `json:"applicable"`
+}
+
+// cartPayload is the result of every cart mutation.
+type cartPayload struct {
+	Cart       *Cart       `json:"cart"`
+	UserErrors []UserError `json:"userErrors"`
+}
+
+const cartFields = `id checkoutUrl note attributes { key value } discountCodes { code applicable }`
+
+const cartAttributesUpdateMutation = `mutation($cartId: ID!, $attributes: [AttributeInput!]!) {
+  cartAttributesUpdate(cartId: $cartId, attributes: $attributes) {
+    cart { ` + cartFields + ` }
+    userErrors { field message }
+  }
+}`
+
+const cartNoteUpdateMutation = `mutation($cartId: ID!, $note: String) {
+  cartNoteUpdate(cartId: $cartId, note: $note) {
+    cart { ` + cartFields + ` }
+    userErrors { field message }
+  }
+}`
+
+const cartDiscountCodesUpdateMutation = `mutation($cartId: ID!, $discountCodes: [String!]) {
+  cartDiscountCodesUpdate(cartId: $cartId, discountCodes: $discountCodes) {
+    cart { ` + cartFields + ` }
+    userErrors { field message }
+  }
+}`
+
+// UpdateAttributes replaces the attributes of a cart
+func (s *StorefrontCartServiceOp) UpdateAttributes(cartID string, attributes []CartAttribute) (*Cart, error) {
+	if attributes == nil {
+		attributes = []CartAttribute{}
+	}
+	vars := map[string]interface{}{"cartId": cartID, "attributes": attributes}
+	resp := struct {
+		Payload cartPayload `json:"cartAttributesUpdate"`
+	}{}
+	return s.mutate(cartAttributesUpdateMutation, vars, &resp, &resp.Payload)
+}
+
+// UpdateNote replaces the note of a cart
+func (s *StorefrontCartServiceOp) UpdateNote(cartID string, note string) (*Cart, error) {
+	vars := map[string]interface{}{"cartId": cartID, "note": note}
+	resp := struct {
+		Payload cartPayload `json:"cartNoteUpdate"`
+	}{}
+	return s.mutate(cartNoteUpdateMutation, vars, &resp, &resp.Payload)
+}
+
+// UpdateDiscountCodes replaces the discount codes applied to a cart, an empty
+// list removes all of them.
+func (s *StorefrontCartServiceOp) UpdateDiscountCodes(cartID string, codes []string) (*Cart, error) {
+	if codes == nil {
+		codes = []string{}
+	}
+	vars := map[string]interface{}{"cartId": cartID, "discountCodes": codes}
+	resp := struct {
+		Payload cartPayload `json:"cartDiscountCodesUpdate"`
+	}{}
+	return s.mutate(cartDiscountCodesUpdateMutation, vars, &resp, &resp.Payload)
+}
+
+func (s *StorefrontCartServiceOp) mutate(mutation string, vars, resp interface{}, payload *cartPayload) (*Cart, error) {
+	err := s.graphQL.Query(mutation, vars, resp)
+	if err == nil {
+		err = userErrorsToError(payload.UserErrors)
+	}
+	return payload.Cart, err
+}
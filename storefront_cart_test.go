@@ -0,0 +1,121 @@
+package goshopify
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+const storefrontGraphQLURL = "https://fooshop.myshopify.com/api/2022-01/graphql.json"
+
+// registerStorefrontCartResponder responds to cart mutations with the given
+// result and records the variables that were sent.
+func registerStorefrontCartResponder(mutation, result string, sent *map[string]interface{}) {
+	httpmock.RegisterResponder("POST", storefrontGraphQLURL, func(req *http.Request) (*http.Response, error) {
+		body := struct {
+			Variables map[string]interface{} `json:"variables"`
+		}{}
+		_ = json.NewDecoder(req.Body).Decode(&body)
+		*sent = body.Variables
+		return httpmock.NewStringResponse(200, `{"data":{"`+mutation+`":`+result+`}}`), nil
+	})
+}
+
+func TestStorefrontCartUpdateAttributes(t *testing.T) {
+	setup()
+	defer teardown()
+
+	sf := NewStorefrontClient("fooshop", "sftoken", WithHTTPClient(client.Client))
+	var sent map[string]interface{}
+	registerStorefrontCartResponder("cartAttributesUpdate",
+		`{"cart":{"id":"gid://shopify/Cart/1","attributes":[{"key":"gift_wrap","value":"yes"}]},"userErrors":[]}`, &sent)
+
+	cart, err := sf.Cart.UpdateAttributes("gid://shopify/Cart/1", []CartAttribute{{Key: "gift_wrap", Value: "yes"}})
+	if err != nil {
+		t.Errorf("Cart.UpdateAttributes returned error: %v", err)
+	}
+
+	expected := &Cart{ID: "gid://shopify/Cart/1", Attributes: []CartAttribute{{Key: "gift_wrap", Value: "yes"}}}
+	if !reflect.DeepEqual(cart, expected) {
+		t.Errorf("Cart.UpdateAttributes returned %+v, expected %+v", cart, expected)
+	}
+
+	expectedVars := map[string]interface{}{
+		"cartId":     "gid://shopify/Cart/1",
+		"attributes": []interface{}{map[string]interface{}{"key": "gift_wrap", "value": "yes"}},
+	}
+	if !reflect.DeepEqual(sent, expectedVars) {
+		t.Errorf("Cart.UpdateAttributes sent %+v, expected %+v", sent, expectedVars)
+	}
+}
+
+func TestStorefrontCartUpdateNote(t *testing.T) {
+	setup()
+	defer teardown()
+
+	sf := NewStorefrontClient("fooshop", "sftoken", WithHTTPClient(client.Client))
+	var sent map[string]interface{}
+	registerStorefrontCartResponder("cartNoteUpdate",
+		`{"cart":{"id":"gid://shopify/Cart/1","note":"Leave at the door"},"userErrors":[]}`, &sent)
+
+	cart, err := sf.Cart.UpdateNote("gid://shopify/Cart/1", "Leave at the door")
+	if err != nil {
+		t.Errorf("Cart.UpdateNote returned error: %v", err)
+	}
+
+	if cart == nil || cart.Note != "Leave at the door" {
+		t.Errorf("Cart.UpdateNote returned %+v, expected note to be set", cart)
+	}
+	if sent["note"] != "Leave at the door" {
+		t.Errorf("Cart.UpdateNote sent %+v", sent)
+	}
+}
+
+func TestStorefrontCartUpdateDiscountCodes(t *testing.T) {
+	setup()
+	defer teardown()
+
+	sf := NewStorefrontClient("fooshop", "sftoken", WithHTTPClient(client.Client))
+	var sent map[string]interface{}
+	registerStorefrontCartResponder("cartDiscountCodesUpdate",
+		`{"cart":{"id":"gid://shopify/Cart/1","discountCodes":[{"code":"SUMMER","applicable":true}]},"userErrors":[]}`, &sent)
+
+	cart, err := sf.Cart.UpdateDiscountCodes("gid://shopify/Cart/1", []string{"SUMMER"})
+	if err != nil {
+		t.Errorf("Cart.UpdateDiscountCodes returned error: %v", err)
+	}
+
+	expected := []CartDiscountCode{{Code: "SUMMER", Applicable: true}}
+	if cart == nil || !reflect.DeepEqual(cart.DiscountCodes, expected) {
+		t.Errorf("Cart.UpdateDiscountCodes returned %+v, expected discount codes %+v", cart, expected)
+	}
+
+	// removing all codes sends an empty list rather than null
+	_, _ = sf.Cart.UpdateDiscountCodes("gid://shopify/Cart/1", nil)
+	if codes, ok := sent["discountCodes"].([]interface{}); !ok || len(codes) != 0 {
+		t.Errorf("Cart.UpdateDiscountCodes(nil) sent discountCodes %#v, expected []", sent["discountCodes"])
+	}
+}
+
+func TestStorefrontCartUserErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	sf := NewStorefrontClient("fooshop", "sftoken", WithHTTPClient(client.Client))
+	var sent map[string]interface{}
+	registerStorefrontCartResponder("cartNoteUpdate",
+		`{"cart":null,"userErrors":[{"field":["cartId"],"message":"The specified cart does not exist."}]}`, &sent)
+
+	_, err := sf.Cart.UpdateNote("gid://shopify/Cart/2", "")
+	expected := ResponseError{
+		Status:  200,
+		Message: "cartId: The specified cart does not exist.",
+		Errors:  []string{"cartId: The specified cart does not exist."},
+	}
+	if !reflect.DeepEqual(err, expected) {
+		t.Errorf("Cart.UpdateNote returned error %#v, expected %#v", err, expected)
+	}
+}
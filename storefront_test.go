@@ -0,0 +1,51 @@
+package goshopify
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestNewStorefrontClient(t *testing.T) {
+	cases := []struct {
+		opts     []Option
+		expected string
+	}{
+		{nil, "api/" + defaultStorefrontApiVersion},
+		{[]Option{WithVersion("2022-04")}, "api/2022-04"},
+		{[]Option{WithVersion(UnstableApiVersion)}, "api/unstable"},
+	}
+
+	for _, c := range cases {
+		sf := NewStorefrontClient("fooshop", "sftoken", c.opts...)
+		if sf.client.pathPrefix != c.expected {
+			t.Errorf("NewStorefrontClient pathPrefix = %s, expected %s", sf.client.pathPrefix, c.expected)
+		}
+	}
+}
+
+func TestStorefrontClientAuthentication(t *testing.T) {
+	setup()
+	defer teardown()
+
+	sf := NewStorefrontClient("fooshop", "sftoken", WithHTTPClient(client.Client))
+
+	var header http.Header
+	httpmock.RegisterResponder("POST", "https://fooshop.myshopify.com/api/2022-01/graphql.json",
+		func(req *http.Request) (*http.Response, error) {
+			header = req.Header
+			return httpmock.NewStringResponse(200, `{"data":{}}`), nil
+		})
+
+	if err := sf.GraphQL.Query("{ shop { name } }", nil, nil); err != nil {
+		t.Fatalf("StorefrontClient.GraphQL.Query returned error: %v", err)
+	}
+
+	if actual := header.Get("X-Shopify-Storefront-Access-Token"); actual != "sftoken" {
+		t.Errorf("X-Shopify-Storefront-Access-Token = %q, expected %q", actual, "sftoken")
+	}
+	if actual := header.Get("X-Shopify-Access-Token"); actual != "" {
+		t.Errorf("X-Shopify-Access-Token = %q, expected none", actual)
+	}
+}
@@ -45,18 +45,13 @@ type StorefrontAccessTokensResource struct {
 // List storefront access tokens
 func (s *StorefrontAccessTokenServiceOp) List(options interface{}) ([]StorefrontAccessToken, error) {
 	path := fmt.Sprintf("%s.json", storefrontAccessTokensBasePath)
-	resource := new(StorefrontAccessTokensResource)
-	err := s.client.Get(path, resource, options)
-	return resource.StorefrontAccessTokens, err
+	return listResource[StorefrontAccessToken](s.client, path, "storefront_access_tokens", options)
 }
 
 // Create a new storefront access token
 func (s *StorefrontAccessTokenServiceOp) Create(storefrontAccessToken StorefrontAccessToken) (*StorefrontAccessToken, error) {
 	path := fmt.Sprintf("%s.json", storefrontAccessTokensBasePath)
-	wrappedData := StorefrontAccessTokenResource{StorefrontAccessToken: &storefrontAccessToken}
-	resource := new(StorefrontAccessTokenResource)
-	err := s.client.Post(path, wrappedData, resource)
-	return resource.StorefrontAccessToken, err
+	return createResource(s.client, path, "storefront_access_token", storefrontAccessToken)
 }
 
 // Delete an existing storefront access token
@@ -0,0 +1,457 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Statuses of a subscription contract.
+const (
+	SubscriptionContractStatusActive    = "ACTIVE"
+	SubscriptionContractStatusPaused    = "PAUSED"
+	SubscriptionContractStatusCancelled = "CANCELLED"
+	SubscriptionContractStatusExpired   = "EXPIRED"
+	SubscriptionContractStatusFailed    = "FAILED"
+)
+
+// defaultBillingAttemptPollInterval is how often WaitBillingAttempt checks on
+// a billing attempt when no interval is given.
+const defaultBillingAttemptPollInterval = 2 * time.Second
+
+// SubscriptionContractService is an interface for interfacing with the subscription contract endpoints
+// of the Shopify API.
+// See: https://shopify.dev/docs/apps/selling-strategies/subscriptions/contracts
+type SubscriptionContractService interface {
+	List() ([]SubscriptionContract, error)
+	Get(string) (*SubscriptionContract, error)
+	SetNextBillingDate(string, time.Time) (*SubscriptionContract, error)
+	UpdateLines(string, ...SubscriptionLineChange) (*SubscriptionContract, error)
+	CreateBillingAttempt(string, SubscriptionBillingAttemptInput) (*SubscriptionBillingAttempt, error)
+	GetBillingAttempt(string) (*SubscriptionBillingAttempt, error)
+	WaitBillingAttempt(context.Context, string, time.Duration) (*SubscriptionBillingAttempt, error)
+}
+
+// SubscriptionContractServiceOp handles communication with the subscription
+// contract related GraphQL queries and mutations.
+type SubscriptionContractServiceOp struct {
+	client *Client
+}
+
+// SubscriptionContract represents the agreement of a customer to buy the
+// lines of the contract on a recurring basis.
+type SubscriptionContract struct {
+	ID              string                      `json:"id"`
+	Status          string                      `json:"status"`
+	CustomerID      string                      `json:"customerId,omitempty"`
+	CurrencyCode    string                      `json:"currencyCode,omitempty"`
+	NextBillingDate *time.Time                  `json:"nextBillingDate,omitempty"`
+	BillingPolicy   *SellingPlanRecurringPolicy `json:"billingPolicy,omitempty"`
+	DeliveryPolicy  *SellingPlanRecurringPolicy `json:"deliveryPolicy,omitempty"`
+	Lines           []SubscriptionLine          `json:"lines,omitempty"`
+	CreatedAt       *time.Time                  `json:"createdAt,omitempty"`
+	UpdatedAt       *time.Time                  `json:"updatedAt,omitempty"`
+}
+
+// SubscriptionLine represents a variant bought with every billing of a
+// contract.
+type SubscriptionLine struct {
+	ID            string           `json:"id"`
+	VariantID     string           `json:"variantId,omitempty"`
+	SellingPlanID string           `json:"sellingPlanId,omitempty"`
+	Title         string           `json:"title,omitempty"`
+	Quantity      int              `json:"quantity"`
+	CurrentPrice  *decimal.Decimal `json:"currentPrice,omitempty"`
+}
+
+// SubscriptionLineChange describes a change to the lines of a contract. A
+// change without a LineID adds the variant, a change with Remove set removes
+// the line and any other change updates its quantity and price.
+type SubscriptionLineChange struct {
+	LineID       string
+	VariantID    int64
+	Quantity     int
+	CurrentPrice *decimal.Decimal
+	Remove       bool
+}
+
+// SubscriptionBillingAttemptInput describes a billing attempt. The
+// IdempotencyKey makes retrying the attempt safe, OriginTime bills the
+// contract as of another time than now.
+type SubscriptionBillingAttemptInput struct {
+	IdempotencyKey string     `json:"idempotencyKey"`
+	OriginTime     *time.Time `json:"originTime,omitempty"`
+}
+
+// SubscriptionBillingAttempt represents an attempt to bill a contract, which
+// is processed asynchronously. It is Ready once processed, with the ID of the
+// created order when it succeeded or an error otherwise.
+type SubscriptionBillingAttempt struct {
+	ID             string     `json:"id"`
+	IdempotencyKey string     `json:"idempotencyKey,omitempty"`
+	Ready          bool       `json:"ready"`
+	ErrorCode      string     `json:"errorCode,omitempty"`
+	ErrorMessage   string     `json:"errorMessage,omitempty"`
+	OrderID        string     `json:"orderId,omitempty"`
+	CreatedAt      *time.Time `json:"createdAt,omitempty"`
+}
+
+// subscriptionContractNode is the GraphQL representation of a contract, its
+// customer and lines are nested objects.
+type subscriptionContractNode struct {
+	ID              string                      `json:"id"`
+	Status          string                      `json:"status"`
+	CurrencyCode    string                      `json:"currencyCode"`
+	NextBillingDate *time.Time                  `json:"nextBillingDate"`
+	BillingPolicy   *SellingPlanRecurringPolicy `json:"billingPolicy"`
+	DeliveryPolicy  *SellingPlanRecurringPolicy `json:"deliveryPolicy"`
+	CreatedAt       *time.Time                  `json:"createdAt"`
+	UpdatedAt       *time.Time                  `json:"updatedAt"`
+	Customer        *struct {
+		ID string `json:"id"`
+	} `json:"customer"`
+	Lines struct {
+		Edges []struct {
+			Node struct {
+				ID            string `json:"id"`
+				VariantID     string `json:"variantId"`
+				SellingPlanID string `json:"sellingPlanId"`
+				Title         string `json:"title"`
+				Quantity      int    `json:"quantity"`
+				CurrentPrice  *struct {
+					Amount *decimal.Decimal `json:"amount"`
+				} `json:"currentPrice"`
+			} `json:"node"`
+		} `json:"edges"`
+	} `json:"lines"`
+}
+
+func (n *subscriptionContractNode) contract() *SubscriptionContract {
+	if n == nil {
+		return nil
+	}
+	contract := &SubscriptionContract{
+		ID:              n.ID,
+		Status:          n.Status,
+		CurrencyCode:    n.CurrencyCode,
+		NextBillingDate: n.NextBillingDate,
+		BillingPolicy:   n.BillingPolicy,
+		DeliveryPolicy:  n.DeliveryPolicy,
+		CreatedAt:       n.CreatedAt,
+		UpdatedAt:       n.UpdatedAt,
+	}
+	if n.Customer != nil {
+		contract.CustomerID = n.Customer.ID
+	}
+	for _, edge := range n.Lines.Edges {
+		line := SubscriptionLine{
+			ID:            edge.Node.ID,
+			VariantID:     edge.Node.VariantID,
+			SellingPlanID: edge.Node.SellingPlanID,
+			Title:         edge.Node.Title,
+			Quantity:      edge.Node.Quantity,
+		}
+		if edge.Node.CurrentPrice != nil {
+			line.CurrentPrice = edge.Node.CurrentPrice.Amount
+		}
+		contract.Lines = append(contract.Lines, line)
+	}
+	return contract
+}
+
+// subscriptionBillingAttemptNode is the GraphQL representation of a billing
+// attempt.
+type subscriptionBillingAttemptNode struct {
+	ID             string     `json:"id"`
+	IdempotencyKey string     `json:"idempotencyKey"`
+	Ready          bool       `json:"ready"`
+	ErrorCode      string     `json:"errorCode"`
+	ErrorMessage   string     `json:"errorMessage"`
+	CreatedAt      *time.Time `json:"createdAt"`
+	Order          *struct {
+		ID string `json:"id"`
+	} `json:"order"`
+}
+
+func (n *subscriptionBillingAttemptNode) attempt() *SubscriptionBillingAttempt {
+	if n == nil {
+		return nil
+	}
+	attempt := &SubscriptionBillingAttempt{
+		ID:             n.ID,
+		IdempotencyKey: n.IdempotencyKey,
+		Ready:          n.Ready,
+		ErrorCode:      n.ErrorCode,
+		ErrorMessage:   n.ErrorMessage,
+		CreatedAt:      n.CreatedAt,
+	}
+	if n.Order != nil {
+		attempt.OrderID = n.Order.ID
+	}
+	return attempt
+}
+
+func variantGID(variantID int64) string {
+	return fmt.Sprintf("gid://shopify/ProductVariant/%d", variantID)
+}
+
+const subscriptionContractFields = `id status currencyCode nextBillingDate createdAt updatedAt
+  customer { id }
+  billingPolicy { interval intervalCount }
+  deliveryPolicy { interval intervalCount }
+  lines(first: 50) {
+    edges { node { id variantId sellingPlanId title quantity currentPrice { amount } } }
+  }`
+
+const subscriptionBillingAttemptFields = `id idempotencyKey ready errorCode errorMessage createdAt order { id }`
+
+const subscriptionContractsQuery = `query($after: String) {
+  subscriptionContracts(first: 50, after: $after) {
+    edges { node { ` + subscriptionContractFields + ` } }
+    pageInfo { hasNextPage endCursor }
+  }
+}`
+
+const subscriptionContractQuery = `query($id: ID!) {
+  subscriptionContract(id: $id) { ` + subscriptionContractFields + ` }
+}`
+
+const subscriptionContractUpdateMutation = `mutation($contractId: ID!) {
+  subscriptionContractUpdate(contractId: $contractId) {
+    draft { id }
+    userErrors { field message }
+  }
+}`
+
+const subscriptionDraftUpdateMutation = `mutation($draftId: ID!, $input: SubscriptionDraftInput!) {
+  subscriptionDraftUpdate(draftId: $draftId, input: $input) {
+    draft { id }
+    userErrors { field message }
+  }
+}`
+
+const subscriptionDraftLineAddMutation = `mutation($draftId: ID!, $input: SubscriptionLineInput!) {
+  subscriptionDraftLineAdd(draftId: $draftId, input: $input) {
+    draft { id }
+    userErrors { field message }
+  }
+}`
+
+const subscriptionDraftLineUpdateMutation = `mutation($draftId: ID!, $lineId: ID!, $input: SubscriptionLineUpdateInput!) {
+  subscriptionDraftLineUpdate(draftId: $draftId, lineId: $lineId, input: $input) {
+    draft { id }
+    userErrors { field message }
+  }
+}`
+
+const subscriptionDraftLineRemoveMutation = `mutation($draftId: ID!, $lineId: ID!) {
+  subscriptionDraftLineRemove(draftId: $draftId, lineId: $lineId) {
+    draft { id }
+    userErrors { field message }
+  }
+}`
+
+const subscriptionDraftCommitMutation = `mutation($draftId: ID!) {
+  subscriptionDraftCommit(draftId: $draftId) {
+    contract { ` + subscriptionContractFields + ` }
+    userErrors { field message }
+  }
+}`
+
+const subscriptionBillingAttemptCreateMutation = `mutation($subscriptionContractId: ID!, $subscriptionBillingAttemptInput: SubscriptionBillingAttemptInput!) {
+  subscriptionBillingAttemptCreate(subscriptionContractId: $subscriptionContractId, subscriptionBillingAttemptInput: $subscriptionBillingAttemptInput) {
+    subscriptionBillingAttempt { ` + subscriptionBillingAttemptFields + ` }
+    userErrors { field message }
+  }
+}`
+
+const subscriptionBillingAttemptQuery = `query($id: ID!) {
+  subscriptionBillingAttempt(id: $id) { ` + subscriptionBillingAttemptFields + ` }
+}`
+
+// List the subscription contracts of the app.
+func (s *SubscriptionContractServiceOp) List() ([]SubscriptionContract, error) {
+	var contracts []SubscriptionContract
+	var after *string
+	for {
+		resp := struct {
+			SubscriptionContracts struct {
+				Edges []struct {
+					Node subscriptionContractNode `json:"node"`
+				} `json:"edges"`
+				PageInfo graphQLPageInfo `json:"pageInfo"`
+			} `json:"subscriptionContracts"`
+		}{}
+
+		err := s.client.GraphQL.Query(subscriptionContractsQuery, map[string]interface{}{"after": after}, &resp)
+		if err != nil {
+			return contracts, err
+		}
+
+		for i := range resp.SubscriptionContracts.Edges {
+			contracts = append(contracts, *resp.SubscriptionContracts.Edges[i].Node.contract())
+		}
+
+		pageInfo := resp.SubscriptionContracts.PageInfo
+		if !pageInfo.HasNextPage {
+			return contracts, nil
+		}
+		after = &pageInfo.EndCursor
+	}
+}
+
+// Get a subscription contract by its ID, nil if there is none.
+func (s *SubscriptionContractServiceOp) Get(id string) (*SubscriptionContract, error) {
+	resp := struct {
+		SubscriptionContract *subscriptionContractNode `json:"subscriptionContract"`
+	}{}
+	err := s.client.GraphQL.Query(subscriptionContractQuery, map[string]interface{}{"id": id}, &resp)
+	return resp.SubscriptionContract.contract(), err
+}
+
+// SetNextBillingDate moves the next billing of a contract to another date.
+func (s *SubscriptionContractServiceOp) SetNextBillingDate(id string, date time.Time) (*SubscriptionContract, error) {
+	return s.edit(id, func(draftID string) error {
+		vars := map[string]interface{}{
+			"draftId": draftID,
+			"input":   map[string]interface{}{"nextBillingDate": date.Format(time.RFC3339)},
+		}
+		return graphQLMutate(s.client, subscriptionDraftUpdateMutation, "subscriptionDraftUpdate", vars)
+	})
+}
+
+// UpdateLines adds, updates and removes lines of a contract. The changes are
+// applied together, either all of them or none.
+func (s *SubscriptionContractServiceOp) UpdateLines(id string, changes ...SubscriptionLineChange) (*SubscriptionContract, error) {
+	return s.edit(id, func(draftID string) error {
+		for _, change := range changes {
+			if err := s.applyLineChange(draftID, change); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *SubscriptionContractServiceOp) applyLineChange(draftID string, change SubscriptionLineChange) error {
+	input := map[string]interface{}{}
+	if change.Quantity != 0 {
+		input["quantity"] = change.Quantity
+	}
+	if change.CurrentPrice != nil {
+		input["currentPrice"] = change.CurrentPrice.String()
+	}
+
+	switch {
+	case change.LineID == "":
+		input["productVariantId"] = variantGID(change.VariantID)
+		vars := map[string]interface{}{"draftId": draftID, "input": input}
+		return graphQLMutate(s.client, subscriptionDraftLineAddMutation, "subscriptionDraftLineAdd", vars)
+	case change.Remove:
+		vars := map[string]interface{}{"draftId": draftID, "lineId": change.LineID}
+		return graphQLMutate(s.client, subscriptionDraftLineRemoveMutation, "subscriptionDraftLineRemove", vars)
+	default:
+		vars := map[string]interface{}{"draftId": draftID, "lineId": change.LineID, "input": input}
+		return graphQLMutate(s.client, subscriptionDraftLineUpdateMutation, "subscriptionDraftLineUpdate", vars)
+	}
+}
+
+// edit changes a contract through a draft, which is created for the
+// contract, changed by fn and committed to return the updated contract.
+func (s *SubscriptionContractServiceOp) edit(id string, fn func(draftID string) error) (*SubscriptionContract, error) {
+	draft := struct {
+		SubscriptionContractUpdate struct {
+			Draft *struct {
+				ID string `json:"id"`
+			} `json:"draft"`
+			UserErrors []UserError `json:"userErrors"`
+		} `json:"subscriptionContractUpdate"`
+	}{}
+	err := s.client.GraphQL.Query(subscriptionContractUpdateMutation, map[string]interface{}{"contractId": id}, &draft)
+	if err == nil {
+		err = userErrorsToError(draft.SubscriptionContractUpdate.UserErrors)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if draft.SubscriptionContractUpdate.Draft == nil {
+		return nil, fmt.Errorf("no draft was created for subscription contract %s", id)
+	}
+	draftID := draft.SubscriptionContractUpdate.Draft.ID
+
+	if err := fn(draftID); err != nil {
+		return nil, err
+	}
+
+	resp := struct {
+		SubscriptionDraftCommit struct {
+			Contract   *subscriptionContractNode `json:"contract"`
+			UserErrors []UserError               `json:"userErrors"`
+		} `json:"subscriptionDraftCommit"`
+	}{}
+	err = s.client.GraphQL.Query(subscriptionDraftCommitMutation, map[string]interface{}{"draftId": draftID}, &resp)
+	if err == nil {
+		err = userErrorsToError(resp.SubscriptionDraftCommit.UserErrors)
+	}
+	return resp.SubscriptionDraftCommit.Contract.contract(), err
+}
+
+// CreateBillingAttempt bills a contract. The attempt is processed
+// asynchronously, see WaitBillingAttempt.
+func (s *SubscriptionContractServiceOp) CreateBillingAttempt(id string, input SubscriptionBillingAttemptInput) (*SubscriptionBillingAttempt, error) {
+	vars := map[string]interface{}{
+		"subscriptionContractId":          id,
+		"subscriptionBillingAttemptInput": input,
+	}
+	resp := struct {
+		SubscriptionBillingAttemptCreate struct {
+			SubscriptionBillingAttempt *subscriptionBillingAttemptNode `json:"subscriptionBillingAttempt"`
+			UserErrors                 []UserError                     `json:"userErrors"`
+		} `json:"subscriptionBillingAttemptCreate"`
+	}{}
+
+	err := s.client.GraphQL.Query(subscriptionBillingAttemptCreateMutation, vars, &resp)
+	if err == nil {
+		err = userErrorsToError(resp.SubscriptionBillingAttemptCreate.UserErrors)
+	}
+	return resp.SubscriptionBillingAttemptCreate.SubscriptionBillingAttempt.attempt(), err
+}
+
+// GetBillingAttempt gets a billing attempt by its ID, nil if there is none.
+func (s *SubscriptionContractServiceOp) GetBillingAttempt(id string) (*SubscriptionBillingAttempt, error) {
+	resp := struct {
+		SubscriptionBillingAttempt *subscriptionBillingAttemptNode `json:"subscriptionBillingAttempt"`
+	}{}
+	err := s.client.GraphQL.Query(subscriptionBillingAttemptQuery, map[string]interface{}{"id": id}, &resp)
+	return resp.SubscriptionBillingAttempt.attempt(), err
+}
+
+// WaitBillingAttempt polls the billing attempt every interval, 2 seconds when
+// zero, until it was processed or the context is done. An attempt that failed
+// is returned along with an error holding its error message.
+func (s *SubscriptionContractServiceOp) WaitBillingAttempt(ctx context.Context, id string, interval time.Duration) (*SubscriptionBillingAttempt, error) {
+	if interval <= 0 {
+		interval = defaultBillingAttemptPollInterval
+	}
+
+	for {
+		attempt, err := s.GetBillingAttempt(id)
+		if err != nil {
+			return nil, err
+		}
+		if attempt == nil {
+			return nil, fmt.Errorf("billing attempt %s not found", id)
+		}
+		if attempt.Ready {
+			if attempt.ErrorMessage != "" {
+				return attempt, fmt.Errorf("billing attempt %s failed: %s", id, attempt.ErrorMessage)
+			}
+			return attempt, nil
+		}
+		if err := sleepContext(ctx, interval); err != nil {
+			return attempt, err
+		}
+	}
+}
@@ -0,0 +1,254 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/shopspring/decimal"
+)
+
+func subscriptionContractTests(t *testing.T, contract SubscriptionContract) {
+	nextBillingDate := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	price := decimal.NewFromFloat(9.5)
+	expected := SubscriptionContract{
+		ID:              "gid://shopify/SubscriptionContract/1",
+		Status:          SubscriptionContractStatusActive,
+		CustomerID:      "gid://shopify/Customer/2",
+		CurrencyCode:    "USD",
+		NextBillingDate: &nextBillingDate,
+		BillingPolicy:   &SellingPlanRecurringPolicy{Interval: SellingPlanIntervalMonth, IntervalCount: 1},
+		DeliveryPolicy:  &SellingPlanRecurringPolicy{Interval: SellingPlanIntervalMonth, IntervalCount: 1},
+		Lines: []SubscriptionLine{{
+			ID:            "gid://shopify/SubscriptionLine/3",
+			VariantID:     "gid://shopify/ProductVariant/4",
+			SellingPlanID: "gid://shopify/SellingPlan/5",
+			Title:         "Coffee",
+			Quantity:      2,
+			CurrentPrice:  &price,
+		}},
+	}
+	if !reflect.DeepEqual(contract, expected) {
+		t.Errorf("SubscriptionContract returned %+v, expected %+v", contract, expected)
+	}
+}
+
+func TestSubscriptionContractGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests, string(loadFixture("subscription_contract.json"))))
+
+	contract, err := client.SubscriptionContract.Get("gid://shopify/SubscriptionContract/1")
+	if err != nil {
+		t.Fatalf("SubscriptionContract.Get returned error: %v", err)
+	}
+
+	subscriptionContractTests(t, *contract)
+}
+
+func TestSubscriptionContractList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests,
+			string(loadFixture("subscription_contracts.json")),
+			`{"data":{"subscriptionContracts":{"edges":[{"node":{"id":"gid://shopify/SubscriptionContract/6","status":"PAUSED","lines":{"edges":[]}}}],"pageInfo":{"hasNextPage":false}}}}`,
+		))
+
+	contracts, err := client.SubscriptionContract.List()
+	if err != nil {
+		t.Fatalf("SubscriptionContract.List returned error: %v", err)
+	}
+	if len(contracts) != 2 {
+		t.Fatalf("SubscriptionContract.List returned %d contracts, expected 2", len(contracts))
+	}
+	subscriptionContractTests(t, contracts[0])
+	if contracts[1].ID != "gid://shopify/SubscriptionContract/6" || contracts[1].Status != SubscriptionContractStatusPaused {
+		t.Errorf("SubscriptionContract.List returned %+v", contracts[1])
+	}
+	if len(requests) != 2 || requests[1].Variables.(map[string]interface{})["after"] != "abc" {
+		t.Errorf("SubscriptionContract.List sent %+v, expected the second page after abc", requests)
+	}
+}
+
+func TestSubscriptionContractSetNextBillingDate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests,
+			`{"data":{"subscriptionContractUpdate":{"draft":{"id":"gid://shopify/SubscriptionDraft/7"},"userErrors":[]}}}`,
+			`{"data":{"subscriptionDraftUpdate":{"draft":{"id":"gid://shopify/SubscriptionDraft/7"},"userErrors":[]}}}`,
+			string(loadFixture("subscription_draft_commit.json")),
+		))
+
+	contract, err := client.SubscriptionContract.SetNextBillingDate("gid://shopify/SubscriptionContract/1", time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("SubscriptionContract.SetNextBillingDate returned error: %v", err)
+	}
+	if contract == nil {
+		t.Fatalf("SubscriptionContract.SetNextBillingDate returned no contract")
+	}
+	subscriptionContractTests(t, *contract)
+
+	expected := []interface{}{
+		map[string]interface{}{"contractId": "gid://shopify/SubscriptionContract/1"},
+		map[string]interface{}{
+			"draftId": "gid://shopify/SubscriptionDraft/7",
+			"input":   map[string]interface{}{"nextBillingDate": "2024-02-01T00:00:00Z"},
+		},
+		map[string]interface{}{"draftId": "gid://shopify/SubscriptionDraft/7"},
+	}
+	if len(requests) != len(expected) {
+		t.Fatalf("SubscriptionContract.SetNextBillingDate sent %d requests, expected %d", len(requests), len(expected))
+	}
+	for i := range expected {
+		if !reflect.DeepEqual(requests[i].Variables, expected[i]) {
+			t.Errorf("request %d sent %+v, expected %+v", i, requests[i].Variables, expected[i])
+		}
+	}
+}
+
+func TestSubscriptionContractUpdateLines(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests,
+			`{"data":{"subscriptionContractUpdate":{"draft":{"id":"gid://shopify/SubscriptionDraft/7"},"userErrors":[]}}}`,
+			`{"data":{"subscriptionDraftLineAdd":{"draft":{"id":"gid://shopify/SubscriptionDraft/7"},"userErrors":[]}}}`,
+			`{"data":{"subscriptionDraftLineUpdate":{"draft":{"id":"gid://shopify/SubscriptionDraft/7"},"userErrors":[]}}}`,
+			`{"data":{"subscriptionDraftLineRemove":{"draft":{"id":"gid://shopify/SubscriptionDraft/7"},"userErrors":[]}}}`,
+			string(loadFixture("subscription_draft_commit.json")),
+		))
+
+	price := decimal.NewFromFloat(8)
+	_, err := client.SubscriptionContract.UpdateLines("gid://shopify/SubscriptionContract/1",
+		SubscriptionLineChange{VariantID: 8, Quantity: 1},
+		SubscriptionLineChange{LineID: "gid://shopify/SubscriptionLine/3", Quantity: 3, CurrentPrice: &price},
+		SubscriptionLineChange{LineID: "gid://shopify/SubscriptionLine/9", Remove: true},
+	)
+	if err != nil {
+		t.Fatalf("SubscriptionContract.UpdateLines returned error: %v", err)
+	}
+
+	expected := []interface{}{
+		map[string]interface{}{"contractId": "gid://shopify/SubscriptionContract/1"},
+		map[string]interface{}{
+			"draftId": "gid://shopify/SubscriptionDraft/7",
+			"input":   map[string]interface{}{"productVariantId": "gid://shopify/ProductVariant/8", "quantity": float64(1)},
+		},
+		map[string]interface{}{
+			"draftId": "gid://shopify/SubscriptionDraft/7",
+			"lineId":  "gid://shopify/SubscriptionLine/3",
+			"input":   map[string]interface{}{"quantity": float64(3), "currentPrice": "8"},
+		},
+		map[string]interface{}{"draftId": "gid://shopify/SubscriptionDraft/7", "lineId": "gid://shopify/SubscriptionLine/9"},
+		map[string]interface{}{"draftId": "gid://shopify/SubscriptionDraft/7"},
+	}
+	if len(requests) != len(expected) {
+		t.Fatalf("SubscriptionContract.UpdateLines sent %d requests, expected %d", len(requests), len(expected))
+	}
+	for i := range expected {
+		if !reflect.DeepEqual(requests[i].Variables, expected[i]) {
+			t.Errorf("request %d sent %+v, expected %+v", i, requests[i].Variables, expected[i])
+		}
+	}
+}
+
+func TestSubscriptionContractUpdateLinesUserError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests,
+			`{"data":{"subscriptionContractUpdate":{"draft":{"id":"gid://shopify/SubscriptionDraft/7"},"userErrors":[]}}}`,
+			`{"data":{"subscriptionDraftLineRemove":{"draft":null,"userErrors":[{"field":["lineId"],"message":"Line does not exist"}]}}}`,
+		))
+
+	_, err := client.SubscriptionContract.UpdateLines("gid://shopify/SubscriptionContract/1",
+		SubscriptionLineChange{LineID: "gid://shopify/SubscriptionLine/9", Remove: true})
+	if err == nil || err.Error() != "lineId: Line does not exist" {
+		t.Errorf("SubscriptionContract.UpdateLines returned %v, expected the user error", err)
+	}
+	if len(requests) != 2 {
+		t.Errorf("SubscriptionContract.UpdateLines sent %d requests, expected the draft not to be committed", len(requests))
+	}
+}
+
+func TestSubscriptionContractCreateBillingAttempt(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests, `{"data":{"subscriptionBillingAttemptCreate":{"subscriptionBillingAttempt":{"id":"gid://shopify/SubscriptionBillingAttempt/10","idempotencyKey":"key","ready":false},"userErrors":[]}}}`))
+
+	attempt, err := client.SubscriptionContract.CreateBillingAttempt("gid://shopify/SubscriptionContract/1", SubscriptionBillingAttemptInput{IdempotencyKey: "key"})
+	if err != nil {
+		t.Fatalf("SubscriptionContract.CreateBillingAttempt returned error: %v", err)
+	}
+
+	expected := &SubscriptionBillingAttempt{ID: "gid://shopify/SubscriptionBillingAttempt/10", IdempotencyKey: "key"}
+	if !reflect.DeepEqual(attempt, expected) {
+		t.Errorf("SubscriptionContract.CreateBillingAttempt returned %+v, expected %+v", attempt, expected)
+	}
+
+	expectedVars := map[string]interface{}{
+		"subscriptionContractId":          "gid://shopify/SubscriptionContract/1",
+		"subscriptionBillingAttemptInput": map[string]interface{}{"idempotencyKey": "key"},
+	}
+	if !reflect.DeepEqual(requests[0].Variables, expectedVars) {
+		t.Errorf("SubscriptionContract.CreateBillingAttempt sent %+v, expected %+v", requests[0].Variables, expectedVars)
+	}
+}
+
+func TestSubscriptionContractWaitBillingAttempt(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests,
+			`{"data":{"subscriptionBillingAttempt":{"id":"gid://shopify/SubscriptionBillingAttempt/10","ready":false,"order":null}}}`,
+			`{"data":{"subscriptionBillingAttempt":{"id":"gid://shopify/SubscriptionBillingAttempt/10","ready":true,"order":{"id":"gid://shopify/Order/11"}}}}`,
+		))
+
+	attempt, err := client.SubscriptionContract.WaitBillingAttempt(context.Background(), "gid://shopify/SubscriptionBillingAttempt/10", time.Millisecond)
+	if err != nil {
+		t.Fatalf("SubscriptionContract.WaitBillingAttempt returned error: %v", err)
+	}
+	if attempt.OrderID != "gid://shopify/Order/11" || len(requests) != 2 {
+		t.Errorf("SubscriptionContract.WaitBillingAttempt returned %+v after %d requests", attempt, len(requests))
+	}
+}
+
+func TestSubscriptionContractWaitBillingAttemptFailed(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests,
+			`{"data":{"subscriptionBillingAttempt":{"id":"gid://shopify/SubscriptionBillingAttempt/10","ready":true,"errorCode":"PAYMENT_METHOD_DECLINED","errorMessage":"Card was declined"}}}`,
+		))
+
+	attempt, err := client.SubscriptionContract.WaitBillingAttempt(context.Background(), "gid://shopify/SubscriptionBillingAttempt/10", time.Millisecond)
+	if attempt == nil || attempt.ErrorCode != "PAYMENT_METHOD_DECLINED" {
+		t.Errorf("SubscriptionContract.WaitBillingAttempt returned %+v, expected the failed attempt", attempt)
+	}
+	if err == nil || err.Error() != "billing attempt gid://shopify/SubscriptionBillingAttempt/10 failed: Card was declined" {
+		t.Errorf("SubscriptionContract.WaitBillingAttempt returned error %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+package goshopify
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Tags are the tags of a resource like a product, customer or order, which
+// Shopify sends as a comma separated string. Tags are trimmed and duplicates
+// dropped when decoding and encoding.
+type Tags []string
+
+// ParseTags splits a comma separated string of tags.
+func ParseTags(s string) Tags {
+	return Tags(strings.Split(s, ",")).normalize()
+}
+
+// String joins the tags the way Shopify does, e.g. "sale, summer".
+func (t Tags) String() string {
+	return strings.Join(t.normalize(), ", ")
+}
+
+// Has reports whether the tag is one of the tags.
+func (t Tags) Has(tag string) bool {
+	tag = strings.TrimSpace(tag)
+	for _, existing := range t {
+		if strings.TrimSpace(existing) == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// Add returns the tags with the given ones appended, unless they already
+// exist.
+func (t Tags) Add(tags ...string) Tags {
+	return append(append(Tags(nil), t...), tags...).normalize()
+}
+
+// Remove returns the tags without the given ones.
+func (t Tags) Remove(tags ...string) Tags {
+	removed := Tags(tags).normalize()
+	var kept Tags
+	for _, tag := range t.normalize() {
+		if !removed.Has(tag) {
+			kept = append(kept, tag)
+		}
+	}
+	return kept
+}
+
+// MarshalJSON encodes the tags as a comma separated string.
+func (t Tags) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+// UnmarshalJSON decodes a comma separated string of tags, or a list of them
+// like GraphQL returns.
+func (t *Tags) UnmarshalJSON(data []byte) error {
+	var list []string
+	if err := json.Unmarshal(data, &list); err == nil {
+		*t = Tags(list).normalize()
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	*t = ParseTags(s)
+	return nil
+}
+
+// normalize trims the tags and drops empty and duplicate ones.
+func (t Tags) normalize() Tags {
+	var tags Tags
+	seen := map[string]bool{}
+	for _, tag := range t {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+	return tags
+}
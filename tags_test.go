@@ -0,0 +1,97 @@
+package goshopify
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestParseTags(t *testing.T) {
+	cases := []struct {
+		in       string
+		expected Tags
+	}{
+		{"", nil},
+		{"sale", Tags{"sale"}},
+		{" sale , summer,,sale, ", Tags{"sale", "summer"}},
+	}
+
+	for _, c := range cases {
+		if tags := ParseTags(c.in); !reflect.DeepEqual(tags, c.expected) {
+			t.Errorf("ParseTags(%q) = %#v, expected %#v", c.in, tags, c.expected)
+		}
+	}
+}
+
+func TestTagsAddRemove(t *testing.T) {
+	tags := Tags{"sale", "summer"}
+
+	added := tags.Add("winter", " sale ")
+	if !reflect.DeepEqual(added, Tags{"sale", "summer", "winter"}) {
+		t.Errorf("Tags.Add returned %#v", added)
+	}
+	if !reflect.DeepEqual(tags, Tags{"sale", "summer"}) {
+		t.Errorf("Tags.Add modified the tags: %#v", tags)
+	}
+
+	removed := added.Remove("sale")
+	if !reflect.DeepEqual(removed, Tags{"summer", "winter"}) {
+		t.Errorf("Tags.Remove returned %#v", removed)
+	}
+
+	if !removed.Has(" winter") || removed.Has("sale") {
+		t.Errorf("Tags.Has returned wrong results for %#v", removed)
+	}
+	if s := removed.String(); s != "summer, winter" {
+		t.Errorf("Tags.String returned %q", s)
+	}
+}
+
+func TestTagsJSON(t *testing.T) {
+	product := Product{Tags: Tags{"sale", "summer", "sale"}}
+	data, err := json.Marshal(product)
+	if err != nil {
+		t.Fatalf("json.Marshal returned error: %v", err)
+	}
+	if string(data) != `{"tags":"sale, summer","image":{}}` {
+		t.Errorf("json.Marshal returned %s", data)
+	}
+
+	data, _ = json.Marshal(Product{})
+	if string(data) != `{"image":{}}` {
+		t.Errorf("json.Marshal returned %s, expected no tags", data)
+	}
+
+	for _, in := range []string{`{"tags":"sale, summer"}`, `{"tags":["sale","summer"]}`} {
+		decoded := Product{}
+		if err := json.Unmarshal([]byte(in), &decoded); err != nil {
+			t.Fatalf("json.Unmarshal(%s) returned error: %v", in, err)
+		}
+		if !reflect.DeepEqual(decoded.Tags, Tags{"sale", "summer"}) {
+			t.Errorf("json.Unmarshal(%s) returned tags %#v", in, decoded.Tags)
+		}
+	}
+
+	if err := json.Unmarshal([]byte(`{"tags":1}`), &Product{}); err == nil {
+		t.Errorf("json.Unmarshal returned no error for numeric tags")
+	}
+}
+
+func TestOrderTags(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"order":{"id":1,"tags":"gift, priority"}}`))
+
+	order, err := client.Order.Get(1, nil)
+	if err != nil {
+		t.Fatalf("Order.Get returned error: %v", err)
+	}
+	if !order.Tags.Has("priority") {
+		t.Errorf("Order.Tags = %#v, expected priority", order.Tags)
+	}
+}
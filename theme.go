@@ -56,35 +56,25 @@ type ThemesResource struct {
 // List all themes
 func (s *ThemeServiceOp) List(options interface{}) ([]Theme, error) {
 	path := fmt.Sprintf("%s.json", themesBasePath)
-	resource := new(ThemesResource)
-	err := s.client.Get(path, resource, options)
-	return resource.Themes, err
+	return listResource[Theme](s.client, path, "themes", options)
 }
 
 // Update a theme
 func (s *ThemeServiceOp) Create(theme Theme) (*Theme, error) {
 	path := fmt.Sprintf("%s.json", themesBasePath)
-	wrappedData := ThemeResource{Theme: &theme}
-	resource := new(ThemeResource)
-	err := s.client.Post(path, wrappedData, resource)
-	return resource.Theme, err
+	return createResource(s.client, path, "theme", theme)
 }
 
 // Get a theme
 func (s *ThemeServiceOp) Get(themeID int64, options interface{}) (*Theme, error) {
 	path := fmt.Sprintf("%s/%d.json", themesBasePath, themeID)
-	resource := new(ThemeResource)
-	err := s.client.Get(path, resource, options)
-	return resource.Theme, err
+	return getResource[Theme](s.client, path, "theme", options)
 }
 
 // Update a theme
 func (s *ThemeServiceOp) Update(theme Theme) (*Theme, error) {
 	path := fmt.Sprintf("%s/%d.json", themesBasePath, theme.ID)
-	wrappedData := ThemeResource{Theme: &theme}
-	resource := new(ThemeResource)
-	err := s.client.Put(path, wrappedData, resource)
-	return resource.Theme, err
+	return updateResource(s.client, path, "theme", theme)
 }
 
 // Delete a theme
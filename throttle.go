@@ -0,0 +1,132 @@
+package goshopify
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// BucketLimits describes one of Shopify's leaky buckets: how much it holds
+// and how much drains from it per second. REST buckets count requests,
+// GraphQL buckets count query cost points.
+// See: https://shopify.dev/concepts/about-apis/rate-limits
+type BucketLimits struct {
+	Size     float64
+	LeakRate float64
+}
+
+var (
+	StandardRESTLimits    = BucketLimits{Size: 40, LeakRate: 2}
+	PlusRESTLimits        = BucketLimits{Size: 400, LeakRate: 20}
+	StandardGraphQLLimits = BucketLimits{Size: 1000, LeakRate: 50}
+	PlusGraphQLLimits     = BucketLimits{Size: 2000, LeakRate: 100}
+)
+
+// defaultGraphQLCost is the cost reserved for a GraphQL query before the cost
+// of an earlier query is known.
+const defaultGraphQLCost = 50
+
+// leakyBucket is a client side model of one of Shopify's rate limit buckets.
+type leakyBucket struct {
+	limits BucketLimits
+
+	mu       sync.Mutex
+	level    float64
+	leakedAt time.Time
+	// cost reserved per request
+	cost float64
+}
+
+func newLeakyBucket(limits BucketLimits, cost float64) *leakyBucket {
+	if limits.Size <= 0 || limits.LeakRate <= 0 {
+		return nil
+	}
+	return &leakyBucket{limits: limits, leakedAt: time.Now(), cost: cost}
+}
+
+// leak drains the bucket for the time passed since the last leak, it must be
+// called with the lock held.
+func (b *leakyBucket) leak(now time.Time) {
+	b.level -= now.Sub(b.leakedAt).Seconds() * b.limits.LeakRate
+	if b.level < 0 {
+		b.level = 0
+	}
+	b.leakedAt = now
+}
+
+// take blocks until the bucket has room for a request and reserves it, or
+// returns the context's error when it is done first. onWait, if not nil, is
+// called before every wait.
+func (b *leakyBucket) take(ctx context.Context, onWait func(time.Duration)) error {
+	for {
+		b.mu.Lock()
+		b.leak(time.Now())
+		cost := b.cost
+		if cost > b.limits.Size {
+			cost = b.limits.Size
+		}
+		if b.level+cost <= b.limits.Size {
+			b.level += cost
+			b.mu.Unlock()
+			return nil
+		}
+		wait := time.Duration((b.level + cost - b.limits.Size) / b.limits.LeakRate * float64(time.Second))
+		b.mu.Unlock()
+
+		if onWait != nil {
+			onWait(wait)
+		}
+		if err := sleepContext(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// sync raises the bucket to the level Shopify reported, a lower reported
+// level is ignored since it may not include requests still in flight.
+func (b *leakyBucket) sync(level float64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.leak(time.Now())
+	if level > b.level {
+		b.level = level
+	}
+}
+
+// setCost sets the cost reserved for the following requests.
+func (b *leakyBucket) setCost(cost float64) {
+	if cost <= 0 {
+		return
+	}
+	b.mu.Lock()
+	b.cost = cost
+	b.mu.Unlock()
+}
+
+// bucketFor returns the bucket a request counts against, if any.
+func (c *Client) bucketFor(req *http.Request) *leakyBucket {
+	if strings.HasSuffix(req.URL.Path, "/"+graphQLPath) {
+		return c.graphQLBucket
+	}
+	return c.restBucket
+}
+
+// throttle blocks until the request fits in its bucket.
+func (c *Client) throttle(req *http.Request) error {
+	bucket := c.bucketFor(req)
+	if bucket == nil {
+		return nil
+	}
+	return bucket.take(req.Context(), c.rateLimited)
+}
+
+// rateLimited tells the OnRateLimited hook the client is about to wait for
+// the rate limit.
+func (c *Client) rateLimited(wait time.Duration) {
+	if c.onRateLimited != nil && wait > 0 {
+		c.onRateLimited(c.baseURL.Host, wait)
+	}
+}
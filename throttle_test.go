@@ -0,0 +1,190 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestLeakyBucketTake(t *testing.T) {
+	bucket := newLeakyBucket(BucketLimits{Size: 2, LeakRate: 20}, 1)
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := bucket.take(context.Background(), nil); err != nil {
+			t.Fatalf("leakyBucket.take() returned error: %v", err)
+		}
+	}
+
+	// the first two fit, the next two each wait for 1/20th of a second
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("leakyBucket.take() 4 times took %s, expected at least 100ms", elapsed)
+	}
+}
+
+func TestLeakyBucketTakeCanceled(t *testing.T) {
+	bucket := newLeakyBucket(BucketLimits{Size: 1, LeakRate: 0.1}, 1)
+	_ = bucket.take(context.Background(), nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := bucket.take(ctx, nil); err != context.DeadlineExceeded {
+		t.Errorf("leakyBucket.take() returned %v, expected %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestLeakyBucketSync(t *testing.T) {
+	bucket := newLeakyBucket(BucketLimits{Size: 40, LeakRate: 2}, 1)
+
+	bucket.sync(30)
+	if bucket.level < 29 || bucket.level > 30 {
+		t.Errorf("leakyBucket.sync(30) set level %v, expected 30", bucket.level)
+	}
+
+	// lower levels may miss requests in flight and are ignored
+	bucket.sync(5)
+	if bucket.level < 29 {
+		t.Errorf("leakyBucket.sync(5) lowered level to %v", bucket.level)
+	}
+}
+
+func TestNewLeakyBucketDisabled(t *testing.T) {
+	if bucket := newLeakyBucket(BucketLimits{}, 1); bucket != nil {
+		t.Errorf("newLeakyBucket() with zero limits returned %v, expected nil", bucket)
+	}
+}
+
+func TestWithRateLimiter(t *testing.T) {
+	c := NewClient(app, "fooshop", "abcd")
+	if c.restBucket != nil || c.graphQLBucket != nil {
+		t.Errorf("NewClient enabled rate limiting")
+	}
+
+	c = NewClient(app, "fooshop", "abcd", WithRateLimiter(PlusRESTLimits, BucketLimits{}))
+	if c.restBucket == nil || c.restBucket.limits != PlusRESTLimits {
+		t.Errorf("WithRateLimiter client.restBucket = %+v, expected limits %+v", c.restBucket, PlusRESTLimits)
+	}
+	if c.graphQLBucket != nil {
+		t.Errorf("WithRateLimiter client.graphQLBucket = %+v, expected nil", c.graphQLBucket)
+	}
+}
+
+func TestRateLimiterConcurrentRequests(t *testing.T) {
+	setup()
+	defer teardown()
+	WithRateLimiter(BucketLimits{Size: 2, LeakRate: 20}, BucketLimits{})(client)
+
+	var mu sync.Mutex
+	var requests []time.Time
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/foo/1", func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		requests = append(requests, time.Now())
+		mu.Unlock()
+		return httpmock.NewStringResponse(http.StatusOK, `{}`), nil
+	})
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, _ := client.NewRequest("GET", "foo/1", nil, nil)
+			_ = client.Do(req, nil)
+		}()
+	}
+	wg.Wait()
+
+	if len(requests) != 4 {
+		t.Fatalf("made %d requests, expected 4", len(requests))
+	}
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("4 requests with a bucket of 2 took %s, expected at least 100ms", elapsed)
+	}
+}
+
+func TestRateLimiterGraphQLCost(t *testing.T) {
+	setup()
+	defer teardown()
+	WithRateLimiter(BucketLimits{}, StandardGraphQLLimits)(client)
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"data":{},"extensions":{"cost":{"requestedQueryCost":120,"actualQueryCost":100,"throttleStatus":{"maximumAvailable":1000.0,"currentlyAvailable":600,"restoreRate":50.0}}}}`))
+
+	if err := client.GraphQL.Query("{ shop { name } }", nil, nil); err != nil {
+		t.Fatalf("GraphQL.Query returned error: %v", err)
+	}
+
+	bucket := client.graphQLBucket
+	if bucket.cost != 120 {
+		t.Errorf("graphQLBucket.cost = %v, expected 120", bucket.cost)
+	}
+	if bucket.level < 399 || bucket.level > 400 {
+		t.Errorf("graphQLBucket.level = %v, expected 400", bucket.level)
+	}
+}
+
+func TestOnRateLimited(t *testing.T) {
+	setup()
+	defer teardown()
+
+	type wait struct {
+		shop string
+		wait time.Duration
+	}
+	var waits []wait
+	WithOnRateLimited(func(shop string, d time.Duration) {
+		waits = append(waits, wait{shop, d})
+	})(client)
+	WithRetry(2)(client)
+
+	calls := 0
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/foo/1", func(req *http.Request) (*http.Response, error) {
+		calls++
+		if calls == 1 {
+			resp := httpmock.NewStringResponse(http.StatusTooManyRequests, `{"errors":"Exceeded 2 calls per second for api client."}`)
+			resp.Header.Add("Retry-After", "0.01")
+			return resp, nil
+		}
+		return httpmock.NewStringResponse(http.StatusOK, `{}`), nil
+	})
+
+	req, _ := client.NewRequest("GET", "foo/1", nil, nil)
+	if err := client.Do(req, nil); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+
+	if len(waits) != 1 || waits[0].shop != "fooshop.myshopify.com" || waits[0].wait != 10*time.Millisecond {
+		t.Errorf("OnRateLimited was called with %+v, expected fooshop.myshopify.com and 10ms", waits)
+	}
+}
+
+func TestOnRateLimitedBucketFull(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var waited time.Duration
+	WithOnRateLimited(func(shop string, d time.Duration) {
+		waited += d
+	})(client)
+	WithRateLimiter(BucketLimits{Size: 1, LeakRate: 50}, BucketLimits{})(client)
+
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/foo/1",
+		httpmock.NewStringResponder(http.StatusOK, `{}`))
+
+	for i := 0; i < 2; i++ {
+		req, _ := client.NewRequest("GET", "foo/1", nil, nil)
+		if err := client.Do(req, nil); err != nil {
+			t.Fatalf("Do returned error: %v", err)
+		}
+	}
+
+	if waited <= 0 {
+		t.Error("OnRateLimited wasn't called while the bucket was full")
+	}
+}
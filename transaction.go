@@ -31,9 +31,7 @@ type TransactionsResource struct {
 // List transactions
 func (s *TransactionServiceOp) List(orderID int64, options interface{}) ([]Transaction, error) {
 	path := fmt.Sprintf("%s/%d/transactions.json", ordersBasePath, orderID)
-	resource := new(TransactionsResource)
-	err := s.client.Get(path, resource, options)
-	return resource.Transactions, err
+	return listResource[Transaction](s.client, path, "transactions", options)
 }
 
 // Count transactions
@@ -45,16 +43,11 @@ func (s *TransactionServiceOp) Count(orderID int64, options interface{}) (int, e
 // Get individual transaction
 func (s *TransactionServiceOp) Get(orderID int64, transactionID int64, options interface{}) (*Transaction, error) {
 	path := fmt.Sprintf("%s/%d/transactions/%d.json", ordersBasePath, orderID, transactionID)
-	resource := new(TransactionResource)
-	err := s.client.Get(path, resource, options)
-	return resource.Transaction, err
+	return getResource[Transaction](s.client, path, "transaction", options)
 }
 
 // Create a new transaction
 func (s *TransactionServiceOp) Create(orderID int64, transaction Transaction) (*Transaction, error) {
 	path := fmt.Sprintf("%s/%d/transactions.json", ordersBasePath, orderID)
-	wrappedData := TransactionResource{Transaction: &transaction}
-	resource := new(TransactionResource)
-	err := s.client.Post(path, wrappedData, resource)
-	return resource.Transaction, err
+	return createResource(s.client, path, "transaction", transaction)
 }
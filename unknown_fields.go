@@ -0,0 +1,145 @@
+package goshopify
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// UnknownFields describes the fields of a response that the struct it was
+// decoded into does not model, e.g. because Shopify added them in a newer API
+// version. Fields are paths like "product.variants[0].unit_price".
+type UnknownFields struct {
+	Method string
+	URL    string
+	Fields []string
+}
+
+// UnknownFieldHandler is called for every response with unknown fields, see
+// WithUnknownFieldHandler.
+type UnknownFieldHandler func(UnknownFields)
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// checkUnknownFields reports the fields of data that v does not model to the
+// unknown field handler, if one is set.
+func (c *Client) checkUnknownFields(method, url string, data []byte, v interface{}) {
+	if c.unknownFieldHandler == nil || v == nil {
+		return
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return
+	}
+
+	var fields []string
+	collectUnknownFields(decoded, reflect.TypeOf(v), "", &fields)
+	if len(fields) == 0 {
+		return
+	}
+	sort.Strings(fields)
+	c.unknownFieldHandler(UnknownFields{Method: method, URL: url, Fields: fields})
+}
+
+// collectUnknownFields walks the decoded JSON value alongside the type it is
+// decoded into and appends the paths of the object keys the type has no field
+// for. Types decoding themselves, e.g. time.Time, and interfaces accept
+// anything.
+func collectUnknownFields(value interface{}, t reflect.Type, path string, fields *[]string) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if reflect.PtrTo(t).Implements(jsonUnmarshalerType) || reflect.PtrTo(t).Implements(textUnmarshalerType) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return
+		}
+		known := jsonFields(t)
+		for key, v := range object {
+			fieldType, ok := known[key]
+			if !ok {
+				fieldType, ok = known[strings.ToLower(key)]
+			}
+			if !ok {
+				*fields = append(*fields, joinFieldPath(path, key))
+				continue
+			}
+			collectUnknownFields(v, fieldType, joinFieldPath(path, key), fields)
+		}
+	case reflect.Map:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return
+		}
+		for key, v := range object {
+			collectUnknownFields(v, t.Elem(), joinFieldPath(path, key), fields)
+		}
+	case reflect.Slice, reflect.Array:
+		array, ok := value.([]interface{})
+		if !ok {
+			return
+		}
+		for i, v := range array {
+			collectUnknownFields(v, t.Elem(), path+"["+strconv.Itoa(i)+"]", fields)
+		}
+	}
+}
+
+// jsonFields returns the types of the fields of a struct by their JSON name,
+// including the fields of embedded structs. Names are also added in lower
+// case since encoding/json matches them case insensitively.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			for embeddedName, embeddedType := range jsonFields(fieldType) {
+				if _, ok := fields[embeddedName]; !ok {
+					fields[embeddedName] = embeddedType
+				}
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field.Type
+		if _, ok := fields[strings.ToLower(name)]; !ok {
+			fields[strings.ToLower(name)] = field.Type
+		}
+	}
+	return fields
+}
+
+func joinFieldPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
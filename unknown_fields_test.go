@@ -0,0 +1,101 @@
+package goshopify
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestUnknownFieldHandler(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var received []UnknownFields
+	WithUnknownFieldHandler(func(u UnknownFields) {
+		received = append(received, u)
+	})(client)
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"product":{"id":1,"title":"Shirt","new_field":true,"created_at":"2020-01-01T00:00:00Z","variants":[{"id":2,"Title":"S","unit_price":"1.00"}],"options":[{"id":3,"values":["S"]}]}}`))
+
+	product, err := client.Product.Get(1, nil)
+	if err != nil {
+		t.Fatalf("Product.Get returned error: %v", err)
+	}
+	if product.Title != "Shirt" || product.Variants[0].Title != "S" {
+		t.Errorf("Product.Get returned %+v", product)
+	}
+
+	expected := []UnknownFields{{
+		Method: "GET",
+		URL:    fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix),
+		Fields: []string{"product.new_field", "product.variants[0].unit_price"},
+	}}
+	if !reflect.DeepEqual(received, expected) {
+		t.Errorf("UnknownFieldHandler received %+v, expected %+v", received, expected)
+	}
+}
+
+func TestUnknownFieldHandlerGraphQL(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var received []UnknownFields
+	WithUnknownFieldHandler(func(u UnknownFields) {
+		received = append(received, u)
+	})(client)
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"data":{"shop":{"name":"Foo","plan":{"displayName":"Basic"}}}}`))
+
+	resp := struct {
+		Shop struct {
+			Name string `json:"name"`
+		} `json:"shop"`
+	}{}
+	if err := client.GraphQL.Query("{ shop { name plan { displayName } } }", nil, &resp); err != nil {
+		t.Fatalf("GraphQL.Query returned error: %v", err)
+	}
+
+	if len(received) != 1 || !reflect.DeepEqual(received[0].Fields, []string{"shop.plan"}) {
+		t.Errorf("UnknownFieldHandler received %+v, expected shop.plan", received)
+	}
+}
+
+func TestCollectUnknownFields(t *testing.T) {
+	type Embedded struct {
+		Inner string `json:"inner"`
+	}
+	type Item struct {
+		Embedded
+		Name    string                 `json:"name"`
+		Ignored string                 `json:"-"`
+		Extra   map[string]interface{} `json:"extra"`
+		Values  map[string]Embedded    `json:"values"`
+	}
+
+	cases := []struct {
+		data     string
+		expected []string
+	}{
+		{`{"name":"a","inner":"b"}`, nil},
+		{`{"NAME":"a"}`, nil},
+		{`{"name":"a","Ignored":"b"}`, []string{"Ignored"}},
+		{`{"extra":{"anything":{"goes":1}}}`, nil},
+		{`{"values":{"x":{"inner":"a","other":1}}}`, []string{"values.x.other"}},
+		{`{"name":null,"missing":[1,2]}`, []string{"missing"}},
+	}
+
+	for _, c := range cases {
+		var fields []string
+		var decoded interface{}
+		json.Unmarshal([]byte(c.data), &decoded)
+		collectUnknownFields(decoded, reflect.TypeOf(&Item{}), "", &fields)
+		if !reflect.DeepEqual(fields, c.expected) {
+			t.Errorf("collectUnknownFields(%s) = %v, expected %v", c.data, fields, c.expected)
+		}
+	}
+}
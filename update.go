@@ -0,0 +1,67 @@
+package goshopify
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// Update is a sparse update of a resource, sent by the UpdateFields methods.
+// Only the fields that are set are sent, as given, so a zero value such as
+// false, 0 or "" is sent instead of being omitted like it is from a struct,
+// and no other field is touched. Fields are named by their JSON names.
+//
+//	update := goshopify.NewUpdate().Set("title", "Summer shirt").Set("published", false).SetNull("published_at")
+//	product, err := client.Product.UpdateFields(1, update)
+type Update struct {
+	fields map[string]interface{}
+}
+
+// NewUpdate returns an empty update.
+func NewUpdate() *Update {
+	return &Update{fields: map[string]interface{}{}}
+}
+
+// Set sets the field to value, which is encoded like any other JSON value.
+func (u *Update) Set(field string, value interface{}) *Update {
+	if u.fields == nil {
+		u.fields = map[string]interface{}{}
+	}
+	u.fields[field] = value
+	return u
+}
+
+// SetNull sets the field to null, clearing it, like NullFields does for
+// struct updates.
+func (u *Update) SetNull(field string) *Update {
+	return u.Set(field, nil)
+}
+
+// MarshalJSON encodes the fields that are set.
+func (u *Update) MarshalJSON() ([]byte, error) {
+	if u == nil || u.fields == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(u.fields)
+}
+
+// forResource checks the fields against the JSON fields of the resource type
+// t, so a typo fails instead of being ignored by Shopify, and returns the
+// fields to send for the resource id.
+func (u *Update) forResource(t reflect.Type, id int64) (map[string]interface{}, error) {
+	known := jsonFields(t)
+	fields := map[string]interface{}{"id": id}
+	if u == nil {
+		return fields, nil
+	}
+	for name, value := range u.fields {
+		if _, ok := known[name]; !ok {
+			return nil, fmt.Errorf("unknown update field %q", name)
+		}
+		if name == "id" {
+			continue
+		}
+		fields[name] = value
+	}
+	return fields, nil
+}
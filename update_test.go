@@ -0,0 +1,138 @@
+package goshopify
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestUpdateMarshalJSON(t *testing.T) {
+	update := NewUpdate().Set("title", "").Set("published", false).Set("tags", "a, b").SetNull("published_at")
+
+	data, err := json.Marshal(update)
+	if err != nil {
+		t.Fatalf("json.Marshal returned error: %v", err)
+	}
+	expected := `{"published":false,"published_at":null,"tags":"a, b","title":""}`
+	if string(data) != expected {
+		t.Errorf("json.Marshal(update) = %s, expected %s", data, expected)
+	}
+
+	data, _ = json.Marshal(NewUpdate())
+	if string(data) != `{}` {
+		t.Errorf("json.Marshal(NewUpdate()) = %s, expected {}", data)
+	}
+}
+
+func TestProductUpdateFields(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var body string
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			data, _ := ioutil.ReadAll(req.Body)
+			body = string(data)
+			return httpmock.NewStringResponse(200, `{"product":{"id":1,"title":"Summer shirt"}}`), nil
+		})
+
+	update := NewUpdate().Set("title", "Summer shirt").Set("published", false).SetNull("published_at")
+	product, err := client.Product.UpdateFields(1, update)
+	if err != nil {
+		t.Fatalf("Product.UpdateFields returned error: %v", err)
+	}
+	if product.ID != 1 || product.Title != "Summer shirt" {
+		t.Errorf("Product.UpdateFields returned %+v", product)
+	}
+
+	expected := `{"product":{"id":1,"published":false,"published_at":null,"title":"Summer shirt"}}`
+	if body != expected {
+		t.Errorf("Product.UpdateFields sent %s, expected %s", body, expected)
+	}
+}
+
+func TestUpdateFieldsUnknownField(t *testing.T) {
+	setup()
+	defer teardown()
+
+	_, err := client.Variant.UpdateFields(1, NewUpdate().Set("compare_price", "10.00"))
+	if err == nil || err.Error() != `unknown update field "compare_price"` {
+		t.Errorf("Variant.UpdateFields returned error %v, expected an unknown field", err)
+	}
+	if count := httpmock.GetTotalCallCount(); count != 0 {
+		t.Errorf("Variant.UpdateFields made %d requests, expected none", count)
+	}
+}
+
+func TestUpdateFields(t *testing.T) {
+	setup()
+	defer teardown()
+
+	cases := []struct {
+		path     string
+		response string
+		update   func(*Update) (interface{}, error)
+		expected string
+	}{
+		{
+			"variants/1.json", `{"variant":{"id":1}}`,
+			func(u *Update) (interface{}, error) {
+				return client.Variant.UpdateFields(1, u.SetNull("compare_at_price"))
+			},
+			`{"variant":{"compare_at_price":null,"id":1}}`,
+		},
+		{
+			"customers/1.json", `{"customer":{"id":1}}`,
+			func(u *Update) (interface{}, error) { return client.Customer.UpdateFields(1, u.Set("note", "")) },
+			`{"customer":{"id":1,"note":""}}`,
+		},
+		{
+			"orders/1.json", `{"order":{"id":1}}`,
+			func(u *Update) (interface{}, error) {
+				return client.Order.UpdateFields(1, u.Set("buyer_accepts_marketing", false))
+			},
+			`{"order":{"buyer_accepts_marketing":false,"id":1}}`,
+		},
+		{
+			"pages/1.json", `{"page":{"id":1}}`,
+			func(u *Update) (interface{}, error) { return client.Page.UpdateFields(1, u.SetNull("published_at")) },
+			`{"page":{"id":1,"published_at":null}}`,
+		},
+		{
+			"custom_collections/1.json", `{"custom_collection":{"id":1}}`,
+			func(u *Update) (interface{}, error) {
+				return client.CustomCollection.UpdateFields(1, u.SetNull("image"))
+			},
+			`{"custom_collection":{"id":1,"image":null}}`,
+		},
+		{
+			"smart_collections/1.json", `{"smart_collection":{"id":1}}`,
+			func(u *Update) (interface{}, error) {
+				return client.SmartCollection.UpdateFields(1, u.Set("sort_order", "manual"))
+			},
+			`{"smart_collection":{"id":1,"sort_order":"manual"}}`,
+		},
+	}
+
+	for _, c := range cases {
+		var body string
+		httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/%s", client.pathPrefix, c.path),
+			func(req *http.Request) (*http.Response, error) {
+				data, _ := ioutil.ReadAll(req.Body)
+				body = string(data)
+				return httpmock.NewStringResponse(200, c.response), nil
+			})
+
+		resource, err := c.update(NewUpdate())
+		if err != nil || resource == nil {
+			t.Errorf("UpdateFields of %s returned %v, %v", c.path, resource, err)
+		}
+		if body != c.expected {
+			t.Errorf("UpdateFields of %s sent %s, expected %s", c.path, body, c.expected)
+		}
+	}
+}
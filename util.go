@@ -46,3 +46,9 @@ func FulfillmentPathPrefix(resource string, resourceID int64) string {
 	}
 	return prefix
 }
+
+// Bool returns a pointer to v, for optional boolean fields where nil means
+// the field is left out of the request.
+func Bool(v bool) *bool {
+	return &v
+}
@@ -100,3 +100,11 @@ func TestFulfillmentPathPrefix(t *testing.T) {
 		}
 	}
 }
+
+func TestBool(t *testing.T) {
+	for _, v := range []bool{true, false} {
+		if actual := Bool(v); actual == nil || *actual != v {
+			t.Errorf("Bool(%v) returned %v", v, actual)
+		}
+	}
+}
@@ -19,6 +19,7 @@ type VariantService interface {
 	Get(int64, interface{}) (*Variant, error)
 	Create(int64, Variant) (*Variant, error)
 	Update(Variant) (*Variant, error)
+	UpdateFields(int64, *Update) (*Variant, error)
 	Delete(int64, int64) error
 
 	// MetafieldsService used for Variant resource to communicate with Metafields resource
@@ -33,34 +34,42 @@ type VariantServiceOp struct {
 
 // Variant represents a Shopify variant
 type Variant struct {
-	ID                   int64            `json:"id,omitempty"`
-	ProductID            int64            `json:"product_id,omitempty"`
-	Title                string           `json:"title,omitempty"`
-	Sku                  string           `json:"sku,omitempty"`
-	Position             int              `json:"position,omitempty"`
-	Grams                int              `json:"grams,omitempty"`
-	InventoryPolicy      string           `json:"inventory_policy,omitempty"`
-	Price                *decimal.Decimal `json:"price,omitempty"`
-	CompareAtPrice       *decimal.Decimal `json:"compare_at_price,omitempty"`
-	FulfillmentService   string           `json:"fulfillment_service,omitempty"`
-	InventoryManagement  string           `json:"inventory_management,omitempty"`
-	InventoryItemId      int64            `json:"inventory_item_id,omitempty"`
-	Option1              string           `json:"option1,omitempty"`
-	Option2              string           `json:"option2,omitempty"`
-	Option3              string           `json:"option3,omitempty"`
-	CreatedAt            *time.Time       `json:"created_at,omitempty"`
-	UpdatedAt            *time.Time       `json:"updated_at,omitempty"`
-	Taxable              bool             `json:"taxable,omitempty"`
-	TaxCode              string           `json:"tax_code,omitempty"`
-	Barcode              string           `json:"barcode,omitempty"`
-	ImageID              int64            `json:"image_id,omitempty"`
-	InventoryQuantity    int              `json:"inventory_quantity,omitempty"`
+	ID                  int64            `json:"id,omitempty"`
+	ProductID           int64            `json:"product_id,omitempty"`
+	Title               string           `json:"title,omitempty"`
+	Sku                 string           `json:"sku,omitempty"`
+	Position            int              `json:"position,omitempty"`
+	Grams               int              `json:"grams,omitempty"`
+	InventoryPolicy     string           `json:"inventory_policy,omitempty"`
+	Price               *decimal.Decimal `json:"price,omitempty"`
+	CompareAtPrice      *decimal.Decimal `json:"compare_at_price,omitempty"`
+	FulfillmentService  string           `json:"fulfillment_service,omitempty"`
+	InventoryManagement string           `json:"inventory_management,omitempty"`
+	InventoryItemId     int64            `json:"inventory_item_id,omitempty"`
+	Option1             string           `json:"option1,omitempty"`
+	Option2             string           `json:"option2,omitempty"`
+	Option3             string           `json:"option3,omitempty"`
+	CreatedAt           *time.Time       `json:"created_at,omitempty"`
+	UpdatedAt           *time.Time       `json:"updated_at,omitempty"`
+	Taxable             *bool            `json:"taxable,omitempty"`
+	TaxCode             string           `json:"tax_code,omitempty"`
+	Barcode             string           `json:"barcode,omitempty"`
+	ImageID             int64            `json:"image_id,omitempty"`
+	// Read only since 2019-10, stock is adjusted through InventoryLevel.
+	InventoryQuantity    int              `json:"inventory_quantity,omitempty" shopify:"until=2019-10"`
 	Weight               *decimal.Decimal `json:"weight,omitempty"`
 	WeightUnit           string           `json:"weight_unit,omitempty"`
-	OldInventoryQuantity int              `json:"old_inventory_quantity,omitempty"`
-	RequireShipping      bool             `json:"requires_shipping,omitempty"`
+	OldInventoryQuantity int              `json:"old_inventory_quantity,omitempty" shopify:"until=2019-10"`
+	RequireShipping      *bool            `json:"requires_shipping,omitempty"`
 	AdminGraphqlAPIID    string           `json:"admin_graphql_api_id,omitempty"`
 	Metafields           []Metafield      `json:"metafields,omitempty"`
+	NullFields           NullFields       `json:"-"`
+}
+
+// MarshalJSON encodes the variant, sending the NullFields as null.
+func (v Variant) MarshalJSON() ([]byte, error) {
+	type variant Variant
+	return marshalWithNullFields(variant(v), v.NullFields)
 }
 
 // VariantResource represents the result from the variants/X.json endpoint
@@ -76,9 +85,7 @@ type VariantsResource struct {
 // List variants
 func (s *VariantServiceOp) List(productID int64, options interface{}) ([]Variant, error) {
 	path := fmt.Sprintf("%s/%d/variants.json", productsBasePath, productID)
-	resource := new(VariantsResource)
-	err := s.client.Get(path, resource, options)
-	return resource.Variants, err
+	return listResource[Variant](s.client, path, "variants", options)
 }
 
 // Count variants
@@ -90,27 +97,25 @@ func (s *VariantServiceOp) Count(productID int64, options interface{}) (int, err
 // Get individual variant
 func (s *VariantServiceOp) Get(variantID int64, options interface{}) (*Variant, error) {
 	path := fmt.Sprintf("%s/%d.json", variantsBasePath, variantID)
-	resource := new(VariantResource)
-	err := s.client.Get(path, resource, options)
-	return resource.Variant, err
+	return getResource[Variant](s.client, path, "variant", options)
 }
 
 // Create a new variant
 func (s *VariantServiceOp) Create(productID int64, variant Variant) (*Variant, error) {
 	path := fmt.Sprintf("%s/%d/variants.json", productsBasePath, productID)
-	wrappedData := VariantResource{Variant: &variant}
-	resource := new(VariantResource)
-	err := s.client.Post(path, wrappedData, resource)
-	return resource.Variant, err
+	return createResource(s.client, path, "variant", variant)
 }
 
 // Update existing variant
 func (s *VariantServiceOp) Update(variant Variant) (*Variant, error) {
 	path := fmt.Sprintf("%s/%d.json", variantsBasePath, variant.ID)
-	wrappedData := VariantResource{Variant: &variant}
-	resource := new(VariantResource)
-	err := s.client.Put(path, wrappedData, resource)
-	return resource.Variant, err
+	return updateResource(s.client, path, "variant", variant)
+}
+
+// UpdateFields updates only the fields set in update on the variant, see Update.
+func (s *VariantServiceOp) UpdateFields(variantID int64, update *Update) (*Variant, error) {
+	path := fmt.Sprintf("%s/%d.json", variantsBasePath, variantID)
+	return updateFieldsResource[Variant](s.client, path, "variant", variantID, update)
 }
 
 // Delete an existing variant
@@ -2,6 +2,8 @@ package goshopify
 
 import (
 	"fmt"
+	"io"
+	"net/http"
 	"reflect"
 	"testing"
 	"time"
@@ -196,6 +198,29 @@ func TestVariantUpdate(t *testing.T) {
 	variantTests(t, *returnedVariant)
 }
 
+func TestVariantUpdateFalseBooleans(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var body string
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/variants/1.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			data, _ := io.ReadAll(req.Body)
+			body = string(data)
+			return httpmock.NewBytesResponse(200, loadFixture("variant.json")), nil
+		})
+
+	variant := Variant{ID: 1, Taxable: Bool(false), RequireShipping: Bool(false)}
+	if _, err := client.Variant.Update(variant); err != nil {
+		t.Fatalf("Variant.Update returned error: %v", err)
+	}
+
+	expected := `{"variant":{"id":1,"taxable":false,"requires_shipping":false}}`
+	if body != expected {
+		t.Errorf("Variant.Update sent %s, expected %s", body, expected)
+	}
+}
+
 func TestVariantWithMetafieldsUpdate(t *testing.T) {
 	setup()
 	defer teardown()
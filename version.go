@@ -0,0 +1,316 @@
+package goshopify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// VersionLatest makes WithVersion pin the client to the latest stable
+	// version at the time the client is created, see LatestStableVersion.
+	VersionLatest = "latest"
+
+	// VersionUnstable makes WithVersion use the unstable version, whose
+	// features may change without notice.
+	VersionUnstable = UnstableApiVersion
+)
+
+// supportedStableVersions is how many stable versions Shopify supports at a
+// time: a version is supported for 12 months and one is released every
+// quarter.
+const supportedStableVersions = 4
+
+// LatestStableVersion returns the latest stable Admin API version released at
+// t. Shopify releases a version at the start of every quarter, named after its
+// year and month, e.g. 2023-04 on April 1st 2023.
+// See: https://shopify.dev/docs/api/usage/versioning
+func LatestStableVersion(t time.Time) string {
+	return versionName(quarterStart(t))
+}
+
+// SupportedVersions returns the stable versions Shopify supports at t, the
+// oldest first and the latest last.
+func SupportedVersions(t time.Time) []string {
+	latest := quarterStart(t)
+	versions := make([]string, 0, supportedStableVersions)
+	for i := supportedStableVersions - 1; i >= 0; i-- {
+		versions = append(versions, versionName(latest.AddDate(0, -3*i, 0)))
+	}
+	return versions
+}
+
+// quarterStart returns the first day of the quarter of t in UTC.
+func quarterStart(t time.Time) time.Time {
+	t = t.UTC()
+	month := (t.Month()-1)/3*3 + 1
+	return time.Date(t.Year(), month, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func versionName(t time.Time) string {
+	return fmt.Sprintf("%04d-%02d", t.Year(), t.Month())
+}
+
+// ApiVersion returns the version requests are sent to, e.g. 2023-04 or
+// unstable.
+func (c *Client) ApiVersion() string {
+	return c.requestApiVersion()
+}
+
+// logApiVersion logs the concrete version requests are pinned to when the
+// client was configured with VersionLatest or VersionUnstable.
+func (c *Client) logApiVersion() {
+	switch c.versionAlias {
+	case VersionLatest:
+		c.log.Infof("api version %s pinned to %s", VersionLatest, c.requestApiVersion())
+	case VersionUnstable:
+		c.log.Infof("api version pinned to %s, its features may change without notice", VersionUnstable)
+	}
+}
+
+// versionTag is the struct tag used to declare the API versions a field is
+// available in. Versions are inclusive for since and exclusive for until,
+// e.g. a field added in 2021-07 and removed in 2023-01 is tagged as
+// `shopify:"since=2021-07,until=2023-01"`.
+// Fields that are not supported by the version the client is configured with
+// are dropped from request bodies so older versions don't reject the payload.
+const versionTag = "shopify"
+
+// unstableVersionOrder sorts the unstable version after every dated version.
+const unstableVersionOrder = "9999-99"
+
+// versionedTypes caches whether a type has any version tagged fields, which
+// is used to skip the filtering entirely for most request bodies.
+var versionedTypes sync.Map
+
+// fieldVersions holds the parsed contents of a version tag.
+type fieldVersions struct {
+	since string
+	until string
+}
+
+func parseVersionTag(tag string) (fieldVersions, bool) {
+	var v fieldVersions
+	if tag == "" {
+		return v, false
+	}
+	for _, part := range strings.Split(tag, ",") {
+		kv := strings.SplitN(strings.TrimSpace(part), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch kv[0] {
+		case "since":
+			v.since = kv[1]
+		case "until":
+			v.until = kv[1]
+		}
+	}
+	return v, v.since != "" || v.until != ""
+}
+
+// supports reports whether the field is available in the given version.
+func (v fieldVersions) supports(version string) bool {
+	if version == UnstableApiVersion {
+		version = unstableVersionOrder
+	}
+	if v.since != "" && version < v.since {
+		return false
+	}
+	if v.until != "" && version >= v.until {
+		return false
+	}
+	return true
+}
+
+// requestApiVersion returns the concrete version requests are sent to.
+func (c *Client) requestApiVersion() string {
+	return path.Base(c.pathPrefix)
+}
+
+// marshalForVersion encodes body as JSON, omitting any version tagged fields
+// the given API version does not support.
+func marshalForVersion(body interface{}, version string) ([]byte, error) {
+	js, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	t := reflect.TypeOf(body)
+	if !hasVersionedFields(t, map[reflect.Type]bool{}) {
+		return js, nil
+	}
+
+	return stripUnsupportedFields(js, t, version), nil
+}
+
+func hasVersionedFields(t reflect.Type, seen map[reflect.Type]bool) bool {
+	if t == nil {
+		return false
+	}
+	if cached, ok := versionedTypes.Load(t); ok {
+		return cached.(bool)
+	}
+	if seen[t] {
+		return false
+	}
+	seen[t] = true
+
+	found := false
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		found = hasVersionedFields(t.Elem(), seen)
+	case reflect.Struct:
+		for i := 0; i < t.NumField() && !found; i++ {
+			f := t.Field(i)
+			if _, ok := parseVersionTag(f.Tag.Get(versionTag)); ok {
+				found = true
+			} else {
+				found = hasVersionedFields(f.Type, seen)
+			}
+		}
+	}
+
+	versionedTypes.Store(t, found)
+	return found
+}
+
+// stripUnsupportedFields walks the encoded JSON alongside the Go type it was
+// encoded from and drops the keys of unsupported fields, keeping the order of
+// the remaining ones.
+// Types with their own MarshalJSON are walked too: resources encode through an
+// alias of themselves, so their objects keep the keys of their json tags. Any
+// other encoding simply doesn't match the struct's keys and is left alone.
+func stripUnsupportedFields(js json.RawMessage, t reflect.Type, version string) json.RawMessage {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		var list []json.RawMessage
+		if err := json.Unmarshal(js, &list); err != nil || list == nil {
+			return js
+		}
+		buf := &bytes.Buffer{}
+		buf.WriteByte('[')
+		for i, elem := range list {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			buf.Write(stripUnsupportedFields(elem, t.Elem(), version))
+		}
+		buf.WriteByte(']')
+		return buf.Bytes()
+	case reflect.Map:
+		obj, ok := decodeJSONObject(js)
+		if !ok {
+			return js
+		}
+		for _, key := range obj.keys {
+			obj.values[key] = stripUnsupportedFields(obj.values[key], t.Elem(), version)
+		}
+		return obj.encode()
+	case reflect.Struct:
+		obj, ok := decodeJSONObject(js)
+		if !ok {
+			return js
+		}
+		stripUnsupportedStructFields(obj, t, version)
+		return obj.encode()
+	}
+	return js
+}
+
+func stripUnsupportedStructFields(obj *jsonObject, t reflect.Type, version string) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" || (f.PkgPath != "" && !f.Anonymous) {
+			continue
+		}
+		if f.Anonymous && name == "" {
+			// embedded struct fields are promoted into the parent object
+			embedded := f.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				stripUnsupportedStructFields(obj, embedded, version)
+			}
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if versions, ok := parseVersionTag(f.Tag.Get(versionTag)); ok && !versions.supports(version) {
+			delete(obj.values, name)
+			continue
+		}
+		if elem, ok := obj.values[name]; ok {
+			obj.values[name] = stripUnsupportedFields(elem, f.Type, version)
+		}
+	}
+}
+
+// jsonObject is a decoded JSON object that keeps the order of its keys.
+type jsonObject struct {
+	keys   []string
+	values map[string]json.RawMessage
+}
+
+func decodeJSONObject(js json.RawMessage) (*jsonObject, bool) {
+	decoder := json.NewDecoder(bytes.NewReader(js))
+	if tok, err := decoder.Token(); err != nil || tok != json.Delim('{') {
+		return nil, false
+	}
+
+	obj := &jsonObject{values: map[string]json.RawMessage{}}
+	for decoder.More() {
+		tok, err := decoder.Token()
+		if err != nil {
+			return nil, false
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return nil, false
+		}
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return nil, false
+		}
+		if _, dup := obj.values[key]; !dup {
+			obj.keys = append(obj.keys, key)
+		}
+		obj.values[key] = value
+	}
+	return obj, true
+}
+
+// encode writes the object back out without the keys deleted from values.
+func (obj *jsonObject) encode() json.RawMessage {
+	buf := &bytes.Buffer{}
+	buf.WriteByte('{')
+	first := true
+	for _, key := range obj.keys {
+		value, ok := obj.values[key]
+		if !ok {
+			continue
+		}
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+		name, _ := json.Marshal(key)
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes()
+}
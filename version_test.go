@@ -0,0 +1,212 @@
+package goshopify
+
+import (
+	"bytes"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFieldVersionsSupports(t *testing.T) {
+	cases := []struct {
+		tag      string
+		version  string
+		expected bool
+	}{
+		{"since=2021-07", "2021-04", false},
+		{"since=2021-07", "2021-07", true},
+		{"since=2021-07", "2022-01", true},
+		{"since=2021-07", UnstableApiVersion, true},
+		{"until=2022-01", "2021-10", true},
+		{"until=2022-01", "2022-01", false},
+		{"until=2022-01", UnstableApiVersion, false},
+		{"since=2021-07,until=2022-01", "2021-10", true},
+		{"since=2021-07,until=2022-01", "2021-04", false},
+	}
+
+	for _, c := range cases {
+		versions, ok := parseVersionTag(c.tag)
+		if !ok {
+			t.Fatalf("parseVersionTag(%s) returned not ok", c.tag)
+		}
+		if actual := versions.supports(c.version); actual != c.expected {
+			t.Errorf("fieldVersions(%s).supports(%s) = %v, expected %v", c.tag, c.version, actual, c.expected)
+		}
+	}
+}
+
+func TestMarshalForVersion(t *testing.T) {
+	type Inner struct {
+		Name string `json:"name"`
+		New  string `json:"new,omitempty" shopify:"since=2021-07"`
+	}
+	type Embedded struct {
+		Old string `json:"old,omitempty" shopify:"until=2021-04"`
+	}
+	type Outer struct {
+		Embedded
+		ID     int64   `json:"id"`
+		Inner  *Inner  `json:"inner"`
+		Inners []Inner `json:"inners"`
+	}
+
+	body := Outer{
+		Embedded: Embedded{Old: "old"},
+		ID:       9007199254740993,
+		Inner:    &Inner{Name: "a", New: "new"},
+		Inners:   []Inner{{Name: "b", New: "new"}},
+	}
+
+	cases := []struct {
+		version  string
+		expected string
+	}{
+		{"2021-01", `{"old":"old","id":9007199254740993,"inner":{"name":"a"},"inners":[{"name":"b"}]}`},
+		{"2021-07", `{"id":9007199254740993,"inner":{"name":"a","new":"new"},"inners":[{"name":"b","new":"new"}]}`},
+	}
+
+	for _, c := range cases {
+		js, err := marshalForVersion(body, c.version)
+		if err != nil {
+			t.Fatalf("marshalForVersion(%s) returned error: %v", c.version, err)
+		}
+		if string(js) != c.expected {
+			t.Errorf("marshalForVersion(%s) = %s, expected %s", c.version, js, c.expected)
+		}
+	}
+}
+
+func TestNewRequestDropsUnsupportedFields(t *testing.T) {
+	testClient := NewClient(app, "fooshop", "abcd", WithVersion("2021-04"))
+
+	type Field struct {
+		Key  string `json:"key"`
+		Type string `json:"type,omitempty" shopify:"since=2021-07"`
+	}
+	body := map[string]Field{"field": {Key: "foo", Type: "single_line_text_field"}}
+	req, err := testClient.NewRequest("POST", "fields.json", body, nil)
+	if err != nil {
+		t.Fatalf("NewRequest() returned error: %v", err)
+	}
+
+	js, _ := ioutil.ReadAll(req.Body)
+	expected := `{"field":{"key":"foo"}}`
+	if string(js) != expected {
+		t.Errorf("NewRequest() Body = %s, expected %s", js, expected)
+	}
+}
+
+func TestNewRequestDropsReadOnlyVariantFields(t *testing.T) {
+	testClient := NewClient(app, "fooshop", "abcd", WithVersion("2023-01"))
+
+	// Product and Variant encode themselves through MarshalJSON
+	body := ProductResource{Product: &Product{
+		Title: "foo",
+		Variants: []Variant{
+			{ID: 1, InventoryQuantity: 5, OldInventoryQuantity: 3, Taxable: Bool(false)},
+		},
+	}}
+	req, err := testClient.NewRequest("PUT", "products/1.json", body, nil)
+	if err != nil {
+		t.Fatalf("NewRequest() returned error: %v", err)
+	}
+
+	js, _ := ioutil.ReadAll(req.Body)
+	expected := `{"product":{"title":"foo","variants":[{"id":1,"taxable":false}],"image":{}}}`
+	if string(js) != expected {
+		t.Errorf("NewRequest() Body = %s, expected %s", js, expected)
+	}
+
+	oldClient := NewClient(app, "fooshop", "abcd", WithVersion("2019-07"))
+	req, err = oldClient.NewRequest("PUT", "variants/1.json", VariantResource{Variant: &Variant{ID: 1, InventoryQuantity: 5}}, nil)
+	if err != nil {
+		t.Fatalf("NewRequest() returned error: %v", err)
+	}
+
+	js, _ = ioutil.ReadAll(req.Body)
+	expected = `{"variant":{"id":1,"inventory_quantity":5}}`
+	if string(js) != expected {
+		t.Errorf("NewRequest() Body = %s, expected %s", js, expected)
+	}
+}
+
+func TestNewRequestKeepsMetafieldType(t *testing.T) {
+	// the default version predates metafield types, which are sent anyway
+	testClient := NewClient(app, "fooshop", "abcd")
+
+	body := MetafieldResource{Metafield: &Metafield{Key: "foo", Type: "single_line_text_field"}}
+	req, err := testClient.NewRequest("POST", "metafields.json", body, nil)
+	if err != nil {
+		t.Fatalf("NewRequest() returned error: %v", err)
+	}
+
+	js, _ := ioutil.ReadAll(req.Body)
+	expected := `{"metafield":{"key":"foo","type":"single_line_text_field"}}`
+	if string(js) != expected {
+		t.Errorf("NewRequest() Body = %s, expected %s", js, expected)
+	}
+}
+
+func TestLatestStableVersion(t *testing.T) {
+	cases := []struct {
+		time     time.Time
+		expected string
+	}{
+		{time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC), "2023-01"},
+		{time.Date(2023, time.March, 31, 23, 59, 0, 0, time.UTC), "2023-01"},
+		{time.Date(2023, time.April, 1, 0, 0, 0, 0, time.UTC), "2023-04"},
+		{time.Date(2023, time.August, 15, 0, 0, 0, 0, time.UTC), "2023-07"},
+		{time.Date(2023, time.December, 31, 0, 0, 0, 0, time.UTC), "2023-10"},
+		// still September 30th in UTC
+		{time.Date(2023, time.October, 1, 1, 0, 0, 0, time.FixedZone("CEST", 2*60*60)), "2023-07"},
+	}
+
+	for _, c := range cases {
+		if actual := LatestStableVersion(c.time); actual != c.expected {
+			t.Errorf("LatestStableVersion(%s) = %s, expected %s", c.time, actual, c.expected)
+		}
+	}
+}
+
+func TestSupportedVersions(t *testing.T) {
+	versions := SupportedVersions(time.Date(2023, time.February, 10, 0, 0, 0, 0, time.UTC))
+	expected := []string{"2022-04", "2022-07", "2022-10", "2023-01"}
+	if !reflect.DeepEqual(versions, expected) {
+		t.Errorf("SupportedVersions returned %v, expected %v", versions, expected)
+	}
+}
+
+func TestWithVersionLatest(t *testing.T) {
+	out := &bytes.Buffer{}
+	logger := &LeveledLogger{Level: LevelInfo, stdoutOverride: out, stderrOverride: out}
+	c := NewClient(App{}, "fooshop", "abcd", WithLogger(logger), WithVersion(VersionLatest))
+
+	latest := LatestStableVersion(time.Now())
+	if c.ApiVersion() != latest || c.pathPrefix != "admin/api/"+latest {
+		t.Errorf("WithVersion(VersionLatest) pinned the client to %s at %s, expected %s", c.ApiVersion(), c.pathPrefix, latest)
+	}
+	if !strings.Contains(out.String(), "latest pinned to "+latest) {
+		t.Errorf("WithVersion(VersionLatest) logged %q", out.String())
+	}
+}
+
+func TestWithVersionUnstable(t *testing.T) {
+	out := &bytes.Buffer{}
+	logger := &LeveledLogger{Level: LevelInfo, stdoutOverride: out, stderrOverride: out}
+	c := NewClient(App{}, "fooshop", "abcd", WithLogger(logger), WithVersion(VersionUnstable))
+
+	if c.ApiVersion() != UnstableApiVersion {
+		t.Errorf("WithVersion(VersionUnstable) pinned the client to %s", c.ApiVersion())
+	}
+	if !strings.Contains(out.String(), "pinned to unstable") {
+		t.Errorf("WithVersion(VersionUnstable) logged %q", out.String())
+	}
+
+	out.Reset()
+	NewClient(App{}, "fooshop", "abcd", WithLogger(logger), WithVersion("2023-04"))
+	if out.Len() != 0 {
+		t.Errorf("WithVersion with a dated version logged %q", out.String())
+	}
+}
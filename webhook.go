@@ -1,8 +1,8 @@
 package goshopify
 
 import (
+	"context"
 	"fmt"
-	"net/http"
 	"time"
 )
 
@@ -19,6 +19,7 @@ type WebhookService interface {
 	Create(Webhook) (*Webhook, error)
 	Update(Webhook) (*Webhook, error)
 	Delete(int64) error
+	Ensure(context.Context, []Webhook, ...EnsureOption) ([]EnsureResult[Webhook], error)
 }
 
 // WebhookServiceOp handles communication with the webhook-related methods of
@@ -67,23 +68,7 @@ func (s *WebhookServiceOp) List(options interface{}) ([]Webhook, error) {
 // List webhooks with pagination
 func (s *WebhookServiceOp) ListWithPagination(options interface{}) ([]Webhook, *Pagination, error) {
 	path := fmt.Sprintf("%s.json", webhooksBasePath)
-	resource := new(WebhooksResource)
-	headers := http.Header{}
-
-	headers, err := s.client.createAndDoGetHeaders("GET", path, nil, options, resource)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	// Extract pagination info from header
-	linkHeader := headers.Get("Link")
-
-	pagination, err := extractPagination(linkHeader)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	return resource.Webhooks, pagination, nil
+	return listResourceWithPagination[Webhook](s.client, path, "webhooks", options)
 }
 
 // Count webhooks
@@ -95,30 +80,131 @@ func (s *WebhookServiceOp) Count(options interface{}) (int, error) {
 // Get individual webhook
 func (s *WebhookServiceOp) Get(webhookdID int64, options interface{}) (*Webhook, error) {
 	path := fmt.Sprintf("%s/%d.json", webhooksBasePath, webhookdID)
-	resource := new(WebhookResource)
-	err := s.client.Get(path, resource, options)
-	return resource.Webhook, err
+	return getResource[Webhook](s.client, path, "webhook", options)
 }
 
 // Create a new webhook
 func (s *WebhookServiceOp) Create(webhook Webhook) (*Webhook, error) {
 	path := fmt.Sprintf("%s.json", webhooksBasePath)
-	wrappedData := WebhookResource{Webhook: &webhook}
-	resource := new(WebhookResource)
-	err := s.client.Post(path, wrappedData, resource)
-	return resource.Webhook, err
+	return createResource(s.client, path, "webhook", webhook)
 }
 
 // Update an existing webhook.
 func (s *WebhookServiceOp) Update(webhook Webhook) (*Webhook, error) {
 	path := fmt.Sprintf("%s/%d.json", webhooksBasePath, webhook.ID)
-	wrappedData := WebhookResource{Webhook: &webhook}
-	resource := new(WebhookResource)
-	err := s.client.Put(path, wrappedData, resource)
-	return resource.Webhook, err
+	return updateResource(s.client, path, "webhook", webhook)
 }
 
 // Delete an existing webhooks
 func (s *WebhookServiceOp) Delete(ID int64) error {
 	return s.client.Delete(fmt.Sprintf("%s/%d.json", webhooksBasePath, ID))
 }
+
+// webhookKey identifies a webhook subscription, a topic can be delivered to
+// several addresses.
+type webhookKey struct {
+	topic   string
+	address string
+}
+
+// Ensure makes sure a webhook exists for every desired topic and address. An
+// existing webhook for the same topic and address is updated when its format,
+// fields or metafield namespaces differ. A desired webhook without one takes
+// over another webhook of its topic, whose address is updated, so a changed
+// address doesn't leave the old subscription delivering too. With
+// WithDeleteUnknown every other webhook, including duplicates of a desired
+// one, is deleted and reported with the EnsureDeleted status.
+func (s *WebhookServiceOp) Ensure(ctx context.Context, desired []Webhook, opts ...EnsureOption) ([]EnsureResult[Webhook], error) {
+	config := ensureConfig{}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	path := fmt.Sprintf("%s.json", webhooksBasePath)
+	existing, err := listAllPages(func(options interface{}) ([]Webhook, *Pagination, error) {
+		return listResourceWithPaginationContext[Webhook](ctx, s.client, path, "webhooks", options)
+	}, ListOptions{Limit: 250})
+	if err != nil {
+		return nil, err
+	}
+
+	byKey := make(map[webhookKey]Webhook, len(existing))
+	for _, webhook := range existing {
+		key := webhookKey{webhook.Topic, webhook.Address}
+		if _, ok := byKey[key]; !ok {
+			byKey[key] = webhook
+		}
+	}
+
+	// match desired webhooks to existing ones with their address first, then
+	// to the remaining webhooks of their topic
+	kept := make(map[int64]bool, len(desired))
+	matches := make([]*Webhook, len(desired))
+	for i, want := range desired {
+		if have, ok := byKey[webhookKey{want.Topic, want.Address}]; ok && !kept[have.ID] {
+			kept[have.ID] = true
+			matches[i] = &have
+		}
+	}
+	for i, want := range desired {
+		if matches[i] != nil {
+			continue
+		}
+		for _, have := range existing {
+			if have.Topic == want.Topic && !kept[have.ID] {
+				kept[have.ID] = true
+				have := have
+				matches[i] = &have
+				break
+			}
+		}
+	}
+
+	results := make([]EnsureResult[Webhook], 0, len(desired))
+	for i, want := range desired {
+		have := matches[i]
+		switch {
+		case have == nil:
+			resource := WebhookResource{}
+			err := s.client.CreateAndDoWithContext(ctx, "POST", path, WebhookResource{Webhook: &want}, nil, &resource)
+			if err != nil {
+				return results, fmt.Errorf("creating webhook %s: %w", want.Topic, err)
+			}
+			if resource.Webhook == nil {
+				return results, fmt.Errorf("creating webhook %s: no webhook in the response", want.Topic)
+			}
+			results = append(results, EnsureResult[Webhook]{Resource: *resource.Webhook, Status: EnsureCreated})
+		case have.Address != want.Address || webhookDiffers(*have, want):
+			want.ID = have.ID
+			resource := WebhookResource{}
+			updatePath := fmt.Sprintf("%s/%d.json", webhooksBasePath, want.ID)
+			err := s.client.CreateAndDoWithContext(ctx, "PUT", updatePath, WebhookResource{Webhook: &want}, nil, &resource)
+			if err != nil {
+				return results, fmt.Errorf("updating webhook %s: %w", want.Topic, err)
+			}
+			if resource.Webhook == nil {
+				return results, fmt.Errorf("updating webhook %s: no webhook in the response", want.Topic)
+			}
+			results = append(results, EnsureResult[Webhook]{Resource: *resource.Webhook, Status: EnsureUpdated})
+		default:
+			results = append(results, EnsureResult[Webhook]{Resource: *have, Status: EnsureUnchanged})
+		}
+	}
+
+	if !config.deleteUnknown {
+		return results, nil
+	}
+
+	for _, have := range existing {
+		if kept[have.ID] {
+			continue
+		}
+		deletePath := fmt.Sprintf("%s/%d.json", webhooksBasePath, have.ID)
+		if err := s.client.CreateAndDoWithContext(ctx, "DELETE", deletePath, nil, nil, nil); err != nil {
+			return results, fmt.Errorf("deleting webhook %s: %w", have.Topic, err)
+		}
+		results = append(results, EnsureResult[Webhook]{Resource: have, Status: EnsureDeleted})
+	}
+
+	return results, nil
+}
@@ -0,0 +1,260 @@
+package goshopify
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// WebhookEvent is a verified webhook delivery. It is published for every
+// topic, topics with a typed event additionally publish that event.
+type WebhookEvent struct {
+	Topic      string
+	ShopDomain string
+	WebhookID  string
+	APIVersion string
+	Payload    json.RawMessage
+}
+
+// Typed events published for the topic in their comment, they embed the
+// delivery they were decoded from.
+type (
+	// orders/create
+	OrderCreated struct {
+		WebhookEvent
+		Order Order
+	}
+	// orders/updated
+	OrderUpdated struct {
+		WebhookEvent
+		Order Order
+	}
+	// orders/paid
+	OrderPaid struct {
+		WebhookEvent
+		Order Order
+	}
+	// orders/cancelled
+	OrderCancelled struct {
+		WebhookEvent
+		Order Order
+	}
+	// products/create
+	ProductCreated struct {
+		WebhookEvent
+		Product Product
+	}
+	// products/update
+	ProductUpdated struct {
+		WebhookEvent
+		Product Product
+	}
+	// products/delete, only the ID of the product is sent
+	ProductDeleted struct {
+		WebhookEvent
+		ProductID int64
+	}
+	// customers/create
+	CustomerCreated struct {
+		WebhookEvent
+		Customer Customer
+	}
+	// customers/update
+	CustomerUpdated struct {
+		WebhookEvent
+		Customer Customer
+	}
+	// app/uninstalled
+	AppUninstalled struct {
+		WebhookEvent
+		Shop Shop
+	}
+	// bulk_operations/finish, get the operation with BulkOperation.Get
+	BulkOperationFinished struct {
+		WebhookEvent
+		BulkOperationID string
+		Status          string
+		ErrorCode       string
+	}
+)
+
+// webhookEventDecoders decode deliveries into the typed event of their topic.
+var webhookEventDecoders = map[string]func(WebhookEvent) (interface{}, error){
+	"orders/create": func(e WebhookEvent) (interface{}, error) {
+		event := OrderCreated{WebhookEvent: e}
+		return event, json.Unmarshal(e.Payload, &event.Order)
+	},
+	"orders/updated": func(e WebhookEvent) (interface{}, error) {
+		event := OrderUpdated{WebhookEvent: e}
+		return event, json.Unmarshal(e.Payload, &event.Order)
+	},
+	"orders/paid": func(e WebhookEvent) (interface{}, error) {
+		event := OrderPaid{WebhookEvent: e}
+		return event, json.Unmarshal(e.Payload, &event.Order)
+	},
+	"orders/cancelled": func(e WebhookEvent) (interface{}, error) {
+		event := OrderCancelled{WebhookEvent: e}
+		return event, json.Unmarshal(e.Payload, &event.Order)
+	},
+	"products/create": func(e WebhookEvent) (interface{}, error) {
+		event := ProductCreated{WebhookEvent: e}
+		return event, json.Unmarshal(e.Payload, &event.Product)
+	},
+	"products/update": func(e WebhookEvent) (interface{}, error) {
+		event := ProductUpdated{WebhookEvent: e}
+		return event, json.Unmarshal(e.Payload, &event.Product)
+	},
+	"products/delete": func(e WebhookEvent) (interface{}, error) {
+		payload := struct {
+			ID int64 `json:"id"`
+		}{}
+		err := json.Unmarshal(e.Payload, &payload)
+		return ProductDeleted{WebhookEvent: e, ProductID: payload.ID}, err
+	},
+	"customers/create": func(e WebhookEvent) (interface{}, error) {
+		event := CustomerCreated{WebhookEvent: e}
+		return event, json.Unmarshal(e.Payload, &event.Customer)
+	},
+	"customers/update": func(e WebhookEvent) (interface{}, error) {
+		event := CustomerUpdated{WebhookEvent: e}
+		return event, json.Unmarshal(e.Payload, &event.Customer)
+	},
+	"app/uninstalled": func(e WebhookEvent) (interface{}, error) {
+		event := AppUninstalled{WebhookEvent: e}
+		return event, json.Unmarshal(e.Payload, &event.Shop)
+	},
+	"bulk_operations/finish": func(e WebhookEvent) (interface{}, error) {
+		payload := struct {
+			ID        string `json:"admin_graphql_api_id"`
+			Status    string `json:"status"`
+			ErrorCode string `json:"error_code"`
+		}{}
+		err := json.Unmarshal(e.Payload, &payload)
+		return BulkOperationFinished{
+			WebhookEvent:    e,
+			BulkOperationID: payload.ID,
+			// the webhook sends the status in lower case
+			Status:    strings.ToUpper(payload.Status),
+			ErrorCode: strings.ToUpper(payload.ErrorCode),
+		}, err
+	},
+}
+
+// EventBus publishes verified webhook deliveries as Go events to the
+// subscribers within the app, which decouples receiving webhooks over HTTP
+// from the business logic reacting to them. It is an http.Handler to mount
+// at the webhook address.
+type EventBus struct {
+	app App
+
+	mu       sync.RWMutex
+	handlers map[reflect.Type][]func(interface{}) error
+}
+
+// NewEventBus returns an event bus verifying deliveries with the app secret.
+func NewEventBus(app App) *EventBus {
+	return &EventBus{app: app, handlers: map[reflect.Type][]func(interface{}) error{}}
+}
+
+// Subscribe registers a handler for events of type E, e.g. OrderCreated.
+// Subscribing to WebhookEvent receives every delivery.
+func Subscribe[E any](bus *EventBus, handler func(E) error) {
+	t := reflect.TypeOf((*E)(nil)).Elem()
+
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+	bus.handlers[t] = append(bus.handlers[t], func(event interface{}) error {
+		return handler(event.(E))
+	})
+}
+
+// Publish passes the delivery to the WebhookEvent subscribers and, when the
+// topic has a typed event, to the subscribers of that event. Every handler
+// runs, the first error is returned.
+func (bus *EventBus) Publish(event WebhookEvent) error {
+	events := []interface{}{event}
+	if decode, ok := webhookEventDecoders[event.Topic]; ok {
+		typed, err := decode(event)
+		if err != nil {
+			return fmt.Errorf("decoding %s webhook: %w", event.Topic, err)
+		}
+		events = append(events, typed)
+	}
+
+	var firstErr error
+	for _, e := range events {
+		bus.mu.RLock()
+		handlers := bus.handlers[reflect.TypeOf(e)]
+		bus.mu.RUnlock()
+
+		for _, handler := range handlers {
+			if err := handler(e); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+// ServeHTTP verifies a webhook delivery and publishes it. Deliveries that fail
+// verification are rejected with 401, a failing handler responds with 500 so
+// Shopify retries the delivery.
+func (bus *EventBus) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	event, err := bus.app.webhookEvent(r)
+	if err != nil {
+		bus.app.rejectWebhook(w, r)
+		return
+	}
+
+	if err := bus.Publish(event); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// rejectWebhook responds 401 to a delivery that failed verification. The
+// verification error can hold the expected HMAC of the body, so it is neither
+// written to the response nor logged, only the topic and shop are.
+func (app App) rejectWebhook(w http.ResponseWriter, r *http.Request) {
+	app.logger().Errorf("rejected webhook delivery: invalid webhook signature (topic %q, shop %q)",
+		r.Header.Get("X-Shopify-Topic"), r.Header.Get("X-Shopify-Shop-Domain"))
+	http.Error(w, "unauthorized", http.StatusUnauthorized)
+}
+
+// logger returns the logger of the app's client, or one logging errors to
+// stderr when the app has no client.
+func (app App) logger() LeveledLoggerInterface {
+	if app.Client != nil && app.Client.log != nil {
+		return app.Client.log
+	}
+	return &LeveledLogger{Level: LevelError}
+}
+
+// webhookEvent verifies a webhook request and reads it into a WebhookEvent.
+func (app App) webhookEvent(r *http.Request) (WebhookEvent, error) {
+	if ok, err := app.VerifyWebhookRequestVerbose(r); !ok {
+		if err == nil {
+			err = errors.New("invalid webhook signature")
+		}
+		return WebhookEvent{}, err
+	}
+
+	payload, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return WebhookEvent{}, err
+	}
+
+	return WebhookEvent{
+		Topic:      r.Header.Get("X-Shopify-Topic"),
+		ShopDomain: r.Header.Get("X-Shopify-Shop-Domain"),
+		WebhookID:  r.Header.Get("X-Shopify-Webhook-Id"),
+		APIVersion: r.Header.Get("X-Shopify-API-Version"),
+		Payload:    payload,
+	}, nil
+}
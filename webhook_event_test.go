@@ -0,0 +1,195 @@
+package goshopify
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// signedWebhookRequest builds a webhook delivery signed with the test app's
+// secret.
+func signedWebhookRequest(topic, body string) *http.Request {
+	mac := hmac.New(sha256.New, []byte(app.ApiSecret))
+	mac.Write([]byte(body))
+
+	req := httptest.NewRequest("POST", "/webhooks", strings.NewReader(body))
+	req.Header.Set("X-Shopify-Hmac-Sha256", base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+	req.Header.Set("X-Shopify-Topic", topic)
+	req.Header.Set("X-Shopify-Shop-Domain", "fooshop.myshopify.com")
+	req.Header.Set("X-Shopify-Webhook-Id", "b54557e4-bdd9-4b37-8a5f-bf7d70bcd043")
+	req.Header.Set("X-Shopify-API-Version", "2021-01")
+	return req
+}
+
+func TestEventBusTypedEvents(t *testing.T) {
+	setup()
+	defer teardown()
+
+	bus := NewEventBus(app)
+
+	var created []OrderCreated
+	var all []string
+	Subscribe(bus, func(e OrderCreated) error {
+		created = append(created, e)
+		return nil
+	})
+	Subscribe(bus, func(e WebhookEvent) error {
+		all = append(all, e.Topic)
+		return nil
+	})
+
+	rec := httptest.NewRecorder()
+	bus.ServeHTTP(rec, signedWebhookRequest("orders/create", `{"id":123,"name":"#1001"}`))
+	if rec.Code != http.StatusOK {
+		t.Errorf("EventBus.ServeHTTP responded %d, expected %d", rec.Code, http.StatusOK)
+	}
+
+	rec = httptest.NewRecorder()
+	bus.ServeHTTP(rec, signedWebhookRequest("shop/update", `{"id":1}`))
+	if rec.Code != http.StatusOK {
+		t.Errorf("EventBus.ServeHTTP responded %d, expected %d", rec.Code, http.StatusOK)
+	}
+
+	if len(created) != 1 {
+		t.Fatalf("OrderCreated subscriber called %d times, expected 1", len(created))
+	}
+	if created[0].Order.ID != 123 || created[0].Order.Name != "#1001" {
+		t.Errorf("OrderCreated.Order = %+v, expected order 123", created[0].Order)
+	}
+	if created[0].ShopDomain != "fooshop.myshopify.com" || created[0].APIVersion != "2021-01" {
+		t.Errorf("OrderCreated.WebhookEvent = %+v", created[0].WebhookEvent)
+	}
+
+	expected := []string{"orders/create", "shop/update"}
+	if !reflect.DeepEqual(all, expected) {
+		t.Errorf("WebhookEvent subscriber received %v, expected %v", all, expected)
+	}
+}
+
+func TestEventBusPublish(t *testing.T) {
+	setup()
+	defer teardown()
+
+	bus := NewEventBus(app)
+
+	var deleted []int64
+	Subscribe(bus, func(e ProductDeleted) error {
+		deleted = append(deleted, e.ProductID)
+		return nil
+	})
+	Subscribe(bus, func(e ProductDeleted) error {
+		deleted = append(deleted, e.ProductID)
+		return nil
+	})
+
+	err := bus.Publish(WebhookEvent{Topic: "products/delete", Payload: []byte(`{"id":788032119674292900}`)})
+	if err != nil {
+		t.Errorf("EventBus.Publish returned error: %v", err)
+	}
+
+	expected := []int64{788032119674292900, 788032119674292900}
+	if !reflect.DeepEqual(deleted, expected) {
+		t.Errorf("ProductDeleted subscribers received %v, expected %v", deleted, expected)
+	}
+}
+
+func TestEventBusHandlerError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	bus := NewEventBus(app)
+	boom := errors.New("boom")
+
+	called := false
+	Subscribe(bus, func(e AppUninstalled) error { return boom })
+	Subscribe(bus, func(e AppUninstalled) error {
+		called = true
+		return nil
+	})
+
+	rec := httptest.NewRecorder()
+	bus.ServeHTTP(rec, signedWebhookRequest("app/uninstalled", `{"id":1,"domain":"fooshop.myshopify.com"}`))
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("EventBus.ServeHTTP responded %d, expected %d", rec.Code, http.StatusInternalServerError)
+	}
+	if !called {
+		t.Errorf("EventBus did not call the handlers after a failing one")
+	}
+}
+
+func TestEventBusInvalidSignature(t *testing.T) {
+	setup()
+	defer teardown()
+
+	out := &bytes.Buffer{}
+	WithLogger(&LeveledLogger{Level: LevelError, stderrOverride: out})(client)
+	app.Client = client
+	bus := NewEventBus(app)
+
+	called := false
+	Subscribe(bus, func(e WebhookEvent) error {
+		called = true
+		return nil
+	})
+
+	req := signedWebhookRequest("orders/create", `{"id":123}`)
+	req.Header.Set("X-Shopify-Hmac-Sha256", "hMTq0K2x7oyOjoBwGYeTj5oxfnaVYXzbanUG9aajpKI=")
+
+	rec := httptest.NewRecorder()
+	bus.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("EventBus.ServeHTTP responded %d, expected %d", rec.Code, http.StatusUnauthorized)
+	}
+	if body := strings.TrimSpace(rec.Body.String()); body != "unauthorized" {
+		t.Errorf("EventBus.ServeHTTP responded %q to an invalid signature, expected %q", body, "unauthorized")
+	}
+	if logged := out.String(); !strings.Contains(logged, "invalid webhook signature") || strings.Contains(logged, "hash") {
+		t.Errorf("EventBus.ServeHTTP logged %q, expected the reason without the verification error", logged)
+	}
+	if called {
+		t.Errorf("EventBus published a delivery with an invalid signature")
+	}
+}
+
+func TestEventBusDecodeError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	bus := NewEventBus(app)
+
+	err := bus.Publish(WebhookEvent{Topic: "orders/create", Payload: []byte(`{"id":"not a number"}`)})
+	if err == nil || !strings.HasPrefix(err.Error(), "decoding orders/create webhook") {
+		t.Errorf("EventBus.Publish returned error %v, expected a decoding error", err)
+	}
+}
+
+func TestEventBusBulkOperationFinished(t *testing.T) {
+	setup()
+	defer teardown()
+
+	bus := NewEventBus(app)
+	var received BulkOperationFinished
+	Subscribe(bus, func(e BulkOperationFinished) error {
+		received = e
+		return nil
+	})
+
+	err := bus.Publish(WebhookEvent{
+		Topic:   "bulk_operations/finish",
+		Payload: json.RawMessage(`{"admin_graphql_api_id":"gid://shopify/BulkOperation/1","completed_at":"2022-01-01T10:05:00-05:00","created_at":"2022-01-01T10:00:00-05:00","error_code":null,"status":"completed","type":"query"}`),
+	})
+	if err != nil {
+		t.Fatalf("EventBus.Publish returned error: %v", err)
+	}
+	if received.BulkOperationID != "gid://shopify/BulkOperation/1" || received.Status != BulkOperationStatusCompleted {
+		t.Errorf("BulkOperationFinished returned %+v", received)
+	}
+}
@@ -0,0 +1,90 @@
+package goshopify
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// WebhookHandler handles a verified webhook delivery.
+type WebhookHandler func(WebhookEvent) error
+
+// WebhookRouter is an http.Handler that verifies webhook deliveries and
+// dispatches them to the handler registered for their topic. Unlike an
+// EventBus, which publishes the deliveries of a fixed set of topics as typed
+// events, the router takes a handler for any topic, see HandleWebhook to
+// decode the payload into a struct.
+type WebhookRouter struct {
+	app App
+
+	mu       sync.RWMutex
+	handlers map[string]WebhookHandler
+	fallback WebhookHandler
+}
+
+// NewWebhookRouter returns a router verifying deliveries with the app secret.
+func NewWebhookRouter(app App) *WebhookRouter {
+	return &WebhookRouter{app: app, handlers: map[string]WebhookHandler{}}
+}
+
+// Handle registers the handler for a topic, e.g. "orders/create", replacing
+// any handler registered before.
+func (r *WebhookRouter) Handle(topic string, handler WebhookHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers[topic] = handler
+}
+
+// HandleDefault registers the handler for the topics without a handler of
+// their own. Without one those deliveries are acknowledged and dropped.
+func (r *WebhookRouter) HandleDefault(handler WebhookHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.fallback = handler
+}
+
+// HandleWebhook registers a handler for a topic that receives the payload
+// decoded into T, e.g. Order for "orders/create" or Product for
+// "products/update".
+func HandleWebhook[T any](r *WebhookRouter, topic string, handler func(WebhookEvent, T) error) {
+	r.Handle(topic, func(event WebhookEvent) error {
+		var payload T
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return fmt.Errorf("decoding %s webhook: %w", event.Topic, err)
+		}
+		return handler(event, payload)
+	})
+}
+
+// Dispatch passes the delivery to the handler of its topic.
+func (r *WebhookRouter) Dispatch(event WebhookEvent) error {
+	r.mu.RLock()
+	handler, ok := r.handlers[event.Topic]
+	if !ok {
+		handler = r.fallback
+	}
+	r.mu.RUnlock()
+
+	if handler == nil {
+		return nil
+	}
+	return handler(event)
+}
+
+// ServeHTTP verifies a webhook delivery and dispatches it. Deliveries that
+// fail verification are rejected with 401, a failing handler responds with
+// 500 so Shopify retries the delivery.
+func (r *WebhookRouter) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	event, err := r.app.webhookEvent(req)
+	if err != nil {
+		r.app.rejectWebhook(w, req)
+		return
+	}
+
+	if err := r.Dispatch(event); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
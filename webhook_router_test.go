@@ -0,0 +1,118 @@
+package goshopify
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWebhookRouterTypedPayload(t *testing.T) {
+	setup()
+	defer teardown()
+
+	router := NewWebhookRouter(app)
+
+	var order Order
+	var event WebhookEvent
+	HandleWebhook(router, "orders/create", func(e WebhookEvent, o Order) error {
+		event, order = e, o
+		return nil
+	})
+	var product Product
+	HandleWebhook(router, "products/update", func(e WebhookEvent, p Product) error {
+		product = p
+		return nil
+	})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, signedWebhookRequest("orders/create", `{"id":123,"name":"#1001"}`))
+	if rec.Code != http.StatusOK {
+		t.Errorf("WebhookRouter.ServeHTTP responded %d, expected %d", rec.Code, http.StatusOK)
+	}
+	if order.ID != 123 || order.Name != "#1001" {
+		t.Errorf("orders/create handler received %+v", order)
+	}
+	if event.Topic != "orders/create" || event.ShopDomain != "fooshop.myshopify.com" ||
+		event.WebhookID != "b54557e4-bdd9-4b37-8a5f-bf7d70bcd043" {
+		t.Errorf("orders/create handler received event %+v", event)
+	}
+	if product.ID != 0 {
+		t.Errorf("products/update handler was called for an orders/create delivery")
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, signedWebhookRequest("products/update", `{"id":7,"title":"Shirt"}`))
+	if rec.Code != http.StatusOK {
+		t.Errorf("WebhookRouter.ServeHTTP responded %d, expected %d", rec.Code, http.StatusOK)
+	}
+	if product.ID != 7 || product.Title != "Shirt" {
+		t.Errorf("products/update handler received %+v", product)
+	}
+}
+
+func TestWebhookRouterUnroutedTopic(t *testing.T) {
+	setup()
+	defer teardown()
+
+	router := NewWebhookRouter(app)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, signedWebhookRequest("shop/update", `{"id":1}`))
+	if rec.Code != http.StatusOK {
+		t.Errorf("WebhookRouter.ServeHTTP responded %d for a topic without handler, expected %d", rec.Code, http.StatusOK)
+	}
+
+	var topics []string
+	router.HandleDefault(func(e WebhookEvent) error {
+		topics = append(topics, e.Topic)
+		return nil
+	})
+	router.Handle("orders/create", func(WebhookEvent) error { return nil })
+
+	for _, topic := range []string{"shop/update", "orders/create", "themes/publish"} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, signedWebhookRequest(topic, `{"id":1}`))
+	}
+	if len(topics) != 2 || topics[0] != "shop/update" || topics[1] != "themes/publish" {
+		t.Errorf("default handler received %v, expected [shop/update themes/publish]", topics)
+	}
+}
+
+func TestWebhookRouterErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	router := NewWebhookRouter(app)
+	router.Handle("orders/create", func(WebhookEvent) error { return errors.New("database down") })
+	HandleWebhook(router, "products/update", func(WebhookEvent, Product) error { return nil })
+
+	cases := []struct {
+		description string
+		request     *http.Request
+		code        int
+	}{
+		{"handler error", signedWebhookRequest("orders/create", `{"id":1}`), http.StatusInternalServerError},
+		{"undecodable payload", signedWebhookRequest("products/update", `{"id":"x"}`), http.StatusInternalServerError},
+	}
+
+	tampered := signedWebhookRequest("orders/create", `{"id":1}`)
+	tampered.Header.Set("X-Shopify-Hmac-Sha256", "forged")
+	cases = append(cases, struct {
+		description string
+		request     *http.Request
+		code        int
+	}{"invalid signature", tampered, http.StatusUnauthorized})
+
+	for _, c := range cases {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, c.request)
+		if rec.Code != c.code {
+			t.Errorf("%s: WebhookRouter.ServeHTTP responded %d, expected %d", c.description, rec.Code, c.code)
+		}
+		if c.code == http.StatusUnauthorized && strings.TrimSpace(rec.Body.String()) != "unauthorized" {
+			t.Errorf("%s: WebhookRouter.ServeHTTP responded %q, expected %q", c.description, rec.Body.String(), "unauthorized")
+		}
+	}
+}
@@ -0,0 +1,265 @@
+package goshopify
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// WebhookSubscriptionService is an interface for managing webhook
+// subscriptions through the GraphQL Admin API, which can deliver to Amazon
+// EventBridge and Google Pub/Sub besides HTTPS and filter the events they
+// deliver.
+// See: https://shopify.dev/docs/api/admin-graphql/latest/objects/WebhookSubscription
+type WebhookSubscriptionService interface {
+	List(topics ...string) ([]WebhookSubscription, error)
+	Get(id string) (*WebhookSubscription, error)
+	Create(WebhookSubscription) (*WebhookSubscription, error)
+	Update(WebhookSubscription) (*WebhookSubscription, error)
+	Delete(id string) error
+}
+
+// WebhookSubscriptionServiceOp handles communication with the webhook
+// subscription related GraphQL queries and mutations.
+type WebhookSubscriptionServiceOp struct {
+	client *Client
+}
+
+// WebhookSubscription represents a webhook subscription. Topic is the GraphQL
+// topic, e.g. ORDERS_CREATE, REST topics like orders/create are converted when
+// passed in. URI is where events are delivered to: an HTTPS url, the ARN of an
+// EventBridge event source or a Pub/Sub topic, see EventBridgeAddress and
+// PubSubAddress. Filter is a search query the events must match, e.g.
+// "total_price:>100", and IncludeFields limits the fields of the payload.
+type WebhookSubscription struct {
+	ID                  string     `json:"id,omitempty"`
+	Topic               string     `json:"topic,omitempty"`
+	URI                 string     `json:"uri,omitempty"`
+	Format              string     `json:"format,omitempty"`
+	Filter              string     `json:"filter,omitempty"`
+	IncludeFields       []string   `json:"includeFields,omitempty"`
+	MetafieldNamespaces []string   `json:"metafieldNamespaces,omitempty"`
+	CreatedAt           *time.Time `json:"createdAt,omitempty"`
+	UpdatedAt           *time.Time `json:"updatedAt,omitempty"`
+}
+
+// webhookSubscriptionInput is the WebhookSubscriptionInput of the mutations.
+type webhookSubscriptionInput struct {
+	URI                 string   `json:"uri,omitempty"`
+	Format              string   `json:"format,omitempty"`
+	Filter              string   `json:"filter,omitempty"`
+	IncludeFields       []string `json:"includeFields,omitempty"`
+	MetafieldNamespaces []string `json:"metafieldNamespaces,omitempty"`
+}
+
+func (w WebhookSubscription) input() webhookSubscriptionInput {
+	return webhookSubscriptionInput{
+		URI:                 w.URI,
+		Format:              w.Format,
+		Filter:              w.Filter,
+		IncludeFields:       w.IncludeFields,
+		MetafieldNamespaces: w.MetafieldNamespaces,
+	}
+}
+
+// EventBridgeAddress returns the address delivering webhooks to the Amazon
+// EventBridge partner event source of the app, to be used as the Address of a
+// Webhook or the URI of a WebhookSubscription.
+func EventBridgeAddress(region string, appID int64, sourceName string) string {
+	return fmt.Sprintf("arn:aws:events:%s::event-source/aws.partner/shopify.com/%d/%s", region, appID, sourceName)
+}
+
+// PubSubAddress returns the address delivering webhooks to a Google Cloud
+// Pub/Sub topic, to be used as the Address of a Webhook or the URI of a
+// WebhookSubscription.
+func PubSubAddress(project, topic string) string {
+	return fmt.Sprintf("pubsub://%s:%s", project, topic)
+}
+
+// webhookSubscriptionTopic converts a REST topic like orders/create to the
+// GraphQL topic ORDERS_CREATE, other topics are returned unchanged.
+func webhookSubscriptionTopic(topic string) string {
+	if !strings.Contains(topic, "/") {
+		return topic
+	}
+	return strings.ToUpper(strings.ReplaceAll(topic, "/", "_"))
+}
+
+// WebhookSubscriptionMinVersion is the first API version whose webhook
+// subscriptions have the uri, filter and includeFields fields the
+// WebhookSubscription service uses, older versions reject its queries.
+const WebhookSubscriptionMinVersion = "2025-04"
+
+// checkVersion returns an error when the client's API version predates
+// WebhookSubscriptionMinVersion.
+func (s *WebhookSubscriptionServiceOp) checkVersion() error {
+	version := s.client.requestApiVersion()
+	if version != UnstableApiVersion && version < WebhookSubscriptionMinVersion {
+		return fmt.Errorf("webhook subscriptions need API version %s or later, the client uses %s", WebhookSubscriptionMinVersion, version)
+	}
+	return nil
+}
+
+const webhookSubscriptionFields = `id topic uri format filter includeFields metafieldNamespaces createdAt updatedAt`
+
+const webhookSubscriptionsQuery = `query($topics: [WebhookSubscriptionTopic!], $after: String) {
+  webhookSubscriptions(first: 250, topics: $topics, after: $after) {
+    edges { node { ` + webhookSubscriptionFields + ` } }
+    pageInfo { hasNextPage endCursor }
+  }
+}`
+
+const webhookSubscriptionQuery = `query($id: ID!) {
+  webhookSubscription(id: $id) { ` + webhookSubscriptionFields + ` }
+}`
+
+const webhookSubscriptionCreateMutation = `mutation($topic: WebhookSubscriptionTopic!, $webhookSubscription: WebhookSubscriptionInput!) {
+  webhookSubscriptionCreate(topic: $topic, webhookSubscription: $webhookSubscription) {
+    webhookSubscription { ` + webhookSubscriptionFields + ` }
+    userErrors { field message }
+  }
+}`
+
+const webhookSubscriptionUpdateMutation = `mutation($id: ID!, $webhookSubscription: WebhookSubscriptionInput!) {
+  webhookSubscriptionUpdate(id: $id, webhookSubscription: $webhookSubscription) {
+    webhookSubscription { ` + webhookSubscriptionFields + ` }
+    userErrors { field message }
+  }
+}`
+
+const webhookSubscriptionDeleteMutation = `mutation($id: ID!) {
+  webhookSubscriptionDelete(id: $id) {
+    deletedWebhookSubscriptionId
+    userErrors { field message }
+  }
+}`
+
+// List the webhook subscriptions of the app, only those of the given topics
+// unless none are passed.
+func (s *WebhookSubscriptionServiceOp) List(topics ...string) ([]WebhookSubscription, error) {
+	if err := s.checkVersion(); err != nil {
+		return nil, err
+	}
+
+	var graphQLTopics []string
+	for _, topic := range topics {
+		graphQLTopics = append(graphQLTopics, webhookSubscriptionTopic(topic))
+	}
+
+	var subscriptions []WebhookSubscription
+	var after *string
+	for {
+		vars := map[string]interface{}{
+			"topics": graphQLTopics,
+			"after":  after,
+		}
+		resp := struct {
+			WebhookSubscriptions struct {
+				Edges []struct {
+					Node WebhookSubscription `json:"node"`
+				} `json:"edges"`
+				PageInfo graphQLPageInfo `json:"pageInfo"`
+			} `json:"webhookSubscriptions"`
+		}{}
+
+		err := s.client.GraphQL.Query(webhookSubscriptionsQuery, vars, &resp)
+		if err != nil {
+			return subscriptions, err
+		}
+
+		for _, edge := range resp.WebhookSubscriptions.Edges {
+			subscriptions = append(subscriptions, edge.Node)
+		}
+
+		pageInfo := resp.WebhookSubscriptions.PageInfo
+		if !pageInfo.HasNextPage {
+			return subscriptions, nil
+		}
+		after = &pageInfo.EndCursor
+	}
+}
+
+// Get a webhook subscription by its ID, nil if there is no such subscription.
+func (s *WebhookSubscriptionServiceOp) Get(id string) (*WebhookSubscription, error) {
+	if err := s.checkVersion(); err != nil {
+		return nil, err
+	}
+
+	resp := struct {
+		WebhookSubscription *WebhookSubscription `json:"webhookSubscription"`
+	}{}
+
+	err := s.client.GraphQL.Query(webhookSubscriptionQuery, map[string]interface{}{"id": id}, &resp)
+	return resp.WebhookSubscription, err
+}
+
+// Create a new webhook subscription
+func (s *WebhookSubscriptionServiceOp) Create(subscription WebhookSubscription) (*WebhookSubscription, error) {
+	if err := s.checkVersion(); err != nil {
+		return nil, err
+	}
+
+	vars := map[string]interface{}{
+		"topic":               webhookSubscriptionTopic(subscription.Topic),
+		"webhookSubscription": subscription.input(),
+	}
+	resp := struct {
+		WebhookSubscriptionCreate struct {
+			WebhookSubscription *WebhookSubscription `json:"webhookSubscription"`
+			UserErrors          []UserError          `json:"userErrors"`
+		} `json:"webhookSubscriptionCreate"`
+	}{}
+
+	err := s.client.GraphQL.Query(webhookSubscriptionCreateMutation, vars, &resp)
+	if err == nil {
+		err = userErrorsToError(resp.WebhookSubscriptionCreate.UserErrors)
+	}
+	return resp.WebhookSubscriptionCreate.WebhookSubscription, err
+}
+
+// Update the URI, format, filter and payload fields of an existing webhook
+// subscription. The topic of a subscription can't be changed. Empty fields are
+// left as they are, so an empty Filter doesn't remove the filter, delete and
+// recreate the subscription for that.
+func (s *WebhookSubscriptionServiceOp) Update(subscription WebhookSubscription) (*WebhookSubscription, error) {
+	if err := s.checkVersion(); err != nil {
+		return nil, err
+	}
+
+	vars := map[string]interface{}{
+		"id":                  subscription.ID,
+		"webhookSubscription": subscription.input(),
+	}
+	resp := struct {
+		WebhookSubscriptionUpdate struct {
+			WebhookSubscription *WebhookSubscription `json:"webhookSubscription"`
+			UserErrors          []UserError          `json:"userErrors"`
+		} `json:"webhookSubscriptionUpdate"`
+	}{}
+
+	err := s.client.GraphQL.Query(webhookSubscriptionUpdateMutation, vars, &resp)
+	if err == nil {
+		err = userErrorsToError(resp.WebhookSubscriptionUpdate.UserErrors)
+	}
+	return resp.WebhookSubscriptionUpdate.WebhookSubscription, err
+}
+
+// Delete an existing webhook subscription
+func (s *WebhookSubscriptionServiceOp) Delete(id string) error {
+	if err := s.checkVersion(); err != nil {
+		return err
+	}
+
+	vars := map[string]interface{}{"id": id}
+	resp := struct {
+		WebhookSubscriptionDelete struct {
+			UserErrors []UserError `json:"userErrors"`
+		} `json:"webhookSubscriptionDelete"`
+	}{}
+
+	err := s.client.GraphQL.Query(webhookSubscriptionDeleteMutation, vars, &resp)
+	if err != nil {
+		return err
+	}
+	return userErrorsToError(resp.WebhookSubscriptionDelete.UserErrors)
+}
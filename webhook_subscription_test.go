@@ -0,0 +1,228 @@
+package goshopify
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestWebhookSubscriptionAddresses(t *testing.T) {
+	eventBridge := EventBridgeAddress("us-east-1", 1234, "orders")
+	if eventBridge != "arn:aws:events:us-east-1::event-source/aws.partner/shopify.com/1234/orders" {
+		t.Errorf("EventBridgeAddress returned %s", eventBridge)
+	}
+
+	pubSub := PubSubAddress("my-project", "orders")
+	if pubSub != "pubsub://my-project:orders" {
+		t.Errorf("PubSubAddress returned %s", pubSub)
+	}
+}
+
+func TestWebhookSubscriptionCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var request graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+				return nil, err
+			}
+			return httpmock.NewStringResponse(200, `{"data":{"webhookSubscriptionCreate":{"webhookSubscription":{"id":"gid://shopify/WebhookSubscription/1","topic":"ORDERS_CREATE","uri":"pubsub://my-project:orders","format":"JSON","filter":"total_price:>100","includeFields":["id","total_price"]},"userErrors":[]}}}`), nil
+		})
+
+	subscription, err := client.WebhookSubscription.Create(WebhookSubscription{
+		Topic:         "orders/create",
+		URI:           PubSubAddress("my-project", "orders"),
+		Format:        "JSON",
+		Filter:        "total_price:>100",
+		IncludeFields: []string{"id", "total_price"},
+	})
+	if err != nil {
+		t.Fatalf("WebhookSubscription.Create returned error: %v", err)
+	}
+
+	expectedVars := map[string]interface{}{
+		"topic": "ORDERS_CREATE",
+		"webhookSubscription": map[string]interface{}{
+			"uri":           "pubsub://my-project:orders",
+			"format":        "JSON",
+			"filter":        "total_price:>100",
+			"includeFields": []interface{}{"id", "total_price"},
+		},
+	}
+	if !reflect.DeepEqual(request.Variables, expectedVars) {
+		t.Errorf("WebhookSubscription.Create sent %+v, expected %+v", request.Variables, expectedVars)
+	}
+
+	expected := &WebhookSubscription{
+		ID:            "gid://shopify/WebhookSubscription/1",
+		Topic:         "ORDERS_CREATE",
+		URI:           "pubsub://my-project:orders",
+		Format:        "JSON",
+		Filter:        "total_price:>100",
+		IncludeFields: []string{"id", "total_price"},
+	}
+	if !reflect.DeepEqual(subscription, expected) {
+		t.Errorf("WebhookSubscription.Create returned %+v, expected %+v", subscription, expected)
+	}
+}
+
+func TestWebhookSubscriptionCreateUserErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"data":{"webhookSubscriptionCreate":{"webhookSubscription":null,"userErrors":[{"field":["webhookSubscription","uri"],"message":"Address is invalid"}]}}}`))
+
+	subscription, err := client.WebhookSubscription.Create(WebhookSubscription{Topic: "ORDERS_CREATE", URI: "arn:nope"})
+	if subscription != nil {
+		t.Errorf("WebhookSubscription.Create returned %+v, expected nil", subscription)
+	}
+	if err == nil || err.Error() != "webhookSubscription.uri: Address is invalid" {
+		t.Errorf("WebhookSubscription.Create returned error %v", err)
+	}
+}
+
+func TestWebhookSubscriptionList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			var request graphQLRequest
+			if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+				return nil, err
+			}
+			requests = append(requests, request)
+			if len(requests) == 1 {
+				return httpmock.NewStringResponse(200, `{"data":{"webhookSubscriptions":{"edges":[{"node":{"id":"gid://shopify/WebhookSubscription/1","topic":"ORDERS_CREATE"}}],"pageInfo":{"hasNextPage":true,"endCursor":"abc"}}}}`), nil
+			}
+			return httpmock.NewStringResponse(200, `{"data":{"webhookSubscriptions":{"edges":[{"node":{"id":"gid://shopify/WebhookSubscription/2","topic":"ORDERS_CREATE"}}],"pageInfo":{"hasNextPage":false}}}}`), nil
+		})
+
+	subscriptions, err := client.WebhookSubscription.List("orders/create")
+	if err != nil {
+		t.Fatalf("WebhookSubscription.List returned error: %v", err)
+	}
+
+	if len(subscriptions) != 2 || subscriptions[1].ID != "gid://shopify/WebhookSubscription/2" {
+		t.Errorf("WebhookSubscription.List returned %+v", subscriptions)
+	}
+	if len(requests) != 2 || requests[1].Variables.(map[string]interface{})["after"] != "abc" {
+		t.Errorf("WebhookSubscription.List sent %+v, expected the second page after abc", requests)
+	}
+	if topics := requests[0].Variables.(map[string]interface{})["topics"]; !reflect.DeepEqual(topics, []interface{}{"ORDERS_CREATE"}) {
+		t.Errorf("WebhookSubscription.List sent topics %v, expected ORDERS_CREATE", topics)
+	}
+}
+
+func TestWebhookSubscriptionGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests,
+			`{"data":{"webhookSubscription":{"id":"gid://shopify/WebhookSubscription/1","topic":"PRODUCTS_UPDATE","uri":"https://example.com/webhooks","format":"JSON","filter":"vendor:Acme","includeFields":[],"metafieldNamespaces":["custom"]}}}`,
+			`{"data":{"webhookSubscription":null}}`,
+		))
+
+	subscription, err := client.WebhookSubscription.Get("gid://shopify/WebhookSubscription/1")
+	if err != nil {
+		t.Fatalf("WebhookSubscription.Get returned error: %v", err)
+	}
+	if subscription == nil || subscription.Filter != "vendor:Acme" || !reflect.DeepEqual(subscription.MetafieldNamespaces, []string{"custom"}) {
+		t.Errorf("WebhookSubscription.Get returned %+v", subscription)
+	}
+	if id := requests[0].Variables.(map[string]interface{})["id"]; id != "gid://shopify/WebhookSubscription/1" {
+		t.Errorf("WebhookSubscription.Get sent id %v", id)
+	}
+
+	subscription, err = client.WebhookSubscription.Get("gid://shopify/WebhookSubscription/2")
+	if err != nil || subscription != nil {
+		t.Errorf("WebhookSubscription.Get of a missing subscription returned %+v, %v", subscription, err)
+	}
+}
+
+func TestWebhookSubscriptionUpdate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests []graphQLRequest
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		recordGraphQL(&requests,
+			`{"data":{"webhookSubscriptionUpdate":{"webhookSubscription":{"id":"gid://shopify/WebhookSubscription/1","topic":"ORDERS_CREATE","uri":"https://example.com/webhooks","format":"JSON","filter":"total_price:>500","includeFields":["id"],"metafieldNamespaces":["loyalty"]},"userErrors":[]}}}`,
+			`{"data":{"webhookSubscriptionUpdate":{"webhookSubscription":null,"userErrors":[{"field":["webhookSubscription","filter"],"message":"Filter is invalid"}]}}}`,
+		))
+
+	subscription, err := client.WebhookSubscription.Update(WebhookSubscription{
+		ID:                  "gid://shopify/WebhookSubscription/1",
+		Filter:              "total_price:>500",
+		IncludeFields:       []string{"id"},
+		MetafieldNamespaces: []string{"loyalty"},
+	})
+	if err != nil {
+		t.Fatalf("WebhookSubscription.Update returned error: %v", err)
+	}
+	if subscription == nil || subscription.Filter != "total_price:>500" {
+		t.Errorf("WebhookSubscription.Update returned %+v", subscription)
+	}
+
+	expectedVars := map[string]interface{}{
+		"id": "gid://shopify/WebhookSubscription/1",
+		"webhookSubscription": map[string]interface{}{
+			"filter":              "total_price:>500",
+			"includeFields":       []interface{}{"id"},
+			"metafieldNamespaces": []interface{}{"loyalty"},
+		},
+	}
+	if !reflect.DeepEqual(requests[0].Variables, expectedVars) {
+		t.Errorf("WebhookSubscription.Update sent %+v, expected %+v", requests[0].Variables, expectedVars)
+	}
+
+	_, err = client.WebhookSubscription.Update(WebhookSubscription{ID: "gid://shopify/WebhookSubscription/1", Filter: "total_price:"})
+	if err == nil {
+		t.Error("WebhookSubscription.Update expected the user error")
+	}
+}
+
+func TestWebhookSubscriptionDelete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"data":{"webhookSubscriptionDelete":{"deletedWebhookSubscriptionId":"gid://shopify/WebhookSubscription/1","userErrors":[]}}}`))
+
+	if err := client.WebhookSubscription.Delete("gid://shopify/WebhookSubscription/1"); err != nil {
+		t.Errorf("WebhookSubscription.Delete returned error: %v", err)
+	}
+}
+
+func TestWebhookSubscriptionOldVersion(t *testing.T) {
+	setup()
+	defer teardown()
+
+	WithVersion("2021-01")(client)
+	if _, err := client.WebhookSubscription.List(); err == nil {
+		t.Errorf("WebhookSubscription.List returned no error on version 2021-01")
+	}
+	if err := client.WebhookSubscription.Delete("gid://shopify/WebhookSubscription/1"); err == nil {
+		t.Errorf("WebhookSubscription.Delete returned no error on version 2021-01")
+	}
+	if count := httpmock.GetTotalCallCount(); count != 0 {
+		t.Errorf("WebhookSubscription made %d requests on version 2021-01, expected none", count)
+	}
+
+	WithVersion(VersionUnstable)(client)
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"data":{"webhookSubscriptionDelete":{"deletedWebhookSubscriptionId":"gid://shopify/WebhookSubscription/1","userErrors":[]}}}`))
+	if err := client.WebhookSubscription.Delete("gid://shopify/WebhookSubscription/1"); err != nil {
+		t.Errorf("WebhookSubscription.Delete returned error on the unstable version: %v", err)
+	}
+}